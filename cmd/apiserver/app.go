@@ -7,11 +7,17 @@ import (
 	"motif-api/internal/graphql/resolvers"
 	"motif-api/internal/handlers"
 	"motif-api/internal/logger"
+	"motif-api/internal/metrics"
 	"motif-api/internal/repository"
 	"motif-api/internal/svc"
+	"context"
+	"crypto/tls"
 	"flag"
 	"log"
+	"net"
 	"net/http"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"os"
 	"os/signal"
 	"syscall"
@@ -23,7 +29,11 @@ type apiServer struct {
 	cfg          *config.Config
 	log          logger.Logger
 	api          resolvers.ApiResolver
+	timeouts     *resolvers.TimeoutTracer
 	srv          *http.Server
+	metricsSrv   *http.Server
+	inFlight     *handlers.DrainHandler
+	drained      chan struct{}
 	isVersionReq bool
 }
 
@@ -73,12 +83,20 @@ func (app *apiServer) run() {
 	app.log.Infof("welcome to Motif GraphQL API server")
 	app.log.Infof("listening for requests on %s", app.cfg.Server.BindAddress)
 
+	// expose metrics, if enabled
+	app.serveMetrics()
+
 	// listen the interface
-	err := app.srv.ListenAndServe()
-	if err != nil {
+	err := app.serve()
+	if err != nil && err != http.ErrServerClosed {
 		app.log.Errorf(err.Error())
 	}
 
+	// wait for the requests in flight to drain
+	if err == http.ErrServerClosed {
+		<-app.drained
+	}
+
 	// terminate the app
 	app.terminate()
 }
@@ -88,6 +106,10 @@ func (app *apiServer) makeHttpServer() {
 	// create request MUXer
 	srvMux := new(http.ServeMux)
 
+	// track requests in flight for the graceful shutdown
+	app.inFlight = handlers.NewDrainHandler(srvMux)
+	app.drained = make(chan struct{})
+
 	// create HTTP server to handle our requests
 	app.srv = &http.Server{
 		Addr:              app.cfg.Server.BindAddress,
@@ -95,32 +117,131 @@ func (app *apiServer) makeHttpServer() {
 		WriteTimeout:      time.Second * time.Duration(app.cfg.Server.WriteTimeout),
 		IdleTimeout:       time.Second * time.Duration(app.cfg.Server.IdleTimeout),
 		ReadHeaderTimeout: time.Second * time.Duration(app.cfg.Server.HeaderTimeout),
-		Handler:           srvMux,
+		Handler:           app.inFlight,
 	}
 
+	// configure HTTP protocols
+	app.setupProtocols()
+
 	// setup handlers
 	app.setupHandlers(srvMux)
 }
 
+// setupProtocols configures HTTP/1.1 and HTTP/2 support of the HTTP server.
+// HTTP/2 is negotiated natively with TLS; the cleartext h2c is served by wrapping the server handler.
+func (app *apiServer) setupProtocols() {
+	cfg := &app.cfg.Server.Http2
+
+	// the server negotiates HTTP/2 over TLS by default; a non-nil empty map turns it off
+	if !cfg.Enabled {
+		app.srv.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+		return
+	}
+
+	h2s := &http2.Server{MaxConcurrentStreams: uint32(cfg.MaxStreams)}
+	if err := http2.ConfigureServer(app.srv, h2s); err != nil {
+		app.log.Fatalf("can not configure HTTP/2; %s", err.Error())
+		return
+	}
+	if cfg.Cleartext {
+		app.srv.Handler = h2c.NewHandler(app.srv.Handler, h2s)
+	}
+	app.log.Noticef("HTTP/2 enabled; h2c %t, max %d streams per connection", cfg.Cleartext, cfg.MaxStreams)
+}
+
+// serve opens the server listener, and serves incoming connections
+// until the server is closed.
+func (app *apiServer) serve() error {
+	l, err := net.Listen("tcp", app.srv.Addr)
+	if err != nil {
+		return err
+	}
+
+	// terminate TLS natively? the certificate is loaded upfront so rejected connections get it too
+	var tlsCfg *tls.Config
+	if app.cfg.Server.TLS.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(app.cfg.Server.TLS.CertFile, app.cfg.Server.TLS.KeyFile)
+		if err != nil {
+			return err
+		}
+
+		tlsCfg = app.srv.TLSConfig.Clone()
+		if tlsCfg == nil {
+			tlsCfg = new(tls.Config)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+		app.srv.TLSConfig = tlsCfg
+	}
+
+	// limit simultaneous connections
+	if app.cfg.Server.MaxConnections > 0 {
+		l = newLimitListener(l, app.cfg.Server.MaxConnections, tlsCfg, app.log)
+		app.log.Noticef("max %d simultaneous connections allowed", app.cfg.Server.MaxConnections)
+	}
+
+	if tlsCfg != nil {
+		return app.srv.ServeTLS(l, "", "")
+	}
+	return app.srv.Serve(l)
+}
+
+// serveMetrics starts the Prometheus metrics endpoint on its own listener, if configured.
+func (app *apiServer) serveMetrics() {
+	if app.cfg.Metrics.Bind == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler(metrics.Default))
+	app.metricsSrv = &http.Server{Addr: app.cfg.Metrics.Bind, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	app.log.Infof("metrics available on %s/metrics", app.cfg.Metrics.Bind)
+	go func(srv *http.Server) {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			app.log.Errorf("metrics endpoint failed; %s", err.Error())
+		}
+	}(app.metricsSrv)
+}
+
 // setupHandlers initializes an array of handlers for our HTTP API end-points.
 func (app *apiServer) setupHandlers(mux *http.ServeMux) {
 	// create root resolver
 	app.api = resolvers.New()
 
-	// setup GraphQL API handler
-	h := http.TimeoutHandler(
-		handlers.Api(app.cfg, app.log, app.api),
-		time.Second*time.Duration(app.cfg.Server.ResolverTimeout),
-		"Service timeout.",
+	// setup GraphQL API handler; the request may take as long as the configured resolver timeout
+	// overloaded server sheds new requests before they even start
+	// all the end-points but the health check share the clients rate limit
+	var shed *handlers.LoadShedHandler
+	limit := handlers.RateLimit(app.cfg, app.log)
+	app.timeouts = resolvers.NewTimeoutTracer(&app.cfg.Server)
+	h := handlers.MustChain(handlers.Api(app.cfg, app.log, app.api, app.timeouts, limit),
+		handlers.Middleware{Name: handlers.MiddlewareLoadShed, Wrap: func(next http.Handler) http.Handler {
+			shed = handlers.NewLoadShedHandler(app.cfg, app.log, next)
+			return shed
+		}},
+		handlers.Middleware{Name: handlers.MiddlewareTimeout, Wrap: func(next http.Handler) http.Handler {
+			return handlers.NewTimeoutHandler(next, app.timeouts.RequestTimeout)
+		}},
 	)
 	mux.Handle("/api", h)
 	mux.Handle("/graphql", h)
 
 	// setup gas price estimator REST API resolver
-	mux.Handle("/json/gas", handlers.GasPrice(app.log))
+	mux.Handle("/json/gas", handlers.MustChain(handlers.GasPrice(app.log), limit))
+
+	// setup load state REST API resolver; it's never shed
+	mux.Handle("/json/load", handlers.MustChain(handlers.LoadStats(shed, app.log), limit))
+
+	// setup health check for load balancer readiness probes
+	mux.Handle("/health", handlers.Health(app.log))
 
 	// handle GraphiQL interface
-	mux.Handle("/graphi", handlers.GraphiHandler(app.cfg.Server.DomainAddress, app.log))
+	mux.Handle("/graphi", handlers.MustChain(handlers.GraphiHandler(app.cfg.Server.DomainAddress, app.log), limit))
+
+	// serve the schema in SDL form for tooling, if enabled
+	if app.cfg.Server.SchemaSDLEnabled() {
+		mux.Handle("/schema.graphql", handlers.MustChain(handlers.SchemaSDL(app.log), limit))
+	}
 }
 
 // observeSignals setups terminate signals observation.
@@ -132,18 +253,73 @@ func (app *apiServer) observeSignals() {
 	ts := make(chan os.Signal, 1)
 	signal.Notify(ts, syscall.SIGINT, syscall.SIGTERM)
 
+	// reload timeouts on hang up
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			app.reloadTimeouts()
+		}
+	}()
+
 	// start monitoring
 	go func() {
 		// wait for the signal
 		<-ts
 
 		// terminate HTTP responder
-		app.log.Notice("closing HTTP server")
+		app.shutdown()
+	}()
+}
+
+// shutdown stops accepting new connections and waits up to the configured grace period
+// for the requests in flight to finish; the requests still running after it are terminated.
+func (app *apiServer) shutdown() {
+	defer close(app.drained)
+
+	grace := time.Duration(app.cfg.Server.ShutdownGracePeriod) * time.Second
+	pending := app.inFlight.InFlight()
+	app.log.Noticef("closing HTTP server; %d requests in flight, grace period %s", pending, grace)
+
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+
+	if err := app.srv.Shutdown(ctx); err != nil {
+		app.log.Warningf("HTTP server not drained in time; %s", err.Error())
 		if err := app.srv.Close(); err != nil {
 			app.log.Errorf("could not terminate HTTP listener")
 			os.Exit(0)
 		}
-	}()
+	}
+
+	terminated := app.inFlight.InFlight()
+	drained := pending - terminated
+	if drained < 0 {
+		drained = 0
+	}
+	app.log.Noticef("HTTP server closed; %d requests drained, %d terminated", drained, terminated)
+}
+
+// reloadTimeouts re-reads the configuration and applies the new resolver timeouts
+// to subsequent requests. The connection timeouts of the live HTTP server can not be changed
+// so they are ignored until the server is restarted.
+func (app *apiServer) reloadTimeouts() {
+	app.log.Notice("reloading server timeouts")
+
+	cfg, err := config.ReloadServer()
+	if err != nil {
+		app.log.Errorf("can not reload server timeouts; %s", err.Error())
+		return
+	}
+
+	// connection timeouts are fixed on the live server
+	if cfg.ReadTimeout != app.cfg.Server.ReadTimeout || cfg.WriteTimeout != app.cfg.Server.WriteTimeout ||
+		cfg.IdleTimeout != app.cfg.Server.IdleTimeout || cfg.HeaderTimeout != app.cfg.Server.HeaderTimeout {
+		app.log.Warning("changed read, write, idle and header timeouts ignored until restart")
+	}
+
+	app.timeouts.Update(cfg)
+	app.log.Noticef("resolver timeout %ds applied", cfg.ResolverTimeout)
 }
 
 // terminate modules of the API server.
@@ -163,4 +339,12 @@ func (app *apiServer) terminate() {
 	if repo := repository.R(); repo != nil {
 		repo.Close()
 	}
+
+	// the metrics are exposed until all the modules are closed
+	if app.metricsSrv != nil {
+		app.log.Notice("closing metrics endpoint")
+		if err := app.metricsSrv.Close(); err != nil {
+			app.log.Errorf("could not close metrics endpoint; %s", err.Error())
+		}
+	}
 }
@@ -0,0 +1,101 @@
+// Package main implements the API server entry point.
+package main
+
+import (
+	"motif-api/internal/logger"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+)
+
+// rejectResponse is sent to clients over the connections limit.
+const rejectResponse = "HTTP/1.1 503 Service Unavailable\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Connection: close\r\n" +
+	"Retry-After: 1\r\n" +
+	"Content-Length: 36\r\n\r\n" +
+	"Too many connections, try it later.\n"
+
+// rejectTimeout is the max time we spend informing a rejected client, including the TLS handshake.
+const rejectTimeout = time.Second
+
+// limitListener implements a net.Listener limiting the number
+// of simultaneously open connections. Connections over the limit
+// are answered with 503 Service Unavailable and closed right away.
+// The listener works below the TLS layer; if the server terminates TLS,
+// the rejection is sent over a TLS session negotiating HTTP/1.1,
+// so the clients can read it. Cleartext HTTP/2 (h2c) clients with prior knowledge
+// do not understand the HTTP/1.1 rejection and see the connection closed.
+type limitListener struct {
+	net.Listener
+	log   logger.Logger
+	tls   *tls.Config
+	slots chan struct{}
+}
+
+// newLimitListener creates a new connections limiting listener wrapping the given listener.
+// The TLS configuration is used to reject connections of a TLS server; nil for cleartext.
+func newLimitListener(l net.Listener, max int, tlsCfg *tls.Config, log logger.Logger) net.Listener {
+	ll := limitListener{
+		Listener: l,
+		log:      log,
+		slots:    make(chan struct{}, max),
+	}
+	if tlsCfg != nil {
+		ll.tls = tlsCfg.Clone()
+		ll.tls.NextProtos = []string{"http/1.1"}
+	}
+	return &ll
+}
+
+// Accept waits for the next connection within the limit.
+func (ll *limitListener) Accept() (net.Conn, error) {
+	for {
+		c, err := ll.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case ll.slots <- struct{}{}:
+			return &limitConn{Conn: c, release: ll.release}, nil
+		default:
+			go ll.reject(c)
+		}
+	}
+}
+
+// release frees a connection slot.
+func (ll *limitListener) release() {
+	<-ll.slots
+}
+
+// reject informs the client about the connections limit and closes the connection.
+func (ll *limitListener) reject(c net.Conn) {
+	ll.log.Warningf("connections limit reached, rejecting %s", c.RemoteAddr().String())
+
+	if ll.tls != nil {
+		c = tls.Server(c, ll.tls)
+	}
+	if err := c.SetDeadline(time.Now().Add(rejectTimeout)); err == nil {
+		_, _ = c.Write([]byte(rejectResponse))
+	}
+	if err := c.Close(); err != nil {
+		ll.log.Debugf("can not close rejected connection; %s", err.Error())
+	}
+}
+
+// limitConn represents a connection occupying a slot of the limit listener.
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+// Close closes the connection and frees its slot.
+func (lc *limitConn) Close() error {
+	err := lc.Conn.Close()
+	lc.once.Do(lc.release)
+	return err
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"bufio"
+	"crypto/tls"
+	"golang.org/x/net/http2"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testLogger provides a logger for the tests.
+func testLogger() logger.Logger {
+	return logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+}
+
+// testOkHandler responds with the protocol of the request.
+var testOkHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte(r.Proto))
+})
+
+// testListen opens a local listener closed with the test.
+func testListen(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can not listen; %s", err.Error())
+	}
+	return l
+}
+
+// testReadReject reads the rejection response from the given connection.
+func testReadReject(t *testing.T, c net.Conn) {
+	_ = c.SetDeadline(time.Now().Add(5 * time.Second))
+	res, err := http.ReadResponse(bufio.NewReader(c), nil)
+	if err != nil {
+		t.Fatalf("can not read rejection; %s", err.Error())
+	}
+	if res.StatusCode != http.StatusServiceUnavailable || res.Header.Get("Retry-After") != "1" {
+		t.Errorf("expected 503 with retry after, got %d / %q", res.StatusCode, res.Header.Get("Retry-After"))
+	}
+}
+
+// TestLimitListener tests connections over the limit are rejected and the slots are released.
+func TestLimitListener(t *testing.T) {
+	l := testListen(t)
+	srv := &http.Server{Handler: testOkHandler}
+	go func() { _ = srv.Serve(newLimitListener(l, 1, nil, testLogger())) }()
+	defer func() { _ = srv.Close() }()
+
+	// the first connection takes the only slot
+	c1, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c1.Write([]byte("GET / HTTP/1.1\r\nHost: test\r\n\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := http.ReadResponse(bufio.NewReader(c1), nil); err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("expected the first connection to be served; %v", err)
+	}
+
+	// the second one is rejected
+	c2, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testReadReject(t, c2)
+	_ = c2.Close()
+
+	// closing the first connection frees the slot
+	_ = c1.Close()
+	client := http.Client{Transport: &http.Transport{DisableKeepAlives: true}, Timeout: 5 * time.Second}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res, err := client.Get("http://" + l.Addr().String())
+		if err == nil && res.StatusCode == http.StatusOK {
+			_ = res.Body.Close()
+			break
+		}
+		if err == nil {
+			_ = res.Body.Close()
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("slot not released")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestLimitListenerTLS tests the rejection is readable by TLS clients.
+func TestLimitListenerTLS(t *testing.T) {
+	// borrow the test certificate and the trusting client config
+	ts := httptest.NewTLSServer(testOkHandler)
+	defer ts.Close()
+	tlsCfg := &tls.Config{Certificates: ts.TLS.Certificates}
+	clientCfg := ts.Client().Transport.(*http.Transport).TLSClientConfig
+
+	l := testListen(t)
+	srv := &http.Server{Handler: testOkHandler, TLSConfig: tlsCfg}
+	go func() { _ = srv.ServeTLS(newLimitListener(l, 1, tlsCfg, testLogger()), "", "") }()
+	defer func() { _ = srv.Close() }()
+
+	c1, err := tls.Dial("tcp", l.Addr().String(), clientCfg)
+	if err != nil {
+		t.Fatalf("first connection failed; %s", err.Error())
+	}
+	defer func() { _ = c1.Close() }()
+
+	c2, err := tls.Dial("tcp", l.Addr().String(), clientCfg)
+	if err != nil {
+		t.Fatalf("rejected connection failed the handshake; %s", err.Error())
+	}
+	testReadReject(t, c2)
+	_ = c2.Close()
+}
+
+// testProtocolsServer serves the test handler on a server configured by setupProtocols.
+func testProtocolsServer(t *testing.T, h2 config.ServerHttp2) string {
+	app := apiServer{
+		cfg: &config.Config{Server: config.Server{Http2: h2}},
+		log: testLogger(),
+		srv: &http.Server{Handler: testOkHandler},
+	}
+	app.setupProtocols()
+
+	l := testListen(t)
+	go func() { _ = app.srv.Serve(l) }()
+	t.Cleanup(func() { _ = app.srv.Close() })
+	return l.Addr().String()
+}
+
+// testH2cGet sends a cleartext HTTP/2 request with prior knowledge.
+func testH2cGet(addr string) (*http.Response, error) {
+	client := http.Client{Timeout: 5 * time.Second, Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}}
+	return client.Get("http://" + addr)
+}
+
+// TestSetupProtocols tests the cleartext HTTP/2 is served only if enabled.
+func TestSetupProtocols(t *testing.T) {
+	addr := testProtocolsServer(t, config.ServerHttp2{Enabled: true, Cleartext: true, MaxStreams: 10})
+	res, err := testH2cGet(addr)
+	if err != nil {
+		t.Fatalf("h2c request failed; %s", err.Error())
+	}
+	_ = res.Body.Close()
+	if res.ProtoMajor != 2 {
+		t.Errorf("expected HTTP/2, got %s", res.Proto)
+	}
+
+	// HTTP/1.1 clients are still served
+	res, err = http.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("HTTP/1.1 request failed; %s", err.Error())
+	}
+	_ = res.Body.Close()
+	if res.ProtoMajor != 1 {
+		t.Errorf("expected HTTP/1.1, got %s", res.Proto)
+	}
+
+	// h2c is not served without the cleartext option
+	addr = testProtocolsServer(t, config.ServerHttp2{Enabled: true})
+	if res, err := testH2cGet(addr); err == nil {
+		_ = res.Body.Close()
+		t.Errorf("unexpected h2c response %s", res.Proto)
+	}
+}
+
+// TestSetupProtocolsDisabled tests HTTP/2 is not negotiated over TLS if disabled.
+func TestSetupProtocolsDisabled(t *testing.T) {
+	ts := httptest.NewTLSServer(testOkHandler)
+	defer ts.Close()
+	clientCfg := ts.Client().Transport.(*http.Transport).TLSClientConfig
+
+	app := apiServer{cfg: &config.Config{}, log: testLogger(), srv: &http.Server{
+		Handler:   testOkHandler,
+		TLSConfig: &tls.Config{Certificates: ts.TLS.Certificates},
+	}}
+	app.setupProtocols()
+
+	l := testListen(t)
+	go func() { _ = app.srv.ServeTLS(l, "", "") }()
+	defer func() { _ = app.srv.Close() }()
+
+	client := http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: clientCfg, ForceAttemptHTTP2: true}}
+	res, err := client.Get("https://" + l.Addr().String())
+	if err != nil {
+		t.Fatalf("request failed; %s", err.Error())
+	}
+	_ = res.Body.Close()
+	if res.ProtoMajor != 1 {
+		t.Errorf("expected HTTP/1.1, got %s", res.Proto)
+	}
+}
@@ -0,0 +1,91 @@
+// Package auth implements API clients authentication and role based access control.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// RoleAdmin represents the role of API server administrators.
+const RoleAdmin = "admin"
+
+// ErrNotAuthenticated is returned by protected resolvers if the request did not provide any credentials.
+var ErrNotAuthenticated = errors.New("authentication required")
+
+// ErrForbidden is returned by protected resolvers if the client does not have the required role.
+var ErrForbidden = errors.New("access denied")
+
+// Claims represents verified identity of an API client.
+type Claims struct {
+	// Subject identifies the client.
+	Subject string
+
+	// Roles is the list of roles granted to the client.
+	Roles []string
+}
+
+// HasRole checks if the client has been granted the given role.
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// claimsKey represents the context key of the verified client claims.
+type claimsKey struct{}
+
+// failureKey represents the context key of the failed credentials verification.
+type failureKey struct{}
+
+// WithClaims creates a derived context carrying the verified client claims.
+func WithClaims(ctx context.Context, c *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, c)
+}
+
+// WithFailure creates a derived context carrying the reason the provided credentials were rejected.
+// Public resolvers ignore the failure, protected resolvers report it back to the client.
+func WithFailure(ctx context.Context, err error) context.Context {
+	return context.WithValue(ctx, failureKey{}, err)
+}
+
+// ClaimsFromContext provides the verified client claims of the request, if any.
+func ClaimsFromContext(ctx context.Context) *Claims {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(claimsKey{}).(*Claims)
+	return c
+}
+
+// FailureFromContext provides the reason the credentials of the request were rejected, if any.
+func FailureFromContext(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	err, _ := ctx.Value(failureKey{}).(error)
+	return err
+}
+
+// Require checks the request context carries verified credentials with the given role.
+// Protected resolvers call it before doing anything else.
+func Require(ctx context.Context, role string) error {
+	// rejected credentials?
+	if err := FailureFromContext(ctx); err != nil {
+		return fmt.Errorf("invalid credentials; %s", err.Error())
+	}
+
+	// any credentials at all?
+	c := ClaimsFromContext(ctx)
+	if c == nil {
+		return ErrNotAuthenticated
+	}
+
+	if !c.HasRole(role) {
+		return ErrForbidden
+	}
+	return nil
+}
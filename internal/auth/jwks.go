@@ -0,0 +1,158 @@
+// Package auth implements API clients authentication and role based access control.
+package auth
+
+import (
+	"motif-api/internal/logger"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"golang.org/x/sync/singleflight"
+	"sync/atomic"
+	"time"
+)
+
+// jwksFetchTimeout represents the max time we wait for the JWKS document.
+const jwksFetchTimeout = 10 * time.Second
+
+// jwksMinRefresh represents the min time between two JWKS document loads;
+// it prevents unknown key ids from flooding the auth service.
+const jwksMinRefresh = time.Minute
+
+// jwksKey represents a single key of the JSON Web Key Set document.
+type jwksKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// jwksCache implements a cache of RSA public keys loaded from a JWKS URL.
+// The keys are refreshed periodically, and on demand if an unknown key id is seen.
+// The key set is never modified, a refreshed set replaces the current one as a whole,
+// so the keys are read without locking and the JWKS document is downloaded
+// by a single request at a time.
+type jwksCache struct {
+	url     string
+	refresh time.Duration
+	log     logger.Logger
+	client  *http.Client
+
+	set   atomic.Value
+	group singleflight.Group
+}
+
+// jwksKeySet represents the set of keys loaded from the JWKS document.
+type jwksKeySet struct {
+	keys   map[string]*rsa.PublicKey
+	loaded time.Time
+}
+
+// newJwksCache creates a new JWKS keys cache for the given URL.
+func newJwksCache(url string, refresh time.Duration, log logger.Logger) *jwksCache {
+	jc := jwksCache{
+		url:     url,
+		refresh: refresh,
+		log:     log,
+		client:  &http.Client{Timeout: jwksFetchTimeout},
+	}
+	jc.set.Store(&jwksKeySet{keys: make(map[string]*rsa.PublicKey)})
+	return &jc
+}
+
+// key provides the public key of the given key id.
+// Expired keys are served while the refresh runs in the background;
+// the caller waits for the refresh only if the key id is unknown.
+func (jc *jwksCache) key(kid string) (*rsa.PublicKey, error) {
+	ks := jc.set.Load().(*jwksKeySet)
+	since := time.Since(ks.loaded)
+
+	pub, ok := ks.keys[kid]
+	switch {
+	case ok && since > jc.refresh:
+		jc.group.DoChan("jwks", jc.reload)
+	case !ok && since > jwksMinRefresh:
+		res := <-jc.group.DoChan("jwks", jc.reload)
+		pub, ok = res.Val.(*jwksKeySet).keys[kid]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %s", kid)
+	}
+	return pub, nil
+}
+
+// reload downloads the JWKS document and replaces the current key set.
+// The current keys are kept if the download fails, the time of the attempt is recorded anyway.
+func (jc *jwksCache) reload() (interface{}, error) {
+	keys, err := jc.load()
+	if err != nil {
+		jc.log.Errorf("can not load JWKS keys; %s", err.Error())
+		keys = jc.set.Load().(*jwksKeySet).keys
+	}
+
+	ks := &jwksKeySet{keys: keys, loaded: time.Now()}
+	jc.set.Store(ks)
+	return ks, nil
+}
+
+// load downloads the JWKS document and decodes its keys.
+func (jc *jwksCache) load() (map[string]*rsa.PublicKey, error) {
+	res, err := jc.client.Get(jc.url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			jc.log.Errorf("can not close JWKS response; %s", err.Error())
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected JWKS response status %d", res.StatusCode)
+	}
+
+	var doc struct {
+		Keys []jwksKey `json:"keys"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	// decode RSA keys; skip anything else
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		pub, err := k.rsaPublicKey()
+		if err != nil {
+			jc.log.Warningf("invalid JWKS key %s; %s", k.Kid, err.Error())
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	jc.log.Debugf("%d JWKS keys loaded", len(keys))
+	return keys, nil
+}
+
+// rsaPublicKey decodes the RSA public key from the JWKS key modulus and exponent.
+func (k *jwksKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	exp := new(big.Int).SetBytes(e)
+	if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+}
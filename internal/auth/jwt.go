@@ -0,0 +1,212 @@
+// Package auth implements API clients authentication and role based access control.
+package auth
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+// jwtLeeway represents the tolerated clock skew on token time claims validation.
+const jwtLeeway = 30 * time.Second
+
+// ErrTokenExpired is returned if the token is past its expiration time.
+var ErrTokenExpired = errors.New("token expired")
+
+// ErrTokenInvalid is returned if the token can not be parsed, or its signature does not match.
+var ErrTokenInvalid = errors.New("token invalid")
+
+// jwtHeader represents the decoded JOSE header of a token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// JwtVerifier implements verification of JSON Web Tokens issued by an external auth service.
+type JwtVerifier struct {
+	key       []byte
+	jwks      *jwksCache
+	issuer    string
+	audience  string
+	roleClaim string
+}
+
+// NewJwtVerifier creates a new JWT verifier from the given configuration.
+// It returns nil if the JWT verification is not configured.
+func NewJwtVerifier(cfg *config.Auth, log logger.Logger) *JwtVerifier {
+	if cfg.JwtKey == "" && cfg.JwksURL == "" {
+		return nil
+	}
+
+	v := JwtVerifier{
+		issuer:    cfg.Issuer,
+		audience:  cfg.Audience,
+		roleClaim: cfg.RoleClaim,
+	}
+
+	if cfg.JwtKey != "" {
+		v.key = []byte(cfg.JwtKey)
+	}
+	if cfg.JwksURL != "" {
+		v.jwks = newJwksCache(cfg.JwksURL, cfg.JwksRefresh, log)
+	}
+	return &v
+}
+
+// Verify validates the given token and provides the client claims it carries.
+func (v *JwtVerifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenInvalid
+	}
+
+	// decode the header
+	var hdr jwtHeader
+	if err := jwtDecodeSegment(parts[0], &hdr); err != nil {
+		return nil, ErrTokenInvalid
+	}
+
+	// verify the signature
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrTokenInvalid
+	}
+	if err := v.verifySignature(&hdr, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+
+	// decode the claims
+	var cl map[string]interface{}
+	if err := jwtDecodeSegment(parts[1], &cl); err != nil {
+		return nil, ErrTokenInvalid
+	}
+	return v.claims(cl, time.Now())
+}
+
+// verifySignature checks the signature of the signed content using the algorithm from the header.
+func (v *JwtVerifier) verifySignature(hdr *jwtHeader, signed []byte, sig []byte) error {
+	switch hdr.Alg {
+	case "HS256", "HS384", "HS512":
+		if v.key == nil {
+			return fmt.Errorf("%s signed tokens not accepted", hdr.Alg)
+		}
+		mac := hmac.New(jwtHashFunc(hdr.Alg), v.key)
+		mac.Write(signed)
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return ErrTokenInvalid
+		}
+		return nil
+	case "RS256", "RS384", "RS512":
+		if v.jwks == nil {
+			return fmt.Errorf("%s signed tokens not accepted", hdr.Alg)
+		}
+		pub, err := v.jwks.key(hdr.Kid)
+		if err != nil {
+			return err
+		}
+		h, ch := jwtHashFunc(hdr.Alg)(), jwtCryptoHash(hdr.Alg)
+		h.Write(signed)
+		if err := rsa.VerifyPKCS1v15(pub, ch, h.Sum(nil), sig); err != nil {
+			return ErrTokenInvalid
+		}
+		return nil
+	}
+	return fmt.Errorf("signing algorithm %s not supported", hdr.Alg)
+}
+
+// claims validates the standard token claims and extracts the client claims.
+func (v *JwtVerifier) claims(cl map[string]interface{}, now time.Time) (*Claims, error) {
+	// check the time constraints; tokens without expiration are never accepted
+	exp, ok := cl["exp"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("token expiration missing")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(jwtLeeway)) {
+		return nil, ErrTokenExpired
+	}
+	if nbf, ok := cl["nbf"].(float64); ok && now.Add(jwtLeeway).Before(time.Unix(int64(nbf), 0)) {
+		return nil, fmt.Errorf("token not valid yet")
+	}
+
+	// check the issuer and the audience
+	if v.issuer != "" && cl["iss"] != v.issuer {
+		return nil, fmt.Errorf("unexpected token issuer")
+	}
+	if v.audience != "" && !jwtHasAudience(cl["aud"], v.audience) {
+		return nil, fmt.Errorf("unexpected token audience")
+	}
+
+	c := Claims{Roles: jwtStrings(cl[v.roleClaim])}
+	c.Subject, _ = cl["sub"].(string)
+	return &c, nil
+}
+
+// jwtDecodeSegment decodes a base64url encoded JSON segment of a token.
+func jwtDecodeSegment(seg string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// jwtHashFunc provides the hash constructor for the given signing algorithm.
+func jwtHashFunc(alg string) func() hash.Hash {
+	switch alg[2:] {
+	case "384":
+		return sha512.New384
+	case "512":
+		return sha512.New
+	}
+	return sha256.New
+}
+
+// jwtCryptoHash provides the crypto hash identifier for the given signing algorithm.
+func jwtCryptoHash(alg string) crypto.Hash {
+	switch alg[2:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	}
+	return crypto.SHA256
+}
+
+// jwtHasAudience checks if the audience claim contains the expected audience.
+// The claim can be either a single string, or an array of strings.
+func jwtHasAudience(aud interface{}, expected string) bool {
+	for _, a := range jwtStrings(aud) {
+		if a == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// jwtStrings converts a claim value, either a single string or an array of strings, into a slice.
+func jwtStrings(val interface{}) []string {
+	switch v := val.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				list = append(list, str)
+			}
+		}
+		return list
+	}
+	return nil
+}
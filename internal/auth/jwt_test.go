@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testLogger provides a logger for the tests.
+func testLogger() logger.Logger {
+	return logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+}
+
+// testSegment encodes the given value into a token segment.
+func testSegment(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// testHS256 creates an HMAC signed token with the given claims.
+func testHS256(t *testing.T, key string, claims map[string]interface{}) string {
+	signed := testSegment(t, jwtHeader{Alg: "HS256"}) + "." + testSegment(t, claims)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// TestJwtVerifierHMAC tests verification of HMAC signed tokens.
+func TestJwtVerifierHMAC(t *testing.T) {
+	v := NewJwtVerifier(&config.Auth{JwtKey: "secret", Issuer: "auth", RoleClaim: "role"}, testLogger())
+	exp := float64(time.Now().Add(time.Hour).Unix())
+
+	cl, err := v.Verify(testHS256(t, "secret", map[string]interface{}{"sub": "client", "iss": "auth", "exp": exp, "role": []string{"admin"}}))
+	if err != nil {
+		t.Fatalf("valid token rejected; %s", err.Error())
+	}
+	if cl.Subject != "client" || !cl.HasRole(RoleAdmin) {
+		t.Errorf("unexpected claims %+v", cl)
+	}
+
+	if _, err := v.Verify(testHS256(t, "other", map[string]interface{}{"iss": "auth", "exp": exp})); err != ErrTokenInvalid {
+		t.Errorf("expected invalid signature, got %v", err)
+	}
+	if _, err := v.Verify(testHS256(t, "secret", map[string]interface{}{"iss": "auth", "exp": float64(time.Now().Add(-time.Hour).Unix())})); err != ErrTokenExpired {
+		t.Errorf("expected expired token, got %v", err)
+	}
+	if _, err := v.Verify(testHS256(t, "secret", map[string]interface{}{"iss": "other", "exp": exp})); err == nil {
+		t.Errorf("unexpected issuer accepted")
+	}
+	if _, err := v.Verify("not.a-token"); err != ErrTokenInvalid {
+		t.Errorf("expected invalid token, got %v", err)
+	}
+	if _, err := v.Verify(testHS256(t, "secret", map[string]interface{}{"iss": "auth"})); err == nil {
+		t.Errorf("token without expiration accepted")
+	}
+	if _, err := v.Verify(testHS256(t, "secret", map[string]interface{}{"iss": "auth", "exp": "never"})); err == nil {
+		t.Errorf("token with invalid expiration accepted")
+	}
+}
+
+// TestJwtVerifierJWKS tests verification of RSA signed tokens with keys loaded from a JWKS URL.
+func TestJwtVerifierJWKS(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// serve the JWKS document
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"keys":[{"kty":"RSA","kid":"k1","n":"%s","e":"%s"}]}`,
+			base64.RawURLEncoding.EncodeToString(pk.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pk.E)).Bytes()))
+	}))
+	defer srv.Close()
+
+	v := NewJwtVerifier(&config.Auth{JwksURL: srv.URL, JwksRefresh: time.Hour, Audience: "api", RoleClaim: "role"}, testLogger())
+
+	// sign the token
+	signed := testSegment(t, jwtHeader{Alg: "RS256", Kid: "k1"}) + "." + testSegment(t, map[string]interface{}{"aud": []string{"api"}, "role": "admin", "exp": float64(time.Now().Add(time.Hour).Unix())})
+	h := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, pk, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl, err := v.Verify(signed + "." + base64.RawURLEncoding.EncodeToString(sig))
+	if err != nil {
+		t.Fatalf("valid token rejected; %s", err.Error())
+	}
+	if !cl.HasRole(RoleAdmin) {
+		t.Errorf("expected admin role, got %v", cl.Roles)
+	}
+
+	// HMAC tokens are not accepted without a key
+	if _, err := v.Verify(testHS256(t, "secret", map[string]interface{}{"aud": "api"})); err == nil {
+		t.Errorf("HMAC token accepted without a key")
+	}
+}
+
+// TestJwksRefreshNotBlocking tests the expired keys are served while the JWKS document
+// is being downloaded, and the refreshed key set replaces the current one.
+func TestJwksRefreshNotBlocking(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the second download hangs until released
+	var loads int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&loads, 1) > 1 {
+			<-release
+		}
+		_, _ = fmt.Fprintf(w, `{"keys":[{"kty":"RSA","kid":"k1","n":"%s","e":"%s"}]}`,
+			base64.RawURLEncoding.EncodeToString(pk.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pk.E)).Bytes()))
+	}))
+	defer srv.Close()
+
+	jc := newJwksCache(srv.URL, time.Hour, testLogger())
+	if _, err := jc.key("k1"); err != nil {
+		t.Fatalf("key not loaded; %s", err.Error())
+	}
+
+	// unknown keys do not trigger a download right after the load
+	if _, err := jc.key("k2"); err == nil || atomic.LoadInt32(&loads) != 1 {
+		t.Fatalf("expected unknown key without download, got %v / %d loads", err, atomic.LoadInt32(&loads))
+	}
+
+	// expire the key set; the known key is served while the refresh hangs
+	old := jc.set.Load().(*jwksKeySet)
+	jc.set.Store(&jwksKeySet{keys: old.keys, loaded: time.Now().Add(-2 * time.Hour)})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := jc.key("k1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expired key not served; %s", err.Error())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("key lookup blocked by the refresh")
+	}
+
+	// let the refresh finish and wait for the new key set
+	close(release)
+	deadline := time.Now().Add(5 * time.Second)
+	for jc.set.Load().(*jwksKeySet) == old || time.Since(jc.set.Load().(*jwksKeySet).loaded) > time.Minute {
+		if time.Now().After(deadline) {
+			t.Fatalf("key set not refreshed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := atomic.LoadInt32(&loads); n != 2 {
+		t.Errorf("expected 2 loads, got %d", n)
+	}
+}
+
+// TestRequire tests the role based access check on request contexts.
+func TestRequire(t *testing.T) {
+	ctx := context.Background()
+	if err := Require(ctx, RoleAdmin); err != ErrNotAuthenticated {
+		t.Errorf("expected not authenticated, got %v", err)
+	}
+	if err := Require(WithClaims(ctx, &Claims{Roles: []string{"reader"}}), RoleAdmin); err != ErrForbidden {
+		t.Errorf("expected forbidden, got %v", err)
+	}
+	if err := Require(WithClaims(ctx, &Claims{Roles: []string{RoleAdmin}}), RoleAdmin); err != nil {
+		t.Errorf("expected access granted, got %v", err)
+	}
+	if err := Require(WithFailure(ctx, ErrTokenExpired), RoleAdmin); err == nil {
+		t.Errorf("expected rejected credentials")
+	}
+}
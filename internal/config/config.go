@@ -31,9 +31,21 @@ type Config struct {
 	// Cache configuration
 	Cache Cache `mapstructure:"cache"`
 
+	// Auth configuration
+	Auth Auth `mapstructure:"auth"`
+
 	// Cache configuration
 	Compiler Compiler `mapstructure:"compiler"`
 
+	// AbiSource configures the remote source of ABIs of contracts not verified locally
+	AbiSource AbiSource `mapstructure:"abi_source"`
+
+	// Fx configures the currency exchange rates used to convert USD values
+	Fx Fx `mapstructure:"fx"`
+
+	// Metrics configures the Prometheus metrics endpoint
+	Metrics Metrics `mapstructure:"metrics"`
+
 	// Repository configuration
 	Repository Repository `mapstructure:"repository"`
 
@@ -55,6 +67,33 @@ type Config struct {
 	// mapped to URL addresses of their logos.
 	TokenLogo map[common.Address]string
 
+	// TokenRisk configures heuristic risk flags of ERC20 tokens
+	TokenRisk TokenRisk `mapstructure:"token_risk"`
+
+	// ApprovalRisk configures the weights of the heuristic account approval risk score
+	ApprovalRisk ApprovalRisk `mapstructure:"approval_risk"`
+
+	// TrxEta configures the pending transaction confirmation estimate heuristics
+	TrxEta TrxEta `mapstructure:"trx_eta"`
+
+	// MethodWatch is a list of contract methods whose calls are indexed for analytics.
+	MethodWatch []MethodWatch `mapstructure:"method_watch"`
+
+	// PriceSnapshot configures the persistence of DeFi token prices for historical queries
+	PriceSnapshot PriceSnapshot `mapstructure:"price_snapshot"`
+
+	// SupplySnapshot configures the persistence of ERC20 token total supply for historical queries
+	SupplySnapshot SupplySnapshot `mapstructure:"supply_snapshot"`
+
+	// Faucet configures the testnet faucet sending native tokens from the server key
+	Faucet Faucet `mapstructure:"faucet"`
+
+	// NameRegistry configures the resolution of human-readable account names
+	NameRegistry NameRegistry `mapstructure:"name_registry"`
+
+	// Views configures the refresh intervals of the periodically refreshed views
+	Views Views `mapstructure:"views"`
+
 	// ReScanBlocks represents the number of blocks to be re-scanned.
 	RepoCommand RepoCmd `mapstructure:"cmd"`
 }
@@ -63,6 +102,10 @@ type Config struct {
 type RepoCmd struct {
 	BlockScanReScan uint64
 	RestoreStake    string
+
+	// BlockScanMaxReorg is the max number of indexed blocks checked against the node
+	// for a chain reorg on the server start; zero disables the check.
+	BlockScanMaxReorg uint64 `mapstructure:"max_reorg_depth"`
 }
 
 // Server represents the GraphQL server configuration
@@ -77,6 +120,169 @@ type Server struct {
 	IdleTimeout     int64    `mapstructure:"idle_timeout"`
 	HeaderTimeout   int64    `mapstructure:"header_timeout"`
 	ResolverTimeout int64    `mapstructure:"resolver_timeout"`
+
+	// CorsMethods is the list of HTTP methods allowed for cross-origin requests.
+	CorsMethods []string `mapstructure:"cors_methods"`
+
+	// CorsHeaders is the list of request headers allowed for cross-origin requests
+	// on top of the headers the API relies on itself.
+	CorsHeaders []string `mapstructure:"cors_headers"`
+
+	// CorsMaxAge is the number of seconds clients may cache the preflight response;
+	// zero omits the Access-Control-Max-Age header.
+	CorsMaxAge int `mapstructure:"cors_max_age"`
+
+	// ShutdownGracePeriod is the number of seconds the requests in flight are allowed
+	// to finish on the server shutdown before they are terminated.
+	ShutdownGracePeriod int64 `mapstructure:"shutdown_grace_period"`
+
+	// ResolverTimeouts configures resolver deadlines per field category.
+	ResolverTimeouts ResolverTimeouts `mapstructure:"resolver_timeouts"`
+
+	// MaxConnections limits the number of simultaneously open client connections;
+	// zero means no limit. Connections over the limit are rejected with 503 Service Unavailable.
+	// The limit counts TCP connections, not requests. An upgraded WebSocket subscription
+	// keeps its connection for its whole lifetime, and a single HTTP/2 connection multiplexes
+	// up to Http2.MaxStreams concurrent requests, so per client request rate limits
+	// apply independently on top of this limit.
+	MaxConnections int `mapstructure:"max_connections"`
+
+	// MaxInFlight is the number of GraphQL requests being served at once above which
+	// new requests are shed early with 503 Service Unavailable; zero disables shedding.
+	// RetryAfter is the number of seconds shed clients are advised to back off.
+	MaxInFlight int   `mapstructure:"max_inflight"`
+	RetryAfter  int64 `mapstructure:"retry_after"`
+
+	// MaxVariablesSize limits the size of the GraphQL request variables in bytes
+	// and MaxVariablesDepth limits the nesting depth of the variables JSON;
+	// zero means no limit. Requests over the limits are rejected before execution.
+	MaxVariablesSize  int `mapstructure:"max_variables_size"`
+	MaxVariablesDepth int `mapstructure:"max_variables_depth"`
+
+	// MaxQueryComplexity limits the estimated cost of a GraphQL query;
+	// zero means no limit. Queries over the limit are rejected before execution.
+	MaxQueryComplexity int `mapstructure:"max_query_complexity"`
+
+	// PersistedQueries is the max number of query texts kept in the registry
+	// of automatic persisted queries; zero disables persisted queries.
+	PersistedQueries int `mapstructure:"persisted_queries"`
+
+	// RateLimitPerSecond is the sustained number of requests per second a single client
+	// can make and RateLimitBurst is the number of requests the client can make at once;
+	// zero rate means no limit. Clients over the limit get 429 Too Many Requests.
+	RateLimitPerSecond float64 `mapstructure:"rate_limit_per_second"`
+	RateLimitBurst     int     `mapstructure:"rate_limit_burst"`
+
+	// TrustedProxyHeader is the name of the HTTP header carrying the client address
+	// set by a trusted reverse proxy, e.g. X-Forwarded-For; the last address of the header
+	// is used. The remote address of the connection is used if empty.
+	TrustedProxyHeader string `mapstructure:"trusted_proxy_header"`
+
+	// TrxSubmitLimit is the max number of transactions a single client
+	// can submit per minute; zero means no limit.
+	TrxSubmitLimit int `mapstructure:"trx_submit_limit"`
+
+	// TLS configures native TLS termination of the server.
+	TLS ServerTLS `mapstructure:"tls"`
+
+	// Http2 configures HTTP/2 support of the server.
+	Http2 ServerHttp2 `mapstructure:"http2"`
+
+	// DisableIntrospection turns off the GraphQL schema introspection queries.
+	DisableIntrospection bool `mapstructure:"disable_introspection"`
+
+	// AllowRawCall enables the contract call passthrough exposing read only calls
+	// of the connected node to the clients.
+	AllowRawCall bool `mapstructure:"allow_raw_call"`
+
+	// SchemaSDL controls the /schema.graphql end-point serving the schema in SDL form;
+	// "auto" serves the schema only if the introspection is enabled, "on" serves
+	// the schema regardless of the introspection, e.g. for trusted deployments,
+	// and "off" disables the end-point.
+	SchemaSDL string `mapstructure:"schema_sdl"`
+
+	// SignedFields is the list of root query fields, e.g. "account", or "price",
+	// responses of which are signed by the server private key so the clients can verify
+	// the data came from a trusted API peer. Empty list disables the response attestation.
+	SignedFields []string `mapstructure:"signed_fields"`
+
+	// PeerFallback configures proxying of read-only queries to the API peers
+	// if the local node is behind, or unreachable.
+	PeerFallback PeerFallback `mapstructure:"peer_fallback"`
+}
+
+// schema SDL end-point modes
+const (
+	SchemaSDLAuto = "auto"
+	SchemaSDLOn   = "on"
+	SchemaSDLOff  = "off"
+)
+
+// SchemaSDLEnabled checks if the schema SDL end-point should be served.
+func (s *Server) SchemaSDLEnabled() bool {
+	switch s.SchemaSDL {
+	case SchemaSDLOn:
+		return true
+	case SchemaSDLAuto:
+		return !s.DisableIntrospection
+	}
+	return false
+}
+
+// ServerTLS represents the TLS termination configuration of the server.
+// TLS is enabled if both the certificate and the key file are configured.
+type ServerTLS struct {
+	CertFile string `mapstructure:"cert"`
+	KeyFile  string `mapstructure:"key"`
+}
+
+// ServerHttp2 represents the HTTP/2 configuration of the server.
+type ServerHttp2 struct {
+	// Enabled switches HTTP/2 on; with TLS it's negotiated natively using ALPN.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Cleartext allows HTTP/2 without TLS (h2c), e.g. behind a TLS terminating proxy.
+	Cleartext bool `mapstructure:"h2c"`
+
+	// MaxStreams limits the number of concurrent streams per HTTP/2 connection.
+	MaxStreams int `mapstructure:"max_streams"`
+}
+
+// PeerFallback represents the configuration of the degraded mode serving read-only queries
+// by the healthy API peers. Peer responses are accepted only if signed by the expected signer.
+type PeerFallback struct {
+	// Enabled switches the peer fallback on.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Signers are the expected signers of the responses of the API peers, in the order of the peers.
+	Signers []common.Address `mapstructure:"signers"`
+
+	// HealthCheck is the interval between two health checks of the peers and the local node.
+	HealthCheck time.Duration `mapstructure:"health_check_interval"`
+
+	// MaxLag is the number of blocks the local node can be behind the best peer
+	// before the queries are proxied.
+	MaxLag uint64 `mapstructure:"max_lag"`
+
+	// MaxAge is the max age of the signature of an accepted peer response.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// Timeout is the deadline of a single peer call.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// ResolverTimeouts represents resolver deadlines in seconds per field category.
+// Categories without a value use the general resolver timeout; the general resolver
+// timeout also limits the whole request, so longer category deadlines are cut by it.
+type ResolverTimeouts struct {
+	// LiveRead applies to cheap reads served live from the node, or the cache.
+	LiveRead int64 `mapstructure:"live"`
+
+	// Indexed applies to queries served from the off-chain index.
+	Indexed int64 `mapstructure:"indexed"`
+
+	// Aggregation applies to expensive analytics and aggregations.
+	Aggregation int64 `mapstructure:"aggregation"`
 }
 
 // ServerSignature represents the signature used by this server
@@ -90,34 +296,335 @@ type ServerSignature struct {
 type Log struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+
+	// JSON switches the outputs to structured JSON lines; the Format is ignored if set.
+	JSON bool `mapstructure:"json"`
+
+	// Outputs represents the list of destinations the log is written to,
+	// any combination of stdout, stderr, file and syslog.
+	Outputs []string `mapstructure:"outputs"`
+
+	// File represents the configuration of the file output.
+	File LogFile `mapstructure:"file"`
+
+	// SyslogTag represents the tag of the records sent to the syslog output.
+	SyslogTag string `mapstructure:"syslog_tag"`
+}
+
+// log output destinations
+const (
+	LogOutputStdout = "stdout"
+	LogOutputStderr = "stderr"
+	LogOutputFile   = "file"
+	LogOutputSyslog = "syslog"
+)
+
+// LogFile represents the configuration of the log file output with size based rotation.
+type LogFile struct {
+	// Path represents the path to the log file.
+	Path string `mapstructure:"path"`
+
+	// MaxSize represents the size of the log file in MB triggering the rotation.
+	MaxSize int64 `mapstructure:"max_size"`
+
+	// MaxFiles represents the number of rotated files retained.
+	MaxFiles int `mapstructure:"max_files"`
 }
 
 // Lachesis represents the Lachesis node access configuration
 type Lachesis struct {
 	Url string `mapstructure:"url"`
+
+	// Urls represents the list of node endpoints for high availability. Calls are routed
+	// to the first healthy node of the list; the single Url is used if the list is empty.
+	Urls []string `mapstructure:"urls"`
+
+	// HealthCheck represents the interval of the nodes health check pings.
+	HealthCheck time.Duration `mapstructure:"health_check_interval"`
+
+	// MaxConcurrency represents the max number of concurrent calls
+	// of a single batch of calls issued to the node.
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+
+	// RetryAttempts represents the max number of attempts of a node call
+	// failed on a transient error, e.g. a dropped connection.
+	RetryAttempts int `mapstructure:"retry_attempts"`
+
+	// RetryBackoff represents the delay before the first retry of a failed node call;
+	// the delay doubles with each next attempt.
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+}
+
+// Endpoints provides the list of the node endpoints to connect.
+// The single node Url is used if the list of endpoints is not configured.
+func (l *Lachesis) Endpoints() []string {
+	if len(l.Urls) > 0 {
+		return l.Urls
+	}
+	return []string{l.Url}
 }
 
 // Database represents the database access configuration.
 type Database struct {
 	Url    string `mapstructure:"url"`
 	DbName string `mapstructure:"db"`
+
+	// MaxPoolSize and MinPoolSize limit the number of connections
+	// kept in the pool per database server; zero max means no limit.
+	MaxPoolSize uint64 `mapstructure:"max_pool_size"`
+	MinPoolSize uint64 `mapstructure:"min_pool_size"`
+
+	// ConnectTimeout is the max duration of opening a new connection.
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+
+	// ServerSelectionTimeout is the max duration of finding an available server
+	// for an operation; operations fail after it if the database is down.
+	ServerSelectionTimeout time.Duration `mapstructure:"server_selection_timeout"`
 }
 
 // Cache represents the cache sub-system configuration.
 type Cache struct {
 	Eviction time.Duration `mapstructure:"eviction"`
 	MaxSize  int           `mapstructure:"size"`
+
+	// BalanceTTL is the lifetime of cached account and token balances.
+	BalanceTTL time.Duration `mapstructure:"balance_ttl"`
+
+	// PriceTTL is the lifetime of cached prices.
+	PriceTTL time.Duration `mapstructure:"price_ttl"`
+
+	// BypassLimit is the max number of forced fresh reads a single client
+	// can request per minute; the allowance refills continuously.
+	BypassLimit int `mapstructure:"bypass_limit"`
+}
+
+// Auth represents the API clients authentication configuration.
+// JWT verification is enabled if either the signing key, or the JWKS URL is set.
+type Auth struct {
+	// JwtKey is the shared secret used to verify HMAC signed tokens.
+	JwtKey string `mapstructure:"jwt_key"`
+
+	// JwksURL is the address of the JSON Web Key Set used to verify RSA signed tokens.
+	JwksURL string `mapstructure:"jwks_url"`
+
+	// JwksRefresh is the interval in which the cached JWKS keys are refreshed.
+	JwksRefresh time.Duration `mapstructure:"jwks_refresh"`
+
+	// Issuer is the expected issuer of the tokens; not verified if empty.
+	Issuer string `mapstructure:"issuer"`
+
+	// Audience is the expected audience of the tokens; not verified if empty.
+	Audience string `mapstructure:"audience"`
+
+	// RoleClaim is the name of the token claim carrying the client roles.
+	RoleClaim string `mapstructure:"role_claim"`
+
+	// Redaction controls how fields restricted to certain roles are resolved
+	// for clients without the role; "off" disables the redaction, "null" resolves
+	// the fields to null, and "error" reports an authorization error on the field.
+	// Traces and raw storage resolve to null without the role even if the redaction is off.
+	Redaction string `mapstructure:"redaction"`
+
+	// AnonymousSubscriptions allows subscriptions on connections without credentials;
+	// anonymous connections can access public subscriptions only, or none if disabled.
+	AnonymousSubscriptions bool `mapstructure:"anonymous_subscriptions"`
 }
 
+// field redaction modes
+const (
+	RedactionOff   = "off"
+	RedactionNull  = "null"
+	RedactionError = "error"
+)
+
 // Compiler represents the contract compilers configuration.
 type Compiler struct {
 	CompilerTempPath       string `mapstructure:"temp"`
 	DefaultSolCompilerPath string `mapstructure:"sol"`
+
+	// SolCompilers maps versions of installed Solidity compilers to their paths;
+	// the default compiler is used if the validation does not request a version.
+	SolCompilers map[string]string `mapstructure:"sol_versions"`
+}
+
+// AbiSource represents the configuration of the remote contract verification service
+// (an explorer API) providing ABIs of contracts not verified locally. The service
+// is expected to respond to the "module=contract&action=getabi" requests.
+type AbiSource struct {
+	// Enabled turns the remote ABI fetching on.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Url is the address of the explorer API end-point.
+	Url string `mapstructure:"url"`
+
+	// ApiKey is the key of the explorer API, if required.
+	ApiKey string `mapstructure:"api_key"`
+
+	// Timeout is the max time we wait for the remote ABI.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Fx represents the configuration of the currency exchange rates of USD.
+// Static rates are always available; rates of the remote feed, if configured,
+// are refreshed periodically and take precedence over the static ones.
+// The feed is expected to respond with {"rates": {"EUR": 0.92, ...}} based on USD.
+type Fx struct {
+	// Rates are the static rates by the currency code, e.g. "EUR": 0.92.
+	Rates map[string]float64 `mapstructure:"rates"`
+
+	// Url is the address of the remote rates feed; empty disables the feed.
+	Url string `mapstructure:"url"`
+
+	// Refresh is the period the remote rates are kept before being refreshed.
+	Refresh time.Duration `mapstructure:"refresh"`
+
+	// Timeout is the max time we wait for the remote rates.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
+// gas price suggestion modes
+const (
+	GasPriceModeNode       = "node"
+	GasPriceModeMedian     = "median"
+	GasPriceModePercentile = "percentile"
+)
+
 // Repository represents the repository configuration.
 type Repository struct {
 	MonitorStakers bool `mapstructure:"stakers"`
+
+	// ReorgRetention is the max number of chain reorg records kept in the database.
+	ReorgRetention int64 `mapstructure:"reorg_retention"`
+
+	// LogsMaxRange is the max number of blocks covered by a single logs query.
+	LogsMaxRange uint64 `mapstructure:"logs_max_range"`
+
+	// LogsChunkSize is the number of blocks requested from the node at once
+	// by a logs query; larger ranges are split into sub-requests. Zero disables the chunking.
+	LogsChunkSize uint64 `mapstructure:"logs_chunk_size"`
+
+	// IndexLogs enables the logs index; all log records of the scanned blocks
+	// are stored so they can be queried page by page.
+	IndexLogs bool `mapstructure:"index_logs"`
+
+	// ValueMaxBits is the sanity bound of token amounts, balances and supplies
+	// in bits; larger values are flagged as suspicious. Zero disables the check.
+	ValueMaxBits int `mapstructure:"value_max_bits"`
+
+	// AcceptKnownTrx makes the transaction relay idempotent; a transaction
+	// already known to the node is reported as sent instead of failing.
+	AcceptKnownTrx bool `mapstructure:"accept_known_trx"`
+
+	// TolerantErc20 enables decoding of known non-standard ERC20 return values,
+	// e.g. bytes32 token names and symbols, if the standard ABI decoding fails.
+	TolerantErc20 bool `mapstructure:"tolerant_erc20"`
+
+	// GasPriceCache is the max age of the cached suggested gas price. Zero disables the caching.
+	GasPriceCache time.Duration `mapstructure:"gas_price_cache"`
+
+	// GasPriceMode selects the source of the suggested gas price; "node" trusts the node,
+	// "median" and "percentile" derive the price from the fee history of the recent blocks.
+	GasPriceMode string `mapstructure:"gas_price_mode"`
+
+	// GasPricePercentile is the percentile of the block gas prices used by the "percentile" mode.
+	GasPricePercentile float64 `mapstructure:"gas_price_percentile"`
+
+	// GasPriceBlocks is the number of the recent blocks the gas price is derived from
+	// by the "median" and the "percentile" modes.
+	GasPriceBlocks uint64 `mapstructure:"gas_price_blocks"`
+
+	// TokenPriceCache is the max age of the cached oracle price of a token. Zero disables the caching.
+	TokenPriceCache time.Duration `mapstructure:"token_price_cache"`
+}
+
+// TrxEta represents the configuration of the heuristic estimating
+// the time to confirmation of a pending transaction. The estimate compares
+// the gas price of the transaction with the gas price suggested by the node.
+type TrxEta struct {
+	// BaseBlocks is the number of blocks a transaction paying
+	// the suggested gas price waits for confirmation.
+	BaseBlocks int64 `mapstructure:"base_blocks"`
+
+	// MaxBlocks is the max number of blocks the estimate can reach;
+	// transactions estimated above the limit are considered underpriced.
+	MaxBlocks int64 `mapstructure:"max_blocks"`
+
+	// Exponent controls how fast the estimate grows with the gas price
+	// paid below the suggested price.
+	Exponent float64 `mapstructure:"exponent"`
+
+	// BlockInterval is the block interval in seconds used
+	// if the chain metrics are not available.
+	BlockInterval float64 `mapstructure:"block_interval"`
+}
+
+// PriceSnapshot represents the configuration of the job persisting oracle prices
+// of the registered DeFi tokens. The interval sets the granularity of the price history;
+// each snapshot stores one record per active token, so halving the interval doubles
+// the storage needed and the history can not resolve price moves shorter than the interval.
+type PriceSnapshot struct {
+	// Enabled turns the price snapshot job on.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval is the time between two consecutive price snapshots.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// SupplySnapshot represents the configuration of the block scanner job persisting
+// the total supply of the most active ERC20 tokens. The snapshots are taken on blocks
+// divisible by the interval, so the history is consistent across re-scans.
+type SupplySnapshot struct {
+	// Interval is the number of blocks between two consecutive supply snapshots;
+	// zero disables the snapshots.
+	Interval uint64 `mapstructure:"interval"`
+
+	// MaxTokens is the max number of the most active ERC20 tokens included in a snapshot.
+	MaxTokens int32 `mapstructure:"max_tokens"`
+}
+
+// Faucet represents the configuration of the testnet faucet. The faucet sends a fixed
+// amount of native tokens from the server signature key to the requested address,
+// each address can be funded once in the cooldown period.
+type Faucet struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// Amount is the amount of native tokens in WEI sent by a single request,
+	// either decimal, or 0x prefixed hexadecimal.
+	Amount string `mapstructure:"amount"`
+
+	// Cooldown is the min time between two requests funding the same address.
+	Cooldown time.Duration `mapstructure:"cooldown"`
+}
+
+// NameRegistry represents the configuration of the ENS-style name registry
+// resolving human-readable names to addresses and back.
+type NameRegistry struct {
+	// Contract is the address of the registry contract; empty address disables the names.
+	Contract common.Address `mapstructure:"contract"`
+}
+
+// Views represents the refresh intervals of the periodically refreshed,
+// materialized views of the chain data. The price snapshots view
+// is refreshed on the price snapshot interval.
+type Views struct {
+	// TrxFlow is the time between two updates of the transaction flow statistics.
+	TrxFlow time.Duration `mapstructure:"trx_flow"`
+
+	// TrxCount is the time between two updates of the total transactions count estimate.
+	TrxCount time.Duration `mapstructure:"trx_count"`
+}
+
+// MethodWatch represents a contract method whose calls are indexed.
+type MethodWatch struct {
+	// Contract is the address of the watched contract.
+	Contract common.Address `mapstructure:"contract"`
+
+	// Method is either the method signature, e.g. "transfer(address,uint256)",
+	// or the 4 bytes method selector, e.g. "0xa9059cbb". The arguments are decoded
+	// by the ABI of the validated contract, or by the method signature; calls of a method
+	// not known to either are recorded with raw arguments. Only the calls made
+	// by transactions directly are recorded, internal calls are not.
+	Method string `mapstructure:"method"`
 }
 
 // Staking represents the PoS Staking module configuration.
@@ -139,6 +646,10 @@ type DeFi struct {
 // DeFiFMint represents the fMint DeFi module configuration.
 type DeFiFMint struct {
 	AddressProvider common.Address `mapstructure:"address_provider"`
+
+	// RiskBuffer is the margin above the minimal collateral ratio in percent
+	// of the ratio a position is considered at risk of liquidation within.
+	RiskBuffer float64 `mapstructure:"risk_buffer"`
 }
 
 // DeFiUniswap represents the Uniswap protocol DeFi module configuration.
@@ -146,6 +657,10 @@ type DeFiUniswap struct {
 	Core           common.Address   `mapstructure:"core"`
 	Router         common.Address   `mapstructure:"router"`
 	PairsWhiteList []common.Address `mapstructure:"whitelist"`
+
+	// PriceBase represents the base, usually a stable, token the DEX spot price
+	// of other tokens is derived against. Empty address disables DEX pricing.
+	PriceBase common.Address `mapstructure:"price_base"`
 }
 
 // Governance represents the governance module configuration.
@@ -166,3 +681,50 @@ type GovernanceContract struct {
 type DeFiFLend struct {
 	LendingPool common.Address `mapstructure:"lending_pool"`
 }
+
+// TokenRisk represents the configuration of heuristic ERC20 token risk flags.
+type TokenRisk struct {
+	// KnownTokens is a list of trusted tokens other tokens
+	// are compared with to detect mimicking names and symbols.
+	KnownTokens []common.Address `mapstructure:"known"`
+
+	// AirdropRecipients is the minimal number of distinct recipients
+	// of a single sender to consider the distribution an airdrop.
+	AirdropRecipients int64 `mapstructure:"airdrop_recipients"`
+
+	// AirdropShare is the minimal share of all the token transfers
+	// made by the single sender to consider the distribution an airdrop.
+	AirdropShare float64 `mapstructure:"airdrop_share"`
+}
+
+// ApprovalRisk represents the weights of the heuristic risk score
+// of active ERC20 approvals granted by an account.
+type ApprovalRisk struct {
+	// Approval is the base score of any active approval.
+	Approval float64 `mapstructure:"approval"`
+
+	// Exposure is the score of an approval covering the whole balance of the owner;
+	// smaller approvals score proportionally less.
+	Exposure float64 `mapstructure:"exposure"`
+
+	// Unlimited is the extra score of an unlimited approval.
+	Unlimited float64 `mapstructure:"unlimited"`
+
+	// Unverified multiplies the score of approvals to spenders without a verified contract source.
+	Unverified float64 `mapstructure:"unverified"`
+
+	// Flagged multiplies the score of approvals to spenders flagged by the token risk heuristics.
+	Flagged float64 `mapstructure:"flagged"`
+
+	// Medium is the minimal total score labeled as a medium risk.
+	Medium float64 `mapstructure:"medium"`
+
+	// High is the minimal total score labeled as a high risk.
+	High float64 `mapstructure:"high"`
+}
+
+// Metrics represents the configuration of the Prometheus metrics endpoint.
+type Metrics struct {
+	// Bind is the address the metrics endpoint listens on; empty disables the endpoint.
+	Bind string `mapstructure:"bind"`
+}
@@ -30,6 +30,49 @@ const (
 	defHeaderTimeout   = 1
 	defResolverTimeout = 30
 
+	// defShutdownGracePeriod holds default number of seconds the requests in flight
+	// are allowed to finish on the server shutdown
+	defShutdownGracePeriod = 30
+
+	// defPeerFallbackHealthCheck holds default interval between two health checks of the API peers
+	defPeerFallbackHealthCheck = 15 * time.Second
+
+	// defPeerFallbackMaxLag holds default number of blocks the local node can be behind the API peers
+	defPeerFallbackMaxLag = 10
+
+	// defPeerFallbackMaxAge holds default max age of an accepted signature of an API peer response
+	defPeerFallbackMaxAge = time.Minute
+
+	// defPeerFallbackTimeout holds default deadline of a single API peer call
+	defPeerFallbackTimeout = 5 * time.Second
+
+	// defHttp2MaxStreams holds default max number of concurrent streams per HTTP/2 connection
+	defHttp2MaxStreams = 250
+
+	// defRetryAfter holds default number of seconds shed clients should back off
+	defRetryAfter = 5
+
+	// defTrxSubmitLimit holds default max number of transactions submitted per client per minute
+	defTrxSubmitLimit = 30
+
+	// defMaxVariablesSize holds default max size of GraphQL request variables in bytes
+	defMaxVariablesSize = 64 * 1024
+
+	// defMaxVariablesDepth holds default max nesting depth of GraphQL request variables
+	defMaxVariablesDepth = 10
+
+	// defMaxQueryComplexity holds default max estimated cost of a GraphQL query
+	defMaxQueryComplexity = 10000
+
+	// defPersistedQueries holds default max number of registered persisted queries
+	defPersistedQueries = 2000
+
+	// defRateLimitPerSecond holds default sustained number of requests per second of a client
+	defRateLimitPerSecond = 25
+
+	// defRateLimitBurst holds default number of requests a client can make at once
+	defRateLimitBurst = 50
+
 	// defServerDomain holds default API server domain address
 	defServerDomain = "localhost:16761"
 
@@ -41,24 +84,116 @@ const (
 	// defLoggingFormat holds default format of the Logger output
 	defLoggingFormat = "%{color}%{level:-8s} %{shortpkg}/%{shortfunc}%{color:reset}: %{message}"
 
+	// defLoggingFilePath holds default path of the log file output
+	defLoggingFilePath = "motif-api.log"
+
+	// defLoggingFileMaxSize holds default size of the log file in MB triggering the rotation
+	defLoggingFileMaxSize = 100
+
+	// defLoggingFileMaxFiles holds default number of rotated log files retained
+	defLoggingFileMaxFiles = 5
+
+	// defLoggingSyslogTag holds default tag of the syslog output records
+	defLoggingSyslogTag = "motif-api"
+
 	// defLachesisUrl holds default Lachesis connection string
 	defLachesisUrl = "~/.lachesis/data/lachesis.ipc"
 
+	// defRpcMaxConcurrency holds default number of concurrent calls of a batch issued to the node
+	defRpcMaxConcurrency = 8
+
+	// defRpcRetryAttempts holds default max number of attempts of a node call failed on a transient error
+	defRpcRetryAttempts = 3
+
+	// defRpcRetryBackoff holds default delay before the first retry of a failed node call
+	defRpcRetryBackoff = 200 * time.Millisecond
+
+	// defRpcHealthCheck holds default interval of the node endpoints health check pings
+	defRpcHealthCheck = 5 * time.Second
+
 	// defMongoUrl holds default MongoDB connection string
 	defMongoUrl = "mongodb://localhost:27017"
 
 	// defMongoDatabase holds the default name of the API persistent database
 	defMongoDatabase = "motif"
 
+	// defMongoMaxPoolSize holds default max number of connections in the database pool
+	defMongoMaxPoolSize = 100
+
+	// defMongoMinPoolSize holds default min number of connections kept in the database pool
+	defMongoMinPoolSize = 0
+
+	// defMongoConnectTimeout holds default max duration of opening a database connection
+	defMongoConnectTimeout = 10 * time.Second
+
+	// defMongoServerSelectionTimeout holds default max duration of finding an available
+	// database server; it must be shorter than the resolver timeout to fail fast
+	defMongoServerSelectionTimeout = 5 * time.Second
+
 	// defCacheEvictionTime holds default time for in-memory eviction periods
 	defCacheEvictionTime = 15 * time.Minute
 
 	// defCacheMax size represents the default max size of the cache in MB
 	defCacheMaxSize = 4096
 
+	// defCacheBalanceTTL holds default lifetime of cached balances
+	defCacheBalanceTTL = 5 * time.Second
+
+	// defCachePriceTTL holds default lifetime of cached prices
+	defCachePriceTTL = 5 * time.Minute
+
+	// defCacheBypassLimit represents the default max number of forced fresh reads per client per minute
+	defCacheBypassLimit = 30
+
+	// defReorgRetention holds default max number of chain reorg records kept
+	defReorgRetention = 1000
+
+	// defLogsMaxRange holds default max number of blocks covered by a single logs query
+	defLogsMaxRange = 10000
+
+	// defLogsChunkSize holds default number of blocks requested from the node at once by a logs query
+	defLogsChunkSize = 2000
+
+	// defValueMaxBits holds default sanity bound of token amounts in bits;
+	// 2^192 is way above 10^48, e.g. a supply of 10^30 tokens with 18 decimals
+	defValueMaxBits = 192
+
+	// defGasPriceCache holds default max age of the cached suggested gas price
+	defGasPriceCache = 3 * time.Second
+
+	// defGasPricePercentile holds default percentile of the block gas prices of the percentile mode
+	defGasPricePercentile = 60
+
+	// defGasPriceBlocks holds default number of the recent blocks the gas price is derived from
+	defGasPriceBlocks = 20
+
+	// defTokenPriceCache holds default max age of the cached oracle price of a token
+	defTokenPriceCache = 30 * time.Second
+
+	// defAuthJwksRefresh holds default interval of JWKS keys refresh
+	defAuthJwksRefresh = 15 * time.Minute
+
+	// defAuthRoleClaim holds default name of the JWT claim carrying client roles
+	defAuthRoleClaim = "role"
+
+	// defAuthRedaction holds default mode of restricted fields redaction
+	defAuthRedaction = RedactionOff
+
+	// defAuthAnonymousSubscriptions holds default permission of subscriptions without credentials
+	defAuthAnonymousSubscriptions = true
+
 	// defSolCompilerPath represents the default SOL compiler path
 	defSolCompilerPath = "/usr/bin/solc"
 
+	// defAbiSourceTimeout represents the default max time we wait for a remote ABI
+	defAbiSourceTimeout = 5 * time.Second
+
+	// defFxRefresh represents the default period of the remote exchange rates refresh
+	defFxRefresh = time.Hour
+
+	// defFxTimeout represents the default max time we wait for the remote exchange rates
+	defFxTimeout = 5 * time.Second
+
 	// defApiStateOrigin represents the default origin used for API state syncing
 	defApiStateOrigin = "https://localhost"
 
@@ -77,11 +212,84 @@ const (
 	// defDefiFMintAddressProvider represents the address of the fMintAddressProvider
 	defDefiUniswapRouter = EmptyAddress
 
+	// defDefiUniswapPriceBase represents the base token of DEX spot prices, none by default
+	defDefiUniswapPriceBase = EmptyAddress
+
+	// defFMintRiskBuffer represents the margin above the minimal collateral ratio
+	// in percent an fMint position is considered at risk of liquidation within
+	defFMintRiskBuffer = 10
+
 	// defTokenLogoFilePath represents the default path to the tokens map file
 	defTokenLogoFilePath = "tokens.json"
 
 	// defBlockScanRescanDepth represents the amount of blocks re-scanned on server start
 	defBlockScanRescanDepth = 200
+
+	// defBlockScanMaxReorgDepth represents the max depth of a chain reorg detected on server start
+	defBlockScanMaxReorgDepth = 1000
+
+	// defTokenRiskAirdropRecipients represents the default number of distinct recipients
+	// of a single sender we consider to be a mass airdrop
+	defTokenRiskAirdropRecipients = 500
+
+	// defTokenRiskAirdropShare represents the default share of token transfers
+	// made by a single sender we consider to be a mass airdrop
+	defTokenRiskAirdropShare = 0.8
+
+	// defApprovalRiskApproval represents the default base score of an active approval
+	defApprovalRiskApproval = 1.0
+
+	// defApprovalRiskExposure represents the default score of an approval covering the whole balance
+	defApprovalRiskExposure = 2.0
+
+	// defApprovalRiskUnlimited represents the default extra score of an unlimited approval
+	defApprovalRiskUnlimited = 5.0
+
+	// defApprovalRiskUnverified represents the default multiplier of approvals to unverified spenders
+	defApprovalRiskUnverified = 2.0
+
+	// defApprovalRiskFlagged represents the default multiplier of approvals to flagged spenders
+	defApprovalRiskFlagged = 4.0
+
+	// defApprovalRiskMedium represents the default minimal score of a medium risk
+	defApprovalRiskMedium = 5.0
+
+	// defApprovalRiskHigh represents the default minimal score of a high risk
+	defApprovalRiskHigh = 15.0
+
+	// defTrxEtaBaseBlocks represents the default number of blocks a transaction
+	// paying the suggested gas price waits for confirmation
+	defTrxEtaBaseBlocks = 2
+
+	// defTrxEtaMaxBlocks represents the default max number of blocks
+	// the confirmation estimate can reach
+	defTrxEtaMaxBlocks = 600
+
+	// defTrxEtaExponent represents the default sensitivity of the confirmation
+	// estimate to the gas price paid below the suggested price
+	defTrxEtaExponent = 2.0
+
+	// defTrxEtaBlockInterval represents the default block interval in seconds
+	// used if the chain metrics are not available yet
+	defTrxEtaBlockInterval = 1.0
+
+	// defPriceSnapshotInterval represents the default time between two DeFi token price snapshots
+	defPriceSnapshotInterval = 15 * time.Minute
+
+	// defSupplySnapshotMaxTokens represents the default max number of tokens in an ERC20 supply snapshot
+	defSupplySnapshotMaxTokens = 200
+
+	// defFaucetAmount represents the default amount of native tokens sent by the faucet, 1 FTM
+	defFaucetAmount = "1000000000000000000"
+
+	// defFaucetCooldown represents the default min time between two faucet requests of an address
+	defFaucetCooldown = 24 * time.Hour
+
+	// defViewsTrxFlow represents the default time between two transaction flow updates
+	defViewsTrxFlow = 7 * time.Minute
+
+	// defViewsTrxCount represents the default time between two transactions count estimate updates
+	defViewsTrxCount = 30 * time.Minute
 )
 
 // default list of API peers
@@ -90,9 +298,24 @@ var defApiPeers = []string{"https://localhost:16761/api"}
 // defCorsAllowOrigins holds CORS default allowed origins.
 var defCorsAllowOrigins = []string{"*"}
 
+// defCorsAllowMethods holds CORS default allowed methods.
+var defCorsAllowMethods = []string{"GET", "POST", "OPTIONS"}
+
+// defCorsAllowHeaders holds CORS default allowed request headers.
+var defCorsAllowHeaders = []string{"Origin", "Accept", "Content-Type", "X-Requested-With"}
+
+// defCorsMaxAge represents the default number of seconds a CORS preflight response is cached
+const defCorsMaxAge = 600
+
 // default list of API peers
 var defVotingSources = make([]string, 0)
 
+// defSignedFields holds the default list of root query fields with signed responses.
+var defSignedFields = make([]string, 0)
+
+// defTokenRiskKnown holds the default list of known tokens used for mimic detection.
+var defTokenRiskKnown = make([]string, 0)
+
 // defERC20Logo defines default no-URL value for ERC20 logo list
 var defERC20Logo = map[common.Address]string{
 	common.HexToAddress(EmptyAddress): "https://i.ibb.co/RNLvGqm/symbol.png",
@@ -102,16 +325,37 @@ var defERC20Logo = map[common.Address]string{
 func applyDefaults(cfg *viper.Viper) {
 	// set simple details
 	cfg.SetDefault(keyAppName, defApplicationName)
+	cfg.SetDefault(keyBlockScanMaxReorgDepth, defBlockScanMaxReorgDepth)
 	cfg.SetDefault(keyBindAddress, defServerBind)
 	cfg.SetDefault(keyDomainAddress, defServerDomain)
 	cfg.SetDefault(keySignatureAddress, defSelfAddress)
 	cfg.SetDefault(keySignaturePrivateKey, defSelfPrivateKey)
 	cfg.SetDefault(keyLoggingLevel, defLoggingLevel)
 	cfg.SetDefault(keyLoggingFormat, defLoggingFormat)
+	cfg.SetDefault(keyLoggingJSON, false)
+	cfg.SetDefault(keyLoggingOutputs, []string{LogOutputStderr})
+	cfg.SetDefault(keyLoggingFilePath, defLoggingFilePath)
+	cfg.SetDefault(keyLoggingFileMaxSize, defLoggingFileMaxSize)
+	cfg.SetDefault(keyLoggingFileMaxFiles, defLoggingFileMaxFiles)
+	cfg.SetDefault(keyLoggingSyslogTag, defLoggingSyslogTag)
 	cfg.SetDefault(keyLachesisUrl, defLachesisUrl)
+	cfg.SetDefault(keyRpcMaxConcurrency, defRpcMaxConcurrency)
+	cfg.SetDefault(keyRpcRetryAttempts, defRpcRetryAttempts)
+	cfg.SetDefault(keyRpcRetryBackoff, defRpcRetryBackoff)
+	cfg.SetDefault(keyRpcHealthCheck, defRpcHealthCheck)
+	cfg.SetDefault(keyLachesisUrls, []string{})
 	cfg.SetDefault(keyMongoUrl, defMongoUrl)
 	cfg.SetDefault(keyMongoDatabase, defMongoDatabase)
+	cfg.SetDefault(keyMongoMaxPoolSize, defMongoMaxPoolSize)
+	cfg.SetDefault(keyMongoMinPoolSize, defMongoMinPoolSize)
+	cfg.SetDefault(keyMongoConnectTimeout, defMongoConnectTimeout)
+	cfg.SetDefault(keyMongoServerSelectionTimeout, defMongoServerSelectionTimeout)
 	cfg.SetDefault(keySolCompilerPath, defSolCompilerPath)
+	cfg.SetDefault(keyAbiSourceEnabled, false)
+	cfg.SetDefault(keyAbiSourceTimeout, defAbiSourceTimeout)
+	cfg.SetDefault(keyFxRefresh, defFxRefresh)
+	cfg.SetDefault(keyFxTimeout, defFxTimeout)
+	cfg.SetDefault(keyMetricsBind, "")
 	cfg.SetDefault(keyApiPeers, defApiPeers)
 	cfg.SetDefault(keyApiStateOrigin, defApiStateOrigin)
 	cfg.SetDefault(keyErc20TokenMapFilePath, defTokenLogoFilePath)
@@ -120,6 +364,33 @@ func applyDefaults(cfg *viper.Viper) {
 	// in-memory cache
 	cfg.SetDefault(keyCacheEvictionTime, defCacheEvictionTime)
 	cfg.SetDefault(keyCacheMaxSize, defCacheMaxSize)
+	cfg.SetDefault(keyCacheBypassLimit, defCacheBypassLimit)
+	cfg.SetDefault(keyCacheBalanceTTL, defCacheBalanceTTL)
+	cfg.SetDefault(keyCachePriceTTL, defCachePriceTTL)
+
+	// chain reorg history
+	cfg.SetDefault(keyRepositoryReorgRetention, defReorgRetention)
+
+	// live logs queries
+	cfg.SetDefault(keyRepositoryLogsMaxRange, defLogsMaxRange)
+	cfg.SetDefault(keyRepositoryLogsChunkSize, defLogsChunkSize)
+	cfg.SetDefault(keyRepositoryIndexLogs, false)
+
+	// token amounts sanity bound
+	cfg.SetDefault(keyRepositoryValueMaxBits, defValueMaxBits)
+	cfg.SetDefault(keyRepositoryAcceptKnownTrx, true)
+	cfg.SetDefault(keyRepositoryTolerantErc20, true)
+	cfg.SetDefault(keyRepositoryGasPriceCache, defGasPriceCache)
+	cfg.SetDefault(keyRepositoryTokenPriceCache, defTokenPriceCache)
+	cfg.SetDefault(keyGasPriceMode, GasPriceModeNode)
+	cfg.SetDefault(keyGasPricePercentile, defGasPricePercentile)
+	cfg.SetDefault(keyGasPriceBlocks, defGasPriceBlocks)
+
+	// clients authentication
+	cfg.SetDefault(keyAuthJwksRefresh, defAuthJwksRefresh)
+	cfg.SetDefault(keyAuthRoleClaim, defAuthRoleClaim)
+	cfg.SetDefault(keyAuthRedaction, defAuthRedaction)
+	cfg.SetDefault(keyAuthAnonymousSubscriptions, defAuthAnonymousSubscriptions)
 
 	// server timeouts
 	cfg.SetDefault(keyTimeoutRead, defReadTimeout)
@@ -127,12 +398,52 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyTimeoutHeader, defHeaderTimeout)
 	cfg.SetDefault(keyTimeoutIdle, defIdleTimeout)
 	cfg.SetDefault(keyTimeoutResolver, defResolverTimeout)
+	cfg.SetDefault(keyShutdownGracePeriod, defShutdownGracePeriod)
+
+	// server connections; HTTP/2 is enabled, but used only with TLS, or h2c explicitly enabled
+	cfg.SetDefault(keyMaxConnections, 0)
+	cfg.SetDefault(keyHttp2Enabled, true)
+	cfg.SetDefault(keyHttp2MaxStreams, defHttp2MaxStreams)
+
+	// load shedding is disabled by default
+	cfg.SetDefault(keyMaxInFlight, 0)
+	cfg.SetDefault(keyRetryAfter, defRetryAfter)
+
+	// transactions submit limit
+	cfg.SetDefault(keyTrxSubmitLimit, defTrxSubmitLimit)
+
+	// request variables limits
+	cfg.SetDefault(keyMaxVariablesSize, defMaxVariablesSize)
+	cfg.SetDefault(keyMaxVariablesDepth, defMaxVariablesDepth)
+	cfg.SetDefault(keyMaxQueryComplexity, defMaxQueryComplexity)
+	cfg.SetDefault(keyPersistedQueries, defPersistedQueries)
+	cfg.SetDefault(keyRateLimitPerSecond, defRateLimitPerSecond)
+	cfg.SetDefault(keyRateLimitBurst, defRateLimitBurst)
+
+	// schema introspection is enabled, the SDL end-point follows it
+	cfg.SetDefault(keyDisableIntrospection, false)
+	cfg.SetDefault(keyAllowRawCall, false)
+	cfg.SetDefault(keySchemaSDL, SchemaSDLAuto)
+
+	// responses are not signed by default
+	cfg.SetDefault(keySignedFields, defSignedFields)
+
+	// queries are not proxied to the API peers by default
+	cfg.SetDefault(keyPeerFallbackEnabled, false)
+	cfg.SetDefault(keyPeerFallbackSigners, make([]string, 0))
+	cfg.SetDefault(keyPeerFallbackHealthCheck, defPeerFallbackHealthCheck)
+	cfg.SetDefault(keyPeerFallbackMaxLag, defPeerFallbackMaxLag)
+	cfg.SetDefault(keyPeerFallbackMaxAge, defPeerFallbackMaxAge)
+	cfg.SetDefault(keyPeerFallbackTimeout, defPeerFallbackTimeout)
 
 	// no voting sources by default
 	cfg.SetDefault(keyVotingSources, defVotingSources)
 
 	// cors
 	cfg.SetDefault(keyCorsAllowOrigins, defCorsAllowOrigins)
+	cfg.SetDefault(keyCorsAllowMethods, defCorsAllowMethods)
+	cfg.SetDefault(keyCorsAllowHeaders, defCorsAllowHeaders)
+	cfg.SetDefault(keyCorsMaxAge, defCorsMaxAge)
 
 	// staking configuration defaults
 	cfg.SetDefault(keyStakingSfcContract, defSfcContract)
@@ -144,4 +455,46 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyDefiFMintAddressProvider, defDefiFMintAddressProvider)
 	cfg.SetDefault(keyDefiUniswapCore, defDefiUniswapCore)
 	cfg.SetDefault(keyDefiUniswapRouter, defDefiUniswapRouter)
+	cfg.SetDefault(keyDefiUniswapPriceBase, defDefiUniswapPriceBase)
+	cfg.SetDefault(keyFMintRiskBuffer, defFMintRiskBuffer)
+
+	// ERC20 token risk heuristics
+	cfg.SetDefault(keyTokenRiskKnown, defTokenRiskKnown)
+	cfg.SetDefault(keyTokenRiskAirdropRecipients, defTokenRiskAirdropRecipients)
+	cfg.SetDefault(keyTokenRiskAirdropShare, defTokenRiskAirdropShare)
+
+	// account approval risk score
+	cfg.SetDefault(keyApprovalRiskApproval, defApprovalRiskApproval)
+	cfg.SetDefault(keyApprovalRiskExposure, defApprovalRiskExposure)
+	cfg.SetDefault(keyApprovalRiskUnlimited, defApprovalRiskUnlimited)
+	cfg.SetDefault(keyApprovalRiskUnverified, defApprovalRiskUnverified)
+	cfg.SetDefault(keyApprovalRiskFlagged, defApprovalRiskFlagged)
+	cfg.SetDefault(keyApprovalRiskMedium, defApprovalRiskMedium)
+	cfg.SetDefault(keyApprovalRiskHigh, defApprovalRiskHigh)
+
+	// pending transaction confirmation estimate
+	cfg.SetDefault(keyTrxEtaBaseBlocks, defTrxEtaBaseBlocks)
+	cfg.SetDefault(keyTrxEtaMaxBlocks, defTrxEtaMaxBlocks)
+	cfg.SetDefault(keyTrxEtaExponent, defTrxEtaExponent)
+	cfg.SetDefault(keyTrxEtaBlockInterval, defTrxEtaBlockInterval)
+
+	// DeFi token price snapshots
+	cfg.SetDefault(keyPriceSnapshotEnabled, false)
+	cfg.SetDefault(keyPriceSnapshotInterval, defPriceSnapshotInterval)
+
+	// ERC20 token supply snapshots are disabled by default
+	cfg.SetDefault(keySupplySnapshotInterval, 0)
+	cfg.SetDefault(keySupplySnapshotMaxTokens, defSupplySnapshotMaxTokens)
+
+	// testnet faucet is disabled by default
+	cfg.SetDefault(keyFaucetEnabled, false)
+	cfg.SetDefault(keyFaucetAmount, defFaucetAmount)
+	cfg.SetDefault(keyFaucetCooldown, defFaucetCooldown)
+
+	// name registry is not configured by default
+	cfg.SetDefault(keyNameRegistryContract, EmptyAddress)
+
+	// materialized views refresh
+	cfg.SetDefault(keyViewsTrxFlow, defViewsTrxFlow)
+	cfg.SetDefault(keyViewsTrxCount, defViewsTrxCount)
 }
@@ -12,6 +12,7 @@ const (
 	keyConfigCmdBlockScanEnd    = "cmd.blk_to"
 	keyConfigCmdBlockScanReScan = "cmd.rescan"
 	keyConfigCmdRestoreStake    = "cmd.fix_stake"
+	keyBlockScanMaxReorgDepth   = "cmd.max_reorg_depth"
 
 	// server related keys
 	keyBindAddress      = "server.bind"
@@ -20,6 +21,11 @@ const (
 	keyApiStateOrigin   = "server.origin"
 	keyCorsAllowOrigins = "server.cors_origins"
 
+	// server CORS policy related keys
+	keyCorsAllowMethods = "server.cors_methods"
+	keyCorsAllowHeaders = "server.cors_headers"
+	keyCorsMaxAge       = "server.cors_max_age"
+
 	// server time out related keys
 	keyTimeoutRead     = "server.read_timeout"
 	keyTimeoutWrite    = "server.write_timeout"
@@ -27,28 +33,121 @@ const (
 	keyTimeoutHeader   = "server.header_timeout"
 	keyTimeoutResolver = "server.resolver_timeout"
 
+	// server shutdown related keys
+	keyShutdownGracePeriod = "server.shutdown_grace_period"
+
+	// server connections related keys
+	keyMaxConnections  = "server.max_connections"
+	keyHttp2Enabled    = "server.http2.enabled"
+	keyHttp2MaxStreams = "server.http2.max_streams"
+	keyMaxInFlight     = "server.max_inflight"
+	keyRetryAfter      = "server.retry_after"
+	keyTrxSubmitLimit  = "server.trx_submit_limit"
+
+	// server request variables related keys
+	keyMaxVariablesSize  = "server.max_variables_size"
+	keyMaxVariablesDepth = "server.max_variables_depth"
+
+	// server query complexity related keys
+	keyMaxQueryComplexity = "server.max_query_complexity"
+
+	// server persisted queries related keys
+	keyPersistedQueries = "server.persisted_queries"
+
+	// server clients rate limiting related keys
+	keyRateLimitPerSecond = "server.rate_limit_per_second"
+	keyRateLimitBurst     = "server.rate_limit_burst"
+	keyTrustedProxyHeader = "server.trusted_proxy_header"
+
+	// server schema exposure related keys
+	keyDisableIntrospection = "server.disable_introspection"
+	keyAllowRawCall         = "server.allow_raw_call"
+	keySchemaSDL            = "server.schema_sdl"
+
+	// server response attestation related keys
+	keySignedFields = "server.signed_fields"
+
+	// server peer fallback related keys
+	keyPeerFallbackEnabled     = "server.peer_fallback.enabled"
+	keyPeerFallbackSigners     = "server.peer_fallback.signers"
+	keyPeerFallbackHealthCheck = "server.peer_fallback.health_check_interval"
+	keyPeerFallbackMaxLag      = "server.peer_fallback.max_lag"
+	keyPeerFallbackMaxAge      = "server.peer_fallback.max_age"
+	keyPeerFallbackTimeout     = "server.peer_fallback.timeout"
+
 	// API server signature related keys
 	keySignatureAddress    = "me.address"
 	keySignaturePrivateKey = "me.pkey"
 
 	// logging related options
-	keyLoggingLevel  = "log.level"
-	keyLoggingFormat = "log.format"
+	keyLoggingLevel        = "log.level"
+	keyLoggingFormat       = "log.format"
+	keyLoggingJSON         = "log.json"
+	keyLoggingOutputs      = "log.outputs"
+	keyLoggingFilePath     = "log.file.path"
+	keyLoggingFileMaxSize  = "log.file.max_size"
+	keyLoggingFileMaxFiles = "log.file.max_files"
+	keyLoggingSyslogTag    = "log.syslog_tag"
 
 	// node connection related options
-	keyLachesisUrl = "lachesis.url"
+	keyLachesisUrl       = "lachesis.url"
+	keyLachesisUrls      = "node.urls"
+	keyRpcMaxConcurrency = "node.max_concurrency"
+	keyRpcRetryAttempts  = "node.retry_attempts"
+	keyRpcRetryBackoff   = "node.retry_backoff"
+	keyRpcHealthCheck    = "node.health_check_interval"
 
 	// off-chain database related options
-	keyMongoUrl      = "db.url"
-	keyMongoDatabase = "db.db"
+	keyMongoUrl                    = "db.url"
+	keyMongoDatabase               = "db.db"
+	keyMongoMaxPoolSize            = "db.max_pool_size"
+	keyMongoMinPoolSize            = "db.min_pool_size"
+	keyMongoConnectTimeout         = "db.connect_timeout"
+	keyMongoServerSelectionTimeout = "db.server_selection_timeout"
 
 	// cache related options
 	keyCacheEvictionTime = "cache.eviction"
 	keyCacheMaxSize      = "cache.size"
+	keyCacheBypassLimit  = "cache.bypass_limit"
+	keyCacheBalanceTTL   = "cache.balance_ttl"
+	keyCachePriceTTL     = "cache.price_ttl"
+
+	// clients authentication related
+	keyAuthJwksRefresh            = "auth.jwks_refresh"
+	keyAuthRoleClaim              = "auth.role_claim"
+	keyAuthRedaction              = "auth.redaction"
+	keyAuthAnonymousSubscriptions = "auth.anonymous_subscriptions"
+
+	// repository related
+	keyRepositoryReorgRetention  = "repository.reorg_retention"
+	keyRepositoryLogsMaxRange    = "repository.logs_max_range"
+	keyRepositoryLogsChunkSize   = "repository.logs_chunk_size"
+	keyRepositoryIndexLogs       = "repository.index_logs"
+	keyRepositoryValueMaxBits    = "repository.value_max_bits"
+	keyRepositoryAcceptKnownTrx  = "repository.accept_known_trx"
+	keyRepositoryTolerantErc20   = "repository.tolerant_erc20"
+	keyRepositoryGasPriceCache   = "repository.gas_price_cache"
+	keyRepositoryTokenPriceCache = "repository.token_price_cache"
+
+	// gas price suggestion
+	keyGasPriceMode       = "repository.gas_price_mode"
+	keyGasPricePercentile = "repository.gas_price_percentile"
+	keyGasPriceBlocks     = "repository.gas_price_blocks"
 
 	// contract validation related
 	keySolCompilerPath = "compiler.sol"
 
+	// remote ABI source
+	keyAbiSourceEnabled = "abi_source.enabled"
+	keyAbiSourceTimeout = "abi_source.timeout"
+
+	// currency exchange rates
+	keyFxRefresh = "fx.refresh"
+	keyFxTimeout = "fx.timeout"
+
+	// metrics endpoint
+	keyMetricsBind = "metrics.bind"
+
 	// utility options
 	keyVotingSources         = "voting.sources"
 	keyErc20TokenMapFilePath = "erc20_tokens_file"
@@ -64,4 +163,46 @@ const (
 	keyDefiFMintAddressProvider = "defi.fmint.address_provider"
 	keyDefiUniswapCore          = "defi.uniswap.core"
 	keyDefiUniswapRouter        = "defi.uniswap.router"
+	keyDefiUniswapPriceBase     = "defi.uniswap.price_base"
+	keyFMintRiskBuffer          = "defi.fmint.risk_buffer"
+
+	// ERC20 token risk heuristics
+	keyTokenRiskKnown             = "token_risk.known"
+	keyTokenRiskAirdropRecipients = "token_risk.airdrop_recipients"
+	keyTokenRiskAirdropShare      = "token_risk.airdrop_share"
+
+	// account approval risk score weights
+	keyApprovalRiskApproval   = "approval_risk.approval"
+	keyApprovalRiskExposure   = "approval_risk.exposure"
+	keyApprovalRiskUnlimited  = "approval_risk.unlimited"
+	keyApprovalRiskUnverified = "approval_risk.unverified"
+	keyApprovalRiskFlagged    = "approval_risk.flagged"
+	keyApprovalRiskMedium     = "approval_risk.medium"
+	keyApprovalRiskHigh       = "approval_risk.high"
+
+	// pending transaction confirmation estimate heuristics
+	keyTrxEtaBaseBlocks    = "trx_eta.base_blocks"
+	keyTrxEtaMaxBlocks     = "trx_eta.max_blocks"
+	keyTrxEtaExponent      = "trx_eta.exponent"
+	keyTrxEtaBlockInterval = "trx_eta.block_interval"
+
+	// DeFi token price snapshots
+	keyPriceSnapshotEnabled  = "price_snapshot.enabled"
+	keyPriceSnapshotInterval = "price_snapshot.interval"
+
+	// ERC20 token supply snapshots
+	keySupplySnapshotInterval  = "supply_snapshot.interval"
+	keySupplySnapshotMaxTokens = "supply_snapshot.max_tokens"
+
+	// testnet faucet
+	keyFaucetEnabled  = "faucet.enabled"
+	keyFaucetAmount   = "faucet.amount"
+	keyFaucetCooldown = "faucet.cooldown"
+
+	// ENS-style names
+	keyNameRegistryContract = "name_registry.contract"
+
+	// materialized views refresh
+	keyViewsTrxFlow  = "views.trx_flow"
+	keyViewsTrxCount = "views.trx_count"
 )
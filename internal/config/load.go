@@ -11,10 +11,16 @@ import (
 	"github.com/spf13/viper"
 	"io/ioutil"
 	"log"
+	"math"
+	"math/big"
 	"os"
 	"reflect"
 )
 
+// loaded is the configuration reader used to load the configuration;
+// it's kept to be able to re-read the configuration file on reload.
+var loaded *viper.Viper
+
 // Load provides a loaded configuration for Motif API server.
 func Load() (*Config, error) {
 	// Get the config reader
@@ -25,6 +31,7 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	loaded = cfg
 
 	// prep the container and try to unmarshal
 	// the config file into the config structure
@@ -34,6 +41,107 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	// validate the server settings
+	if err = validateServer(&config.Server); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the response attestation
+	if err = validateAttestation(&config); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the testnet faucet
+	if err = validateFaucet(&config); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the database connection pool
+	if err = validateDb(&config.Db); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the auth settings
+	if err = validateAuth(&config.Auth); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the log outputs
+	if err = validateLog(&config.Log); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the remote ABI source
+	if config.AbiSource.Enabled && config.AbiSource.Url == "" {
+		log.Println("invalid API server configuration")
+		log.Println("remote ABI source enabled without the URL")
+		return nil, fmt.Errorf("missing remote ABI source URL")
+	}
+
+	// validate the fMint liquidation risk buffer
+	if config.DeFi.FMint.RiskBuffer < 0 {
+		log.Println("invalid API server configuration")
+		log.Println("fMint risk buffer must not be negative")
+		return nil, fmt.Errorf("invalid fMint risk buffer %f", config.DeFi.FMint.RiskBuffer)
+	}
+
+	// validate the exchange rates
+	if err = validateFx(&config.Fx); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the repository
+	if err = validateRepository(&config.Repository); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the views refresh
+	if err = validateViews(&config); err != nil {
+		log.Println("invalid API server configuration")
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// validate the node access
+	if config.Lachesis.MaxConcurrency <= 0 {
+		log.Println("invalid API server configuration")
+		log.Println("node calls concurrency must be positive")
+		return nil, fmt.Errorf("invalid node calls concurrency %d", config.Lachesis.MaxConcurrency)
+	}
+	if config.Lachesis.RetryAttempts <= 0 || config.Lachesis.RetryBackoff < 0 {
+		log.Println("invalid API server configuration")
+		log.Println("node calls retry must have at least one attempt and non-negative backoff")
+		return nil, fmt.Errorf("invalid node calls retry %d / %s", config.Lachesis.RetryAttempts, config.Lachesis.RetryBackoff)
+	}
+	if config.Lachesis.HealthCheck <= 0 {
+		log.Println("invalid API server configuration")
+		log.Println("node health check interval must be positive")
+		return nil, fmt.Errorf("invalid node health check interval %s", config.Lachesis.HealthCheck)
+	}
+	for _, url := range config.Lachesis.Endpoints() {
+		if url == "" {
+			log.Println("invalid API server configuration")
+			log.Println("node endpoint must not be empty")
+			return nil, fmt.Errorf("empty node endpoint")
+		}
+	}
+
 	// try to load the logo map file
 	loadErc20LogMap(&config)
 
@@ -41,6 +149,260 @@ func Load() (*Config, error) {
 	return &config, nil
 }
 
+// ReloadServer re-reads the configuration file and provides the new HTTP server
+// configuration. The configuration must be loaded by Load first.
+func ReloadServer() (*Server, error) {
+	if loaded == nil {
+		return nil, fmt.Errorf("configuration not loaded")
+	}
+
+	// re-read the file; the defaults are already in place
+	if err := loaded.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("can not read the server configuration; %s", err.Error())
+	}
+
+	var config Config
+	if err := loaded.Unmarshal(&config, setupConfigUnmarshaler); err != nil {
+		return nil, fmt.Errorf("can not extract API server configuration; %s", err.Error())
+	}
+	if err := validateServer(&config.Server); err != nil {
+		return nil, fmt.Errorf("invalid API server configuration; %s", err.Error())
+	}
+	return &config.Server, nil
+}
+
+// validateAttestation checks the server private key is available
+// if the responses are to be signed.
+func validateAttestation(cfg *Config) error {
+	if len(cfg.Server.SignedFields) > 0 && cfg.MySignature.PrivateKey.D == nil {
+		return fmt.Errorf("signed responses require the server private key")
+	}
+	return nil
+}
+
+// validateFaucet checks the faucet has the server private key to send the funds from,
+// a positive amount to send and a non-negative cooldown, if enabled.
+func validateFaucet(cfg *Config) error {
+	if !cfg.Faucet.Enabled {
+		return nil
+	}
+	if cfg.MySignature.PrivateKey.D == nil {
+		return fmt.Errorf("faucet requires the server private key")
+	}
+	if amount, ok := new(big.Int).SetString(cfg.Faucet.Amount, 0); !ok || amount.Sign() <= 0 {
+		return fmt.Errorf("invalid faucet amount %s", cfg.Faucet.Amount)
+	}
+	if cfg.Faucet.Cooldown < 0 {
+		return fmt.Errorf("invalid faucet cooldown %s", cfg.Faucet.Cooldown)
+	}
+	return nil
+}
+
+// validatePeerFallback checks each API peer has its expected signer
+// and the peer calls timing is sane, if the peer fallback is enabled.
+func validatePeerFallback(cfg *Server) error {
+	if !cfg.PeerFallback.Enabled {
+		return nil
+	}
+	if len(cfg.Peers) == 0 || len(cfg.PeerFallback.Signers) != len(cfg.Peers) {
+		return fmt.Errorf("peer fallback needs a signer for each of %d peers, %d given", len(cfg.Peers), len(cfg.PeerFallback.Signers))
+	}
+	if cfg.PeerFallback.HealthCheck <= 0 || cfg.PeerFallback.MaxAge <= 0 || cfg.PeerFallback.Timeout <= 0 {
+		return fmt.Errorf("invalid peer fallback timing %s / %s / %s", cfg.PeerFallback.HealthCheck, cfg.PeerFallback.MaxAge, cfg.PeerFallback.Timeout)
+	}
+	return nil
+}
+
+// validateServer checks the HTTP server configuration for conflicting,
+// or out of range values.
+func validateServer(cfg *Server) error {
+	if cfg.MaxConnections < 0 {
+		return fmt.Errorf("invalid max connections %d", cfg.MaxConnections)
+	}
+
+	// TLS needs both the cert and the key
+	if (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == "") {
+		return fmt.Errorf("both TLS certificate and key files must be configured")
+	}
+	for _, fn := range []string{cfg.TLS.CertFile, cfg.TLS.KeyFile} {
+		if fn == "" {
+			continue
+		}
+		if _, err := os.Stat(fn); err != nil {
+			return fmt.Errorf("TLS file not available; %s", err.Error())
+		}
+	}
+
+	// load shedding
+	if cfg.MaxInFlight < 0 {
+		return fmt.Errorf("invalid max in-flight requests %d", cfg.MaxInFlight)
+	}
+	if cfg.MaxInFlight > 0 && cfg.RetryAfter <= 0 {
+		return fmt.Errorf("invalid retry after %d seconds", cfg.RetryAfter)
+	}
+
+	// CORS policy
+	if len(cfg.CorsMethods) == 0 {
+		return fmt.Errorf("no CORS methods allowed")
+	}
+	if cfg.CorsMaxAge < 0 {
+		return fmt.Errorf("invalid CORS max age %d", cfg.CorsMaxAge)
+	}
+
+	// peer fallback
+	if err := validatePeerFallback(cfg); err != nil {
+		return err
+	}
+
+	// graceful shutdown
+	if cfg.ShutdownGracePeriod < 0 {
+		return fmt.Errorf("invalid shutdown grace period %d", cfg.ShutdownGracePeriod)
+	}
+
+	// request variables limits
+	if cfg.MaxVariablesSize < 0 {
+		return fmt.Errorf("invalid max variables size %d", cfg.MaxVariablesSize)
+	}
+	if cfg.MaxVariablesDepth < 0 {
+		return fmt.Errorf("invalid max variables depth %d", cfg.MaxVariablesDepth)
+	}
+
+	// query complexity limit
+	if cfg.MaxQueryComplexity < 0 {
+		return fmt.Errorf("invalid max query complexity %d", cfg.MaxQueryComplexity)
+	}
+
+	// persisted queries registry
+	if cfg.PersistedQueries < 0 {
+		return fmt.Errorf("invalid persisted queries limit %d", cfg.PersistedQueries)
+	}
+
+	// clients rate limit
+	if cfg.RateLimitPerSecond < 0 {
+		return fmt.Errorf("invalid rate limit %f requests per second", cfg.RateLimitPerSecond)
+	}
+	if cfg.RateLimitPerSecond > 0 && cfg.RateLimitBurst < 1 {
+		return fmt.Errorf("invalid rate limit burst %d", cfg.RateLimitBurst)
+	}
+
+	// transactions submit limit
+	if cfg.TrxSubmitLimit < 0 {
+		return fmt.Errorf("invalid transactions submit limit %d", cfg.TrxSubmitLimit)
+	}
+
+	// HTTP/2 options
+	if cfg.Http2.Cleartext && !cfg.Http2.Enabled {
+		return fmt.Errorf("h2c requires HTTP/2 to be enabled")
+	}
+	if cfg.Http2.MaxStreams < 0 || int64(cfg.Http2.MaxStreams) > math.MaxUint32 {
+		return fmt.Errorf("invalid HTTP/2 max streams %d", cfg.Http2.MaxStreams)
+	}
+
+	// schema SDL end-point
+	switch cfg.SchemaSDL {
+	case SchemaSDLAuto, SchemaSDLOn, SchemaSDLOff:
+	default:
+		return fmt.Errorf("unknown schema SDL mode %s", cfg.SchemaSDL)
+	}
+	return nil
+}
+
+// validateAuth checks the clients authentication configuration.
+func validateAuth(cfg *Auth) error {
+	switch cfg.Redaction {
+	case RedactionOff, RedactionNull, RedactionError:
+		return nil
+	}
+	return fmt.Errorf("unknown field redaction mode %s", cfg.Redaction)
+}
+
+// validateLog checks the logger outputs configuration; the log file must be writable.
+func validateLog(cfg *Log) error {
+	if len(cfg.Outputs) == 0 {
+		return fmt.Errorf("no log output configured")
+	}
+
+	for _, out := range cfg.Outputs {
+		switch out {
+		case LogOutputStdout, LogOutputStderr, LogOutputSyslog:
+		case LogOutputFile:
+			if cfg.File.MaxSize <= 0 {
+				return fmt.Errorf("invalid log file max size %d", cfg.File.MaxSize)
+			}
+			if cfg.File.MaxFiles < 0 {
+				return fmt.Errorf("invalid log file max files %d", cfg.File.MaxFiles)
+			}
+
+			f, err := os.OpenFile(cfg.File.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				return fmt.Errorf("log file not writable; %s", err.Error())
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("log file not writable; %s", err.Error())
+			}
+		default:
+			return fmt.Errorf("unknown log output %s", out)
+		}
+	}
+	return nil
+}
+
+// validateDb checks the database connection pool configuration.
+func validateDb(cfg *Database) error {
+	if cfg.MaxPoolSize > 0 && cfg.MinPoolSize > cfg.MaxPoolSize {
+		return fmt.Errorf("invalid database pool size %d - %d", cfg.MinPoolSize, cfg.MaxPoolSize)
+	}
+	if cfg.ConnectTimeout <= 0 || cfg.ServerSelectionTimeout <= 0 {
+		return fmt.Errorf("invalid database timeouts %s / %s", cfg.ConnectTimeout, cfg.ServerSelectionTimeout)
+	}
+	return nil
+}
+
+// validateFx checks the exchange rates configuration.
+func validateFx(cfg *Fx) error {
+	for cur, rate := range cfg.Rates {
+		if rate <= 0 {
+			return fmt.Errorf("invalid exchange rate %f of %s", rate, cur)
+		}
+	}
+	if cfg.Url != "" && cfg.Refresh <= 0 {
+		return fmt.Errorf("invalid exchange rates refresh period %s", cfg.Refresh)
+	}
+	return nil
+}
+
+// validateRepository checks the gas price suggestion configuration.
+func validateRepository(cfg *Repository) error {
+	switch cfg.GasPriceMode {
+	case GasPriceModeNode:
+		return nil
+	case GasPriceModeMedian, GasPriceModePercentile:
+	default:
+		return fmt.Errorf("unknown gas price mode %s", cfg.GasPriceMode)
+	}
+	if cfg.GasPriceBlocks < 1 || cfg.GasPriceBlocks > 1024 {
+		return fmt.Errorf("invalid gas price blocks %d", cfg.GasPriceBlocks)
+	}
+	if cfg.GasPriceMode == GasPriceModePercentile && (cfg.GasPricePercentile < 0 || cfg.GasPricePercentile > 100) {
+		return fmt.Errorf("invalid gas price percentile %f", cfg.GasPricePercentile)
+	}
+	return nil
+}
+
+// validateViews checks the refresh intervals of the materialized views.
+func validateViews(cfg *Config) error {
+	if cfg.Views.TrxFlow <= 0 || cfg.Views.TrxCount <= 0 {
+		return fmt.Errorf("invalid views refresh interval")
+	}
+	if cfg.PriceSnapshot.Enabled && cfg.PriceSnapshot.Interval <= 0 {
+		return fmt.Errorf("invalid price snapshot interval %s", cfg.PriceSnapshot.Interval)
+	}
+	if cfg.SupplySnapshot.Interval > 0 && cfg.SupplySnapshot.MaxTokens <= 0 {
+		return fmt.Errorf("invalid supply snapshot max tokens %d", cfg.SupplySnapshot.MaxTokens)
+	}
+	return nil
+}
+
 // attachCliFlags connects CLI flags to certain configuration options.
 func attachCliFlags(cfg *Config) {
 	flag.Uint64Var(&cfg.RepoCommand.BlockScanReScan, keyConfigCmdBlockScanReScan, defBlockScanRescanDepth, "How many blocks are re-scanned on the server start.")
@@ -83,38 +445,47 @@ func loadErc20LogMap(cfg *Config) {
 		return
 	}
 
-	// try to open the file
-	f, err := os.Open(cfg.TokenLogoFilePath)
+	// inform about tokens loading
+	log.Printf("loading ERC20 tokens from %s", cfg.TokenLogoFilePath)
+
+	logos, err := ReadErc20LogoMap(cfg.TokenLogoFilePath)
 	if err != nil {
-		log.Printf("can not open ERC20 tokens map file; %s", err.Error())
+		log.Print(err.Error())
 		return
 	}
+	cfg.TokenLogo = logos
+
+	// inform about tokens
+	log.Printf("found %d ERC20 tokens", len(cfg.TokenLogo))
+}
+
+// ReadErc20LogoMap reads the map of ERC20 token logos from the given JSON file.
+func ReadErc20LogoMap(path string) (map[common.Address]string, error) {
+	// try to open the file
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("can not open ERC20 tokens map file; %s", err.Error())
+	}
 
 	// make sure to close the file
 	defer func() {
 		if err := f.Close(); err != nil {
-			log.Printf("config file can not be closed; %s", err.Error())
+			log.Printf("ERC20 tokens map file can not be closed; %s", err.Error())
 		}
 	}()
 
-	// inform about tokens loading
-	log.Printf("loading ERC20 tokens from %s", cfg.TokenLogoFilePath)
-
 	// read the whole file
 	data, err := ioutil.ReadAll(f)
 	if err != nil {
-		log.Printf("can not read ERC20 tokens map file; %s", err.Error())
-		return
+		return nil, fmt.Errorf("can not read ERC20 tokens map file; %s", err.Error())
 	}
 
 	// try to unmarshal the data
-	if err := json.Unmarshal(data, &cfg.TokenLogo); err != nil {
-		log.Printf("can not decode ERC20 tokens map file; %s", err.Error())
-		return
+	var logos map[common.Address]string
+	if err := json.Unmarshal(data, &logos); err != nil {
+		return nil, fmt.Errorf("can not decode ERC20 tokens map file; %s", err.Error())
 	}
-
-	// inform about tokens
-	log.Printf("found %d ERC20 tokens", len(cfg.TokenLogo))
+	return logos, nil
 }
 
 // setupConfigUnmarshaler configures the Config loader to properly unmarshal
@@ -2,8 +2,10 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"golang.org/x/sync/singleflight"
@@ -13,6 +15,13 @@ import (
 // accMaxTransactionsPerRequest maximal number of transaction end-client can request in one query.
 const accMaxTransactionsPerRequest = 50
 
+// accMaxTxListPerRequest maximal number of transaction of the account history end-client can request in one query.
+const accMaxTxListPerRequest = 100
+
+// accMaxFailedTransactionsPerRequest maximal number of failed transaction end-client can request in one query;
+// the revert reason of each of them may need a replay on the node.
+const accMaxFailedTransactionsPerRequest = 25
+
 // Account represents resolvable blockchain account structure.
 type Account struct {
 	types.Account
@@ -27,9 +36,18 @@ func NewAccount(acc *types.Account) *Account {
 }
 
 // Account resolves blockchain account by address.
-func (rs *rootResolver) Account(args struct{ Address common.Address }) (*Account, error) {
+func (rs *rootResolver) Account(ctx context.Context, args struct{ Address common.Address }) (*Account, error) {
+	// fresh read demanded?
+	if repository.IsFreshRead(ctx) {
+		repository.R().RefreshAccount(&args.Address)
+	}
+
 	// simply pull the block by hash
-	acc, err := repository.R().Account(&args.Address)
+	var acc *types.Account
+	err := resolveWithin(ctx, func() (err error) {
+		acc, err = repository.R().Account(&args.Address)
+		return err
+	})
 	if err != nil {
 		log.Errorf("could not get the specified account")
 		return nil, err
@@ -38,15 +56,28 @@ func (rs *rootResolver) Account(args struct{ Address common.Address }) (*Account
 }
 
 // AccountsActive resolves total number of active accounts on the blockchain.
-func (rs *rootResolver) AccountsActive() (hexutil.Uint64, error) {
-	return repository.R().AccountsActive()
+func (rs *rootResolver) AccountsActive(ctx context.Context) (hexutil.Uint64, error) {
+	var val hexutil.Uint64
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().AccountsActive()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return val, nil
 }
 
-// Balance resolves total balance of the account.
-func (acc *Account) Balance() (hexutil.Big, error) {
+// Balance resolves total balance of the account, optionally at the given block.
+func (acc *Account) Balance(ctx context.Context, args struct{ Block *hexutil.Uint64 }) (hexutil.Big, error) {
+	key := "balance"
+	if args.Block != nil {
+		key = fmt.Sprintf("balance:%d", uint64(*args.Block))
+	}
+
 	// get the balance
-	val, err, _ := acc.cg.Do("balance", func() (interface{}, error) {
-		return repository.R().AccountBalance(&acc.Address)
+	val, err, _ := acc.cg.Do(key, func() (interface{}, error) {
+		return repository.R().AccountBalanceAtContext(ctx, &acc.Address, args.Block)
 	})
 
 	// can not get the balance?
@@ -57,9 +88,9 @@ func (acc *Account) Balance() (hexutil.Big, error) {
 }
 
 // TotalValue resolves account total value including delegated amount and pending rewards.
-func (acc *Account) TotalValue() (hexutil.Big, error) {
+func (acc *Account) TotalValue(ctx context.Context) (hexutil.Big, error) {
 	// get the balance
-	balance, err := acc.Balance()
+	balance, err := acc.Balance(ctx, struct{ Block *hexutil.Uint64 }{})
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -86,17 +117,33 @@ func (acc *Account) TxCount() (hexutil.Uint64, error) {
 	return *bal, nil
 }
 
+// Nonce resolves the number of confirmed transactions sent by the account.
+func (acc *Account) Nonce() (hexutil.Uint64, error) {
+	return acc.TxCount()
+}
+
+// PendingNonce resolves the number of transactions sent by the account including the pending ones.
+// The pending nonce equals the nonce if the account has no transactions in flight.
+func (acc *Account) PendingNonce() (hexutil.Uint64, error) {
+	nonce, err := repository.R().AccountPendingNonce(&acc.Address)
+	if err != nil {
+		return hexutil.Uint64(0), err
+	}
+	return *nonce, nil
+}
+
 // TxList resolves list of transaction associated with the account.
 func (acc *Account) TxList(args struct {
-	Cursor *Cursor
-	Count  int32
+	Cursor    *Cursor
+	Count     int32
+	SkipTotal bool
 }) (*TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	args.Count = listLimitCount(args.Count, accMaxTxListPerRequest)
 
 	// get the transaction hash list from repository
-	bl, err := repository.R().AccountTransactions(&acc.Address, (*string)(args.Cursor), args.Count)
+	bl, err := repository.R().AccountTransactions(&acc.Address, (*string)(args.Cursor), args.Count, args.SkipTotal)
 	if err != nil {
 		return nil, err
 	}
@@ -104,12 +151,31 @@ func (acc *Account) TxList(args struct {
 	return NewTransactionList(bl), nil
 }
 
-// Erc20TxList resolves list of ERC20 transactions associated with the account.
-func (acc *Account) Erc20TxList(args struct {
+// FailedTransactions resolves list of outbound transactions of the account which failed.
+func (acc *Account) FailedTransactions(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
-	Token  *common.Address
-	TxType *string
+}) (*TransactionList, error) {
+	args.Count = listLimitCount(args.Count, accMaxFailedTransactionsPerRequest)
+
+	var tl *types.TransactionList
+	err := resolveWithin(ctx, func() (err error) {
+		tl, err = repository.R().AccountFailedTransactions(&acc.Address, (*string)(args.Cursor), args.Count)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewTransactionList(tl), nil
+}
+
+// Erc20TxList resolves list of ERC20 transactions associated with the account.
+func (acc *Account) Erc20TxList(args struct {
+	Cursor    *Cursor
+	Count     int32
+	Token     *common.Address
+	TxType    *string
+	SkipTotal bool
 }) (*ERC20TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
@@ -124,6 +190,7 @@ func (acc *Account) Erc20TxList(args struct {
 		ercTrxTypeFromName(args.TxType),
 		(*string)(args.Cursor),
 		args.Count,
+		args.SkipTotal,
 	)
 	if err != nil {
 		return nil, err
@@ -134,11 +201,12 @@ func (acc *Account) Erc20TxList(args struct {
 
 // Erc721TxList resolves list of ERC721 transactions associated with the account.
 func (acc *Account) Erc721TxList(args struct {
-	Cursor  *Cursor
-	Count   int32
-	Token   *common.Address
-	TokenId *hexutil.Big
-	TxType  *string
+	Cursor    *Cursor
+	Count     int32
+	Token     *common.Address
+	TokenId   *hexutil.Big
+	TxType    *string
+	SkipTotal bool
 }) (*ERC721TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
@@ -153,6 +221,7 @@ func (acc *Account) Erc721TxList(args struct {
 		ercTrxTypeFromName(args.TxType),
 		(*string)(args.Cursor),
 		args.Count,
+		args.SkipTotal,
 	)
 	if err != nil {
 		return nil, err
@@ -163,11 +232,12 @@ func (acc *Account) Erc721TxList(args struct {
 
 // Erc1155TxList resolves list of ERC1155 transactions associated with the account.
 func (acc *Account) Erc1155TxList(args struct {
-	Cursor  *Cursor
-	Count   int32
-	Token   *common.Address
-	TokenId *hexutil.Big
-	TxType  *string
+	Cursor    *Cursor
+	Count     int32
+	Token     *common.Address
+	TokenId   *hexutil.Big
+	TxType    *string
+	SkipTotal bool
 }) (*ERC1155TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
@@ -182,6 +252,7 @@ func (acc *Account) Erc1155TxList(args struct {
 		ercTrxTypeFromName(args.TxType),
 		(*string)(args.Cursor),
 		args.Count,
+		args.SkipTotal,
 	)
 	if err != nil {
 		return nil, err
@@ -273,3 +344,22 @@ func (acc *Account) delegationsTotal() (amount *big.Int, rewards *big.Int, err e
 	// loop all delegations and calculate
 	return amount, rewards, nil
 }
+
+// StorageAt resolves the raw value of the given storage slot of the account,
+// if the client is permitted to see it.
+func (acc *Account) StorageAt(ctx context.Context, args struct{ Slot common.Hash }) (*common.Hash, error) {
+	if hide, err := redacted(ctx, "Account.storageAt"); hide {
+		return nil, err
+	}
+
+	var val common.Hash
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().AccountStorageAt(&acc.Address, args.Slot)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not load storage slot %s of %s; %s", args.Slot.String(), acc.Address.String(), err.Error())
+		return nil, err
+	}
+	return &val, nil
+}
@@ -0,0 +1,32 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountOverview represents a resolvable live overview of an account.
+type AccountOverview struct {
+	types.AccountOverview
+}
+
+// AccountOverviews resolves live overviews of the given accounts loaded in a single batch.
+func (rs *rootResolver) AccountOverviews(ctx context.Context, args struct{ Addresses []common.Address }) ([]*AccountOverview, error) {
+	var list []*types.AccountOverview
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = repository.R().AccountOverviews(args.Addresses)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*AccountOverview, len(list))
+	for i, ao := range list {
+		res[i] = &AccountOverview{*ao}
+	}
+	return res, nil
+}
@@ -0,0 +1,45 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+)
+
+// ApprovalRisk represents resolvable heuristic risk score of ERC20 approvals granted by an account.
+type ApprovalRisk struct {
+	types.ApprovalRisk
+}
+
+// ApprovalRiskItem represents resolvable active ERC20 approval contributing to the risk score.
+type ApprovalRiskItem struct {
+	types.ApprovalRiskItem
+}
+
+// ApprovalRisk resolves the heuristic risk score of the active ERC20 approvals granted by the account.
+func (acc *Account) ApprovalRisk(ctx context.Context) (*ApprovalRisk, error) {
+	var ar *types.ApprovalRisk
+	err := resolveWithin(ctx, func() (err error) {
+		ar, err = repository.R().ApprovalRisk(&acc.Address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ApprovalRisk{ApprovalRisk: *ar}, nil
+}
+
+// Approvals resolves the breakdown of the active approvals.
+func (ar *ApprovalRisk) Approvals() []*ApprovalRiskItem {
+	res := make([]*ApprovalRiskItem, len(ar.ApprovalRisk.Approvals))
+	for i, item := range ar.ApprovalRisk.Approvals {
+		res[i] = &ApprovalRiskItem{ApprovalRiskItem: item}
+	}
+	return res
+}
+
+// Erc20Token resolves the approved ERC20 token.
+func (item *ApprovalRiskItem) Erc20Token() *ERC20Token {
+	return NewErc20Token(&item.ApprovalRiskItem.Token)
+}
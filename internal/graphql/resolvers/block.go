@@ -2,8 +2,10 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -19,19 +21,31 @@ func NewBlock(blk *types.Block) *Block {
 }
 
 // Block resolves blockchain block by number or by hash. If neither is provided, the most recent block is given.
-func (rs *rootResolver) Block(args *struct {
+// Providing both the number and the hash is ambiguous and is rejected.
+func (rs *rootResolver) Block(ctx context.Context, args *struct {
 	Number *hexutil.Uint64
 	Hash   *common.Hash
 }) (*Block, error) {
-	// do we have the number, or hash is not given?
-	if args.Number != nil || args.Hash == nil {
-		b, err := repository.R().BlockByNumber(args.Number)
-		return NewBlock(b), err
+	if args.Number != nil && args.Hash != nil {
+		return nil, fmt.Errorf("block number and hash can not be combined")
 	}
 
-	// simply pull the block by hash
-	b, err := repository.R().BlockByHash(args.Hash)
-	return NewBlock(b), err
+	var b *types.Block
+	err := resolveWithin(ctx, func() (err error) {
+		// do we have the number, or hash is not given?
+		if args.Hash == nil {
+			b, err = repository.R().BlockByNumber(args.Number)
+			return err
+		}
+
+		// simply pull the block by hash
+		b, err = repository.R().BlockByHash(args.Hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewBlock(b), nil
 }
 
 // Parent resolves parent block information to the given block.
@@ -0,0 +1,22 @@
+package resolvers
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"testing"
+)
+
+// TestBlockAmbiguousArgs tests the block can not be resolved by both the number and the hash.
+func TestBlockAmbiguousArgs(t *testing.T) {
+	num := hexutil.Uint64(1)
+	hash := common.HexToHash("0x01")
+
+	blk, err := (&rootResolver{}).Block(context.Background(), &struct {
+		Number *hexutil.Uint64
+		Hash   *common.Hash
+	}{Number: &num, Hash: &hash})
+	if err == nil || blk != nil {
+		t.Fatalf("expected error, got %v", blk)
+	}
+}
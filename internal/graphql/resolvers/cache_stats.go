@@ -0,0 +1,85 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"context"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// CacheStats represents resolvable usage statistics of the in-memory cache.
+type CacheStats struct {
+	types.CacheStats
+}
+
+// CacheStats resolves the usage statistics of the in-memory cache.
+// Only administrators are allowed to access the statistics.
+func (rs *rootResolver) CacheStats(ctx context.Context) (*CacheStats, error) {
+	if err := auth.Require(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return &CacheStats{*repository.R().CacheStats()}, nil
+}
+
+// ResetCacheStats resolves the usage statistics of the in-memory cache collected
+// until now and restarts the counters. Only administrators are allowed to reset the counters.
+func (rs *rootResolver) ResetCacheStats(ctx context.Context) (*CacheStats, error) {
+	if err := auth.Require(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	st := repository.R().ResetCacheStats()
+	log.Noticef("cache statistics reset; %d hits, %d misses, %d evictions", st.Hits, st.Misses, st.Evictions)
+	return &CacheStats{*st}, nil
+}
+
+// Entries resolves the number of entries in the cache.
+func (cs *CacheStats) Entries() int32 {
+	return int32(cs.CacheStats.Entries)
+}
+
+// MemoryUsage resolves the approximate number of bytes allocated by the cache.
+func (cs *CacheStats) MemoryUsage() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Capacity)
+}
+
+// Hits resolves the number of lookups served from the cache.
+func (cs *CacheStats) Hits() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Hits)
+}
+
+// Misses resolves the number of lookups not found in the cache.
+func (cs *CacheStats) Misses() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Misses)
+}
+
+// HitRate resolves the share of lookups served from the cache.
+func (cs *CacheStats) HitRate() float64 {
+	return cacheLookupRate(cs.CacheStats.Hits, cs.CacheStats.Misses)
+}
+
+// MissRate resolves the share of lookups not found in the cache.
+func (cs *CacheStats) MissRate() float64 {
+	return cacheLookupRate(cs.CacheStats.Misses, cs.CacheStats.Hits)
+}
+
+// Evictions resolves the number of entries removed on expiration, or to make space.
+func (cs *CacheStats) Evictions() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Evictions)
+}
+
+// Since resolves the UNIX timestamp of the start of the counters.
+func (cs *CacheStats) Since() hexutil.Uint64 {
+	return hexutil.Uint64(cs.CacheStats.Since.Unix())
+}
+
+// cacheLookupRate calculates the share of the given lookups in all the lookups.
+func cacheLookupRate(part, other uint64) float64 {
+	total := part + other
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
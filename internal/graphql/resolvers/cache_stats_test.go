@@ -0,0 +1,27 @@
+package resolvers
+
+import (
+	"motif-api/internal/types"
+	"testing"
+)
+
+// TestCacheStatsRates tests the hit and the miss rates of the cache lookups.
+func TestCacheStatsRates(t *testing.T) {
+	tests := []struct {
+		hits, misses uint64
+		hit, miss    float64
+	}{
+		{0, 0, 0, 0},
+		{3, 1, 0.75, 0.25},
+		{5, 0, 1, 0},
+	}
+	for _, tc := range tests {
+		cs := CacheStats{types.CacheStats{Hits: tc.hits, Misses: tc.misses}}
+		if got := cs.HitRate(); got != tc.hit {
+			t.Errorf("expected hit rate %v for %d/%d, got %v", tc.hit, tc.hits, tc.misses, got)
+		}
+		if got := cs.MissRate(); got != tc.miss {
+			t.Errorf("expected miss rate %v for %d/%d, got %v", tc.miss, tc.hits, tc.misses, got)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+)
+
+// ChainMetrics represents resolvable chain throughput metrics.
+type ChainMetrics struct {
+	types.ChainMetrics
+}
+
+// ChainMetrics resolves the chain throughput metrics of recently indexed blocks.
+func (rs *rootResolver) ChainMetrics() *ChainMetrics {
+	cm := repository.R().ChainMetrics()
+	if cm == nil {
+		return nil
+	}
+	return &ChainMetrics{*cm}
+}
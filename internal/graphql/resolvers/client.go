@@ -0,0 +1,21 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import "context"
+
+// clientKey represents the context key of the remote client address.
+type clientKey struct{}
+
+// WithClient creates a derived context carrying the address of the remote client.
+func WithClient(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, clientKey{}, addr)
+}
+
+// clientAddress provides the address of the remote client of the request, if known.
+func clientAddress(ctx context.Context) string {
+	addr, ok := ctx.Value(clientKey{}).(string)
+	if !ok {
+		return ""
+	}
+	return addr
+}
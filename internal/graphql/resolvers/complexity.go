@@ -0,0 +1,41 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+// fieldCategoryCosts maps the field categories to the query complexity cost
+// of a single resolution of a field of the category. Live reads make a node call
+// on a cache miss, so they cost more than the plain fields of a loaded object.
+var fieldCategoryCosts = map[FieldCategory]int{
+	FieldCategoryDefault:     1,
+	FieldCategoryLiveRead:    10,
+	FieldCategoryIndexed:     5,
+	FieldCategoryAggregation: 20,
+}
+
+// FieldCost provides the query complexity cost of a single resolution
+// of the given field based on its category tag.
+func FieldCost(typeName, fieldName string) int {
+	return fieldCategoryCosts[fieldCategories[typeName+"."+fieldName]]
+}
+
+// fieldMaxCounts maps the list fields to the max number of items loaded in one request,
+// if other than the default max number of edges.
+var fieldMaxCounts = map[string]uint32{
+	"Account.txList":             accMaxTxListPerRequest,
+	"Account.failedTransactions": accMaxFailedTransactionsPerRequest,
+	"Account.erc20TxList":        accMaxTransactionsPerRequest,
+	"Account.erc721TxList":       accMaxTransactionsPerRequest,
+	"Account.erc1155TxList":      accMaxTransactionsPerRequest,
+	"Query.erc20Transactions":    accMaxTransactionsPerRequest,
+	"Query.erc20Transfers":       accMaxTransactionsPerRequest,
+	"Query.erc721Transactions":   accMaxTransactionsPerRequest,
+	"Query.erc1155Transactions":  accMaxTransactionsPerRequest,
+	"Staker.delegations":         accMaxTransactionsPerRequest,
+}
+
+// FieldMaxCount provides the max number of items the given list field loads in one request.
+func FieldMaxCount(typeName, fieldName string) int {
+	if max, ok := fieldMaxCounts[typeName+"."+fieldName]; ok {
+		return int(max)
+	}
+	return int(listMaxEdgesPerRequest)
+}
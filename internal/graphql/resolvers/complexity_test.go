@@ -0,0 +1,45 @@
+package resolvers
+
+import (
+	gqlschema "motif-api/internal/graphql/schema"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// TestFieldMaxCounts tests the list fields with a specific max count exist in the schema
+// and accept the count argument.
+func TestFieldMaxCounts(t *testing.T) {
+	schema := graphql.MustParseSchema(gqlschema.Schema(), nil).ASTSchema()
+	for key := range fieldMaxCounts {
+		parts := strings.SplitN(key, ".", 2)
+		typ, ok := schema.Types[parts[0]].(*types.ObjectTypeDefinition)
+		if !ok {
+			t.Errorf("%s: type not found", key)
+			continue
+		}
+		field := typ.Fields.Get(parts[1])
+		if field == nil || field.Arguments.Get("count") == nil {
+			t.Errorf("%s: list field with count not found", key)
+		}
+	}
+
+	if FieldMaxCount("Account", "failedTransactions") != accMaxFailedTransactionsPerRequest {
+		t.Errorf("expected the field specific max count")
+	}
+	if FieldMaxCount("Query", "blocks") != int(listMaxEdgesPerRequest) {
+		t.Errorf("expected the default max count")
+	}
+}
+
+// TestFieldCost tests the live node reads cost more than the plain fields.
+func TestFieldCost(t *testing.T) {
+	if FieldCost("Transaction", "trace") <= FieldCost("Transaction", "hash") {
+		t.Errorf("expected live reads to cost more than plain fields")
+	}
+	if FieldCost("Transaction", "hash") != 1 {
+		t.Errorf("expected plain fields to cost 1, got %d", FieldCost("Transaction", "hash"))
+	}
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/sha256"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
@@ -31,6 +32,10 @@ const (
 // version string syntax. We enforce specific syntax on provided contract versions.
 var scVersionSyntaxRegexp = regexp.MustCompile("^\\w?(\\d+\\.)+\\d+$")
 
+// scCompilerVersionRegexp represents a regular expression for testing Solidity compiler
+// version string syntax, i.e. "0.8.4", or "v0.8.4+commit.c7e474f2".
+var scCompilerVersionRegexp = regexp.MustCompile("^v?\\d+\\.\\d+\\.\\d+(\\+commit\\.[0-9a-f]+)?$")
+
 // Contract represents resolvable blockchain smart contract structure.
 type Contract struct {
 	types.Contract
@@ -68,6 +73,10 @@ type ContractValidationInput struct {
 	// during the contract compilation.
 	OptimizeRuns int32 `json:"optimizeRuns"`
 
+	// CompilerVersion represents an optional version of the Solidity compiler
+	// used to compile the contract.
+	CompilerVersion *string `json:"compilerVersion,omitempty"`
+
 	// SourceCode represents the Solidity source code to be validated.
 	SourceCode string `json:"sourceCode"`
 }
@@ -83,6 +92,33 @@ func (con *Contract) DeployedBy() (*Transaction, error) {
 	return NewTransaction(tr), err
 }
 
+// SourceCode resolves the source code of the contract; the code is empty
+// if the client is not permitted to see it.
+func (con *Contract) SourceCode(ctx context.Context) (string, error) {
+	if hide, err := redacted(ctx, "Contract.sourceCode"); hide {
+		return "", err
+	}
+	return con.Contract.SourceCode, nil
+}
+
+// Abi resolves the ABI of the contract; if the contract is not validated locally,
+// the ABI is loaded from the remote source, if configured.
+func (con *Contract) Abi(ctx context.Context) string {
+	if con.Contract.Abi != "" {
+		return con.Contract.Abi
+	}
+
+	var abi string
+	err := resolveWithin(ctx, func() (err error) {
+		abi, err = repository.R().ContractAbi(&con.Address)
+		return err
+	})
+	if err != nil {
+		return ""
+	}
+	return abi
+}
+
 // sanitizeStringOption sanitizes and validates optional string value from the
 // smart contract validation check.
 func sanitizeStringOption(o *string, length int) (bool, *string) {
@@ -131,6 +167,11 @@ func isValidationValid(in *ContractValidationInput) error {
 		return fmt.Errorf("invalid version information provided")
 	}
 
+	// validate the compiler version syntax
+	if in.CompilerVersion != nil && !scCompilerVersionRegexp.MatchString(*in.CompilerVersion) {
+		return fmt.Errorf("invalid compiler version provided")
+	}
+
 	// validate the version syntax
 	if in.OptimizeRuns < 0 {
 		return fmt.Errorf("invalid number of optimization runs provided")
@@ -203,8 +244,12 @@ func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidati
 	sc.SourceCodeHash = &hash
 	updateContractFromInput(&args.Contract, sc)
 
-	// do the validation
-	if err := repository.R().ValidateContract(sc); err != nil {
+	// do the validation with the requested compiler, if any
+	var solc string
+	if args.Contract.CompilerVersion != nil {
+		solc = *args.Contract.CompilerVersion
+	}
+	if err := repository.R().ValidateContract(sc, solc); err != nil {
 		log.Errorf("contract validation failed; %s", err.Error())
 		return nil, err
 	}
@@ -0,0 +1,32 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractCall resolves the raw return data of a read only call of the given contract.
+// The call is available only if the raw calls are allowed by the server configuration.
+func (rs *rootResolver) ContractCall(ctx context.Context, args struct {
+	To    common.Address
+	Data  hexutil.Bytes
+	Block *hexutil.Uint64
+}) (hexutil.Bytes, error) {
+	if !rs.allowRawCall {
+		return nil, fmt.Errorf("raw contract calls are not allowed on this server")
+	}
+
+	var val hexutil.Bytes
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().ContractCall(&args.To, args.Data, args.Block)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
+}
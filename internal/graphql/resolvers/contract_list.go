@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -26,7 +27,7 @@ func NewContractList(cl *types.ContractList) *ContractList {
 }
 
 // Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-func (rs *rootResolver) Contracts(args *struct {
+func (rs *rootResolver) Contracts(ctx context.Context, args *struct {
 	ValidatedOnly bool
 	Cursor        *Cursor
 	Count         int32
@@ -36,7 +37,11 @@ func (rs *rootResolver) Contracts(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the contract list from repository
-	cl, err := repository.R().Contracts(args.ValidatedOnly, (*string)(args.Cursor), args.Count)
+	var cl *types.ContractList
+	err := resolveWithin(ctx, func() (err error) {
+		cl, err = repository.R().Contracts(args.ValidatedOnly, (*string)(args.Cursor), args.Count)
+		return err
+	})
 	if err != nil {
 		log.Errorf("can not get contracts list; %s", err.Error())
 		return nil, err
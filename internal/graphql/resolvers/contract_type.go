@@ -0,0 +1,62 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractType represents resolvable classification of an account by its code.
+type ContractType struct {
+	types.ContractType
+}
+
+// ContractType resolves the classification of the account by its code.
+func (acc *Account) ContractType(ctx context.Context) (*ContractType, error) {
+	var ct *types.ContractType
+	err := resolveWithin(ctx, func() (err error) {
+		ct, err = repository.R().ContractType(&acc.Address)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not classify account %s; %s", acc.Address.String(), err.Error())
+		return nil, err
+	}
+	return &ContractType{ContractType: *ct}, nil
+}
+
+// Code resolves the code deployed at the address of the account; empty for externally owned accounts.
+func (acc *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	var code []byte
+	err := resolveWithin(ctx, func() (err error) {
+		code, err = repository.R().AccountCode(&acc.Address)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not load code of %s; %s", acc.Address.String(), err.Error())
+		return nil, err
+	}
+	if code == nil {
+		code = make([]byte, 0)
+	}
+	return code, nil
+}
+
+// IsContract resolves the flag of an account with code deployed at its address.
+func (acc *Account) IsContract(ctx context.Context) (bool, error) {
+	code, err := acc.Code(ctx)
+	if err != nil {
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+// ImplementationType resolves the classification of the implementation contract of a proxy.
+func (ct *ContractType) ImplementationType() *string {
+	if ct.Implementation == nil {
+		return nil
+	}
+	return &ct.ContractType.ImplementationType
+}
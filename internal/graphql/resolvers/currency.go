@@ -0,0 +1,47 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// currencyRate provides the exchange rate of USD to the requested currency;
+// the rate of USD is always one, so no rates source is needed by default.
+func currencyRate(currency string) (float64, error) {
+	if currency == "" || currency == types.FxBaseCurrency {
+		return 1, nil
+	}
+	return repository.R().FxRate(currency)
+}
+
+// convertBig converts the given USD amount to the target currency by the exchange rate.
+func convertBig(val hexutil.Big, rate float64) hexutil.Big {
+	if rate == 1 {
+		return val
+	}
+	res, _ := new(big.Float).Mul(new(big.Float).SetInt(val.ToInt()), big.NewFloat(rate)).Int(nil)
+	return hexutil.Big(*res)
+}
+
+// convertPortfolio converts the values of the portfolio distribution to the target currency
+// by the exchange rate; the shares of the holdings don't change.
+func convertPortfolio(pd *types.PortfolioDistribution, rate float64) *types.PortfolioDistribution {
+	res := *pd
+	res.Total = pd.Total * rate
+	res.Holdings = make([]types.PortfolioHolding, len(pd.Holdings))
+	for i, h := range pd.Holdings {
+		h.Value *= rate
+		res.Holdings[i] = h
+	}
+	return &res
+}
+
+// convertNetWorth converts the value of the net worth point to the target currency by the exchange rate.
+func convertNetWorth(pt *types.NetWorthPoint, rate float64) *types.NetWorthPoint {
+	res := *pt
+	res.Value = pt.Value * rate
+	return &res
+}
@@ -0,0 +1,41 @@
+package resolvers
+
+import (
+	"motif-api/internal/types"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestConvertPortfolio tests the portfolio values are converted and the source is kept intact.
+func TestConvertPortfolio(t *testing.T) {
+	pd := &types.PortfolioDistribution{
+		Total:    100,
+		Holdings: []types.PortfolioHolding{{Symbol: "FTM", Value: 60, Share: 60}, {Symbol: "fUSD", Value: 40, Share: 40}},
+	}
+
+	res := convertPortfolio(pd, 0.5)
+	if res.Total != 50 || res.Holdings[0].Value != 30 || res.Holdings[1].Value != 20 {
+		t.Errorf("unexpected converted portfolio %+v", res)
+	}
+	if res.Holdings[0].Share != 60 {
+		t.Errorf("expected share kept, got %f", res.Holdings[0].Share)
+	}
+	if pd.Total != 100 || pd.Holdings[0].Value != 60 {
+		t.Errorf("source portfolio modified %+v", pd)
+	}
+}
+
+// TestConvertBig tests conversion of a price with decimals.
+func TestConvertBig(t *testing.T) {
+	// 2.5 with 18 decimals converted at 0.92
+	price := hexutil.Big(*new(big.Int).Mul(big.NewInt(25), new(big.Int).Exp(big.NewInt(10), big.NewInt(17), nil)))
+	want := new(big.Int).Mul(big.NewInt(23), new(big.Int).Exp(big.NewInt(10), big.NewInt(17), nil))
+
+	got := convertBig(price, 0.92)
+	diff := new(big.Int).Abs(new(big.Int).Sub(got.ToInt(), want))
+	if diff.Cmp(big.NewInt(1000)) > 0 {
+		t.Errorf("expected %s, got %s", want.String(), got.ToInt().String())
+	}
+}
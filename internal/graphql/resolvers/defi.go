@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -48,15 +49,24 @@ func (rs *rootResolver) DefiNativeToken() *ERC20Token {
 }
 
 // Price resolves the value of the token in ref. denomination
-// using on-chain price oracle.
-func (dt *DefiToken) Price() (hexutil.Big, error) {
-	return repository.R().DefiTokenPrice(&dt.Address)
+// using on-chain price oracle, converted to the requested currency if any.
+func (dt *DefiToken) Price(args struct{ Currency string }) (hexutil.Big, error) {
+	rate, err := currencyRate(args.Currency)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+
+	price, err := repository.R().DefiTokenPrice(&dt.Address)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return convertBig(price, rate), nil
 }
 
 // AvailableBalance resolves the total amount of ERC20 tokens
 // available to the specified token holder.
-func (dt *DefiToken) AvailableBalance(args *struct{ Owner common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&dt.Address, &args.Owner)
+func (dt *DefiToken) AvailableBalance(ctx context.Context, args *struct{ Owner common.Address }) (hexutil.Big, error) {
+	return erc20BalanceOf(ctx, &dt.Address, &args.Owner)
 }
 
 // Allowance resolves the total amount of ERC20 tokens unlocked
@@ -2,10 +2,12 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
 )
 
 // FMintAccount represents resolvable DeFi account information.
@@ -20,6 +22,27 @@ type FMintTokenBalance struct {
 	Type         types.DefiTokenType
 }
 
+// FMintTokenPosition represents a resolvable token position on an fMint account.
+type FMintTokenPosition struct {
+	types.FMintTokenPosition
+}
+
+// FMintLiquidationPrice represents a resolvable liquidation price of an fMint collateral token.
+type FMintLiquidationPrice struct {
+	types.FMintLiquidationPrice
+}
+
+// FMintLiquidationRisk represents a resolvable distance of an fMint account
+// from the minimal collateral ratio.
+type FMintLiquidationRisk struct {
+	types.FMintLiquidationRisk
+}
+
+// FMintRewards represents resolvable rewards of an fMint account.
+type FMintRewards struct {
+	types.FMintRewards
+}
+
 // NewFMintAccount creates new instance of resolvable DeFi account.
 func NewFMintAccount(ac *types.FMintAccount) *FMintAccount {
 	return &FMintAccount{FMintAccount: *ac}
@@ -35,9 +58,13 @@ func NewFMintTokenBalance(owner common.Address, token common.Address, tp types.D
 }
 
 // FMintAccount resolves details of a DeFi account by its address.
-func (rs *rootResolver) FMintAccount(args *struct{ Owner common.Address }) (*FMintAccount, error) {
+func (rs *rootResolver) FMintAccount(ctx context.Context, args *struct{ Owner common.Address }) (*FMintAccount, error) {
 	// get the delegator detail from backend
-	ac, err := repository.R().FMintAccount(args.Owner)
+	var ac *types.FMintAccount
+	err := resolveWithin(ctx, func() (err error) {
+		ac, err = repository.R().FMintAccount(args.Owner)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +72,46 @@ func (rs *rootResolver) FMintAccount(args *struct{ Owner common.Address }) (*FMi
 	return NewFMintAccount(ac), nil
 }
 
+// FMintLiquidationRisk resolves the distance of the collateral ratio of a DeFi account
+// from the minimal collateral ratio. Accounts without a debt don't have any risk.
+func (rs *rootResolver) FMintLiquidationRisk(ctx context.Context, args *struct{ Owner common.Address }) (*FMintLiquidationRisk, error) {
+	var risk *types.FMintLiquidationRisk
+	err := resolveWithin(ctx, func() (err error) {
+		risk, err = repository.R().FMintLiquidationRisk(&args.Owner)
+		return err
+	})
+	if err != nil || risk == nil {
+		return nil, err
+	}
+	return &FMintLiquidationRisk{*risk}, nil
+}
+
+// FMintRewards resolves the pending rewards of a DeFi account along with the reward token
+// and the reward rate. Accounts which never minted have zero rewards.
+func (rs *rootResolver) FMintRewards(ctx context.Context, args *struct{ Owner common.Address }) (*FMintRewards, error) {
+	var rw *types.FMintRewards
+	err := resolveWithin(ctx, func() (err error) {
+		rw, err = repository.R().FMintRewards(&args.Owner)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FMintRewards{*rw}, nil
+}
+
+// AccrualRate resolves the amount of rewards accruing on the account per second,
+// i.e. the share of the reward rate by the principal balance of the account.
+func (rw *FMintRewards) AccrualRate() hexutil.Big {
+	total := rw.TotalPrincipalBalance.ToInt()
+	if total.Sign() <= 0 {
+		return hexutil.Big{}
+	}
+
+	val := new(big.Int).Mul(rw.RewardRate.ToInt(), rw.PrincipalBalance.ToInt())
+	return hexutil.Big(*val.Div(val, total))
+}
+
 // Collateral resolves the list of collateral token balance containers.
 func (fac *FMintAccount) Collateral() []*FMintTokenBalance {
 	// prep container and loop all the collateral addresses
@@ -67,6 +134,61 @@ func (fac *FMintAccount) Debt() []*FMintTokenBalance {
 	return list
 }
 
+// CollateralTokens resolves the list of collateral tokens deposited on the account.
+func (fac *FMintAccount) CollateralTokens() ([]*FMintTokenPosition, error) {
+	return fMintTokenPositions(&fac.Address, types.DefiTokenTypeCollateral)
+}
+
+// DebtTokens resolves the list of tokens borrowed/minted on the account.
+func (fac *FMintAccount) DebtTokens() ([]*FMintTokenPosition, error) {
+	return fMintTokenPositions(&fac.Address, types.DefiTokenTypeDebt)
+}
+
+// fMintTokenPositions resolves the list of token positions of the account on the given side.
+func fMintTokenPositions(owner *common.Address, tp types.DefiTokenType) ([]*FMintTokenPosition, error) {
+	pl, err := repository.R().FMintAccountTokens(owner, tp)
+	if err != nil {
+		return nil, err
+	}
+
+	// make the resolvable list
+	list := make([]*FMintTokenPosition, len(pl))
+	for i, pos := range pl {
+		list[i] = &FMintTokenPosition{*pos}
+	}
+	return list, nil
+}
+
+// CollateralRatio resolves the current ratio between the collateral value and the debt value
+// of the account. Accounts without a debt have no ratio.
+func (fac *FMintAccount) CollateralRatio() *float64 {
+	debt := fac.DebtValue.ToInt()
+	if debt.Sign() <= 0 {
+		return nil
+	}
+
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(fac.CollateralValue.ToInt()), new(big.Float).SetInt(debt)).Float64()
+	return &ratio
+}
+
+// LiquidationPrices resolves the liquidation price of each collateral token of the account.
+func (fac *FMintAccount) LiquidationPrices(ctx context.Context) ([]*FMintLiquidationPrice, error) {
+	var pl []types.FMintLiquidationPrice
+	err := resolveWithin(ctx, func() (err error) {
+		pl, err = repository.R().FMintLiquidationPrices(&fac.Address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*FMintLiquidationPrice, len(pl))
+	for i := range pl {
+		list[i] = &FMintLiquidationPrice{pl[i]}
+	}
+	return list, nil
+}
+
 // RewardsEarned resolves the total amount of rewards
 // accumulated on the account for the excessive collateral deposits.
 func (fac *FMintAccount) RewardsEarned() (hexutil.Big, error) {
@@ -115,7 +237,35 @@ func (mb *FMintTokenBalance) Balance() (hexutil.Big, error) {
 	return repository.R().FMintTokenBalance(&mb.OwnerAddress, &mb.TokenAddress, mb.Type)
 }
 
+// Token resolves the token information of the position.
+func (pos *FMintTokenPosition) Token() (*DefiToken, error) {
+	tk, err := repository.R().DefiToken(&pos.FMintTokenPosition.Token)
+	if err != nil {
+		return nil, err
+	}
+	return NewDefiToken(tk), nil
+}
+
+// TokenAddress resolves the address of the token of the position.
+func (pos *FMintTokenPosition) TokenAddress() common.Address {
+	return pos.FMintTokenPosition.Token
+}
+
 // Value resolves the value of the token for the related token address in fUSD.
 func (mb *FMintTokenBalance) Value() (hexutil.Big, error) {
 	return repository.R().FMintTokenValue(&mb.OwnerAddress, &mb.TokenAddress, mb.Type)
 }
+
+// TokenAddress resolves the address of the collateral token.
+func (lp *FMintLiquidationPrice) TokenAddress() common.Address {
+	return lp.FMintLiquidationPrice.Token
+}
+
+// Token resolves the detail of the collateral token.
+func (lp *FMintLiquidationPrice) Token() (*DefiToken, error) {
+	tk, err := repository.R().DefiToken(&lp.FMintLiquidationPrice.Token)
+	if err != nil {
+		return nil, err
+	}
+	return NewDefiToken(tk), nil
+}
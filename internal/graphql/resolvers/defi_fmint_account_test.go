@@ -0,0 +1,42 @@
+package resolvers
+
+import (
+	"motif-api/internal/types"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestFMintCollateralRatio tests the collateral ratio of fMint accounts.
+func TestFMintCollateralRatio(t *testing.T) {
+	fac := NewFMintAccount(&types.FMintAccount{
+		CollateralValue: hexutil.Big(*big.NewInt(4500)),
+		DebtValue:       hexutil.Big(*big.NewInt(1500)),
+	})
+	if ratio := fac.CollateralRatio(); ratio == nil || *ratio != 3 {
+		t.Errorf("expected ratio 3, got %v", ratio)
+	}
+
+	fac = NewFMintAccount(&types.FMintAccount{CollateralValue: hexutil.Big(*big.NewInt(4500))})
+	if ratio := fac.CollateralRatio(); ratio != nil {
+		t.Errorf("expected no ratio without debt, got %f", *ratio)
+	}
+}
+
+// TestFMintRewardsAccrualRate tests the share of the reward rate accruing on an fMint account.
+func TestFMintRewardsAccrualRate(t *testing.T) {
+	rw := FMintRewards{types.FMintRewards{
+		RewardRate:            hexutil.Big(*big.NewInt(1000)),
+		PrincipalBalance:      hexutil.Big(*big.NewInt(250)),
+		TotalPrincipalBalance: hexutil.Big(*big.NewInt(1000)),
+	}}
+	if rate := rw.AccrualRate(); rate.ToInt().Int64() != 250 {
+		t.Errorf("expected accrual rate 250, got %s", rate.ToInt().String())
+	}
+
+	rw.TotalPrincipalBalance = hexutil.Big{}
+	if rate := rw.AccrualRate(); rate.ToInt().Sign() != 0 {
+		t.Errorf("expected zero accrual rate without principal, got %s", rate.ToInt().String())
+	}
+}
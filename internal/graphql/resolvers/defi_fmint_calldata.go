@@ -0,0 +1,55 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FMintCallData represents a resolvable unsigned call of the fMint minter contract.
+type FMintCallData struct {
+	types.FMintCallData
+}
+
+// FMintCallDataArgs represents the arguments of the fMint call data builders.
+type FMintCallDataArgs struct {
+	Token  common.Address
+	Amount hexutil.Big
+	Owner  *common.Address
+}
+
+// fMintCallData builds the resolvable call of the given fMint operation.
+func fMintCallData(ctx context.Context, op int, args *FMintCallDataArgs) (*FMintCallData, error) {
+	var cd *types.FMintCallData
+	err := resolveWithin(ctx, func() (err error) {
+		cd, err = repository.R().FMintCallData(op, args.Owner, &args.Token, args.Amount.ToInt())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FMintCallData{*cd}, nil
+}
+
+// FMintDepositData resolves the call depositing the given amount of collateral token.
+func (rs *rootResolver) FMintDepositData(ctx context.Context, args *FMintCallDataArgs) (*FMintCallData, error) {
+	return fMintCallData(ctx, types.FMintTrxTypeDeposit, args)
+}
+
+// FMintWithdrawData resolves the call withdrawing the given amount of collateral token.
+func (rs *rootResolver) FMintWithdrawData(ctx context.Context, args *FMintCallDataArgs) (*FMintCallData, error) {
+	return fMintCallData(ctx, types.FMintTrxTypeWithdraw, args)
+}
+
+// FMintMintData resolves the call minting the given amount of synthetic token.
+func (rs *rootResolver) FMintMintData(ctx context.Context, args *FMintCallDataArgs) (*FMintCallData, error) {
+	return fMintCallData(ctx, types.FMintTrxTypeMint, args)
+}
+
+// FMintRepayData resolves the call repaying the given amount of debt token.
+func (rs *rootResolver) FMintRepayData(ctx context.Context, args *FMintCallDataArgs) (*FMintCallData, error) {
+	return fMintCallData(ctx, types.FMintTrxTypeRepay, args)
+}
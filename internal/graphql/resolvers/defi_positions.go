@@ -0,0 +1,72 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+)
+
+// DefiPositions represents resolvable positions of an account across the DeFi protocols.
+type DefiPositions struct {
+	types.DefiPositions
+}
+
+// FMintPosition represents a resolvable combined fMint position of an account.
+type FMintPosition struct {
+	types.FMintPosition
+}
+
+// LiquidityPosition represents a resolvable liquidity position of an account on a DEX pair.
+type LiquidityPosition struct {
+	types.LiquidityPosition
+}
+
+// DefiPositions resolves the positions of the account across the DeFi protocols.
+func (acc *Account) DefiPositions(ctx context.Context) (*DefiPositions, error) {
+	var dp *types.DefiPositions
+	err := resolveWithin(ctx, func() (err error) {
+		dp, err = repository.R().DefiPositions(&acc.Address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DefiPositions{*dp}, nil
+}
+
+// FMint resolves the fMint position of the account, if any.
+func (dp *DefiPositions) FMint() *FMintPosition {
+	if dp.DefiPositions.FMint == nil {
+		return nil
+	}
+	return &FMintPosition{*dp.DefiPositions.FMint}
+}
+
+// Liquidity resolves the list of DEX liquidity positions of the account.
+func (dp *DefiPositions) Liquidity() []*LiquidityPosition {
+	list := make([]*LiquidityPosition, len(dp.DefiPositions.Liquidity))
+	for i := range dp.DefiPositions.Liquidity {
+		list[i] = &LiquidityPosition{dp.DefiPositions.Liquidity[i]}
+	}
+	return list
+}
+
+// Collateral resolves the list of tokens deposited as collateral.
+func (fm *FMintPosition) Collateral() []*FMintTokenPosition {
+	return fMintPositionTokens(fm.FMintPosition.Collateral)
+}
+
+// Debt resolves the list of tokens borrowed/minted.
+func (fm *FMintPosition) Debt() []*FMintTokenPosition {
+	return fMintPositionTokens(fm.FMintPosition.Debt)
+}
+
+// fMintPositionTokens converts the given token positions to the resolvable list.
+func fMintPositionTokens(pl []*types.FMintTokenPosition) []*FMintTokenPosition {
+	list := make([]*FMintTokenPosition, len(pl))
+	for i, pos := range pl {
+		list[i] = &FMintTokenPosition{*pos}
+	}
+	return list
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,12 +29,21 @@ func NewDelegation(d *types.Delegation) *Delegation {
 }
 
 // Delegation resolves details of a delegator by it's address.
-func (rs *rootResolver) Delegation(args *struct {
+func (rs *rootResolver) Delegation(ctx context.Context, args *struct {
 	Address common.Address
 	Staker  hexutil.Big
 }) (*Delegation, error) {
+	// fresh read demanded?
+	if repository.IsFreshRead(ctx) {
+		repository.R().RefreshDelegation(&args.Address, &args.Staker)
+	}
+
 	// get the delegator detail from backend
-	d, err := repository.R().Delegation(&args.Address, &args.Staker)
+	var d *types.Delegation
+	err := resolveWithin(ctx, func() (err error) {
+		d, err = repository.R().Delegation(&args.Address, &args.Staker)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -19,8 +19,13 @@ func NewErc1155Contract(adr *common.Address) *ERC1155Contract {
 }
 
 // Erc1155Contract resolves an instance of ERC1155 contract if available.
-func (rs *rootResolver) Erc1155Contract(args *struct{ Address common.Address }) *ERC1155Contract {
-	return NewErc1155Contract(&args.Address)
+// Contracts not declaring the ERC1155 interface are rejected.
+func (rs *rootResolver) Erc1155Contract(args *struct{ Address common.Address }) (*ERC1155Contract, error) {
+	con, err := repository.R().Erc1155Contract(&args.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC1155Contract{*con}, nil
 }
 
 // Uri provides URI of Metadata JSON Schema of the token.
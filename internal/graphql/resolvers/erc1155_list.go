@@ -2,11 +2,25 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 )
 
 // Erc1155ContractList resolves a list of ERC1155 multi-token contracts.
-func (rs *rootResolver) Erc1155ContractList(args struct{ Count int32 }) ([]*ERC1155Contract, error) {
+func (rs *rootResolver) Erc1155ContractList(ctx context.Context, args struct{ Count int32 }) ([]*ERC1155Contract, error) {
+	var list []*ERC1155Contract
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = rs.erc1155ContractList(args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// erc1155ContractList builds the list of active ERC1155 contracts.
+func (rs *rootResolver) erc1155ContractList(args struct{ Count int32 }) ([]*ERC1155Contract, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
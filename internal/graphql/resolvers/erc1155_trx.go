@@ -40,3 +40,9 @@ func (trx *ERC1155Transaction) Token() *ERC1155Contract {
 func (trx *ERC1155Transaction) TrxType() string {
 	return ercTrxTypeToName(trx.Type)
 }
+
+// AmountSuspicious resolves the flag signaling the amount of the transaction
+// exceeds the sanity bound and should not be trusted.
+func (trx *ERC1155Transaction) AmountSuspicious() bool {
+	return repository.R().IsSuspiciousValue(trx.Amount.ToInt())
+}
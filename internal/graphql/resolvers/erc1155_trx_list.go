@@ -21,10 +21,13 @@ func NewERC1155TransactionList(tl *types.TokenTransactionList) *ERC1155Transacti
 	return &ERC1155TransactionList{TokenTransactionList: *tl}
 }
 
-// TotalCount resolves the total number of ERC1155 transactions in the list.
-func (txl *ERC1155TransactionList) TotalCount() hexutil.Big {
-	val := (*hexutil.Big)(new(big.Int).SetUint64(txl.Total))
-	return *val
+// TotalCount resolves the total number of ERC1155 transactions in the list,
+// if it has been calculated.
+func (txl *ERC1155TransactionList) TotalCount() *hexutil.Big {
+	if txl.TotalSkipped {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetUint64(txl.Total))
 }
 
 // PageInfo resolves the current page information for the ERC1155 transaction list.
@@ -2,15 +2,37 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync"
 )
 
 // ERC20Token represents a generic ERC20 token
 type ERC20Token struct {
 	types.Erc20Token
+
+	// values loaded for the token are shared by the fields resolving them
+	supply   erc20Value
+	mu       sync.Mutex
+	balances map[common.Address]*erc20Value
+}
+
+// erc20Value represents a token value loaded at most once per resolvable token.
+type erc20Value struct {
+	once sync.Once
+	val  hexutil.Big
+	err  error
+}
+
+// load provides the value, loading it by the given function on the first call.
+func (ev *erc20Value) load(fn func() (hexutil.Big, error)) (hexutil.Big, error) {
+	ev.once.Do(func() {
+		ev.val, ev.err = fn()
+	})
+	return ev.val, ev.err
 }
 
 // NewErc20Token creates a new instance of resolvable ERC20 token, it also validates
@@ -23,55 +45,141 @@ func NewErc20Token(adr *common.Address) *ERC20Token {
 		return nil
 	}
 	// make the instance of the token
-	return &ERC20Token{*erc20}
+	return &ERC20Token{Erc20Token: *erc20}
 }
 
 // Erc20Token resolves an instance of ERC20 token if available.
-func (rs *rootResolver) Erc20Token(args *struct{ Token common.Address }) *ERC20Token {
-	return NewErc20Token(&args.Token)
+func (rs *rootResolver) Erc20Token(ctx context.Context, args *struct{ Token common.Address }) (*ERC20Token, error) {
+	var token *ERC20Token
+	err := resolveWithin(ctx, func() error {
+		token = NewErc20Token(&args.Token)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
 }
 
 // FMintTokenAllowance resolves the amount of ERC20 tokens unlocked
 // by the token owner for DeFi operations.
-func (rs *rootResolver) FMintTokenAllowance(args *struct {
+func (rs *rootResolver) FMintTokenAllowance(ctx context.Context, args *struct {
 	Owner common.Address
 	Token common.Address
 }) (hexutil.Big, error) {
-	return repository.R().Erc20Allowance(&args.Token, &args.Owner, nil)
+	var val hexutil.Big
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().Erc20Allowance(&args.Token, &args.Owner, nil)
+		return err
+	})
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return val, nil
 }
 
 // ErcTotalSupply resolves the current total supply of the specified token.
-func (rs *rootResolver) ErcTotalSupply(args *struct{ Token common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20TotalSupply(&args.Token)
+func (rs *rootResolver) ErcTotalSupply(ctx context.Context, args *struct{ Token common.Address }) (hexutil.Big, error) {
+	var val hexutil.Big
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().Erc20TotalSupply(&args.Token)
+		return err
+	})
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return val, nil
 }
 
 // ErcTokenBalance resolves the current available balance of the specified token
 // for the specified owner.
-func (rs *rootResolver) ErcTokenBalance(args *struct {
+func (rs *rootResolver) ErcTokenBalance(ctx context.Context, args *struct {
 	Owner common.Address
 	Token common.Address
 }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&args.Token, &args.Owner)
+	var val hexutil.Big
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = erc20BalanceOf(ctx, &args.Token, &args.Owner)
+		return err
+	})
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return val, nil
 }
 
 // ErcTokenAllowance resolves the current amount of ERC20 tokens unlocked
 // by the token owner for the spender to be manipulated with.
-func (rs *rootResolver) ErcTokenAllowance(args *struct {
+func (rs *rootResolver) ErcTokenAllowance(ctx context.Context, args *struct {
 	Token   common.Address
 	Owner   common.Address
 	Spender common.Address
 }) (hexutil.Big, error) {
-	return repository.R().Erc20Allowance(&args.Token, &args.Owner, &args.Spender)
+	var val hexutil.Big
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().Erc20Allowance(&args.Token, &args.Owner, &args.Spender)
+		return err
+	})
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return val, nil
 }
 
 // TotalSupply resolves the total supply of the given ERC20 token.
 func (token *ERC20Token) TotalSupply() (hexutil.Big, error) {
-	return repository.R().Erc20TotalSupply(&token.Address)
+	return token.supply.load(func() (hexutil.Big, error) {
+		return repository.R().Erc20TotalSupply(&token.Address)
+	})
 }
 
 // BalanceOf resolves the available balance of the given ERC20 token to a user.
-func (token *ERC20Token) BalanceOf(args *struct{ Owner common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&token.Address, &args.Owner)
+func (token *ERC20Token) BalanceOf(ctx context.Context, args *struct{ Owner common.Address }) (hexutil.Big, error) {
+	token.mu.Lock()
+	if token.balances == nil {
+		token.balances = make(map[common.Address]*erc20Value)
+	}
+	bal, ok := token.balances[args.Owner]
+	if !ok {
+		bal = new(erc20Value)
+		token.balances[args.Owner] = bal
+	}
+	token.mu.Unlock()
+
+	return bal.load(func() (hexutil.Big, error) {
+		return erc20BalanceOf(ctx, &token.Address, &args.Owner)
+	})
+}
+
+// erc20BalanceOf loads the ERC20 token balance of the owner;
+// the cached balance is dropped first if the request demands a fresh read.
+func erc20BalanceOf(ctx context.Context, token *common.Address, owner *common.Address) (hexutil.Big, error) {
+	if repository.IsFreshRead(ctx) {
+		repository.R().RefreshErc20Balance(token, owner)
+	}
+	return repository.R().Erc20BalanceOf(token, owner)
+}
+
+// TotalSupplySuspicious resolves the flag signaling the total supply of the token
+// exceeds the sanity bound and should not be trusted. The supply resolved
+// for the totalSupply field is shared, it's not loaded again.
+func (token *ERC20Token) TotalSupplySuspicious() (bool, error) {
+	val, err := token.TotalSupply()
+	if err != nil {
+		return false, err
+	}
+	return repository.R().IsSuspiciousValue(val.ToInt()), nil
+}
+
+// BalanceOfSuspicious resolves the flag signaling the balance of the token
+// of the given owner exceeds the sanity bound and should not be trusted.
+// The balance resolved for the balanceOf field of the owner is shared.
+func (token *ERC20Token) BalanceOfSuspicious(ctx context.Context, args *struct{ Owner common.Address }) (bool, error) {
+	val, err := token.BalanceOf(ctx, args)
+	if err != nil {
+		return false, err
+	}
+	return repository.R().IsSuspiciousValue(val.ToInt()), nil
 }
 
 // Allowance resolves the unlocked allowance of the given ERC20 token from the owner to spender.
@@ -96,3 +204,44 @@ func (token *ERC20Token) TotalDeposit() (hexutil.Big, error) {
 func (token *ERC20Token) TotalDebt() (hexutil.Big, error) {
 	return repository.R().FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeDebt)
 }
+
+// ERC20PriceComparison represents a resolvable comparison of the oracle and DEX price of a token.
+type ERC20PriceComparison struct {
+	types.PriceComparison
+}
+
+// PriceComparison resolves the oracle price of the token cross-referenced with the DEX spot price.
+func (token *ERC20Token) PriceComparison(ctx context.Context) (*ERC20PriceComparison, error) {
+	var pc *types.PriceComparison
+	err := resolveWithin(ctx, func() (err error) {
+		pc, err = repository.R().PriceComparison(&token.Address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20PriceComparison{*pc}, nil
+}
+
+// ERC20TokenRiskFlags represents a resolvable set of heuristic risk flags of an ERC20 token.
+type ERC20TokenRiskFlags struct {
+	types.Erc20RiskFlags
+}
+
+// RiskFlags resolves a set of heuristic flags signaling the token may be a scam, or a spam token.
+func (token *ERC20Token) RiskFlags(ctx context.Context) (*ERC20TokenRiskFlags, error) {
+	var rf *types.Erc20RiskFlags
+	err := resolveWithin(ctx, func() (err error) {
+		rf, err = repository.R().Erc20RiskFlags(&token.Address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20TokenRiskFlags{*rf}, nil
+}
+
+// AirdropRecipients resolves the number of distinct recipients of the most distributing sender.
+func (rf *ERC20TokenRiskFlags) AirdropRecipients() hexutil.Uint64 {
+	return hexutil.Uint64(rf.Erc20RiskFlags.AirdropRecipients)
+}
@@ -0,0 +1,37 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ERC20ApproveCallData represents a resolvable unsigned ERC20 approve call.
+type ERC20ApproveCallData struct {
+	types.Erc20ApproveCallData
+}
+
+// Erc20ApproveCallDataArgs represents the arguments of the ERC20 approve call builder.
+type Erc20ApproveCallDataArgs struct {
+	Token   common.Address
+	Spender common.Address
+	Amount  *hexutil.Big
+	Owner   *common.Address
+}
+
+// Erc20ApproveData resolves the call unlocking the given amount of ERC20 tokens for the spender.
+// Missing, or zero amount resolves the call revoking the allowance.
+func (rs *rootResolver) Erc20ApproveData(ctx context.Context, args *Erc20ApproveCallDataArgs) (*ERC20ApproveCallData, error) {
+	var cd *types.Erc20ApproveCallData
+	err := resolveWithin(ctx, func() (err error) {
+		cd, err = repository.R().Erc20ApproveCallData(&args.Token, &args.Spender, args.Owner, args.Amount.ToInt())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20ApproveCallData{*cd}, nil
+}
@@ -0,0 +1,72 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// ERC20TokenHolder represents resolvable balance of an ERC20 token held by an account.
+type ERC20TokenHolder struct {
+	types.Erc20Holder
+	totalSupply *big.Int
+}
+
+// Erc20TokenHolders resolves the top holders of the given ERC20 token sorted by their balance.
+func (rs *rootResolver) Erc20TokenHolders(ctx context.Context, args struct {
+	Token common.Address
+	Count int32
+}) ([]*ERC20TokenHolder, error) {
+	var list []*types.Erc20Holder
+	var supply hexutil.Big
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = repository.R().Erc20TokenHolders(&args.Token, args.Count)
+		if err != nil {
+			return err
+		}
+
+		// the share is calculated from the current total supply
+		supply, err = repository.R().Erc20TotalSupply(&args.Token)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ERC20TokenHolder, len(list))
+	for i, h := range list {
+		res[i] = &ERC20TokenHolder{Erc20Holder: *h, totalSupply: supply.ToInt()}
+	}
+	return res, nil
+}
+
+// Address resolves the address of the holder.
+func (eh *ERC20TokenHolder) Address() common.Address {
+	return eh.Erc20Holder.Owner
+}
+
+// Balance resolves the amount of tokens held.
+func (eh *ERC20TokenHolder) Balance() hexutil.Big {
+	return eh.Erc20Holder.Balance
+}
+
+// Share resolves the percentage of the total supply held.
+func (eh *ERC20TokenHolder) Share() float64 {
+	return holderShare(eh.Erc20Holder.Balance.ToInt(), eh.totalSupply)
+}
+
+// holderShare calculates the percentage of the total supply represented by the balance.
+func holderShare(balance *big.Int, supply *big.Int) float64 {
+	if supply == nil || supply.Sign() <= 0 {
+		return 0
+	}
+	share, _ := new(big.Float).Quo(
+		new(big.Float).Mul(new(big.Float).SetInt(balance), big.NewFloat(100)),
+		new(big.Float).SetInt(supply),
+	).Float64()
+	return share
+}
@@ -0,0 +1,27 @@
+package resolvers
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestHolderShare tests the share of the total supply held by an account.
+func TestHolderShare(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance *big.Int
+		supply  *big.Int
+		want    float64
+	}{
+		{"quarter", big.NewInt(250), big.NewInt(1000), 25},
+		{"whole", big.NewInt(1000), big.NewInt(1000), 100},
+		{"no supply", big.NewInt(10), big.NewInt(0), 0},
+		{"unknown supply", big.NewInt(10), nil, 0},
+	}
+
+	for _, tt := range tests {
+		if got := holderShare(tt.balance, tt.supply); got != tt.want {
+			t.Errorf("%s: expected %f, got %f", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ERC20TokenInfo represents resolvable metadata of an ERC20 token loaded at once.
+type ERC20TokenInfo struct {
+	types.Erc20TokenInfo
+}
+
+// Erc20TokenInfo resolves the name, the symbol, the decimals and the total supply
+// of the given ERC20 token in a single round-trip to the node.
+func (rs *rootResolver) Erc20TokenInfo(ctx context.Context, args struct{ Token common.Address }) (*ERC20TokenInfo, error) {
+	var info *types.Erc20TokenInfo
+	err := resolveWithin(ctx, func() (err error) {
+		info, err = repository.R().Erc20TokenInfo(&args.Token)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20TokenInfo{*info}, nil
+}
@@ -2,12 +2,26 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // Erc20TokenList resolves an instance of ERC20 token list if available.
-func (rs *rootResolver) Erc20TokenList(args struct{ Count int32 }) ([]*ERC20Token, error) {
+func (rs *rootResolver) Erc20TokenList(ctx context.Context, args struct{ Count int32 }) ([]*ERC20Token, error) {
+	var list []*ERC20Token
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = rs.erc20TokenList(args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// erc20TokenList builds the list of active ERC20 tokens.
+func (rs *rootResolver) erc20TokenList(args struct{ Count int32 }) ([]*ERC20Token, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
@@ -28,7 +42,23 @@ func (rs *rootResolver) Erc20TokenList(args struct{ Count int32 }) ([]*ERC20Toke
 }
 
 // Erc20Assets resolves a list of instances of ERC20 tokens for the given owner.
-func (rs *rootResolver) Erc20Assets(args struct {
+func (rs *rootResolver) Erc20Assets(ctx context.Context, args struct {
+	Owner common.Address
+	Count int32
+}) ([]*ERC20Token, error) {
+	var list []*ERC20Token
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = rs.erc20Assets(args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// erc20Assets builds the list of ERC20 tokens of the owner.
+func (rs *rootResolver) erc20Assets(args struct {
 	Owner common.Address
 	Count int32
 }) ([]*ERC20Token, error) {
@@ -0,0 +1,22 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/repository"
+	"context"
+)
+
+// ReloadErc20LogoMap reloads the map of ERC20 token logos from the configured file
+// and resolves the number of tokens in the new map. Only administrators are allowed to reload.
+func (rs *rootResolver) ReloadErc20LogoMap(ctx context.Context) (int32, error) {
+	if err := auth.Require(ctx, auth.RoleAdmin); err != nil {
+		return 0, err
+	}
+
+	count, err := repository.R().ReloadErc20LogoMap()
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
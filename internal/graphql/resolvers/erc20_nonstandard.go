@@ -0,0 +1,29 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Erc20NonStandardToken represents a resolvable ERC20 token with non-standard return values.
+type Erc20NonStandardToken struct {
+	types.Erc20NonStandardToken
+}
+
+// Erc20NonStandardTokens resolves a list of ERC20 tokens with non-standard return values.
+func (rs *rootResolver) Erc20NonStandardTokens() []*Erc20NonStandardToken {
+	list := repository.R().Erc20NonStandardTokens()
+
+	res := make([]*Erc20NonStandardToken, len(list))
+	for i := range list {
+		res[i] = &Erc20NonStandardToken{list[i]}
+	}
+	return res
+}
+
+// Address resolves the address of the token contract.
+func (nst *Erc20NonStandardToken) Address() common.Address {
+	return nst.Token
+}
@@ -0,0 +1,48 @@
+package resolvers
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestErc20ValueShared tests a token value is loaded once and shared by all the fields.
+func TestErc20ValueShared(t *testing.T) {
+	var ev erc20Value
+	var calls int
+	var mu sync.Mutex
+	load := func() (hexutil.Big, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return *(*hexutil.Big)(hexutil.MustDecodeBig("0x2a")), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := ev.load(load)
+			if err != nil || val.ToInt().Int64() != 42 {
+				t.Errorf("unexpected value %s, %v", val.String(), err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected value loaded once, loaded %d times", calls)
+	}
+
+	// the failure is shared as well
+	var failed erc20Value
+	fail := errors.New("node down")
+	for i := 0; i < 2; i++ {
+		if _, err := failed.load(func() (hexutil.Big, error) { return hexutil.Big{}, fail }); err != fail {
+			t.Errorf("expected shared failure, got %v", err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // ERC20Transaction represents a resolvable ERC20 token transaction.
@@ -21,6 +22,11 @@ func (trx *ERC20Transaction) TrxHash() common.Hash {
 	return trx.TokenTransaction.Transaction
 }
 
+// BlockNumber resolves the number of the block containing the ERC20 call.
+func (trx *ERC20Transaction) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(trx.TokenTransaction.BlockNumber)
+}
+
 // Transaction resolves an instance of the transaction executing the ERC20 call.
 func (trx *ERC20Transaction) Transaction() (*Transaction, error) {
 	// get the transaction from repo
@@ -40,3 +46,9 @@ func (trx *ERC20Transaction) Token() *ERC20Token {
 func (trx *ERC20Transaction) TrxType() string {
 	return ercTrxTypeToName(trx.Type)
 }
+
+// AmountSuspicious resolves the flag signaling the amount of the transaction
+// exceeds the sanity bound and should not be trusted.
+func (trx *ERC20Transaction) AmountSuspicious() bool {
+	return repository.R().IsSuspiciousValue(trx.Amount.ToInt())
+}
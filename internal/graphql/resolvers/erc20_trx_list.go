@@ -21,10 +21,13 @@ func NewERC20TransactionList(tl *types.TokenTransactionList) *ERC20TransactionLi
 	return &ERC20TransactionList{TokenTransactionList: *tl}
 }
 
-// TotalCount resolves the total number of ERC20 transactions in the list.
-func (txl *ERC20TransactionList) TotalCount() hexutil.Big {
-	val := (*hexutil.Big)(new(big.Int).SetUint64(txl.Total))
-	return *val
+// TotalCount resolves the total number of ERC20 transactions in the list,
+// if it has been calculated.
+func (txl *ERC20TransactionList) TotalCount() *hexutil.Big {
+	if txl.TotalSkipped {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetUint64(txl.Total))
 }
 
 // PageInfo resolves the current page information for the ERC20 transaction list.
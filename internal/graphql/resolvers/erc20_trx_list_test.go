@@ -0,0 +1,75 @@
+package resolvers
+
+import (
+	"motif-api/internal/types"
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// skipTotalTestSchema is a minimal schema exposing a page of ERC20 transactions.
+const skipTotalTestSchema = `
+schema { query: Query }
+scalar BigInt
+scalar Cursor
+type Query { page(first: Boolean!): ERC20TransactionList! }
+type ERC20TransactionList { totalCount: BigInt pageInfo: ListPageInfo! }
+type ListPageInfo { first: Cursor last: Cursor hasNext: Boolean! hasPrevious: Boolean! }
+`
+
+// skipTotalTestQuery implements the root resolver of the skipped total test schema.
+type skipTotalTestQuery struct{}
+
+// Page resolves the first, or the second page of a list of 4 transactions loaded without the total.
+func (*skipTotalTestQuery) Page(args struct{ First bool }) *ERC20TransactionList {
+	if args.First {
+		return NewERC20TransactionList(&types.TokenTransactionList{
+			Collection:   []*types.TokenTransaction{{ID: "0x04"}, {ID: "0x03"}},
+			Total:        1,
+			TotalSkipped: true,
+			IsStart:      true,
+		})
+	}
+	return NewERC20TransactionList(&types.TokenTransactionList{
+		Collection:   []*types.TokenTransaction{{ID: "0x02"}, {ID: "0x01"}},
+		Total:        1,
+		TotalSkipped: true,
+		IsEnd:        true,
+	})
+}
+
+// TestSkippedTotalPaging tests the list pages correctly with the total count skipped.
+func TestSkippedTotalPaging(t *testing.T) {
+	schema := graphql.MustParseSchema(skipTotalTestSchema, &skipTotalTestQuery{}, graphql.UseFieldResolvers())
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: `{ page(first: true) { totalCount pageInfo { first last hasNext hasPrevious } } }`,
+			want:  `{"page":{"totalCount":null,"pageInfo":{"first":"0x04","last":"0x03","hasNext":true,"hasPrevious":false}}}`,
+		},
+		{
+			query: `{ page(first: false) { totalCount pageInfo { first last hasNext hasPrevious } } }`,
+			want:  `{"page":{"totalCount":null,"pageInfo":{"first":"0x02","last":"0x01","hasNext":false,"hasPrevious":true}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		res := schema.Exec(context.Background(), tt.query, "", nil)
+		if len(res.Errors) > 0 {
+			t.Fatalf("unexpected errors %v", res.Errors)
+		}
+		if string(res.Data) != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, string(res.Data))
+		}
+	}
+
+	// the total is still provided if not skipped
+	tl := NewERC20TransactionList(&types.TokenTransactionList{Total: 42})
+	if tc := tl.TotalCount(); tc == nil || tc.ToInt().Int64() != 42 {
+		t.Errorf("expected total 42, got %v", tc)
+	}
+}
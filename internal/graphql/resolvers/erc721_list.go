@@ -2,11 +2,25 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 )
 
 // Erc721ContractList resolves an instance of ERC721 token list if available.
-func (rs *rootResolver) Erc721ContractList(args struct{ Count int32 }) ([]*ERC721Contract, error) {
+func (rs *rootResolver) Erc721ContractList(ctx context.Context, args struct{ Count int32 }) ([]*ERC721Contract, error) {
+	var list []*ERC721Contract
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = rs.erc721ContractList(args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// erc721ContractList builds the list of active ERC721 contracts.
+func (rs *rootResolver) erc721ContractList(args struct{ Count int32 }) ([]*ERC721Contract, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
@@ -21,10 +21,13 @@ func NewERC721TransactionList(tl *types.TokenTransactionList) *ERC721Transaction
 	return &ERC721TransactionList{TokenTransactionList: *tl}
 }
 
-// TotalCount resolves the total number of ERC721 transactions in the list.
-func (txl *ERC721TransactionList) TotalCount() hexutil.Big {
-	val := (*hexutil.Big)(new(big.Int).SetUint64(txl.Total))
-	return *val
+// TotalCount resolves the total number of ERC721 transactions in the list,
+// if it has been calculated.
+func (txl *ERC721TransactionList) TotalCount() *hexutil.Big {
+	if txl.TotalSkipped {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetUint64(txl.Total))
 }
 
 // PageInfo resolves the current page information for the ERC721 transaction list.
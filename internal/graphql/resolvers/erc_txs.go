@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -9,27 +10,33 @@ import (
 )
 
 // Erc20Transactions resolves list of ERC20 transactions.
-func (rs *rootResolver) Erc20Transactions(args struct {
-	Cursor  *Cursor
-	Count   int32
-	Token   *common.Address
-	Account *common.Address
-	TxType  *string
+func (rs *rootResolver) Erc20Transactions(ctx context.Context, args struct {
+	Cursor    *Cursor
+	Count     int32
+	Token     *common.Address
+	Account   *common.Address
+	TxType    *string
+	SkipTotal bool
 }) (*ERC20TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
-		types.AccountTypeERC20Token,
-		args.Token,
-		nil,
-		args.Account,
-		ercTrxTypeFromName(args.TxType),
-		(*string)(args.Cursor),
-		args.Count,
-	)
+	var tl *types.TokenTransactionList
+	err := resolveWithin(ctx, func() (err error) {
+		tl, err = repository.R().TokenTransactions(
+			types.AccountTypeERC20Token,
+			args.Token,
+			nil,
+			args.Account,
+			ercTrxTypeFromName(args.TxType),
+			(*string)(args.Cursor),
+			args.Count,
+			args.SkipTotal,
+		)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -37,29 +44,58 @@ func (rs *rootResolver) Erc20Transactions(args struct {
 	return NewERC20TransactionList(tl), nil
 }
 
+// Erc20Transfers resolves list of Transfer events of the given ERC20 token
+// optionally filtered by the sender and/or the recipient.
+func (rs *rootResolver) Erc20Transfers(ctx context.Context, args struct {
+	Token  common.Address
+	From   *common.Address
+	To     *common.Address
+	Cursor *Cursor
+	Count  int32
+}) (*ERC20TransactionList, error) {
+	// limit query size; the cap applies to the full token history as well
+	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+
+	var tl *types.TokenTransactionList
+	err := resolveWithin(ctx, func() (err error) {
+		tl, err = repository.R().Erc20Transfers(args.Token, args.From, args.To, (*string)(args.Cursor), args.Count)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewERC20TransactionList(tl), nil
+}
+
 // Erc721Transactions resolves list of ERC721 transactions.
-func (rs *rootResolver) Erc721Transactions(args struct {
-	Cursor  *Cursor
-	Count   int32
-	Token   *common.Address
-	TokenId *hexutil.Big
-	Account *common.Address
-	TxType  *string
+func (rs *rootResolver) Erc721Transactions(ctx context.Context, args struct {
+	Cursor    *Cursor
+	Count     int32
+	Token     *common.Address
+	TokenId   *hexutil.Big
+	Account   *common.Address
+	TxType    *string
+	SkipTotal bool
 }) (*ERC721TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
-		types.AccountTypeERC721Contract,
-		args.Token,
-		(*big.Int)(args.TokenId),
-		args.Account,
-		ercTrxTypeFromName(args.TxType),
-		(*string)(args.Cursor),
-		args.Count,
-	)
+	var tl *types.TokenTransactionList
+	err := resolveWithin(ctx, func() (err error) {
+		tl, err = repository.R().TokenTransactions(
+			types.AccountTypeERC721Contract,
+			args.Token,
+			(*big.Int)(args.TokenId),
+			args.Account,
+			ercTrxTypeFromName(args.TxType),
+			(*string)(args.Cursor),
+			args.Count,
+			args.SkipTotal,
+		)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -68,28 +104,34 @@ func (rs *rootResolver) Erc721Transactions(args struct {
 }
 
 // Erc1155Transactions resolves list of ERC1155 transactions.
-func (rs *rootResolver) Erc1155Transactions(args struct {
-	Cursor  *Cursor
-	Count   int32
-	Token   *common.Address
-	TokenId *hexutil.Big
-	Account *common.Address
-	TxType  *string
+func (rs *rootResolver) Erc1155Transactions(ctx context.Context, args struct {
+	Cursor    *Cursor
+	Count     int32
+	Token     *common.Address
+	TokenId   *hexutil.Big
+	Account   *common.Address
+	TxType    *string
+	SkipTotal bool
 }) (*ERC1155TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
-		types.AccountTypeERC1155Contract,
-		args.Token,
-		(*big.Int)(args.TokenId),
-		args.Account,
-		ercTrxTypeFromName(args.TxType),
-		(*string)(args.Cursor),
-		args.Count,
-	)
+	var tl *types.TokenTransactionList
+	err := resolveWithin(ctx, func() (err error) {
+		tl, err = repository.R().TokenTransactions(
+			types.AccountTypeERC1155Contract,
+			args.Token,
+			(*big.Int)(args.TokenId),
+			args.Account,
+			ercTrxTypeFromName(args.TxType),
+			(*string)(args.Cursor),
+			args.Count,
+			args.SkipTotal,
+		)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"fmt"
@@ -61,7 +62,23 @@ func (rs *rootResolver) estimateRewardsByAddress(addr *common.Address, ep *types
 }
 
 // EstimateRewards resolves reward estimation for the given address or amount staked.
-func (rs *rootResolver) EstimateRewards(args *struct {
+func (rs *rootResolver) EstimateRewards(ctx context.Context, args *struct {
+	Address *common.Address
+	Amount  *hexutil.Uint64
+}) (EstimatedRewards, error) {
+	var er EstimatedRewards
+	err := resolveWithin(ctx, func() (err error) {
+		er, err = rs.estimateRewards(args)
+		return err
+	})
+	if err != nil {
+		return EstimatedRewards{}, err
+	}
+	return er, nil
+}
+
+// estimateRewards calculates the rewards estimate for the given address, or amount.
+func (rs *rootResolver) estimateRewards(args *struct {
 	Address *common.Address
 	Amount  *hexutil.Uint64
 }) (EstimatedRewards, error) {
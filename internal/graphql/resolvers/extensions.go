@@ -0,0 +1,53 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"sync"
+)
+
+// Extensions collects debugging details of a request the resolvers
+// want to report back to the client in the response extensions.
+type Extensions struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// extensionsKey represents the context key of the request extensions collector.
+type extensionsKey struct{}
+
+// WithExtensions creates a derived context carrying a new request extensions collector.
+func WithExtensions(ctx context.Context) (context.Context, *Extensions) {
+	ext := &Extensions{values: make(map[string]interface{})}
+	return context.WithValue(ctx, extensionsKey{}, ext), ext
+}
+
+// AddExtensionCount adds the given value to the named counter of the request extensions, if collected.
+func AddExtensionCount(ctx context.Context, name string, val int) {
+	ext, ok := ctx.Value(extensionsKey{}).(*Extensions)
+	if !ok {
+		return
+	}
+
+	ext.mu.Lock()
+	defer ext.mu.Unlock()
+
+	cnt, _ := ext.values[name].(int)
+	ext.values[name] = cnt + val
+}
+
+// Values provides a copy of the collected extensions; nil if nothing was collected.
+func (ext *Extensions) Values() map[string]interface{} {
+	ext.mu.Lock()
+	defer ext.mu.Unlock()
+
+	if len(ext.values) == 0 {
+		return nil
+	}
+
+	res := make(map[string]interface{}, len(ext.values))
+	for k, v := range ext.values {
+		res[k] = v
+	}
+	return res
+}
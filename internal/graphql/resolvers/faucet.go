@@ -0,0 +1,26 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FaucetSend sends the configured amount of native tokens from the server key
+// to the given address. The faucet requests share the transactions submit limit
+// of the client; each address can be funded once in the configured cooldown period.
+func (rs *rootResolver) FaucetSend(ctx context.Context, args struct{ To common.Address }) (*Transaction, error) {
+	if client := clientAddress(ctx); !rs.trxSubmits.allow(client) {
+		log.Warningf("faucet requests limit reached for %s", client)
+		return nil, fmt.Errorf("too many requests submitted, try again later")
+	}
+
+	trx, err := repository.R().FaucetSend(&args.To)
+	if err != nil {
+		log.Warningf("faucet can not fund %s; %s", args.To.String(), err.Error())
+		return nil, err
+	}
+	return NewTransaction(trx), nil
+}
@@ -0,0 +1,31 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FeeHistory represents a resolvable history of the base fee and the priority fee rewards.
+type FeeHistory struct {
+	types.FeeHistory
+}
+
+// FeeHistory resolves the base fee and the priority fee rewards at the given percentiles
+// of the given number of the most recent blocks.
+func (rs *rootResolver) FeeHistory(ctx context.Context, args struct {
+	BlockCount        hexutil.Uint64
+	RewardPercentiles []float64
+}) (*FeeHistory, error) {
+	var fh *types.FeeHistory
+	err := resolveWithin(ctx, func() (err error) {
+		fh, err = repository.R().FeeHistory(args.BlockCount, args.RewardPercentiles)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FeeHistory{*fh}, nil
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -59,9 +60,13 @@ func fMintTypeToPurpose(t int32) string {
 
 // FMintUserTokens resolves list of fMint users and associated tokens
 // used for specified purpose.
-func (rs *rootResolver) FMintUserTokens(args struct{ Purpose string }) ([]*FMintUserToken, error) {
+func (rs *rootResolver) FMintUserTokens(ctx context.Context, args struct{ Purpose string }) ([]*FMintUserToken, error) {
 	// get the aggregated list of addresses and their tokens
-	list, err := repository.R().FMintUsers(fMintPurposeToType(args.Purpose))
+	var list []*types.FMintUserTokens
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = repository.R().FMintUsers(fMintPurposeToType(args.Purpose))
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
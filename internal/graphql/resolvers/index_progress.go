@@ -0,0 +1,31 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+)
+
+// IndexProgress represents a resolvable indexing progress of a scanner indexer.
+type IndexProgress struct {
+	types.IndexProgress
+}
+
+// IndexProgress resolves the indexing progress of the scanner indexers.
+func (rs *rootResolver) IndexProgress(ctx context.Context) ([]*IndexProgress, error) {
+	var list []types.IndexProgress
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = repository.R().IndexProgress()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*IndexProgress, len(list))
+	for i, ip := range list {
+		res[i] = &IndexProgress{ip}
+	}
+	return res, nil
+}
@@ -20,16 +20,43 @@ type ApiResolver interface {
 	Version() string
 
 	// Epochs resolves a list of epochs for the given cursor and count.
-	Epochs(args struct {
+	Epochs(ctx context.Context, args struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*EpochList, error)
 
+	// ReorgHistory resolves a list of past chain reorgs for the given cursor and count.
+	ReorgHistory(ctx context.Context, args struct {
+		Cursor *Cursor
+		Count  int32
+	}) (*ReorgList, error)
+
+	// MethodCalls resolves a list of recorded calls of watched methods of the given contract.
+	MethodCalls(ctx context.Context, args struct {
+		Address common.Address
+		Method  *string
+		Cursor  *Cursor
+		Count   int32
+	}) (*MethodCallList, error)
+
+	// Logs resolves the logs matching the given filter pulled live from the node.
+	Logs(context.Context, struct{ Filter LogFilter }) ([]*Log, error)
+
+	// IndexedLogs resolves a list of log records matching the given filter pulled from the logs index.
+	IndexedLogs(ctx context.Context, args struct {
+		Filter LogFilter
+		Cursor *Cursor
+		Count  int32
+	}) (*LogList, error)
+
 	// Account resolves blockchain account by address.
-	Account(struct{ Address common.Address }) (*Account, error)
+	Account(context.Context, struct{ Address common.Address }) (*Account, error)
+
+	// AccountOverviews resolves live overviews of the given accounts loaded in a single batch.
+	AccountOverviews(context.Context, struct{ Addresses []common.Address }) ([]*AccountOverview, error)
 
 	// Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-	Contracts(*struct {
+	Contracts(context.Context, *struct {
 		ValidatedOnly bool
 		Cursor        *Cursor
 		Count         int32
@@ -41,7 +68,7 @@ type ApiResolver interface {
 	ValidateContract(*struct{ Contract ContractValidationInput }) (*Contract, error)
 
 	// Block resolves blockchain block by number or by hash. If neither is provided, the most recent block is given.
-	Block(*struct {
+	Block(context.Context, *struct {
 		Number *hexutil.Uint64
 		Hash   *common.Hash
 	}) (*Block, error)
@@ -53,12 +80,13 @@ type ApiResolver interface {
 	}) (*BlockList, error)
 
 	// Transaction resolves blockchain transaction by hash.
-	Transaction(*struct{ Hash common.Hash }) (*Transaction, error)
+	Transaction(context.Context, *struct{ Hash common.Hash }) (*Transaction, error)
 
 	// Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-	Transactions(*struct {
-		Cursor *Cursor
-		Count  int32
+	Transactions(context.Context, *struct {
+		Cursor    *Cursor
+		Count     int32
+		SkipTotal bool
 	}) (*TransactionList, error)
 
 	// OnBlock resolves subscription to new blocks' event broadcast.
@@ -67,6 +95,9 @@ type ApiResolver interface {
 	// OnTransaction resolves subscription to new transactions' event broadcast.
 	OnTransaction(ctx context.Context) <-chan *Transaction
 
+	// OnAccountBalanceChange resolves subscription to balance changes of the given account.
+	OnAccountBalanceChange(ctx context.Context, args struct{ Address common.Address }) <-chan *AccountBalanceChange
+
 	// CurrentEpoch resolves id of the current epoch.
 	CurrentEpoch() (hexutil.Uint64, error)
 
@@ -80,7 +111,7 @@ type ApiResolver interface {
 	StakersNum() (hexutil.Uint64, error)
 
 	// Staker resolves a staker information from SFC smart contract.
-	Staker(struct {
+	Staker(context.Context, struct {
 		Id      *hexutil.Big
 		Address *common.Address
 	}) (*Staker, error)
@@ -88,21 +119,24 @@ type ApiResolver interface {
 	// Stakers resolves a list of staker information from SFC smart contract.
 	Stakers() ([]*Staker, error)
 
+	// Validators resolves a list of validators sorted by total stake, optionally active only.
+	Validators(ctx context.Context, args struct{ ActiveOnly bool }) ([]*Staker, error)
+
 	// Delegation resolves details of a delegator by its address.
-	Delegation(*struct {
+	Delegation(context.Context, *struct {
 		Address common.Address
 		Staker  hexutil.Big
 	}) (*Delegation, error)
 
 	// DelegationsOf a list of delegations information of a staker.
-	DelegationsOf(*struct {
+	DelegationsOf(context.Context, *struct {
 		Staker hexutil.Big
 		Cursor *Cursor
 		Count  int32
 	}) (*DelegationList, error)
 
 	// DelegationsByAddress a list of own delegations by the account address.
-	DelegationsByAddress(*struct {
+	DelegationsByAddress(context.Context, *struct {
 		Address common.Address
 		Cursor  *Cursor
 		Count   int32
@@ -112,34 +146,94 @@ type ApiResolver interface {
 	Price(*struct{ To string }) (types.Price, error)
 
 	// GasPrice resolves the current amount of WEI for single Gas.
-	GasPrice() (hexutil.Uint64, error)
+	GasPrice(context.Context) (hexutil.Uint64, error)
+
+	// ChainMetrics resolves the chain throughput metrics of recently indexed blocks.
+	ChainMetrics() *ChainMetrics
+
+	// IndexProgress resolves the indexing progress of the scanner indexers.
+	IndexProgress(context.Context) ([]*IndexProgress, error)
+
+	// MaterializedViews resolves the refresh state of the materialized views.
+	MaterializedViews() []*MaterializedView
+
+	// CacheStats resolves the usage statistics of the in-memory cache.
+	CacheStats(context.Context) (*CacheStats, error)
+
+	// ContractCall resolves the raw return data of a read only call of the given contract.
+	ContractCall(ctx context.Context, args struct {
+		To    common.Address
+		Data  hexutil.Bytes
+		Block *hexutil.Uint64
+	}) (hexutil.Bytes, error)
 
 	// EstimateGas resolves the estimated amount of Gas required to perform
 	// transaction described by the input params.
-	EstimateGas(struct {
+	EstimateGas(context.Context, struct {
 		From  *common.Address
 		To    *common.Address
 		Value *hexutil.Big
 		Data  *string
 	}) (*hexutil.Uint64, error)
 
+	// FeeHistory resolves the base fee and the priority fee rewards at the given percentiles
+	// of the given number of the most recent blocks.
+	FeeHistory(context.Context, struct {
+		BlockCount        hexutil.Uint64
+		RewardPercentiles []float64
+	}) (*FeeHistory, error)
+
 	// EstimateRewards resolves reward estimation for the given address or amount staked.
-	EstimateRewards(*struct {
+	EstimateRewards(context.Context, *struct {
 		Address *common.Address
 		Amount  *hexutil.Uint64
 	}) (EstimatedRewards, error)
 
+	// EstimateStakingRewards resolves projected rewards of a delegation to a validator with an optional lock-up.
+	EstimateStakingRewards(ctx context.Context, args struct {
+		ValidatorId hexutil.Big
+		Amount      hexutil.Big
+		LockDays    int32
+	}) (*StakingRewardsEstimate, error)
+
 	// SfcRewardsCollectedAmount resolves the amount of collected rewards
 	// based on provided filtering criteria.
-	SfcRewardsCollectedAmount(struct {
+	SfcRewardsCollectedAmount(context.Context, struct {
 		Delegator *common.Address
 		Staker    *hexutil.Big
 		Since     *hexutil.Uint64
 		Until     *hexutil.Uint64
 	}) (hexutil.Big, error)
 
+	// TransactionReceipt resolves the receipt of the given transaction; null for a pending transaction.
+	TransactionReceipt(ctx context.Context, args struct{ Hash common.Hash }) (*TransactionReceipt, error)
+
+	// TransactionStatus resolves the processing status of the given transaction.
+	TransactionStatus(ctx context.Context, args struct{ Hash common.Hash }) (*TransactionStatus, error)
+
+	// ResolveName resolves the address registered for the given name; null if not registered.
+	ResolveName(ctx context.Context, args struct{ Name string }) (*common.Address, error)
+
+	// LookupAddress resolves the name of the given address; null if there is none.
+	LookupAddress(ctx context.Context, args struct{ Address common.Address }) (*string, error)
+
 	// SendTransaction sends raw signed and RLP encoded transaction to the blockchain.
-	SendTransaction(*struct{ Tx hexutil.Bytes }) (*Transaction, error)
+	SendTransaction(context.Context, *struct{ Tx hexutil.Bytes }) (*Transaction, error)
+
+	// FaucetSend sends the configured amount of native tokens from the server key to the given address.
+	FaucetSend(context.Context, struct{ To common.Address }) (*Transaction, error)
+
+	// RestartScanner restarts the block scanner, optionally from the given block.
+	RestartScanner(context.Context, struct{ FromBlock *hexutil.Uint64 }) (bool, error)
+
+	// ResetCacheStats resolves the usage statistics of the in-memory cache and restarts the counters.
+	ResetCacheStats(context.Context) (*CacheStats, error)
+
+	// RefreshView refreshes the materialized view of the given name right away.
+	RefreshView(context.Context, struct{ Name string }) (*MaterializedView, error)
+
+	// ReloadErc20LogoMap reloads the map of ERC20 token logos from the configured file.
+	ReloadErc20LogoMap(context.Context) (int32, error)
 
 	// DefiConfiguration resolves the current DeFi contract settings.
 	DefiConfiguration() (*DefiConfiguration, error)
@@ -147,9 +241,62 @@ type ApiResolver interface {
 	// DefiTokens resolves list of DeFi tokens available for the DeFi functions.
 	DefiTokens() ([]*DefiToken, error)
 
+	// PriceHistory resolves the persisted oracle price snapshots of the given DeFi token.
+	PriceHistory(ctx context.Context, args struct {
+		Token common.Address
+		From  *hexutil.Uint64
+		To    *hexutil.Uint64
+		Count int32
+	}) ([]*PriceSnapshot, error)
+
+	// TokenPrice resolves the current oracle price of the given token; null if the oracle has no feed.
+	TokenPrice(ctx context.Context, args struct{ Token common.Address }) (*TokenPrice, error)
+
+	// ConvertAmount resolves the value of an amount of a token in another token; null if any has no price feed.
+	ConvertAmount(ctx context.Context, args struct {
+		FromToken common.Address
+		ToToken   common.Address
+		Amount    hexutil.Big
+	}) (*hexutil.Big, error)
+
+	// TrendingTokens resolves the tokens with the highest number of transactions over the trailing window.
+	TrendingTokens(ctx context.Context, args struct {
+		Window int32
+		Count  int32
+	}) ([]*TokenActivity, error)
+
+	// Erc20TokenHolders resolves the top holders of the given ERC20 token sorted by their balance.
+	Erc20TokenHolders(ctx context.Context, args struct {
+		Token common.Address
+		Count int32
+	}) ([]*ERC20TokenHolder, error)
+
+	// Erc20SupplyHistory resolves the persisted total supply snapshots of the given ERC20 token.
+	Erc20SupplyHistory(ctx context.Context, args struct {
+		Token     common.Address
+		FromBlock hexutil.Uint64
+		ToBlock   hexutil.Uint64
+	}) ([]*ERC20SupplySnapshot, error)
+
+	// Erc20Transfers resolves list of Transfer events of the given ERC20 token
+	// optionally filtered by the sender and/or the recipient.
+	Erc20Transfers(ctx context.Context, args struct {
+		Token  common.Address
+		From   *common.Address
+		To     *common.Address
+		Cursor *Cursor
+		Count  int32
+	}) (*ERC20TransactionList, error)
+
 	// DefiUniswapPairs resolves a list of all pairs managed by the Uniswap core.
 	DefiUniswapPairs() []*UniswapPair
 
+	// UniswapPair resolves the Uniswap pair of the given tokens; null if the pair does not exist.
+	UniswapPair(ctx context.Context, args struct {
+		TokenA common.Address
+		TokenB common.Address
+	}) (*UniswapPair, error)
+
 	// DefiUniswapAmountsOut resolves a list of output amounts for the given
 	// input amount and a list of tokens to be used to make the swap operation.
 	DefiUniswapAmountsOut(*struct {
@@ -164,6 +311,14 @@ type ApiResolver interface {
 		Tokens    []common.Address
 	}) ([]hexutil.Big, error)
 
+	// UniswapQuote resolves a slippage adjusted quote of a swap
+	// of the given input amount along the given path of tokens.
+	UniswapQuote(context.Context, *struct {
+		AmountIn    hexutil.Big
+		Path        []common.Address
+		SlippageBps int32
+	}) (*UniswapQuote, error)
+
 	// DefiUniswapQuoteLiquidity resolves a list of optimal amounts of tokens
 	// to be added to both sides of a pair on addLiquidity call.
 	DefiUniswapQuoteLiquidity(*struct {
@@ -172,48 +327,81 @@ type ApiResolver interface {
 	}) ([]hexutil.Big, error)
 
 	// FMintAccount resolves details of a specified DeFi account.
-	FMintAccount(*struct{ Owner common.Address }) (*FMintAccount, error)
+	FMintAccount(context.Context, *struct{ Owner common.Address }) (*FMintAccount, error)
+
+	// FMintLiquidationRisk resolves the distance of a DeFi account from the minimal collateral ratio.
+	FMintLiquidationRisk(context.Context, *struct{ Owner common.Address }) (*FMintLiquidationRisk, error)
+
+	// FMintRewards resolves the pending rewards of a DeFi account.
+	FMintRewards(context.Context, *struct{ Owner common.Address }) (*FMintRewards, error)
+
+	// Erc20ApproveData resolves the unsigned ERC20 approve call, or the allowance revocation.
+	Erc20ApproveData(context.Context, *Erc20ApproveCallDataArgs) (*ERC20ApproveCallData, error)
+
+	// FMintDepositData resolves the unsigned call depositing fMint collateral.
+	FMintDepositData(context.Context, *FMintCallDataArgs) (*FMintCallData, error)
+
+	// FMintWithdrawData resolves the unsigned call withdrawing fMint collateral.
+	FMintWithdrawData(context.Context, *FMintCallDataArgs) (*FMintCallData, error)
+
+	// FMintMintData resolves the unsigned call minting fMint synthetic tokens.
+	FMintMintData(context.Context, *FMintCallDataArgs) (*FMintCallData, error)
+
+	// FMintRepayData resolves the unsigned call repaying fMint debt.
+	FMintRepayData(context.Context, *FMintCallDataArgs) (*FMintCallData, error)
+
+	// FMintFeesCollected resolves the fees paid on fMint minting over the trailing window.
+	FMintFeesCollected(ctx context.Context, args struct{ Window int32 }) (*ProtocolFees, error)
+
+	// DefiUniswapFeesCollected resolves the trading fees paid on Uniswap swaps over the trailing window.
+	DefiUniswapFeesCollected(ctx context.Context, args struct{ Window int32 }) (*ProtocolFees, error)
 
 	// FMintTokenAllowance resolves the amount of ERC20 tokens unlocked
 	// by the token owner for DeFi/fMint protocol operations.
-	FMintTokenAllowance(args *struct {
+	FMintTokenAllowance(ctx context.Context, args *struct {
 		Owner common.Address
 		Token common.Address
 	}) (hexutil.Big, error)
 
 	// Erc20Token resolves an instance of ERC20 token if available.
-	Erc20Token(*struct{ Token common.Address }) *ERC20Token
+	Erc20Token(context.Context, *struct{ Token common.Address }) (*ERC20Token, error)
 
 	Erc721Contract(*struct{ Token common.Address }) *ERC721Contract
 
+	// Erc20TokenInfo resolves the metadata of the given ERC20 token in a single round-trip to the node.
+	Erc20TokenInfo(ctx context.Context, args struct{ Token common.Address }) (*ERC20TokenInfo, error)
+
 	// Erc20TokenList resolves a list of instances of ERC20 tokens.
-	Erc20TokenList(struct{ Count int32 }) ([]*ERC20Token, error)
+	Erc20TokenList(context.Context, struct{ Count int32 }) ([]*ERC20Token, error)
+
+	// Erc20NonStandardTokens resolves a list of ERC20 tokens with non-standard return values.
+	Erc20NonStandardTokens() []*Erc20NonStandardToken
 
 	// Erc20Assets resolves a list of instances of ERC20 tokens for the given owner.
-	Erc20Assets(struct {
+	Erc20Assets(context.Context, struct {
 		Owner common.Address
 		Count int32
 	}) ([]*ERC20Token, error)
 
 	// Erc721Assets resolves a list of ERC721 tokens 
-	Erc721ContractList(struct {
+	Erc721ContractList(context.Context, struct {
 		Count int32
 	}) ([]*ERC721Contract, error)
 
  
 	// ErcTokenBalance resolves the current available balance of the specified token
 	// for the specified owner.
-	ErcTokenBalance(args *struct {
+	ErcTokenBalance(ctx context.Context, args *struct {
 		Owner common.Address
 		Token common.Address
 	}) (hexutil.Big, error)
 
 	// ErcTotalSupply resolves the current total supply of the specified token.
-	ErcTotalSupply(args *struct{ Token common.Address }) (hexutil.Big, error)
+	ErcTotalSupply(ctx context.Context, args *struct{ Token common.Address }) (hexutil.Big, error)
 
 	// ErcTokenAllowance resolves the current amount of ERC20 tokens unlocked
 	// by the token owner for the spender to be manipulated with.
-	ErcTokenAllowance(args *struct {
+	ErcTokenAllowance(ctx context.Context, args *struct {
 		Token   common.Address
 		Owner   common.Address
 		Spender common.Address
@@ -226,7 +414,7 @@ type ApiResolver interface {
 	GovContract(struct{ Address common.Address }) (*GovernanceContract, error)
 
 	// GovProposals represents list of joined proposals across all the Governance contracts.
-	GovProposals(struct {
+	GovProposals(context.Context, struct {
 		Cursor     *Cursor
 		Count      int32
 		ActiveOnly bool
@@ -234,19 +422,19 @@ type ApiResolver interface {
 
 	// TrxVolume resolves list of daily aggregations
 	// of the network transaction flow.
-	TrxVolume(args struct {
+	TrxVolume(ctx context.Context, args struct {
 		From *string
 		To   *string
 	}) ([]*DailyTrxVolume, error)
 
 	// TrxSpeed resolves the recent speed of the network in transactions processed per second.
-	TrxSpeed(args struct {
+	TrxSpeed(ctx context.Context, args struct {
 		Range int32
 	}) (float64, error)
 
 	// TrxGasSpeed resolves the gas consumption speed
 	// of the network in transactions processed per second.
-	TrxGasSpeed(args struct {
+	TrxGasSpeed(ctx context.Context, args struct {
 		Range int32
 		To    *string
 	}) (float64, error)
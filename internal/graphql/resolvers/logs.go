@@ -0,0 +1,147 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etc "github.com/ethereum/go-ethereum/core/types"
+)
+
+// extLogsSubRequests is the name of the response extension carrying
+// the number of node sub-requests made by logs queries.
+const extLogsSubRequests = "logsSubRequests"
+
+// LogFilter represents the filter of a live logs query.
+type LogFilter struct {
+	FromBlock hexutil.Uint64
+	ToBlock   hexutil.Uint64
+	Address   *[]common.Address
+	Topics    *[][]common.Hash
+}
+
+// Log represents resolvable log record emitted by a transaction.
+type Log struct {
+	etc.Log
+}
+
+// LogList represents resolvable list of indexed log record edges structure.
+type LogList struct {
+	types.IndexedLogList
+}
+
+// LogListEdge represents a single edge of an indexed log record list structure.
+type LogListEdge struct {
+	Log *Log
+}
+
+// Logs resolves the logs matching the given filter pulled live from the node.
+func (rs *rootResolver) Logs(ctx context.Context, args struct{ Filter LogFilter }) ([]*Log, error) {
+	adr, topics := args.Filter.match()
+	logs, sub, err := repository.R().Logs(ctx, uint64(args.Filter.FromBlock), uint64(args.Filter.ToBlock), adr, topics)
+	AddExtensionCount(ctx, extLogsSubRequests, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Log, len(logs))
+	for i := range logs {
+		list[i] = &Log{Log: logs[i]}
+	}
+	return list, nil
+}
+
+// IndexedLogs resolves a list of log records matching the given filter pulled from the logs index.
+func (rs *rootResolver) IndexedLogs(ctx context.Context, args struct {
+	Filter LogFilter
+	Cursor *Cursor
+	Count  int32
+}) (*LogList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	adr, topics := args.Filter.match()
+	var ll *types.IndexedLogList
+	err := resolveWithin(ctx, func() (err error) {
+		ll, err = repository.R().IndexedLogs(uint64(args.Filter.FromBlock), uint64(args.Filter.ToBlock), adr, topics, (*string)(args.Cursor), args.Count)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not get indexed logs; %s", err.Error())
+		return nil, err
+	}
+	return &LogList{IndexedLogList: *ll}, nil
+}
+
+// match provides the addresses and the topics the logs of the filter must match.
+func (lf *LogFilter) match() ([]common.Address, [][]common.Hash) {
+	var adr []common.Address
+	if lf.Address != nil {
+		adr = *lf.Address
+	}
+	var topics [][]common.Hash
+	if lf.Topics != nil {
+		topics = *lf.Topics
+	}
+	return adr, topics
+}
+
+// TotalCount resolves the total number of log records in the list.
+func (ll *LogList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(ll.Total)
+}
+
+// PageInfo resolves the current page information for the log record list.
+func (ll *LogList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(ll.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(ll.Collection[0].Pk())
+	last := Cursor(ll.Collection[len(ll.Collection)-1].Pk())
+	return NewListPageInfo(&first, &last, !ll.IsEnd, !ll.IsStart)
+}
+
+// Edges resolves list of edges for the log record list.
+func (ll *LogList) Edges() []*LogListEdge {
+	edges := make([]*LogListEdge, len(ll.Collection))
+	for i, il := range ll.Collection {
+		edges[i] = &LogListEdge{Log: &Log{Log: il.Log}}
+	}
+	return edges
+}
+
+// Cursor resolves a cursor of an edge in the edges list.
+func (lle *LogListEdge) Cursor() Cursor {
+	return Cursor(types.IndexedLogPk(lle.Log.Log.BlockNumber, uint64(lle.Log.Index)))
+}
+
+// Data resolves the non-indexed data of the log record.
+func (l *Log) Data() hexutil.Bytes {
+	return l.Log.Data
+}
+
+// BlockNumber resolves the number of the block containing the log record.
+func (l *Log) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(l.Log.BlockNumber)
+}
+
+// TransactionHash resolves the hash of the transaction emitting the log record.
+func (l *Log) TransactionHash() common.Hash {
+	return l.TxHash
+}
+
+// TransactionIndex resolves the index of the transaction emitting the log record in the block.
+func (l *Log) TransactionIndex() hexutil.Uint64 {
+	return hexutil.Uint64(l.TxIndex)
+}
+
+// LogIndex resolves the index of the log record in the block.
+func (l *Log) LogIndex() hexutil.Uint64 {
+	return hexutil.Uint64(l.Index)
+}
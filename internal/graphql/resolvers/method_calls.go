@@ -0,0 +1,89 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// MethodCallList represents resolvable list of watched method call edges structure.
+type MethodCallList struct {
+	types.MethodCallList
+}
+
+// MethodCallListEdge represents a single edge of a watched method call list structure.
+type MethodCallListEdge struct {
+	Call *MethodCall
+}
+
+// MethodCall represents resolvable call of a watched contract method.
+type MethodCall struct {
+	types.MethodCall
+}
+
+// MethodCalls resolves a list of recorded calls of watched methods of the given contract.
+func (rs *rootResolver) MethodCalls(ctx context.Context, args struct {
+	Address common.Address
+	Method  *string
+	Cursor  *Cursor
+	Count   int32
+}) (*MethodCallList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	var ml *types.MethodCallList
+	err := resolveWithin(ctx, func() (err error) {
+		ml, err = repository.R().MethodCalls(&args.Address, args.Method, (*string)(args.Cursor), args.Count)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not get method calls of %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	return &MethodCallList{MethodCallList: *ml}, nil
+}
+
+// TotalCount resolves the total number of method calls in the list.
+func (ml *MethodCallList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(ml.Total)
+}
+
+// PageInfo resolves the current page information for the method call list.
+func (ml *MethodCallList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(ml.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(ml.Collection[0].Pk())
+	last := Cursor(ml.Collection[len(ml.Collection)-1].Pk())
+	return NewListPageInfo(&first, &last, !ml.IsEnd, !ml.IsStart)
+}
+
+// Edges resolves list of edges for the method call list.
+func (ml *MethodCallList) Edges() []*MethodCallListEdge {
+	edges := make([]*MethodCallListEdge, len(ml.Collection))
+	for i, c := range ml.Collection {
+		edges[i] = &MethodCallListEdge{Call: &MethodCall{MethodCall: *c}}
+	}
+	return edges
+}
+
+// Cursor resolves a cursor of an edge in the edges list.
+func (mle *MethodCallListEdge) Cursor() Cursor {
+	return Cursor(mle.Call.Pk())
+}
+
+// Method resolves the name of the called method; the selector is used
+// if the method signature is not known.
+func (mc *MethodCall) Method() string {
+	if mc.Name == "" {
+		return mc.Selector.String()
+	}
+	return mc.Name
+}
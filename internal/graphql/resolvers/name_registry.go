@@ -0,0 +1,36 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ResolveName resolves the address registered for the given human-readable name;
+// null is provided if the name is not registered.
+func (rs *rootResolver) ResolveName(ctx context.Context, args struct{ Name string }) (*common.Address, error) {
+	var val *common.Address
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().ResolveName(args.Name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// LookupAddress resolves the human-readable name of the given address;
+// null is provided if the address has no name.
+func (rs *rootResolver) LookupAddress(ctx context.Context, args struct{ Address common.Address }) (*string, error) {
+	var val *string
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().LookupAddress(&args.Address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
+}
@@ -0,0 +1,48 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NetWorthPoint represents resolvable USD value of an account portfolio at a block.
+type NetWorthPoint struct {
+	types.NetWorthPoint
+}
+
+// NetWorthHistory resolves the value of the account portfolio in the requested currency,
+// USD by default, at blocks of the given range separated by the given interval.
+func (acc *Account) NetWorthHistory(ctx context.Context, args struct {
+	FromBlock hexutil.Uint64
+	ToBlock   hexutil.Uint64
+	Interval  hexutil.Uint64
+	Currency  string
+}) ([]*NetWorthPoint, error) {
+	rate, err := currencyRate(args.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*types.NetWorthPoint
+	err = resolveWithin(ctx, func() (err error) {
+		list, err = repository.R().NetWorthHistory(&acc.Address, uint64(args.FromBlock), uint64(args.ToBlock), uint64(args.Interval))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*NetWorthPoint, len(list))
+	for i, pt := range list {
+		res[i] = &NetWorthPoint{NetWorthPoint: *convertNetWorth(pt, rate)}
+	}
+	return res, nil
+}
+
+// Timestamp resolves the time stamp of the block as a UNIX timestamp.
+func (pt *NetWorthPoint) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(pt.Time.Unix())
+}
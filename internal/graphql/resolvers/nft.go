@@ -0,0 +1,119 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NftHoldingList represents resolvable list of NFT holding edges structure.
+type NftHoldingList struct {
+	types.NftHoldingList
+}
+
+// NftHoldingListEdge represents a single edge of an NFT holding list structure.
+type NftHoldingListEdge struct {
+	Holding *NftHolding
+}
+
+// NftHolding represents resolvable ERC721/ERC1155 token held by an account.
+type NftHolding struct {
+	types.NftHolding
+}
+
+// Nfts resolves list of ERC721/ERC1155 tokens held by the account.
+func (acc *Account) Nfts(ctx context.Context, args struct {
+	Cursor *Cursor
+	Count  int32
+	Token  *common.Address
+}) (*NftHoldingList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	var nl *types.NftHoldingList
+	err := resolveWithin(ctx, func() (err error) {
+		nl, err = repository.R().NftHoldings(&acc.Address, args.Token, (*string)(args.Cursor), args.Count)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not get NFT holdings of %s; %s", acc.Address.String(), err.Error())
+		return nil, err
+	}
+	return &NftHoldingList{NftHoldingList: *nl}, nil
+}
+
+// TotalCount resolves the total number of NFT holdings in the list.
+func (nl *NftHoldingList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(nl.Total)
+}
+
+// PageInfo resolves the current page information for the NFT holding list.
+func (nl *NftHoldingList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(nl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := nftHoldingCursor(nl.Collection[0])
+	last := nftHoldingCursor(nl.Collection[len(nl.Collection)-1])
+	return NewListPageInfo(&first, &last, !nl.IsEnd, !nl.IsStart)
+}
+
+// Edges resolves list of edges for the NFT holding list.
+func (nl *NftHoldingList) Edges() []*NftHoldingListEdge {
+	edges := make([]*NftHoldingListEdge, len(nl.Collection))
+	for i, h := range nl.Collection {
+		edges[i] = &NftHoldingListEdge{Holding: &NftHolding{NftHolding: *h}}
+	}
+	return edges
+}
+
+// Cursor resolves a cursor of an edge in the edges list.
+func (nle *NftHoldingListEdge) Cursor() Cursor {
+	return nftHoldingCursor(&nle.Holding.NftHolding)
+}
+
+// nftHoldingCursor provides the list cursor of the given NFT holding.
+func nftHoldingCursor(nh *types.NftHolding) Cursor {
+	return Cursor(types.NftHoldingPk(&nh.Owner, &nh.Token, nh.TokenId.ToInt()))
+}
+
+// Erc721Contract resolves the ERC721 contract of the token, if the token is ERC721.
+func (nh *NftHolding) Erc721Contract() *ERC721Contract {
+	if nh.TokenType != types.AccountTypeERC721Contract {
+		return nil
+	}
+	return NewErc721Contract(&nh.Token)
+}
+
+// Erc1155Contract resolves the ERC1155 contract of the token, if the token is ERC1155.
+func (nh *NftHolding) Erc1155Contract() *ERC1155Contract {
+	if nh.TokenType != types.AccountTypeERC1155Contract {
+		return nil
+	}
+	return NewErc1155Contract(&nh.Token)
+}
+
+// MetadataUri resolves URI of the token metadata JSON. The URI is loaded
+// from the contract only if requested; the failure to load it is not an error.
+func (nh *NftHolding) MetadataUri() *string {
+	var uri string
+	var err error
+
+	if nh.TokenType == types.AccountTypeERC721Contract {
+		uri, err = repository.R().Erc721TokenURI(&nh.Token, nh.TokenId.ToInt())
+	} else {
+		uri, err = repository.R().Erc1155Uri(&nh.Token, nh.TokenId.ToInt())
+	}
+
+	if err != nil {
+		log.Debugf("NFT %s #%s metadata URI not available; %s", nh.Token.String(), nh.TokenId.String(), err.Error())
+		return nil
+	}
+	return &uri
+}
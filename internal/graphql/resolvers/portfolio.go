@@ -0,0 +1,37 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+)
+
+// PortfolioDistribution represents resolvable breakdown of the account portfolio value by tokens.
+type PortfolioDistribution struct {
+	types.PortfolioDistribution
+}
+
+// PortfolioDistribution resolves the breakdown of the current value of the account portfolio by tokens
+// in the requested currency, USD by default.
+func (acc *Account) PortfolioDistribution(ctx context.Context, args struct{ Currency string }) (*PortfolioDistribution, error) {
+	rate, err := currencyRate(args.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// fresh read demanded?
+	if repository.IsFreshRead(ctx) {
+		repository.R().RefreshPortfolio(&acc.Address)
+	}
+
+	var pd *types.PortfolioDistribution
+	err = resolveWithin(ctx, func() (err error) {
+		pd, err = repository.R().PortfolioDistribution(&acc.Address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PortfolioDistribution{*convertPortfolio(pd, rate)}, nil
+}
@@ -0,0 +1,86 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// priceHistoryMaxCount represents the max number of price snapshots provided by a single request.
+const priceHistoryMaxCount = 1000
+
+// priceHistoryDefaultPeriod represents the period covered by the price history
+// if the starting time is not specified.
+const priceHistoryDefaultPeriod = 30 * 24 * time.Hour
+
+// PriceSnapshot represents resolvable oracle price of a token observed at a point in time.
+type PriceSnapshot struct {
+	types.PriceSnapshot
+}
+
+// PriceHistory resolves the persisted oracle price snapshots of the given DeFi token.
+func (rs *rootResolver) PriceHistory(ctx context.Context, args struct {
+	Token common.Address
+	From  *hexutil.Uint64
+	To    *hexutil.Uint64
+	Count int32
+}) ([]*PriceSnapshot, error) {
+	// decode the time range; default to the last month
+	to := time.Now().UTC()
+	if args.To != nil {
+		to = time.Unix(int64(*args.To), 0).UTC()
+	}
+	from := to.Add(-priceHistoryDefaultPeriod)
+	if args.From != nil {
+		from = time.Unix(int64(*args.From), 0).UTC()
+	}
+
+	// limit the number of snapshots
+	if args.Count <= 0 || args.Count > priceHistoryMaxCount {
+		args.Count = priceHistoryMaxCount
+	}
+
+	var list []*types.PriceSnapshot
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = repository.R().PriceHistory(&args.Token, from, to, args.Count)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*PriceSnapshot, len(list))
+	for i, ps := range list {
+		res[i] = &PriceSnapshot{PriceSnapshot: *ps}
+	}
+	return res, nil
+}
+
+// Token resolves the address of the token.
+func (ps *PriceSnapshot) Token() common.Address {
+	return ps.PriceSnapshot.Token
+}
+
+// Price resolves the oracle price of the token.
+func (ps *PriceSnapshot) Price() hexutil.Big {
+	return ps.PriceSnapshot.Price
+}
+
+// PriceDecimals resolves the number of decimals of the price.
+func (ps *PriceSnapshot) PriceDecimals() int32 {
+	return ps.PriceSnapshot.PriceDecimals
+}
+
+// BlockNumber resolves the chain head block number at the time of the snapshot.
+func (ps *PriceSnapshot) BlockNumber() hexutil.Uint64 {
+	return ps.PriceSnapshot.BlockNumber
+}
+
+// Timestamp resolves the time of the snapshot as a UNIX timestamp.
+func (ps *PriceSnapshot) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(ps.PriceSnapshot.Time.Unix())
+}
@@ -0,0 +1,69 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// ProtocolFees represents resolvable fees collected by a protocol over a time window.
+type ProtocolFees struct {
+	types.ProtocolFees
+}
+
+// TokenFee represents resolvable protocol fees collected in a single token.
+type TokenFee struct {
+	types.TokenFee
+}
+
+// FMintFeesCollected resolves the fees paid on fMint minting over the trailing window given in hours.
+func (rs *rootResolver) FMintFeesCollected(ctx context.Context, args struct{ Window int32 }) (*ProtocolFees, error) {
+	var pf *types.ProtocolFees
+	err := resolveWithin(ctx, func() (err error) {
+		pf, err = repository.R().FMintFeesCollected(feesWindow(args.Window))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ProtocolFees{*pf}, nil
+}
+
+// DefiUniswapFeesCollected resolves the trading fees paid on Uniswap swaps
+// over the trailing window given in hours.
+func (rs *rootResolver) DefiUniswapFeesCollected(ctx context.Context, args struct{ Window int32 }) (*ProtocolFees, error) {
+	var pf *types.ProtocolFees
+	err := resolveWithin(ctx, func() (err error) {
+		pf, err = repository.R().UniswapFeesCollected(feesWindow(args.Window))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ProtocolFees{*pf}, nil
+}
+
+// feesWindow converts the fees window in hours to duration.
+func feesWindow(hours int32) time.Duration {
+	if hours <= 0 {
+		hours = 1
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// Since resolves the start of the aggregation window as a UNIX timestamp.
+func (pf *ProtocolFees) Since() hexutil.Uint64 {
+	return hexutil.Uint64(pf.ProtocolFees.Since.Unix())
+}
+
+// Fees resolves the list of fees collected by token.
+func (pf *ProtocolFees) Fees() []*TokenFee {
+	list := make([]*TokenFee, len(pf.ProtocolFees.Fees))
+	for i := range pf.ProtocolFees.Fees {
+		list[i] = &TokenFee{pf.ProtocolFees.Fees[i]}
+	}
+	return list
+}
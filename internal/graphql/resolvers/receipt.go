@@ -0,0 +1,65 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TransactionReceipt represents resolvable receipt of a processed transaction.
+type TransactionReceipt struct {
+	types.TransactionReceipt
+}
+
+// DecodedEvent represents resolvable log record decoded by the ABI of the emitting contract.
+type DecodedEvent struct {
+	types.DecodedEvent
+}
+
+// TransactionReceipt resolves the receipt of the given transaction;
+// null is provided for a pending transaction.
+func (rs *rootResolver) TransactionReceipt(ctx context.Context, args struct{ Hash common.Hash }) (*TransactionReceipt, error) {
+	var rec *types.TransactionReceipt
+	err := resolveWithin(ctx, func() (err error) {
+		rec, err = repository.R().TransactionReceipt(&args.Hash)
+		return err
+	})
+	if err != nil || rec == nil {
+		return nil, err
+	}
+	return &TransactionReceipt{TransactionReceipt: *rec}, nil
+}
+
+// EffectiveGasPrice resolves the gas price actually paid per unit of gas.
+func (rec *TransactionReceipt) EffectiveGasPrice() hexutil.Big {
+	if rec.TransactionReceipt.EffectiveGasPrice == nil {
+		return hexutil.Big{}
+	}
+	return *rec.TransactionReceipt.EffectiveGasPrice
+}
+
+// Logs resolves the log records emitted by the transaction.
+func (rec *TransactionReceipt) Logs() []*Log {
+	list := make([]*Log, len(rec.TransactionReceipt.Logs))
+	for i := range rec.TransactionReceipt.Logs {
+		list[i] = &Log{Log: rec.TransactionReceipt.Logs[i]}
+	}
+	return list
+}
+
+// Event resolves the log record decoded by the ABI of the validated emitting contract.
+func (l *Log) Event() (*DecodedEvent, error) {
+	ev, err := repository.R().DecodeLog(&l.Log)
+	if err != nil || ev == nil {
+		return nil, err
+	}
+	return &DecodedEvent{DecodedEvent: *ev}, nil
+}
+
+// Fields resolves the decoded arguments of the event.
+func (de *DecodedEvent) Fields() []types.DecodedEventField {
+	return de.DecodedEvent.Fields
+}
@@ -0,0 +1,55 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/config"
+	"context"
+)
+
+// fieldRoles maps resolvable fields to the role a client needs to see their value.
+// Fields not listed here are public. Nullable fields are redacted to null; non-null fields
+// resolve to their empty value instead, and an authorization error on them nulls their parent.
+var fieldRoles = map[string]string{
+	"Contract.sourceCode": auth.RoleAdmin,
+	"Transaction.trace":   auth.RoleAdmin,
+	"Account.storageAt":   auth.RoleAdmin,
+}
+
+// fieldsAlwaysRestricted lists the fields of fieldRoles exposing the node internals;
+// they resolve to null for clients without the role even if the redaction is off.
+var fieldsAlwaysRestricted = map[string]bool{
+	"Transaction.trace": true,
+	"Account.storageAt": true,
+}
+
+// redacted checks if the value of the given field should be withheld
+// from the client of the request. If the redaction is configured to report
+// an error, the authorization error is returned as well.
+func redacted(ctx context.Context, field string) (bool, error) {
+	role, ok := fieldRoles[field]
+	if !ok {
+		return false, nil
+	}
+
+	mode := config.RedactionOff
+	if cfg != nil && cfg.Auth.Redaction != "" {
+		mode = cfg.Auth.Redaction
+	}
+	if mode == config.RedactionOff {
+		if !fieldsAlwaysRestricted[field] {
+			return false, nil
+		}
+		mode = config.RedactionNull
+	}
+
+	err := auth.Require(ctx, role)
+	if err == nil {
+		return false, nil
+	}
+
+	if mode == config.RedactionError {
+		return true, err
+	}
+	return true, nil
+}
@@ -0,0 +1,150 @@
+package resolvers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"context"
+	"encoding/json"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// redactTestSchema is a minimal schema exposing restricted fields next to public ones.
+const redactTestSchema = `
+schema { query: Query }
+type Query { contract: Contract transaction: Transaction! version: String! }
+type Contract { name: String! sourceCode: String! }
+type Transaction { nonce: Long! trace: String }
+scalar Long
+`
+
+// redactTestQuery implements the root resolver of the redaction test schema.
+type redactTestQuery struct{}
+
+// Contract resolves a test contract with known source code.
+func (*redactTestQuery) Contract() *Contract {
+	return NewContract(&types.Contract{Name: "Token", SourceCode: "contract Token {}"})
+}
+
+// Transaction resolves a test transaction.
+func (*redactTestQuery) Transaction() *Transaction {
+	return &Transaction{Transaction: types.Transaction{Nonce: 7}}
+}
+
+// Version resolves a public root field.
+func (*redactTestQuery) Version() string {
+	return "1.0"
+}
+
+// TestFieldRedaction tests restricted fields are redacted while the rest of the query resolves.
+func TestFieldRedaction(t *testing.T) {
+	schema := graphql.MustParseSchema(redactTestSchema, &redactTestQuery{}, graphql.UseFieldResolvers())
+
+	admin := auth.WithClaims(context.Background(), &auth.Claims{Subject: "ops", Roles: []string{auth.RoleAdmin}})
+	user := auth.WithClaims(context.Background(), &auth.Claims{Subject: "dapp", Roles: []string{"user"}})
+
+	tests := []struct {
+		name     string
+		mode     string
+		ctx      context.Context
+		query    string
+		contract bool
+		source   string
+		errors   int
+	}{
+		{"disabled", config.RedactionOff, context.Background(), `{ version contract { name sourceCode } }`, true, "contract Token {}", 0},
+		{"anonymous null", config.RedactionNull, context.Background(), `{ version contract { name sourceCode } }`, true, "", 0},
+		{"forbidden null", config.RedactionNull, user, `{ version contract { name sourceCode } transaction { nonce trace } }`, true, "", 0},
+		{"forbidden error", config.RedactionError, user, `{ version contract { name sourceCode } }`, false, "", 1},
+		{"forbidden trace error", config.RedactionError, user, `{ version contract { name } transaction { nonce trace } }`, true, "", 1},
+		{"permitted", config.RedactionError, admin, `{ version contract { name sourceCode } }`, true, "contract Token {}", 0},
+	}
+
+	defer func(c *config.Config) { cfg = c }(cfg)
+	for _, tc := range tests {
+		cfg = &config.Config{Auth: config.Auth{Redaction: tc.mode}}
+		res := schema.Exec(tc.ctx, tc.query, "", nil)
+
+		if len(res.Errors) != tc.errors {
+			t.Errorf("%s: expected %d errors, got %v", tc.name, tc.errors, res.Errors)
+		}
+		if tc.errors > 0 && len(res.Errors) > 0 && res.Errors[0].Message != auth.ErrForbidden.Error() {
+			t.Errorf("%s: unexpected error %s", tc.name, res.Errors[0].Message)
+		}
+
+		var data struct {
+			Version  string
+			Contract *struct {
+				Name       string
+				SourceCode string
+			}
+			Transaction *struct {
+				Nonce string
+				Trace *string
+			}
+		}
+		if err := json.Unmarshal(res.Data, &data); err != nil {
+			t.Fatalf("%s: can not decode response; %s", tc.name, err.Error())
+		}
+
+		// the unrestricted fields always resolve
+		if data.Version != "1.0" {
+			t.Errorf("%s: expected version 1.0, got %q", tc.name, data.Version)
+		}
+		if data.Transaction != nil && (data.Transaction.Nonce != "0x7" || data.Transaction.Trace != nil) {
+			t.Errorf("%s: unexpected transaction %+v", tc.name, *data.Transaction)
+		}
+
+		// an error on the non-null field nulls its parent
+		if (data.Contract != nil) != tc.contract {
+			t.Fatalf("%s: unexpected contract %+v", tc.name, data.Contract)
+		}
+		if data.Contract != nil && (data.Contract.Name != "Token" || data.Contract.SourceCode != tc.source) {
+			t.Errorf("%s: unexpected contract %+v", tc.name, *data.Contract)
+		}
+	}
+}
+
+// TestFieldRedactionDefault tests anonymous clients get no traces and no raw storage
+// under the default configuration with the redaction off.
+func TestFieldRedactionDefault(t *testing.T) {
+	schema := graphql.MustParseSchema(redactTestSchema, &redactTestQuery{}, graphql.UseFieldResolvers())
+	admin := auth.WithClaims(context.Background(), &auth.Claims{Subject: "ops", Roles: []string{auth.RoleAdmin}})
+
+	defer func(c *config.Config) { cfg = c }(cfg)
+	for _, mode := range []string{"", config.RedactionOff} {
+		cfg = &config.Config{Auth: config.Auth{Redaction: mode}}
+
+		res := schema.Exec(context.Background(), `{ transaction { nonce trace } contract { sourceCode } }`, "", nil)
+		if len(res.Errors) != 0 {
+			t.Fatalf("%q: unexpected errors %v", mode, res.Errors)
+		}
+		var data struct {
+			Transaction struct {
+				Nonce string
+				Trace *string
+			}
+			Contract struct {
+				SourceCode string
+			}
+		}
+		if err := json.Unmarshal(res.Data, &data); err != nil {
+			t.Fatalf("%q: can not decode response; %s", mode, err.Error())
+		}
+		if data.Transaction.Nonce != "0x7" || data.Transaction.Trace != nil {
+			t.Errorf("%q: expected no trace, got %+v", mode, data.Transaction)
+		}
+		if data.Contract.SourceCode != "contract Token {}" {
+			t.Errorf("%q: expected the source code, got %q", mode, data.Contract.SourceCode)
+		}
+
+		if hide, _ := redacted(context.Background(), "Account.storageAt"); !hide {
+			t.Errorf("%q: expected raw storage withheld from anonymous clients", mode)
+		}
+		if hide, _ := redacted(admin, "Account.storageAt"); hide {
+			t.Errorf("%q: expected raw storage visible to admin", mode)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ReorgList represents resolvable list of chain reorg edges structure.
+type ReorgList struct {
+	types.ReorgList
+}
+
+// ReorgListEdge represents a single edge of a chain reorg list structure.
+type ReorgListEdge struct {
+	Reorg *Reorg
+}
+
+// Reorg represents resolvable chain reorg record.
+type Reorg struct {
+	types.Reorg
+}
+
+// ReorgHistory resolves a list of past chain reorgs for the given cursor and count.
+func (rs *rootResolver) ReorgHistory(ctx context.Context, args struct {
+	Cursor *Cursor
+	Count  int32
+}) (*ReorgList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	var rl *types.ReorgList
+	err := resolveWithin(ctx, func() (err error) {
+		rl, err = repository.R().Reorgs((*string)(args.Cursor), args.Count)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not get reorg list; %s", err.Error())
+		return nil, err
+	}
+	return &ReorgList{ReorgList: *rl}, nil
+}
+
+// TotalCount resolves the total number of reorgs in the history.
+func (rl *ReorgList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(rl.Total)
+}
+
+// PageInfo resolves the current page information for the reorg list.
+func (rl *ReorgList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(rl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(rl.Collection[0].Id.String())
+	last := Cursor(rl.Collection[len(rl.Collection)-1].Id.String())
+	return NewListPageInfo(&first, &last, !rl.IsEnd, !rl.IsStart)
+}
+
+// Edges resolves list of edges for the reorg list.
+func (rl *ReorgList) Edges() []*ReorgListEdge {
+	edges := make([]*ReorgListEdge, len(rl.Collection))
+	for i, r := range rl.Collection {
+		edges[i] = &ReorgListEdge{Reorg: &Reorg{Reorg: *r}}
+	}
+	return edges
+}
+
+// Cursor resolves a cursor of an edge in the edges list.
+func (rle *ReorgListEdge) Cursor() Cursor {
+	return Cursor(rle.Reorg.Id.String())
+}
+
+// DetectedAt resolves the time the reorg was detected as a UNIX timestamp.
+func (r *Reorg) DetectedAt() hexutil.Uint64 {
+	return hexutil.Uint64(r.Detected.Unix())
+}
@@ -0,0 +1,65 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// RewardSources represents resolvable rewards claimed by an account grouped by the validator.
+type RewardSources struct {
+	types.RewardSources
+}
+
+// RewardSource represents resolvable rewards claimed by an account from a single validator.
+type RewardSource struct {
+	types.RewardSource
+}
+
+// RewardSources resolves the rewards claimed by the account over the trailing window
+// given in hours grouped by the validator they were earned on.
+func (acc *Account) RewardSources(ctx context.Context, args struct {
+	Window    int32
+	Ascending bool
+}) (*RewardSources, error) {
+	if args.Window <= 0 {
+		args.Window = 1
+	}
+
+	var rs *types.RewardSources
+	err := resolveWithin(ctx, func() (err error) {
+		rs, err = repository.R().RewardSources(&acc.Address, time.Duration(args.Window)*time.Hour, args.Ascending)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not get reward sources of %s; %s", acc.Address.String(), err.Error())
+		return nil, err
+	}
+	return &RewardSources{*rs}, nil
+}
+
+// Since resolves the start of the aggregation window as a UNIX timestamp.
+func (rs *RewardSources) Since() hexutil.Uint64 {
+	return hexutil.Uint64(rs.RewardSources.Since.Unix())
+}
+
+// Sources resolves the list of validators the rewards were earned on.
+func (rs *RewardSources) Sources() []*RewardSource {
+	list := make([]*RewardSource, len(rs.RewardSources.Sources))
+	for i := range rs.RewardSources.Sources {
+		list[i] = &RewardSource{rs.RewardSources.Sources[i]}
+	}
+	return list
+}
+
+// Validator resolves the detail of the validator the rewards were earned on.
+func (src *RewardSource) Validator() (*Staker, error) {
+	st, err := repository.R().Validator(&src.ValidatorId)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaker(st), nil
+}
@@ -41,6 +41,17 @@ type rootResolver struct {
 	unsubscribeOnTrx chan string
 	trxSubscribers   map[string]*subscriptOnTrx
 	onTrxEvents      chan *types.Transaction
+
+	// balance change subscriptions management; fed by the transaction events
+	subscribeOnBalance   chan *subscriptOnBalance
+	unsubscribeOnBalance chan string
+	balanceSubscribers   map[string]*subscriptOnBalance
+
+	// transactions submit rate limiter
+	trxSubmits *submitLimiter
+
+	// raw contract calls passthrough switch
+	allowRawCall bool
 }
 
 // log represents the logger to be used by the repository.
@@ -87,6 +98,16 @@ func New() ApiResolver {
 		unsubscribeOnTrx: make(chan string, subscriptionQueueCapacity),
 		trxSubscribers:   make(map[string]*subscriptOnTrx, subscriptionInitialCapacity),
 		onTrxEvents:      make(chan *types.Transaction, onBlockChannelCapacity),
+
+		// balance change events subscription basics
+		subscribeOnBalance:   make(chan *subscriptOnBalance, subscriptionQueueCapacity),
+		unsubscribeOnBalance: make(chan string, subscriptionQueueCapacity),
+		balanceSubscribers:   make(map[string]*subscriptOnBalance, subscriptionInitialCapacity),
+
+		// limit transactions submitted by a single client
+		trxSubmits: newSubmitLimiter(cfg.Server.TrxSubmitLimit),
+
+		allowRawCall: cfg.Server.AllowRawCall,
 	}
 
 	// pass subscription data source channels to the service manager
@@ -136,17 +157,24 @@ func (rs *rootResolver) run() {
 		case id := <-rs.unsubscribeOnTrx:
 			delete(rs.trxSubscribers, id)
 
+		case id := <-rs.unsubscribeOnBalance:
+			delete(rs.balanceSubscribers, id)
+
 		case sub := <-rs.subscribeOnBlock:
 			rs.addBlockSubscriber(sub)
 
 		case sub := <-rs.subscribeOnTrx:
 			rs.addTrxSubscriber(sub)
 
+		case sub := <-rs.subscribeOnBalance:
+			rs.addBalanceSubscriber(sub)
+
 		case evt := <-rs.onBlockEvents:
 			rs.dispatchOnBlock(evt)
 
 		case evt := <-rs.onTrxEvents:
 			rs.dispatchOnTransaction(evt)
+			rs.dispatchOnBalance(evt)
 		}
 	}
 }
@@ -0,0 +1,23 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/svc"
+	"context"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RestartScanner restarts the block scanner, optionally from the given block.
+// Only administrators are allowed to restart the scanner.
+func (rs *rootResolver) RestartScanner(ctx context.Context, args struct{ FromBlock *hexutil.Uint64 }) (bool, error) {
+	if err := auth.Require(ctx, auth.RoleAdmin); err != nil {
+		return false, err
+	}
+
+	if err := svc.Manager().RestartScanner((*uint64)(args.FromBlock)); err != nil {
+		log.Errorf("can not restart block scanner; %s", err.Error())
+		return false, err
+	}
+	return true, nil
+}
@@ -2,7 +2,9 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
+	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -32,29 +34,29 @@ func (rs *rootResolver) StakersNum() (hexutil.Uint64, error) {
 }
 
 // Staker resolves a validator information from SFC smart contract.
-func (rs *rootResolver) Staker(args struct {
+func (rs *rootResolver) Staker(ctx context.Context, args struct {
 	Id      *hexutil.Big
 	Address *common.Address
 }) (*Staker, error) {
-	// by ID or by address?
-	if args.Id != nil {
-		st, err := repository.R().Validator(args.Id)
-		if err != nil {
-			return nil, err
+	var st *types.Validator
+	err := resolveWithin(ctx, func() (err error) {
+		// by ID or by address?
+		if args.Id != nil {
+			st, err = repository.R().Validator(args.Id)
+			return err
 		}
-		return NewStaker(st), err
-	}
-
-	st, err := repository.R().ValidatorByAddress(args.Address)
+		st, err = repository.R().ValidatorByAddress(args.Address)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return NewStaker(st), err
+	return NewStaker(st), nil
 }
 
 // SfcRewardsCollectedAmount resolves the amount of collected rewards
 // based on provided filtering criteria.
-func (rs *rootResolver) SfcRewardsCollectedAmount(args struct {
+func (rs *rootResolver) SfcRewardsCollectedAmount(ctx context.Context, args struct {
 	Delegator *common.Address
 	Staker    *hexutil.Big
 	Since     *hexutil.Uint64
@@ -75,7 +77,11 @@ func (rs *rootResolver) SfcRewardsCollectedAmount(args struct {
 	}
 
 	// get the filtered amount
-	val, err := repository.R().RewardsClaimed(args.Delegator, (*big.Int)(args.Staker), since, until)
+	var val *big.Int
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().RewardsClaimed(args.Delegator, (*big.Int)(args.Staker), since, until)
+		return err
+	})
 	if err != nil {
 		return hexutil.Big{}, err
 	}
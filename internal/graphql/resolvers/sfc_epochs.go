@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,7 +23,7 @@ func NewEpochList(el *types.EpochList) *EpochList {
 }
 
 // Epochs resolves a list of epochs for the given cursor and count.
-func (rs *rootResolver) Epochs(args struct {
+func (rs *rootResolver) Epochs(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*EpochList, error) {
@@ -31,7 +32,11 @@ func (rs *rootResolver) Epochs(args struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the transaction hash list from repository
-	epl, err := repository.R().Epochs((*string)(args.Cursor), args.Count)
+	var epl *types.EpochList
+	err := resolveWithin(ctx, func() (err error) {
+		epl, err = repository.R().Epochs((*string)(args.Cursor), args.Count)
+		return err
+	})
 	if err != nil {
 		log.Errorf("can not get epoch list; %s", err.Error())
 		return nil, err
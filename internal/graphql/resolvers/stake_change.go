@@ -0,0 +1,78 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StakeChangeList represents resolvable list of stake change edges structure.
+type StakeChangeList struct {
+	types.StakeChangeList
+}
+
+// StakeChangeListEdge represents a single edge of a stake change list structure.
+type StakeChangeListEdge struct {
+	Change *StakeChange
+}
+
+// StakeChange represents resolvable change of a delegator stake.
+type StakeChange struct {
+	types.StakeChange
+}
+
+// StakingHistory resolves list of delegation and stake changes of the account.
+func (acc *Account) StakingHistory(ctx context.Context, args struct {
+	Cursor      *Cursor
+	Count       int32
+	ValidatorId *hexutil.Big
+}) (*StakeChangeList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	var sl *types.StakeChangeList
+	err := resolveWithin(ctx, func() (err error) {
+		sl, err = repository.R().StakeChanges(&acc.Address, args.ValidatorId, (*string)(args.Cursor), args.Count)
+		return err
+	})
+	if err != nil {
+		log.Errorf("can not get staking history of %s; %s", acc.Address.String(), err.Error())
+		return nil, err
+	}
+	return &StakeChangeList{StakeChangeList: *sl}, nil
+}
+
+// TotalCount resolves the total number of stake changes in the list.
+func (sl *StakeChangeList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(sl.Total)
+}
+
+// PageInfo resolves the current page information for the stake change list.
+func (sl *StakeChangeList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(sl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(sl.Collection[0].Pk())
+	last := Cursor(sl.Collection[len(sl.Collection)-1].Pk())
+	return NewListPageInfo(&first, &last, !sl.IsEnd, !sl.IsStart)
+}
+
+// Edges resolves list of edges for the stake change list.
+func (sl *StakeChangeList) Edges() []*StakeChangeListEdge {
+	edges := make([]*StakeChangeListEdge, len(sl.Collection))
+	for i, c := range sl.Collection {
+		edges[i] = &StakeChangeListEdge{Change: &StakeChange{StakeChange: *c}}
+	}
+	return edges
+}
+
+// Cursor resolves a cursor of an edge in the edges list.
+func (sle *StakeChangeListEdge) Cursor() Cursor {
+	return Cursor(sle.Change.Pk())
+}
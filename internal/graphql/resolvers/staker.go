@@ -210,6 +210,15 @@ func (st Staker) IsActive() bool {
 	return st.Status == 0
 }
 
+// Commission resolves the share of delegators' rewards taken by the validator.
+func (st Staker) Commission() (hexutil.Big, error) {
+	val, err := repository.R().SfcValidatorCommission()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*val), nil
+}
+
 // IsWithdrawn signals if the validator has withdrawn from the validators.
 func (st Staker) IsWithdrawn() bool {
 	return st.Status&sfcStatusWithdrawn > 0
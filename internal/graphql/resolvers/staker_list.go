@@ -1,49 +1,49 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"math/big"
+	"motif-api/internal/types"
 	"sort"
 )
 
 // Stakers resolves a list of staker information from SFC smart contract.
 func (rs *rootResolver) Stakers() ([]*Staker, error) {
-	// get the number
-	num, err := repository.R().LastValidatorId()
+	return rs.Validators(context.Background(), struct{ ActiveOnly bool }{})
+}
+
+// Validators resolves a list of validators from SFC smart contract sorted by their total stake.
+// Deactivated validators are filtered out if requested.
+func (rs *rootResolver) Validators(ctx context.Context, args struct{ ActiveOnly bool }) ([]*Staker, error) {
+	var vl []*types.Validator
+	err := resolveWithin(ctx, func() (err error) {
+		vl, err = repository.R().Validators()
+		return err
+	})
 	if err != nil {
-		log.Errorf("can not get the highest staker id; %s", err.Error())
+		log.Errorf("can not get the list of validators; %s", err.Error())
 		return nil, err
 	}
 
 	// make the list
-	list := make([]*Staker, 0)
-	for i := uint64(1); i <= num; i++ {
-		// extract the staker info
-		st, err := repository.R().Validator((*hexutil.Big)(new(big.Int).SetUint64(i)))
-		if err != nil {
-			log.Criticalf("can not extract staker #%d information; %s", i, err.Error())
-			continue
-		}
-
-		// staker not valid?
-		if st.Id.ToInt().Uint64() == 0 {
-			log.Debugf("staker #%d has invalid ID", i)
+	list := make([]*Staker, 0, len(vl))
+	for _, val := range vl {
+		if args.ActiveOnly && !isActiveValidator(val) {
 			continue
 		}
-
-		// store the staker into the list
-		list = append(list, NewStaker(st))
+		list = append(list, NewStaker(val))
 	}
 
-	// inform
-	log.Debugf("found %d stakers", len(list))
-
 	// sort the list by total amount delegated and return the result
 	sort.Sort(StakesByTotalStaked(list))
 	return list, nil
 }
 
+// isActiveValidator checks if the validator is active and not deactivated.
+func isActiveValidator(val *types.Validator) bool {
+	return val.Status == 0 && val.DeactivatedEpoch == 0
+}
+
 // StakesByTotalStaked represents a list of staking sortable by their total staked amount.
 type StakesByTotalStaked []*Staker
 
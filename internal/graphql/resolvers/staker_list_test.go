@@ -0,0 +1,46 @@
+package resolvers
+
+import (
+	"motif-api/internal/types"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestIsActiveValidator tests the deactivated validators are recognized.
+func TestIsActiveValidator(t *testing.T) {
+	tests := []struct {
+		val    types.Validator
+		active bool
+	}{
+		{types.Validator{}, true},
+		{types.Validator{Status: sfcStatusOffline}, false},
+		{types.Validator{Status: sfcStatusWithdrawn, DeactivatedEpoch: 10}, false},
+		{types.Validator{DeactivatedEpoch: 10}, false},
+	}
+	for i, tc := range tests {
+		if got := isActiveValidator(&tc.val); got != tc.active {
+			t.Errorf("validator %d; expected active %t, got %t", i, tc.active, got)
+		}
+	}
+}
+
+// TestStakesByTotalStaked tests the stakers are sorted by the total stake, the largest first.
+func TestStakesByTotalStaked(t *testing.T) {
+	list := make([]*Staker, 0, 3)
+	for i, amo := range []int64{10, 30, 20} {
+		list = append(list, NewStaker(&types.Validator{
+			Id:         hexutil.Big(*big.NewInt(int64(i + 1))),
+			TotalStake: (*hexutil.Big)(big.NewInt(amo)),
+		}))
+	}
+
+	sort.Sort(StakesByTotalStaked(list))
+	for i, id := range []int64{2, 3, 1} {
+		if list[i].Id.ToInt().Int64() != id {
+			t.Errorf("position %d; expected validator #%d, got #%d", i, id, list[i].Id.ToInt().Int64())
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// StakingRewardsEstimate represents resolvable projection of rewards
+// of a delegation to a validator with an optional lock-up.
+type StakingRewardsEstimate struct {
+	ValidatorId hexutil.Big
+	Amount      hexutil.Big
+	LockDays    int32
+	Commission  hexutil.Big
+
+	// lockRatio is the share of the full reward paid for the lock-up in 18 decimals
+	lockRatio *big.Int
+
+	// fullRewardPerSecond is the reward per second of the delegation before commission and lock-up
+	fullRewardPerSecond *big.Int
+}
+
+// EstimateStakingRewards resolves projected rewards of the given amount delegated
+// to the validator and locked for the given number of days; zero days means no lock-up.
+func (rs *rootResolver) EstimateStakingRewards(ctx context.Context, args struct {
+	ValidatorId hexutil.Big
+	Amount      hexutil.Big
+	LockDays    int32
+}) (*StakingRewardsEstimate, error) {
+	var est *StakingRewardsEstimate
+	err := resolveWithin(ctx, func() (err error) {
+		est, err = rs.estimateStakingRewards(args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return est, nil
+}
+
+// estimateStakingRewards calculates the staking rewards estimate of the given amount.
+func (rs *rootResolver) estimateStakingRewards(args struct {
+	ValidatorId hexutil.Big
+	Amount      hexutil.Big
+	LockDays    int32
+}) (*StakingRewardsEstimate, error) {
+	if args.Amount.ToInt().Sign() <= 0 {
+		return nil, fmt.Errorf("staked amount must be positive")
+	}
+
+	// validate the lock range against the SFC configuration
+	cfg, err := repository.R().SfcConfiguration()
+	if err != nil {
+		log.Errorf("can not get SFC configuration; %s", err.Error())
+		return nil, err
+	}
+	lock := new(big.Int).Mul(big.NewInt(int64(args.LockDays)), new(big.Int).SetUint64(erwSecondsInDay))
+	if err := validateLockDuration(lock, cfg.MinLockupDuration.ToInt(), cfg.MaxLockupDuration.ToInt()); err != nil {
+		return nil, err
+	}
+
+	// the validator must exist and be active to receive rewards
+	val, err := repository.R().Validator(&args.ValidatorId)
+	if err != nil {
+		log.Errorf("can not get validator #%d; %s", args.ValidatorId.ToInt().Uint64(), err.Error())
+		return nil, err
+	}
+	if val.CreatedTime == 0 || !isActiveValidator(val) {
+		return nil, fmt.Errorf("validator #%d not active", args.ValidatorId.ToInt().Uint64())
+	}
+
+	// get the reward rates
+	ep, err := repository.R().CurrentSealedEpoch()
+	if err != nil {
+		log.Errorf("can not get the current sealed epoch information; %s", err.Error())
+		return nil, err
+	}
+	total, err := repository.R().TotalStaked()
+	if err != nil {
+		log.Errorf("can not get the current total staked amount; %s", err.Error())
+		return nil, err
+	}
+	commission, err := repository.R().SfcValidatorCommission()
+	if err != nil {
+		log.Errorf("can not get validator commission; %s", err.Error())
+		return nil, err
+	}
+	unlocked, err := repository.R().SfcUnlockedRewardRatio()
+	if err != nil {
+		log.Errorf("can not get unlocked reward ratio; %s", err.Error())
+		return nil, err
+	}
+
+	return &StakingRewardsEstimate{
+		ValidatorId:         args.ValidatorId,
+		Amount:              args.Amount,
+		LockDays:            args.LockDays,
+		Commission:          hexutil.Big(*commission),
+		lockRatio:           lockRewardRatio(unlocked, lock, cfg.MaxLockupDuration.ToInt()),
+		fullRewardPerSecond: delegationRewardPerSecond(ep.BaseRewardPerSecond.ToInt(), total.ToInt(), args.Amount.ToInt()),
+	}, nil
+}
+
+// validateLockDuration checks the lock duration is either zero, or within the allowed lock-up range.
+func validateLockDuration(lock, min, max *big.Int) error {
+	if lock.Sign() < 0 {
+		return fmt.Errorf("lock duration can not be negative")
+	}
+	if lock.Sign() == 0 {
+		return nil
+	}
+	if max.Sign() <= 0 {
+		return fmt.Errorf("lock-up range not available")
+	}
+	if lock.Cmp(min) < 0 || lock.Cmp(max) > 0 {
+		return fmt.Errorf("lock duration must be between %d and %d days",
+			new(big.Int).Div(min, new(big.Int).SetUint64(erwSecondsInDay)).Uint64(),
+			new(big.Int).Div(max, new(big.Int).SetUint64(erwSecondsInDay)).Uint64())
+	}
+	return nil
+}
+
+// lockRewardRatio calculates the share of the full reward paid for the given lock duration.
+// Following the SFC rules, the unlocked ratio is paid without lock-up and the remaining
+// share grows linearly with the lock duration up to the full reward on the max lock-up.
+func lockRewardRatio(unlocked, lock, max *big.Int) *big.Int {
+	if lock.Sign() <= 0 || max.Sign() <= 0 {
+		return new(big.Int).Set(unlocked)
+	}
+	bonus := new(big.Int).Sub(weiToFtmDecimals, unlocked)
+	bonus.Mul(bonus, lock)
+	bonus.Div(bonus, max)
+	return bonus.Add(bonus, unlocked)
+}
+
+// delegationRewardPerSecond calculates the full reward per second of the given amount
+// added to the total stake; the base reward is split by the share on the total stake.
+func delegationRewardPerSecond(base, total, amount *big.Int) *big.Int {
+	stake := new(big.Int).Add(total, amount)
+	if base.Sign() <= 0 || stake.Sign() <= 0 {
+		return new(big.Int)
+	}
+	val := new(big.Int).Mul(base, amount)
+	return val.Div(val, stake)
+}
+
+// LockMultiplier resolves the share of the full reward paid for the lock-up.
+func (sre StakingRewardsEstimate) LockMultiplier() float64 {
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(sre.lockRatio), new(big.Float).SetInt(weiToFtmDecimals)).Float64()
+	return val
+}
+
+// rewards calculates the projected rewards for the given period in seconds
+// with the validator commission deducted.
+func (sre StakingRewardsEstimate) rewards(period uint64) hexutil.Big {
+	val := new(big.Int).Mul(sre.fullRewardPerSecond, new(big.Int).SetUint64(period))
+	val.Mul(val, sre.lockRatio)
+	val.Div(val, weiToFtmDecimals)
+
+	// deduct the validator commission
+	fee := new(big.Int).Mul(val, sre.Commission.ToInt())
+	fee.Div(fee, weiToFtmDecimals)
+	return hexutil.Big(*val.Sub(val, fee))
+}
+
+// DailyReward resolves the projected rewards per day.
+func (sre StakingRewardsEstimate) DailyReward() hexutil.Big {
+	return sre.rewards(erwSecondsInDay)
+}
+
+// MonthlyReward resolves the projected rewards per month.
+func (sre StakingRewardsEstimate) MonthlyReward() hexutil.Big {
+	return sre.rewards(erwSecondsInMonth)
+}
+
+// YearlyReward resolves the projected rewards per year.
+func (sre StakingRewardsEstimate) YearlyReward() hexutil.Big {
+	return sre.rewards(erwSecondsInYear)
+}
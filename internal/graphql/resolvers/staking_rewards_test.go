@@ -0,0 +1,75 @@
+package resolvers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestValidateLockDuration tests the lock duration is checked against the lock-up range.
+func TestValidateLockDuration(t *testing.T) {
+	day := int64(erwSecondsInDay)
+	min, max := big.NewInt(14*day), big.NewInt(365*day)
+
+	tests := []struct {
+		days  int64
+		valid bool
+	}{
+		{0, true},
+		{-1, false},
+		{7, false},
+		{14, true},
+		{200, true},
+		{365, true},
+		{366, false},
+	}
+	for _, tc := range tests {
+		err := validateLockDuration(big.NewInt(tc.days*day), min, max)
+		if (err == nil) != tc.valid {
+			t.Errorf("lock of %d days; expected valid %t, got %v", tc.days, tc.valid, err)
+		}
+	}
+
+	if err := validateLockDuration(big.NewInt(14*day), min, new(big.Int)); err == nil {
+		t.Errorf("expected error on missing lock-up range")
+	}
+}
+
+// TestStakingRewardsProjection tests the projected rewards follow the lock-up and commission rules.
+func TestStakingRewardsProjection(t *testing.T) {
+	unlocked := new(big.Int).Div(new(big.Int).Mul(weiToFtmDecimals, big.NewInt(3)), big.NewInt(10))
+	commission := new(big.Int).Div(new(big.Int).Mul(weiToFtmDecimals, big.NewInt(15)), big.NewInt(100))
+	max := big.NewInt(365 * int64(erwSecondsInDay))
+
+	sre := StakingRewardsEstimate{
+		Commission:          hexutil.Big(*commission),
+		lockRatio:           lockRewardRatio(unlocked, new(big.Int), max),
+		fullRewardPerSecond: delegationRewardPerSecond(big.NewInt(1000), big.NewInt(750), big.NewInt(250)),
+	}
+	if sre.fullRewardPerSecond.Int64() != 250 {
+		t.Fatalf("expected full reward 250 per second, got %s", sre.fullRewardPerSecond.String())
+	}
+	if sre.LockMultiplier() != 0.3 {
+		t.Errorf("expected unlocked multiplier 0.3, got %f", sre.LockMultiplier())
+	}
+
+	// 250 * 86400 * 0.3 * 0.85
+	daily := sre.DailyReward()
+	if daily.ToInt().Int64() != 5508000 {
+		t.Errorf("expected daily reward 5508000, got %s", daily.ToInt().String())
+	}
+
+	// half of the max lock gets half of the bonus
+	sre.lockRatio = lockRewardRatio(unlocked, new(big.Int).Div(max, big.NewInt(2)), max)
+	if sre.LockMultiplier() != 0.65 {
+		t.Errorf("expected multiplier 0.65, got %f", sre.LockMultiplier())
+	}
+
+	// the max lock gets the full reward
+	sre.lockRatio = lockRewardRatio(unlocked, max, max)
+	yearly := sre.YearlyReward()
+	if yearly.ToInt().Int64() != 250*int64(erwSecondsInYear)*85/100 {
+		t.Errorf("unexpected yearly reward %s", yearly.ToInt().String())
+	}
+}
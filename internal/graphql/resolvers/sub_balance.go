@@ -0,0 +1,151 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// onBalanceChannelCapacity is the number of balance change events held in memory for being broadcast to subscriber.
+const onBalanceChannelCapacity = 50
+
+// AccountBalanceChange represents a change of the balance of an account.
+type AccountBalanceChange struct {
+	// Address is the address of the account.
+	Address common.Address
+
+	// Balance is the new balance of the account.
+	Balance hexutil.Big
+
+	// BlockNumber is the number of the block which changed the balance.
+	BlockNumber hexutil.Uint64
+}
+
+// subscriptOnBalance represents reference to a subscriber to onAccountBalanceChange events broadcast.
+type subscriptOnBalance struct {
+	address common.Address
+	stop    <-chan struct{}
+	events  chan<- *AccountBalanceChange
+
+	// the last block checked and the last known balance
+	lock      sync.Mutex
+	lastBlock uint64
+	balance   *big.Int
+}
+
+// OnAccountBalanceChange resolves subscription to balance changes of the given account.
+func (rs *rootResolver) OnAccountBalanceChange(ctx context.Context, args struct{ Address common.Address }) <-chan *AccountBalanceChange {
+	// make the stream
+	c := make(chan *AccountBalanceChange, onBalanceChannelCapacity)
+	sub := subscriptOnBalance{
+		address: args.Address,
+		stop:    ctx.Done(),
+		events:  c,
+	}
+
+	// the current balance is the base of the changes
+	bal, err := repository.R().AccountBalance(&args.Address)
+	if err == nil {
+		sub.balance = bal.ToInt()
+	}
+
+	// subscribe to event dispatch
+	rs.subscribeOnBalance <- &sub
+	return c
+}
+
+// addBalanceSubscriber adds a new subscription to onAccountBalanceChange events.
+func (rs *rootResolver) addBalanceSubscriber(sub *subscriptOnBalance) {
+	id, err := uuid()
+	if err == nil {
+		// add the subscriber to the map
+		rs.balanceSubscribers[id] = sub
+	} else {
+		// log critical issue
+		log.Critical("can not generate UUID for new onAccountBalanceChange subscriber")
+		log.Critical(err)
+	}
+}
+
+// dispatchOnBalance dispatches the new transaction to subscribers of balance changes
+// of the accounts touched by the transaction.
+func (rs *rootResolver) dispatchOnBalance(trx *types.Transaction) {
+	if trx.BlockNumber == nil {
+		return
+	}
+
+	for id, sub := range rs.balanceSubscribers {
+		if sub.address == trx.From || (trx.To != nil && sub.address == *trx.To) {
+			go rs.notifyOnBalance(uint64(*trx.BlockNumber), sub, id)
+		}
+	}
+}
+
+// notifyOnBalance checks the balance of the subscribed account at the given block
+// and broadcasts the change to the subscriber, if any.
+func (rs *rootResolver) notifyOnBalance(blk uint64, sub *subscriptOnBalance, id string) {
+	// check if the context isn't already closed in which case we just unsub and leave
+	select {
+	case <-sub.stop:
+		rs.unsubscribeOnBalance <- id
+		return
+	default:
+	}
+
+	bal, changed := sub.update(blk, func() (*big.Int, error) {
+		num := hexutil.Uint64(blk)
+		val, err := repository.R().AccountBalanceAt(&sub.address, &num)
+		if err != nil {
+			return nil, err
+		}
+		return val.ToInt(), nil
+	})
+	if !changed {
+		return
+	}
+
+	// broadcast
+	select {
+	case <-sub.stop:
+		// just unsub on broken context
+		rs.unsubscribeOnBalance <- id
+
+	case sub.events <- &AccountBalanceChange{Address: sub.address, Balance: hexutil.Big(*bal), BlockNumber: hexutil.Uint64(blk)}:
+		// push the change to subscriber
+
+	case <-time.After(time.Second):
+		// timeout reached without response? just remove the subscriber
+		rs.unsubscribeOnBalance <- id
+	}
+}
+
+// update reads the balance of the subscribed account at the given block using the reader
+// and checks if it changed since the last read. Each block is checked only once, so multiple
+// transactions of the same block produce a single change; older blocks are skipped.
+func (sub *subscriptOnBalance) update(blk uint64, read func() (*big.Int, error)) (*big.Int, bool) {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+
+	if blk <= sub.lastBlock {
+		return nil, false
+	}
+	sub.lastBlock = blk
+
+	bal, err := read()
+	if err != nil {
+		log.Errorf("can not read balance of %s at #%d; %s", sub.address.String(), blk, err.Error())
+		return nil, false
+	}
+	if sub.balance != nil && sub.balance.Cmp(bal) == 0 {
+		return nil, false
+	}
+
+	sub.balance = bal
+	return bal, true
+}
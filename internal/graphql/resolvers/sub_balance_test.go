@@ -0,0 +1,47 @@
+package resolvers
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestBalanceSubscriptionUpdate tests the balance changes are debounced by the block
+// and reported only if the balance actually changed.
+func TestBalanceSubscriptionUpdate(t *testing.T) {
+	sub := subscriptOnBalance{balance: big.NewInt(100)}
+
+	reads := 0
+	reader := func(val int64) func() (*big.Int, error) {
+		return func() (*big.Int, error) {
+			reads++
+			return big.NewInt(val), nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		blk     uint64
+		balance int64
+		changed bool
+		reads   int
+	}{
+		{"unchanged balance", 10, 100, false, 1},
+		{"changed balance", 11, 80, true, 2},
+		{"same block again", 11, 60, false, 2},
+		{"older block", 9, 60, false, 2},
+		{"next block", 12, 60, true, 3},
+	}
+
+	for _, tt := range tests {
+		bal, changed := sub.update(tt.blk, reader(tt.balance))
+		if changed != tt.changed {
+			t.Errorf("%s: expected change %t, got %t", tt.name, tt.changed, changed)
+		}
+		if changed && bal.Int64() != tt.balance {
+			t.Errorf("%s: expected balance %d, got %s", tt.name, tt.balance, bal.String())
+		}
+		if reads != tt.reads {
+			t.Errorf("%s: expected %d reads, got %d", tt.name, tt.reads, reads)
+		}
+	}
+}
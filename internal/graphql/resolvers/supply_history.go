@@ -0,0 +1,48 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ERC20SupplySnapshot represents resolvable total supply of an ERC20 token at a block.
+type ERC20SupplySnapshot struct {
+	types.SupplySnapshot
+}
+
+// Erc20SupplyHistory resolves the persisted total supply snapshots of the given ERC20 token
+// taken in the given block range.
+func (rs *rootResolver) Erc20SupplyHistory(ctx context.Context, args struct {
+	Token     common.Address
+	FromBlock hexutil.Uint64
+	ToBlock   hexutil.Uint64
+}) ([]*ERC20SupplySnapshot, error) {
+	if args.FromBlock > args.ToBlock {
+		return nil, fmt.Errorf("invalid block range, #%d is after #%d", uint64(args.FromBlock), uint64(args.ToBlock))
+	}
+
+	var list []*types.SupplySnapshot
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = repository.R().Erc20SupplyHistory(&args.Token, uint64(args.FromBlock), uint64(args.ToBlock))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ERC20SupplySnapshot, len(list))
+	for i, ss := range list {
+		res[i] = &ERC20SupplySnapshot{SupplySnapshot: *ss}
+	}
+	return res, nil
+}
+
+// Timestamp resolves the time of the snapshot block as a UNIX timestamp.
+func (ss *ERC20SupplySnapshot) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(ss.SupplySnapshot.Time.Unix())
+}
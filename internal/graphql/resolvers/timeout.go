@@ -0,0 +1,260 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/metrics"
+	"context"
+	"fmt"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/trace"
+	"sync"
+	"time"
+)
+
+// FieldCategory represents a category of resolvable fields sharing the same deadline.
+type FieldCategory int
+
+// categories of resolvable fields
+const (
+	// FieldCategoryDefault applies to fields without a category tag.
+	FieldCategoryDefault FieldCategory = iota
+
+	// FieldCategoryLiveRead applies to cheap reads served live from the node, or the cache.
+	FieldCategoryLiveRead
+
+	// FieldCategoryIndexed applies to queries served from the off-chain index.
+	FieldCategoryIndexed
+
+	// FieldCategoryAggregation applies to expensive analytics and aggregations.
+	FieldCategoryAggregation
+)
+
+// fieldCategories maps resolvable fields to their category tag.
+// Fields not listed here inherit the deadline of their parent field,
+// top level fields fall back to the default resolver timeout.
+var fieldCategories = map[string]FieldCategory{
+	// live reads
+	"Query.account":                         FieldCategoryLiveRead,
+	"Query.accountOverviews":                FieldCategoryLiveRead,
+	"Query.indexProgress":                   FieldCategoryLiveRead,
+	"Query.materializedViews":               FieldCategoryLiveRead,
+	"Query.cacheStats":                      FieldCategoryLiveRead,
+	"Query.erc20NonStandardTokens":          FieldCategoryLiveRead,
+	"Query.block":                           FieldCategoryLiveRead,
+	"Query.transaction":                     FieldCategoryLiveRead,
+	"Query.transactionReceipt":              FieldCategoryLiveRead,
+	"Query.transactionStatus":               FieldCategoryLiveRead,
+	"Query.resolveName":                     FieldCategoryLiveRead,
+	"Query.lookupAddress":                   FieldCategoryLiveRead,
+	"Query.gasPrice":                        FieldCategoryLiveRead,
+	"Query.estimateGas":                     FieldCategoryLiveRead,
+	"Query.contractCall":                    FieldCategoryLiveRead,
+	"Query.feeHistory":                      FieldCategoryLiveRead,
+	"Query.erc20Token":                      FieldCategoryLiveRead,
+	"Query.erc20TokenInfo":                  FieldCategoryLiveRead,
+	"Query.ercTotalSupply":                  FieldCategoryLiveRead,
+	"Query.ercTokenBalance":                 FieldCategoryLiveRead,
+	"Query.ercTokenAllowance":               FieldCategoryLiveRead,
+	"Query.staker":                          FieldCategoryLiveRead,
+	"Query.validators":                      FieldCategoryLiveRead,
+	"Query.estimateStakingRewards":          FieldCategoryLiveRead,
+	"Query.delegation":                      FieldCategoryLiveRead,
+	"Query.fMintAccount":                    FieldCategoryLiveRead,
+	"Query.fMintLiquidationRisk":            FieldCategoryLiveRead,
+	"Query.fMintRewards":                    FieldCategoryLiveRead,
+	"Query.fMintTokenAllowance":             FieldCategoryLiveRead,
+	"ERC20Token.priceComparison":            FieldCategoryLiveRead,
+	"Query.fMintDepositData":                FieldCategoryLiveRead,
+	"Query.erc20ApproveData":                FieldCategoryLiveRead,
+	"Query.fMintWithdrawData":               FieldCategoryLiveRead,
+	"Query.fMintMintData":                   FieldCategoryLiveRead,
+	"Query.fMintRepayData":                  FieldCategoryLiveRead,
+	"Query.uniswapQuote":                    FieldCategoryLiveRead,
+	"Query.uniswapPair":                     FieldCategoryLiveRead,
+	"Transaction.estimatedConfirmationTime": FieldCategoryLiveRead,
+	"Transaction.revertReason":              FieldCategoryLiveRead,
+	"Transaction.trace":                     FieldCategoryLiveRead,
+	"Account.storageAt":                     FieldCategoryLiveRead,
+	"Account.contractType":                  FieldCategoryLiveRead,
+	"Account.code":                          FieldCategoryLiveRead,
+	"Account.isContract":                    FieldCategoryLiveRead,
+	"Contract.abi":                          FieldCategoryLiveRead,
+	"FMintAccount.liquidationPrices":        FieldCategoryLiveRead,
+	"Query.tokenPrice":                      FieldCategoryLiveRead,
+	"Query.convertAmount":                   FieldCategoryLiveRead,
+	"Query.logs":                            FieldCategoryLiveRead,
+
+	// indexed queries
+	"Query.accountsActive":       FieldCategoryIndexed,
+	"Query.contracts":            FieldCategoryIndexed,
+	"Query.transactions":         FieldCategoryIndexed,
+	"Query.erc20Transactions":    FieldCategoryIndexed,
+	"Query.erc20Transfers":       FieldCategoryIndexed,
+	"Query.erc721Transactions":   FieldCategoryIndexed,
+	"Query.erc1155Transactions":  FieldCategoryIndexed,
+	"Query.epochs":               FieldCategoryIndexed,
+	"Query.delegationsOf":        FieldCategoryIndexed,
+	"Query.delegationsByAddress": FieldCategoryIndexed,
+	"Query.erc20TokenList":       FieldCategoryIndexed,
+	"Query.erc20Assets":          FieldCategoryIndexed,
+	"Query.erc721ContractList":   FieldCategoryIndexed,
+	"Query.erc1155ContractList":  FieldCategoryIndexed,
+	"Query.defiUniswapActions":   FieldCategoryIndexed,
+	"Query.govProposals":         FieldCategoryIndexed,
+	"Query.erc20TokenHolders":    FieldCategoryIndexed,
+	"Query.erc20SupplyHistory":   FieldCategoryIndexed,
+	"Query.reorgHistory":         FieldCategoryIndexed,
+	"Query.methodCalls":          FieldCategoryIndexed,
+	"Query.indexedLogs":          FieldCategoryIndexed,
+	"Query.priceHistory":         FieldCategoryIndexed,
+	"Account.nfts":               FieldCategoryIndexed,
+	"Account.failedTransactions": FieldCategoryIndexed,
+	"Account.stakingHistory":     FieldCategoryIndexed,
+
+	// aggregations
+	"Query.estimateRewards":           FieldCategoryAggregation,
+	"Query.sfcRewardsCollectedAmount": FieldCategoryAggregation,
+	"Query.fMintUserTokens":           FieldCategoryAggregation,
+	"Query.defiUniswapVolumes":        FieldCategoryAggregation,
+	"Query.defiTimeVolumes":           FieldCategoryAggregation,
+	"Query.defiTimePrices":            FieldCategoryAggregation,
+	"Query.defiTimeReserves":          FieldCategoryAggregation,
+	"Query.trxVolume":                 FieldCategoryAggregation,
+	"Query.trxSpeed":                  FieldCategoryAggregation,
+	"Query.trxGasSpeed":               FieldCategoryAggregation,
+	"Query.trendingTokens":            FieldCategoryAggregation,
+	"Query.fMintFeesCollected":        FieldCategoryAggregation,
+	"Query.defiUniswapFeesCollected":  FieldCategoryAggregation,
+	"ERC20Token.riskFlags":            FieldCategoryAggregation,
+	"Account.netWorthHistory":         FieldCategoryAggregation,
+	"Account.portfolioDistribution":   FieldCategoryAggregation,
+	"Account.approvalRisk":            FieldCategoryAggregation,
+	"Account.defiPositions":           FieldCategoryAggregation,
+	"Account.rewardSources":           FieldCategoryAggregation,
+}
+
+// TimeoutTracer implements GraphQL field tracer applying resolver deadlines
+// based on the category tag of the resolved field.
+type TimeoutTracer struct {
+	trace.OpenTracingTracer
+	lock     sync.RWMutex
+	timeouts map[FieldCategory]time.Duration
+}
+
+// NewTimeoutTracer creates a new resolver deadline tracer from the given server configuration.
+func NewTimeoutTracer(cfg *config.Server) *TimeoutTracer {
+	return &TimeoutTracer{timeouts: categoryTimeouts(cfg)}
+}
+
+// Update replaces the resolver deadlines by the given server configuration.
+// The new deadlines apply to fields resolved after the update.
+func (tt *TimeoutTracer) Update(cfg *config.Server) {
+	to := categoryTimeouts(cfg)
+
+	tt.lock.Lock()
+	tt.timeouts = to
+	tt.lock.Unlock()
+}
+
+// categoryTimeouts builds the map of deadlines per field category from the server configuration.
+func categoryTimeouts(cfg *config.Server) map[FieldCategory]time.Duration {
+	def := time.Duration(cfg.ResolverTimeout) * time.Second
+	return map[FieldCategory]time.Duration{
+		FieldCategoryDefault:     def,
+		FieldCategoryLiveRead:    categoryTimeout(cfg.ResolverTimeouts.LiveRead, def),
+		FieldCategoryIndexed:     categoryTimeout(cfg.ResolverTimeouts.Indexed, def),
+		FieldCategoryAggregation: categoryTimeout(cfg.ResolverTimeouts.Aggregation, def),
+	}
+}
+
+// categoryTimeout converts the configured category timeout in seconds
+// falling back to the given default if not configured.
+func categoryTimeout(sec int64, def time.Duration) time.Duration {
+	if sec <= 0 {
+		return def
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// RequestTimeout provides the deadline of the whole request, i.e. the configured
+// general resolver timeout. Fields of a category with a longer deadline are cut by it.
+func (tt *TimeoutTracer) RequestTimeout() time.Duration {
+	tt.lock.RLock()
+	defer tt.lock.RUnlock()
+	return tt.timeouts[FieldCategoryDefault]
+}
+
+// Timeout provides the deadline of the given field, and a flag signaling
+// if the field deadline should be applied at all.
+func (tt *TimeoutTracer) Timeout(typeName, fieldName string) (time.Duration, bool) {
+	cat, ok := fieldCategories[typeName+"."+fieldName]
+	if !ok {
+		// top level fields without a tag use the default deadline
+		if typeName != "Query" && typeName != "Mutation" {
+			return 0, false
+		}
+		cat = FieldCategoryDefault
+	}
+
+	tt.lock.RLock()
+	defer tt.lock.RUnlock()
+	return tt.timeouts[cat], true
+}
+
+// TraceField applies the field deadline to the context passed to the field resolver
+// and to all the nested fields. Resolvers of tagged fields waiting on the node, or the database,
+// enforce the deadline by resolveWithin. Latency of non-trivial resolvers is recorded in the metrics.
+func (tt *TimeoutTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	ctx, finish := tt.OpenTracingTracer.TraceField(ctx, label, typeName, fieldName, trivial, args)
+	if !trivial {
+		start, traced := time.Now(), finish
+		finish = func(err *gqlerrors.QueryError) {
+			metrics.ResolverDuration.ObserveSince(typeName+"."+fieldName, start)
+			traced(err)
+		}
+	}
+
+	// any deadline to apply?
+	to, ok := tt.Timeout(typeName, fieldName)
+	if !ok {
+		return ctx, finish
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, to)
+	return ctx, func(err *gqlerrors.QueryError) {
+		cancel()
+		finish(err)
+	}
+}
+
+// resolveWithin runs the given resolution of a field and waits for it until the deadline
+// of the field context. If the deadline passes first, the field fails with the context error
+// and the abandoned resolution finishes in the background; its results must not be used.
+func resolveWithin(ctx context.Context, resolve func() error) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return resolve()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		// the executor can not recover a panic of another goroutine
+		defer func() {
+			if r := recover(); r != nil {
+				log.Criticalf("resolver panic; %v", r)
+				done <- fmt.Errorf("resolver failed; %v", r)
+			}
+		}()
+		done <- resolve()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
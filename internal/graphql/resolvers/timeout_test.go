@@ -0,0 +1,154 @@
+package resolvers
+
+import (
+	"motif-api/internal/config"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// TestTimeoutTracerCategories tests the resolver deadline is applied by the field category.
+func TestTimeoutTracerCategories(t *testing.T) {
+	tt := NewTimeoutTracer(&config.Server{
+		ResolverTimeout:  30,
+		ResolverTimeouts: config.ResolverTimeouts{LiveRead: 5, Aggregation: 120},
+	})
+
+	tests := []struct {
+		typeName  string
+		fieldName string
+		want      time.Duration
+		applied   bool
+	}{
+		{"Query", "account", 5 * time.Second, true},
+		{"Query", "transactions", 30 * time.Second, true},
+		{"Query", "logs", 5 * time.Second, true},
+		{"Query", "indexedLogs", 30 * time.Second, true},
+		{"Query", "trxVolume", 120 * time.Second, true},
+		{"Query", "version", 30 * time.Second, true},
+		{"ERC20Token", "riskFlags", 120 * time.Second, true},
+		{"Account", "balance", 0, false},
+	}
+
+	for _, tc := range tests {
+		to, ok := tt.Timeout(tc.typeName, tc.fieldName)
+		if ok != tc.applied || to != tc.want {
+			t.Errorf("%s.%s: expected %s (%t), got %s (%t)", tc.typeName, tc.fieldName, tc.want, tc.applied, to, ok)
+		}
+	}
+
+	if tt.RequestTimeout() != 30*time.Second {
+		t.Errorf("expected request timeout 30s, got %s", tt.RequestTimeout())
+	}
+}
+
+// TestTimeoutTracerDeadline tests the field context carries the category deadline.
+func TestTimeoutTracerDeadline(t *testing.T) {
+	tt := NewTimeoutTracer(&config.Server{ResolverTimeout: 30, ResolverTimeouts: config.ResolverTimeouts{LiveRead: 5}})
+
+	ctx, finish := tt.TraceField(context.Background(), "Query.account", "Query", "account", false, nil)
+	dl, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected deadline on live read field")
+	}
+	if left := time.Until(dl); left > 5*time.Second || left < 4*time.Second {
+		t.Errorf("expected live read deadline around 5s, got %s", left)
+	}
+
+	// nested fields without a tag inherit the parent deadline
+	nested, nFinish := tt.TraceField(ctx, "Account.balance", "Account", "balance", false, nil)
+	if ndl, _ := nested.Deadline(); !ndl.Equal(dl) {
+		t.Errorf("expected inherited deadline %s, got %s", dl, ndl)
+	}
+	nFinish(nil)
+
+	// the deadline is released when the field is finished
+	finish(nil)
+	if ctx.Err() == nil {
+		t.Errorf("expected field context to be cancelled after finish")
+	}
+}
+
+// TestTimeoutTracerUpdate tests the updated deadlines apply to the subsequent fields.
+func TestTimeoutTracerUpdate(t *testing.T) {
+	tt := NewTimeoutTracer(&config.Server{ResolverTimeout: 30, ResolverTimeouts: config.ResolverTimeouts{LiveRead: 5}})
+	tt.Update(&config.Server{ResolverTimeout: 10, ResolverTimeouts: config.ResolverTimeouts{Aggregation: 60}})
+
+	if to, _ := tt.Timeout("Query", "account"); to != 10*time.Second {
+		t.Errorf("expected live read to fall back to 10s, got %s", to)
+	}
+	if to, _ := tt.Timeout("Query", "version"); to != 10*time.Second {
+		t.Errorf("expected default 10s, got %s", to)
+	}
+	if tt.RequestTimeout() != 10*time.Second {
+		t.Errorf("expected request timeout 10s, got %s", tt.RequestTimeout())
+	}
+}
+
+// timeoutTestQuery implements the root resolver of the deadline enforcement test schema.
+type timeoutTestQuery struct{}
+
+// Account resolves a live read field slower than its deadline.
+func (*timeoutTestQuery) Account(ctx context.Context) (*string, error) {
+	var val string
+	err := resolveWithin(ctx, func() error {
+		time.Sleep(time.Second)
+		val = "late"
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// Version resolves an untagged field in time.
+func (*timeoutTestQuery) Version(ctx context.Context) (string, error) {
+	var val string
+	err := resolveWithin(ctx, func() error {
+		val = "1.0"
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return val, nil
+}
+
+// TestTimeoutTracerAbort tests a slow field is aborted at the deadline of its category
+// while the rest of the query resolves.
+func TestTimeoutTracerAbort(t *testing.T) {
+	tt := &TimeoutTracer{timeouts: map[FieldCategory]time.Duration{
+		FieldCategoryDefault:  5 * time.Second,
+		FieldCategoryLiveRead: 50 * time.Millisecond,
+	}}
+	schema := graphql.MustParseSchema(`schema { query: Query } type Query { account: String version: String! }`,
+		&timeoutTestQuery{}, graphql.Tracer(tt))
+
+	start := time.Now()
+	res := schema.Exec(context.Background(), `{ account version }`, "", nil)
+	if took := time.Since(start); took > 500*time.Millisecond {
+		t.Errorf("expected the live read aborted after 50ms, took %s", took)
+	}
+
+	if len(res.Errors) != 1 || res.Errors[0].Message != context.DeadlineExceeded.Error() {
+		t.Fatalf("expected deadline error, got %v", res.Errors)
+	}
+	if p := res.Errors[0].Path; len(p) != 1 || p[0] != "account" {
+		t.Errorf("expected error on account, got %v", p)
+	}
+
+	var data struct {
+		Account *string
+		Version string
+	}
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.Fatalf("can not decode response; %s", err.Error())
+	}
+	if data.Account != nil || data.Version != "1.0" {
+		t.Errorf("unexpected response %s", string(res.Data))
+	}
+}
@@ -0,0 +1,68 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// TokenActivity represents resolvable number of transactions of a token over a time window.
+type TokenActivity struct {
+	types.TokenActivity
+}
+
+// TrendingTokens resolves the tokens with the highest number of transactions
+// over the trailing window given in hours.
+func (rs *rootResolver) TrendingTokens(ctx context.Context, args struct {
+	Window int32
+	Count  int32
+}) ([]*TokenActivity, error) {
+	if args.Window <= 0 {
+		args.Window = 1
+	}
+	if args.Count <= 0 || uint32(args.Count) > listMaxEdgesPerRequest {
+		args.Count = int32(listMaxEdgesPerRequest)
+	}
+
+	var list []*types.TokenActivity
+	err := resolveWithin(ctx, func() (err error) {
+		list, err = repository.R().TrendingTokens(time.Duration(args.Window)*time.Hour, args.Count)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*TokenActivity, len(list))
+	for i, ta := range list {
+		res[i] = &TokenActivity{TokenActivity: *ta}
+	}
+	return res, nil
+}
+
+// Token resolves the address of the token.
+func (ta *TokenActivity) Token() common.Address {
+	return ta.TokenActivity.Token
+}
+
+// TokenType resolves the type of the token.
+func (ta *TokenActivity) TokenType() string {
+	return ta.TokenActivity.TokenType
+}
+
+// TransactionsCount resolves the number of token transactions in the window.
+func (ta *TokenActivity) TransactionsCount() hexutil.Uint64 {
+	return hexutil.Uint64(ta.TokenActivity.Count)
+}
+
+// Erc20Token resolves the details of the token, if it's an ERC20 token.
+func (ta *TokenActivity) Erc20Token() *ERC20Token {
+	if ta.TokenActivity.TokenType != types.AccountTypeERC20Token {
+		return nil
+	}
+	return NewErc20Token(&ta.TokenActivity.Token)
+}
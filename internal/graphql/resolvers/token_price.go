@@ -0,0 +1,48 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// TokenPrice represents resolvable current oracle price of a token.
+type TokenPrice struct {
+	types.TokenPrice
+}
+
+// TokenPrice resolves the current oracle price of the given token;
+// null is provided if the oracle has no price feed for the token.
+func (rs *rootResolver) TokenPrice(ctx context.Context, args struct{ Token common.Address }) (*TokenPrice, error) {
+	var tp *types.TokenPrice
+	err := resolveWithin(ctx, func() (err error) {
+		tp, err = repository.R().TokenPrice(&args.Token)
+		return err
+	})
+	if err != nil || tp == nil {
+		return nil, err
+	}
+	return &TokenPrice{TokenPrice: *tp}, nil
+}
+
+// ConvertAmount resolves the value of the given amount of the source token in the target token
+// by the current oracle prices; null is provided if any of the tokens has no price feed.
+func (rs *rootResolver) ConvertAmount(ctx context.Context, args struct {
+	FromToken common.Address
+	ToToken   common.Address
+	Amount    hexutil.Big
+}) (*hexutil.Big, error) {
+	var val *big.Int
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().ConvertAmount(&args.FromToken, &args.ToToken, args.Amount.ToInt())
+		return err
+	})
+	if err != nil || val == nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(val), nil
+}
@@ -57,3 +57,9 @@ func (ttx *TokenTransaction) TokenSymbol() (sym string, err error) {
 	}
 	return
 }
+
+// AmountSuspicious resolves the flag signaling the amount of the transaction
+// exceeds the sanity bound and should not be trusted.
+func (ttx *TokenTransaction) AmountSuspicious() bool {
+	return repository.R().IsSuspiciousValue(ttx.Amount.ToInt())
+}
@@ -2,8 +2,11 @@
 package resolvers
 
 import (
+	"context"
+	"encoding/json"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"golang.org/x/sync/singleflight"
@@ -24,9 +27,13 @@ func NewTransaction(trx *types.Transaction) *Transaction {
 }
 
 // Transaction resolves blockchain transaction by transaction hash.
-func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (*Transaction, error) {
+func (rs *rootResolver) Transaction(ctx context.Context, args *struct{ Hash common.Hash }) (*Transaction, error) {
 	// get the transaction from repository
-	trx, err := repository.R().Transaction(&args.Hash)
+	var trx *types.Transaction
+	err := resolveWithin(ctx, func() (err error) {
+		trx, err = repository.R().Transaction(&args.Hash)
+		return err
+	})
 	if err != nil {
 		log.Warningf("can not get transaction %s", args.Hash)
 		return nil, err
@@ -36,7 +43,13 @@ func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (*Transact
 }
 
 // SendTransaction sends raw signed and RLP encoded transaction to the block chain.
-func (rs *rootResolver) SendTransaction(args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
+// The number of transactions submitted by a single client is limited.
+func (rs *rootResolver) SendTransaction(ctx context.Context, args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
+	if client := clientAddress(ctx); !rs.trxSubmits.allow(client) {
+		log.Warningf("transactions submit limit reached for %s", client)
+		return nil, fmt.Errorf("too many transactions submitted, try again later")
+	}
+
 	// get the transaction from repository
 	trx, err := repository.R().SendTransaction(args.Tx)
 	if err != nil {
@@ -173,3 +186,57 @@ func (trx *Transaction) Erc1155Transactions() ([]*ERC1155Transaction, error) {
 	}
 	return list, nil
 }
+
+// EstimatedConfirmationTime resolves a best-effort estimate of the time to confirmation
+// of a pending transaction; confirmed transactions don't have any estimate.
+func (trx *Transaction) EstimatedConfirmationTime(ctx context.Context) (*TransactionEta, error) {
+	if trx.BlockNumber != nil {
+		return nil, nil
+	}
+
+	var eta *types.TrxEta
+	err := resolveWithin(ctx, func() (err error) {
+		eta, err = repository.R().TransactionEta(&trx.Transaction)
+		return err
+	})
+	if err != nil || eta == nil {
+		return nil, err
+	}
+	return &TransactionEta{TrxEta: *eta}, nil
+}
+
+// RevertReason resolves the decoded revert reason of a failed transaction.
+func (trx *Transaction) RevertReason(ctx context.Context) (*string, error) {
+	var reason *string
+	err := resolveWithin(ctx, func() (err error) {
+		reason, err = repository.R().TransactionRevertReason(&trx.Transaction)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reason, nil
+}
+
+// Trace resolves the internal call trace of the transaction, if the client is permitted to see it.
+func (trx *Transaction) Trace(ctx context.Context) (*string, error) {
+	if hide, err := redacted(ctx, "Transaction.trace"); hide {
+		return nil, err
+	}
+
+	var trace json.RawMessage
+	err := resolveWithin(ctx, func() (err error) {
+		trace, err = repository.R().TransactionTrace(&trx.Hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	val := string(trace)
+	return &val, nil
+}
+
+// TransactionEta represents resolvable estimate of a pending transaction confirmation.
+type TransactionEta struct {
+	types.TrxEta
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"fmt"
@@ -19,7 +20,7 @@ type DailyTrxVolume struct {
 }
 
 // TrxVolume resolves list of daily aggregations of the network transaction flow.
-func (rs *rootResolver) TrxVolume(args struct {
+func (rs *rootResolver) TrxVolume(ctx context.Context, args struct {
 	From *string
 	To   *string
 }) ([]*DailyTrxVolume, error) {
@@ -30,7 +31,11 @@ func (rs *rootResolver) TrxVolume(args struct {
 	}
 
 	// load data
-	dv, err := repository.R().TrxFlowVolume(from, to)
+	var dv []*types.DailyTrxVolume
+	err = resolveWithin(ctx, func() (err error) {
+		dv, err = repository.R().TrxFlowVolume(from, to)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +50,7 @@ func (rs *rootResolver) TrxVolume(args struct {
 
 // TrxGasSpeed resolves the gas consumption speed speed
 // of the network in transactions processed per second.
-func (rs *rootResolver) TrxGasSpeed(args struct {
+func (rs *rootResolver) TrxGasSpeed(ctx context.Context, args struct {
 	Range int32
 	To    *string
 }) (val float64, err error) {
@@ -68,18 +73,36 @@ func (rs *rootResolver) TrxGasSpeed(args struct {
 
 	// log what we do
 	log.Noticef("calculating gas speed from %s to %s", from.String(), to.String())
-	return repository.R().TrxGasSpeed(&from, &to)
+
+	var speed float64
+	err = resolveWithin(ctx, func() (err error) {
+		speed, err = repository.R().TrxGasSpeed(&from, &to)
+		return err
+	})
+	if err != nil {
+		return 0.0, err
+	}
+	return speed, nil
 }
 
 // TrxSpeed resolves the recent speed of the network in transactions processed per second.
-func (rs *rootResolver) TrxSpeed(args struct {
+func (rs *rootResolver) TrxSpeed(ctx context.Context, args struct {
 	Range int32
 }) (float64, error) {
 	// make sure to obey the minimal range
 	if args.Range < 60 {
 		args.Range = 60
 	}
-	return repository.R().TrxFlowSpeed(args.Range)
+
+	var speed float64
+	err := resolveWithin(ctx, func() (err error) {
+		speed, err = repository.R().TrxFlowSpeed(args.Range)
+		return err
+	})
+	if err != nil {
+		return 0.0, err
+	}
+	return speed, nil
 }
 
 // trxVolumeRange generates the time range for trx volume resolver.
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -27,16 +28,21 @@ func NewTransactionList(txs *types.TransactionList) *TransactionList {
 }
 
 // Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-func (rs *rootResolver) Transactions(args *struct {
-	Cursor *Cursor
-	Count  int32
+func (rs *rootResolver) Transactions(ctx context.Context, args *struct {
+	Cursor    *Cursor
+	Count     int32
+	SkipTotal bool
 }) (*TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the transaction hash list from repository
-	txs, err := repository.R().Transactions((*string)(args.Cursor), args.Count)
+	var txs *types.TransactionList
+	err := resolveWithin(ctx, func() (err error) {
+		txs, err = repository.R().Transactions((*string)(args.Cursor), args.Count, args.SkipTotal)
+		return err
+	})
 	if err != nil {
 		log.Errorf("can not get transactions list; %s", err.Error())
 		return nil, err
@@ -44,10 +50,13 @@ func (rs *rootResolver) Transactions(args *struct {
 	return NewTransactionList(txs), nil
 }
 
-// TotalCount resolves the total number of transactions in the list.
-func (tl *TransactionList) TotalCount() hexutil.Big {
-	val := (*hexutil.Big)(big.NewInt(int64(tl.Total)))
-	return *val
+// TotalCount resolves the total number of transactions in the list,
+// if it has been calculated.
+func (tl *TransactionList) TotalCount() *hexutil.Big {
+	if tl.TotalSkipped {
+		return nil
+	}
+	return (*hexutil.Big)(big.NewInt(int64(tl.Total)))
 }
 
 // PageInfo resolves the current page information for the transaction list.
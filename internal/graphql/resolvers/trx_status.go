@@ -0,0 +1,28 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TransactionStatus represents resolvable processing status of a transaction.
+type TransactionStatus struct {
+	types.TrxStatus
+}
+
+// TransactionStatus resolves the processing status of the given transaction
+// along with the number of its confirmations.
+func (rs *rootResolver) TransactionStatus(ctx context.Context, args struct{ Hash common.Hash }) (*TransactionStatus, error) {
+	var ts *types.TrxStatus
+	err := resolveWithin(ctx, func() (err error) {
+		ts, err = repository.R().TransactionStatus(&args.Hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionStatus{TrxStatus: *ts}, nil
+}
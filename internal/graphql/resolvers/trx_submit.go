@@ -0,0 +1,48 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"sync"
+	"time"
+)
+
+// trxSubmitWindow represents the time window of the transactions submit rate limiter.
+const trxSubmitWindow = time.Minute
+
+// submitLimiter implements fixed window rate limiter of transactions submitted by clients.
+type submitLimiter struct {
+	limit int
+
+	mu     sync.Mutex
+	window time.Time
+	hits   map[string]int
+}
+
+// newSubmitLimiter creates a new transactions submit limiter; zero limit disables it.
+func newSubmitLimiter(limit int) *submitLimiter {
+	return &submitLimiter{limit: limit, hits: make(map[string]int)}
+}
+
+// allow checks if the given client can submit another transaction in the current window.
+func (sl *submitLimiter) allow(client string) bool {
+	if sl.limit <= 0 {
+		return true
+	}
+
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	// start a new window if the current one expired
+	now := time.Now()
+	if now.Sub(sl.window) >= trxSubmitWindow {
+		sl.window = now
+		sl.hits = make(map[string]int)
+	}
+
+	// do we have a free slot?
+	if sl.hits[client] >= sl.limit {
+		return false
+	}
+	sl.hits[client]++
+	return true
+}
@@ -0,0 +1,27 @@
+package resolvers
+
+import "testing"
+
+// TestSubmitLimiter tests the transactions submitted are limited per client.
+func TestSubmitLimiter(t *testing.T) {
+	sl := newSubmitLimiter(2)
+	for i := 0; i < 2; i++ {
+		if !sl.allow("10.0.0.1") {
+			t.Fatalf("expected submit #%d to be allowed", i+1)
+		}
+	}
+	if sl.allow("10.0.0.1") {
+		t.Errorf("expected submit over the limit to be rejected")
+	}
+	if !sl.allow("10.0.0.2") {
+		t.Errorf("expected other client submit to be allowed")
+	}
+
+	// zero limit disables the limiter
+	sl = newSubmitLimiter(0)
+	for i := 0; i < 10; i++ {
+		if !sl.allow("10.0.0.1") {
+			t.Fatalf("expected unlimited submits")
+		}
+	}
+}
@@ -2,9 +2,11 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -73,6 +75,27 @@ func (rs *rootResolver) DefiUniswapPairs() []*UniswapPair {
 	return rs.defiUniswapPairs()
 }
 
+// UniswapPair resolves the Uniswap pair of the given tokens; null if the pair does not exist.
+func (rs *rootResolver) UniswapPair(ctx context.Context, args struct {
+	TokenA common.Address
+	TokenB common.Address
+}) (*UniswapPair, error) {
+	var adr *common.Address
+	err := resolveWithin(ctx, func() (err error) {
+		adr, err = repository.R().UniswapPair(&args.TokenA, &args.TokenB)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the factory provides empty address for unknown pairs
+	if adr == nil || *adr == (common.Address{}) {
+		return nil, nil
+	}
+	return NewUniswapPair(adr), nil
+}
+
 // DefiUniswapAmountsOut resolves a list of output amounts for the given
 // input amount and a list of tokens to be used to make the swap operation.
 func (rs *rootResolver) DefiUniswapAmountsOut(args *struct {
@@ -205,7 +228,21 @@ func (up *UniswapPair) Tokens() ([]*ERC20Token, error) {
 }
 
 // DefiUniswapVolumes returns all swap pairs and their information for swap volumes
-func (rs *rootResolver) DefiUniswapVolumes() []*UniswapPairVolume {
+func (rs *rootResolver) DefiUniswapVolumes(ctx context.Context) []*UniswapPairVolume {
+	var list []*UniswapPairVolume
+	err := resolveWithin(ctx, func() error {
+		list = rs.defiUniswapVolumes()
+		return nil
+	})
+	if err != nil {
+		log.Errorf("can not get uniswap volumes; %s", err.Error())
+		return make([]*UniswapPairVolume, 0)
+	}
+	return list
+}
+
+// defiUniswapVolumes builds the list of volumes of all the known uniswap pairs.
+func (rs *rootResolver) defiUniswapVolumes() []*UniswapPairVolume {
 	// get all the pairs
 	pairs := rs.defiUniswapPairs()
 
@@ -278,7 +315,7 @@ func (upv *UniswapPairVolume) IsInFUSD() (bool, error) {
 
 // DefiTimeVolumes resolves swap volumes for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimeVolumes(args *struct {
+func (rs *rootResolver) DefiTimeVolumes(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -300,7 +337,11 @@ func (rs *rootResolver) DefiTimeVolumes(args *struct {
 	}
 
 	// get volumes from DB repository
-	swapVolumes, err := repository.R().UniswapTimeVolumes(&args.Address, resolution, fDate, tDate)
+	var swapVolumes []types.DefiSwapVolume
+	err := resolveWithin(ctx, func() (err error) {
+		swapVolumes, err = repository.R().UniswapTimeVolumes(&args.Address, resolution, fDate, tDate)
+		return err
+	})
 	if err != nil {
 		log.Errorf("Can not get swap volumes from DB repository: %s", err.Error())
 		return make([]*DefiTimeVolume, 0)
@@ -320,7 +361,7 @@ func (rs *rootResolver) DefiTimeVolumes(args *struct {
 
 // DefiTimePrices resolves swap prices for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimePrices(args *struct {
+func (rs *rootResolver) DefiTimePrices(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -349,7 +390,11 @@ func (rs *rootResolver) DefiTimePrices(args *struct {
 	}
 
 	// get prices from DB repository
-	swapPrices, err := repository.R().UniswapTimePrices(&args.Address, resolution, fDate, tDate, dir)
+	var swapPrices []types.DefiTimePrice
+	err := resolveWithin(ctx, func() (err error) {
+		swapPrices, err = repository.R().UniswapTimePrices(&args.Address, resolution, fDate, tDate, dir)
+		return err
+	})
 	if err != nil {
 		log.Errorf("Can not get uniswap prices from DB repository: %s", err.Error())
 		return make([]types.DefiTimePrice, 0)
@@ -362,6 +407,52 @@ func (up *UniswapPair) Reserves() ([]hexutil.Big, error) {
 	return repository.R().UniswapReserves(&up.PairAddress)
 }
 
+// SpotPrices resolves the spot price of each token of the pair in units of the other token
+// implied by the current reserves; the prices are adjusted for decimals of the tokens.
+func (up *UniswapPair) SpotPrices() ([]float64, error) {
+	tokens, err := repository.R().UniswapTokens(&up.PairAddress)
+	if err != nil {
+		return nil, err
+	}
+	reserves, err := repository.R().UniswapReserves(&up.PairAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) != 2 || len(reserves) != 2 {
+		return nil, fmt.Errorf("invalid Uniswap pair %s", up.PairAddress.String())
+	}
+
+	// get the decimals of the tokens
+	decimals := make([]int32, len(tokens))
+	for i := range tokens {
+		decimals[i], err = repository.R().Erc20Decimals(&tokens[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return uniswapSpotPrices(reserves, decimals), nil
+}
+
+// uniswapSpotPrices calculates the spot prices of a pair of tokens from their reserves;
+// the prices are zero if any of the reserves is empty.
+func uniswapSpotPrices(reserves []hexutil.Big, decimals []int32) []float64 {
+	prices := make([]float64, 2)
+	if reserves[0].ToInt().Sign() <= 0 || reserves[1].ToInt().Sign() <= 0 {
+		return prices
+	}
+
+	// amounts of the tokens in the pool
+	amo := make([]*big.Float, 2)
+	for i := range amo {
+		amo[i] = new(big.Float).Quo(new(big.Float).SetInt(reserves[i].ToInt()),
+			new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals[i])), nil)))
+	}
+
+	prices[0], _ = new(big.Float).Quo(amo[1], amo[0]).Float64()
+	prices[1], _ = new(big.Float).Quo(amo[0], amo[1]).Float64()
+	return prices
+}
+
 // ReservesTimeStamp resolves reserves of the given Uniswap pair.
 func (up *UniswapPair) ReservesTimeStamp() (hexutil.Uint64, error) {
 	return repository.R().UniswapReservesTimeStamp(&up.PairAddress)
@@ -380,8 +471,8 @@ func (up *UniswapPair) TotalSupply() (hexutil.Big, error) {
 }
 
 // ShareOf resolves the total amount of a share of the given user on the given Uniswap pair.
-func (up *UniswapPair) ShareOf(args *struct{ User common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&up.PairAddress, &args.User)
+func (up *UniswapPair) ShareOf(ctx context.Context, args *struct{ User common.Address }) (hexutil.Big, error) {
+	return erc20BalanceOf(ctx, &up.PairAddress, &args.User)
 }
 
 // LastKValue resolves the last value of the pool control coefficient.
@@ -398,7 +489,7 @@ func checkDate(td *int32) int64 {
 
 // DefiTimeReserves resolves uniswap reserves for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimeReserves(args *struct {
+func (rs *rootResolver) DefiTimeReserves(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -420,7 +511,11 @@ func (rs *rootResolver) DefiTimeReserves(args *struct {
 	}
 
 	// get reserves from DB repository
-	timeReserves, err := repository.R().UniswapTimeReserves(&args.Address, resolution, fDate, tDate)
+	var timeReserves []types.DefiTimeReserve
+	err := resolveWithin(ctx, func() (err error) {
+		timeReserves, err = repository.R().UniswapTimeReserves(&args.Address, resolution, fDate, tDate)
+		return err
+	})
 	if err != nil {
 		log.Errorf("Can not get uniswap reserves from DB repository: %s", err.Error())
 		return make([]DefiTimeReserve, 0)
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -41,7 +42,7 @@ func NewUniswapActionList(ual *types.UniswapActionList) *UniswapActionList {
 }
 
 // DefiUniswapActions resolves list of blockchain uniswap actions encapsulated in a listable structure.
-func (rs *rootResolver) DefiUniswapActions(args *struct {
+func (rs *rootResolver) DefiUniswapActions(ctx context.Context, args *struct {
 	Cursor      *Cursor
 	Count       int32
 	PairAddress *common.Address
@@ -56,7 +57,11 @@ func (rs *rootResolver) DefiUniswapActions(args *struct {
 	}
 
 	// get the uniswap action list from repository
-	al, err := repository.R().UniswapActions(args.PairAddress, (*string)(args.Cursor), args.Count, *args.ActionType)
+	var al *types.UniswapActionList
+	err := resolveWithin(ctx, func() (err error) {
+		al, err = repository.R().UniswapActions(args.PairAddress, (*string)(args.Cursor), args.Count, *args.ActionType)
+		return err
+	})
 	if err != nil {
 		log.Errorf("can not get uniswap action list; %s", err.Error())
 		return nil, err
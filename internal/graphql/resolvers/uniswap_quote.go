@@ -0,0 +1,33 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UniswapQuote represents resolvable slippage adjusted swap quote.
+type UniswapQuote struct {
+	types.UniswapQuote
+}
+
+// UniswapQuote resolves a slippage adjusted quote of a swap
+// of the given input amount along the given path of tokens.
+func (rs *rootResolver) UniswapQuote(ctx context.Context, args *struct {
+	AmountIn    hexutil.Big
+	Path        []common.Address
+	SlippageBps int32
+}) (*UniswapQuote, error) {
+	var q *types.UniswapQuote
+	err := resolveWithin(ctx, func() (err error) {
+		q, err = repository.R().UniswapQuote(args.AmountIn, args.Path, args.SlippageBps)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapQuote{UniswapQuote: *q}, nil
+}
@@ -0,0 +1,25 @@
+package resolvers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestUniswapSpotPrices tests the spot prices are derived from the reserves and decimals of the tokens.
+func TestUniswapSpotPrices(t *testing.T) {
+	// 1000 tokens of 18 decimals against 2000 tokens of 6 decimals
+	r0 := new(big.Int).Mul(big.NewInt(1000), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	r1 := big.NewInt(2000000000)
+
+	prices := uniswapSpotPrices([]hexutil.Big{hexutil.Big(*r0), hexutil.Big(*r1)}, []int32{18, 6})
+	if prices[0] != 2 || prices[1] != 0.5 {
+		t.Errorf("expected prices [2 0.5], got %v", prices)
+	}
+
+	prices = uniswapSpotPrices([]hexutil.Big{hexutil.Big(*r0), {}}, []int32{18, 6})
+	if prices[0] != 0 || prices[1] != 0 {
+		t.Errorf("expected zero prices of empty pool, got %v", prices)
+	}
+}
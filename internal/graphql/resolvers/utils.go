@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/rand"
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
@@ -25,9 +26,13 @@ func (rs *rootResolver) Price(args *struct{ To string }) (types.Price, error) {
 }
 
 // GasPrice resolves the current amount of WEI for single Gas.
-func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
+func (rs *rootResolver) GasPrice(ctx context.Context) (hexutil.Uint64, error) {
 	// get the actual value
-	price, err := repository.R().GasPrice()
+	var price hexutil.Big
+	err := resolveWithin(ctx, func() (err error) {
+		price, err = repository.R().GasPrice()
+		return err
+	})
 	if err != nil {
 		return hexutil.Uint64(0), err
 	}
@@ -45,13 +50,21 @@ func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
 
 // EstimateGas resolves the estimated amount of Gas required to perform
 // transaction described by the input params.
-func (rs *rootResolver) EstimateGas(args struct {
+func (rs *rootResolver) EstimateGas(ctx context.Context, args struct {
 	From  *common.Address
 	To    *common.Address
 	Value *hexutil.Big
 	Data  *string
 }) (*hexutil.Uint64, error) {
-	return repository.R().GasEstimate(&args)
+	var val *hexutil.Uint64
+	err := resolveWithin(ctx, func() (err error) {
+		val, err = repository.R().GasEstimate(&args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
 }
 
 // uuid generates new random subscription UUID
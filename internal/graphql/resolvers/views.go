@@ -0,0 +1,71 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/svc"
+	"motif-api/internal/types"
+	"context"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// MaterializedView represents a resolvable refresh state of a materialized view.
+type MaterializedView struct {
+	types.MaterializedView
+}
+
+// MaterializedViews resolves the refresh state of the materialized views.
+func (rs *rootResolver) MaterializedViews() []*MaterializedView {
+	list := svc.Manager().Views()
+
+	res := make([]*MaterializedView, len(list))
+	for i, mv := range list {
+		res[i] = &MaterializedView{mv}
+	}
+	return res
+}
+
+// RefreshView refreshes the materialized view of the given name right away.
+// Only administrators are allowed to force a refresh.
+func (rs *rootResolver) RefreshView(ctx context.Context, args struct{ Name string }) (*MaterializedView, error) {
+	if err := auth.Require(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	mv, err := svc.Manager().RefreshView(args.Name)
+	if err != nil {
+		log.Errorf("can not refresh view %s; %s", args.Name, err.Error())
+		return nil, err
+	}
+	return &MaterializedView{*mv}, nil
+}
+
+// Interval resolves the time between two scheduled refreshes of the view in seconds.
+func (mv *MaterializedView) Interval() hexutil.Uint64 {
+	return hexutil.Uint64(mv.MaterializedView.Interval / time.Second)
+}
+
+// LastRefresh resolves the UNIX timestamp of the last refresh of the view.
+func (mv *MaterializedView) LastRefresh() *hexutil.Uint64 {
+	if mv.MaterializedView.LastRefresh == nil {
+		return nil
+	}
+	ts := hexutil.Uint64(mv.MaterializedView.LastRefresh.Unix())
+	return &ts
+}
+
+// Staleness resolves the number of seconds elapsed since the last refresh of the view.
+func (mv *MaterializedView) Staleness() *hexutil.Uint64 {
+	st := mv.MaterializedView.Staleness(time.Now())
+	if st == nil {
+		return nil
+	}
+	val := hexutil.Uint64(*st / time.Second)
+	return &val
+}
+
+// IsStale resolves the flag of the view missing its scheduled refresh.
+func (mv *MaterializedView) IsStale() bool {
+	return mv.MaterializedView.IsStale(time.Now())
+}
@@ -91,8 +91,10 @@ type DefiToken {
     canTrade: Boolean!
 
     # price represents the value of the token in ref. denomination.
-    # We use fUSD tokens as the synth reference value.
-    price: BigInt!
+    # We use fUSD tokens as the synth reference value. The price is converted
+    # to the given currency code, if supported by the API server, keeping
+    # the same number of price decimals.
+    price(currency: String = "USD"): BigInt!
 
     # priceDecimals is the number of decimals used on the price
     # field to properly handle value calculations without loosing precision.
@@ -143,6 +145,10 @@ type ERC20Transaction {
     # executing the ERC20 call.
     trxHash: Bytes32!
 
+    # blockNumber represents the number of the block
+    # containing the ERC20 call.
+    blockNumber: Long!
+
     # transaction represents the transaction
     # executing the ERC20 call.
     transaction: Transaction!
@@ -173,6 +179,10 @@ type ERC20Transaction {
     # with the correct number of decimals from the ERC20 token detail.
     amount: BigInt!
 
+    # amountSuspicious signals the amount exceeds the sanity bound
+    # of the API server and should not be trusted, nor aggregated.
+    amountSuspicious: Boolean!
+
     # timeStamp represents the Unix epoch time stamp
     # of the ERC20 transaction processing.
     timeStamp: Long!
@@ -247,7 +257,8 @@ type TransactionList {
     edges: [TransactionListEdge!]!
 
     # TotalCount is the maximum number of transactions available for sequential access.
-    totalCount: BigInt!
+    # It is null if the client asked to skip the total calculation.
+    totalCount: BigInt
 
     # PageInfo is an information about the current page of transaction edges.
     pageInfo: ListPageInfo!
@@ -389,6 +400,39 @@ type Transaction {
     # erc1155Transactions provides list of ERC-1155 NFT transactions executed in the scope
     # of this blockchain transaction call.
     erc1155Transactions: [ERC1155Transaction!]!
+
+    # estimatedConfirmationTime provides a best-effort estimate of the time
+    # to confirmation of a pending transaction. Null once the transaction is confirmed.
+    estimatedConfirmationTime: TransactionEta
+
+    # revertReason is the reason a failed transaction reverted with, decoded by replaying
+    # the transaction. Null for successful transactions, and if the reason is not recoverable.
+    revertReason: String
+
+    # trace is the internal call trace of the transaction in the JSON form reported by the node.
+    # Restricted to privileged clients; null if the client is not permitted to see it.
+    trace: String
+}
+
+# TransactionEta represents a best-effort heuristic estimate of the time
+# to confirmation of a pending transaction. The estimate compares the gas price
+# of the transaction with the gas price currently suggested by the node
+# and uses the recent average block interval of the chain. It's chain specific
+# and does not guarantee the transaction will be confirmed in the estimated time.
+type TransactionEta {
+    # blocks is the estimated number of blocks until the confirmation.
+    blocks: Int!
+
+    # seconds is the estimated number of seconds until the confirmation.
+    seconds: Float!
+
+    # gasPriceRatio is the ratio of the transaction gas price
+    # to the currently suggested gas price.
+    gasPriceRatio: Float!
+
+    # isUnderpriced signals the gas price is too low to estimate
+    # the confirmation; the transaction may never be confirmed.
+    isUnderpriced: Boolean!
 }
 
 # Block is an Opera block chain block.
@@ -501,6 +545,10 @@ type ERC20Token {
     # totalSupply represents total amount of tokens across all accounts
     totalSupply: BigInt!
 
+    # totalSupplySuspicious signals the total supply exceeds the sanity bound
+    # of the API server and should not be trusted, nor aggregated.
+    totalSupplySuspicious: Boolean!
+
     # logoURL represents a URL address of a logo of the token. It's always
     # provided, but unknown tokens have this set to a generic logo file.
     logoURL: String!
@@ -511,6 +559,10 @@ type ERC20Token {
     # on the account behalf.
     balanceOf(owner: Address!): BigInt!
 
+    # balanceOfSuspicious signals the balance of the owner exceeds the sanity
+    # bound of the API server and should not be trusted, nor aggregated.
+    balanceOfSuspicious(owner: Address!): Boolean!
+
     # allowance represents the amount of ERC20 tokens unlocked
     # by the owner / token holder to be accessible for the given spender.
     allowance(owner: Address!, spender: Address!): BigInt!
@@ -520,6 +572,14 @@ type ERC20Token {
 
     # totalDebt represents total amount of borrowed/minted tokens on fMint.
     totalDebt: BigInt!
+
+    # priceComparison represents the oracle price of the token cross-referenced
+    # with the DEX spot price against the configured base token.
+    priceComparison: ERC20PriceComparison!
+
+    # riskFlags represents a set of flags signaling the token may be a scam,
+    # or a spam token. The flags are heuristics, not a definitive verdict.
+    riskFlags: ERC20TokenRiskFlags!
 }
 
 # DelegationList is a list of delegations edges provided by sequential access request.
@@ -684,7 +744,8 @@ type ERC721TransactionList {
     edges: [ERC721TransactionListEdge!]!
 
     # TotalCount is the maximum number of ERC721 transactions available for sequential access.
-    totalCount: BigInt!
+    # It is null if the client asked to skip the total calculation.
+    totalCount: BigInt
 
     # PageInfo is an information about the current page of ERC721 transaction edges.
     pageInfo: ListPageInfo!
@@ -725,10 +786,14 @@ type Contract {
     "Smart contract compiler identifier. Empty if not available."
     compiler: String!
 
-    "Smart contract source code. Empty if not available."
+    "Smart contract source code. Empty if not available, or not permitted to the client."
     sourceCode: String!
 
-    "Smart contract ABI definition. Empty if not available."
+    """
+    Smart contract ABI definition. Empty if not available.
+    ABI of a contract not validated locally is loaded from the remote
+    verification service, if the API server is configured to use one.
+    """
     abi: String!
 
     """
@@ -771,6 +836,13 @@ input ContractValidationInput {
     """
     optimizeRuns: Int = 200
 
+    """
+    CompilerVersion specifies the version of the Solidity compiler the contract
+    was compiled with, i.e. "0.8.4". The compiler must be installed on the API server.
+    The default compiler is used if not specified.
+    """
+    compilerVersion: String
+
     "Smart contract source code."
     sourceCode: String!
 }
@@ -799,7 +871,8 @@ type ERC20TransactionList {
     edges: [ERC20TransactionListEdge!]!
 
     # TotalCount is the maximum number of ERC20 transactions available for sequential access.
-    totalCount: BigInt!
+    # It is null if the client asked to skip the total calculation.
+    totalCount: BigInt
 
     # PageInfo is an information about the current page of ERC20 transaction edges.
     pageInfo: ListPageInfo!
@@ -990,6 +1063,10 @@ type Staker {
     # Number of seconds the staker is offline.
     downtime: Long!
 
+    # Share of delegators' rewards taken by the staker as a commission.
+    # The value is a fraction of 10^18.
+    commission: BigInt!
+
     # List of delegations of this staker. Cursor is used to obtain specific slice
     # of the staker's delegations. The most recent delegations
     # are provided if cursor is omitted.
@@ -1009,7 +1086,8 @@ type ERC1155TransactionList {
     edges: [ERC1155TransactionListEdge!]!
 
     # TotalCount is the maximum number of ERC1155 transactions available for sequential access.
-    totalCount: BigInt!
+    # It is null if the client asked to skip the total calculation.
+    totalCount: BigInt
 
     # PageInfo is an information about the current page of ERC1155 transaction edges.
     pageInfo: ListPageInfo!
@@ -1048,6 +1126,25 @@ type FMintAccount {
     # in ref. denomination (fUSD).
     debtValue: BigInt!
 
+    # collateralRatio represents the current ratio between the collateral value
+    # and the debt value of the account, e.g. 3.0 for collateral worth three times
+    # the debt. NULL if the account has no debt.
+    collateralRatio: Float
+
+    # collateralTokens represents the list of tokens deposited
+    # on the account as a collateral. Tokens with no balance are not listed.
+    collateralTokens: [FMintTokenPosition!]!
+
+    # debtTokens represents the list of tokens borrowed/minted
+    # on the account. Tokens with no balance are not listed.
+    debtTokens: [FMintTokenPosition!]!
+
+    # liquidationPrices represents the price of each collateral token at which
+    # the account would drop below the minimal collateral ratio. Each price is calculated
+    # assuming the prices of all the other tokens, both collateral and debt,
+    # stay at their current level.
+    liquidationPrices: [FMintLiquidationPrice!]!
+
     # rewardsEarned represents accumulated rewards
     # earned on the DeFi / fMint account for the excessive
     # collateral value. Please note that the rewards could still
@@ -1075,6 +1172,30 @@ type FMintAccount {
     canPushNewRewards: Boolean!
 }
 
+# FMintTokenPosition represents a position of a single token
+# on an fMint account, either deposited as a collateral,
+# or borrowed/minted as a debt.
+type FMintTokenPosition {
+    # type represents the side of the position.
+    type: DefiTokenBalanceType!
+
+    # tokenAddress represents unique identifier of the token.
+    tokenAddress: Address!
+
+    # token represents the detail of the token
+    token: DefiToken!
+
+    # amount of the token on the position.
+    amount: BigInt!
+
+    # value of the position in USD corrected for the token decimals.
+    # The value uses the price oracle decimals, see valueDecimals.
+    value: BigInt!
+
+    # valueDecimals is the number of decimals of the value.
+    valueDecimals: Int!
+}
+
 # FMintTokenBalance represents a balance of a specific DeFi token
 # on an fMint protocol account.
 # The balance is used for both collateral deposits and minting debt.
@@ -1096,6 +1217,77 @@ type FMintTokenBalance {
     value: BigInt!
 }
 
+# FMintRewards represents the rewards of an fMint account
+# distributed by the fMint reward distribution contract.
+type FMintRewards {
+    # owner represents the address of the fMint account.
+    owner: Address!
+
+    # rewardToken is the address of the token the rewards are paid in.
+    rewardToken: Address!
+
+    # pending is the amount of rewards accumulated on the account,
+    # including the stashed rewards.
+    pending: BigInt!
+
+    # rewardRate is the amount of rewards distributed per second
+    # across all the fMint accounts.
+    rewardRate: BigInt!
+
+    # principalBalance is the principal balance of the account
+    # the rewards accrue on.
+    principalBalance: BigInt!
+
+    # totalPrincipalBalance is the principal balance of all the fMint accounts.
+    totalPrincipalBalance: BigInt!
+
+    # accrualRate is the amount of rewards accruing on the account per second
+    # at the current reward rate, i.e. the share of the reward rate by the principal
+    # balance of the account. Use it to project the accrual of the rewards.
+    accrualRate: BigInt!
+}
+
+# FMintLiquidationRisk represents the distance of the collateral ratio
+# of an fMint account from the minimal collateral ratio the account
+# can be liquidated below.
+type FMintLiquidationRisk {
+    # collateralRatio is the current ratio between the collateral
+    # and the debt values of the account.
+    collateralRatio: Float!
+
+    # minCollateralRatio is the minimal allowed ratio between
+    # the collateral and the debt values.
+    minCollateralRatio: Float!
+
+    # margin is the distance of the collateral ratio above the minimal ratio
+    # in percent of the minimal ratio. It's negative if the account
+    # is already below the ratio.
+    margin: Float!
+
+    # atRisk signals the margin is within the risk buffer.
+    atRisk: Boolean!
+}
+
+# FMintLiquidationPrice represents the price of a collateral token at which
+# an fMint account can be liquidated, if the prices of the other tokens
+# of the account do not change.
+type FMintLiquidationPrice {
+    # tokenAddress represents unique identifier of the collateral token.
+    tokenAddress: Address!
+
+    # token represents the detail of the collateral token.
+    token: DefiToken!
+
+    # price is the USD price of the token at which the account drops
+    # below the minimal collateral ratio. It's above the current price
+    # if the account is already below the ratio. Null if the account
+    # has no debt, or the other collateral alone keeps it above the ratio.
+    price: Float
+
+    # currentPrice is the current USD price of the token.
+    currentPrice: Float!
+}
+
 # DefiSettings represents the set of current settings and limits
 # applied to DeFi operations.
 type DefiSettings {
@@ -1252,6 +1444,13 @@ type UniswapPair {
     # in which this reserves state was reached.
     reservesTimeStamp: Long!
 
+    # spot prices of the tokens of the pair implied by the reserves.
+    # The price of each token is given in units of the other token
+    # adjusted for decimals of the tokens; zero if the pool is empty.
+    # The price index inside the array corresponds
+    # with the token position.
+    spotPrices: [Float!]!
+
     # cumulative prices of the tokens of the pair.
     # The price index inside the array corresponds
     # with the token position.
@@ -1601,6 +1800,10 @@ type ERC1155Transaction {
     # from the token Metadata JSON Schema.
     amount: BigInt!
 
+    # amountSuspicious signals the amount exceeds the sanity bound
+    # of the API server and should not be trusted, nor aggregated.
+    amountSuspicious: Boolean!
+
     # timeStamp represents the Unix epoch time stamp
     # of the ERC1155 transaction processing.
     timeStamp: Long!
@@ -1697,6 +1900,10 @@ type TokenTransaction {
     # amount of tokens involved in the transaction.
     amount: BigInt!
 
+    # amountSuspicious signals the amount exceeds the sanity bound
+    # of the API server and should not be trusted, nor aggregated.
+    amountSuspicious: Boolean!
+
     # multi-token contracts (ERC-721/ERC-1155) token ID involved in the transaction.
     tokenId: BigInt!
 
@@ -1710,7 +1917,10 @@ type Account {
     address: Address!
 
     # Balance is the current balance of the Account in WEI.
-    balance: BigInt!
+    # The balance at the given block is provided if the block number is specified;
+    # the state of older blocks is available only if the API server is connected
+    # to an archive node.
+    balance(block: Long): BigInt!
 
     # TotalValue is the current total value of the account in WEI.
     # It includes available balance, delegated amount and pending rewards.
@@ -1720,17 +1930,72 @@ type Account {
     # txCount represents number of transaction sent from the account (Nonce).
     txCount: Long!
 
+    # nonce represents number of confirmed transactions sent from the account.
+    nonce: Long!
+
+    # pendingNonce represents number of transactions sent from the account
+    # including the transactions pending in the node transaction pool.
+    # If it equals the nonce, the account has no transactions in flight.
+    pendingNonce: Long!
+
     # txList represents list of transactions of the account in form of TransactionList.
-    txList(cursor:Cursor, count:Int!): TransactionList!
+    # Up to 100 transactions are provided per page; an account without transactions
+    # resolves to an empty list.
+    # The totalCount is not calculated, and resolves to null, if <skipTotal> is set.
+    txList(cursor:Cursor, count:Int!, skipTotal: Boolean = false): TransactionList!
+
+    # failedTransactions represents list of outbound transactions of the account
+    # which failed, sorted from the most recent one back. The revert reason
+    # of each transaction is available if recoverable.
+    failedTransactions(cursor:Cursor, count:Int = 25): TransactionList!
 
     # erc20TxList represents list of ERC20 transactions of the account.
-    erc20TxList(cursor:Cursor, count:Int = 25, token: Address, txType: String): ERC20TransactionList!
+    # The totalCount is not calculated, and resolves to null, if <skipTotal> is set.
+    erc20TxList(cursor:Cursor, count:Int = 25, token: Address, txType: String, skipTotal: Boolean = false): ERC20TransactionList!
 
     # erc721TxList represents list of ERC721 transactions of the account.
-    erc721TxList(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, txType: String): ERC721TransactionList!
+    # The totalCount is not calculated, and resolves to null, if <skipTotal> is set.
+    erc721TxList(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, txType: String, skipTotal: Boolean = false): ERC721TransactionList!
 
     # erc1155TxList represents list of ERC1155 transactions of the account.
-    erc1155TxList(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, txType: String): ERC1155TransactionList!
+    # The totalCount is not calculated, and resolves to null, if <skipTotal> is set.
+    erc1155TxList(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, txType: String, skipTotal: Boolean = false): ERC1155TransactionList!
+
+    # nfts represents list of ERC721/ERC1155 tokens held by the account
+    # sorted by the contract and the token id. The list can be limited
+    # to a single contract using the token argument.
+    nfts(cursor:Cursor, count:Int = 25, token: Address): NftHoldingList!
+
+    # stakingHistory represents list of delegation and stake changes
+    # of the account sorted from the most recent one back by default.
+    # The list can be limited to a single validator using the validatorId argument.
+    stakingHistory(cursor:Cursor, count:Int = 25, validatorId: BigInt): StakeChangeList!
+
+    # netWorthHistory represents the USD value of the account portfolio at blocks
+    # of the given range separated by the interval, up to 100 points. The portfolio
+    # consists of the native balance and balances of the active DeFi tokens.
+    # Historical balances require the API server to be connected to an archive node,
+    # historical prices require the price snapshots to be enabled on the API server
+    # over the range. Points missing any of these provide partial values with flags.
+    # The values are converted to the given currency code, if supported by the API server.
+    netWorthHistory(fromBlock: Long!, toBlock: Long!, interval: Long!, currency: String = "USD"): [NetWorthPoint!]!
+
+    # portfolioDistribution represents the breakdown of the current USD value
+    # of the account portfolio by tokens, using the current oracle prices.
+    # The portfolio consists of the same balances as the net worth history.
+    # The distribution is cached for 30 seconds. The values are converted
+    # to the given currency code, if supported by the API server.
+    portfolioDistribution(currency: String = "USD"): PortfolioDistribution!
+
+    # defiPositions represents the positions of the account across the DeFi protocols,
+    # e.g. the fMint position and the liquidity positions on the whitelisted DEX pairs.
+    # Protocols the account has no position in are omitted.
+    defiPositions: DefiPositions!
+
+    # approvalRisk represents a heuristic risk score of the active ERC20 approvals
+    # granted by the account. Unlimited approvals to unverified, or flagged spenders
+    # score the highest; the weights are configured on the API server.
+    approvalRisk: ApprovalRisk!
 
     # Details of a staker, if the account is a staker.
     staker: Staker
@@ -1738,8 +2003,29 @@ type Account {
     # List of delegations of the account, if the account is a delegator.
     delegations(cursor:Cursor, count:Int = 25): DelegationList!
 
+    # rewardSources represents the rewards claimed by the account over the trailing
+    # window given in hours, up to 365 days, grouped by the validator they were earned on.
+    # The validators are sorted by the claimed amount from the highest one,
+    # unless <ascending> is set. The list is empty if there are no claims in the window.
+    rewardSources(window: Int = 720, ascending: Boolean = false): RewardSources!
+
     # Details about smart contract, if the account is a smart contract.
     contract: Contract
+
+    # code represents the byte code deployed at the address of the account.
+    # The code is empty for externally owned accounts.
+    code: Bytes!
+
+    # isContract signals the account has a code deployed at its address.
+    isContract: Boolean!
+
+    # contractType represents classification of the account by its code
+    # convenient for UI badges. The classification is cached.
+    contractType: ContractType!
+
+    # storageAt provides the raw value of the given storage slot of the account.
+    # Restricted to privileged clients; null if the client is not permitted to see it.
+    storageAt(slot: Bytes32!): Bytes32
 }
 
 # GovernanceContract represents basic information
@@ -2000,6 +2286,11 @@ type Query {
     # Get an Account information by hash address.
     account(address:Address!):Account!
 
+    # accountOverviews provides live overviews of up to 100 accounts loaded
+    # from the node in a single batch of calls. The overviews are in the order
+    # of the addresses; each one degrades independently if its calls fail.
+    accountOverviews(addresses:[Address!]!):[AccountOverview!]!
+
     # Get list of Contracts with at most <count> edges.
     # If <count> is positive, return edges after the cursor,
     # if negative, return edges before the cursor.
@@ -2011,6 +2302,7 @@ type Query {
 
     # Get block information by number or by hash.
     # If neither is provided, the most recent block is given.
+    # Providing both the number and the hash is rejected as ambiguous.
     block(number:Long, hash: Bytes32):Block
 
     # Get list of Blocks with at most <count> edges.
@@ -2023,21 +2315,56 @@ type Query {
     # Get transaction information for given transaction hash.
     transaction(hash:Bytes32!):Transaction
 
+    # Get receipt of the transaction for given transaction hash.
+    # Null is returned for a pending transaction.
+    transactionReceipt(hash:Bytes32!):TransactionReceipt
+
+    # Get processing status of the transaction for given transaction hash
+    # along with the number of its confirmations, i.e. the number of blocks
+    # on top of the block containing the transaction. Pending transactions
+    # are waiting in the transaction pool of the node. Transactions the node
+    # has never seen have the unknown status.
+    transactionStatus(hash:Bytes32!):TransactionStatus!
+
+    # Resolve the address registered for the given human-readable name
+    # in the configured name registry, e.g. "alice.motif". Null is returned
+    # if the name is not registered, or no registry is configured.
+    # Resolutions are cached for the standard cache eviction time.
+    resolveName(name:String!):Address
+
+    # Resolve the human-readable name of the given address by its reverse record
+    # in the configured name registry. The name is returned only if it resolves
+    # back to the same address; null is returned otherwise.
+    lookupAddress(address:Address!):String
+
     # Get list of Transactions with at most <count> edges.
     # If <count> is positive, return edges after the cursor,
     # if negative, return edges before the cursor.
     # For undefined cursor, positive <count> starts the list from top,
     # negative <count> starts the list from bottom.
-    transactions(cursor:Cursor, count:Int!):TransactionList!
+    #
+    # Counting the total of large lists is expensive; clients not using
+    # the totalCount can set <skipTotal> to avoid the cost, the totalCount
+    # is null in that case and the paging works as usual. The same applies
+    # to the ERC transactions lists below and the transaction lists of an account.
+    # Lists with cheap totals backed by counters (blocks, epochs) always provide them;
+    # the top page of the transactions list served from the cache does as well.
+    transactions(cursor:Cursor, count:Int!, skipTotal: Boolean = false):TransactionList!
 
     # Get filtered list of ERC20 Transactions.
-    erc20Transactions(cursor:Cursor, count:Int = 25, token: Address, account: Address, txType: String): ERC20TransactionList!
+    erc20Transactions(cursor:Cursor, count:Int = 25, token: Address, account: Address, txType: String, skipTotal: Boolean = false): ERC20TransactionList!
+
+    # erc20Transfers provides the Transfer events of the given ERC20 token, including
+    # mints and burns, filtered by the sender and/or the recipient, if provided.
+    # Without the filters the full transfer history of the token is listed.
+    # The count is capped by the API server the same way the other transaction lists are.
+    erc20Transfers(token: Address!, from: Address, to: Address, cursor: Cursor, count: Int = 25): ERC20TransactionList!
 
     # Get filtered list of ERC721 Transactions.
-    erc721Transactions(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, account: Address, txType: String): ERC721TransactionList!
+    erc721Transactions(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, account: Address, txType: String, skipTotal: Boolean = false): ERC721TransactionList!
 
     # Get filtered list of ERC1155 Transactions.
-    erc1155Transactions(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, account: Address, txType: String): ERC1155TransactionList!
+    erc1155Transactions(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, account: Address, txType: String, skipTotal: Boolean = false): ERC1155TransactionList!
 
     # Get the id of the current epoch of the Opera blockchain.
     currentEpoch:Long!
@@ -2049,6 +2376,28 @@ type Query {
     # Get a scrollable list of epochs sorted from the last one back by default.
     epochs(cursor: Cursor, count: Int = 25): EpochList!
 
+    # Get a scrollable list of chain reorganizations detected by the API server
+    # sorted from the most recent one back by default. The list is empty
+    # if no reorg has been observed.
+    reorgHistory(cursor: Cursor, count: Int = 25): ReorgList!
+
+    # Get a scrollable list of recorded calls of watched methods of the given contract
+    # sorted from the most recent one back by default. The method can be specified
+    # by its name, or by its 0x prefixed 4 bytes selector. Only methods configured
+    # for watching by the API server are recorded.
+    methodCalls(address: Address!, method: String, cursor: Cursor, count: Int = 25): MethodCallList!
+
+    # Get the list of logs matching the filter pulled live from the node.
+    # The block range is limited by the API server configuration; wide ranges
+    # are pulled from the node by sub-ranges and the number of sub-requests made
+    # is reported in the "logsSubRequests" extension of the response.
+    logs(filter: LogFilter!): [Log!]!
+
+    # Get a scrollable list of log records matching the filter pulled from the logs index
+    # sorted from the most recent one back by default. Log records are indexed only
+    # if the logs index is enabled on the API server.
+    indexedLogs(filter: LogFilter!, cursor: Cursor, count: Int = 25): LogList!
+
     # The last staker id in Opera blockchain.
     lastStakerId: Long!
 
@@ -2062,6 +2411,10 @@ type Query {
     # List of staker information from SFC smart contract.
     stakers: [Staker!]!
 
+    # List of validators from SFC smart contract sorted by their total stake.
+    # Deactivated validators are skipped if activeOnly is set.
+    validators(activeOnly: Boolean = true): [Staker!]!
+
     # The list of delegations for the given staker ID.
     # Cursor is used to obtain specific slice of the staker's delegations.
     # The most recent delegations are provided if cursor is omitted.
@@ -2077,10 +2430,38 @@ type Query {
     # Returns the current price per gas in WEI units.
     gasPrice: Long!
 
+    # chainMetrics provides the chain throughput metrics derived from
+    # recently indexed blocks. Returns NULL during the API server startup
+    # until enough blocks are indexed.
+    chainMetrics: ChainMetrics
+
+    # indexProgress provides the indexing progress of the scanner indexers,
+    # e.g. the last indexed block and the lag behind the chain head per collection.
+    indexProgress: [IndexProgress!]!
+
+    # materializedViews provides the refresh state of the periodically refreshed views.
+    materializedViews: [MaterializedView!]!
+
+    # cacheStats provides the usage statistics of the in-memory cache since the start
+    # of the server, or the last reset of the counters. Use it to tune the cache size.
+    # Only administrators can access the statistics.
+    cacheStats: CacheStats!
+
     # estimateGas returns the estimated amount of gas required
     # for the transaction described by the parameters of the call.
     estimateGas(from: Address, to: Address, value: BigInt, data: String): Long
 
+    # contractCall executes a read only call of the contract with the given input data
+    # on the state of the given block, or the latest one, and returns the raw return data.
+    # The revert reason is provided as the error if the call reverts.
+    # The call is available only if the API server allows raw contract calls.
+    contractCall(to: Address!, data: Bytes!, block: Long): Bytes!
+
+    # feeHistory provides the base fee and the priority fee rewards at the given
+    # percentiles of up to 1024 most recent blocks. The percentiles must be
+    # in the range of 0 to 100 in ascending order.
+    feeHistory(blockCount: Long!, rewardPercentiles: [Float!]!): FeeHistory!
+
     # Get price details of the Opera blockchain token for the given target symbols.
     price(to:String!):Price!
 
@@ -2090,6 +2471,12 @@ type Query {
     # If you provide both, the address takes precedence and the amount is ignored.
     estimateRewards(address:Address, amount:Long):EstimatedRewards!
 
+    # Get projected rewards of the amount in WEI delegated to the given validator
+    # and locked for the given number of days. Zero days means no lock-up,
+    # otherwise the lock must fit into the lock-up range of the SFC contract.
+    # The projection deducts the validator commission.
+    estimateStakingRewards(validatorId: BigInt!, amount: BigInt!, lockDays: Int = 0): StakingRewardsEstimate!
+
     # sfcRewardsCollectedAmount provides an amount of rewards collected based on given
     # filtering options, which are all optional. If no filter option is passed,
     # the total amount of collected rewards is being presented.
@@ -2101,6 +2488,44 @@ type Query {
     # defiTokens represents a list of all available DeFi tokens.
     defiTokens:[DefiToken!]!
 
+    # priceHistory provides the oracle price snapshots of the given DeFi token
+    # taken in the given time range, sorted from the oldest one. The range is
+    # given by UNIX timestamps and defaults to the last month. If the range holds
+    # more than count snapshots, the most recent ones are provided.
+    # Snapshots are taken only if enabled on the API server, in the configured interval.
+    priceHistory(token: Address!, from: Long, to: Long, count: Int = 100):[PriceSnapshot!]!
+
+    # tokenPrice provides the current price of the given token from the DeFi price oracle.
+    # Prices are cached on the API server for a short configurable time.
+    # Null is returned if the oracle has no price feed for the token.
+    tokenPrice(token: Address!):TokenPrice
+
+    # convertAmount provides the value of the given amount of the source token
+    # in the target token by the current prices of both tokens from the DeFi price oracle.
+    # Amounts are in the smallest units of the tokens; the decimals of both tokens
+    # are respected and the result is rounded down.
+    # Null is returned if the oracle has no price feed for any of the tokens.
+    convertAmount(fromToken: Address!, toToken: Address!, amount: BigInt!):BigInt
+
+    # trendingTokens provides the tokens with the highest number of transactions
+    # over the trailing window given in hours, sorted from the most active one.
+    # Token transactions are counted in hourly buckets by the time of their block,
+    # so the window starts at the beginning of the hour it reaches into and can
+    # cover up to an hour more than requested. The window is limited to 30 days.
+    trendingTokens(window: Int = 24, count: Int = 25):[TokenActivity!]!
+
+    # erc20TokenHolders provides the top holders of the given ERC20 token sorted
+    # by their balance from the highest. The balances are accumulated from the token
+    # transfers indexed by the API server. Up to 200 holders are provided.
+    erc20TokenHolders(token: Address!, count: Int = 25):[ERC20TokenHolder!]!
+
+    # erc20SupplyHistory provides the total supply snapshots of the given ERC20 token
+    # taken in the given inclusive block range, sorted from the oldest one. The nearest
+    # snapshot before the range is included if the first block of the range is not recorded.
+    # Up to 1000 snapshots are provided. Snapshots are taken only if enabled on the API server,
+    # on the configured interval of blocks, for the most active tokens.
+    erc20SupplyHistory(token: Address!, fromBlock: Long!, toBlock: Long!):[ERC20SupplySnapshot!]!
+
     # defiNativeToken represents the information about the native token
     # wrapper ERC20 contract. Returns NULL if the native token wraper
     # is not available.
@@ -2109,18 +2534,65 @@ type Query {
     # fMintAccount provides DeFi/fMint information about an account on fMint protocol.
     fMintAccount(owner: Address!):FMintAccount!
 
+    # fMintLiquidationRisk provides the distance of the collateral ratio of the given
+    # fMint account from the minimal collateral ratio at the current oracle prices.
+    # The account is at risk if the margin is within the buffer configured on the API server.
+    # Null if the account has no debt.
+    fMintLiquidationRisk(owner: Address!):FMintLiquidationRisk
+
+    # fMintRewards provides the pending rewards of the given fMint account
+    # along with the reward token and the reward rate of the fMint reward distribution.
+    # Accounts which never minted have zero rewards.
+    fMintRewards(owner: Address!):FMintRewards!
+
     # fMintTokenAllowance resolves the amount of ERC20 tokens unlocked
     # by the token owner for DeFi/fMint operations.
     fMintTokenAllowance(owner: Address!, token: Address!):BigInt!
 
+    # fMintDepositData builds an unsigned call of the fMint minter contract depositing
+    # the amount of the collateral token. The call is not signed, nor sent; the client
+    # signs it with the owner key. The owner is optional and only used to estimate the gas.
+    fMintDepositData(token: Address!, amount: BigInt!, owner: Address): FMintCallData!
+
+    # fMintWithdrawData builds an unsigned call withdrawing the amount of the collateral token.
+    # If the owner is given, the resulting position is checked and a warning is provided
+    # if the withdrawal would make the position liquidatable.
+    fMintWithdrawData(token: Address!, amount: BigInt!, owner: Address): FMintCallData!
+
+    # fMintMintData builds an unsigned call minting the amount of the synthetic token.
+    # If the owner is given, the resulting position is checked and a warning is provided
+    # if the mint would make the position liquidatable.
+    fMintMintData(token: Address!, amount: BigInt!, owner: Address): FMintCallData!
+
+    # fMintRepayData builds an unsigned call repaying the amount of the debt token.
+    # The owner is optional and only used to estimate the gas.
+    fMintRepayData(token: Address!, amount: BigInt!, owner: Address): FMintCallData!
+
+    # erc20ApproveData builds an unsigned ERC20 approve call unlocking the amount
+    # of the token for the spender. Missing, or zero amount builds the call revoking
+    # the allowance of the spender. The call is not signed, nor sent; the client signs it
+    # with the owner key. The owner is optional and only used to estimate the gas.
+    erc20ApproveData(token: Address!, spender: Address!, amount: BigInt, owner: Address): ERC20ApproveCallData!
+
     # fMintUserTokens resolves a list of pairs of fMint users and their tokens
     # used for a specified purpose.
     fMintUserTokens(purpose:FMintUserTokenPurpose=FMINT_COLLATERAL):[FMintUserToken!]!
 
+    # fMintFeesCollected resolves the fees paid on fMint minting over the trailing
+    # window given in hours, up to 90 days. Each mint pays the fee in the minted token
+    # by the fee rate effective at the time (see mintFee4 of the DeFi settings),
+    # the fees are aggregated from the indexed mint events by the minted token
+    # with precision of 6 decimals. USD values use the current oracle prices.
+    fMintFeesCollected(window: Int = 24): ProtocolFees!
+
     # defiUniswapPairs represents a list of all pairs managed
     # by the Uniswap Core contract on Opera blockchain.
     defiUniswapPairs: [UniswapPair!]!
 
+    # uniswapPair resolves the pair of the given tokens managed
+    # by the Uniswap Core contract; null if the pair does not exist.
+    uniswapPair(tokenA: Address!, tokenB: Address!): UniswapPair
+
     # defiUniswapAmountsOut calculates the expected output amounts
     # required to finalize a swap operation specified by a list of
     # tokens involved in the swap steps and the input amount.
@@ -2135,6 +2607,13 @@ type Query {
     # for the calculation to succeed.
     defiUniswapAmountsIn(amountOut: BigInt!, tokens:[Address!]!): [BigInt!]!
 
+    # uniswapQuote calculates the expected output of a swap of the given input
+    # amount along the path of tokens, and the minimal output with the slippage
+    # tolerance given in basis points applied; e.g. 50 represents 0.5%.
+    # The minimal output is the amountOutMin value of the swap call.
+    # The slippage tolerance must be between 0 and 5000 basis points.
+    uniswapQuote(amountIn: BigInt!, path:[Address!]!, slippageBps: Int = 50): UniswapQuote!
+
     # defiUniswapQuoteLiquidity calculates optimal amount of tokens
     # of an Uniswap pair defined by a pair of tokens for the given amount
     # of both tokens desired to be added to the liquidity pool.
@@ -2147,6 +2626,15 @@ type Query {
     # of traded volumes
     defiUniswapVolumes:[DefiUniswapVolume!]!
 
+    # defiUniswapFeesCollected resolves the trading fees paid on Uniswap swaps over
+    # the trailing window given in hours, up to 90 days. The pairs do not expose
+    # the accumulated fees; the 0.3% fee is charged on the input amount of each swap
+    # and stays in the pair reserves for the liquidity providers, so the fees are
+    # derived from the indexed swaps with precision of 9 decimals by the input token.
+    # USD values use the current oracle prices, tokens not priced by the oracle
+    # make the result partial.
+    defiUniswapFeesCollected(window: Int = 24): ProtocolFees!
+
     # defiTimeVolumes returns volumes for specified pair, time resolution and interval.
     # Address is pair address and is mandatory.
     # Resolution can be {month, day, 4h, 1h, 30m 15m, 5m, 1m}, is optional, default is a day.
@@ -2185,10 +2673,20 @@ type Query {
     # address, if available. The resolver returns NULL if the token does not exist.
     erc20Token(token: Address!):ERC20Token
 
+    # erc20TokenInfo provides the name, the symbol, the decimals and the total supply
+    # of an ERC20 token loaded in a single round-trip to the node. Values the token
+    # does not provide are NULL.
+    erc20TokenInfo(token: Address!):ERC20TokenInfo!
+
     # erc20TokenList provides list of the most active ERC20 tokens
     # deployed on the block chain.
     erc20TokenList(count: Int = 50):[ERC20Token!]!
 
+    # erc20NonStandardTokens provides the list of ERC20 tokens which required
+    # a non-standard decoding of their return values since the API server started,
+    # e.g. tokens returning the name and symbol as bytes32.
+    erc20NonStandardTokens: [Erc20NonStandardToken!]!
+
     # erc20Assets provides list of tokens owned by the given
     # account address.
     erc20Assets(owner: Address!, count: Int = 50):[ERC20Token!]!
@@ -2211,7 +2709,8 @@ type Query {
     # erc721ContractList provides list of the most active ERC721 non-fungible tokens (NFT) on the block chain.
     erc721ContractList(count: Int = 50):[ERC721Contract!]!
 
-    # erc1155Token provides the information about ERC1155 multi-token contract by it's address.
+    # erc1155Contract provides the information about ERC1155 multi-token contract by it's address.
+    # Contracts not declaring the ERC1155 interface via ERC165 are rejected with an error.
     erc1155Contract(address: Address!):ERC1155Contract
 
     # erc1155ContractList provides list of the most active ERC1155 multi-token contract on the block chain.
@@ -2252,14 +2751,44 @@ type Query {
 type Mutation {
     # SendTransaction submits a raw signed transaction into the block chain.
     # The tx parameter represents raw signed and RLP encoded transaction data.
+    # The node rejection message is provided as the error, if the transaction
+    # is not accepted. The number of transactions a single client can submit
+    # per minute is limited by the API server configuration.
     sendTransaction(tx: Bytes!):Transaction
 
+    # faucetSend sends a fixed amount of native tokens configured on the API server
+    # to the given address from the server key. It's meant for testnet deployments
+    # and is rejected unless the faucet is enabled. Each address can be funded once
+    # in the configured cooldown period; faucet requests count against the transactions
+    # submit limit of the client.
+    faucetSend(to: Address!):Transaction!
+
     # Validate a deployed contract byte code with the provided source code
     # so potential users can check the contract source code, access contract ABI
     # to be able to interact with the contract and get the right metadata.
     # Returns updated contract information. If the contract can not be validated,
     # it raises a GraphQL error.
     validateContract(contract: ContractValidationInput!): Contract!
+
+    # restartScanner stops the block scanner and starts it again. If fromBlock
+    # is provided, the scanner checkpoint is reset and the scan starts at the block;
+    # otherwise the scan resumes from the last known block.
+    # Only administrators can restart the scanner; concurrent restarts are rejected.
+    restartScanner(fromBlock: Long): Boolean!
+
+    # refreshView refreshes the periodically refreshed view of the given name
+    # right away, regardless of its schedule, and returns its updated state.
+    # Only administrators can force a refresh.
+    refreshView(name: String!): MaterializedView!
+
+    # resetCacheStats restarts the usage counters of the in-memory cache and returns
+    # the statistics collected until the reset. Only administrators can reset the counters.
+    resetCacheStats: CacheStats!
+
+    # reloadErc20LogoMap reads the configured map of ERC20 token logos again
+    # and replaces the map used to resolve token logos. It returns the number
+    # of tokens in the new map. Only administrators can reload the map.
+    reloadErc20LogoMap: Int!
 }
 
 # Subscriptions to live events broadcasting
@@ -2269,6 +2798,1012 @@ type Subscription {
 
     # Subscribe to receive information about new transactions in the blockchain.
     onTransaction: Transaction!
+
+    # Subscribe to receive the new balance of the given account whenever a new block
+    # with a transaction sent from, or to the account changes it. Multiple transactions
+    # of the same block produce a single update. Balance changes made by internal
+    # transactions only are not detected.
+    onAccountBalanceChange(address: Address!): AccountBalanceChange!
+}
+
+# ERC20TokenRiskFlags represents a set of heuristic flags signaling
+# the ERC20 token may be a scam, or a spam token. The flags are heuristics
+# derived from on-chain data, they are not a definitive verdict.
+type ERC20TokenRiskFlags {
+    # massAirdrop signals the token has been distributed
+    # by a single sender to a large number of recipients.
+    massAirdrop: Boolean!
+
+    # airdropSender is the address of the most distributing sender
+    # of the token, if any transfer of the token is known.
+    airdropSender: Address
+
+    # airdropRecipients is the number of distinct recipients
+    # of the most distributing sender.
+    airdropRecipients: Long!
+
+    # mimicsKnownToken signals the name, or the symbol of the token
+    # matches a known token deployed on a different address.
+    mimicsKnownToken: Boolean!
+
+    # mimickedToken is the address of the known token being mimicked, if any.
+    mimickedToken: Address
+
+    # missingMetadata signals the token does not provide
+    # the standard name, or symbol metadata.
+    missingMetadata: Boolean!
+}
+
+# ChainMetrics represents throughput metrics of the blockchain
+# derived from a moving window of recently indexed blocks.
+type ChainMetrics {
+    # blocks is the number of blocks in the window. The window may be
+    # only partially filled shortly after the API server started.
+    blocks: Int!
+
+    # firstBlock is the number of the oldest block in the window.
+    firstBlock: Long!
+
+    # lastBlock is the number of the most recent block in the window.
+    lastBlock: Long!
+
+    # avgBlockInterval is the average time between blocks in seconds.
+    avgBlockInterval: Float!
+
+    # tps is the average number of transactions per second.
+    tps: Float!
+
+    # avgGasUtilization is the average ratio of used gas
+    # to the gas limit of the blocks in the window, in range <0, 1>.
+    avgGasUtilization: Float!
+}
+
+# Reorg represents a chain reorganization detected by the block scanner.
+type Reorg {
+    # depth is the number of indexed blocks replaced by the reorg.
+    depth: Long!
+
+    # fromBlock is the number of the lowest replaced block.
+    fromBlock: Long!
+
+    # toBlock is the number of the highest replaced block.
+    toBlock: Long!
+
+    # oldHead is the hash of the replaced block on the highest replaced height.
+    oldHead: Bytes32!
+
+    # newHead is the hash of the canonical block on the highest replaced height.
+    newHead: Bytes32!
+
+    # detectedAt is the UNIX timestamp of the reorg detection.
+    detectedAt: Long!
+}
+
+# ReorgList is a list of chain reorg edges provided by sequential access request.
+type ReorgList {
+    # Edges contains provided edges of the sequential list.
+    edges: [ReorgListEdge!]!
+
+    # TotalCount is the number of reorgs kept in the history.
+    totalCount: Long!
+
+    # PageInfo is an information about the current page of reorg list edges.
+    pageInfo: ListPageInfo!
+}
+
+# ReorgListEdge is a single edge in a sequential list of chain reorgs.
+type ReorgListEdge {
+    # Cursor defines a scroll key to this edge.
+    cursor: Cursor!
+
+    # reorg represents the chain reorg provided by this list edge.
+    reorg: Reorg!
+}
+
+# NftHolding represents an ERC721/ERC1155 token held by an account.
+type NftHolding {
+    # owner is the address of the account holding the token.
+    owner: Address!
+
+    # token is the address of the ERC721/ERC1155 contract.
+    token: Address!
+
+    # tokenType is the type of the contract, either ERC721, or ERC1155.
+    tokenType: String!
+
+    # tokenId is the identifier of the token inside the contract.
+    tokenId: BigInt!
+
+    # quantity is the number of the tokens held; it's always 1 for ERC721.
+    quantity: BigInt!
+
+    # updated is the time stamp of the last transfer changing the holding.
+    updated: Long!
+
+    # erc721Contract is the ERC721 contract of the token, if the token is ERC721.
+    erc721Contract: ERC721Contract
+
+    # erc1155Contract is the ERC1155 contract of the token, if the token is ERC1155.
+    erc1155Contract: ERC1155Contract
+
+    # metadataUri is the URI of the token metadata JSON, if available.
+    # The URI is loaded from the contract on demand, avoid it on long lists.
+    metadataUri: String
+}
+
+# NftHoldingList is a list of NFT holding edges provided by sequential access request.
+type NftHoldingList {
+    # Edges contains provided edges of the sequential list.
+    edges: [NftHoldingListEdge!]!
+
+    # TotalCount is the number of NFT holdings matching the list filter.
+    totalCount: Long!
+
+    # PageInfo is an information about the current page of NFT holding list edges.
+    pageInfo: ListPageInfo!
+}
+
+# NftHoldingListEdge is a single edge in a sequential list of NFT holdings.
+type NftHoldingListEdge {
+    # Cursor defines a scroll key to this edge.
+    cursor: Cursor!
+
+    # holding represents the NFT holding provided by this list edge.
+    holding: NftHolding!
+}
+
+# UniswapQuote represents a slippage adjusted quote of a swap along a path of tokens.
+type UniswapQuote {
+    # path is the list of tokens the swap goes through.
+    path: [Address!]!
+
+    # amountIn is the amount of the first token of the path sent in.
+    amountIn: BigInt!
+
+    # amountOut is the expected amount of the last token of the path received,
+    # the swap fees are already deducted.
+    amountOut: BigInt!
+
+    # amountOutMin is the minimal amount of the last token received
+    # with the slippage tolerance applied.
+    amountOutMin: BigInt!
+
+    # amounts is the list of expected amounts on each step of the path.
+    amounts: [BigInt!]!
+
+    # slippageBps is the applied slippage tolerance in basis points.
+    slippageBps: Int!
+
+    # priceImpact is the relative loss of the output caused by moving
+    # the pools prices, e.g. 0.01 represents 1% impact.
+    priceImpact: Float!
+
+    # executionPrice is the number of output tokens received per one input token,
+    # adjusted by decimals of both tokens.
+    executionPrice: Float!
+}
+
+# MethodCall represents a recorded call of a watched contract method.
+type MethodCall {
+    # transaction is the hash of the transaction making the call.
+    transaction: Bytes32!
+
+    # contract is the address of the called contract.
+    contract: Address!
+
+    # from is the address of the caller.
+    from: Address!
+
+    # selector is the 4 bytes selector of the called method.
+    selector: Bytes!
+
+    # method is the name of the called method, or its selector
+    # if the method signature is not known.
+    method: String!
+
+    # args is the list of call arguments. Decoded values are provided
+    # if the method signature is known, raw 32 bytes words of the call
+    # data are provided otherwise.
+    args: [String!]!
+
+    # decoded signals the arguments were decoded using the method signature.
+    decoded: Boolean!
+
+    # value is the amount of native tokens sent along with the call.
+    value: BigInt!
+
+    # blockNumber is the number of the block containing the call.
+    blockNumber: Long!
+
+    # timeStamp is the UNIX timestamp of the block containing the call.
+    timeStamp: Long!
+}
+
+# MethodCallList is a list of watched method call edges provided by sequential access request.
+type MethodCallList {
+    # Edges contains provided edges of the sequential list.
+    edges: [MethodCallListEdge!]!
+
+    # TotalCount is the number of recorded calls matching the request.
+    totalCount: Long!
+
+    # PageInfo is an information about the current page of method call edges.
+    pageInfo: ListPageInfo!
+}
+
+# MethodCallListEdge is a single edge in a sequential list of watched method calls.
+type MethodCallListEdge {
+    # Cursor defines a scroll key to this edge.
+    cursor: Cursor!
+
+    # call represents the method call provided by this list edge.
+    call: MethodCall!
+}
+
+# StakeChange represents a single change of a delegator stake in the SFC contract.
+type StakeChange {
+    # type is the type of the change; one of DELEGATED, UNDELEGATED,
+    # WITHDRAWN, RESTAKED, LOCKED, or UNLOCKED.
+    type: String!
+
+    # delegator is the address of the delegator.
+    delegator: Address!
+
+    # validatorId is the id of the validator receiving the delegation.
+    validatorId: BigInt!
+
+    # amount is the amount of tokens affected by the change.
+    amount: BigInt!
+
+    # penalty is the amount of tokens slashed by an early unlock.
+    penalty: BigInt!
+
+    # duration is the lock duration in seconds of a LOCKED change.
+    duration: Long!
+
+    # transaction is the hash of the transaction emitting the change.
+    transaction: Bytes32!
+
+    # blockNumber is the number of the block containing the change.
+    blockNumber: Long!
+
+    # timeStamp is the UNIX timestamp of the block containing the change.
+    timeStamp: Long!
+}
+
+# StakeChangeList is a list of stake change edges provided by sequential access request.
+type StakeChangeList {
+    # Edges contains provided edges of the sequential list.
+    edges: [StakeChangeListEdge!]!
+
+    # TotalCount is the number of stake changes matching the request.
+    totalCount: Long!
+
+    # PageInfo is an information about the current page of stake change edges.
+    pageInfo: ListPageInfo!
+}
+
+# StakeChangeListEdge is a single edge in a sequential list of stake changes.
+type StakeChangeListEdge {
+    # Cursor defines a scroll key to this edge.
+    cursor: Cursor!
+
+    # change represents the stake change provided by this list edge.
+    change: StakeChange!
+}
+
+# Log represents a log record emitted by a transaction.
+type Log {
+    # address is the address of the contract emitting the log record.
+    address: Address!
+
+    # topics is the list of indexed log record topics.
+    topics: [Bytes32!]!
+
+    # data is the non-indexed data of the log record.
+    data: Bytes!
+
+    # blockNumber is the number of the block containing the log record.
+    blockNumber: Long!
+
+    # blockHash is the hash of the block containing the log record.
+    blockHash: Bytes32!
+
+    # transactionHash is the hash of the transaction emitting the log record.
+    transactionHash: Bytes32!
+
+    # transactionIndex is the index of the transaction in the block.
+    transactionIndex: Long!
+
+    # logIndex is the index of the log record in the block.
+    logIndex: Long!
+
+    # event is the log record decoded by the ABI of the emitting contract.
+    # Null if the contract is not validated, or its ABI does not match the log record.
+    event: DecodedEvent
+}
+
+# LogList is a list of log record edges provided by sequential access request.
+type LogList {
+    # Edges contains provided edges of the sequential list.
+    edges: [LogListEdge!]!
+
+    # TotalCount is the number of log records matching the request.
+    totalCount: Long!
+
+    # PageInfo is an information about the current page of log record edges.
+    pageInfo: ListPageInfo!
+}
+
+# LogListEdge is a single edge in a sequential list of log records.
+type LogListEdge {
+    # Cursor defines a scroll key to this edge.
+    cursor: Cursor!
+
+    # log represents the log record provided by this list edge.
+    log: Log!
+}
+
+# LogFilter represents the filter of a logs query.
+input LogFilter {
+    # fromBlock is the number of the first block of the range, inclusive.
+    fromBlock: Long!
+
+    # toBlock is the number of the last block of the range, inclusive.
+    toBlock: Long!
+
+    # address limits the logs to the ones emitted by the listed contracts.
+    address: [Address!]
+
+    # topics limits the logs by their topics on corresponding positions.
+    # An empty list on a position matches any topic.
+    topics: [[Bytes32!]!]
+}
+
+# ContractType represents classification of an account by its code.
+type ContractType {
+    # type is the classification of the account; one of eoa, erc20, erc721,
+    # erc1155, proxy, or unknown-contract if the account has code, but
+    # no known interface matches it.
+    type: String!
+
+    # implementation is the address of the implementation contract
+    # of an EIP-1967 proxy. Null for other accounts.
+    implementation: Address
+
+    # implementationType is the classification of the implementation contract
+    # of an EIP-1967 proxy. Null for other accounts.
+    implementationType: String
+}
+
+# PriceSnapshot represents the oracle price of a DeFi token observed at a point in time.
+type PriceSnapshot {
+    # token is the address of the token.
+    token: Address!
+
+    # price is the oracle price of the token.
+    price: BigInt!
+
+    # priceDecimals is the number of decimals of the price.
+    priceDecimals: Int!
+
+    # blockNumber is the number of the chain head block at the time of the snapshot.
+    blockNumber: Long!
+
+    # timestamp is the UNIX timestamp of the snapshot.
+    timestamp: Long!
+}
+
+# TokenActivity represents the number of transactions of a token over a time window.
+type TokenActivity {
+    # token is the address of the token contract.
+    token: Address!
+
+    # tokenType is the type of the token, e.g. ERC20, ERC721, or ERC1155.
+    tokenType: String!
+
+    # transactionsCount is the number of transfers, approvals, mints and burns
+    # of the token made in the window.
+    transactionsCount: Long!
+
+    # erc20Token is the details of the token, if it's an ERC20 token.
+    erc20Token: ERC20Token
+}
+
+# NetWorthPoint represents the value of an account portfolio at a block
+# in the requested currency, USD by default.
+type NetWorthPoint {
+    # blockNumber is the number of the block the value is calculated at.
+    blockNumber: Long!
+
+    # timestamp is the UNIX timestamp of the block.
+    timestamp: Long!
+
+    # value is the total value of the native balance and the balances
+    # of the active DeFi tokens priced by the latest price snapshot before the block.
+    value: Float!
+
+    # balancesMissing signals some historical balances were not available,
+    # e.g. the connected node does not keep the state of the block. The value is partial.
+    balancesMissing: Boolean!
+
+    # pricesMissing signals some held tokens had no price snapshot close
+    # to the time of the block. The value is partial.
+    pricesMissing: Boolean!
+}
+
+# ProtocolFees represents fees collected by a protocol over a time window.
+type ProtocolFees {
+    # since is the UNIX timestamp of the start of the aggregation window.
+    since: Long!
+
+    # fees is the list of fees collected by token, sorted by the amount.
+    fees: [TokenFee!]!
+
+    # totalUsd is the total USD value of the fees with known prices.
+    totalUsd: Float!
+
+    # isPartial signals some collected tokens could not be priced
+    # and the total USD value does not cover them.
+    isPartial: Boolean!
+}
+
+# TokenFee represents protocol fees collected in a single token.
+type TokenFee {
+    # token is the address of the token the fees were collected in.
+    token: Address!
+
+    # amount is the amount of tokens collected.
+    amount: BigInt!
+
+    # usdValue is the USD value of the amount by the current oracle price
+    # of the token; null if the token is not priced by the oracle.
+    usdValue: Float
+}
+
+# ApprovalRiskLevel represents the label of the heuristic approval risk score.
+enum ApprovalRiskLevel {
+    LOW
+    MEDIUM
+    HIGH
+}
+
+# ApprovalRisk represents a heuristic risk score of active ERC20 approvals
+# granted by an account. The score is not a verdict, it only suggests
+# the approvals should be reviewed and possibly revoked.
+type ApprovalRisk {
+    # score is the total score of all the active approvals.
+    score: Float!
+
+    # level is the label of the total score.
+    level: ApprovalRiskLevel!
+
+    # approvals is the breakdown of the active approvals contributing
+    # to the score, ordered by their contribution, highest first.
+    approvals: [ApprovalRiskItem!]!
+}
+
+# ApprovalRiskItem represents a single active ERC20 approval contributing
+# to the account approval risk score.
+type ApprovalRiskItem {
+    # token is the address of the approved ERC20 token.
+    token: Address!
+
+    # spender is the address allowed to transfer the tokens.
+    spender: Address!
+
+    # allowance is the current amount of tokens the spender can transfer.
+    allowance: BigInt!
+
+    # isUnlimited signals the allowance covers the whole token supply, or more.
+    isUnlimited: Boolean!
+
+    # isVerified signals the spender is a contract with verified source code.
+    isVerified: Boolean!
+
+    # isFlagged signals the spender is a token flagged by the token risk heuristics.
+    isFlagged: Boolean!
+
+    # exposure is the share of the owner balance covered by the allowance, 0 to 1.
+    exposure: Float!
+
+    # score is the contribution of the approval to the total score.
+    score: Float!
+
+    # erc20Token resolves the approved ERC20 token, if available.
+    erc20Token: ERC20Token
+}
+
+# FMintCallData represents an unsigned call of the fMint minter contract
+# prepared for the client to be signed and sent.
+type FMintCallData {
+    # to is the address of the fMint minter contract to be called.
+    to: Address!
+
+    # data is the ABI encoded input of the call.
+    data: Bytes!
+
+    # gasLimit is the suggested gas limit of the transaction. The gas is estimated
+    # if the owner is known, otherwise a safe default of the operation is provided.
+    gasLimit: Long!
+
+    # warning describes a problem the call would most likely run into, e.g. the resulting
+    # position would be liquidatable and the contract would reject the call. Null if none.
+    warning: String
+}
+
+# ERC20PriceComparison represents the oracle price of a token cross-referenced
+# with the spot price derived from the DEX pair against the base token configured
+# on the API server, usually a stable coin. A large divergence may signal a stale
+# oracle, or a thin liquidity of the pair. Prices not available are null.
+type ERC20PriceComparison {
+    # oraclePrice is the price of the token provided by the on-chain price oracle.
+    oraclePrice: Float
+
+    # dexPrice is the spot price of the token in the base token derived
+    # from the DEX pair reserves. Null if the base token is not configured,
+    # or the pair does not exist.
+    dexPrice: Float
+
+    # divergence is the difference of the DEX price from the oracle price in percent.
+    divergence: Float
+}
+
+# AccountOverview represents a live overview of an account loaded from the node.
+# Values failed to load are null and the failure is described by the error.
+type AccountOverview {
+    # address is the address of the account.
+    address: Address!
+
+    # balance is the current native balance of the account.
+    balance: BigInt
+
+    # nonce is the number of transactions sent by the account.
+    nonce: Long
+
+    # codeSize is the size of the code deployed at the address; zero for wallets.
+    codeSize: Long
+
+    # error describes the values failed to load, if any.
+    error: String
+}
+
+# PortfolioDistribution represents the breakdown of the current value
+# of an account portfolio by tokens in the requested currency, USD by default.
+type PortfolioDistribution {
+    # total is the value of the priced holdings.
+    total: Float!
+
+    # holdings are the priced holdings ordered by their value, the highest first.
+    holdings: [PortfolioHolding!]!
+
+    # unpriced are the holdings without a price available. They are not
+    # included in the total and their share is zero.
+    unpriced: [PortfolioHolding!]!
+}
+
+# PortfolioHolding represents a single token held by an account.
+type PortfolioHolding {
+    # token is the address of the token; null for the native balance.
+    token: Address
+
+    # symbol is the symbol of the token.
+    symbol: String!
+
+    # balance is the raw balance of the token.
+    balance: BigInt!
+
+    # decimals is the number of decimals of the balance.
+    decimals: Int!
+
+    # value is the value of the balance; zero for unpriced holdings.
+    value: Float!
+
+    # share is the percentage of the total portfolio value.
+    share: Float!
+}
+
+# IndexProgress represents the indexing progress of a single scanner indexer.
+type IndexProgress {
+    # name is the name of the indexer, e.g. transactions, transfers, staking, or prices.
+    name: String!
+
+    # enabled indicates the indexer runs on this API node.
+    enabled: Boolean!
+
+    # lastBlock is the last block processed by the indexer.
+    # NULL if the indexer is disabled, or did not process any block yet.
+    lastBlock: Long
+
+    # lag is the number of blocks the indexer is behind the chain head.
+    # NULL if the last processed block is not known.
+    lag: Long
+}
+
+# DefiPositions represents positions of an account across the DeFi protocols.
+type DefiPositions {
+    # fMint is the fMint position of the account; null if the account has no position.
+    fMint: FMintPosition
+
+    # liquidity is the list of liquidity positions of the account on the whitelisted
+    # DEX pairs. Empty if the account provides no liquidity, or the DEX is not configured.
+    liquidity: [LiquidityPosition!]!
+
+    # isPartial signals some tokens of the positions could not be priced
+    # and the USD values do not cover them.
+    isPartial: Boolean!
+}
+
+# FMintPosition represents a combined fMint position of an account.
+type FMintPosition {
+    # collateral is the list of tokens deposited as collateral.
+    collateral: [FMintTokenPosition!]!
+
+    # debt is the list of tokens borrowed/minted.
+    debt: [FMintTokenPosition!]!
+
+    # collateralUsd is the USD value of the collateral.
+    collateralUsd: Float!
+
+    # debtUsd is the USD value of the debt.
+    debtUsd: Float!
+
+    # healthRatio is the ratio between the collateral and the debt values;
+    # null if there is no debt. The position can be liquidated below minCollateralRatio.
+    healthRatio: Float
+
+    # minCollateralRatio is the minimal allowed ratio between the collateral and the debt values.
+    minCollateralRatio: Float!
+}
+
+# LiquidityPosition represents a liquidity position of an account on a DEX pair.
+type LiquidityPosition {
+    # pair is the address of the pair.
+    pair: Address!
+
+    # tokens are the addresses of the tokens of the pair.
+    tokens: [Address!]!
+
+    # balance is the amount of the pair liquidity tokens held.
+    balance: BigInt!
+
+    # share is the percentage of the pair liquidity held.
+    share: Float!
+
+    # amounts are the amounts of the underlying tokens represented by the share,
+    # in the order of the tokens.
+    amounts: [BigInt!]!
+
+    # valueUsd is the USD value of the underlying amounts;
+    # null if any of the tokens is not priced by the oracle.
+    valueUsd: Float
+}
+
+# MaterializedView represents the refresh state of a periodically refreshed view
+# of the chain data, e.g. the transaction flow statistics, or the price snapshots.
+type MaterializedView {
+    # name is the name of the view, e.g. trx_flow, trx_count, or prices.
+    name: String!
+
+    # interval is the time between two scheduled refreshes of the view in seconds.
+    interval: Long!
+
+    # lastRefresh is the UNIX timestamp of the last finished refresh of the view.
+    # NULL if the view was not refreshed yet.
+    lastRefresh: Long
+
+    # staleness is the number of seconds elapsed since the last refresh of the view.
+    # NULL if the view was not refreshed yet.
+    staleness: Long
+
+    # isStale indicates the view missed its scheduled refresh,
+    # e.g. it was not refreshed within two intervals.
+    isStale: Boolean!
+}
+
+# RewardSources represents rewards claimed by an account over a time window
+# grouped by the validator they were earned on.
+type RewardSources {
+    # since is the start of the aggregation window as a UNIX timestamp.
+    since: Long!
+
+    # total is the total amount of rewards claimed in the window.
+    total: BigInt!
+
+    # sources is the list of validators the rewards were earned on.
+    sources: [RewardSource!]!
+}
+
+# RewardSource represents rewards claimed by an account from a single validator.
+type RewardSource {
+    # validatorId is the ID of the validator the rewards were earned on.
+    validatorId: BigInt!
+
+    # validator represents the detail of the validator.
+    validator: Staker!
+
+    # amount is the total amount of rewards claimed.
+    amount: BigInt!
+
+    # claims is the number of reward claims.
+    claims: Int!
+
+    # share is the percentage of all the rewards claimed in the window.
+    share: Float!
+}
+
+# Erc20NonStandardToken represents an ERC20 token which required
+# a non-standard decoding of some of its return values.
+type Erc20NonStandardToken {
+    # address is the address of the token contract.
+    address: Address!
+
+    # variants is the list of non-standard return values found,
+    # e.g. bytes32_string, or short_decimals.
+    variants: [String!]!
+}
+
+# FeeHistory represents the history of the base fee and the priority fee
+# rewards of a range of the most recent blocks.
+type FeeHistory {
+    # oldestBlock is the number of the oldest block of the range.
+    oldestBlock: Long!
+
+    # baseFeePerGas is the base fee per gas in WEI of each block of the range,
+    # including the next block after the newest one.
+    baseFeePerGas: [BigInt!]!
+
+    # gasUsedRatio is the ratio of the gas used to the gas limit of each block of the range.
+    gasUsedRatio: [Float!]!
+
+    # reward is the priority fee per gas in WEI at the requested percentiles
+    # of each block of the range.
+    reward: [[BigInt!]!]!
+}
+
+# AccountBalanceChange represents a change of the balance of an account.
+type AccountBalanceChange {
+    # address is the address of the account.
+    address: Address!
+
+    # balance is the new balance of the account in WEI.
+    balance: BigInt!
+
+    # blockNumber is the number of the block which changed the balance.
+    blockNumber: Long!
+}
+
+# ERC20TokenHolder represents the balance of an ERC20 token held by an account.
+type ERC20TokenHolder {
+    # address is the address of the holder.
+    address: Address!
+
+    # balance is the amount of tokens held.
+    balance: BigInt!
+
+    # share is the percentage of the current total supply held.
+    share: Float!
+}
+
+# TransactionReceipt represents the receipt of a processed transaction.
+type TransactionReceipt {
+    # transactionHash is the hash of the transaction.
+    transactionHash: Bytes32!
+
+    # transactionIndex is the index of the transaction in the block.
+    transactionIndex: Long!
+
+    # blockHash is the hash of the block containing the transaction.
+    blockHash: Bytes32!
+
+    # blockNumber is the number of the block containing the transaction.
+    blockNumber: Long!
+
+    # from is the address of the sender.
+    from: Address!
+
+    # to is the address of the recipient; null for a contract creation.
+    to: Address
+
+    # status is 1 if the transaction succeeded, or 0 if it failed.
+    status: Long!
+
+    # gasUsed is the amount of gas used by the transaction.
+    gasUsed: Long!
+
+    # cumulativeGasUsed is the total amount of gas used in the block
+    # up to and including the transaction.
+    cumulativeGasUsed: Long!
+
+    # effectiveGasPrice is the gas price actually paid per unit of gas in WEI.
+    effectiveGasPrice: BigInt!
+
+    # contractAddress is the address of the contract created by the transaction;
+    # null if no contract was created.
+    contractAddress: Address
+
+    # logs is the list of log records emitted by the transaction.
+    logs: [Log!]!
+}
+
+# DecodedEvent represents a log record decoded by the ABI of the emitting contract.
+type DecodedEvent {
+    # name is the name of the event.
+    name: String!
+
+    # signature is the canonical signature of the event, i.e. "Transfer(address,address,uint256)".
+    signature: String!
+
+    # fields is the list of the decoded event arguments.
+    fields: [DecodedEventField!]!
+}
+
+# DecodedEventField represents a decoded argument of an event.
+type DecodedEventField {
+    # name is the name of the argument.
+    name: String!
+
+    # type is the ABI type of the argument.
+    type: String!
+
+    # value is the argument value; addresses and bytes are provided in hex,
+    # numbers in decimal.
+    value: String!
+
+    # indexed signals the argument is stored in the log topics.
+    # Indexed dynamic values, e.g. strings, are provided as their hash.
+    indexed: Boolean!
+}
+
+# TokenPrice represents the current oracle price of a token.
+type TokenPrice {
+    # token is the address of the token.
+    token: Address!
+
+    # price is the raw oracle price of the token.
+    price: BigInt!
+
+    # priceDecimals is the number of decimals of the price,
+    # i.e. the value is price / 10^priceDecimals.
+    priceDecimals: Int!
+
+    # value is the price corrected for the price decimals.
+    value: Float!
+}
+
+# StakingRewardsEstimate represents projected rewards of a delegation to a validator.
+type StakingRewardsEstimate {
+    # ID of the validator the delegation is projected for.
+    validatorId: BigInt!
+
+    # Amount of tokens delegated in WEI units.
+    amount: BigInt!
+
+    # Number of days the delegation is locked for; zero means no lock-up.
+    lockDays: Int!
+
+    # Share of delegators' rewards taken by the validator as a commission.
+    # The value is a fraction of 10^18.
+    commission: BigInt!
+
+    # Share of the full reward paid for the lock-up. Delegations without
+    # lock-up get the unlocked reward ratio of the SFC contract, the share
+    # grows linearly with the lock duration up to 1 on the max lock-up.
+    lockMultiplier: Float!
+
+    # Projected rewards in WEI per day.
+    dailyReward: BigInt!
+
+    # Projected rewards in WEI per month.
+    monthlyReward: BigInt!
+
+    # Projected rewards in WEI per year.
+    yearlyReward: BigInt!
+}
+
+# CacheStats represents the usage statistics of the in-memory cache.
+type CacheStats {
+    # entries is the number of entries in the cache.
+    entries: Int!
+
+    # memoryUsage is the approximate number of bytes allocated by the cache.
+    memoryUsage: Long!
+
+    # hits is the number of lookups served from the cache.
+    hits: Long!
+
+    # misses is the number of lookups not found in the cache.
+    misses: Long!
+
+    # hitRate is the share of lookups served from the cache, between 0 and 1.
+    hitRate: Float!
+
+    # missRate is the share of lookups not found in the cache, between 0 and 1.
+    missRate: Float!
+
+    # evictions is the number of entries removed from the cache on expiration,
+    # or to make space for new entries.
+    evictions: Long!
+
+    # since is the UNIX timestamp of the server start, or the last reset of the counters.
+    since: Long!
+}
+
+# ERC20TokenInfo represents the metadata of an ERC20 token loaded at once.
+# The name, the symbol and the decimals are optional in ERC20; they are null
+# if the token does not provide them.
+type ERC20TokenInfo {
+    # address is the address of the token contract.
+    address: Address!
+
+    # name is the name of the token.
+    name: String
+
+    # symbol is the symbol of the token.
+    symbol: String
+
+    # decimals is the number of decimals of the token amounts.
+    decimals: Int
+
+    # totalSupply is the current total supply of the token.
+    totalSupply: BigInt
+}
+
+# ERC20ApproveCallData represents an unsigned ERC20 approve call
+# prepared for the client to be signed and sent.
+type ERC20ApproveCallData {
+    # to is the address of the ERC20 token contract to be called.
+    to: Address!
+
+    # data is the ABI encoded input of the call.
+    data: Bytes!
+
+    # gasLimit is the suggested gas limit of the transaction. The gas is estimated
+    # if the owner is known, otherwise a safe default is provided.
+    gasLimit: Long!
+
+    # isRevoke signals the call revokes the allowance of the spender.
+    isRevoke: Boolean!
+}
+
+# ERC20SupplySnapshot represents the total supply of an ERC20 token observed at a block.
+type ERC20SupplySnapshot {
+    # token is the address of the token.
+    token: Address!
+
+    # blockNumber is the number of the block the supply was observed at.
+    blockNumber: Long!
+
+    # totalSupply is the total supply of the token at the block.
+    totalSupply: BigInt!
+
+    # timestamp is the UNIX timestamp of the block.
+    timestamp: Long!
+}
+
+# TransactionStatusType represents the processing status of a transaction.
+enum TransactionStatusType {
+    PENDING
+    SUCCESS
+    FAILED
+    UNKNOWN
+}
+
+# TransactionStatus represents the processing status of a transaction.
+type TransactionStatus {
+    # hash is the hash of the transaction.
+    hash: Bytes32!
+
+    # status is the processing status of the transaction.
+    status: TransactionStatusType!
+
+    # blockNumber is the number of the block containing the transaction.
+    # Null if the transaction has not been processed yet.
+    blockNumber: Long
+
+    # confirmations is the number of blocks on top of the block
+    # containing the transaction. Zero if not processed yet.
+    confirmations: Long!
 }
 
 `
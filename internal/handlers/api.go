@@ -7,42 +7,67 @@ import (
 	gqlSchema "motif-api/internal/graphql/schema"
 	"motif-api/internal/logger"
 	"github.com/graph-gophers/graphql-go"
-	"github.com/graph-gophers/graphql-go/relay"
-	"github.com/graph-gophers/graphql-transport-ws/graphqlws"
 	"github.com/rs/cors"
 	"net/http"
 )
 
 // Api constructs and return the API HTTP handlers chain for serving GraphQL API calls.
-func Api(cfg *config.Config, log logger.Logger, rs resolvers.ApiResolver) http.Handler {
+// The resolver deadlines are applied by the given timeout tracer and the clients
+// are limited by the given rate limiting middleware.
+func Api(cfg *config.Config, log logger.Logger, rs resolvers.ApiResolver, tt *resolvers.TimeoutTracer, limit Middleware) http.Handler {
 	// Create new CORS handler and attach the logger into it so we get information on Debug level if needed
 	corsHandler := cors.New(corsOptions(cfg))
 	corsHandler.Log = log
 
 	// we don't want to write a method for each type field if it could be matched directly
-	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
+	// and we apply resolver deadlines by the field category
+	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers(), graphql.Tracer(tt)}
+	if cfg.Server.DisableIntrospection {
+		opts = append(opts, graphql.DisableIntrospection())
+	}
 
 	// create new parsed GraphQL schema
 	schema := graphql.MustParseSchema(gqlSchema.Schema(), rs, opts...)
 
 	// return the constructed API handler chain
-	return &LoggingHandler{
-		logger:  log,
-		handler: corsHandler.Handler(graphqlws.NewHandlerFunc(schema, &relay.Handler{Schema: schema})),
+	return MustChain(NewGraphQLHandler(log, schema, NewVariablesLimits(&cfg.Server), NewComplexityLimit(&cfg.Server, schema), NewPersistedQueries(&cfg.Server), NewResponseSigner(cfg)), apiMiddlewares(cfg, log, schema, corsHandler, limit)...)
+}
+
+// apiMiddlewares provides the middlewares of the API handler chain in the order they see the request.
+func apiMiddlewares(cfg *config.Config, log logger.Logger, schema *graphql.Schema, corsHandler *cors.Cors, limit Middleware) []Middleware {
+	return []Middleware{
+		{Name: MiddlewareRequestID, Wrap: func(h http.Handler) http.Handler {
+			return NewRequestIDHandler(h)
+		}},
+		{Name: MiddlewareLogging, Wrap: func(h http.Handler) http.Handler {
+			return &LoggingHandler{logger: log, handler: h}
+		}},
+		{Name: MiddlewareCors, Wrap: corsHandler.Handler},
+		limit,
+		{Name: MiddlewareAuth, Wrap: func(h http.Handler) http.Handler {
+			return NewAuthHandler(cfg, log, h)
+		}},
+		{Name: MiddlewareFreshRead, Wrap: func(h http.Handler) http.Handler {
+			return NewFreshReadHandler(cfg, log, h)
+		}},
+		{Name: MiddlewareSubscriptions, Wrap: func(h http.Handler) http.Handler {
+			return NewSubscriptionHandler(cfg, log, schema, h)
+		}},
+		{Name: MiddlewarePeerFallback, Wrap: func(h http.Handler) http.Handler {
+			return NewPeerFallbackHandler(cfg, log, h)
+		}},
 	}
 }
 
 // corsOptions constructs new set of options for the CORS handler based on provided configuration.
+// Unless all origins are allowed, only the matching origin is echoed back with the Vary: Origin header.
 func corsOptions(cfg *config.Config) cors.Options {
 	return cors.Options{
-
-		AllowedOrigins: []string{"http://localhost:8088"}, 
-		AllowedMethods: []string{"HEAD", "GET", "POST"},
-		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With"},
-		MaxAge:         300,
+		AllowedOrigins: cfg.Server.CorsOrigin,
+		AllowedMethods: cfg.Server.CorsMethods,
+		AllowedHeaders: append(append([]string{}, cfg.Server.CorsHeaders...), authHeader, maxStaleHeader, requestIDHeader),
+		ExposedHeaders: []string{requestIDHeader},
+		MaxAge:         cfg.Server.CorsMaxAge,
 	}
 }
-//[]string{"http://localhost:8089"}, works for wallet.motif.founsaiton connecting
-//[]string{"https://wallet.motif.foundation"}, works localhost mac connecting to api
-  
  
\ No newline at end of file
@@ -0,0 +1,208 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"time"
+)
+
+// attestationExtension is the response extension carrying the signature of the response data.
+const attestationExtension = "attestation"
+
+// ResponseSigner signs the data of GraphQL responses selecting any of the configured
+// root query fields with the server private key. The signed message is the unix timestamp
+// of the signature, the hash of the request and the canonical JSON of the response data,
+// i.e. with sorted object keys and no white space, joined by colons. The message is signed
+// as an Ethereum personal message, so the clients can recover the signer with any wallet
+// library, reject stale signatures by the timestamp, and make sure the data respond
+// the query they sent by the request hash.
+type ResponseSigner struct {
+	key    *ecdsa.PrivateKey
+	signer common.Address
+	fields map[string]bool
+}
+
+// Attestation represents the signature of a GraphQL response.
+type Attestation struct {
+	Signer    common.Address `json:"signer"`
+	Timestamp int64          `json:"timestamp"`
+	Request   common.Hash    `json:"request"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// NewResponseSigner creates the response signer from the server configuration.
+// Nil is returned if the response attestation is disabled; the nil signer does not sign anything.
+func NewResponseSigner(cfg *config.Config) *ResponseSigner {
+	if len(cfg.Server.SignedFields) == 0 || cfg.MySignature.PrivateKey.D == nil {
+		return nil
+	}
+
+	rs := ResponseSigner{
+		key:    &cfg.MySignature.PrivateKey,
+		signer: crypto.PubkeyToAddress(cfg.MySignature.PrivateKey.PublicKey),
+		fields: make(map[string]bool, len(cfg.Server.SignedFields)),
+	}
+	for _, f := range cfg.Server.SignedFields {
+		rs.fields[f] = true
+	}
+	return &rs
+}
+
+// Covers checks if the given operation of the query selects any of the signed root fields.
+func (rs *ResponseSigner) Covers(query string, operationName string) bool {
+	if rs == nil {
+		return false
+	}
+
+	doc, err := parseQuery(query)
+	if err != nil {
+		return false
+	}
+	op := doc.operation(operationName)
+	if op == nil || op.kind() != "query" {
+		return false
+	}
+	return rs.selects(doc, op.selections, make(map[string]bool))
+}
+
+// selects checks if the given root selections contain any of the signed fields.
+// Each fragment is visited once; a fragment seen before did not select any of them.
+func (rs *ResponseSigner) selects(doc *gqlDocument, list []*gqlSelection, seen map[string]bool) bool {
+	for _, sel := range list {
+		switch {
+		case sel.spread != "":
+			if seen[sel.spread] {
+				continue
+			}
+			seen[sel.spread] = true
+			if frag, ok := doc.fragments[sel.spread]; ok && rs.selects(doc, frag.selections, seen) {
+				return true
+			}
+		case sel.inline:
+			if rs.selects(doc, sel.selections, seen) {
+				return true
+			}
+		case rs.fields[sel.name]:
+			return true
+		}
+	}
+	return false
+}
+
+// RequestHash calculates the hash of the GraphQL request identifying the signed response.
+// The hash covers the query with the ignored tokens removed, the operation name,
+// and the canonical JSON of the variables; each of them terminated by a zero byte.
+func RequestHash(query string, operationName string, variables json.RawMessage) (common.Hash, error) {
+	norm, err := normalizeQuery(query)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	vars := []byte("{}")
+	if trimmed := bytes.TrimSpace(variables); len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null")) {
+		if vars, err = canonicalJSON(trimmed); err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(norm)
+	buf.WriteByte(0)
+	buf.WriteString(operationName)
+	buf.WriteByte(0)
+	buf.Write(vars)
+	buf.WriteByte(0)
+	return crypto.Keccak256Hash(buf.Bytes()), nil
+}
+
+// Sign signs the given response data of the request identified by the hash at the given time.
+func (rs *ResponseSigner) Sign(request common.Hash, data json.RawMessage, now time.Time) (*Attestation, error) {
+	msg, err := attestationMessage(request, data, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(accounts.TextHash(msg), rs.key)
+	if err != nil {
+		return nil, err
+	}
+
+	// use the recovery id of the personal message signatures
+	sig[crypto.RecoveryIDOffset] += 27
+	return &Attestation{Signer: rs.signer, Timestamp: now.Unix(), Request: request, Signature: sig}, nil
+}
+
+// attestationMessage builds the signed message of the given request hash, response data and timestamp.
+func attestationMessage(request common.Hash, data json.RawMessage, ts int64) ([]byte, error) {
+	canon, err := canonicalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(fmt.Sprintf("%d:%s:", ts, request.Hex())), canon...), nil
+}
+
+// canonicalJSON re-encodes the given JSON with sorted object keys and no white space.
+// Numbers are kept in their original form.
+func canonicalJSON(data json.RawMessage) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var val interface{}
+	if err := dec.Decode(&val); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(val); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// VerifyAttestation checks the given response data of the request identified by the hash
+// was signed by the expected signer no longer than the max age before the given time.
+func VerifyAttestation(request common.Hash, data json.RawMessage, att *Attestation, signer common.Address, now time.Time, maxAge time.Duration) error {
+	if att == nil {
+		return fmt.Errorf("response not signed")
+	}
+	if att.Request != request {
+		return fmt.Errorf("signature of unexpected request %s", att.Request.Hex())
+	}
+	if att.Signer != signer {
+		return fmt.Errorf("unexpected signer %s", att.Signer.String())
+	}
+	if age := now.Sub(time.Unix(att.Timestamp, 0)); age > maxAge || age < -maxAge {
+		return fmt.Errorf("signature timestamp %d out of range", att.Timestamp)
+	}
+	if len(att.Signature) != crypto.SignatureLength {
+		return fmt.Errorf("invalid signature length %d", len(att.Signature))
+	}
+
+	msg, err := attestationMessage(request, data, att.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	sig := make([]byte, len(att.Signature))
+	copy(sig, att.Signature)
+	sig[crypto.RecoveryIDOffset] -= 27
+
+	pub, err := crypto.SigToPub(accounts.TextHash(msg), sig)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pub) != signer {
+		return fmt.Errorf("signature does not match signer %s", signer.String())
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// testResponseSigner creates a response signer of a random key signing the ping field.
+func testResponseSigner(t *testing.T) *ResponseSigner {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("can not generate key; %s", err.Error())
+	}
+	return NewResponseSigner(&config.Config{
+		Server:      config.Server{SignedFields: []string{"ping"}},
+		MySignature: config.ServerSignature{PrivateKey: *key},
+	})
+}
+
+// TestResponseSignerCovers tests only queries of the signed root fields are signed.
+func TestResponseSignerCovers(t *testing.T) {
+	rs := testResponseSigner(t)
+	tests := []struct {
+		query string
+		op    string
+		want  bool
+	}{
+		{"{ ping }", "", true},
+		{"{ p: ping other }", "", true},
+		{"query A { other } query B { ping }", "B", true},
+		{"query A { other } query B { ping }", "A", false},
+		{"{ ...F } fragment F on Query { ping }", "", true},
+		{"{ other { ping } }", "", false},
+		{"mutation { ping }", "", false},
+	}
+	for _, tc := range tests {
+		if got := rs.Covers(tc.query, tc.op); got != tc.want {
+			t.Errorf("%s / %s: expected %v, got %v", tc.query, tc.op, tc.want, got)
+		}
+	}
+
+	var none *ResponseSigner
+	if none.Covers("{ ping }", "") {
+		t.Errorf("expected disabled signer not to sign")
+	}
+}
+
+// TestGraphQLHandlerAttestation tests the signature of the response data recovers the signer.
+func TestGraphQLHandlerAttestation(t *testing.T) {
+	rs := testResponseSigner(t)
+	schema := graphql.MustParseSchema(`schema { query: Query } type Query { ping: String! }`, &extTestQuery{})
+	h := NewGraphQLHandler(testLogger(), schema, VariablesLimits{}, ComplexityLimit{}, nil, rs)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ ping }","variables":{"b":1,"a":2}}`)))
+
+	var res struct {
+		Data       json.RawMessage
+		Extensions struct {
+			Attestation *Attestation
+		}
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("can not decode response; %s", err.Error())
+	}
+	att := res.Extensions.Attestation
+	if att == nil {
+		t.Fatalf("expected attestation, got %s", rec.Body.String())
+	}
+	if att.Signer != rs.signer || time.Since(time.Unix(att.Timestamp, 0)) > time.Minute {
+		t.Errorf("unexpected attestation %v", att)
+	}
+
+	req, _ := RequestHash("{ping}", "", json.RawMessage(`{"a":2,"b":1}`))
+	if att.Request != req {
+		t.Errorf("expected request hash %s, got %s", req.Hex(), att.Request.Hex())
+	}
+
+	if err := VerifyAttestation(req, res.Data, att, rs.signer, time.Now(), time.Minute); err != nil {
+		t.Errorf("expected valid attestation; %s", err.Error())
+	}
+	other, _ := RequestHash("{ ping }", "", nil)
+	if err := VerifyAttestation(other, res.Data, att, rs.signer, time.Now(), time.Minute); err == nil {
+		t.Errorf("expected other request refused")
+	}
+	swapped := *att
+	swapped.Request = other
+	if err := VerifyAttestation(other, res.Data, &swapped, rs.signer, time.Now(), time.Minute); err == nil {
+		t.Errorf("expected swapped request refused")
+	}
+	if err := VerifyAttestation(req, json.RawMessage(`{"ping":"fake"}`), att, rs.signer, time.Now(), time.Minute); err == nil {
+		t.Errorf("expected tampered data refused")
+	}
+	if err := VerifyAttestation(req, res.Data, att, rs.signer, time.Now().Add(time.Hour), time.Minute); err == nil {
+		t.Errorf("expected stale signature refused")
+	}
+}
+
+// TestCanonicalJSON tests the canonical form of the signed data.
+func TestCanonicalJSON(t *testing.T) {
+	got, err := canonicalJSON(json.RawMessage(`{ "b": 1.50, "a": {"y": "<x>", "x": [2, 1]} }`))
+	if err != nil {
+		t.Fatalf("can not canonicalize; %s", err.Error())
+	}
+	if want := `{"a":{"x":[2,1],"y":"<x>"},"b":1.50}`; string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+// TestRequestHash tests the request hash ignores the formatting, but not the content of the request.
+func TestRequestHash(t *testing.T) {
+	base, err := RequestHash("query A($x: Int) { ping(x: $x) }", "A", json.RawMessage(`{"x":1,"y":"z"}`))
+	if err != nil {
+		t.Fatalf("can not hash request; %s", err.Error())
+	}
+
+	same := []struct {
+		query string
+		vars  string
+	}{
+		{"query A($x:Int){ping(x:$x)}", `{"x":1,"y":"z"}`},
+		{"# comment\nquery A(\n  $x: Int,\n) {\n  ping(x: $x)\n}\n", `{"x":1,"y":"z"}`},
+		{"query A($x: Int) { ping(x: $x) }", ` { "y": "z", "x": 1 } `},
+	}
+	for _, tc := range same {
+		if got, err := RequestHash(tc.query, "A", json.RawMessage(tc.vars)); err != nil || got != base {
+			t.Errorf("%q / %s: expected the same hash; %v", tc.query, tc.vars, err)
+		}
+	}
+
+	differ := []struct {
+		query string
+		op    string
+		vars  string
+	}{
+		{"query A($x: Int) { ping(x: $x) }", "", `{"x":1,"y":"z"}`},
+		{"query A($x: Int) { ping(x: $x) }", "A", `{"x":2,"y":"z"}`},
+		{"query A($x: Int) { ping(x: $x) }", "A", `{"x":1}`},
+		{"query A($x: Int) { p: ping(x: $x) }", "A", `{"x":1,"y":"z"}`},
+		{`query A($x: Int) { ping(x: $x, s: "a  b") }`, "A", `{"x":1,"y":"z"}`},
+	}
+	for _, tc := range differ {
+		if got, err := RequestHash(tc.query, tc.op, json.RawMessage(tc.vars)); err != nil || got == base {
+			t.Errorf("%q / %s / %s: expected a different hash; %v", tc.query, tc.op, tc.vars, err)
+		}
+	}
+
+	// missing variables are the same as empty ones
+	empty, _ := RequestHash("{ ping }", "", json.RawMessage(`{}`))
+	for _, vars := range []json.RawMessage{nil, json.RawMessage(`null`)} {
+		if got, err := RequestHash("{ ping }", "", vars); err != nil || got != empty {
+			t.Errorf("%s: expected the empty variables hash; %v", vars, err)
+		}
+	}
+
+	if _, err := RequestHash(`{ ping(s: "open) }`, "", nil); err == nil {
+		t.Errorf("expected invalid query refused")
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/config"
+	flogger "motif-api/internal/logger"
+	"net/http"
+	"strings"
+)
+
+// authHeader is the HTTP header carrying client credentials.
+const authHeader = "Authorization"
+
+// bearerPrefix is the prefix of the bearer token credentials.
+const bearerPrefix = "Bearer "
+
+// AuthHandler defines HTTP handler middleware verifying client credentials.
+// Verified claims are attached to the request context for the role based access control;
+// requests without credentials pass as anonymous and can access public resolvers only.
+type AuthHandler struct {
+	logger   flogger.Logger
+	handler  http.Handler
+	verifier *auth.JwtVerifier
+}
+
+// NewAuthHandler creates a new client credentials verification handler middleware.
+func NewAuthHandler(cfg *config.Config, log flogger.Logger, h http.Handler) *AuthHandler {
+	return &AuthHandler{
+		logger:   log,
+		handler:  h,
+		verifier: auth.NewJwtVerifier(&cfg.Auth, log),
+	}
+}
+
+// ServeHTTP handles incoming request by verifying provided credentials, if any,
+// and passing it to the next handler in the chain.
+func (h *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// any bearer token?
+	token := r.Header.Get(authHeader)
+	if h.verifier != nil && strings.HasPrefix(token, bearerPrefix) {
+		cl, err := h.verifier.Verify(strings.TrimSpace(strings.TrimPrefix(token, bearerPrefix)))
+		if err != nil {
+			h.logger.Debugf("credentials of %s rejected; %s", r.RemoteAddr, err.Error())
+			r = r.WithContext(auth.WithFailure(r.Context(), err))
+		} else {
+			r = r.WithContext(auth.WithClaims(r.Context(), cl))
+		}
+	}
+
+	// pass request down the chain
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// names of the middlewares of the HTTP handler chain
+const (
+	MiddlewareLoadShed      = "load_shed"
+	MiddlewareTimeout       = "timeout"
+	MiddlewareRequestID     = "request_id"
+	MiddlewareLogging       = "logging"
+	MiddlewareCors          = "cors"
+	MiddlewareRateLimit     = "rate_limit"
+	MiddlewareAuth          = "auth"
+	MiddlewareFreshRead     = "fresh_read"
+	MiddlewareSubscriptions = "subscriptions"
+	MiddlewarePeerFallback  = "peer_fallback"
+)
+
+// Middleware represents a named stage of the HTTP handler chain.
+type Middleware struct {
+	// Name identifies the middleware for the ordering validation.
+	Name string
+
+	// Wrap wraps the next handler of the chain with the middleware.
+	Wrap func(http.Handler) http.Handler
+}
+
+// middlewareOrder lists the required relative ordering of middlewares;
+// the first middleware of each pair must precede the second one in a chain,
+// if both are present. Preceding middleware sees the request first.
+var middlewareOrder = []struct {
+	before string
+	after  string
+	reason string
+}{
+	{MiddlewareLoadShed, MiddlewareTimeout, "shed requests must not start the timeout clock"},
+	{MiddlewareRequestID, MiddlewareLogging, "request logs must carry the correlation ID"},
+	{MiddlewareLogging, MiddlewareCors, "rejected cross-origin requests must be logged"},
+	{MiddlewareCors, MiddlewareAuth, "preflight requests carry no credentials"},
+	{MiddlewareRequestID, MiddlewareRateLimit, "rate limited requests must carry the correlation ID"},
+	{MiddlewareLogging, MiddlewareRateLimit, "rate limited requests must be logged"},
+	{MiddlewareCors, MiddlewareRateLimit, "rate limited cross-origin requests must be readable by the browser"},
+	{MiddlewareRateLimit, MiddlewareFreshRead, "fresh reads are keyed by the client resolved by the rate limiter"},
+	{MiddlewareAuth, MiddlewareFreshRead, "fresh reads must see the client identity"},
+	{MiddlewareAuth, MiddlewareSubscriptions, "subscriptions fall back to the HTTP credentials"},
+	{MiddlewareSubscriptions, MiddlewarePeerFallback, "subscriptions are not proxied to the peers"},
+}
+
+// NewChain constructs the handler chain of the given middlewares ending with the handler.
+// The first middleware sees the request first. The middlewares are validated
+// for the required relative ordering.
+func NewChain(h http.Handler, mw ...Middleware) (http.Handler, error) {
+	names := make([]string, len(mw))
+	for i, m := range mw {
+		names[i] = m.Name
+	}
+	if err := ValidateChain(names); err != nil {
+		return nil, err
+	}
+
+	// wrap from the innermost
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i].Wrap(h)
+	}
+	return h, nil
+}
+
+// MustChain constructs the handler chain as NewChain does, but panics on invalid ordering.
+// It's intended for the server startup.
+func MustChain(h http.Handler, mw ...Middleware) http.Handler {
+	chain, err := NewChain(h, mw...)
+	if err != nil {
+		panic(err)
+	}
+	return chain
+}
+
+// ValidateChain checks the middlewares of the given names are unique
+// and follow the required relative ordering.
+func ValidateChain(names []string) error {
+	pos := make(map[string]int, len(names))
+	for i, n := range names {
+		if _, ok := pos[n]; ok {
+			return fmt.Errorf("middleware %s used twice", n)
+		}
+		pos[n] = i
+	}
+
+	for _, o := range middlewareOrder {
+		b, okB := pos[o.before]
+		a, okA := pos[o.after]
+		if okB && okA && b > a {
+			return fmt.Errorf("middleware %s must precede %s; %s", o.before, o.after, o.reason)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/rs/cors"
+)
+
+// chainTestOrder records the order in which the middlewares see the request.
+func chainTestOrder(order *[]string, name string) Middleware {
+	return Middleware{Name: name, Wrap: func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*order = append(*order, name)
+			h.ServeHTTP(w, r)
+		})
+	}}
+}
+
+// TestApiChainOrdering tests the API handler chain has the required relative ordering.
+func TestApiChainOrdering(t *testing.T) {
+	cfg := config.Config{}
+	schema := graphql.MustParseSchema(subTestSchema, &subTestResolver{})
+	mw := apiMiddlewares(&cfg, testLogger(), schema, cors.New(corsOptions(&cfg)), RateLimit(&cfg, testLogger()))
+
+	// the API chain is wrapped by the load shedding and timeout in the server
+	names := []string{MiddlewareLoadShed, MiddlewareTimeout}
+	for _, m := range mw {
+		names = append(names, m.Name)
+	}
+	if err := ValidateChain(names); err != nil {
+		t.Fatalf("invalid API chain; %s", err.Error())
+	}
+
+	pos := make(map[string]int)
+	for i, n := range names {
+		pos[n] = i
+	}
+	for _, o := range middlewareOrder {
+		b, okB := pos[o.before]
+		a, okA := pos[o.after]
+		if !okB || !okA {
+			t.Errorf("API chain misses %s, or %s", o.before, o.after)
+			continue
+		}
+		if b > a {
+			t.Errorf("middleware %s does not precede %s", o.before, o.after)
+		}
+	}
+}
+
+// TestNewChainOrder tests the chain passes the request through the middlewares in the given order
+// and rejects invalid ordering.
+func TestNewChainOrder(t *testing.T) {
+	var order []string
+	h, err := NewChain(http.NotFoundHandler(),
+		chainTestOrder(&order, MiddlewareLogging),
+		chainTestOrder(&order, MiddlewareCors),
+		chainTestOrder(&order, MiddlewareAuth),
+	)
+	if err != nil {
+		t.Fatalf("valid chain rejected; %s", err.Error())
+	}
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := strings.Join(order, ","); got != "logging,cors,auth" {
+		t.Errorf("unexpected middleware order %s", got)
+	}
+
+	// auth before CORS
+	_, err = NewChain(http.NotFoundHandler(),
+		chainTestOrder(&order, MiddlewareAuth),
+		chainTestOrder(&order, MiddlewareCors),
+	)
+	if err == nil {
+		t.Errorf("invalid chain ordering accepted")
+	}
+
+	// duplicate middleware
+	if err := ValidateChain([]string{MiddlewareAuth, MiddlewareLogging, MiddlewareAuth}); err == nil {
+		t.Errorf("duplicate middleware accepted")
+	}
+}
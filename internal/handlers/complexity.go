@@ -0,0 +1,348 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/graphql/resolvers"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/types"
+	"strings"
+)
+
+// complexityCountArg is the name of the argument limiting the number of items of a list field.
+const complexityCountArg = "count"
+
+// ComplexityLimit represents the limit of the estimated cost of GraphQL queries.
+// Each resolved field costs by its category; the cost of the sub-selection of a field
+// with the count argument is multiplied by the requested count. Zero limit means no limit.
+type ComplexityLimit struct {
+	schema *types.Schema
+	max    int
+}
+
+// NewComplexityLimit creates the query complexity limit of the given schema from the server configuration.
+func NewComplexityLimit(cfg *config.Server, schema *graphql.Schema) ComplexityLimit {
+	return ComplexityLimit{schema: schema.ASTSchema(), max: cfg.MaxQueryComplexity}
+}
+
+// complexityNode represents the estimated cost of a field and its sub-selection,
+// or of a fragment spread without a key. The node of a fragment is shared by all
+// the spreads of the fragment, so nodes keep only their own path element.
+type complexityNode struct {
+	key      interface{}
+	cost     float64
+	mult     float64
+	children []*complexityNode
+}
+
+// Check estimates the cost of the given operation of the query and rejects it if the cost
+// exceeds the limit. The error names the deepest field path on which the limit is exceeded.
+// The estimate stops as soon as the limit is passed, so the reported complexity is the part
+// of the cost counted until then.
+// Queries not parseable by the estimator are rejected since their cost is unknown;
+// a missing operation is left for the GraphQL executor to report.
+func (cl ComplexityLimit) Check(query string, operationName string, vars map[string]interface{}) *gqlerrors.QueryError {
+	if cl.max <= 0 || cl.schema == nil {
+		return nil
+	}
+
+	doc, err := parseQuery(query)
+	if err != nil {
+		return &gqlerrors.QueryError{
+			Message:    fmt.Sprintf("query complexity can not be estimated; %s", err.Error()),
+			Extensions: map[string]interface{}{"code": "GRAPHQL_PARSE_FAILED"},
+		}
+	}
+	op := doc.operation(operationName)
+	if op == nil {
+		return nil
+	}
+
+	ca := complexityAnalysis{
+		schema:    cl.schema,
+		doc:       doc,
+		vars:      vars,
+		defaults:  op.defaults,
+		max:       float64(cl.max),
+		fragments: make(map[string]*complexityNode),
+	}
+	nodes := ca.selections(op.selections, cl.schema.EntryPoints[op.kind()])
+
+	var total float64
+	for _, n := range nodes {
+		total += n.cost
+	}
+	if !ca.over && total <= float64(cl.max) {
+		return nil
+	}
+
+	path := complexityOffender(nodes, float64(cl.max))
+	return &gqlerrors.QueryError{
+		Message:    fmt.Sprintf("query complexity %.0f exceeds the limit of %d at %s", total, cl.max, complexityPathString(path)),
+		Path:       path,
+		Extensions: map[string]interface{}{"code": "QUERY_TOO_COMPLEX", "complexity": total, "limit": cl.max},
+	}
+}
+
+// operation picks the operation of the document to be executed.
+func (doc *gqlDocument) operation(name string) *gqlOperation {
+	if name == "" {
+		if len(doc.operations) != 1 {
+			return nil
+		}
+		return doc.operations[0]
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op
+		}
+	}
+	return nil
+}
+
+// kind provides the type of the operation; anonymous operations are always queries.
+func (op *gqlOperation) kind() string {
+	if op.opType == "" {
+		return "query"
+	}
+	return op.opType
+}
+
+// complexityAnalysis estimates the cost of selections of a query.
+type complexityAnalysis struct {
+	schema   *types.Schema
+	doc      *gqlDocument
+	vars     map[string]interface{}
+	defaults map[string]gqlValue
+	max      float64
+
+	// fragments keeps the estimated nodes of fragments by the fragment and its type,
+	// so a fragment spread many times is estimated only once.
+	fragments map[string]*complexityNode
+
+	// over signals the limit has been passed and the estimate stopped.
+	over bool
+}
+
+// selections estimates the cost of the given selections on the given type.
+// The costs are never negative and the multipliers are at least one, so the cost
+// of any sub-selection over the limit means the whole query is over the limit.
+func (ca *complexityAnalysis) selections(list []*gqlSelection, typ types.NamedType) []*complexityNode {
+	var nodes []*complexityNode
+	var sum float64
+	for _, sel := range list {
+		var sub []*complexityNode
+		switch {
+		case sel.spread != "":
+			if fr := ca.fragment(sel.spread, typ); fr != nil {
+				sub = []*complexityNode{fr}
+			}
+		case sel.inline:
+			sub = ca.selections(sel.selections, ca.typeOf(sel.typeCond, typ))
+		default:
+			sub = []*complexityNode{ca.field(sel, typ)}
+		}
+
+		nodes = append(nodes, sub...)
+		for _, n := range sub {
+			sum += n.cost
+		}
+		if sum > ca.max {
+			ca.over = true
+		}
+		if ca.over {
+			break
+		}
+	}
+	return nodes
+}
+
+// fragment estimates the cost of the named fragment spread on the given type.
+func (ca *complexityAnalysis) fragment(name string, parent types.NamedType) *complexityNode {
+	fr, ok := ca.doc.fragments[name]
+	if !ok {
+		return nil
+	}
+
+	typ := ca.typeOf(fr.typeCond, parent)
+	key := name
+	if typ != nil {
+		key = fmt.Sprintf("%s@%s", name, typ.TypeName())
+	}
+	if node, ok := ca.fragments[key]; ok {
+		return node
+	}
+
+	node := complexityNode{mult: 1, children: ca.selections(fr.selections, typ)}
+	for _, c := range node.children {
+		node.cost += c.cost
+	}
+	ca.fragments[key] = &node
+	return &node
+}
+
+// field estimates the cost of the given field selection on the given type.
+func (ca *complexityAnalysis) field(sel *gqlSelection, typ types.NamedType) *complexityNode {
+	key := sel.name
+	if sel.alias != "" {
+		key = sel.alias
+	}
+	node := complexityNode{key: key, mult: 1}
+	if sel.name == "__typename" {
+		return &node
+	}
+
+	// find the field definition to get its result type and the default count
+	var def *types.FieldDefinition
+	typeName := ""
+	if typ != nil {
+		typeName = typ.TypeName()
+		def = fieldDefinition(typ, sel.name)
+	}
+
+	if cnt, ok := ca.count(sel, def, typeName); ok {
+		node.mult = cnt
+	}
+
+	var sub types.NamedType
+	if def != nil {
+		sub = unwrapType(def.Type)
+	}
+	node.children = ca.selections(sel.selections, sub)
+
+	node.cost = float64(resolvers.FieldCost(typeName, sel.name))
+	for _, c := range node.children {
+		node.cost += node.mult * c.cost
+	}
+	return &node
+}
+
+// count provides the number of items requested by the count argument of the field.
+// A variable not provided falls back to its default value and then to the default value
+// of the argument. The count not resolved to a number is estimated by the max number
+// of items the field loads. Fields without the count argument are not multiplied.
+func (ca *complexityAnalysis) count(sel *gqlSelection, def *types.FieldDefinition, typeName string) (float64, bool) {
+	var ad *types.InputValueDefinition
+	if def != nil {
+		ad = def.Arguments.Get(complexityCountArg)
+	}
+	arg, given := sel.args[complexityCountArg]
+	if !given && ad == nil {
+		return 0, false
+	}
+
+	var val interface{}
+	switch {
+	case given && arg.int != nil:
+		val = *arg.int
+	case given && arg.variable != "":
+		if v, ok := ca.vars[arg.variable]; ok {
+			val = v
+		} else if dv, ok := ca.defaults[arg.variable]; ok {
+			if dv.int != nil {
+				val = *dv.int
+			}
+		} else if ad != nil && ad.Default != nil {
+			val = ad.Default.Deserialize(nil)
+		}
+	case !given && ad.Default != nil:
+		val = ad.Default.Deserialize(nil)
+	}
+
+	var cnt float64
+	switch v := val.(type) {
+	case int64:
+		cnt = float64(v)
+	case int32:
+		cnt = float64(v)
+	case float64:
+		cnt = v
+	default:
+		cnt = float64(resolvers.FieldMaxCount(typeName, sel.name))
+	}
+
+	// negative count pages backwards
+	if cnt < 0 {
+		cnt = -cnt
+	}
+	if cnt < 1 {
+		cnt = 1
+	}
+	return cnt, true
+}
+
+// typeOf resolves the type of a fragment type condition; the parent type is used if none.
+func (ca *complexityAnalysis) typeOf(name string, parent types.NamedType) types.NamedType {
+	if name == "" {
+		return parent
+	}
+	return ca.schema.Types[name]
+}
+
+// fieldDefinition finds the definition of the given field of the type.
+func fieldDefinition(typ types.NamedType, name string) *types.FieldDefinition {
+	switch t := typ.(type) {
+	case *types.ObjectTypeDefinition:
+		return t.Fields.Get(name)
+	case *types.InterfaceTypeDefinition:
+		return t.Fields.Get(name)
+	}
+	return nil
+}
+
+// unwrapType provides the named type of a list, or a non-null type.
+func unwrapType(typ types.Type) types.NamedType {
+	for {
+		switch t := typ.(type) {
+		case *types.List:
+			typ = t.OfType
+		case *types.NonNull:
+			typ = t.OfType
+		case types.NamedType:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// complexityOffender finds the deepest field with a sub-selection whose cost alone exceeds
+// the limit; the most expensive top level field is used if no single field exceeds it.
+// Fragment spreads are followed, but they are not part of the path.
+func complexityOffender(nodes []*complexityNode, max float64) []interface{} {
+	var path []interface{}
+	scale := 1.0
+	for {
+		var best *complexityNode
+		for _, n := range nodes {
+			if (best == nil || n.cost > best.cost) && (len(path) == 0 || len(n.children) > 0) {
+				best = n
+			}
+		}
+		if best == nil || (len(path) > 0 && scale*best.cost <= max) {
+			break
+		}
+
+		nodes = best.children
+		if best.key == nil {
+			continue
+		}
+		path = append(path, best.key)
+		if scale*best.cost <= max {
+			break
+		}
+		scale *= best.mult
+	}
+	return path
+}
+
+// complexityPathString formats the field path for the error message.
+func complexityPathString(path []interface{}) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = fmt.Sprintf("%v", p)
+	}
+	return strings.Join(parts, ".")
+}
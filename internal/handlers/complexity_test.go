@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// complexityTestSchema is the schema of the query complexity tests.
+const complexityTestSchema = `
+	schema { query: Query }
+	type Query { tokens(count: Int = 10): [Token!]! token: Token }
+	type Token { name: String! holders(count: Int!): [Holder!]! }
+	type Holder { address: String! }`
+
+// TestComplexityLimit tests the queries over the complexity limit are rejected
+// with the path of the offending field.
+func TestComplexityLimit(t *testing.T) {
+	cl := ComplexityLimit{schema: graphql.MustParseSchema(complexityTestSchema, nil).ASTSchema(), max: 100}
+
+	tests := []struct {
+		name  string
+		query string
+		op    string
+		vars  map[string]interface{}
+		path  string
+	}{
+		{"default count", `{ tokens { name } }`, "", nil, ""},
+		{"single object", `{ token { name holders(count: 90) { address } } }`, "", nil, ""},
+		{"nested lists", `{ tokens(count: 50) { holders(count: 50) { address } } }`, "", nil, "tokens.holders"},
+		{"variable count", `query Q($n: Int) { tokens(count: $n) { name } }`, "", map[string]interface{}{"n": float64(200)}, "tokens"},
+		{"negative count", `{ tokens(count: -200) { name } }`, "", nil, "tokens"},
+		{"aliases", `{ a: tokens(count: 60) { name } b: tokens(count: 60) { name } }`, "", nil, "a"},
+		{"fragment", `query { ...F } fragment F on Query { tokens(count: 200) { ... on Token { name __typename } } }`, "", nil, "tokens"},
+		{"named operation", `query A { tokens(count: 200) { name } } # "comment"
+			query B { token { name } }`, "B", nil, ""},
+		{"variable default", `query Q($n: Int = 200) { tokens(count: $n) { name } }`, "", nil, "tokens"},
+		{"variable over default", `query Q($n: Int = 200) { tokens(count: $n) { name } }`, "", map[string]interface{}{"n": float64(5)}, ""},
+		{"argument default", `query Q($n: Int) { tokens(count: $n) { name } }`, "", nil, ""},
+		{"variable types", `query Q($l: [String!]! = ["a", "b"], $n: Int! = 20 @x) { tokens(count: $n) { name } }`, "", nil, ""},
+		{"unresolved count", `query Q($m: Int!) { token { name holders(count: $m) { address } } }`, "", nil, "token.holders"},
+		{"null count", `query Q($m: Int) { token { name holders(count: $m) { address } } }`, "", map[string]interface{}{"m": nil}, "token.holders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qe := cl.Check(tt.query, tt.op, tt.vars)
+			if tt.path == "" {
+				if qe != nil {
+					t.Errorf("unexpected rejection; %s", qe.Message)
+				}
+				return
+			}
+			if qe == nil {
+				t.Fatalf("expected rejection")
+			}
+			if complexityPathString(qe.Path) != tt.path || qe.Extensions["code"] != "QUERY_TOO_COMPLEX" {
+				t.Errorf("unexpected rejection at %v; %s", qe.Path, qe.Message)
+			}
+		})
+	}
+}
+
+// TestComplexityLimitSyntax tests queries not parseable by the estimator are rejected.
+func TestComplexityLimitSyntax(t *testing.T) {
+	cl := ComplexityLimit{schema: graphql.MustParseSchema(complexityTestSchema, nil).ASTSchema(), max: 100}
+	for _, query := range []string{`{ tokens(count: 200) { name }`, `query Q($n) { tokens { name } }`, `{ tokens(count: "open) { name } }`} {
+		qe := cl.Check(query, "", nil)
+		if qe == nil || qe.Extensions["code"] != "GRAPHQL_PARSE_FAILED" {
+			t.Errorf("%s: expected rejection, got %v", query, qe)
+		}
+	}
+
+	// unknown operation is left for the executor
+	if qe := cl.Check(`query A { token { name } }`, "B", nil); qe != nil {
+		t.Errorf("unexpected rejection; %s", qe.Message)
+	}
+}
+
+// TestComplexityLimitDisabled tests zero limit does not reject queries.
+func TestComplexityLimitDisabled(t *testing.T) {
+	cl := ComplexityLimit{schema: graphql.MustParseSchema(complexityTestSchema, nil).ASTSchema()}
+	if qe := cl.Check(`{ tokens(count: 1000000) { holders(count: 1000000) { address } } }`, "", nil); qe != nil {
+		t.Errorf("unexpected rejection; %s", qe.Message)
+	}
+}
+
+// TestComplexityLimitFragmentChain tests a chain of fragments each spreading the previous one
+// twice is estimated in a linear time and rejected at the field spreading it.
+func TestComplexityLimitFragmentChain(t *testing.T) {
+	cl := ComplexityLimit{schema: graphql.MustParseSchema(complexityTestSchema, nil).ASTSchema(), max: 100}
+
+	var sb strings.Builder
+	sb.WriteString("query { token { ...F25 } } fragment F0 on Token { name holders(count: 2) { address } }")
+	for i := 1; i <= 25; i++ {
+		fmt.Fprintf(&sb, " fragment F%d on Token { ...F%d ...F%d }", i, i-1, i-1)
+	}
+
+	start := time.Now()
+	qe := cl.Check(sb.String(), "", nil)
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("estimate took %s", d)
+	}
+	if qe == nil || qe.Extensions["code"] != "QUERY_TOO_COMPLEX" {
+		t.Fatalf("expected rejection, got %v", qe)
+	}
+	if complexityPathString(qe.Path) != "token" {
+		t.Errorf("unexpected rejection at %v; %s", qe.Path, qe.Message)
+	}
+}
+
+// TestComplexityLimitFragmentValidation tests fragment cycles and deep nesting are rejected.
+func TestComplexityLimitFragmentValidation(t *testing.T) {
+	cl := ComplexityLimit{schema: graphql.MustParseSchema(complexityTestSchema, nil).ASTSchema(), max: 100}
+
+	deep := "{ token " + strings.Repeat("{ ... on Token ", gqlMaxDepth) + "{ name }" + strings.Repeat(" }", gqlMaxDepth) + " }"
+	for _, query := range []string{
+		`query { ...A } fragment A on Query { ...B } fragment B on Query { token { name } ...A }`,
+		`query { token { name } } fragment A on Token { ...A }`,
+		deep,
+	} {
+		qe := cl.Check(query, "", nil)
+		if qe == nil || qe.Extensions["code"] != "GRAPHQL_PARSE_FAILED" {
+			t.Errorf("%s: expected rejection, got %v", query, qe)
+		}
+	}
+}
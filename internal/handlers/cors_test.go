@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/cors"
+)
+
+// testCorsPreflight sends a preflight request of the given origin and method through the CORS handler.
+func testCorsPreflight(srv *config.Server, origin string, method string) *httptest.ResponseRecorder {
+	h := cors.New(corsOptions(&config.Config{Server: *srv})).Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/graphql", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestCorsPreflight tests the preflight response follows the configured policy.
+func TestCorsPreflight(t *testing.T) {
+	srv := config.Server{
+		CorsOrigin:  []string{"https://wallet.example.org"},
+		CorsMethods: []string{"GET", "POST", "OPTIONS"},
+		CorsMaxAge:  600,
+	}
+
+	rec := testCorsPreflight(&srv, "https://wallet.example.org", http.MethodPost)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://wallet.example.org" {
+		t.Errorf("expected matching origin echoed, got %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("expected max age 600, got %q", got)
+	}
+	if got := rec.Header().Values("Vary"); len(got) == 0 || got[0] != "Origin" {
+		t.Errorf("expected Vary: Origin, got %v", got)
+	}
+
+	if got := testCorsPreflight(&srv, "https://evil.example.org", http.MethodPost).Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected foreign origin refused, got %q", got)
+	}
+	if got := testCorsPreflight(&srv, "https://wallet.example.org", http.MethodDelete).Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected method refused, got %q", got)
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// DrainHandler defines HTTP handler middleware tracking the number of requests in flight
+// so the graceful shutdown can report how many of them were drained and how many terminated.
+// WebSocket upgrades are not counted; the server shutdown does not wait for them
+// and the subscriptions are closed along with the resolvers.
+type DrainHandler struct {
+	handler  http.Handler
+	inFlight int64
+}
+
+// NewDrainHandler creates a new requests in flight tracking middleware.
+func NewDrainHandler(h http.Handler) *DrainHandler {
+	return &DrainHandler{handler: h}
+}
+
+// ServeHTTP counts the request in flight while it is served by the next handler in the chain.
+func (h *DrainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	atomic.AddInt64(&h.inFlight, 1)
+	defer atomic.AddInt64(&h.inFlight, -1)
+	h.handler.ServeHTTP(w, r)
+}
+
+// InFlight provides the number of requests being served right now.
+func (h *DrainHandler) InFlight() int64 {
+	return atomic.LoadInt64(&h.inFlight)
+}
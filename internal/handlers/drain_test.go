@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestDrainHandler tests the requests in flight are counted, WebSocket upgrades are not.
+func TestDrainHandler(t *testing.T) {
+	var dh *DrainHandler
+	var seen int64
+	dh = NewDrainHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		seen = dh.InFlight()
+	}))
+
+	dh.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/graphql", nil))
+	if seen != 1 {
+		t.Errorf("expected 1 request in flight, got %d", seen)
+	}
+	if n := dh.InFlight(); n != 0 {
+		t.Errorf("expected no request in flight after the response, got %d", n)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	req.Header.Set("Upgrade", "websocket")
+	dh.ServeHTTP(httptest.NewRecorder(), req)
+	if seen != 0 {
+		t.Errorf("expected WebSocket upgrade not counted, got %d", seen)
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	flogger "motif-api/internal/logger"
+	"motif-api/internal/repository"
+	"net"
+	"net/http"
+	"time"
+)
+
+// maxStaleHeader is the HTTP header clients use to demand a fresh read;
+// the only supported value is "0" forcing cached read-only fields to be loaded live.
+const maxStaleHeader = "X-Max-Stale"
+
+// FreshReadHandler defines HTTP handler middleware marking requests demanding
+// a fresh read so the resolvers bypass the in-memory cache.
+// The fresh reads of each client are limited by a token bucket to prevent cache stampede;
+// the bucket holds the configured number of fresh reads per minute and refills continuously.
+type FreshReadHandler struct {
+	logger  flogger.Logger
+	handler http.Handler
+	limiter *tokenBuckets
+}
+
+// NewFreshReadHandler creates a new fresh read handler middleware.
+func NewFreshReadHandler(cfg *config.Config, log flogger.Logger, h http.Handler) *FreshReadHandler {
+	fh := FreshReadHandler{
+		logger:  log,
+		handler: h,
+	}
+	if cfg.Cache.BypassLimit > 0 {
+		fh.limiter = newTokenBuckets(float64(cfg.Cache.BypassLimit)/time.Minute.Seconds(), float64(cfg.Cache.BypassLimit))
+	}
+	return &fh
+}
+
+// ServeHTTP handles incoming request by marking the request context for a fresh read,
+// if demanded and allowed, and passing it to the next handler in the chain.
+func (h *FreshReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// fresh read requested?
+	if r.Header.Get(maxStaleHeader) == "0" {
+		if client := clientAddress(r); h.allow(client, time.Now()) {
+			r = r.WithContext(repository.WithFreshRead(r.Context()))
+		} else {
+			h.logger.Debugf("fresh read limit reached for %s, serving cached", client)
+		}
+	}
+
+	// pass request down the chain
+	h.handler.ServeHTTP(w, r)
+}
+
+// allow checks if the given client can make another fresh read; fresh reads are disabled
+// if the limit is not configured.
+func (h *FreshReadHandler) allow(client string, now time.Time) bool {
+	if h.limiter == nil {
+		return false
+	}
+	_, ok := h.limiter.allow(client, now)
+	return ok
+}
+
+// clientAddress extracts the address of the remote client from the request;
+// the client resolved by the rate limiter behind a trusted proxy is preferred.
+func clientAddress(r *http.Request) string {
+	if adr, ok := r.Context().Value(clientAddressKey{}).(string); ok {
+		return adr
+	}
+	return remoteAddress(r)
+}
+
+// remoteAddress extracts the address of the remote end of the request connection.
+func remoteAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testFreshReadRequest serves a request from the given remote address with the given max stale header.
+func testFreshReadRequest(h http.Handler, remote string, maxStale string) {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.RemoteAddr = remote
+	if maxStale != "" {
+		req.Header.Set(maxStaleHeader, maxStale)
+	}
+
+	h.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+// TestFreshReadHandler tests the fresh reads are marked and limited per client.
+func TestFreshReadHandler(t *testing.T) {
+	var fresh bool
+	cfg := config.Config{Cache: config.Cache{BypassLimit: 2}}
+	h := NewFreshReadHandler(&cfg, testLogger(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fresh = repository.IsFreshRead(r.Context())
+	}))
+	serve := func(remote string, maxStale string) bool {
+		fresh = false
+		testFreshReadRequest(h, remote, maxStale)
+		return fresh
+	}
+
+	if serve("10.0.0.1:1000", "") {
+		t.Errorf("request without the header must not be fresh")
+	}
+	if serve("10.0.0.1:1000", "60") {
+		t.Errorf("only zero max stale is supported")
+	}
+
+	for i := 0; i < 2; i++ {
+		if !serve("10.0.0.1:1000", "0") {
+			t.Fatalf("expected fresh read %d to be allowed", i)
+		}
+	}
+	if serve("10.0.0.1:1001", "0") {
+		t.Errorf("expected fresh read over the limit to be served cached")
+	}
+	if !serve("10.0.0.2:1000", "0") {
+		t.Errorf("expected fresh read of another client to be allowed")
+	}
+}
+
+// TestFreshReadRefill tests the fresh reads are refilled continuously, not by a fixed window.
+func TestFreshReadRefill(t *testing.T) {
+	cfg := config.Config{Cache: config.Cache{BypassLimit: 60}}
+	h := NewFreshReadHandler(&cfg, testLogger(), http.NotFoundHandler())
+	now := time.Now()
+
+	for i := 0; i < 60; i++ {
+		if !h.allow("c", now) {
+			t.Fatalf("expected fresh read %d to be allowed", i)
+		}
+	}
+	if h.allow("c", now) {
+		t.Fatalf("expected fresh read over the limit to be rejected")
+	}
+
+	// a single fresh read is available after a second, not a full minute
+	if !h.allow("c", now.Add(time.Second)) {
+		t.Errorf("expected fresh read to be refilled")
+	}
+	if h.allow("c", now.Add(time.Second)) {
+		t.Errorf("expected only one fresh read to be refilled")
+	}
+}
+
+// TestFreshReadDisabled tests no fresh reads are allowed without the limit configured.
+func TestFreshReadDisabled(t *testing.T) {
+	h := NewFreshReadHandler(&config.Config{}, testLogger(), http.NotFoundHandler())
+	if h.allow("c", time.Now()) {
+		t.Errorf("expected fresh reads to be disabled")
+	}
+}
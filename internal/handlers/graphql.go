@@ -0,0 +1,126 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"motif-api/internal/graphql/resolvers"
+	"motif-api/internal/logger"
+	"encoding/json"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"net/http"
+	"time"
+)
+
+// GraphQLHandler implements HTTP handler executing GraphQL requests against the schema.
+// Debugging details collected by the resolvers are added into the response extensions.
+// Request variables over the configured limits and too complex queries are rejected before the execution.
+// Automatic persisted queries are resolved from the registry, if provided.
+// Responses of the signed root fields are attested by the signer, if provided.
+type GraphQLHandler struct {
+	schema     *graphql.Schema
+	log        logger.Logger
+	limits     VariablesLimits
+	complexity ComplexityLimit
+	persisted  *PersistedQueries
+	signer     *ResponseSigner
+}
+
+// NewGraphQLHandler creates a new GraphQL request handler for the given schema.
+func NewGraphQLHandler(log logger.Logger, schema *graphql.Schema, limits VariablesLimits, complexity ComplexityLimit, persisted *PersistedQueries, signer *ResponseSigner) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema, log: log, limits: limits, complexity: complexity, persisted: persisted, signer: signer}
+}
+
+// ServeHTTP executes the GraphQL request and writes the response.
+func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Query         string          `json:"query"`
+		OperationName string          `json:"operationName"`
+		Variables     json.RawMessage `json:"variables"`
+		Extensions    struct {
+			PersistedQuery json.RawMessage `json:"persistedQuery"`
+		} `json:"extensions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query, qe := h.persisted.Resolve(params.Query, params.Extensions.PersistedQuery)
+	if qe != nil {
+		h.writeQueryError(w, http.StatusOK, qe)
+		return
+	}
+
+	vars, err := h.limits.Decode(params.Variables)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if qe := h.complexity.Check(query, params.OperationName, vars); qe != nil {
+		logger.WithContext(r.Context(), h.log).Warningf("query rejected; %s", qe.Message)
+		h.writeQueryError(w, http.StatusBadRequest, qe)
+		return
+	}
+
+	ctx, ext := resolvers.WithExtensions(resolvers.WithClient(r.Context(), clientAddress(r)))
+	res := h.schema.Exec(ctx, query, params.OperationName, vars)
+
+	// add collected extensions
+	if vals := ext.Values(); vals != nil {
+		if res.Extensions == nil {
+			res.Extensions = make(map[string]interface{}, len(vals))
+		}
+		for k, v := range vals {
+			res.Extensions[k] = v
+		}
+	}
+
+	// sign the response data, if requested
+	if len(res.Data) > 0 && h.signer.Covers(query, params.OperationName) {
+		att, err := h.sign(query, params.OperationName, params.Variables, res.Data)
+		if err != nil {
+			logger.WithContext(r.Context(), h.log).Errorf("can not sign GraphQL response; %s", err.Error())
+		} else {
+			if res.Extensions == nil {
+				res.Extensions = make(map[string]interface{}, 1)
+			}
+			res.Extensions[attestationExtension] = att
+		}
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		h.log.Errorf("can not write GraphQL response; %s", err.Error())
+	}
+}
+
+// sign attests the response data of the given request.
+func (h *GraphQLHandler) sign(query string, operationName string, variables json.RawMessage, data json.RawMessage) (*Attestation, error) {
+	hash, err := RequestHash(query, operationName, variables)
+	if err != nil {
+		return nil, err
+	}
+	return h.signer.Sign(hash, data, time.Now())
+}
+
+// writeError writes a GraphQL error response for a request rejected before the execution.
+func (h *GraphQLHandler) writeError(w http.ResponseWriter, status int, err error) {
+	h.writeQueryError(w, status, gqlerrors.Errorf("%s", err.Error()))
+}
+
+// writeQueryError writes the given GraphQL error response for a request rejected before the execution.
+func (h *GraphQLHandler) writeQueryError(w http.ResponseWriter, status int, qe *gqlerrors.QueryError) {
+	data, _ := json.Marshal(&graphql.Response{Errors: []*gqlerrors.QueryError{qe}})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(data); err != nil {
+		h.log.Errorf("can not write GraphQL error response; %s", err.Error())
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"motif-api/internal/graphql/resolvers"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// extTestQuery implements the root resolver of the extensions test schema.
+type extTestQuery struct{}
+
+// Ping resolves a test field reporting an extension counter.
+func (*extTestQuery) Ping(ctx context.Context) string {
+	resolvers.AddExtensionCount(ctx, "pings", 2)
+	return "pong"
+}
+
+// TestGraphQLHandlerExtensions tests values collected by resolvers are added into the response extensions.
+func TestGraphQLHandlerExtensions(t *testing.T) {
+	schema := graphql.MustParseSchema(`schema { query: Query } type Query { ping: String! }`, &extTestQuery{})
+	h := NewGraphQLHandler(testLogger(), schema, VariablesLimits{}, ComplexityLimit{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ ping }"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var res struct {
+		Data       struct{ Ping string }
+		Extensions map[string]int
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("can not decode response; %s", err.Error())
+	}
+	if res.Data.Ping != "pong" || res.Extensions["pings"] != 2 {
+		t.Errorf("unexpected response %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"sync"
+)
+
+// testLog is the logger shared by the tests; the logger setup is global
+// and connections of a finished test may still log while the next test runs.
+var testLog struct {
+	once sync.Once
+	log  logger.Logger
+}
+
+// testLogger provides a logger for the tests.
+func testLogger() logger.Logger {
+	testLog.once.Do(func() {
+		testLog.log = logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+	})
+	return testLog.log
+}
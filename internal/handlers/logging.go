@@ -15,7 +15,7 @@ type LoggingHandler struct {
 // and passing it to the next handler in the chain.
 func (h *LoggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// We log incoming requests on Debug level since in production the actual incoming traffic is not very important.
-	h.logger.Debugf("[%s <- %s] %s %s (%s)", r.Proto, r.RemoteAddr, r.Method, r.URL, r.UserAgent())
+	flogger.WithContext(r.Context(), h.logger).Debugf("[%s <- %s] %s %s (%s)", r.Proto, r.RemoteAddr, r.Method, r.URL, r.UserAgent())
 
 	// Pass request down the chain
 	h.handler.ServeHTTP(w, r)
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	flogger "motif-api/internal/logger"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// peerFallbackMaxBody is the max size of a request body proxied to an API peer.
+const peerFallbackMaxBody = 1 << 20
+
+// peerFallbackMaxResponse is the max size of an API peer response accepted.
+const peerFallbackMaxResponse = 16 << 20
+
+// apiPeer represents an API peer the read-only queries can be proxied to.
+type apiPeer struct {
+	url     string
+	health  string
+	signer  common.Address
+	latency time.Duration
+	head    uint64
+}
+
+// PeerFallbackHandler defines HTTP handler middleware proxying read-only queries
+// to the healthy API peers if the local node is unreachable, or behind the peers.
+// The peers and the local node are health-checked periodically; the healthy peers
+// are tried in the order of their latency and a peer response is accepted only if signed
+// by the expected signer of the peer. The request is served locally if no peer succeeds.
+type PeerFallbackHandler struct {
+	log     flogger.Logger
+	handler http.Handler
+	client  *http.Client
+	cfg     config.PeerFallback
+	peers   []*apiPeer
+	local   func() *types.Health
+	done    chan struct{}
+
+	// the health state of the last check
+	mu       sync.RWMutex
+	ranked   []*apiPeer
+	degraded bool
+}
+
+// NewPeerFallbackHandler creates a new peer fallback handler middleware.
+// The health of the peers is checked in the background until the handler is closed.
+func NewPeerFallbackHandler(cfg *config.Config, log flogger.Logger, h http.Handler) *PeerFallbackHandler {
+	pf := newPeerFallbackHandler(cfg, log, h, func() *types.Health {
+		return repository.R().Health()
+	})
+	if cfg.Server.PeerFallback.Enabled {
+		go pf.monitor()
+	}
+	return pf
+}
+
+// newPeerFallbackHandler creates the peer fallback handler using the given local health provider.
+func newPeerFallbackHandler(cfg *config.Config, log flogger.Logger, h http.Handler, local func() *types.Health) *PeerFallbackHandler {
+	pf := PeerFallbackHandler{
+		log:     log,
+		handler: h,
+		client:  &http.Client{Timeout: cfg.Server.PeerFallback.Timeout},
+		cfg:     cfg.Server.PeerFallback,
+		local:   local,
+		done:    make(chan struct{}),
+	}
+
+	if cfg.Server.PeerFallback.Enabled {
+		pf.peers = make([]*apiPeer, len(cfg.Server.Peers))
+		for i, peer := range cfg.Server.Peers {
+			pf.peers[i] = &apiPeer{url: peer, health: peerHealthUrl(peer), signer: cfg.Server.PeerFallback.Signers[i]}
+		}
+	}
+	return &pf
+}
+
+// Close terminates the health monitoring of the peers.
+func (pf *PeerFallbackHandler) Close() {
+	close(pf.done)
+}
+
+// ServeHTTP proxies the request to a healthy peer if the local node is degraded
+// and the request is a read-only query; it's passed down the chain otherwise.
+func (pf *PeerFallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	peers := pf.candidates()
+	if len(peers) == 0 || r.Method != http.MethodPost {
+		pf.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// the body is needed downstream if the request is not proxied
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, peerFallbackMaxBody+1))
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil || len(body) > peerFallbackMaxBody {
+		pf.handler.ServeHTTP(w, r)
+		return
+	}
+	hash, ok := readOnlyQueryHash(body)
+	if !ok {
+		pf.handler.ServeHTTP(w, r)
+		return
+	}
+
+	for _, peer := range peers {
+		res, err := pf.forward(r.Context(), peer, body, hash, r.Header.Get(requestIDHeader))
+		if err != nil {
+			flogger.WithContext(r.Context(), pf.log).Warningf("peer %s fallback failed; %s", peer.url, err.Error())
+			continue
+		}
+
+		flogger.WithContext(r.Context(), pf.log).Infof("query served by peer %s", peer.url)
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(res); err != nil {
+			pf.log.Errorf("can not write peer response; %s", err.Error())
+		}
+		return
+	}
+	pf.handler.ServeHTTP(w, r)
+}
+
+// candidates provides the healthy peers ranked by latency if the local node is degraded.
+func (pf *PeerFallbackHandler) candidates() []*apiPeer {
+	pf.mu.RLock()
+	defer pf.mu.RUnlock()
+
+	if !pf.degraded {
+		return nil
+	}
+	return pf.ranked
+}
+
+// readOnlyQueryHash provides the request hash of the GraphQL request body if it executes
+// a query operation, i.e. not a mutation, or a subscription. Persisted queries sent by the hash
+// only are not eligible.
+func readOnlyQueryHash(body []byte) (common.Hash, bool) {
+	var params struct {
+		Query         string          `json:"query"`
+		OperationName string          `json:"operationName"`
+		Variables     json.RawMessage `json:"variables"`
+	}
+	if err := json.Unmarshal(body, &params); err != nil || params.Query == "" {
+		return common.Hash{}, false
+	}
+
+	doc, err := parseQuery(params.Query)
+	if err != nil {
+		return common.Hash{}, false
+	}
+	if op := doc.operation(params.OperationName); op == nil || op.kind() != "query" {
+		return common.Hash{}, false
+	}
+
+	hash, err := RequestHash(params.Query, params.OperationName, params.Variables)
+	if err != nil {
+		return common.Hash{}, false
+	}
+	return hash, true
+}
+
+// forward sends the request body to the peer and provides the peer response
+// if it's signed by the expected signer of the peer for the request of the given hash.
+func (pf *PeerFallbackHandler) forward(ctx context.Context, peer *apiPeer, body []byte, hash common.Hash, reqID string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, peer.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if reqID != "" {
+		req.Header.Set(requestIDHeader, reqID)
+	}
+
+	resp, err := pf.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, peerFallbackMaxResponse))
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Data       json.RawMessage `json:"data"`
+		Extensions struct {
+			Attestation *Attestation `json:"attestation"`
+		} `json:"extensions"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	if err := VerifyAttestation(hash, res.Data, res.Extensions.Attestation, peer.signer, time.Now(), pf.cfg.MaxAge); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// monitor checks the health of the peers and the local node periodically.
+func (pf *PeerFallbackHandler) monitor() {
+	ticker := time.NewTicker(pf.cfg.HealthCheck)
+	defer ticker.Stop()
+
+	pf.check()
+	for {
+		select {
+		case <-pf.done:
+			return
+		case <-ticker.C:
+			pf.check()
+		}
+	}
+}
+
+// check updates the health state of the peers and the local node.
+func (pf *PeerFallbackHandler) check() {
+	healthy := make([]bool, len(pf.peers))
+	var wg sync.WaitGroup
+	for i, peer := range pf.peers {
+		wg.Add(1)
+		go func(i int, peer *apiPeer) {
+			defer wg.Done()
+			healthy[i] = pf.checkPeer(peer)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	// rank the healthy peers by latency
+	ranked := make([]*apiPeer, 0, len(pf.peers))
+	var best uint64
+	for i, peer := range pf.peers {
+		if !healthy[i] {
+			continue
+		}
+		ranked = append(ranked, peer)
+		if peer.head > best {
+			best = peer.head
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].latency < ranked[j].latency
+	})
+
+	// the local node is degraded if unhealthy, or too far behind the best peer
+	local := pf.local()
+	degraded := !local.Healthy || local.Node == nil || uint64(local.Node.CurrentBlock)+pf.cfg.MaxLag < best
+	if degraded {
+		pf.log.Warningf("local node degraded, %d healthy peers available", len(ranked))
+	}
+
+	pf.mu.Lock()
+	pf.ranked = ranked
+	pf.degraded = degraded
+	pf.mu.Unlock()
+}
+
+// checkPeer loads the health state of the peer and updates its latency and head.
+func (pf *PeerFallbackHandler) checkPeer(peer *apiPeer) bool {
+	start := time.Now()
+	resp, err := pf.client.Get(peer.health)
+	if err != nil {
+		pf.log.Debugf("peer %s not available; %s", peer.url, err.Error())
+		return false
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var h types.Health
+	if err := json.NewDecoder(resp.Body).Decode(&h); err != nil || resp.StatusCode != http.StatusOK || !h.Healthy || h.Node == nil {
+		pf.log.Debugf("peer %s not healthy", peer.url)
+		return false
+	}
+
+	// each peer is updated by its own check only; the ranking reads the values after all the checks
+	peer.latency = time.Since(start)
+	peer.head = uint64(h.Node.CurrentBlock)
+	return true
+}
+
+// peerHealthUrl derives the health end-point of the peer from its API end-point.
+func peerHealthUrl(peer string) string {
+	u, err := url.Parse(peer)
+	if err != nil {
+		return peer
+	}
+	u.Path = "/health"
+	u.RawQuery = ""
+	return u.String()
+}
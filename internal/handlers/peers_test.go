@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// testApiPeer creates a mock API peer at the given head signing its responses by the given signer.
+func testApiPeer(t *testing.T, rs *ResponseSigner, head uint64) *httptest.Server {
+	schema := graphql.MustParseSchema(`schema { query: Query } type Query { ping: String! }`, &extTestQuery{})
+	gql := NewGraphQLHandler(testLogger(), schema, VariablesLimits{}, ComplexityLimit{}, nil, rs)
+
+	mux := http.NewServeMux()
+	mux.Handle("/api", gql)
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(types.Health{Healthy: true, Node: &types.SyncProgress{CurrentBlock: hexutil.Uint64(head)}})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// testPeerFallback creates the peer fallback handler of the given peer and the local node state.
+func testPeerFallback(t *testing.T, peer *httptest.Server, signer common.Address, local types.Health) *PeerFallbackHandler {
+	cfg := config.Config{Server: config.Server{
+		Peers: []string{peer.URL + "/api"},
+		PeerFallback: config.PeerFallback{
+			Enabled: true,
+			Signers: []common.Address{signer},
+			MaxLag:  10,
+			MaxAge:  time.Minute,
+			Timeout: time.Second,
+		},
+	}}
+
+	local.Node = &types.SyncProgress{CurrentBlock: 100}
+	pf := newPeerFallbackHandler(&cfg, testLogger(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("local"))
+	}), func() *types.Health {
+		return &local
+	})
+	pf.check()
+	return pf
+}
+
+// testPeerRequest sends the GraphQL request body through the peer fallback handler.
+func testPeerRequest(pf *PeerFallbackHandler, body string) string {
+	rec := httptest.NewRecorder()
+	pf.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+	return rec.Body.String()
+}
+
+// TestPeerFallback tests read-only queries are proxied to the signing peer if the local node is degraded.
+func TestPeerFallback(t *testing.T) {
+	rs := testResponseSigner(t)
+	peer := testApiPeer(t, rs, 100)
+
+	pf := testPeerFallback(t, peer, rs.signer, types.Health{Healthy: false})
+	if res := testPeerRequest(pf, `{"query":"{ ping }"}`); !strings.Contains(res, "pong") {
+		t.Errorf("expected query served by peer, got %s", res)
+	}
+	if res := testPeerRequest(pf, `{"query":"mutation { ping }"}`); res != "local" {
+		t.Errorf("expected mutation served locally, got %s", res)
+	}
+
+	// the local node is healthy and in sync
+	pf = testPeerFallback(t, peer, rs.signer, types.Health{Healthy: true})
+	if res := testPeerRequest(pf, `{"query":"{ ping }"}`); res != "local" {
+		t.Errorf("expected query served locally, got %s", res)
+	}
+}
+
+// TestPeerFallbackLag tests queries are proxied if the local node is behind the peers.
+func TestPeerFallbackLag(t *testing.T) {
+	rs := testResponseSigner(t)
+	pf := testPeerFallback(t, testApiPeer(t, rs, 200), rs.signer, types.Health{Healthy: true})
+	if res := testPeerRequest(pf, `{"query":"{ ping }"}`); !strings.Contains(res, "pong") {
+		t.Errorf("expected query served by peer, got %s", res)
+	}
+}
+
+// TestPeerFallbackUntrusted tests responses of unexpected signers are refused.
+func TestPeerFallbackUntrusted(t *testing.T) {
+	pf := testPeerFallback(t, testApiPeer(t, testResponseSigner(t), 100), common.HexToAddress("0x01"), types.Health{Healthy: false})
+	if res := testPeerRequest(pf, `{"query":"{ ping }"}`); res != "local" {
+		t.Errorf("expected query served locally, got %s", res)
+	}
+}
+
+// TestPeerFallbackReplay tests signed responses of other requests are refused.
+func TestPeerFallbackReplay(t *testing.T) {
+	rs := testResponseSigner(t)
+	schema := graphql.MustParseSchema(`schema { query: Query } type Query { ping: String! }`, &extTestQuery{})
+	gql := NewGraphQLHandler(testLogger(), schema, VariablesLimits{}, ComplexityLimit{}, nil, rs)
+
+	// the peer answers any request by a signed response of another one
+	peer := testApiPeer(t, rs, 100)
+	replay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gql.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api", strings.NewReader(`{"query":"{ ping }"}`)))
+	}))
+	t.Cleanup(replay.Close)
+
+	pf := testPeerFallback(t, peer, rs.signer, types.Health{Healthy: false})
+	pf.peers[0].url = replay.URL
+	if res := testPeerRequest(pf, `{"query":"{ ping }"}`); !strings.Contains(res, "pong") {
+		t.Errorf("expected query served by peer, got %s", res)
+	}
+	if res := testPeerRequest(pf, `{"query":"{ p: ping }"}`); res != "local" {
+		t.Errorf("expected replayed response refused, got %s", res)
+	}
+}
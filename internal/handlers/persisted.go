@@ -0,0 +1,108 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// persistedQueryVersion is the supported version of the automatic persisted queries protocol.
+const persistedQueryVersion = 1
+
+// persistedQueryExtension represents the persisted query extension of a GraphQL request.
+type persistedQueryExtension struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// PersistedQueries implements the registry of automatic persisted queries.
+// Clients send the SHA-256 hash of the query instead of the query text; unknown queries
+// are reported so the client resends the full text, which is registered under its hash.
+// The oldest queries are dropped when the registry is full.
+type PersistedQueries struct {
+	mu      sync.Mutex
+	max     int
+	queries map[string]string
+	order   []string
+	next    int
+}
+
+// NewPersistedQueries creates the persisted queries registry from the server configuration.
+func NewPersistedQueries(cfg *config.Server) *PersistedQueries {
+	return &PersistedQueries{max: cfg.PersistedQueries, queries: make(map[string]string)}
+}
+
+// Resolve provides the query text of the request with the given persisted query extension.
+// The query text sent by the client is registered under the hash if it matches.
+func (pq *PersistedQueries) Resolve(query string, raw json.RawMessage) (string, *gqlerrors.QueryError) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return query, nil
+	}
+	if pq == nil || pq.max <= 0 {
+		return "", persistedQueryError("PersistedQueryNotSupported", "PERSISTED_QUERY_NOT_SUPPORTED")
+	}
+
+	var ext persistedQueryExtension
+	if err := json.Unmarshal(raw, &ext); err != nil {
+		return "", gqlerrors.Errorf("invalid persisted query extension; %s", err.Error())
+	}
+	if ext.Version != persistedQueryVersion {
+		return "", gqlerrors.Errorf("unsupported persisted query version %d", ext.Version)
+	}
+	hash := strings.ToLower(ext.Sha256Hash)
+
+	// no query text; look for the registered one
+	if query == "" {
+		if q, ok := pq.get(hash); ok {
+			return q, nil
+		}
+		return "", persistedQueryError("PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND")
+	}
+
+	// register the query text under its hash
+	sum := sha256.Sum256([]byte(query))
+	if hex.EncodeToString(sum[:]) != hash {
+		return "", gqlerrors.Errorf("provided sha256 hash does not match the query")
+	}
+	pq.put(hash, query)
+	return query, nil
+}
+
+// get provides the query registered under the given hash.
+func (pq *PersistedQueries) get(hash string) (string, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	q, ok := pq.queries[hash]
+	return q, ok
+}
+
+// put registers the query under the given hash replacing the oldest query if the registry is full.
+func (pq *PersistedQueries) put(hash string, query string) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if _, ok := pq.queries[hash]; ok {
+		return
+	}
+	if len(pq.order) < pq.max {
+		pq.order = append(pq.order, hash)
+	} else {
+		delete(pq.queries, pq.order[pq.next])
+		pq.order[pq.next] = hash
+		pq.next = (pq.next + 1) % pq.max
+	}
+	pq.queries[hash] = query
+}
+
+// persistedQueryError creates the error of the persisted queries protocol
+// recognized by the clients.
+func persistedQueryError(msg string, code string) *gqlerrors.QueryError {
+	return &gqlerrors.QueryError{Message: msg, Extensions: map[string]interface{}{"code": code}}
+}
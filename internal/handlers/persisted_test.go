@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// testPersistedRequest builds a GraphQL request body with the persisted query extension.
+func testPersistedRequest(query string, hash string) string {
+	q, _ := json.Marshal(query)
+	return fmt.Sprintf(`{"query":%s,"extensions":{"persistedQuery":{"version":1,"sha256Hash":"%s"}}}`, q, hash)
+}
+
+// testQueryHash calculates the persisted query hash of the given query.
+func testQueryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// TestPersistedQueriesHandler tests unknown queries are reported and registered on resend.
+func TestPersistedQueriesHandler(t *testing.T) {
+	schema := graphql.MustParseSchema(`schema { query: Query } type Query { ping: String! }`, &extTestQuery{})
+	h := NewGraphQLHandler(testLogger(), schema, VariablesLimits{}, ComplexityLimit{}, &PersistedQueries{max: 10, queries: make(map[string]string)}, nil)
+
+	query := "{ ping }"
+	hash := testQueryHash(query)
+	exec := func(body string) string {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	if res := exec(testPersistedRequest("", hash)); !strings.Contains(res, "PersistedQueryNotFound") {
+		t.Fatalf("expected query not found, got %s", res)
+	}
+	if res := exec(testPersistedRequest(query, hash)); !strings.Contains(res, "pong") {
+		t.Fatalf("expected query executed, got %s", res)
+	}
+	if res := exec(testPersistedRequest("", hash)); !strings.Contains(res, "pong") {
+		t.Errorf("expected persisted query executed, got %s", res)
+	}
+	if res := exec(testPersistedRequest(query, testQueryHash("{ other }"))); !strings.Contains(res, "does not match") {
+		t.Errorf("expected hash mismatch, got %s", res)
+	}
+}
+
+// TestPersistedQueriesEviction tests the oldest queries are dropped from the full registry.
+func TestPersistedQueriesEviction(t *testing.T) {
+	pq := PersistedQueries{max: 2, queries: make(map[string]string)}
+	for _, q := range []string{"{ a }", "{ b }", "{ c }"} {
+		if _, qe := pq.Resolve(q, json.RawMessage(fmt.Sprintf(`{"version":1,"sha256Hash":"%s"}`, testQueryHash(q)))); qe != nil {
+			t.Fatalf("can not register %s; %s", q, qe.Message)
+		}
+	}
+
+	if _, ok := pq.get(testQueryHash("{ a }")); ok {
+		t.Errorf("expected the oldest query dropped")
+	}
+	for _, q := range []string{"{ b }", "{ c }"} {
+		if got, ok := pq.get(testQueryHash(q)); !ok || got != q {
+			t.Errorf("expected query %s registered, got %s", q, got)
+		}
+	}
+}
+
+// TestPersistedQueriesDisabled tests persisted queries are refused if the registry is disabled.
+func TestPersistedQueriesDisabled(t *testing.T) {
+	var pq *PersistedQueries
+	if q, qe := pq.Resolve("{ ping }", nil); qe != nil || q != "{ ping }" {
+		t.Errorf("expected plain query passed, got %q, %v", q, qe)
+	}
+	if _, qe := pq.Resolve("", json.RawMessage(`{"version":1,"sha256Hash":"00"}`)); qe == nil || qe.Message != "PersistedQueryNotSupported" {
+		t.Errorf("expected persisted queries not supported, got %v", qe)
+	}
+}
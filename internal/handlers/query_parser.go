@@ -0,0 +1,480 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// gqlDocument represents the parts of a GraphQL executable document
+// needed to estimate the cost of the requested operation.
+type gqlDocument struct {
+	operations []*gqlOperation
+	fragments  map[string]*gqlFragment
+}
+
+// gqlOperation represents an operation of a GraphQL document.
+// Only the integer and variable default values of the variables are kept.
+type gqlOperation struct {
+	opType     string
+	name       string
+	defaults   map[string]gqlValue
+	selections []*gqlSelection
+}
+
+// gqlFragment represents a named fragment of a GraphQL document.
+type gqlFragment struct {
+	typeCond   string
+	selections []*gqlSelection
+}
+
+// gqlSelection represents a field, a fragment spread, or an inline fragment.
+// Only the integer and variable arguments of fields are kept.
+type gqlSelection struct {
+	alias      string
+	name       string
+	args       map[string]gqlValue
+	spread     string
+	inline     bool
+	typeCond   string
+	selections []*gqlSelection
+}
+
+// gqlValue represents an argument value; either a variable reference, or an integer literal.
+type gqlValue struct {
+	variable string
+	int      *int64
+}
+
+// gqlToken represents a lexical token of a GraphQL document.
+type gqlToken struct {
+	kind  byte
+	value string
+}
+
+// kinds of GraphQL tokens
+const (
+	gqlTokenEOF    byte = 0
+	gqlTokenPunct  byte = 'p'
+	gqlTokenName   byte = 'n'
+	gqlTokenInt    byte = 'i'
+	gqlTokenFloat  byte = 'f'
+	gqlTokenString byte = 's'
+)
+
+// gqlMaxDepth is the max nesting of selection sets and fragment spreads of a GraphQL document.
+const gqlMaxDepth = 32
+
+// gqlParser implements a minimal recursive descent parser of GraphQL executable documents.
+type gqlParser struct {
+	src string
+	pos int
+	tok gqlToken
+}
+
+// parseQuery parses the given GraphQL executable document.
+func parseQuery(src string) (doc *gqlDocument, err error) {
+	p := gqlParser{src: strings.TrimPrefix(src, "\ufeff")}
+	defer func() {
+		if r := recover(); r != nil {
+			if pe, ok := r.(gqlParseError); ok {
+				doc, err = nil, pe
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	p.next()
+	doc = &gqlDocument{fragments: make(map[string]*gqlFragment)}
+	for p.tok.kind != gqlTokenEOF {
+		switch {
+		case p.is(gqlTokenPunct, "{"):
+			doc.operations = append(doc.operations, &gqlOperation{selections: p.selectionSet()})
+		case p.is(gqlTokenName, "fragment"):
+			p.next()
+			name := p.expect(gqlTokenName, "")
+			p.expect(gqlTokenName, "on")
+			fr := gqlFragment{typeCond: p.expect(gqlTokenName, "")}
+			p.directives()
+			fr.selections = p.selectionSet()
+			doc.fragments[name] = &fr
+		case p.is(gqlTokenName, "query"), p.is(gqlTokenName, "mutation"), p.is(gqlTokenName, "subscription"):
+			op := gqlOperation{opType: p.tok.value}
+			p.next()
+			if p.tok.kind == gqlTokenName {
+				op.name = p.tok.value
+				p.next()
+			}
+			if p.is(gqlTokenPunct, "(") {
+				op.defaults = p.variableDefinitions()
+			}
+			p.directives()
+			op.selections = p.selectionSet()
+			doc.operations = append(doc.operations, &op)
+		default:
+			p.fail("unexpected %q", p.tok.value)
+		}
+	}
+	doc.validate()
+	return doc, nil
+}
+
+// validate rejects documents with cyclic fragment spreads, or with selections
+// nested deeper than gqlMaxDepth; fragment spreads count as a level of nesting.
+func (doc *gqlDocument) validate() {
+	// depth of each named fragment; zero marks a fragment being visited
+	depths := make(map[string]int, len(doc.fragments))
+
+	var depth func(list []*gqlSelection) int
+	depth = func(list []*gqlSelection) int {
+		max := 0
+		for _, sel := range list {
+			var d int
+			switch {
+			case sel.spread != "":
+				fr, ok := doc.fragments[sel.spread]
+				if !ok {
+					// unknown fragments are left for the GraphQL executor to report
+					continue
+				}
+				fd, seen := depths[sel.spread]
+				if seen && fd == 0 {
+					panic(gqlParseError(fmt.Sprintf("fragment %q spreads itself", sel.spread)))
+				}
+				if !seen {
+					depths[sel.spread] = 0
+					fd = depth(fr.selections) + 1
+					depths[sel.spread] = fd
+				}
+				d = fd
+			default:
+				d = depth(sel.selections) + 1
+			}
+			if d > gqlMaxDepth {
+				panic(gqlParseError(fmt.Sprintf("selections nested deeper than %d levels", gqlMaxDepth)))
+			}
+			if d > max {
+				max = d
+			}
+		}
+		return max
+	}
+
+	for name, fr := range doc.fragments {
+		if _, seen := depths[name]; !seen {
+			depths[name] = 0
+			depths[name] = depth(fr.selections) + 1
+		}
+	}
+	for _, op := range doc.operations {
+		depth(op.selections)
+	}
+}
+
+// normalizeQuery provides the given GraphQL document with the ignored tokens removed,
+// i.e. the remaining tokens joined by a single space.
+func normalizeQuery(src string) (norm string, err error) {
+	p := gqlParser{src: strings.TrimPrefix(src, "\ufeff")}
+	defer func() {
+		if r := recover(); r != nil {
+			if pe, ok := r.(gqlParseError); ok {
+				norm, err = "", pe
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	var sb strings.Builder
+	for p.next(); p.tok.kind != gqlTokenEOF; p.next() {
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(p.tok.value)
+	}
+	return sb.String(), nil
+}
+
+// gqlParseError represents a syntax error of the parsed document.
+type gqlParseError string
+
+// Error returns the text of the syntax error.
+func (e gqlParseError) Error() string {
+	return string(e)
+}
+
+// fail aborts the parsing with a syntax error.
+func (p *gqlParser) fail(format string, args ...interface{}) {
+	panic(gqlParseError(fmt.Sprintf("syntax error at %d; ", p.pos) + fmt.Sprintf(format, args...)))
+}
+
+// is checks the current token; empty value matches any token of the kind.
+func (p *gqlParser) is(kind byte, value string) bool {
+	return p.tok.kind == kind && (value == "" || p.tok.value == value)
+}
+
+// expect consumes the current token of the given kind and value and provides its value.
+func (p *gqlParser) expect(kind byte, value string) string {
+	if !p.is(kind, value) {
+		p.fail("unexpected %q", p.tok.value)
+	}
+	val := p.tok.value
+	p.next()
+	return val
+}
+
+// selectionSet parses a selection set enclosed in braces.
+func (p *gqlParser) selectionSet() []*gqlSelection {
+	p.expect(gqlTokenPunct, "{")
+	var list []*gqlSelection
+	for !p.is(gqlTokenPunct, "}") {
+		list = append(list, p.selection())
+	}
+	p.next()
+	return list
+}
+
+// selection parses a single field, or a fragment.
+func (p *gqlParser) selection() *gqlSelection {
+	var sel gqlSelection
+	if p.is(gqlTokenPunct, "...") {
+		p.next()
+		switch {
+		case p.is(gqlTokenName, "on"):
+			p.next()
+			sel.inline = true
+			sel.typeCond = p.expect(gqlTokenName, "")
+		case p.is(gqlTokenName, ""):
+			sel.spread = p.tok.value
+			p.next()
+			p.directives()
+			return &sel
+		default:
+			sel.inline = true
+		}
+		p.directives()
+		sel.selections = p.selectionSet()
+		return &sel
+	}
+
+	sel.name = p.expect(gqlTokenName, "")
+	if p.is(gqlTokenPunct, ":") {
+		p.next()
+		sel.alias, sel.name = sel.name, p.expect(gqlTokenName, "")
+	}
+	if p.is(gqlTokenPunct, "(") {
+		sel.args = p.arguments()
+	}
+	p.directives()
+	if p.is(gqlTokenPunct, "{") {
+		sel.selections = p.selectionSet()
+	}
+	return &sel
+}
+
+// arguments parses the arguments of a field.
+func (p *gqlParser) arguments() map[string]gqlValue {
+	args := make(map[string]gqlValue)
+	p.expect(gqlTokenPunct, "(")
+	for !p.is(gqlTokenPunct, ")") {
+		name := p.expect(gqlTokenName, "")
+		p.expect(gqlTokenPunct, ":")
+		args[name] = p.value()
+	}
+	p.next()
+	return args
+}
+
+// value parses an argument value; only variables and integers are decoded.
+func (p *gqlParser) value() gqlValue {
+	switch {
+	case p.is(gqlTokenPunct, "$"):
+		p.next()
+		return gqlValue{variable: p.expect(gqlTokenName, "")}
+	case p.is(gqlTokenInt, ""):
+		val, err := strconv.ParseInt(p.tok.value, 10, 64)
+		if err != nil {
+			p.fail("invalid integer %s", p.tok.value)
+		}
+		p.next()
+		return gqlValue{int: &val}
+	case p.is(gqlTokenPunct, "["):
+		p.next()
+		for !p.is(gqlTokenPunct, "]") {
+			p.value()
+		}
+		p.next()
+	case p.is(gqlTokenPunct, "{"):
+		p.next()
+		for !p.is(gqlTokenPunct, "}") {
+			p.expect(gqlTokenName, "")
+			p.expect(gqlTokenPunct, ":")
+			p.value()
+		}
+		p.next()
+	case p.is(gqlTokenName, ""), p.is(gqlTokenFloat, ""), p.is(gqlTokenString, ""):
+		p.next()
+	default:
+		p.fail("unexpected %q", p.tok.value)
+	}
+	return gqlValue{}
+}
+
+// directives skips the directives of a definition, or a selection.
+func (p *gqlParser) directives() {
+	for p.is(gqlTokenPunct, "@") {
+		p.next()
+		p.expect(gqlTokenName, "")
+		if p.is(gqlTokenPunct, "(") {
+			p.arguments()
+		}
+	}
+}
+
+// variableDefinitions parses the variable definitions of an operation
+// and provides the default values of the variables.
+func (p *gqlParser) variableDefinitions() map[string]gqlValue {
+	defaults := make(map[string]gqlValue)
+	p.expect(gqlTokenPunct, "(")
+	for !p.is(gqlTokenPunct, ")") {
+		p.expect(gqlTokenPunct, "$")
+		name := p.expect(gqlTokenName, "")
+		p.expect(gqlTokenPunct, ":")
+		p.typeRef()
+		if p.is(gqlTokenPunct, "=") {
+			p.next()
+			defaults[name] = p.value()
+		}
+		p.directives()
+	}
+	p.next()
+	return defaults
+}
+
+// typeRef parses the type of a variable definition.
+func (p *gqlParser) typeRef() {
+	if p.is(gqlTokenPunct, "[") {
+		p.next()
+		p.typeRef()
+		p.expect(gqlTokenPunct, "]")
+	} else {
+		p.expect(gqlTokenName, "")
+	}
+	if p.is(gqlTokenPunct, "!") {
+		p.next()
+	}
+}
+
+// next reads the next token of the document.
+func (p *gqlParser) next() {
+	// skip ignored tokens
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		p.pos++
+	}
+	if p.pos >= len(p.src) {
+		p.tok = gqlToken{kind: gqlTokenEOF}
+		return
+	}
+
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = gqlToken{kind: gqlTokenPunct, value: "..."}
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		p.pos++
+		p.tok = gqlToken{kind: gqlTokenPunct, value: string(c)}
+	case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		for p.pos < len(p.src) && isNameChar(p.src[p.pos]) {
+			p.pos++
+		}
+		p.tok = gqlToken{kind: gqlTokenName, value: p.src[start:p.pos]}
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.number()
+	case strings.HasPrefix(p.src[p.pos:], `"""`):
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		for end >= 0 && p.src[p.pos+3+end-1] == '\\' {
+			next := strings.Index(p.src[p.pos+3+end+3:], `"""`)
+			if next < 0 {
+				end = -1
+				break
+			}
+			end += 3 + next
+		}
+		if end < 0 {
+			p.fail("unterminated string")
+		}
+		p.pos += 3 + end + 3
+		p.tok = gqlToken{kind: gqlTokenString, value: p.src[start:p.pos]}
+	case c == '"':
+		p.pos++
+		for p.pos < len(p.src) && p.src[p.pos] != '"' {
+			if p.src[p.pos] == '\\' {
+				p.pos++
+			}
+			if p.pos < len(p.src) && (p.src[p.pos] == '\n' || p.src[p.pos] == '\r') {
+				p.fail("unterminated string")
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.src) {
+			p.fail("unterminated string")
+		}
+		p.pos++
+		p.tok = gqlToken{kind: gqlTokenString, value: p.src[start:p.pos]}
+	default:
+		p.fail("unexpected character %q", c)
+	}
+}
+
+// number reads an integer, or a float token.
+func (p *gqlParser) number() {
+	start := p.pos
+	kind := gqlTokenInt
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() {
+		from := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		if p.pos == from {
+			p.fail("invalid number")
+		}
+	}
+
+	digits()
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		kind = gqlTokenFloat
+		p.pos++
+		digits()
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		kind = gqlTokenFloat
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+	p.tok = gqlToken{kind: kind, value: p.src[start:p.pos]}
+}
+
+// isNameChar checks if the given character can be a part of a GraphQL name.
+func isNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
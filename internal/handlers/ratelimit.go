@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	flogger "motif-api/internal/logger"
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitSweepInterval represents the interval of removing idle clients from the rate limiter.
+const rateLimitSweepInterval = time.Minute
+
+// clientAddressKey represents the request context key of the resolved client address.
+type clientAddressKey struct{}
+
+// RateLimitHandler defines HTTP handler middleware limiting the rate of requests
+// of each client by a token bucket. Clients over the limit get 429 Too Many Requests
+// with the Retry-After header. The exempt paths, e.g. the health check, are never limited.
+type RateLimitHandler struct {
+	logger      flogger.Logger
+	handler     http.Handler
+	proxyHeader string
+	exempt      map[string]bool
+
+	// token buckets of the clients
+	*tokenBuckets
+}
+
+// tokenBuckets represents the token bucket rate limiter state of a set of clients.
+type tokenBuckets struct {
+	rate      float64
+	burst     float64
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket represents the rate limiter state of a single client.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBuckets creates a new token bucket rate limiter with the given rate per second and burst.
+func newTokenBuckets(rate float64, burst float64) *tokenBuckets {
+	return &tokenBuckets{
+		rate:      rate,
+		burst:     burst,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// NewRateLimitHandler creates a new rate limiting handler middleware;
+// requests of the given paths are not limited.
+func NewRateLimitHandler(cfg *config.Config, log flogger.Logger, h http.Handler, exempt ...string) *RateLimitHandler {
+	rl := RateLimitHandler{
+		logger:       log,
+		handler:      h,
+		proxyHeader:  cfg.Server.TrustedProxyHeader,
+		exempt:       make(map[string]bool, len(exempt)),
+		tokenBuckets: newTokenBuckets(cfg.Server.RateLimitPerSecond, float64(cfg.Server.RateLimitBurst)),
+	}
+	for _, p := range exempt {
+		rl.exempt[p] = true
+	}
+	return &rl
+}
+
+// RateLimit provides the rate limiting middleware of the handler chains;
+// all the handlers wrapped by the middleware share the token buckets of the clients.
+func RateLimit(cfg *config.Config, log flogger.Logger) Middleware {
+	tb := newTokenBuckets(cfg.Server.RateLimitPerSecond, float64(cfg.Server.RateLimitBurst))
+	return Middleware{Name: MiddlewareRateLimit, Wrap: func(h http.Handler) http.Handler {
+		rl := NewRateLimitHandler(cfg, log, h)
+		rl.tokenBuckets = tb
+		return rl
+	}}
+}
+
+// ServeHTTP handles incoming request by rejecting it if the client is over the limit,
+// or passing it to the next handler in the chain.
+func (h *RateLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// resolve the client behind a trusted proxy for the rest of the chain
+	client := h.client(r)
+	r = r.WithContext(context.WithValue(r.Context(), clientAddressKey{}, client))
+
+	if h.rate > 0 && !h.exempt[r.URL.Path] {
+		if wait, ok := h.allow(client, time.Now()); !ok {
+			h.logger.Debugf("rate limit reached for %s", client)
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10))
+			http.Error(w, "Too many requests, try it later.", http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
+
+// client resolves the address of the client; the trusted proxy header is used, if configured.
+func (h *RateLimitHandler) client(r *http.Request) string {
+	if h.proxyHeader != "" {
+		if val := r.Header.Values(h.proxyHeader); len(val) > 0 {
+			// the trusted proxy appends the address it sees as the last one
+			list := strings.Split(val[len(val)-1], ",")
+			if adr := strings.TrimSpace(list[len(list)-1]); adr != "" {
+				return adr
+			}
+		}
+	}
+	return remoteAddress(r)
+}
+
+// allow takes a token from the bucket of the given client. If the bucket is empty,
+// the time to wait for the next token is provided.
+func (h *tokenBuckets) allow(client string, now time.Time) (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sweep(now)
+
+	b, ok := h.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: h.burst, last: now}
+		h.buckets[client] = b
+	}
+
+	// refill the bucket by the time passed
+	b.tokens = math.Min(h.burst, b.tokens+now.Sub(b.last).Seconds()*h.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / h.rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// sweep removes the buckets of clients idle long enough to have the bucket full again.
+func (h *tokenBuckets) sweep(now time.Time) {
+	if now.Sub(h.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	h.lastSweep = now
+
+	full := time.Duration(h.burst / h.rate * float64(time.Second))
+	for c, b := range h.buckets {
+		if now.Sub(b.last) >= full {
+			delete(h.buckets, c)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testRateLimitHandler creates a rate limiter with the given proxy header echoing the client address.
+func testRateLimitHandler(proxyHeader string) *RateLimitHandler {
+	cfg := config.Config{Server: config.Server{RateLimitPerSecond: 1, RateLimitBurst: 2, TrustedProxyHeader: proxyHeader}}
+	return NewRateLimitHandler(&cfg, testLogger(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(clientAddress(r)))
+	}), "/health")
+}
+
+// testRateLimitRequest serves a request of the given path from the given remote address.
+func testRateLimitRequest(h http.Handler, path string, remote string, forwarded string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remote
+	if forwarded != "" {
+		req.Header.Set("X-Forwarded-For", forwarded)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestRateLimitHandler tests the clients over the limit are rejected and the health check is exempt.
+func TestRateLimitHandler(t *testing.T) {
+	h := testRateLimitHandler("")
+
+	for i := 0; i < 2; i++ {
+		if rec := testRateLimitRequest(h, "/graphql", "10.0.0.1:1000", ""); rec.Code != http.StatusOK {
+			t.Fatalf("expected burst request %d to pass, got %d", i, rec.Code)
+		}
+	}
+
+	rec := testRateLimitRequest(h, "/graphql", "10.0.0.1:1001", "")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected 429 with retry after 1s, got %d / %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	if rec := testRateLimitRequest(h, "/health", "10.0.0.1:1002", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected health check to pass, got %d", rec.Code)
+	}
+	if rec := testRateLimitRequest(h, "/graphql", "10.0.0.2:1000", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected other client to pass, got %d", rec.Code)
+	}
+}
+
+// TestRateLimitProxyHeader tests the forwarded address is used only with the trusted proxy header set.
+func TestRateLimitProxyHeader(t *testing.T) {
+	if rec := testRateLimitRequest(testRateLimitHandler(""), "/", "10.0.0.1:1000", "1.2.3.4"); rec.Body.String() != "10.0.0.1" {
+		t.Errorf("expected remote address, got %s", rec.Body.String())
+	}
+
+	h := testRateLimitHandler("X-Forwarded-For")
+	if rec := testRateLimitRequest(h, "/", "10.0.0.1:1000", "6.6.6.6, 1.2.3.4"); rec.Body.String() != "1.2.3.4" {
+		t.Errorf("expected the last forwarded address, got %s", rec.Body.String())
+	}
+	if rec := testRateLimitRequest(h, "/", "10.0.0.1:1000", ""); rec.Body.String() != "10.0.0.1" {
+		t.Errorf("expected remote address without the header, got %s", rec.Body.String())
+	}
+}
+
+// TestRateLimitRefill tests the tokens are refilled by the time passed.
+func TestRateLimitRefill(t *testing.T) {
+	h := testRateLimitHandler("")
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if _, ok := h.allow("c", now); !ok {
+			t.Fatalf("expected burst request %d to pass", i)
+		}
+	}
+	if wait, ok := h.allow("c", now); ok || wait != time.Second {
+		t.Fatalf("expected rejection with 1s wait, got %t / %s", ok, wait)
+	}
+	if _, ok := h.allow("c", now.Add(time.Second)); !ok {
+		t.Errorf("expected request to pass after refill")
+	}
+
+	// idle clients are swept
+	h.allow("d", now.Add(2*rateLimitSweepInterval))
+	if _, ok := h.buckets["c"]; ok {
+		t.Errorf("expected idle client to be swept")
+	}
+}
+
+// TestRateLimitMiddleware tests the handlers wrapped by the rate limiting middleware
+// share the client limit and the middleware is ordered after the request logging.
+func TestRateLimitMiddleware(t *testing.T) {
+	cfg := config.Config{Server: config.Server{RateLimitPerSecond: 1, RateLimitBurst: 1}}
+	limit := RateLimit(&cfg, testLogger())
+	gas := MustChain(http.NotFoundHandler(), limit)
+	api := MustChain(http.NotFoundHandler(), limit)
+
+	if rec := testRateLimitRequest(gas, "/json/gas", "10.0.0.1:1000", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected first request to pass, got %d", rec.Code)
+	}
+	if rec := testRateLimitRequest(api, "/api", "10.0.0.1:1001", ""); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected the limit shared by the end-points, got %d", rec.Code)
+	}
+
+	if err := ValidateChain([]string{MiddlewareRateLimit, MiddlewareRequestID, MiddlewareLogging}); err == nil {
+		t.Errorf("rate limit before the request ID accepted")
+	}
+	if err := ValidateChain([]string{MiddlewareRequestID, MiddlewareRateLimit, MiddlewareLogging}); err == nil {
+		t.Errorf("rate limit before the request logging accepted")
+	}
+	if err := ValidateChain([]string{MiddlewareRequestID, MiddlewareLogging, MiddlewareRateLimit, MiddlewareFreshRead}); err != nil {
+		t.Errorf("valid rate limit ordering rejected; %s", err.Error())
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	flogger "motif-api/internal/logger"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// requestIDHeader is the HTTP header carrying the request correlation ID.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDMaxLength is the max length of a correlation ID accepted from the client.
+const requestIDMaxLength = 64
+
+// RequestIDHandler defines HTTP handler middleware assigning a correlation ID to each request.
+// The ID sent by the client is used if valid, a random one is generated otherwise.
+// The ID is stored in the request context for the request scoped logging
+// and returned to the client in the response header.
+type RequestIDHandler struct {
+	handler http.Handler
+}
+
+// NewRequestIDHandler creates a new request correlation ID middleware.
+func NewRequestIDHandler(h http.Handler) *RequestIDHandler {
+	return &RequestIDHandler{handler: h}
+}
+
+// ServeHTTP assigns the correlation ID and passes the request down the chain.
+func (h *RequestIDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := r.Header.Get(requestIDHeader)
+	if !isValidRequestID(id) {
+		id = newRequestID()
+	}
+
+	w.Header().Set(requestIDHeader, id)
+	h.handler.ServeHTTP(w, r.WithContext(flogger.WithRequestID(r.Context(), id)))
+}
+
+// isValidRequestID checks the correlation ID sent by the client is safe to be logged.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > requestIDMaxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !isNameChar(c) && c != '-' && c != '.' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random correlation ID.
+func newRequestID() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf[:])
+}
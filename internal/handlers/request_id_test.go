@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	flogger "motif-api/internal/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRequestIDHandler tests the correlation ID is accepted from the client, or generated.
+func TestRequestIDHandler(t *testing.T) {
+	var seen string
+	h := NewRequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = flogger.RequestID(r.Context())
+	}))
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"client id", "abc-123.x:1", true},
+		{"missing id", "", false},
+		{"invalid id", "bad id\n", false},
+		{"too long id", strings.Repeat("a", requestIDMaxLength+1), false},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		if tc.header != "" {
+			req.Header.Set(requestIDHeader, tc.header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		id := rec.Header().Get(requestIDHeader)
+		if id == "" || id != seen {
+			t.Errorf("%s; expected the same id in response and context, got %q and %q", tc.name, id, seen)
+		}
+		if (id == tc.header) != tc.keep {
+			t.Errorf("%s; unexpected id %q", tc.name, id)
+		}
+		if !tc.keep && len(id) != 32 {
+			t.Errorf("%s; expected generated id, got %q", tc.name, id)
+		}
+	}
+}
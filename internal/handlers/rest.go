@@ -30,3 +30,30 @@ func GasPrice(log logger.Logger) http.Handler {
 		}
 	})
 }
+
+// LoadStats constructs and return the REST API HTTP handler providing the load shedding state.
+func LoadStats(shed *LoadShedHandler, log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(shed.Stats()); err != nil {
+			log.Criticalf("can not encode load stats; %s", err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// Health constructs and return the REST API HTTP handler reporting the state of the API server dependencies.
+// It responds with 503 Service Unavailable if the node is not reachable, or synced, or the database fails.
+func Health(log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := repository.R().Health()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !h.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(h); err != nil {
+			log.Criticalf("can not encode health state; %s", err.Error())
+		}
+	})
+}
@@ -0,0 +1,201 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	gqlSchema "motif-api/internal/graphql/schema"
+	"motif-api/internal/logger"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/introspection"
+)
+
+// sdlBuiltins are the types and directives every schema has; they are not printed.
+var sdlBuiltins = map[string]bool{
+	"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true,
+	"include": true, "skip": true, "deprecated": true, "specifiedBy": true,
+}
+
+// sdlAllFields includes deprecated fields and enum values in the introspection listings.
+var sdlAllFields = &struct{ IncludeDeprecated bool }{IncludeDeprecated: true}
+
+// SchemaSDL constructs and return the HTTP handler serving the GraphQL schema in SDL form.
+// The SDL is printed from the parsed API schema, so the served document is normalized
+// and carries the descriptions as block strings tooling understands.
+func SchemaSDL(log logger.Logger) http.Handler {
+	sdl := []byte(printSchema(graphql.MustParseSchema(gqlSchema.Schema(), nil)))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/graphql; charset=utf-8")
+		if _, err := w.Write(sdl); err != nil {
+			log.Errorf("can not write schema SDL; %s", err.Error())
+		}
+	})
+}
+
+// printSchema serializes the parsed GraphQL schema into SDL; types and directives are sorted by name.
+func printSchema(schema *graphql.Schema) string {
+	is := introspection.WrapSchema(schema.ASTSchema())
+
+	var sb strings.Builder
+	sb.WriteString("schema {\n")
+	for _, ep := range []struct {
+		op  string
+		typ *introspection.Type
+	}{{"query", is.QueryType()}, {"mutation", is.MutationType()}, {"subscription", is.SubscriptionType()}} {
+		if ep.typ != nil {
+			sb.WriteString("    " + ep.op + ": " + *ep.typ.Name() + "\n")
+		}
+	}
+	sb.WriteString("}\n")
+
+	for _, d := range is.Directives() {
+		if sdlBuiltins[d.Name()] {
+			continue
+		}
+		sb.WriteString("\n")
+		sdlDescription(&sb, "", d.Description())
+		sb.WriteString("directive @" + d.Name() + sdlArgs(d.Args()) + " on " + strings.Join(d.Locations(), " | ") + "\n")
+	}
+
+	for _, t := range is.Types() {
+		name := *t.Name()
+		if sdlBuiltins[name] || strings.HasPrefix(name, "__") {
+			continue
+		}
+		sb.WriteString("\n")
+		sdlType(&sb, t)
+	}
+	return sb.String()
+}
+
+// sdlType writes the definition of the given named type.
+func sdlType(sb *strings.Builder, t *introspection.Type) {
+	name := *t.Name()
+	sdlDescription(sb, "", t.Description())
+
+	switch t.Kind() {
+	case "SCALAR":
+		sb.WriteString("scalar " + name + "\n")
+	case "UNION":
+		members := make([]string, 0)
+		for _, m := range *t.PossibleTypes() {
+			members = append(members, *m.Name())
+		}
+		sb.WriteString("union " + name + " = " + strings.Join(members, " | ") + "\n")
+	case "ENUM":
+		sb.WriteString("enum " + name + " {\n")
+		for _, v := range *t.EnumValues(sdlAllFields) {
+			sdlDescription(sb, "    ", v.Description())
+			sb.WriteString("    " + v.Name() + sdlDeprecated(v.IsDeprecated(), v.DeprecationReason()) + "\n")
+		}
+		sb.WriteString("}\n")
+	case "INPUT_OBJECT":
+		sb.WriteString("input " + name + " {\n")
+		for _, f := range *t.InputFields() {
+			sdlDescription(sb, "    ", f.Description())
+			sb.WriteString("    " + sdlInputValue(f) + "\n")
+		}
+		sb.WriteString("}\n")
+	case "OBJECT", "INTERFACE":
+		if t.Kind() == "OBJECT" {
+			sb.WriteString("type " + name)
+		} else {
+			sb.WriteString("interface " + name)
+		}
+		if ifs := t.Interfaces(); ifs != nil && len(*ifs) > 0 {
+			names := make([]string, 0, len(*ifs))
+			for _, i := range *ifs {
+				names = append(names, *i.Name())
+			}
+			sb.WriteString(" implements " + strings.Join(names, " & "))
+		}
+		sb.WriteString(" {\n")
+		for _, f := range *t.Fields(sdlAllFields) {
+			sdlDescription(sb, "    ", f.Description())
+			sb.WriteString("    " + f.Name() + sdlArgs(f.Args()) + ": " + sdlTypeRef(f.Type()) + sdlDeprecated(f.IsDeprecated(), f.DeprecationReason()) + "\n")
+		}
+		sb.WriteString("}\n")
+	}
+}
+
+// sdlTypeRef provides the reference to the given type, e.g. [Transaction!]!.
+func sdlTypeRef(t *introspection.Type) string {
+	switch t.Kind() {
+	case "NON_NULL":
+		return sdlTypeRef(t.OfType()) + "!"
+	case "LIST":
+		return "[" + sdlTypeRef(t.OfType()) + "]"
+	default:
+		return *t.Name()
+	}
+}
+
+// sdlArgs provides the argument list of a field, or a directive; empty if there are no arguments.
+func sdlArgs(args []*introspection.InputValue) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	list := make([]string, len(args))
+	for i, a := range args {
+		list[i] = sdlInputValue(a)
+	}
+	return "(" + strings.Join(list, ", ") + ")"
+}
+
+// sdlInputValue provides the definition of an argument, or an input field, with its default value.
+func sdlInputValue(v *introspection.InputValue) string {
+	def := v.Name() + ": " + sdlTypeRef(v.Type())
+	if dv := v.DefaultValue(); dv != nil {
+		def += " = " + *dv
+	}
+	return def
+}
+
+// sdlDeprecated provides the deprecation directive of a deprecated field, or enum value.
+func sdlDeprecated(deprecated bool, reason *string) string {
+	if !deprecated {
+		return ""
+	}
+	if reason == nil {
+		return " @deprecated"
+	}
+	return " @deprecated(reason: " + strconv.Quote(*reason) + ")"
+}
+
+// sdlDescription writes the description with the given indentation, if any. Block strings
+// are used unless the block string dedent, or its delimiter, would alter the text.
+func sdlDescription(sb *strings.Builder, indent string, desc *string) {
+	if desc == nil || *desc == "" {
+		return
+	}
+
+	lines := strings.Split(*desc, "\n")
+	block := len(lines) > 1 && !strings.Contains(*desc, `"""`) && !strings.Contains(*desc, "\r") &&
+		strings.TrimSpace(lines[0]) != "" && strings.TrimSpace(lines[len(lines)-1]) != ""
+	for _, l := range lines {
+		if l != "" && (l[0] == ' ' || l[0] == '\t') {
+			block = false
+		}
+	}
+	if !block {
+		sb.WriteString(indent + strconv.Quote(*desc) + "\n")
+		return
+	}
+
+	sb.WriteString(indent + `"""` + "\n")
+	for _, l := range lines {
+		if l != "" {
+			sb.WriteString(indent + l)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(indent + `"""` + "\n")
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	gqlSchema "motif-api/internal/graphql/schema"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/introspection"
+)
+
+// sdlTestSchema is a schema covering all the kinds of type definitions.
+const sdlTestSchema = `
+	schema { query: Query }
+
+	# Node is anything with an ID.
+	interface Node { id: ID! }
+
+	# Account represents an account
+	# with a multi-line description.
+	type Account implements Node {
+		id: ID!
+		"Balance of the account."
+		balance(unit: Unit = WEI, at: Long): BigInt!
+		tags: [String!] @deprecated(reason: "Use \"labels\" instead.")
+	}
+
+	type Query {
+		node(id: ID!): Node
+		search(filter: Filter = {limit: 10}): [Result!]!
+	}
+
+	union Result = Account
+
+	enum Unit { WEI FTM @deprecated }
+
+	input Filter { text: String, limit: Int = 25 }
+
+	scalar Long
+	scalar BigInt
+`
+
+// TestPrintSchema tests the SDL printed from a parsed schema.
+func TestPrintSchema(t *testing.T) {
+	want := `schema {
+    query: Query
+}
+
+"""
+Account represents an account
+with a multi-line description.
+"""
+type Account implements Node {
+    id: ID!
+    balance(unit: Unit = WEI, at: Long): BigInt!
+    tags: [String!] @deprecated(reason: "Use \"labels\" instead.")
+}
+
+scalar BigInt
+
+input Filter {
+    text: String
+    limit: Int = 25
+}
+
+scalar Long
+
+"Node is anything with an ID."
+interface Node {
+    id: ID!
+}
+
+type Query {
+    node(id: ID!): Node
+    search(filter: Filter = {limit: 10}): [Result!]!
+}
+
+union Result = Account
+
+enum Unit {
+    WEI
+    FTM @deprecated(reason: "No longer supported")
+}
+`
+	got := printSchema(graphql.MustParseSchema(sdlTestSchema, nil))
+	if got != want {
+		t.Errorf("unexpected SDL\n%s\nexpected\n%s", got, want)
+	}
+}
+
+// sdlTestTypes provides the descriptors of the named types of the schema; builtin types are left out.
+func sdlTestTypes(s *graphql.Schema) map[string][]string {
+	types := make(map[string][]string)
+	for _, t := range introspection.WrapSchema(s.ASTSchema()).Types() {
+		name := *t.Name()
+		if sdlBuiltins[name] || strings.HasPrefix(name, "__") {
+			continue
+		}
+
+		desc := []string{t.Kind(), sdlTestString(t.Description())}
+		if fields := t.Fields(sdlAllFields); fields != nil {
+			for _, f := range *fields {
+				desc = append(desc, fmt.Sprintf("field %s%s: %s %v %s %s", f.Name(), sdlArgs(f.Args()), sdlTypeRef(f.Type()),
+					f.IsDeprecated(), sdlTestString(f.DeprecationReason()), sdlTestString(f.Description())))
+			}
+		}
+		if values := t.EnumValues(sdlAllFields); values != nil {
+			for _, v := range *values {
+				desc = append(desc, fmt.Sprintf("value %s %v %s", v.Name(), v.IsDeprecated(), sdlTestString(v.Description())))
+			}
+		}
+		if inputs := t.InputFields(); inputs != nil {
+			for _, f := range *inputs {
+				desc = append(desc, fmt.Sprintf("input %s %s", sdlInputValue(f), sdlTestString(f.Description())))
+			}
+		}
+		if ifs := t.Interfaces(); ifs != nil {
+			for _, i := range *ifs {
+				desc = append(desc, "implements "+*i.Name())
+			}
+		}
+		if pts := t.PossibleTypes(); pts != nil {
+			for _, p := range *pts {
+				desc = append(desc, "member "+*p.Name())
+			}
+		}
+		types[name] = desc
+	}
+	return types
+}
+
+// sdlTestString provides the optional string for the type descriptors.
+func sdlTestString(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%q", *s)
+}
+
+// TestSchemaSDL tests the served SDL defines the same types as the API schema.
+func TestSchemaSDL(t *testing.T) {
+	rec := httptest.NewRecorder()
+	SchemaSDL(testLogger()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/schema.graphql", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if rec.Body.String() == gqlSchema.Schema() {
+		t.Fatalf("expected SDL printed from the parsed schema, got the raw bundle")
+	}
+
+	// the served SDL carries descriptions as strings
+	served, err := graphql.ParseSchema(rec.Body.String(), nil, graphql.UseStringDescriptions())
+	if err != nil {
+		t.Fatalf("can not parse served schema; %s", err.Error())
+	}
+	api := graphql.MustParseSchema(gqlSchema.Schema(), nil)
+
+	want, got := sdlTestTypes(api), sdlTestTypes(served)
+	if len(want) == 0 || len(got) != len(want) {
+		t.Errorf("expected %d types, got %d", len(want), len(got))
+	}
+	for name, wd := range want {
+		gd, ok := got[name]
+		if !ok {
+			t.Errorf("type %s not served", name)
+			continue
+		}
+		if strings.Join(gd, "\n") != strings.Join(wd, "\n") {
+			t.Errorf("type %s differs; got\n%s\nexpected\n%s", name, strings.Join(gd, "\n"), strings.Join(wd, "\n"))
+		}
+	}
+
+	for _, op := range []string{"query", "mutation", "subscription"} {
+		if served.ASTSchema().EntryPoints[op] == nil || served.ASTSchema().EntryPoints[op].TypeName() != api.ASTSchema().EntryPoints[op].TypeName() {
+			t.Errorf("entry point %s differs", op)
+		}
+	}
+}
+
+// TestSchemaSDLMethod tests the SDL end-point rejects non-GET requests.
+func TestSchemaSDLMethod(t *testing.T) {
+	rec := httptest.NewRecorder()
+	SchemaSDL(testLogger()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/schema.graphql", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", rec.Code)
+	}
+}
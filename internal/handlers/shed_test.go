@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/metrics"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// TestLoadShedHandler simulates an overload by blocking requests in flight
+// and verifies new requests are shed until the load drops.
+func TestLoadShedHandler(t *testing.T) {
+	cfg := config.Config{Server: config.Server{MaxInFlight: 2, RetryAfter: 7}}
+
+	// the backend blocks until released
+	var started sync.WaitGroup
+	unblock := make(chan struct{})
+	h := NewLoadShedHandler(&cfg, testLogger(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started.Done()
+		<-unblock
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// fill the server up to the threshold
+	var done sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		started.Add(1)
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("expected request in flight to pass, got %d", rec.Code)
+			}
+		}()
+	}
+	started.Wait()
+
+	// the next request is shed
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected overloaded request to be shed, got %d", rec.Code)
+	}
+	if ra := rec.Header().Get("Retry-After"); ra != "7" {
+		t.Errorf("expected Retry-After 7, got %q", ra)
+	}
+
+	st := h.Stats()
+	if !st.Shedding || st.InFlight != 2 || st.Shed != 1 {
+		t.Errorf("unexpected shedding stats %+v", st)
+	}
+	if metrics.LoadShedding.Value() != 1 || metrics.InFlightRequests.Value() != 2 {
+		t.Errorf("unexpected exported shedding %v, in flight %v", metrics.LoadShedding.Value(), metrics.InFlightRequests.Value())
+	}
+
+	// WebSocket upgrades are not shed
+	started.Add(1)
+	req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	req.Header.Set("Upgrade", "websocket")
+	go func() {
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+	started.Wait()
+
+	// drop the load and try again
+	close(unblock)
+	done.Wait()
+
+	started.Add(1)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected request to pass after the load dropped, got %d", rec.Code)
+	}
+	if h.Stats().Shedding {
+		t.Errorf("expected shedding to stop after the load dropped")
+	}
+	if metrics.LoadShedding.Value() != 0 || metrics.InFlightRequests.Value() != 0 {
+		t.Errorf("unexpected exported shedding %v, in flight %v", metrics.LoadShedding.Value(), metrics.InFlightRequests.Value())
+	}
+}
+
+// TestLoadShedHandlerDisabled verifies no request is shed without a threshold.
+func TestLoadShedHandlerDisabled(t *testing.T) {
+	h := NewLoadShedHandler(&config.Config{}, testLogger(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 10; i++ {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected request to pass, got %d", rec.Code)
+		}
+	}
+}
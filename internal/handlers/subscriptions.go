@@ -0,0 +1,396 @@
+package handlers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/config"
+	"motif-api/internal/graphql/resolvers"
+	flogger "motif-api/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/rs/cors"
+)
+
+// wsProtocol is the WebSocket sub-protocol of the GraphQL subscriptions
+// we speak with the clients (the Apollo subscriptions-transport-ws).
+const wsProtocol = "graphql-ws"
+
+// close codes sent to clients on the connection initialization failure
+const (
+	// wsCloseBadInit signals a malformed, or missing connection init message.
+	wsCloseBadInit = 4400
+
+	// wsCloseUnauthorized signals rejected, or missing required credentials.
+	wsCloseUnauthorized = 4401
+
+	// wsCloseInitTimeout signals the client did not initialize the connection in time.
+	wsCloseInitTimeout = 4408
+)
+
+// wsInitTimeout represents the max time we wait for the connection init message.
+const wsInitTimeout = 10 * time.Second
+
+// wsWriteTimeout represents the max time we wait for a single message to be sent.
+const wsWriteTimeout = 5 * time.Second
+
+// wsReadLimit represents the max size of an incoming message.
+const wsReadLimit = 64 * 1024
+
+// GraphQL over WebSocket message types
+const (
+	wsMsgConnectionInit      = "connection_init"
+	wsMsgConnectionAck       = "connection_ack"
+	wsMsgConnectionError     = "connection_error"
+	wsMsgConnectionTerminate = "connection_terminate"
+	wsMsgKeepAlive           = "ka"
+	wsMsgStart               = "start"
+	wsMsgStop                = "stop"
+	wsMsgData                = "data"
+	wsMsgError               = "error"
+	wsMsgComplete            = "complete"
+)
+
+// wsMessage represents a single GraphQL over WebSocket protocol message.
+type wsMessage struct {
+	Id      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsStartPayload represents the payload of the subscription start message.
+type wsStartPayload struct {
+	Query         string          `json:"query"`
+	OperationName string          `json:"operationName"`
+	Variables     json.RawMessage `json:"variables"`
+}
+
+// SubscriptionHandler defines HTTP handler middleware serving GraphQL subscriptions
+// over WebSocket connections. Clients may send a bearer token in the connection init payload;
+// the token is verified before any subscription starts, and the verified claims stay attached
+// to the connection for its whole lifetime. Other requests are passed down the chain.
+type SubscriptionHandler struct {
+	logger    flogger.Logger
+	handler   http.Handler
+	schema    *graphql.Schema
+	verifier  *auth.JwtVerifier
+	anonymous bool
+	limits    VariablesLimits
+	upgrader  websocket.Upgrader
+}
+
+// NewSubscriptionHandler creates a new GraphQL subscriptions handler middleware.
+func NewSubscriptionHandler(cfg *config.Config, log flogger.Logger, schema *graphql.Schema, h http.Handler) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		logger:    log,
+		handler:   h,
+		schema:    schema,
+		verifier:  auth.NewJwtVerifier(&cfg.Auth, log),
+		anonymous: cfg.Auth.AnonymousSubscriptions,
+		limits:    NewVariablesLimits(&cfg.Server),
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{wsProtocol},
+			CheckOrigin: wsCheckOrigin(cfg),
+		},
+	}
+}
+
+// wsCheckOrigin provides the origin check of WebSocket upgrades by the configured CORS origins.
+// Browsers do not apply the CORS policy to WebSocket connections, so the check is on us.
+// Requests without the Origin header do not come from browsers and are allowed.
+func wsCheckOrigin(cfg *config.Config) func(*http.Request) bool {
+	policy := cors.New(corsOptions(cfg))
+	return func(r *http.Request) bool {
+		return r.Header.Get("Origin") == "" || policy.OriginAllowed(r)
+	}
+}
+
+// ServeHTTP handles incoming request by upgrading GraphQL WebSocket connections,
+// or passing the request to the next handler in the chain.
+func (h *SubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) || !hasSubProtocol(r, wsProtocol) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Errorf("can not upgrade connection of %s; %s", r.RemoteAddr, err.Error())
+		return
+	}
+
+	// the request context ends with this call; keep just the credentials verified on the upgrade request
+	ctx := resolvers.WithClient(context.Background(), clientAddress(r))
+	if cl := auth.ClaimsFromContext(r.Context()); cl != nil {
+		ctx = auth.WithClaims(ctx, cl)
+	}
+	if err := auth.FailureFromContext(r.Context()); err != nil {
+		ctx = auth.WithFailure(ctx, err)
+	}
+
+	wc := &wsConnection{handler: h, conn: conn, remote: r.RemoteAddr, subs: make(map[string]context.CancelFunc)}
+	go wc.serve(ctx)
+}
+
+// hasSubProtocol checks if the client offered the given WebSocket sub-protocol.
+func hasSubProtocol(r *http.Request, proto string) bool {
+	for _, p := range websocket.Subprotocols(r) {
+		if p == proto {
+			return true
+		}
+	}
+	return false
+}
+
+// credentials verifies the credentials of the connection init payload, if any,
+// and provides the connection context with the verified claims attached.
+func (h *SubscriptionHandler) credentials(ctx context.Context, payload json.RawMessage) (context.Context, error) {
+	token := initToken(payload)
+	if token != "" {
+		if h.verifier == nil {
+			return nil, errors.New("credentials not accepted")
+		}
+		cl, err := h.verifier.Verify(token)
+		if err != nil {
+			return nil, err
+		}
+		return auth.WithClaims(context.Background(), cl), nil
+	}
+
+	// credentials of the upgrade request rejected?
+	if err := auth.FailureFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	// anonymous connection
+	if auth.ClaimsFromContext(ctx) == nil && !h.anonymous {
+		return nil, auth.ErrNotAuthenticated
+	}
+	return ctx, nil
+}
+
+// initToken extracts the bearer token from the connection init payload.
+// We accept both the "Authorization" header style value, and the raw "authToken".
+func initToken(payload json.RawMessage) string {
+	var pl map[string]interface{}
+	if len(payload) == 0 || json.Unmarshal(payload, &pl) != nil {
+		return ""
+	}
+
+	for _, key := range []string{authHeader, strings.ToLower(authHeader)} {
+		if val, ok := pl[key].(string); ok && strings.HasPrefix(val, bearerPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(val, bearerPrefix))
+		}
+	}
+
+	token, _ := pl["authToken"].(string)
+	return strings.TrimSpace(token)
+}
+
+// wsConnection represents a single GraphQL subscriptions WebSocket connection.
+type wsConnection struct {
+	handler *SubscriptionHandler
+	conn    *websocket.Conn
+	remote  string
+
+	wmu sync.Mutex
+
+	smu  sync.Mutex
+	subs map[string]context.CancelFunc
+}
+
+// serve initializes the connection and handles incoming messages until the connection is closed.
+func (wc *wsConnection) serve(ctx context.Context) {
+	defer func() {
+		if err := wc.conn.Close(); err != nil {
+			wc.handler.logger.Debugf("can not close subscriptions connection of %s; %s", wc.remote, err.Error())
+		}
+	}()
+	wc.conn.SetReadLimit(wsReadLimit)
+
+	// initialize the connection
+	ctx, ok := wc.init(ctx)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for {
+		var msg wsMessage
+		if err := wc.conn.ReadJSON(&msg); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				wc.handler.logger.Debugf("subscriptions connection of %s failed; %s", wc.remote, err.Error())
+			}
+			return
+		}
+
+		switch msg.Type {
+		case wsMsgStart:
+			wc.start(ctx, &msg)
+		case wsMsgStop:
+			wc.stop(msg.Id)
+		case wsMsgConnectionTerminate:
+			return
+		default:
+			wc.write(&wsMessage{Id: msg.Id, Type: wsMsgError, Payload: wsErrorPayload("unknown message type " + msg.Type)})
+		}
+	}
+}
+
+// init waits for the connection init message, verifies credentials it carries
+// and acknowledges the connection. The connection is closed with a clear close code
+// if the initialization fails.
+func (wc *wsConnection) init(ctx context.Context) (context.Context, bool) {
+	if err := wc.conn.SetReadDeadline(time.Now().Add(wsInitTimeout)); err != nil {
+		return nil, false
+	}
+
+	var msg wsMessage
+	if err := wc.conn.ReadJSON(&msg); err != nil {
+		var ne interface{ Timeout() bool }
+		if errors.As(err, &ne) && ne.Timeout() {
+			wc.close(wsCloseInitTimeout, "connection init timeout")
+			return nil, false
+		}
+		wc.close(wsCloseBadInit, "invalid connection init")
+		return nil, false
+	}
+	if msg.Type != wsMsgConnectionInit {
+		wc.close(wsCloseBadInit, "connection init expected")
+		return nil, false
+	}
+
+	// verify credentials
+	ctx, err := wc.handler.credentials(ctx, msg.Payload)
+	if err != nil {
+		wc.handler.logger.Debugf("subscriptions credentials of %s rejected; %s", wc.remote, err.Error())
+
+		reason := "invalid credentials"
+		if err == auth.ErrNotAuthenticated {
+			reason = err.Error()
+		}
+		wc.write(&wsMessage{Type: wsMsgConnectionError, Payload: wsErrorPayload(reason)})
+		wc.close(wsCloseUnauthorized, reason)
+		return nil, false
+	}
+
+	if err := wc.conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, false
+	}
+	wc.write(&wsMessage{Type: wsMsgConnectionAck})
+	wc.write(&wsMessage{Type: wsMsgKeepAlive})
+	return ctx, true
+}
+
+// start opens a new subscription and streams its responses to the client.
+func (wc *wsConnection) start(ctx context.Context, msg *wsMessage) {
+	var pl wsStartPayload
+	if err := json.Unmarshal(msg.Payload, &pl); err != nil {
+		wc.write(&wsMessage{Id: msg.Id, Type: wsMsgError, Payload: wsErrorPayload("invalid subscription payload")})
+		return
+	}
+
+	vars, err := wc.handler.limits.Decode(pl.Variables)
+	if err != nil {
+		wc.write(&wsMessage{Id: msg.Id, Type: wsMsgError, Payload: wsErrorPayload(err.Error())})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	wc.smu.Lock()
+	if _, ok := wc.subs[msg.Id]; ok {
+		wc.smu.Unlock()
+		cancel()
+		wc.write(&wsMessage{Id: msg.Id, Type: wsMsgError, Payload: wsErrorPayload("subscription id already in use")})
+		return
+	}
+	wc.subs[msg.Id] = cancel
+	wc.smu.Unlock()
+
+	ch, err := wc.handler.schema.Subscribe(ctx, pl.Query, pl.OperationName, vars)
+	if err != nil {
+		wc.stop(msg.Id)
+		wc.write(&wsMessage{Id: msg.Id, Type: wsMsgError, Payload: wsErrorPayload(err.Error())})
+		return
+	}
+
+	go func(id string) {
+		for res := range ch {
+			data, err := json.Marshal(res)
+			if err != nil {
+				wc.handler.logger.Errorf("can not encode subscription response; %s", err.Error())
+				continue
+			}
+			wc.write(&wsMessage{Id: id, Type: wsMsgData, Payload: data})
+		}
+
+		// the stream ended; let the client know unless it stopped the subscription itself
+		if wc.remove(id) {
+			wc.write(&wsMessage{Id: id, Type: wsMsgComplete})
+		}
+	}(msg.Id)
+}
+
+// stop terminates the subscription of the given id.
+func (wc *wsConnection) stop(id string) {
+	if wc.remove(id) {
+		wc.write(&wsMessage{Id: id, Type: wsMsgComplete})
+	}
+}
+
+// remove cancels the subscription of the given id, if it's still active.
+func (wc *wsConnection) remove(id string) bool {
+	wc.smu.Lock()
+	defer wc.smu.Unlock()
+
+	cancel, ok := wc.subs[id]
+	if !ok {
+		return false
+	}
+	cancel()
+	delete(wc.subs, id)
+	return true
+}
+
+// write sends the given message to the client; concurrent writes are serialized.
+func (wc *wsConnection) write(msg *wsMessage) {
+	wc.wmu.Lock()
+	defer wc.wmu.Unlock()
+
+	if err := wc.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return
+	}
+	if err := wc.conn.WriteJSON(msg); err != nil {
+		wc.handler.logger.Debugf("can not send subscription message to %s; %s", wc.remote, err.Error())
+	}
+}
+
+// close sends the close message with the given code and reason to the client.
+func (wc *wsConnection) close(code int, reason string) {
+	wc.wmu.Lock()
+	defer wc.wmu.Unlock()
+
+	msg := websocket.FormatCloseMessage(code, reason)
+	if err := wc.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteTimeout)); err != nil {
+		wc.handler.logger.Debugf("can not close subscriptions connection of %s; %s", wc.remote, err.Error())
+	}
+}
+
+// wsErrorPayload encodes the error message payload.
+func wsErrorPayload(msg string) json.RawMessage {
+	data, err := json.Marshal(struct {
+		Message string `json:"message"`
+	}{Message: msg})
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"motif-api/internal/auth"
+	"motif-api/internal/config"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// subTestKey is the JWT signing key of the subscriptions test.
+const subTestKey = "subscriptions-test-key"
+
+// subTestSchema is the schema of the subscriptions test.
+const subTestSchema = `
+	schema { query: Query subscription: Subscription }
+	type Query { ping: String! }
+	type Subscription { onPublic: String! onPrivate: String! }
+`
+
+// subTestResolver implements the root resolver of the subscriptions test schema.
+type subTestResolver struct{}
+
+// Ping resolves a dummy query; the schema must have one.
+func (*subTestResolver) Ping() string {
+	return "pong"
+}
+
+// OnPublic resolves a subscription available to anybody.
+func (*subTestResolver) OnPublic() <-chan string {
+	return subTestStream("public")
+}
+
+// OnPrivate resolves a subscription available to administrators only.
+func (*subTestResolver) OnPrivate(ctx context.Context) (<-chan string, error) {
+	if err := auth.Require(ctx, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return subTestStream("private"), nil
+}
+
+// subTestStream creates a stream of a single event.
+func subTestStream(val string) <-chan string {
+	ch := make(chan string, 1)
+	ch <- val
+	close(ch)
+	return ch
+}
+
+// subTestToken creates a signed token with the given roles.
+func subTestToken(t *testing.T, roles ...string) string {
+	enc := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("can not encode token; %s", err.Error())
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signed := enc(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." +
+		enc(map[string]interface{}{"sub": "tester", "roles": roles, "exp": time.Now().Add(time.Hour).Unix()})
+
+	mac := hmac.New(sha256.New, []byte(subTestKey))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// subTestServer starts a test server with the subscriptions handler.
+func subTestServer(t *testing.T, anonymous bool) *httptest.Server {
+	cfg := config.Config{Auth: config.Auth{JwtKey: subTestKey, RoleClaim: "roles", AnonymousSubscriptions: anonymous}}
+	schema := graphql.MustParseSchema(subTestSchema, &subTestResolver{})
+
+	srv := httptest.NewServer(NewSubscriptionHandler(&cfg, testLogger(), schema, http.NotFoundHandler()))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// subTestConnect opens a subscriptions connection and sends the connection init with the given payload.
+func subTestConnect(t *testing.T, srv *httptest.Server, payload string) *websocket.Conn {
+	dialer := websocket.Dialer{Subprotocols: []string{wsProtocol}, HandshakeTimeout: time.Second}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("can not connect; %s", err.Error())
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	if err := conn.WriteJSON(wsMessage{Type: wsMsgConnectionInit, Payload: json.RawMessage(payload)}); err != nil {
+		t.Fatalf("can not init connection; %s", err.Error())
+	}
+	return conn
+}
+
+// subTestRead reads the next message skipping keep alive signals.
+func subTestRead(t *testing.T, conn *websocket.Conn) (*wsMessage, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("can not set deadline; %s", err.Error())
+	}
+	for {
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return nil, err
+		}
+		if msg.Type != wsMsgKeepAlive {
+			return &msg, nil
+		}
+	}
+}
+
+// subTestExpect reads the next message and checks its type.
+func subTestExpect(t *testing.T, conn *websocket.Conn, typ string) *wsMessage {
+	msg, err := subTestRead(t, conn)
+	if err != nil {
+		t.Fatalf("expected %s, got %s", typ, err.Error())
+	}
+	if msg.Type != typ {
+		t.Fatalf("expected %s, got %s %s", typ, msg.Type, string(msg.Payload))
+	}
+	return msg
+}
+
+// subTestSubscribe starts the given subscription and provides the first response.
+func subTestSubscribe(t *testing.T, conn *websocket.Conn, id string, field string) *graphql.Response {
+	pl, _ := json.Marshal(wsStartPayload{Query: "subscription { " + field + " }"})
+	if err := conn.WriteJSON(wsMessage{Id: id, Type: wsMsgStart, Payload: pl}); err != nil {
+		t.Fatalf("can not start subscription; %s", err.Error())
+	}
+
+	msg := subTestExpect(t, conn, wsMsgData)
+	if msg.Id != id {
+		t.Fatalf("expected subscription %s, got %s", id, msg.Id)
+	}
+
+	var res graphql.Response
+	if err := json.Unmarshal(msg.Payload, &res); err != nil {
+		t.Fatalf("can not decode response; %s", err.Error())
+	}
+	subTestExpect(t, conn, wsMsgComplete)
+	return &res
+}
+
+// subTestExpectClose checks the connection is closed with the given close code.
+func subTestExpectClose(t *testing.T, conn *websocket.Conn, code int) {
+	for {
+		msg, err := subTestRead(t, conn)
+		if err == nil {
+			if msg.Type != wsMsgConnectionError {
+				t.Fatalf("expected connection close, got %s", msg.Type)
+			}
+			continue
+		}
+		if !websocket.IsCloseError(err, code) {
+			t.Fatalf("expected close code %d, got %s", code, err.Error())
+		}
+		return
+	}
+}
+
+// TestSubscriptionsAuthenticated tests a client with verified credentials can access protected subscriptions.
+func TestSubscriptionsAuthenticated(t *testing.T) {
+	conn := subTestConnect(t, subTestServer(t, false), `{"Authorization":"Bearer `+subTestToken(t, auth.RoleAdmin)+`"}`)
+	subTestExpect(t, conn, wsMsgConnectionAck)
+
+	res := subTestSubscribe(t, conn, "1", "onPrivate")
+	if len(res.Errors) > 0 || string(res.Data) != `{"onPrivate":"private"}` {
+		t.Errorf("unexpected private response %s %v", string(res.Data), res.Errors)
+	}
+
+	res = subTestSubscribe(t, conn, "2", "onPublic")
+	if len(res.Errors) > 0 || string(res.Data) != `{"onPublic":"public"}` {
+		t.Errorf("unexpected public response %s %v", string(res.Data), res.Errors)
+	}
+}
+
+// TestSubscriptionsAnonymous tests an anonymous client can access public subscriptions only.
+func TestSubscriptionsAnonymous(t *testing.T) {
+	conn := subTestConnect(t, subTestServer(t, true), `{}`)
+	subTestExpect(t, conn, wsMsgConnectionAck)
+
+	res := subTestSubscribe(t, conn, "1", "onPublic")
+	if len(res.Errors) > 0 || string(res.Data) != `{"onPublic":"public"}` {
+		t.Errorf("unexpected public response %s %v", string(res.Data), res.Errors)
+	}
+
+	res = subTestSubscribe(t, conn, "2", "onPrivate")
+	if len(res.Errors) != 1 || res.Errors[0].Message != auth.ErrNotAuthenticated.Error() {
+		t.Errorf("expected authentication error, got %s %v", string(res.Data), res.Errors)
+	}
+}
+
+// TestSubscriptionsAnonymousDisabled tests anonymous connections are rejected if not allowed.
+func TestSubscriptionsAnonymousDisabled(t *testing.T) {
+	conn := subTestConnect(t, subTestServer(t, false), `{}`)
+	subTestExpectClose(t, conn, wsCloseUnauthorized)
+}
+
+// TestSubscriptionsInvalidCredentials tests connections with invalid credentials are rejected.
+func TestSubscriptionsInvalidCredentials(t *testing.T) {
+	token := subTestToken(t, auth.RoleAdmin)
+	conn := subTestConnect(t, subTestServer(t, true), `{"authToken":"`+token[:len(token)-4]+`AAAA"}`)
+	subTestExpectClose(t, conn, wsCloseUnauthorized)
+}
+
+// TestSubscriptionsOrigin tests upgrades are allowed from the configured CORS origins only.
+func TestSubscriptionsOrigin(t *testing.T) {
+	cfg := config.Config{
+		Server: config.Server{CorsOrigin: []string{"https://app.example.com", "https://*.wallet.io"}},
+		Auth:   config.Auth{AnonymousSubscriptions: true},
+	}
+	schema := graphql.MustParseSchema(subTestSchema, &subTestResolver{})
+	srv := httptest.NewServer(NewSubscriptionHandler(&cfg, testLogger(), schema, http.NotFoundHandler()))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		origin string
+		ok     bool
+	}{
+		{"https://app.example.com", true},
+		{"https://beta.wallet.io", true},
+		{"", true},
+		{"https://evil.example.com", false},
+		{"http://app.example.com", false},
+	}
+	for _, tc := range tests {
+		hdr := http.Header{}
+		if tc.origin != "" {
+			hdr.Set("Origin", tc.origin)
+		}
+
+		dialer := websocket.Dialer{Subprotocols: []string{wsProtocol}, HandshakeTimeout: time.Second}
+		conn, resp, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), hdr)
+		if conn != nil {
+			_ = conn.Close()
+		}
+		if tc.ok && err != nil {
+			t.Errorf("expected origin %q allowed; %s", tc.origin, err.Error())
+		}
+		if !tc.ok && (err == nil || resp == nil || resp.StatusCode != http.StatusForbidden) {
+			t.Errorf("expected origin %q forbidden, got %v", tc.origin, err)
+		}
+	}
+}
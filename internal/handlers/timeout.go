@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NewTimeoutHandler creates a handler middleware limiting the time to serve a request.
+// The timeout is obtained for each request so it can be changed on a live server.
+// Long living WebSocket connections can not be hijacked through the timeout handler
+// so they bypass it.
+func NewTimeoutHandler(h http.Handler, timeout func() time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			h.ServeHTTP(w, r)
+			return
+		}
+		http.TimeoutHandler(h, timeout(), "Service timeout.").ServeHTTP(w, r)
+	})
+}
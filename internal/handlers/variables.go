@@ -0,0 +1,75 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"motif-api/internal/config"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// VariablesLimits represents the limits of GraphQL request variables;
+// zero means no limit.
+type VariablesLimits struct {
+	MaxSize  int
+	MaxDepth int
+}
+
+// NewVariablesLimits creates the variables limits from the server configuration.
+func NewVariablesLimits(cfg *config.Server) VariablesLimits {
+	return VariablesLimits{MaxSize: cfg.MaxVariablesSize, MaxDepth: cfg.MaxVariablesDepth}
+}
+
+// Decode checks the raw variables JSON against the limits and decodes it.
+// Missing, or null variables decode into nil.
+func (vl VariablesLimits) Decode(raw json.RawMessage) (map[string]interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if vl.MaxSize > 0 && len(raw) > vl.MaxSize {
+		return nil, fmt.Errorf("variables size of %d bytes exceeds the limit of %d bytes", len(raw), vl.MaxSize)
+	}
+	if vl.MaxDepth > 0 {
+		depth, err := jsonDepth(raw)
+		if err != nil {
+			return nil, err
+		}
+		if depth > vl.MaxDepth {
+			return nil, fmt.Errorf("variables nesting depth of %d exceeds the limit of %d", depth, vl.MaxDepth)
+		}
+	}
+
+	var vars map[string]interface{}
+	if err := json.Unmarshal(raw, &vars); err != nil {
+		return nil, fmt.Errorf("invalid variables; %s", err.Error())
+	}
+	return vars, nil
+}
+
+// jsonDepth calculates the maximal nesting depth of objects and arrays in the given JSON
+// without decoding the values; the variables object itself is on the depth of 1.
+func jsonDepth(raw json.RawMessage) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+
+	var depth, max int
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return max, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid variables; %s", err.Error())
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+			if depth > max {
+				max = depth
+			}
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// varsTestQuery implements the root resolver of the variables limits test schema.
+type varsTestQuery struct {
+	calls int
+}
+
+// Echo resolves a test field returning the given text.
+func (q *varsTestQuery) Echo(args struct{ Text string }) string {
+	q.calls++
+	return args.Text
+}
+
+// testVariablesRequest sends a GraphQL request with the given variables JSON to a handler with test limits.
+func testVariablesRequest(t *testing.T, vars string) (*httptest.ResponseRecorder, *varsTestQuery) {
+	q := &varsTestQuery{}
+	schema := graphql.MustParseSchema(`schema { query: Query } type Query { echo(text: String!): String! }`, q)
+	h := NewGraphQLHandler(testLogger(), schema, VariablesLimits{MaxSize: 64, MaxDepth: 3}, ComplexityLimit{}, nil, nil)
+
+	body := `{"query":"query ($text: String!) { echo(text: $text) }","variables":` + vars + `}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+	return rec, q
+}
+
+// TestVariablesLimitsPass tests variables within the limits are executed.
+func TestVariablesLimitsPass(t *testing.T) {
+	rec, q := testVariablesRequest(t, `{"text":"hello","x":[{"y":1}]}`)
+	if rec.Code != http.StatusOK || q.calls != 1 {
+		t.Fatalf("expected executed request, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	// no variables at all
+	rec, _ = testVariablesRequest(t, `null`)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200 without variables, got %d", rec.Code)
+	}
+}
+
+// TestVariablesLimitsSize tests over-size variables are rejected before execution.
+func TestVariablesLimitsSize(t *testing.T) {
+	rec, q := testVariablesRequest(t, `{"text":"`+strings.Repeat("a", 100)+`"}`)
+	if rec.Code != http.StatusBadRequest || q.calls != 0 {
+		t.Fatalf("expected rejected request, got %d with %d calls", rec.Code, q.calls)
+	}
+
+	var res struct {
+		Errors []struct{ Message string }
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("can not decode response; %s", err.Error())
+	}
+	if len(res.Errors) != 1 || !strings.Contains(res.Errors[0].Message, "exceeds the limit of 64 bytes") {
+		t.Errorf("unexpected response %s", rec.Body.String())
+	}
+}
+
+// TestVariablesLimitsDepth tests deeply nested variables are rejected before execution.
+func TestVariablesLimitsDepth(t *testing.T) {
+	rec, q := testVariablesRequest(t, `{"text":"a","x":[[{"y":1}]]}`)
+	if rec.Code != http.StatusBadRequest || q.calls != 0 {
+		t.Fatalf("expected rejected request, got %d with %d calls", rec.Code, q.calls)
+	}
+	if !strings.Contains(rec.Body.String(), "nesting depth of 4 exceeds the limit of 3") {
+		t.Errorf("unexpected response %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/op/go-logging"
+	"io"
+	"strings"
+	"time"
+)
+
+// jsonCallerFormat is used to resolve the package and the function emitting the record.
+var jsonCallerFormat = logging.MustStringFormatter("%{shortpkg} %{shortfunc}")
+
+// jsonRecord represents a single structured log line.
+type jsonRecord struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Package   string `json:"package"`
+	Function  string `json:"function"`
+	Message   string `json:"message"`
+}
+
+// jsonFormatter implements logging formatter writing records as JSON lines.
+type jsonFormatter struct{}
+
+// Format writes the record as a JSON object; the backend terminates the line.
+func (jsonFormatter) Format(calldepth int, r *logging.Record, w io.Writer) error {
+	// resolve the caller the same way the string formatter does
+	var caller bytes.Buffer
+	if err := jsonCallerFormat.Format(calldepth+1, r, &caller); err != nil {
+		return err
+	}
+	pkg, fn := caller.String(), ""
+	if i := strings.IndexByte(pkg, ' '); i >= 0 {
+		pkg, fn = pkg[:i], pkg[i+1:]
+	}
+
+	data, err := json.Marshal(jsonRecord{
+		Timestamp: r.Time.UTC().Format(time.RFC3339Nano),
+		Level:     r.Level.String(),
+		Package:   pkg,
+		Function:  fn,
+		Message:   r.Message(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/op/go-logging"
+	"testing"
+)
+
+// TestJSONFormatter tests records are written as JSON lines with the caller resolved.
+func TestJSONFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	backend := logging.AddModuleLevel(logging.NewBackendFormatter(logging.NewLogBackend(&buf, "", 0), jsonFormatter{}))
+	backend.SetLevel(logging.DEBUG, "")
+
+	l := logging.MustGetLogger("test")
+	l.SetBackend(backend)
+	l.Warningf("value %d %q", 42, "x")
+
+	var rec jsonRecord
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &rec); err != nil {
+		t.Fatalf("can not decode record %q; %s", buf.String(), err.Error())
+	}
+	if rec.Level != "WARNING" || rec.Message != `value 42 "x"` || rec.Timestamp == "" {
+		t.Errorf("unexpected record %+v", rec)
+	}
+	if rec.Package != "logger" || rec.Function != "TestJSONFormatter" {
+		t.Errorf("unexpected caller %s/%s", rec.Package, rec.Function)
+	}
+}
@@ -3,9 +3,13 @@ package logger
 import (
 	"motif-api/internal/config"
 	"github.com/op/go-logging"
+	"log"
 	"os"
 )
 
+// logFileSizeUnit represents the unit of the configured log file size, 1 MB.
+const logFileSizeUnit = 1 << 20
+
 // ApiLogger defines extended logger with generic no-level logging option
 type ApiLogger struct {
 	logging.Logger
@@ -17,23 +21,36 @@ func (a ApiLogger) Printf(format string, args ...interface{}) {
 	a.Debugf(format, args...)
 }
 
-// New provides pre-configured Logger with stderr output and leveled filtering.
+// New provides pre-configured Logger with the configured outputs and leveled filtering.
+// The configured format applies to all the outputs; JSON lines are written instead if enabled.
 // Modules are not supported at the moment, but may be added in the future to make the logging setup more granular.
 func New(cfg *config.Config) Logger {
-	// Prep the backend for exporting the log records
-	// @todo Allow app to define different logging backend by configuration means.
-	backend := logging.NewLogBackend(os.Stderr, "", 0)
+	// Parse log format from configuration and apply it to all the backends
+	var format logging.Formatter = jsonFormatter{}
+	if !cfg.Log.JSON {
+		format = logging.MustStringFormatter(cfg.Log.Format)
+	}
+	backends := make([]logging.Backend, 0, len(cfg.Log.Outputs))
+	for _, out := range cfg.Log.Outputs {
+		backend, err := newBackend(out, &cfg.Log)
+		if err != nil {
+			log.Printf("log output %s not available; %s", out, err.Error())
+			continue
+		}
+		backends = append(backends, logging.NewBackendFormatter(backend, format))
+	}
 
-	// Parse log format from configuration and apply it to the backend
-	format := logging.MustStringFormatter(cfg.Log.Format)
-	fmtBackend := logging.NewBackendFormatter(backend, format)
+	// stderr is the last resort
+	if len(backends) == 0 {
+		backends = append(backends, logging.NewBackendFormatter(logging.NewLogBackend(os.Stderr, "", 0), format))
+	}
 
 	// Parse and apply the configured level on which the recording will be emitted
 	level, err := logging.LogLevel(cfg.Log.Level)
 	if err != nil {
 		level = logging.INFO
 	}
-	lvlBackend := logging.AddModuleLevel(fmtBackend)
+	lvlBackend := logging.MultiLogger(backends...)
 	lvlBackend.SetLevel(level, "")
 
 	// assign the backend and return the new logger
@@ -42,3 +59,20 @@ func New(cfg *config.Config) Logger {
 
 	return &ApiLogger{*l}
 }
+
+// newBackend creates the logging backend of the given output.
+func newBackend(out string, cfg *config.Log) (logging.Backend, error) {
+	switch out {
+	case config.LogOutputStdout:
+		return logging.NewLogBackend(os.Stdout, "", 0), nil
+	case config.LogOutputFile:
+		rf, err := newRotatingFile(cfg.File.Path, cfg.File.MaxSize*logFileSizeUnit, cfg.File.MaxFiles)
+		if err != nil {
+			return nil, err
+		}
+		return logging.NewLogBackend(rf, "", 0), nil
+	case config.LogOutputSyslog:
+		return logging.NewSyslogBackend(cfg.SyslogTag)
+	}
+	return logging.NewLogBackend(os.Stderr, "", 0), nil
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+)
+
+// requestIDKey represents the context key of the request correlation ID.
+type requestIDKey struct{}
+
+// WithRequestID creates a derived context carrying the correlation ID of the request.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID provides the correlation ID of the request, if any.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithContext provides a logger tagging each record with the correlation ID
+// of the request carried by the context. The logger is returned as is if there is none.
+func WithContext(ctx context.Context, l Logger) Logger {
+	id := RequestID(ctx)
+	if id == "" {
+		return l
+	}
+
+	// the wrapper adds a call frame; skip it so the records keep the real caller
+	if al, ok := l.(*ApiLogger); ok {
+		cp := *al
+		cp.ExtraCalldepth++
+		l = &cp
+	}
+	return &requestLogger{Logger: l, prefix: fmt.Sprintf("[req %s] ", id)}
+}
+
+// requestLogger implements Logger prefixing records with the request correlation ID.
+type requestLogger struct {
+	Logger
+	prefix string
+}
+
+// Fatal logs fatal error without formatting.
+func (rl *requestLogger) Fatal(args ...interface{}) {
+	rl.Logger.Fatal(append([]interface{}{rl.prefix}, args...)...)
+}
+
+// Fatalf logs fatal error with formatting.
+func (rl *requestLogger) Fatalf(format string, args ...interface{}) {
+	rl.Logger.Fatalf(rl.prefix+format, args...)
+}
+
+// Panic logs critical panic error without formatting.
+func (rl *requestLogger) Panic(args ...interface{}) {
+	rl.Logger.Panic(append([]interface{}{rl.prefix}, args...)...)
+}
+
+// Panicf logs critical panic error with formatting.
+func (rl *requestLogger) Panicf(format string, args ...interface{}) {
+	rl.Logger.Panicf(rl.prefix+format, args...)
+}
+
+// Critical logs critical error without formatting.
+func (rl *requestLogger) Critical(args ...interface{}) {
+	rl.Logger.Critical(append([]interface{}{rl.prefix}, args...)...)
+}
+
+// Criticalf logs critical error with formatting.
+func (rl *requestLogger) Criticalf(format string, args ...interface{}) {
+	rl.Logger.Criticalf(rl.prefix+format, args...)
+}
+
+// Error logs regular error without formatting.
+func (rl *requestLogger) Error(args ...interface{}) {
+	rl.Logger.Error(append([]interface{}{rl.prefix}, args...)...)
+}
+
+// Errorf logs regular error with formatting.
+func (rl *requestLogger) Errorf(format string, args ...interface{}) {
+	rl.Logger.Errorf(rl.prefix+format, args...)
+}
+
+// Warning logs suspicious state situation without formatting.
+func (rl *requestLogger) Warning(args ...interface{}) {
+	rl.Logger.Warning(append([]interface{}{rl.prefix}, args...)...)
+}
+
+// Warningf logs suspicious state situation with formatting.
+func (rl *requestLogger) Warningf(format string, args ...interface{}) {
+	rl.Logger.Warningf(rl.prefix+format, args...)
+}
+
+// Notice logs significant state change without formatting.
+func (rl *requestLogger) Notice(args ...interface{}) {
+	rl.Logger.Notice(append([]interface{}{rl.prefix}, args...)...)
+}
+
+// Noticef logs significant state change with formatting.
+func (rl *requestLogger) Noticef(format string, args ...interface{}) {
+	rl.Logger.Noticef(rl.prefix+format, args...)
+}
+
+// Info logs common and regular state change without formatting.
+func (rl *requestLogger) Info(args ...interface{}) {
+	rl.Logger.Info(append([]interface{}{rl.prefix}, args...)...)
+}
+
+// Infof logs common and regular state change with formatting.
+func (rl *requestLogger) Infof(format string, args ...interface{}) {
+	rl.Logger.Infof(rl.prefix+format, args...)
+}
+
+// Debug logs regular and detailed state change without formatting.
+func (rl *requestLogger) Debug(args ...interface{}) {
+	rl.Logger.Debug(append([]interface{}{rl.prefix}, args...)...)
+}
+
+// Debugf logs regular and detailed state change with formatting.
+func (rl *requestLogger) Debugf(format string, args ...interface{}) {
+	rl.Logger.Debugf(rl.prefix+format, args...)
+}
+
+// Printf logs regular and detailed state change with formatting.
+func (rl *requestLogger) Printf(format string, args ...interface{}) {
+	rl.Logger.Printf(rl.prefix+format, args...)
+}
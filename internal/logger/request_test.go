@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"github.com/op/go-logging"
+	"strings"
+	"testing"
+)
+
+// TestWithContext tests records are tagged with the request correlation ID keeping the real caller.
+func TestWithContext(t *testing.T) {
+	var buf bytes.Buffer
+	backend := logging.AddModuleLevel(logging.NewBackendFormatter(logging.NewLogBackend(&buf, "", 0),
+		logging.MustStringFormatter("%{shortfunc}: %{message}")))
+	backend.SetLevel(logging.DEBUG, "")
+
+	l := logging.MustGetLogger("test")
+	l.SetBackend(backend)
+	al := &ApiLogger{*l}
+
+	if WithContext(context.Background(), al) != Logger(al) {
+		t.Errorf("expected the logger kept without request id")
+	}
+
+	WithContext(WithRequestID(context.Background(), "r1"), al).Errorf("failed %d", 1)
+	if got := strings.TrimSpace(buf.String()); got != "TestWithContext: [req r1] failed 1" {
+		t.Errorf("unexpected record %q", got)
+	}
+}
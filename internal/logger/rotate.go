@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// rotatingFile implements a log file output rotated by size.
+// The current file is renamed to <path>.1 once it reaches the max size,
+// older files are shifted up to <path>.<maxFiles>, the oldest one is removed.
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// newRotatingFile opens the log file for appending and prepares the rotation.
+func newRotatingFile(path string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	rf := rotatingFile{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return &rf, nil
+}
+
+// Write writes the record into the log file, rotating the file first
+// if the record would not fit into the max size.
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Close closes the current log file.
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.file.Close()
+}
+
+// open opens the current log file and picks up its size.
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	st, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	rf.file = f
+	rf.size = st.Size()
+	return nil
+}
+
+// rotate shifts the retained files and starts a new log file.
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return err
+	}
+
+	// no files retained; just start over
+	if rf.maxFiles <= 0 {
+		if err := os.Remove(rf.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return rf.open()
+	}
+
+	// drop the oldest file and shift the rest
+	if err := os.Remove(rf.rotatedPath(rf.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := rf.maxFiles - 1; i > 0; i-- {
+		if err := os.Rename(rf.rotatedPath(i), rf.rotatedPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(rf.path, rf.rotatedPath(1)); err != nil {
+		return err
+	}
+	return rf.open()
+}
+
+// rotatedPath provides the path of the rotated file of the given index.
+func (rf *rotatingFile) rotatedPath(i int) string {
+	return fmt.Sprintf("%s.%d", rf.path, i)
+}
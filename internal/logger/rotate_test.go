@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRotatingFile tests the log file is rotated once it reaches the configured size.
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "api.log")
+	rf, err := newRotatingFile(path, 100, 2)
+	if err != nil {
+		t.Fatalf("can not open log file; %s", err.Error())
+	}
+	defer rf.Close()
+
+	record := bytes.Repeat([]byte("x"), 39)
+	record = append(record, '\n')
+
+	// two records fit, the third one triggers the rotation
+	for i := 0; i < 2; i++ {
+		if _, err := rf.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("log file rotated before reaching the max size")
+	}
+
+	if _, err := rf.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	assertFileSize(t, path+".1", 80)
+	assertFileSize(t, path, 40)
+
+	// rotate over the retained files count; the oldest file is dropped
+	for i := 0; i < 4; i++ {
+		if _, err := rf.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertFileSize(t, path, 40)
+	assertFileSize(t, path+".1", 80)
+	assertFileSize(t, path+".2", 80)
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected only 2 rotated files to be retained")
+	}
+}
+
+// assertFileSize checks the file exists and has the expected size.
+func assertFileSize(t *testing.T, path string, size int64) {
+	t.Helper()
+
+	st, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("log file %s not found; %s", path, err.Error())
+	}
+	if st.Size() != size {
+		t.Errorf("expected %s of %d bytes, got %d", path, size, st.Size())
+	}
+}
@@ -0,0 +1,278 @@
+// Package metrics implements collection of the API server performance metrics
+// and their exposition in the Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// namespace is the prefix of names of all the metrics of the API server.
+const namespace = "motif_"
+
+// DefaultBuckets are the upper bounds of latency histogram buckets in seconds.
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Default is the registry of the API server metrics exposed by the metrics endpoint.
+var Default = NewRegistry()
+
+// metrics of the API server
+var (
+	// RpcCallDuration tracks latency of the node RPC calls by the method.
+	RpcCallDuration = Default.NewHistogramVec("rpc_call_duration_seconds", "Latency of node RPC calls.", "method", DefaultBuckets)
+
+	// ResolverDuration tracks latency of the GraphQL resolvers by the field.
+	ResolverDuration = Default.NewHistogramVec("resolver_duration_seconds", "Latency of GraphQL field resolvers.", "field", DefaultBuckets)
+
+	// DbCommandDuration tracks latency of the database commands by the command name.
+	DbCommandDuration = Default.NewHistogramVec("db_command_duration_seconds", "Latency of database commands.", "command", DefaultBuckets)
+
+	// CacheRequests counts the in-memory cache lookups by the result, hit or miss.
+	CacheRequests = Default.NewCounterVec("cache_requests_total", "In-memory cache lookups.", "result")
+
+	// InFlightRequests tracks the number of requests counted by the load shedding.
+	InFlightRequests = Default.NewGauge("in_flight_requests", "Requests being served, as counted by the load shedding.")
+
+	// LoadShedding signals new requests are being rejected by the load shedding, 1 if so, 0 otherwise.
+	LoadShedding = Default.NewGauge("load_shedding", "Load shedding of new requests active.")
+)
+
+// collector represents a metric written into the exposition.
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+// Registry represents a set of metrics exposed together.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+// NewRegistry creates a new empty metrics registry.
+func NewRegistry() *Registry {
+	return &Registry{collectors: make([]collector, 0)}
+}
+
+// register adds the collector into the registry.
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// Write writes all the metrics of the registry in the Prometheus text format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	list := make([]collector, len(r.collectors))
+	copy(list, r.collectors)
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range list {
+		c.write(bw)
+	}
+	return bw.Flush()
+}
+
+// Handler provides HTTP handler exposing the metrics of the registry.
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.Write(w)
+	})
+}
+
+// CounterVec represents a set of counters partitioned by a label.
+type CounterVec struct {
+	name   string
+	help   string
+	label  string
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounterVec creates and registers a new counter partitioned by the given label.
+func (r *Registry) NewCounterVec(name, help, label string) *CounterVec {
+	c := CounterVec{name: namespace + name, help: help, label: label, values: make(map[string]float64)}
+	r.register(&c)
+	return &c
+}
+
+// Inc increments the counter of the given label value.
+func (c *CounterVec) Inc(value string) {
+	c.Add(value, 1)
+}
+
+// Add adds the given non-negative amount to the counter of the given label value.
+func (c *CounterVec) Add(value string, v float64) {
+	if v < 0 {
+		return
+	}
+	c.mu.Lock()
+	c.values[value] += v
+	c.mu.Unlock()
+}
+
+// write writes the counters in the Prometheus text format.
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	for _, lv := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s{%s} %s\n", c.name, labelPair(c.label, lv), formatFloat(c.values[lv]))
+	}
+}
+
+// Gauge represents a single value which can go up and down.
+type Gauge struct {
+	name  string
+	help  string
+	mu    sync.Mutex
+	value float64
+}
+
+// NewGauge creates and registers a new gauge.
+func (r *Registry) NewGauge(name, help string) *Gauge {
+	g := Gauge{name: namespace + name, help: help}
+	r.register(&g)
+	return &g
+}
+
+// Set sets the gauge to the given value.
+func (g *Gauge) Set(v float64) {
+	g.mu.Lock()
+	g.value = v
+	g.mu.Unlock()
+}
+
+// Add adds the given amount to the gauge; the amount may be negative.
+func (g *Gauge) Add(v float64) {
+	g.mu.Lock()
+	g.value += v
+	g.mu.Unlock()
+}
+
+// Value provides the current value of the gauge.
+func (g *Gauge) Value() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value
+}
+
+// write writes the gauge in the Prometheus text format.
+func (g *Gauge) write(w *bufio.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	writeHeader(w, g.name, g.help, "gauge")
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.value))
+}
+
+// histogram represents a single series of a histogram.
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// HistogramVec represents a set of histograms partitioned by a label.
+type HistogramVec struct {
+	name    string
+	help    string
+	label   string
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogram
+}
+
+// NewHistogramVec creates and registers a new histogram with the given buckets partitioned by the given label.
+func (r *Registry) NewHistogramVec(name, help, label string, buckets []float64) *HistogramVec {
+	h := HistogramVec{name: namespace + name, help: help, label: label, buckets: buckets, series: make(map[string]*histogram)}
+	r.register(&h)
+	return &h
+}
+
+// Observe adds the given value to the histogram of the given label value.
+func (h *HistogramVec) Observe(value string, v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[value]
+	if !ok {
+		s = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.series[value] = s
+	}
+	for i, le := range h.buckets {
+		if v <= le {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+// ObserveSince adds the time elapsed since the given start in seconds
+// to the histogram of the given label value.
+func (h *HistogramVec) ObserveSince(value string, start time.Time) {
+	h.Observe(value, time.Since(start).Seconds())
+}
+
+// write writes the histograms in the Prometheus text format.
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, lv := range keys {
+		s := h.series[lv]
+		lp := labelPair(h.label, lv)
+		for i, le := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", h.name, lp, formatFloat(le), s.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", h.name, lp, s.count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", h.name, lp, formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", h.name, lp, s.count)
+	}
+}
+
+// writeHeader writes the help and the type line of a metric.
+func writeHeader(w *bufio.Writer, name, help, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// labelPair formats the label with the escaped value.
+func labelPair(name, value string) string {
+	return name + "=\"" + labelEscaper.Replace(value) + "\""
+}
+
+// labelEscaper escapes label values of the text format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatFloat formats the sample value of the text format.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// sortedKeys provides the sorted keys of the given map.
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
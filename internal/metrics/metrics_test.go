@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRegistryWrite tests the metrics are written in the Prometheus text format.
+func TestRegistryWrite(t *testing.T) {
+	r := NewRegistry()
+	hv := r.NewHistogramVec("test_duration_seconds", "Test latency.", "method", []float64{0.1, 1})
+	cv := r.NewCounterVec("test_total", "Test counter.", "result")
+	g := r.NewGauge("test_in_flight", "Test gauge.")
+
+	hv.Observe("ftm_getBalance", 0.05)
+	hv.Observe("ftm_getBalance", 0.5)
+	hv.Observe("ftm_getBalance", 2)
+	cv.Inc("hit")
+	cv.Add("miss", 2)
+	cv.Add("miss", -1)
+	cv.Inc(`a"b`)
+	g.Add(3)
+	g.Add(-1)
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatalf("can not write metrics; %s", err.Error())
+	}
+
+	want := []string{
+		"# TYPE motif_test_duration_seconds histogram",
+		`motif_test_duration_seconds_bucket{method="ftm_getBalance",le="0.1"} 1`,
+		`motif_test_duration_seconds_bucket{method="ftm_getBalance",le="1"} 2`,
+		`motif_test_duration_seconds_bucket{method="ftm_getBalance",le="+Inf"} 3`,
+		`motif_test_duration_seconds_sum{method="ftm_getBalance"} 2.55`,
+		`motif_test_duration_seconds_count{method="ftm_getBalance"} 3`,
+		"# TYPE motif_test_total counter",
+		`motif_test_total{result="hit"} 1`,
+		`motif_test_total{result="miss"} 2`,
+		`motif_test_total{result="a\"b"} 1`,
+		"# TYPE motif_test_in_flight gauge",
+		"motif_test_in_flight 2",
+	}
+	out := buf.String()
+	for _, w := range want {
+		if !strings.Contains(out, w+"\n") {
+			t.Errorf("missing %q in\n%s", w, out)
+		}
+	}
+}
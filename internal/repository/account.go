@@ -10,6 +10,7 @@ package repository
 
 import (
 	"motif-api/internal/types"
+	"context"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -71,6 +72,29 @@ func (p *proxy) AccountBalance(addr *common.Address) (*hexutil.Big, error) {
 	return p.rpc.AccountBalance(addr)
 }
 
+// AccountBalanceAt returns the balance of an account at the given block of Opera blockchain.
+// The current balance is provided if the block is not specified.
+func (p *proxy) AccountBalanceAt(addr *common.Address, block *hexutil.Uint64) (*hexutil.Big, error) {
+	return p.rpc.AccountBalanceAt(addr, block)
+}
+
+// AccountBalanceAtContext returns the balance of an account as AccountBalanceAt does
+// within the request context; failures are logged with the request correlation ID.
+func (p *proxy) AccountBalanceAtContext(ctx context.Context, addr *common.Address, block *hexutil.Uint64) (*hexutil.Big, error) {
+	return p.rpc.AccountBalanceAtContext(ctx, addr, block)
+}
+
+// AccountBalances returns the current balances of the given accounts loaded concurrently.
+// Balances failed to load are reported by the errors map instead.
+func (p *proxy) AccountBalances(addrs []common.Address) (map[common.Address]*hexutil.Big, map[common.Address]error, error) {
+	if len(addrs) > types.AccountBalancesMaxAddresses {
+		return nil, nil, fmt.Errorf("too many addresses requested, max %d addresses allowed", types.AccountBalancesMaxAddresses)
+	}
+
+	bal, failed := p.rpc.AccountBalances(addrs)
+	return bal, failed, nil
+}
+
 // AccountNonce returns the current number of sent transactions of an account at Opera blockchain.
 func (p *proxy) AccountNonce(addr *common.Address) (*hexutil.Uint64, error) {
 	val, err := p.rpc.AccountNonce(addr)
@@ -83,15 +107,34 @@ func (p *proxy) AccountNonce(addr *common.Address) (*hexutil.Uint64, error) {
 	return &nonce, nil
 }
 
+// AccountPendingNonce returns the number of sent transactions of an account at Opera blockchain
+// including the transactions pending in the transaction pool of the node.
+func (p *proxy) AccountPendingNonce(addr *common.Address) (*hexutil.Uint64, error) {
+	val, err := p.rpc.AccountPendingNonce(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// make the value and return
+	nonce := hexutil.Uint64(val)
+	return &nonce, nil
+}
+
 // AccountTransactions returns slice of AccountTransaction structure for a given account at Opera blockchain.
-func (p *proxy) AccountTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+// The total is not calculated if skipTotal is set.
+func (p *proxy) AccountTransactions(addr *common.Address, cursor *string, count int32, skipTotal bool) (*types.TransactionList, error) {
 	// do we have an account?
 	if addr == nil {
 		return nil, fmt.Errorf("can not get transaction list for empty account")
 	}
 
 	// go to the database for the list of hashes of transaction searched
-	return p.db.AccountTransactions(addr, cursor, count)
+	return p.db.AccountTransactions(addr, cursor, count, skipTotal)
+}
+
+// AccountFailedTransactions returns list of outbound transactions of the given account which failed.
+func (p *proxy) AccountFailedTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+	return p.db.AccountFailedTransactions(addr, cursor, count)
 }
 
 // AccountsActive returns total number of accounts known to repository.
@@ -136,3 +179,15 @@ func (p *proxy) StoreAccount(acc *types.Account) error {
 func (p *proxy) AccountMarkActivity(addr *common.Address, ts uint64) error {
 	return p.db.AccountMarkActivity(addr, ts)
 }
+
+// AccountOverviews provides the live overviews of the given accounts loaded
+// from the node in a single batch. Each overview degrades independently on failure.
+func (p *proxy) AccountOverviews(addrs []common.Address) ([]*types.AccountOverview, error) {
+	if len(addrs) > types.AccountOverviewsMaxAddresses {
+		return nil, fmt.Errorf("too many addresses requested, max %d addresses allowed", types.AccountOverviewsMaxAddresses)
+	}
+	if len(addrs) == 0 {
+		return []*types.AccountOverview{}, nil
+	}
+	return p.rpc.AccountOverviews(addrs), nil
+}
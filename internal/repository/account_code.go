@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountCode provides the code deployed at the given address; the code is empty
+// for externally owned accounts.
+func (p *proxy) AccountCode(addr *common.Address) ([]byte, error) {
+	if code, ok := p.cache.PullAccountCode(addr); ok {
+		return code, nil
+	}
+
+	code, err := p.rpc.Code(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.cache.PushAccountCode(addr, code); err != nil {
+		p.log.Warningf("can not cache code of %s; %s", addr.String(), err.Error())
+	}
+	return code, nil
+}
+
+// AccountStorageAt provides the raw value of the given storage slot of the given account.
+func (p *proxy) AccountStorageAt(addr *common.Address, slot common.Hash) (common.Hash, error) {
+	return p.rpc.StorageAt(addr, slot)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testAccountCall provides a mock node resolver of the account state calls;
+// the handler receives the method, the account address and the block tag.
+func testAccountCall(handler func(method string, addr common.Address, tag string) (string, error)) testCall {
+	return func(method string, params []json.RawMessage) (interface{}, error) {
+		var addr common.Address
+		var tag string
+		if len(params) != 2 || json.Unmarshal(params[0], &addr) != nil || json.Unmarshal(params[1], &tag) != nil {
+			return nil, &testRpcError{Code: -32602, Message: "invalid params"}
+		}
+
+		out, err := handler(method, addr, tag)
+		if err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+// TestAccountBalanceAt tests the balance is read at the requested block.
+func TestAccountBalanceAt(t *testing.T) {
+	p := testErc20Proxy(t, newTestNode(t, testAccountCall(func(method string, _ common.Address, tag string) (string, error) {
+		if method != "ftm_getBalance" {
+			return "", errors.New("method not found")
+		}
+		switch tag {
+		case "latest":
+			return "0x64", nil
+		case "0x1":
+			return "", errors.New("missing trie node 1f6b (path )")
+		}
+		return "0x2a", nil
+	})), true)
+
+	addr := common.HexToAddress("0x01")
+	bal, err := p.AccountBalance(&addr)
+	if err != nil || bal.ToInt().Int64() != 100 {
+		t.Fatalf("unexpected latest balance %v; %v", bal, err)
+	}
+
+	blk := hexutil.Uint64(0x10)
+	bal, err = p.AccountBalanceAt(&addr, &blk)
+	if err != nil || bal.ToInt().Int64() != 42 {
+		t.Fatalf("unexpected balance at block %v; %v", bal, err)
+	}
+
+	pruned := hexutil.Uint64(1)
+	if _, err = p.AccountBalanceAt(&addr, &pruned); err == nil || !strings.Contains(err.Error(), "not available") {
+		t.Errorf("expected descriptive pruned state error, got %v", err)
+	}
+}
+
+// TestAccountBalances tests the balances of accounts are loaded concurrently
+// and the failures are reported per address.
+func TestAccountBalances(t *testing.T) {
+	broken := common.HexToAddress("0x02")
+	p := testErc20Proxy(t, newTestNode(t, testAccountCall(func(_ string, addr common.Address, _ string) (string, error) {
+		if addr == broken {
+			return "", errors.New("account state unavailable")
+		}
+		return hexutil.EncodeBig(addr.Hash().Big()), nil
+	})), true)
+
+	addrs := make([]common.Address, 0, 11)
+	for i := int64(1); i <= 10; i++ {
+		addrs = append(addrs, common.BigToAddress(big.NewInt(i)))
+	}
+	addrs = append(addrs, addrs[0])
+
+	bal, failed, err := p.AccountBalances(addrs)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	if len(bal) != 9 || len(failed) != 1 || failed[broken] == nil {
+		t.Fatalf("expected 9 balances and 1 failure, got %d and %d", len(bal), len(failed))
+	}
+	for adr, val := range bal {
+		if val.ToInt().Cmp(adr.Hash().Big()) != 0 {
+			t.Errorf("unexpected balance %s of %s", val.String(), adr.String())
+		}
+	}
+
+	if _, _, err := p.AccountBalances(make([]common.Address, 101)); err == nil {
+		t.Errorf("expected error on too many addresses")
+	}
+}
+
+// TestAccountPendingNonce tests the pending nonce is read from the pending block.
+func TestAccountPendingNonce(t *testing.T) {
+	p := testErc20Proxy(t, newTestNode(t, testAccountCall(func(method string, _ common.Address, tag string) (string, error) {
+		if method != "ftm_getTransactionCount" {
+			return "", errors.New("method not found")
+		}
+		if tag == "pending" {
+			return "0x7", nil
+		}
+		return "0x5", nil
+	})), true)
+
+	addr := common.HexToAddress("0x01")
+	nonce, err := p.AccountNonce(&addr)
+	if err != nil || *nonce != 5 {
+		t.Fatalf("unexpected nonce %v; %v", nonce, err)
+	}
+
+	pending, err := p.AccountPendingNonce(&addr)
+	if err != nil || *pending != 7 {
+		t.Fatalf("unexpected pending nonce %v; %v", pending, err)
+	}
+}
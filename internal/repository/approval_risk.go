@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"sort"
+)
+
+// approvalUnlimitedBits represents the bit length of an allowance we consider unlimited
+// even if it does not reach the total supply of the token; wallets usually approve 2^256-1.
+const approvalUnlimitedBits = 128
+
+// approvalSpender represents the risk profile of an approved spender.
+type approvalSpender struct {
+	verified bool
+	flagged  bool
+}
+
+// ApprovalRisk provides a heuristic risk score of the active ERC20 approvals granted
+// by the given owner. Unlimited approvals to unverified, or flagged spenders score the highest.
+func (p *proxy) ApprovalRisk(owner *common.Address) (*types.ApprovalRisk, error) {
+	list, err := p.db.Erc20Approvals(owner, types.ApprovalRiskMaxApprovals)
+	if err != nil {
+		return nil, err
+	}
+
+	// load the on-chain state of all the approvals at once
+	states, err := p.rpc.Erc20ApprovalStates(owner, list)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]types.ApprovalRiskItem, 0, len(list))
+	spenders := make(map[common.Address]approvalSpender)
+	for i, ap := range list {
+		item, err := p.approvalRiskItem(&ap, &states[i], spenders)
+		if err != nil {
+			p.log.Debugf("approval of %s to %s by %s not evaluated; %s", ap.Token.String(), ap.Spender.String(), owner.String(), err.Error())
+			continue
+		}
+
+		// spent, or revoked approvals are harmless
+		if item != nil {
+			items = append(items, *item)
+		}
+	}
+	return approvalRisk(items, &p.cfg.ApprovalRisk), nil
+}
+
+// approvalRiskItem evaluates a single approval of the given on-chain state.
+// It returns nil if the approval is not active anymore.
+func (p *proxy) approvalRiskItem(ap *types.Erc20Approval, st *types.Erc20ApprovalState, spenders map[common.Address]approvalSpender) (*types.ApprovalRiskItem, error) {
+	if st.Error != nil {
+		return nil, st.Error
+	}
+	if st.Allowance.ToInt().Sign() <= 0 {
+		return nil, nil
+	}
+
+	// profile the spender; each spender is profiled once
+	sp, ok := spenders[ap.Spender]
+	if !ok {
+		sp = p.approvalSpenderProfile(&ap.Spender)
+		spenders[ap.Spender] = sp
+	}
+
+	item := types.ApprovalRiskItem{
+		Token:       ap.Token,
+		Spender:     ap.Spender,
+		Allowance:   st.Allowance,
+		IsUnlimited: isUnlimitedAllowance(st.Allowance.ToInt(), st.Supply.ToInt()),
+		IsVerified:  sp.verified,
+		IsFlagged:   sp.flagged,
+	}
+	item.Exposure = approvalExposure(st.Allowance.ToInt(), st.Balance.ToInt(), item.IsUnlimited)
+	item.Score = approvalRiskScore(&item, &p.cfg.ApprovalRisk)
+	return &item, nil
+}
+
+// approvalSpenderProfile checks if the spender is a contract with verified source code,
+// and if it is a token flagged by the token risk heuristics.
+func (p *proxy) approvalSpenderProfile(addr *common.Address) approvalSpender {
+	var sp approvalSpender
+
+	ct, err := p.ContractType(addr)
+	if err != nil {
+		p.log.Debugf("can not classify spender %s; %s", addr.String(), err.Error())
+		return sp
+	}
+	if ct.Type == types.ContractTypeEOA {
+		return sp
+	}
+
+	// verified contract source?
+	sc, err := p.Contract(addr)
+	if err == nil && sc != nil && sc.Validated != nil {
+		sp.verified = true
+	}
+
+	// spenders posing as tokens are checked by the token risk heuristics
+	if ct.Type == types.ContractTypeErc20 || ct.ImplementationType == types.ContractTypeErc20 {
+		flags, err := p.Erc20RiskFlags(addr)
+		if err == nil {
+			sp.flagged = flags.MimicsKnownToken || flags.MassAirdrop
+		}
+	}
+	return sp
+}
+
+// isUnlimitedAllowance checks if the allowance is effectively unlimited,
+// e.g. it covers the whole supply of the token.
+func isUnlimitedAllowance(allowance *big.Int, supply *big.Int) bool {
+	if allowance.BitLen() >= approvalUnlimitedBits {
+		return true
+	}
+	return supply.Sign() > 0 && allowance.Cmp(supply) >= 0
+}
+
+// approvalExposure calculates the share of the owner balance covered by the allowance.
+func approvalExposure(allowance *big.Int, balance *big.Int, unlimited bool) float64 {
+	if unlimited || (balance.Sign() > 0 && allowance.Cmp(balance) >= 0) {
+		return 1
+	}
+	if balance.Sign() <= 0 {
+		return 0
+	}
+
+	share, _ := new(big.Float).Quo(new(big.Float).SetInt(allowance), new(big.Float).SetInt(balance)).Float64()
+	return share
+}
+
+// approvalRiskScore calculates the score of a single approval using the configured weights.
+func approvalRiskScore(item *types.ApprovalRiskItem, cfg *config.ApprovalRisk) float64 {
+	score := cfg.Approval + cfg.Exposure*item.Exposure
+	if item.IsUnlimited {
+		score += cfg.Unlimited
+	}
+
+	// risky spenders multiply the score
+	if !item.IsVerified {
+		score *= cfg.Unverified
+	}
+	if item.IsFlagged {
+		score *= cfg.Flagged
+	}
+	return score
+}
+
+// approvalRisk sums the approval scores, labels the total and orders the breakdown by the score.
+func approvalRisk(items []types.ApprovalRiskItem, cfg *config.ApprovalRisk) *types.ApprovalRisk {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Score > items[j].Score
+	})
+
+	res := types.ApprovalRisk{Level: types.ApprovalRiskLow, Approvals: items}
+	for _, item := range items {
+		res.Score += item.Score
+	}
+
+	switch {
+	case res.Score >= cfg.High:
+		res.Level = types.ApprovalRiskHigh
+	case res.Score >= cfg.Medium:
+		res.Level = types.ApprovalRiskMedium
+	}
+	return &res
+}
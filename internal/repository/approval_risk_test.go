@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testApprovalRiskCfg is the approval risk score configuration used by the tests.
+var testApprovalRiskCfg = config.ApprovalRisk{
+	Approval:   1,
+	Exposure:   2,
+	Unlimited:  5,
+	Unverified: 2,
+	Flagged:    4,
+	Medium:     5,
+	High:       15,
+}
+
+// testApprovalItem builds an approval risk item for the given allowance, balance and supply.
+func testApprovalItem(allowance, balance, supply *big.Int, verified bool, flagged bool) types.ApprovalRiskItem {
+	item := types.ApprovalRiskItem{
+		Allowance:   hexutil.Big(*allowance),
+		IsUnlimited: isUnlimitedAllowance(allowance, supply),
+		IsVerified:  verified,
+		IsFlagged:   flagged,
+	}
+	item.Exposure = approvalExposure(allowance, balance, item.IsUnlimited)
+	item.Score = approvalRiskScore(&item, &testApprovalRiskCfg)
+	return item
+}
+
+// TestIsUnlimitedAllowance tests detection of effectively unlimited allowances.
+func TestIsUnlimitedAllowance(t *testing.T) {
+	maxUint := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	tests := []struct {
+		name      string
+		allowance *big.Int
+		supply    *big.Int
+		want      bool
+	}{
+		{"max uint256", maxUint, big.NewInt(1000), true},
+		{"whole supply", big.NewInt(1000), big.NewInt(1000), true},
+		{"part of supply", big.NewInt(999), big.NewInt(1000), false},
+		{"unknown supply", big.NewInt(999), new(big.Int), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUnlimitedAllowance(tt.allowance, tt.supply); got != tt.want {
+				t.Errorf("isUnlimitedAllowance() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApprovalExposure tests the share of the balance covered by an allowance.
+func TestApprovalExposure(t *testing.T) {
+	if got := approvalExposure(big.NewInt(25), big.NewInt(100), false); got != 0.25 {
+		t.Errorf("expected quarter exposure, got %f", got)
+	}
+	if got := approvalExposure(big.NewInt(250), big.NewInt(100), false); got != 1 {
+		t.Errorf("expected full exposure, got %f", got)
+	}
+	if got := approvalExposure(big.NewInt(25), new(big.Int), false); got != 0 {
+		t.Errorf("expected no exposure on empty balance, got %f", got)
+	}
+}
+
+// TestApprovalRiskUnlimitedUnverified tests an unlimited approval to an unverified contract
+// scores higher than bounded approvals to verified contracts.
+func TestApprovalRiskUnlimitedUnverified(t *testing.T) {
+	maxUint := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	supply, balance := big.NewInt(1000000), big.NewInt(1000)
+
+	risky := approvalRisk([]types.ApprovalRiskItem{
+		testApprovalItem(maxUint, balance, supply, false, false),
+	}, &testApprovalRiskCfg)
+
+	bounded := approvalRisk([]types.ApprovalRiskItem{
+		testApprovalItem(big.NewInt(100), balance, supply, true, false),
+		testApprovalItem(big.NewInt(500), balance, supply, true, false),
+	}, &testApprovalRiskCfg)
+
+	if risky.Score <= bounded.Score {
+		t.Errorf("unlimited unverified approval scored %f, not above bounded approvals %f", risky.Score, bounded.Score)
+	}
+	if risky.Level != types.ApprovalRiskMedium && risky.Level != types.ApprovalRiskHigh {
+		t.Errorf("unlimited unverified approval labeled %s", risky.Level)
+	}
+	if bounded.Level != types.ApprovalRiskLow {
+		t.Errorf("bounded approvals labeled %s", bounded.Level)
+	}
+
+	// flagged spender makes it even worse
+	flagged := approvalRisk([]types.ApprovalRiskItem{
+		testApprovalItem(maxUint, balance, supply, false, true),
+	}, &testApprovalRiskCfg)
+	if flagged.Score <= risky.Score || flagged.Level != types.ApprovalRiskHigh {
+		t.Errorf("flagged spender scored %f %s, not above %f", flagged.Score, flagged.Level, risky.Score)
+	}
+}
+
+// TestApprovalRiskOrder tests the breakdown is ordered by the approval score.
+func TestApprovalRiskOrder(t *testing.T) {
+	supply, balance := big.NewInt(1000000), big.NewInt(1000)
+	res := approvalRisk([]types.ApprovalRiskItem{
+		testApprovalItem(big.NewInt(100), balance, supply, true, false),
+		testApprovalItem(supply, balance, supply, false, false),
+		testApprovalItem(big.NewInt(500), balance, supply, true, false),
+	}, &testApprovalRiskCfg)
+
+	for i := 1; i < len(res.Approvals); i++ {
+		if res.Approvals[i-1].Score < res.Approvals[i].Score {
+			t.Fatalf("approvals not ordered by score at %d", i)
+		}
+	}
+	if !res.Approvals[0].IsUnlimited {
+		t.Errorf("expected the unlimited approval first")
+	}
+}
@@ -49,6 +49,21 @@ func (p *proxy) CacheBlock(blk *types.Block) {
 	p.cache.AddBlock(blk)
 }
 
+// UpdateChainMetrics updates the chain throughput metrics of the recent blocks window.
+func (p *proxy) UpdateChainMetrics(cm *types.ChainMetrics) {
+	p.chainMetrics.Store(cm)
+}
+
+// ChainMetrics provides the chain throughput metrics of the recent blocks window.
+// It returns nil if not enough blocks have been indexed yet.
+func (p *proxy) ChainMetrics() *types.ChainMetrics {
+	cm, ok := p.chainMetrics.Load().(*types.ChainMetrics)
+	if !ok {
+		return nil
+	}
+	return cm
+}
+
 // BlockByNumber returns a block at Opera blockchain represented by a number. Top block is returned if the number
 // is not provided.
 // If the block is not found, ErrBlockNotFound error is returned.
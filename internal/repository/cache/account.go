@@ -80,3 +80,8 @@ func (b *MemBridge) PushAccountKnown(addr *common.Address) {
 		b.log.Errorf("can not cache account %s existence; %s", addr.String(), err.Error())
 	}
 }
+
+// EvictAccount removes the account information from the in-memory cache.
+func (b *MemBridge) EvictAccount(addr *common.Address) {
+	b.cache.Delete(accountId(addr))
+}
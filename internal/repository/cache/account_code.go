@@ -0,0 +1,29 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// accountCodeCacheIdPrefix is the prefix of account code cache ids.
+const accountCodeCacheIdPrefix = "code_"
+
+// PullAccountCode extracts the code deployed at the given address from the in-memory cache if available.
+func (b *MemBridge) PullAccountCode(addr *common.Address) ([]byte, bool) {
+	data, err := b.cache.Get(accountCodeCacheIdPrefix + addr.String())
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil, false
+	}
+	return data, true
+}
+
+// PushAccountCode stores the code deployed at the given address in the in-memory cache.
+// The deployed code is immutable so the entry does not expire; an empty code of an account
+// expires as usual since a contract can still be deployed to the address.
+func (b *MemBridge) PushAccountCode(addr *common.Address, code []byte) error {
+	if len(code) == 0 {
+		return b.cache.Set(accountCodeCacheIdPrefix+addr.String(), code)
+	}
+	return b.cache.SetWithTTL(accountCodeCacheIdPrefix+addr.String(), code, NoExpiration)
+}
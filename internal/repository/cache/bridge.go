@@ -4,8 +4,10 @@ package cache
 import (
 	"motif-api/internal/config"
 	"motif-api/internal/logger"
+	"motif-api/internal/metrics"
 	"motif-api/internal/repository/cache/ring"
 	"github.com/allegro/bigcache"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,20 +19,31 @@ const TransactionRingCacheSize = 75
 // in fast in-memory ring cache for fast loading.
 const BlockRingCacheSize = 75
 
+// noLifeWindow represents the life window of the cache long enough to never expire an entry.
+const noLifeWindow = 100 * 365 * 24 * time.Hour
+
 // MemBridge represents BigCache abstraction layer.
 type MemBridge struct {
-	cache *bigcache.BigCache
+	cache meteredCache
 	log   logger.Logger
 
 	// ring of the most recent blocks and transactions
 	blkRing *ring.Ring
 	trxRing *ring.Ring
+
+	// TTL of the common entry types
+	balanceTTL time.Duration
+	priceTTL   time.Duration
+
+	// signal to terminate the expired entries scanner
+	done chan struct{}
 }
 
 // New creates a new BigCache bridge.
 func New(cfg *config.Config, log logger.Logger) (*MemBridge, error) {
 	// create the cache
-	c, err := bigcache.NewBigCache(cacheConfig(cfg, log))
+	cc := newCacheCounters()
+	c, err := bigcache.NewBigCache(cacheConfig(cfg, log, cc))
 	if err != nil {
 		log.Critical(err)
 		return nil, err
@@ -40,33 +53,78 @@ func New(cfg *config.Config, log logger.Logger) (*MemBridge, error) {
 	log.Notice("memory cache initialized")
 
 	// make a new Bridge
-	return &MemBridge{
-		cache: c,
+	b := MemBridge{
+		cache: meteredCache{BigCache: c, counters: cc, ttl: cfg.Cache.Eviction},
 		log:   log,
 
 		// make rings
 		blkRing: ring.New(BlockRingCacheSize),
 		trxRing: ring.New(TransactionRingCacheSize),
-	}, nil
+
+		balanceTTL: cfg.Cache.BalanceTTL,
+		priceTTL:   cfg.Cache.PriceTTL,
+		done:       make(chan struct{}),
+	}
+
+	// expired entries are removed by the bridge, not the cache itself
+	go b.scan(b.done)
+	return &b, nil
+}
+
+// Close terminates the expired entries scanner and releases the cache.
+func (b *MemBridge) Close() {
+	close(b.done)
+	if err := b.cache.BigCache.Close(); err != nil {
+		b.log.Errorf("can not close memory cache; %s", err.Error())
+	}
+}
+
+// meteredCache wraps the BigCache counting the lookups in the metrics and the usage counters.
+// Each entry carries its own expiration, entries stored without an explicit TTL
+// expire after the default one.
+type meteredCache struct {
+	*bigcache.BigCache
+	counters *cacheCounters
+	ttl      time.Duration
+}
+
+// Get reads the entry for the key counting the hit, or the miss.
+// Expired entries are removed and reported as not found.
+func (mc meteredCache) Get(key string) ([]byte, error) {
+	data, err := mc.BigCache.Get(key)
+	if err == nil {
+		data, err = mc.unwrap(key, data, time.Now())
+	}
+
+	if err != nil {
+		atomic.AddUint64(&mc.counters.misses, 1)
+		metrics.CacheRequests.Inc("miss")
+	} else {
+		atomic.AddUint64(&mc.counters.hits, 1)
+		metrics.CacheRequests.Inc("hit")
+	}
+	return data, err
 }
 
 // cacheConfig constructs a configuration structure for BigCache initialization.
-func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
+// The evictions are counted by the given usage counters.
+func cacheConfig(cfg *config.Config, log logger.Logger, cc *cacheCounters) bigcache.Config {
 	// log the info
-	log.Debugf("memory cache eviction set to %s", cfg.Cache.Eviction)
+	log.Debugf("memory cache eviction set to %s, balances %s, prices %s", cfg.Cache.Eviction, cfg.Cache.BalanceTTL, cfg.Cache.PriceTTL)
 
 	// return the cache config
 	return bigcache.Config{
 		// number of shards (must be a power of 2)
 		Shards: 2048,
 
-		// time after which entry can be evicted
-		LifeWindow: cfg.Cache.Eviction,
+		// time after which entry can be evicted; entries carry their own TTL
+		// so the cache must not drop them on its own
+		LifeWindow: noLifeWindow,
 
 		// Interval between removing expired entries (clean up).
 		// If set to <= 0 then no action is performed.
-		// Setting to < 1 second is counterproductive — big cache has a one second resolution.
-		CleanWindow: 5 * time.Minute,
+		// The expired entries are removed by the bridge scanner honoring their TTL.
+		CleanWindow: 0,
 
 		// rps * lifeWindow, used only in initial memory allocation
 		MaxEntriesInWindow: 1000 * 10 * 60,
@@ -88,7 +146,7 @@ func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
 		// for the new entry, or because delete was called. A constant representing the reason will be passed through.
 		// Default value is nil which means no callback and it prevents from unwrapping the oldest entry.
 		// Ignored if OnRemove is specified.
-		OnRemoveWithReason: nil,
+		OnRemoveWithReason: cc.onRemove,
 
 		// prints information about additional memory allocation
 		Verbose: true,
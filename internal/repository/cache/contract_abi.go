@@ -0,0 +1,26 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// contractAbiCacheIdPrefix is the prefix of remote contract ABI cache ids.
+const contractAbiCacheIdPrefix = "abi_"
+
+// PullContractAbi extracts the remote ABI of the contract from the in-memory cache if available.
+// The flag signals the cache knows the contract; an empty ABI means the remote source does not have it.
+func (b *MemBridge) PullContractAbi(addr *common.Address) (string, bool) {
+	data, err := b.cache.Get(contractAbiCacheIdPrefix + addr.String())
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return "", false
+	}
+	return string(data), true
+}
+
+// PushContractAbi stores the remote ABI of the contract in the in-memory cache.
+// Empty ABI is stored to remember the remote source does not have it.
+func (b *MemBridge) PushContractAbi(addr *common.Address, abi string) error {
+	return b.cache.Set(contractAbiCacheIdPrefix+addr.String(), []byte(abi))
+}
@@ -0,0 +1,51 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// contractTypeCacheIdPrefix is the prefix of account classification cache ids.
+const contractTypeCacheIdPrefix = "ctt_"
+
+// contractTypeProxyTTL is the TTL of proxy classifications; a proxy can be upgraded
+// to a different implementation at any time.
+const contractTypeProxyTTL = 5 * time.Minute
+
+// PullContractType extracts the account classification from the in-memory cache if available.
+func (b *MemBridge) PullContractType(addr *common.Address) *types.ContractType {
+	data, err := b.cache.Get(contractTypeCacheIdPrefix + addr.String())
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil
+	}
+
+	ct, err := types.UnmarshalContractType(data)
+	if err != nil {
+		b.log.Criticalf("can not decode contract type from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return ct
+}
+
+// PushContractType stores the account classification in the in-memory cache.
+// Proxy classifications expire sooner than the others.
+func (b *MemBridge) PushContractType(ct *types.ContractType) error {
+	if ct == nil {
+		return fmt.Errorf("invalid or nil contract type can not be pushed to the in-memory cache")
+	}
+
+	data, err := ct.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal contract type to JSON; %s", err.Error())
+		return err
+	}
+	ttl := b.cache.ttl
+	if ct.Type == types.ContractTypeProxy && (ttl == NoExpiration || ttl > contractTypeProxyTTL) {
+		ttl = contractTypeProxyTTL
+	}
+	return b.cache.SetWithTTL(contractTypeCacheIdPrefix+ct.Address.String(), data, ttl)
+}
@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestContractTypeTTL tests proxy classifications expire sooner than the others.
+func TestContractTypeTTL(t *testing.T) {
+	b := MemBridge{cache: testMeteredCache(t, time.Hour)}
+
+	proxy := common.HexToAddress("0xa1")
+	token := common.HexToAddress("0xa2")
+	_ = b.PushContractType(&types.ContractType{Address: proxy, Type: types.ContractTypeProxy, Implementation: &token})
+	_ = b.PushContractType(&types.ContractType{Address: token, Type: types.ContractTypeErc20})
+
+	if ct := b.PullContractType(&proxy); ct == nil || ct.Type != types.ContractTypeProxy || *ct.Implementation != token {
+		t.Fatalf("expected proxy classification cached, got %+v", ct)
+	}
+	if n := b.cache.evictExpired(time.Now().Add(contractTypeProxyTTL + time.Second)); n != 1 {
+		t.Errorf("expected 1 entry evicted, got %d", n)
+	}
+	if ct := b.PullContractType(&proxy); ct != nil {
+		t.Errorf("expected proxy classification expired, got %+v", ct)
+	}
+	if ct := b.PullContractType(&token); ct == nil || ct.Type != types.ContractTypeErc20 {
+		t.Errorf("expected token classification kept, got %+v", ct)
+	}
+}
@@ -55,3 +55,9 @@ func (b *MemBridge) PushDelegation(dlg *types.Delegation) {
 		b.log.Criticalf("can not cache delegation of %s to #%d; %s", dlg.Address.String(), dlg.ToStakerId.ToInt().Uint64(), err.Error())
 	}
 }
+
+// EvictDelegation removes the delegation from the given address to the given validator
+// from internal in-memory cache.
+func (b *MemBridge) EvictDelegation(adr common.Address, valID *hexutil.Big) {
+	b.cache.Delete(delegationCacheKey(adr, valID))
+}
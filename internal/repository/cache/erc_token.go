@@ -6,12 +6,16 @@ import (
 	"motif-api/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
 	"strings"
 )
 
 const (
 	Erc20CacheIdPrefix   = "erc20_"
 	Erc721CacheIdPrefix  = "erc721_"
+
+	erc20BalanceCacheIdPrefix = "erc20_bal_"
 )
 
 // ErcTokenId generates cache id for storing ERC token contract.
@@ -45,6 +49,7 @@ func (b *MemBridge) PullErc20Token(addr *common.Address) *types.Erc20Token {
 }
 
 // PushErc20Token stores provided ERC20 token in the in-memory cache.
+// The token details do not change so the entry does not expire.
 func (b *MemBridge) PushErc20Token(token *types.Erc20Token) error {
 	// we need valid account
 	if nil == token {
@@ -59,7 +64,41 @@ func (b *MemBridge) PushErc20Token(token *types.Erc20Token) error {
 	}
 
 	// set the data to cache
-	return b.cache.Set(ErcTokenId(&token.Address, Erc20CacheIdPrefix), data)
+	return b.cache.SetWithTTL(ErcTokenId(&token.Address, Erc20CacheIdPrefix), data, NoExpiration)
+}
+
+// erc20BalanceId generates cache id for storing ERC20 token balance of the owner.
+func erc20BalanceId(token *common.Address, owner *common.Address) string {
+	var sb strings.Builder
+
+	sb.WriteString(erc20BalanceCacheIdPrefix)
+	sb.WriteString(token.String())
+	sb.WriteString(owner.String())
+
+	return sb.String()
+}
+
+// PullErc20Balance extracts the ERC20 token balance of the owner from the in-memory cache if available.
+func (b *MemBridge) PullErc20Balance(token *common.Address, owner *common.Address) *hexutil.Big {
+	data, err := b.cache.Get(erc20BalanceId(token, owner))
+	if err != nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetBytes(data))
+}
+
+// PushErc20Balance stores the ERC20 token balance of the owner in the in-memory cache
+// for the configured balance lifetime.
+func (b *MemBridge) PushErc20Balance(token *common.Address, owner *common.Address, val *hexutil.Big) error {
+	if b.balanceTTL <= 0 {
+		return nil
+	}
+	return b.cache.SetWithTTL(erc20BalanceId(token, owner), val.ToInt().Bytes(), b.balanceTTL)
+}
+
+// EvictErc20Balance removes the ERC20 token balance of the owner from the in-memory cache.
+func (b *MemBridge) EvictErc20Balance(token *common.Address, owner *common.Address) {
+	b.cache.Delete(erc20BalanceId(token, owner))
 }
 
 // PullErc721Contract pulls ERC-721 token contract details from cache, if available.
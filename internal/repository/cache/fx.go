@@ -0,0 +1,47 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"time"
+)
+
+// fxRatesCacheId is the cache id of the remote exchange rates.
+const fxRatesCacheId = "fx_rates"
+
+// PullFxRates extracts the remote exchange rates from the in-memory cache
+// if available and not older than the given max age.
+func (b *MemBridge) PullFxRates(maxAge time.Duration) *types.FxRates {
+	data, err := b.cache.Get(fxRatesCacheId)
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil
+	}
+
+	fx, err := types.UnmarshalFxRates(data)
+	if err != nil {
+		b.log.Criticalf("can not decode exchange rates from in-memory cache; %s", err.Error())
+		return nil
+	}
+
+	// stale rates are refreshed
+	if time.Since(fx.Updated) > maxAge {
+		return nil
+	}
+	return fx
+}
+
+// PushFxRates stores the remote exchange rates in the in-memory cache.
+func (b *MemBridge) PushFxRates(fx *types.FxRates) error {
+	if fx == nil {
+		return fmt.Errorf("invalid or nil exchange rates can not be pushed to the in-memory cache")
+	}
+
+	data, err := fx.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal exchange rates to JSON; %s", err.Error())
+		return err
+	}
+	return b.cache.Set(fxRatesCacheId, data)
+}
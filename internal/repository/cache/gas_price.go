@@ -0,0 +1,35 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"encoding/binary"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// gasPriceCacheId is the cache id of the suggested gas price.
+const gasPriceCacheId = "gas_price"
+
+// PullGasPrice extracts the suggested gas price from the in-memory cache
+// if available and not older than the given max age.
+func (b *MemBridge) PullGasPrice(maxAge time.Duration) *hexutil.Big {
+	data, err := b.cache.Get(gasPriceCacheId)
+	if err != nil || len(data) < 8 {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil
+	}
+
+	// stale price is not used
+	if time.Since(time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))) > maxAge {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetBytes(data[8:]))
+}
+
+// PushGasPrice stores the suggested gas price in the in-memory cache.
+func (b *MemBridge) PushGasPrice(price *hexutil.Big) error {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(time.Now().UnixNano()))
+	return b.cache.Set(gasPriceCacheId, append(data, price.ToInt().Bytes()...))
+}
@@ -0,0 +1,66 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// nameAddressCacheIdPrefix is the prefix of the cache id of a name resolution.
+	nameAddressCacheIdPrefix = "name_addr_"
+
+	// addressNameCacheIdPrefix is the prefix of the cache id of a reverse name resolution.
+	addressNameCacheIdPrefix = "addr_name_"
+)
+
+// PullNameAddress extracts the address of the given name from the in-memory cache if available.
+// The known absence of the name is provided as a nil address with the found flag set.
+func (b *MemBridge) PullNameAddress(name string) (*common.Address, bool) {
+	data, err := b.cache.Get(nameAddressCacheIdPrefix + name)
+	if err != nil || len(data) == 0 {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil, false
+	}
+
+	// the first byte flags a registered name
+	if data[0] == 0 {
+		return nil, true
+	}
+	addr := common.BytesToAddress(data[1:])
+	return &addr, true
+}
+
+// PushNameAddress stores the address of the given name in the in-memory cache.
+// Nil address records the absence of the name.
+func (b *MemBridge) PushNameAddress(name string, addr *common.Address) error {
+	data := []byte{0}
+	if addr != nil {
+		data = append([]byte{1}, addr.Bytes()...)
+	}
+	return b.cache.Set(nameAddressCacheIdPrefix+name, data)
+}
+
+// PullAddressName extracts the name of the given address from the in-memory cache if available.
+// The known absence of the name is provided as a nil name with the found flag set.
+func (b *MemBridge) PullAddressName(addr *common.Address) (*string, bool) {
+	data, err := b.cache.Get(addressNameCacheIdPrefix + addr.String())
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+
+	if data[0] == 0 {
+		return nil, true
+	}
+	name := string(data[1:])
+	return &name, true
+}
+
+// PushAddressName stores the name of the given address in the in-memory cache.
+// Nil name records the absence of the name.
+func (b *MemBridge) PushAddressName(addr *common.Address, name *string) error {
+	data := []byte{0}
+	if name != nil {
+		data = append([]byte{1}, *name...)
+	}
+	return b.cache.Set(addressNameCacheIdPrefix+addr.String(), data)
+}
@@ -0,0 +1,53 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// portfolioCacheIdPrefix is the prefix of portfolio distribution cache ids.
+const portfolioCacheIdPrefix = "pfd_"
+
+// PullPortfolioDistribution extracts the portfolio distribution of the account from the in-memory cache
+// if available and not older than the given max age.
+func (b *MemBridge) PullPortfolioDistribution(addr *common.Address, maxAge time.Duration) *types.PortfolioDistribution {
+	data, err := b.cache.Get(portfolioCacheIdPrefix + addr.String())
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil
+	}
+
+	pd, err := types.UnmarshalPortfolioDistribution(data)
+	if err != nil {
+		b.log.Criticalf("can not decode portfolio distribution from in-memory cache; %s", err.Error())
+		return nil
+	}
+
+	// stale value is not used
+	if time.Since(pd.Computed) > maxAge {
+		return nil
+	}
+	return pd
+}
+
+// PushPortfolioDistribution stores the portfolio distribution of the account in the in-memory cache.
+func (b *MemBridge) PushPortfolioDistribution(addr *common.Address, pd *types.PortfolioDistribution) error {
+	if pd == nil {
+		return fmt.Errorf("invalid or nil portfolio distribution can not be pushed to the in-memory cache")
+	}
+
+	data, err := pd.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal portfolio distribution to JSON; %s", err.Error())
+		return err
+	}
+	return b.cache.Set(portfolioCacheIdPrefix+addr.String(), data)
+}
+
+// EvictPortfolioDistribution removes the portfolio distribution of the account from the in-memory cache.
+func (b *MemBridge) EvictPortfolioDistribution(addr *common.Address) {
+	b.cache.Delete(portfolioCacheIdPrefix + addr.String())
+}
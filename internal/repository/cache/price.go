@@ -44,7 +44,7 @@ func (b *MemBridge) PushPrice(sym string, pri *types.Price) error {
 	}
 
 	// set the data to cache by block number
-	return b.cache.Set(getPriceKeyBySymbol(sym), data)
+	return b.cache.SetWithTTL(getPriceKeyBySymbol(sym), data, b.priceTTL)
 }
 
 // getPriceKeyBySymbol build a cache key for the given price symbol.
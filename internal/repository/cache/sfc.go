@@ -3,6 +3,7 @@ package cache
 
 import (
 	"motif-api/internal/types"
+	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -14,6 +15,9 @@ const (
 	sfcMaxDelegatedRatioKey = "sfc_dlr"
 	sfcConfigurationKey     = "sfc_cfg"
 	sfcValidatorAddress     = "val_adr"
+	sfcValidatorCommission  = "sfc_vcm"
+	sfcUnlockedRewardRatio  = "sfc_urr"
+	sfcValidatorListKey     = "val_list"
 )
 
 // PullSfcMaxDelegatedRatio extract the ratio from cache, if possible.
@@ -36,6 +40,44 @@ func (b *MemBridge) PushSfcMaxDelegatedRatio(val *big.Int) {
 	}
 }
 
+// PullSfcValidatorCommission extract the validator commission from cache, if possible.
+func (b *MemBridge) PullSfcValidatorCommission() *big.Int {
+	data, err := b.cache.Get(sfcValidatorCommission)
+	if err != nil {
+		return nil
+	}
+	return new(big.Int).SetBytes(data)
+}
+
+// PushSfcValidatorCommission stores the validator commission in cache, if possible.
+func (b *MemBridge) PushSfcValidatorCommission(val *big.Int) {
+	if val == nil {
+		return
+	}
+	if err := b.cache.Set(sfcValidatorCommission, val.Bytes()); err != nil {
+		b.log.Errorf("can not store SFC validator commission value")
+	}
+}
+
+// PullSfcUnlockedRewardRatio extract the unlocked reward ratio from cache, if possible.
+func (b *MemBridge) PullSfcUnlockedRewardRatio() *big.Int {
+	data, err := b.cache.Get(sfcUnlockedRewardRatio)
+	if err != nil {
+		return nil
+	}
+	return new(big.Int).SetBytes(data)
+}
+
+// PushSfcUnlockedRewardRatio stores the unlocked reward ratio in cache, if possible.
+func (b *MemBridge) PushSfcUnlockedRewardRatio(val *big.Int) {
+	if val == nil {
+		return
+	}
+	if err := b.cache.Set(sfcUnlockedRewardRatio, val.Bytes()); err != nil {
+		b.log.Errorf("can not store SFC unlocked reward ratio value")
+	}
+}
+
 // PullSfcConfig extract the SFC configuration from cache, if possible.
 func (b *MemBridge) PullSfcConfig() *types.SfcConfig {
 	// try to get the account data from the cache
@@ -117,3 +159,38 @@ func (b *MemBridge) PullValidatorAddress(valID *hexutil.Big) *common.Address {
 	adr := common.BytesToAddress(data)
 	return &adr
 }
+
+// PullValidators tries to pull the list of validators from memory cache.
+func (b *MemBridge) PullValidators() []*types.Validator {
+	data, err := b.cache.Get(sfcValidatorListKey)
+	if err != nil {
+		return nil
+	}
+
+	// decode the list
+	var list []*types.Validator
+	if err := json.Unmarshal(data, &list); err != nil {
+		b.log.Errorf("can not decode validators list; %s", err.Error())
+		return nil
+	}
+	return list
+}
+
+// PushValidators stores the list of validators in the memory cache.
+func (b *MemBridge) PushValidators(list []*types.Validator) {
+	if list == nil {
+		return
+	}
+
+	// encode the list
+	data, err := json.Marshal(list)
+	if err != nil {
+		b.log.Errorf("can not encode validators list; %s", err.Error())
+		return
+	}
+
+	// store the data
+	if err := b.cache.Set(sfcValidatorListKey, data); err != nil {
+		b.log.Errorf("can not store validators list")
+	}
+}
@@ -0,0 +1,57 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"motif-api/internal/types"
+	"github.com/allegro/bigcache"
+	"sync/atomic"
+	"time"
+)
+
+// cacheCounters represents the usage counters of the cache updated atomically.
+type cacheCounters struct {
+	hits      uint64
+	misses    uint64
+	evictions uint64
+	since     int64
+}
+
+// newCacheCounters creates a new set of counters started now.
+func newCacheCounters() *cacheCounters {
+	return &cacheCounters{since: time.Now().UnixNano()}
+}
+
+// onRemove counts the entries evicted on expiration, or to make space for new entries;
+// explicitly deleted entries are not counted.
+func (cc *cacheCounters) onRemove(_ string, _ []byte, reason bigcache.RemoveReason) {
+	if reason == bigcache.Expired || reason == bigcache.NoSpace {
+		atomic.AddUint64(&cc.evictions, 1)
+	}
+}
+
+// reset restarts the counters.
+func (cc *cacheCounters) reset() {
+	atomic.StoreUint64(&cc.hits, 0)
+	atomic.StoreUint64(&cc.misses, 0)
+	atomic.StoreUint64(&cc.evictions, 0)
+	atomic.StoreInt64(&cc.since, time.Now().UnixNano())
+}
+
+// Stats provides the usage statistics of the cache.
+func (b *MemBridge) Stats() *types.CacheStats {
+	return &types.CacheStats{
+		Entries:   b.cache.Len(),
+		Capacity:  b.cache.Capacity(),
+		Hits:      atomic.LoadUint64(&b.cache.counters.hits),
+		Misses:    atomic.LoadUint64(&b.cache.counters.misses),
+		Evictions: atomic.LoadUint64(&b.cache.counters.evictions),
+		Since:     time.Unix(0, atomic.LoadInt64(&b.cache.counters.since)).UTC(),
+	}
+}
+
+// ResetStats provides the usage statistics of the cache and restarts the counters.
+func (b *MemBridge) ResetStats() *types.CacheStats {
+	st := b.Stats()
+	b.cache.counters.reset()
+	return st
+}
@@ -0,0 +1,52 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"motif-api/internal/types"
+	"encoding/binary"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// tokenPriceCacheIdPrefix is the prefix of the cache id of a token oracle price.
+const tokenPriceCacheIdPrefix = "token_price_"
+
+// PullTokenPrice extracts the oracle price of the given token from the in-memory cache
+// if available and not older than the given max age. The known absence of the price feed
+// is provided as a nil price with the found flag set.
+func (b *MemBridge) PullTokenPrice(token *common.Address, maxAge time.Duration) (*types.TokenPrice, bool) {
+	data, err := b.cache.Get(tokenPriceCacheIdPrefix + token.String())
+	if err != nil || len(data) < 12 {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil, false
+	}
+
+	// stale price is not used
+	if time.Since(time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))) > maxAge {
+		return nil, false
+	}
+
+	// no price feed
+	if len(data) == 12 {
+		return nil, true
+	}
+	return &types.TokenPrice{
+		Token:         *token,
+		Price:         hexutil.Big(*new(big.Int).SetBytes(data[12:])),
+		PriceDecimals: int32(binary.BigEndian.Uint32(data[8:12])),
+	}, true
+}
+
+// PushTokenPrice stores the oracle price of the given token in the in-memory cache.
+// Nil price records the absence of the price feed for the token.
+func (b *MemBridge) PushTokenPrice(token *common.Address, tp *types.TokenPrice) error {
+	data := make([]byte, 12)
+	binary.BigEndian.PutUint64(data, uint64(time.Now().UnixNano()))
+	if tp != nil {
+		binary.BigEndian.PutUint32(data[8:], uint32(tp.PriceDecimals))
+		data = append(data, tp.Price.ToInt().Bytes()...)
+	}
+	return b.cache.Set(tokenPriceCacheIdPrefix+token.String(), data)
+}
@@ -0,0 +1,128 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"encoding/binary"
+	"github.com/allegro/bigcache"
+	"sync/atomic"
+	"time"
+)
+
+// NoExpiration represents the TTL of cache entries kept until removed, or dropped to make space.
+const NoExpiration time.Duration = 0
+
+// cacheScanInterval represents the interval of the scan for expired cache entries.
+const cacheScanInterval = time.Minute
+
+// entryHeaderSize represents the fixed size of the header of each cache entry;
+// the header holds the expiration time and the length of the key following it.
+const entryHeaderSize = 10
+
+// SetWithTTL stores the entry for the key expiring after the given TTL.
+// The entry does not expire if the TTL is not positive.
+func (mc meteredCache) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	return mc.BigCache.Set(key, wrapEntry(key, data, ttl, time.Now()))
+}
+
+// Set stores the entry for the key expiring after the default TTL.
+func (mc meteredCache) Set(key string, data []byte) error {
+	return mc.SetWithTTL(key, data, mc.ttl)
+}
+
+// unwrap strips the header of the entry; an expired entry is evicted.
+func (mc meteredCache) unwrap(key string, entry []byte, now time.Time) ([]byte, error) {
+	ek, data, ok := splitEntry(entry)
+	if !ok || ek != key {
+		_ = mc.BigCache.Delete(key)
+		return nil, bigcache.ErrEntryNotFound
+	}
+	if isExpired(entry, now) {
+		mc.evict(key)
+		return nil, bigcache.ErrEntryNotFound
+	}
+	return data, nil
+}
+
+// evict removes the expired entry of the key counting the eviction.
+func (mc meteredCache) evict(key string) {
+	if err := mc.BigCache.Delete(key); err == nil {
+		atomic.AddUint64(&mc.counters.evictions, 1)
+	}
+}
+
+// evictExpired scans the cache and removes all the expired entries.
+// It returns the number of entries removed. The key is taken from the entry header
+// since the cache iterator does not provide the keys reliably.
+func (mc meteredCache) evictExpired(now time.Time) int {
+	var count int
+	it := mc.BigCache.Iterator()
+	for it.SetNext() {
+		ei, err := it.Value()
+		if err != nil || !isExpired(ei.Value(), now) {
+			continue
+		}
+
+		key, _, ok := splitEntry(ei.Value())
+		if !ok {
+			continue
+		}
+
+		// the entry may have been replaced since the shard keys were copied
+		if entry, err := mc.BigCache.Get(key); err != nil || !isExpired(entry, now) {
+			continue
+		}
+		mc.evict(key)
+		count++
+	}
+	return count
+}
+
+// scan periodically removes the expired entries until the done channel is closed.
+func (b *MemBridge) scan(done chan struct{}) {
+	ticker := time.NewTicker(cacheScanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			if n := b.cache.evictExpired(now); n > 0 {
+				b.log.Debugf("%d expired entries evicted from memory cache", n)
+			}
+		}
+	}
+}
+
+// wrapEntry prefixes the entry data with the header holding the expiration and the key.
+func wrapEntry(key string, data []byte, ttl time.Duration, now time.Time) []byte {
+	entry := make([]byte, entryHeaderSize+len(key)+len(data))
+	if ttl > 0 {
+		binary.BigEndian.PutUint64(entry, uint64(now.Add(ttl).UnixNano()))
+	}
+	binary.BigEndian.PutUint16(entry[8:], uint16(len(key)))
+	copy(entry[entryHeaderSize:], key)
+	copy(entry[entryHeaderSize+len(key):], data)
+	return entry
+}
+
+// splitEntry provides the key and the data of the entry with the header.
+func splitEntry(entry []byte) (string, []byte, bool) {
+	if len(entry) < entryHeaderSize {
+		return "", nil, false
+	}
+	end := entryHeaderSize + int(binary.BigEndian.Uint16(entry[8:]))
+	if len(entry) < end {
+		return "", nil, false
+	}
+	return string(entry[entryHeaderSize:end]), entry[end:], true
+}
+
+// isExpired checks if the entry with the expiration header expired at the given time.
+func isExpired(entry []byte, now time.Time) bool {
+	if len(entry) < entryHeaderSize {
+		return true
+	}
+	exp := binary.BigEndian.Uint64(entry)
+	return exp != 0 && uint64(now.UnixNano()) >= exp
+}
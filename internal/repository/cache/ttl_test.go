@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"github.com/allegro/bigcache"
+	"testing"
+	"time"
+)
+
+// testMeteredCache creates a small cache with the given default TTL.
+func testMeteredCache(t *testing.T, ttl time.Duration) meteredCache {
+	cc := newCacheCounters()
+	c, err := bigcache.NewBigCache(bigcache.Config{
+		Shards:             4,
+		LifeWindow:         noLifeWindow,
+		MaxEntriesInWindow: 16,
+		MaxEntrySize:       64,
+		OnRemoveWithReason: cc.onRemove,
+	})
+	if err != nil {
+		t.Fatalf("can not create cache; %s", err.Error())
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return meteredCache{BigCache: c, counters: cc, ttl: ttl}
+}
+
+// TestEntryExpiration tests the expiration header of the cache entries.
+func TestEntryExpiration(t *testing.T) {
+	now := time.Now()
+
+	entry := wrapEntry("key", []byte("data"), time.Second, now)
+	if isExpired(entry, now) || !isExpired(entry, now.Add(time.Second)) {
+		t.Errorf("expected entry expired after its TTL")
+	}
+
+	entry = wrapEntry("key", []byte("data"), NoExpiration, now)
+	if isExpired(entry, now.Add(100*365*24*time.Hour)) {
+		t.Errorf("expected entry without TTL not expired")
+	}
+	if key, data, ok := splitEntry(entry); !ok || key != "key" || string(data) != "data" {
+		t.Errorf("expected entry key and data kept, got %q, %q", key, data)
+	}
+}
+
+// TestPerEntryTTL tests entries expire by their own TTL and the scanner removes them.
+func TestPerEntryTTL(t *testing.T) {
+	mc := testMeteredCache(t, time.Hour)
+
+	_ = mc.SetWithTTL("short", []byte{1}, time.Millisecond)
+	_ = mc.SetWithTTL("forever", []byte{2}, NoExpiration)
+	_ = mc.Set("default", []byte{3})
+
+	if data, err := mc.Get("short"); err != nil || data[0] != 1 {
+		t.Fatalf("expected short lived entry available, got %v; %v", data, err)
+	}
+
+	if n := mc.evictExpired(time.Now().Add(time.Minute)); n != 1 {
+		t.Errorf("expected 1 entry evicted, got %d", n)
+	}
+	if _, err := mc.Get("short"); err == nil {
+		t.Errorf("expected expired entry removed")
+	}
+	for _, key := range []string{"forever", "default"} {
+		if _, err := mc.Get(key); err != nil {
+			t.Errorf("expected entry %s available; %s", key, err.Error())
+		}
+	}
+	if ev := mc.counters.evictions; ev != 1 {
+		t.Errorf("expected 1 eviction counted, got %d", ev)
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+)
+
+// CacheStats provides the usage statistics of the in-memory cache.
+func (p *proxy) CacheStats() *types.CacheStats {
+	return p.cache.Stats()
+}
+
+// ResetCacheStats provides the usage statistics of the in-memory cache
+// collected until now and restarts the counters.
+func (p *proxy) ResetCacheStats() *types.CacheStats {
+	return p.cache.ResetStats()
+}
@@ -116,9 +116,9 @@ func updateContractDetails(sc *types.Contract, detail *compiler.Contract) {
 }
 
 // ValidateContract tries to validate contract byte code using
-// provided source code. If successful, the contract information
-// is updated the the repository.
-func (p *proxy) ValidateContract(sc *types.Contract) error {
+// provided source code and the Solidity compiler of the given version.
+// If successful, the contract information is updated the the repository.
+func (p *proxy) ValidateContract(sc *types.Contract, solcVersion string) error {
 	// get the byte code of the actual contract
 	tx, err := p.Transaction(&sc.TransactionHash)
 	if err != nil {
@@ -126,8 +126,15 @@ func (p *proxy) ValidateContract(sc *types.Contract) error {
 		return err
 	}
 
-	// try to compile the source code provided
-	contracts, err := compiler.CompileSolidityString(p.solCompiler, sc.SourceCode)
+	// find the requested compiler
+	solc, err := p.solCompilerPath(solcVersion)
+	if err != nil {
+		p.log.Errorf("solidity compiler not available; %s", err.Error())
+		return err
+	}
+
+	// try to compile the source code provided with the requested optimization
+	contracts, err := compileSolidity(solc, solcVersion, sc.SourceCode, sc.IsOptimized, sc.OptimizeRuns)
 	if err != nil {
 		p.log.Errorf("solidity code compilation failed")
 		return err
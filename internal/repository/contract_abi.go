@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// abiSourceMaxSize represents the max size of the remote ABI response we accept.
+const abiSourceMaxSize = 4 << 20
+
+// abiSourceResponse represents the response of the remote ABI source.
+type abiSourceResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Result  string `json:"result"`
+}
+
+// ContractAbi provides the ABI of the contract at the given address. The ABI
+// of a locally validated contract is preferred; if it's not available, the ABI
+// is fetched from the remote source, if configured. Empty ABI is provided
+// if the ABI is not known.
+func (p *proxy) ContractAbi(addr *common.Address) (string, error) {
+	sc, err := p.Contract(addr)
+	if err == nil && sc != nil && sc.Abi != "" {
+		return sc.Abi, nil
+	}
+
+	if !p.cfg.AbiSource.Enabled {
+		return "", nil
+	}
+
+	// the remote source has been asked already?
+	if abi, ok := p.cache.PullContractAbi(addr); ok {
+		return abi, nil
+	}
+
+	abi, err, _ := p.apiRequestGroup.Do("abi+"+addr.String(), func() (interface{}, error) {
+		return p.remoteContractAbi(addr)
+	})
+	if err != nil {
+		// failed requests are not cached, the source may recover
+		p.log.Errorf("remote ABI of %s not available; %s", addr.String(), err.Error())
+		return "", err
+	}
+
+	// remember the response, the missing ABI included
+	if err := p.cache.PushContractAbi(addr, abi.(string)); err != nil {
+		p.log.Errorf("can not cache remote ABI of %s; %s", addr.String(), err.Error())
+	}
+	return abi.(string), nil
+}
+
+// remoteContractAbi fetches the ABI of the given contract from the remote source.
+// Empty ABI is provided if the source does not know the contract.
+func (p *proxy) remoteContractAbi(addr *common.Address) (string, error) {
+	q := url.Values{}
+	q.Set("module", "contract")
+	q.Set("action", "getabi")
+	q.Set("address", addr.String())
+	if p.cfg.AbiSource.ApiKey != "" {
+		q.Set("apikey", p.cfg.AbiSource.ApiKey)
+	}
+
+	client := http.Client{Timeout: p.cfg.AbiSource.Timeout}
+	res, err := client.Get(p.cfg.AbiSource.Url + "?" + q.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			p.log.Errorf("can not close remote ABI response; %s", err.Error())
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("remote ABI source responded with %s", res.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, abiSourceMaxSize))
+	if err != nil {
+		return "", err
+	}
+	return parseAbiSourceResponse(data)
+}
+
+// parseAbiSourceResponse extracts the ABI from the remote source response.
+// Empty ABI is provided if the source does not have the contract verified;
+// other failures, e.g. the rate limit, are errors so they are not cached.
+func parseAbiSourceResponse(data []byte) (string, error) {
+	var res abiSourceResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return "", fmt.Errorf("invalid remote ABI response; %s", err.Error())
+	}
+
+	// the result is the ABI JSON on success, an error message otherwise
+	if res.Status == "1" {
+		if !json.Valid([]byte(res.Result)) {
+			return "", fmt.Errorf("invalid remote ABI")
+		}
+		return res.Result, nil
+	}
+	if strings.Contains(strings.ToLower(res.Result), "not verified") {
+		return "", nil
+	}
+	return "", fmt.Errorf("remote ABI source failed; %s %s", res.Message, res.Result)
+}
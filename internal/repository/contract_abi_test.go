@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"testing"
+)
+
+// TestParseAbiSourceResponse tests the remote ABI responses are told apart;
+// ABIs, contracts not verified remotely and failures.
+func TestParseAbiSourceResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{"abi", `{"status":"1","message":"OK","result":"[{\"type\":\"fallback\"}]"}`, `[{"type":"fallback"}]`, false},
+		{"not verified", `{"status":"0","message":"NOTOK","result":"Contract source code not verified"}`, "", false},
+		{"rate limit", `{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`, "", true},
+		{"invalid abi", `{"status":"1","message":"OK","result":"[{"}`, "", true},
+		{"invalid response", `<html></html>`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAbiSourceResponse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAbiSourceResponse() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAbiSourceResponse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
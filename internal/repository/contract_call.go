@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractCall executes a read only call of the given contract with the given input data
+// on the state of the given block, or the latest one, and provides the raw return data.
+func (p *proxy) ContractCall(to *common.Address, data hexutil.Bytes, block *hexutil.Uint64) (hexutil.Bytes, error) {
+	return p.rpc.ContractCall(to, data, block)
+}
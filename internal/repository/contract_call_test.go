@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestContractCallRevert tests the revert reason of a reverted call is provided as the error.
+func TestContractCallRevert(t *testing.T) {
+	tests := []struct {
+		name    string
+		callErr *testRpcError
+		want    string
+	}{
+		{"revert reason", &testRpcError{Code: -32000, Message: "execution reverted: not owner"}, "execution reverted: not owner"},
+		{"other error", &testRpcError{Code: -32000, Message: "out of gas"}, "out of gas"},
+	}
+
+	to := common.Address{2}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testRelayProxy(t, newTestNode(t, testFailing("ftm_call", tt.callErr)), true)
+			res, err := p.ContractCall(&to, hexutil.Bytes{0x70, 0xa0, 0x82, 0x31}, nil)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("expected error %q, got %v, %v", tt.want, res, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// eip1967ImplementationSlot is the storage slot of EIP-1967 proxies
+	// keeping the implementation address; keccak256("eip1967.proxy.implementation") - 1
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+	// erc721InterfaceId is the ERC165 interface id of ERC721 contracts.
+	erc721InterfaceId = [4]byte{0x80, 0xac, 0x58, 0xcd}
+
+	// erc1155InterfaceId is the ERC165 interface id of ERC1155 contracts.
+	erc1155InterfaceId = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+)
+
+// ContractType provides classification of the given address by its code.
+// Proxies are classified along with their implementation.
+func (p *proxy) ContractType(addr *common.Address) (*types.ContractType, error) {
+	if ct := p.cache.PullContractType(addr); ct != nil {
+		return ct, nil
+	}
+
+	ct, err := p.detectContractType(addr, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// a contract can still be deployed to an address without code
+	if ct.Type == types.ContractTypeEOA {
+		return ct, nil
+	}
+
+	if err := p.cache.PushContractType(ct); err != nil {
+		p.log.Warningf("can not cache contract type of %s; %s", addr.String(), err.Error())
+	}
+	return ct, nil
+}
+
+// detectContractType classifies the given address by its code, optionally following
+// the EIP-1967 proxy to its implementation.
+func (p *proxy) detectContractType(addr *common.Address, followProxy bool) (*types.ContractType, error) {
+	ct := types.ContractType{Address: *addr, Type: types.ContractTypeUnknown}
+
+	code, err := p.AccountCode(addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		ct.Type = types.ContractTypeEOA
+		return &ct, nil
+	}
+
+	// EIP-1967 proxy?
+	if followProxy {
+		if impl := p.eip1967Implementation(addr); impl != nil {
+			it, err := p.detectContractType(impl, false)
+			if err != nil {
+				return nil, err
+			}
+			ct.Type = types.ContractTypeProxy
+			ct.Implementation = impl
+			ct.ImplementationType = it.Type
+			return &ct, nil
+		}
+	}
+
+	// interfaces detectable by ERC165
+	if ok, err := p.rpc.Erc165SupportsInterface(addr, erc1155InterfaceId); err == nil && ok {
+		ct.Type = types.ContractTypeErc1155
+		return &ct, nil
+	}
+	if ok, err := p.rpc.Erc165SupportsInterface(addr, erc721InterfaceId); err == nil && ok {
+		ct.Type = types.ContractTypeErc721
+		return &ct, nil
+	}
+
+	if p.looksLikeErc20(addr) {
+		ct.Type = types.ContractTypeErc20
+	}
+	return &ct, nil
+}
+
+// eip1967Implementation provides the implementation address of an EIP-1967 proxy;
+// nil if the contract is not a proxy.
+func (p *proxy) eip1967Implementation(addr *common.Address) *common.Address {
+	val, err := p.rpc.StorageAt(addr, eip1967ImplementationSlot)
+	if err != nil || val == (common.Hash{}) {
+		return nil
+	}
+
+	impl := common.BytesToAddress(val.Bytes())
+	return &impl
+}
+
+// looksLikeErc20 checks the contract responds to the ERC20 token calls.
+func (p *proxy) looksLikeErc20(addr *common.Address) bool {
+	if _, err := p.rpc.Erc20Symbol(addr); err != nil {
+		return false
+	}
+	if _, err := p.rpc.Erc20Decimals(addr); err != nil {
+		return false
+	}
+	_, err := p.rpc.Erc20TotalSupply(addr)
+	return err == nil
+}
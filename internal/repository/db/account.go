@@ -170,7 +170,7 @@ func (db *MongoDbBridge) AccountCount() (uint64, error) {
 }
 
 // AccountTransactions loads list of transaction hashes of an account.
-func (db *MongoDbBridge) AccountTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+func (db *MongoDbBridge) AccountTransactions(addr *common.Address, cursor *string, count int32, skipTotal bool) (*types.TransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero blocks requested")
@@ -188,7 +188,22 @@ func (db *MongoDbBridge) AccountTransactions(addr *common.Address, cursor *strin
 	filter := bson.D{{Key: "$or", Value: bson.A{bson.D{{Key: "from", Value: addr.String()}}, bson.D{{Key: "to", Value: addr.String()}}}}}
 
 	// return list of transactions filtered by the account
-	return db.Transactions(cursor, count, &filter)
+	return db.Transactions(cursor, count, &filter, skipTotal)
+}
+
+// AccountFailedTransactions loads the list of outbound transactions of the given account
+// which failed, e.g. reverted, or ran out of gas.
+func (db *MongoDbBridge) AccountFailedTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+	if addr == nil {
+		return nil, fmt.Errorf("can not list failed transactions of empty account")
+	}
+
+	// the receipt status of a failed transaction is zero
+	filter := bson.D{
+		{Key: fiTransactionSender, Value: addr.String()},
+		{Key: fiTransactionStatus, Value: 0},
+	}
+	return db.Transactions(cursor, count, &filter, false)
 }
 
 // AccountMarkActivity marks the latest account activity in the repository.
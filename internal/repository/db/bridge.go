@@ -5,12 +5,14 @@ import (
 	"context"
 	"motif-api/internal/config"
 	"motif-api/internal/logger"
+	"motif-api/internal/metrics"
 	"fmt"
 	"math/big"
 	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -22,17 +24,25 @@ type MongoDbBridge struct {
 	dbName string
 
 	// init state marks
-	initAccounts     *sync.Once
-	initTransactions *sync.Once
-	initContracts    *sync.Once
-	initSwaps        *sync.Once
-	initDelegations  *sync.Once
-	initWithdrawals  *sync.Once
-	initRewards      *sync.Once
-	initErc20Trx     *sync.Once
-	initFMintTrx     *sync.Once
-	initEpochs       *sync.Once
-	initGasPrice     *sync.Once
+	initAccounts        *sync.Once
+	initTransactions    *sync.Once
+	initContracts       *sync.Once
+	initSwaps           *sync.Once
+	initDelegations     *sync.Once
+	initWithdrawals     *sync.Once
+	initRewards         *sync.Once
+	initErc20Trx        *sync.Once
+	initFMintTrx        *sync.Once
+	initEpochs          *sync.Once
+	initGasPrice        *sync.Once
+	initNftHoldings     *sync.Once
+	initMethodCalls     *sync.Once
+	initStakeChanges    *sync.Once
+	initLogs            *sync.Once
+	initPriceSnapshots  *sync.Once
+	initTokenActivity   *sync.Once
+	initErc20Holders    *sync.Once
+	initSupplySnapshots *sync.Once
 }
 
 // docListCountAggregationTimeout represents a max duration of DB query executed to calculate
@@ -40,6 +50,9 @@ type MongoDbBridge struct {
 // ad we fall back to full collection documents count estimation.
 const docListCountAggregationTimeout = 500 * time.Millisecond
 
+// dbPingTimeout represents the max duration of the database health check.
+const dbPingTimeout = 2 * time.Second
+
 // intZero represents an empty big value.
 var intZero = new(big.Int)
 
@@ -47,6 +60,8 @@ var intZero = new(big.Int)
 func New(cfg *config.Config, log logger.Logger) (*MongoDbBridge, error) {
 	// log what we do
 	log.Debugf("connecting database at %s/%s", cfg.Db.Url, cfg.Db.DbName)
+	log.Noticef("database pool size %d - %d, connect timeout %s, server selection timeout %s",
+		cfg.Db.MinPoolSize, cfg.Db.MaxPoolSize, cfg.Db.ConnectTimeout, cfg.Db.ServerSelectionTimeout)
 
 	// open the database connection
 	con, err := connectDb(&cfg.Db)
@@ -76,7 +91,7 @@ func connectDb(cfg *config.Database) (*mongo.Client, error) {
 	ctx := context.Background()
 
 	// create new Mongo client
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.Url))
+	client, err := mongo.Connect(ctx, clientOptions(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +105,34 @@ func connectDb(cfg *config.Database) (*mongo.Client, error) {
 	return client, nil
 }
 
+// clientOptions provides the Mongo client options of the given database configuration.
+// Zero values keep the driver defaults.
+func clientOptions(cfg *config.Database) *options.ClientOptions {
+	opt := options.Client().ApplyURI(cfg.Url).SetMonitor(commandMonitor())
+	opt.SetMaxPoolSize(cfg.MaxPoolSize)
+	opt.SetMinPoolSize(cfg.MinPoolSize)
+
+	if cfg.ConnectTimeout > 0 {
+		opt.SetConnectTimeout(cfg.ConnectTimeout)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opt.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	return opt
+}
+
+// commandMonitor provides the monitor recording latency of the database commands in the metrics.
+func commandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, ev *event.CommandSucceededEvent) {
+			metrics.DbCommandDuration.Observe(ev.CommandName, time.Duration(ev.DurationNanos).Seconds())
+		},
+		Failed: func(_ context.Context, ev *event.CommandFailedEvent) {
+			metrics.DbCommandDuration.Observe(ev.CommandName, time.Duration(ev.DurationNanos).Seconds())
+		},
+	}
+}
+
 // Close will terminate or finish all operations and close the connection to Mongo database.
 func (db *MongoDbBridge) Close() {
 	// do we have a client?
@@ -176,6 +219,15 @@ func (db *MongoDbBridge) CheckDatabaseInitState() {
 	db.collectionNeedInit("fmint transactions", db.FMintTransactionCount, &db.initFMintTrx)
 	db.collectionNeedInit("epochs", db.EpochsCount, &db.initEpochs)
 	db.collectionNeedInit("gas price periods", db.GasPricePeriodCount, &db.initGasPrice)
+	db.dropLegacyNftHoldings()
+	db.collectionNeedInit("NFT holdings", db.NftHoldingsCount, &db.initNftHoldings)
+	db.collectionNeedInit("method calls", db.MethodCallsCount, &db.initMethodCalls)
+	db.collectionNeedInit("stake changes", db.StakeChangesCount, &db.initStakeChanges)
+	db.collectionNeedInit("logs", db.LogsCount, &db.initLogs)
+	db.collectionNeedInit("price snapshots", db.PriceSnapshotsCount, &db.initPriceSnapshots)
+	db.collectionNeedInit("token activity", db.TokenActivityCount, &db.initTokenActivity)
+	db.collectionNeedInit("ERC20 holders", db.Erc20HoldersCount, &db.initErc20Holders)
+	db.collectionNeedInit("supply snapshots", db.SupplySnapshotsCount, &db.initSupplySnapshots)
 }
 
 // checkAccountCollectionState checks the Accounts collection state.
@@ -226,6 +278,13 @@ func (db *MongoDbBridge) EstimateCount(col *mongo.Collection) (uint64, error) {
 	return uint64(val), nil
 }
 
+// listDocumentsProbe checks if any document matches the filter without counting them all.
+// It's used instead of the count if the client does not need the list total; it returns 1
+// if there is at least one document, and 0 otherwise.
+func (db *MongoDbBridge) listDocumentsProbe(col *mongo.Collection, filter *bson.D) (int64, error) {
+	return col.CountDocuments(context.Background(), filter, options.Count().SetLimit(1))
+}
+
 // listDocumentsCount tries to calculate precise documents count and if it's not counted in limited
 // time, use general estimation to speed up the loader.
 func (db *MongoDbBridge) listDocumentsCount(col *mongo.Collection, filter *bson.D) (int64, error) {
@@ -246,3 +305,10 @@ func (db *MongoDbBridge) listDocumentsCount(col *mongo.Collection, filter *bson.
 	}
 	return total, nil
 }
+
+// Ping checks the database responds.
+func (db *MongoDbBridge) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbPingTimeout)
+	defer cancel()
+	return db.client.Ping(ctx, nil)
+}
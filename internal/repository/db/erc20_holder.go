@@ -0,0 +1,137 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+)
+
+// colErc20Holders represents the name of the ERC20 holders balance index collection in database.
+// The balances are accumulated incrementally from the indexed token transfers.
+const colErc20Holders = "erc20_holders"
+
+// initErc20HoldersCollection initializes the ERC20 holders collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initErc20HoldersCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiErc20HolderToken, Value: 1}, {Key: types.FiErc20HolderBalance, Value: -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for ERC20 holders collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("ERC20 holders collection initialized")
+}
+
+// Erc20HoldersCount calculates total number of ERC20 holders in the database.
+func (db *MongoDbBridge) Erc20HoldersCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colErc20Holders))
+}
+
+// isErc20Transfer checks if the given token transaction moves ERC20 tokens between accounts.
+func isErc20Transfer(trx *types.TokenTransaction) bool {
+	if trx.TokenType != types.AccountTypeERC20Token {
+		return false
+	}
+	return trx.Type == types.TokenTrxTypeTransfer || trx.Type == types.TokenTrxTypeMint || trx.Type == types.TokenTrxTypeBurn
+}
+
+// updateErc20Holders applies the given ERC20 token transfer to the balances of the sender
+// and the recipient. The transfer is reverted by the negative direction, e.g. on a chain reorg.
+func (db *MongoDbBridge) updateErc20Holders(trx *types.TokenTransaction, dir int) {
+	if !isErc20Transfer(trx) {
+		return
+	}
+
+	amount := trx.Amount.ToInt()
+	if dir < 0 {
+		amount = new(big.Int).Neg(amount)
+	}
+
+	col := db.client.Database(db.dbName).Collection(colErc20Holders)
+	if trx.Sender != (common.Address{}) {
+		db.updateErc20Holder(col, trx, &trx.Sender, new(big.Int).Neg(amount))
+	}
+	if trx.Recipient != (common.Address{}) {
+		db.updateErc20Holder(col, trx, &trx.Recipient, amount)
+	}
+
+	// make sure the collection is initialized
+	if db.initErc20Holders != nil {
+		db.initErc20Holders.Do(func() { db.initErc20HoldersCollection(col); db.initErc20Holders = nil })
+	}
+}
+
+// updateErc20Holder adds the given difference to the token balance of the owner.
+// Holders without positive balance are removed from the index.
+func (db *MongoDbBridge) updateErc20Holder(col *mongo.Collection, trx *types.TokenTransaction, owner *common.Address, diff *big.Int) {
+	ctx := context.Background()
+	pk := types.Erc20HolderPk(&trx.TokenAddress, owner)
+
+	// load the current balance, if any
+	var cur types.Erc20Holder
+	err := col.FindOne(ctx, bson.D{{Key: types.FiErc20HolderPk, Value: pk}}).Decode(&cur)
+	if err != nil && err != mongo.ErrNoDocuments {
+		db.log.Errorf("can not load ERC20 %s holder %s; %s", trx.TokenAddress.String(), owner.String(), err.Error())
+		return
+	}
+
+	bal := new(big.Int).Add(cur.Balance.ToInt(), diff)
+	if bal.Sign() <= 0 {
+		if _, err := col.DeleteOne(ctx, bson.D{{Key: types.FiErc20HolderPk, Value: pk}}); err != nil {
+			db.log.Errorf("can not remove ERC20 %s holder %s; %s", trx.TokenAddress.String(), owner.String(), err.Error())
+		}
+		return
+	}
+
+	_, err = col.ReplaceOne(ctx, bson.D{{Key: types.FiErc20HolderPk, Value: pk}}, &types.Erc20Holder{
+		Owner:   *owner,
+		Token:   trx.TokenAddress,
+		Balance: hexutil.Big(*bal),
+		Updated: trx.TimeStamp,
+	}, options.Replace().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("can not update ERC20 %s holder %s; %s", trx.TokenAddress.String(), owner.String(), err.Error())
+	}
+}
+
+// Erc20Holders loads the given number of the top holders of the ERC20 token
+// sorted by their balance from the highest.
+func (db *MongoDbBridge) Erc20Holders(token *common.Address, count int64) ([]*types.Erc20Holder, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colErc20Holders)
+
+	ld, err := col.Find(ctx, bson.D{{Key: types.FiErc20HolderToken, Value: token.String()}},
+		options.Find().SetSort(bson.D{{Key: types.FiErc20HolderBalance, Value: -1}}).SetLimit(count))
+	if err != nil {
+		db.log.Errorf("can not load ERC20 %s holders; %s", token.String(), err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing ERC20 holders cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.Erc20Holder, 0, count)
+	for ld.Next(ctx) {
+		var row types.Erc20Holder
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode ERC20 holder; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -0,0 +1,69 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Erc20Approvals provides the latest ERC20 approvals granted by the given owner,
+// one per token and spender, the most recent first. The approvals may have been
+// spent, or revoked since; the current allowance must be checked on chain.
+func (db *MongoDbBridge) Erc20Approvals(owner *common.Address, count int64) ([]types.Erc20Approval, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+
+	cursor, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: types.FiTokenTransactionSender, Value: owner.String()},
+			{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token},
+			{Key: types.FiTokenTransactionType, Value: types.TokenTrxTypeApproval},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "tok", Value: "$" + types.FiTokenTransactionToken},
+				{Key: "to", Value: "$" + types.FiTokenTransactionRecipient},
+			}},
+			{Key: "ts", Value: bson.D{{Key: "$max", Value: "$ts"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "ts", Value: -1}}}},
+		{{Key: "$limit", Value: count}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not aggregate ERC20 approvals of %s; %s", owner.String(), err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("error closing ERC20 approvals cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]types.Erc20Approval, 0)
+	for cursor.Next(ctx) {
+		var row struct {
+			Id struct {
+				Token   string `bson:"tok"`
+				Spender string `bson:"to"`
+			} `bson:"_id"`
+			TimeStamp uint64 `bson:"ts"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode ERC20 approval; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, types.Erc20Approval{
+			Token:     common.HexToAddress(row.Id.Token),
+			Spender:   common.HexToAddress(row.Id.Spender),
+			TimeStamp: hexutil.Uint64(row.TimeStamp),
+		})
+	}
+	return list, nil
+}
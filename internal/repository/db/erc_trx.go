@@ -26,6 +26,9 @@ func (db *MongoDbBridge) initErc20TrxCollection(col *mongo.Collection) {
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiTokenTransactionRecipient, Value: 1}}})
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiTokenTransactionOrdinal, Value: -1}}})
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiTokenTransactionCallHash, Value: 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiTokenTransactionToken, Value: 1}, {Key: types.FiTokenTransactionTokenId, Value: 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiTokenTransactionToken, Value: 1}, {Key: types.FiTokenTransactionSender, Value: 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiTokenTransactionToken, Value: 1}, {Key: types.FiTokenTransactionRecipient, Value: 1}}})
 
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
@@ -56,6 +59,11 @@ func (db *MongoDbBridge) AddERC20Transaction(trx *types.TokenTransaction) error
 	if db.initErc20Trx != nil {
 		db.initErc20Trx.Do(func() { db.initErc20TrxCollection(col); db.initErc20Trx = nil })
 	}
+
+	// count the transaction into the token activity, the holders balance and the NFT ownership
+	db.updateTokenActivity(trx, 1)
+	db.updateErc20Holders(trx, 1)
+	db.updateNftHoldings(trx)
 	return nil
 }
 
@@ -93,14 +101,21 @@ func (db *MongoDbBridge) ErcTransactionCount() (uint64, error) {
 }
 
 // ercTrxListInit initializes list of ERC20 transactions based on provided cursor, count, and filter.
-func (db *MongoDbBridge) ercTrxListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.TokenTransactionList, error) {
+func (db *MongoDbBridge) ercTrxListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D, skipTotal bool) (*types.TokenTransactionList, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
-	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	// find how many transactions do we have in the database;
+	// just check there are some if the total is not needed
+	var total int64
+	var err error
+	if skipTotal {
+		total, err = db.listDocumentsProbe(col, filter)
+	} else {
+		total, err = col.CountDocuments(context.Background(), *filter)
+	}
 	if err != nil {
 		db.log.Errorf("can not count ERC20 transactions")
 		return nil, err
@@ -110,12 +125,13 @@ func (db *MongoDbBridge) ercTrxListInit(col *mongo.Collection, cursor *string, c
 	db.log.Debugf("found %d filtered ERC20 transactions", total)
 	list := types.TokenTransactionList{
 		Collection: make([]*types.TokenTransaction, 0),
-		Total:      uint64(total),
-		First:      0,
-		Last:       0,
-		IsStart:    total == 0,
-		IsEnd:      total == 0,
-		Filter:     *filter,
+		Total:        uint64(total),
+		TotalSkipped: skipTotal,
+		First:        0,
+		Last:         0,
+		IsStart:      total == 0,
+		IsEnd:        total == 0,
+		Filter:       *filter,
 	}
 
 	// is the list non-empty? return the list with properly calculated range marks
@@ -280,7 +296,8 @@ func (db *MongoDbBridge) ercTrxListLoad(col *mongo.Collection, cursor *string, c
 }
 
 // Erc20Transactions pulls list of ERC20 transactions starting at the specified cursor.
-func (db *MongoDbBridge) Erc20Transactions(cursor *string, count int32, filter *bson.D) (*types.TokenTransactionList, error) {
+// The total number of transactions is not calculated if skipTotal is set.
+func (db *MongoDbBridge) Erc20Transactions(cursor *string, count int32, filter *bson.D, skipTotal bool) (*types.TokenTransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero erc transactions requested")
@@ -290,7 +307,7 @@ func (db *MongoDbBridge) Erc20Transactions(cursor *string, count int32, filter *
 	col := db.client.Database(db.dbName).Collection(colErcTransactions)
 
 	// init the list
-	list, err := db.ercTrxListInit(col, cursor, count, filter)
+	list, err := db.ercTrxListInit(col, cursor, count, filter, skipTotal)
 	if err != nil {
 		db.log.Errorf("can not build erc transaction list; %s", err.Error())
 		return nil, err
@@ -373,3 +390,79 @@ func (db *MongoDbBridge) TokenTransactionsByCall(trxHash *common.Hash) ([]*types
 	}
 	return list, nil
 }
+
+// erc20TopSenderRow represents the structure of the ERC20 top sender aggregation output row.
+type erc20TopSenderRow struct {
+	Sender     string `bson:"_id"`
+	Recipients int64  `bson:"recipients"`
+	Transfers  int64  `bson:"transfers"`
+}
+
+// Erc20TopSender provides transfer statistics of the sender who distributed
+// the given ERC20 token to the largest number of distinct recipients.
+// It returns nil if the token has not been transferred yet.
+func (db *MongoDbBridge) Erc20TopSender(token *common.Address) (*types.Erc20SenderStats, error) {
+	// we consider both regular transfers and mints
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+	filter := bson.D{
+		{Key: types.FiTokenTransactionToken, Value: token.String()},
+		{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token},
+		{Key: types.FiTokenTransactionType, Value: bson.D{{Key: "$in", Value: bson.A{types.TokenTrxTypeTransfer, types.TokenTrxTypeMint}}}},
+	}
+
+	// how many transfers do we have in total
+	total, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count ERC20 %s transfers; %s", token.String(), err.Error())
+		return nil, err
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	// aggregate sender/recipient pairs first so no single document has to hold
+	// all the recipients of a sender, then count the distinct recipients by sender
+	// and pick the top one
+	cursor, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "from", Value: "$from"}, {Key: "to", Value: "$to"}}},
+			{Key: "transfers", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.from"},
+			{Key: "recipients", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "transfers", Value: bson.D{{Key: "$sum", Value: "$transfers"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "recipients", Value: -1}}}},
+		{{Key: "$limit", Value: 1}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not aggregate ERC20 %s senders; %s", token.String(), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	// decode the top row, if any
+	if !cursor.Next(context.Background()) {
+		return nil, nil
+	}
+
+	var row erc20TopSenderRow
+	if err := cursor.Decode(&row); err != nil {
+		db.log.Errorf("can not decode ERC20 top sender row; %s", err.Error())
+		return nil, err
+	}
+
+	return &types.Erc20SenderStats{
+		Sender:     common.HexToAddress(row.Sender),
+		Recipients: row.Recipients,
+		Transfers:  row.Transfers,
+		Total:      total,
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colFaucetRequests represents the name of the faucet requests collection in database.
+	colFaucetRequests = "faucet_requests"
+
+	// fiFaucetRequestPk is the name of the primary key, the funded address.
+	fiFaucetRequestPk = "_id"
+
+	// fiFaucetRequestTime is the name of the time stamp of the last funding of the address.
+	fiFaucetRequestTime = "ts"
+)
+
+// FaucetReserve records the faucet request of the given address at the given time,
+// if the address was not funded in the cooldown period before. The check and the record
+// are done atomically, so concurrent requests of the same address can not pass together.
+func (db *MongoDbBridge) FaucetReserve(addr *common.Address, now time.Time, cooldown time.Duration) (bool, error) {
+	col := db.client.Database(db.dbName).Collection(colFaucetRequests)
+
+	// the filter does not match a recent request; the upsert then collides with its _id
+	_, err := col.UpdateOne(context.Background(), bson.D{
+		{Key: fiFaucetRequestPk, Value: addr.String()},
+		{Key: fiFaucetRequestTime, Value: bson.D{{Key: "$lte", Value: now.Add(-cooldown)}}},
+	}, bson.D{{Key: "$set", Value: bson.D{{Key: fiFaucetRequestTime, Value: now}}}}, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		db.log.Errorf("can not record faucet request of %s; %s", addr.String(), err.Error())
+		return false, err
+	}
+	return true, nil
+}
+
+// FaucetRelease removes the faucet request of the given address recorded at the given time,
+// so the address can request the funds again, e.g. if the funds could not be sent.
+func (db *MongoDbBridge) FaucetRelease(addr *common.Address, ts time.Time) error {
+	col := db.client.Database(db.dbName).Collection(colFaucetRequests)
+	_, err := col.DeleteOne(context.Background(), bson.D{
+		{Key: fiFaucetRequestPk, Value: addr.String()},
+		{Key: fiFaucetRequestTime, Value: ts},
+	})
+	if err != nil {
+		db.log.Errorf("can not release faucet request of %s; %s", addr.String(), err.Error())
+	}
+	return err
+}
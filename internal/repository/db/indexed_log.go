@@ -0,0 +1,185 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colLogs represents the name of the indexed log records collection in database.
+const colLogs = "logs"
+
+// initLogsCollection initializes the indexed log records collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initLogsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiIndexedLogAddress, Value: 1}, {Key: types.FiIndexedLogPk, Value: -1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiIndexedLogTopics + ".0", Value: 1}, {Key: types.FiIndexedLogPk, Value: -1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiIndexedLogBlock, Value: 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for logs collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("logs collection initialized")
+}
+
+// LogsCount calculates total number of indexed log records in the database.
+func (db *MongoDbBridge) LogsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colLogs))
+}
+
+// AddLog stores a log record in the logs index;
+// an existing record of the same log is replaced.
+func (db *MongoDbBridge) AddLog(il *types.IndexedLog) error {
+	if il == nil {
+		return fmt.Errorf("empty log record received")
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colLogs)
+	if _, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: types.FiIndexedLogPk, Value: il.Pk()}},
+		il,
+		options.Replace().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not store log record of %s; %s", il.TxHash.String(), err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initLogs != nil {
+		db.initLogs.Do(func() { db.initLogsCollection(col); db.initLogs = nil })
+	}
+	return nil
+}
+
+// EraseLogs removes log records indexed in the given range of blocks.
+func (db *MongoDbBridge) EraseLogs(fromBlock uint64, toBlock uint64) error {
+	col := db.client.Database(db.dbName).Collection(colLogs)
+
+	// the primary key starts with the block number
+	res, err := col.DeleteMany(context.Background(), bson.D{{Key: types.FiIndexedLogPk, Value: bson.D{
+		{Key: "$gte", Value: types.IndexedLogPk(fromBlock, 0)},
+		{Key: "$lt", Value: types.IndexedLogPk(toBlock+1, 0)},
+	}}})
+	if err != nil {
+		db.log.Errorf("can not erase log records of blocks <#%d, #%d>; %s", fromBlock, toBlock, err.Error())
+		return err
+	}
+
+	if res.DeletedCount > 0 {
+		db.log.Noticef("%d log records of blocks <#%d, #%d> erased", res.DeletedCount, fromBlock, toBlock)
+	}
+	return nil
+}
+
+// IndexedLogs pulls list of indexed log records of the given block range matching the given
+// addresses and topics starting at the specified cursor.
+// Positive count loads older log records below the cursor starting from the newest one,
+// negative count loads newer log records above the cursor starting from the oldest one.
+func (db *MongoDbBridge) IndexedLogs(fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, cursor *string, count int32) (*types.IndexedLogList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero log records requested")
+	}
+
+	filter := indexedLogsFilter(fromBlock, toBlock, addresses, topics)
+	col := db.client.Database(db.dbName).Collection(colLogs)
+	total, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count log records; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.IndexedLogList{
+		Collection: make([]*types.IndexedLog, 0),
+		Total:      uint64(total),
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+	}
+	if total == 0 {
+		return &list, nil
+	}
+
+	// apply the cursor; the list is sorted from new to old
+	var after interface{}
+	if cursor != nil {
+		after = *cursor
+	}
+	opt := pageQuery(&filter, types.FiIndexedLogPk, after, count)
+	if err := db.indexedLogsLoad(col, &filter, opt, &list); err != nil {
+		return nil, err
+	}
+
+	keep, isStart, isEnd, reverse := pageBounds(len(list.Collection), cursor != nil, count)
+	list.Collection, list.IsStart, list.IsEnd = list.Collection[:keep], isStart, isEnd
+	if reverse {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// indexedLogsFilter creates the filter of indexed log records of the given block range.
+// Empty addresses match any emitting contract; an empty list of topics on a position
+// matches any topic of the position.
+func indexedLogsFilter(fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash) bson.D {
+	filter := bson.D{{Key: types.FiIndexedLogBlock, Value: bson.D{
+		{Key: "$gte", Value: fromBlock},
+		{Key: "$lte", Value: toBlock},
+	}}}
+
+	if len(addresses) > 0 {
+		adr := make([]string, len(addresses))
+		for i, a := range addresses {
+			adr[i] = a.String()
+		}
+		filter = append(filter, bson.E{Key: types.FiIndexedLogAddress, Value: bson.D{{Key: "$in", Value: adr}}})
+	}
+
+	for pos, set := range topics {
+		if len(set) == 0 {
+			continue
+		}
+		top := make([]string, len(set))
+		for i, t := range set {
+			top[i] = t.String()
+		}
+		filter = append(filter, bson.E{Key: fmt.Sprintf("%s.%d", types.FiIndexedLogTopics, pos), Value: bson.D{{Key: "$in", Value: top}}})
+	}
+	return filter
+}
+
+// indexedLogsLoad loads indexed log records of the given query into the list.
+func (db *MongoDbBridge) indexedLogsLoad(col *mongo.Collection, filter *bson.D, opt *options.FindOptions, list *types.IndexedLogList) error {
+	ctx := context.Background()
+	ld, err := col.Find(ctx, filter, opt)
+	if err != nil {
+		db.log.Errorf("error loading log records; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing log records cursor; %s", err.Error())
+		}
+	}()
+
+	for ld.Next(ctx) {
+		var row types.IndexedLog
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode log record; %s", err.Error())
+			return err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+	return nil
+}
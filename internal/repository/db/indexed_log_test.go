@@ -0,0 +1,77 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestIndexedLogsFilter tests the block range, the addresses and the topics positions of the logs filter.
+func TestIndexedLogsFilter(t *testing.T) {
+	blocks := bson.E{Key: "blk", Value: bson.D{{Key: "$gte", Value: uint64(10)}, {Key: "$lte", Value: uint64(20)}}}
+	adr := common.HexToAddress("0xc0")
+	sig := common.HexToHash("0xee")
+	who := common.HexToHash("0xd1")
+
+	tests := []struct {
+		name      string
+		addresses []common.Address
+		topics    [][]common.Hash
+		want      bson.D
+	}{
+		{"range only", nil, nil, bson.D{blocks}},
+		{"address", []common.Address{adr}, nil, bson.D{blocks,
+			{Key: "adr", Value: bson.D{{Key: "$in", Value: []string{adr.String()}}}},
+		}},
+		{"any topic on empty positions", nil, [][]common.Hash{{}, {who}}, bson.D{blocks,
+			{Key: "top.1", Value: bson.D{{Key: "$in", Value: []string{who.String()}}}},
+		}},
+		{"address and topics", []common.Address{adr}, [][]common.Hash{{sig}, {who, sig}}, bson.D{blocks,
+			{Key: "adr", Value: bson.D{{Key: "$in", Value: []string{adr.String()}}}},
+			{Key: "top.0", Value: bson.D{{Key: "$in", Value: []string{sig.String()}}}},
+			{Key: "top.1", Value: bson.D{{Key: "$in", Value: []string{who.String(), sig.String()}}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := indexedLogsFilter(10, 20, tt.addresses, tt.topics); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected filter %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestIndexedLogBson tests the indexed log record survives the BSON round trip
+// and its identifier sorts logs in the chain order.
+func TestIndexedLogBson(t *testing.T) {
+	il := types.IndexedLog{Log: retypes.Log{
+		Address:     common.HexToAddress("0xc0"),
+		Topics:      []common.Hash{common.HexToHash("0xee"), common.HexToHash("0xd1")},
+		Data:        []byte{1, 2, 3},
+		BlockNumber: 300,
+		BlockHash:   common.HexToHash("0xbb"),
+		TxHash:      common.HexToHash("0xaa"),
+		TxIndex:     2,
+		Index:       5,
+	}}
+
+	data, err := bson.Marshal(&il)
+	if err != nil {
+		t.Fatalf("can not marshal log; %s", err.Error())
+	}
+	var back types.IndexedLog
+	if err := bson.Unmarshal(data, &back); err != nil {
+		t.Fatalf("can not unmarshal log; %s", err.Error())
+	}
+	if !reflect.DeepEqual(back, il) {
+		t.Errorf("expected %+v, got %+v", il, back)
+	}
+
+	if !(types.IndexedLogPk(299, 9) < il.Pk() && il.Pk() < types.IndexedLogPk(300, 6) && types.IndexedLogPk(300, 6) < types.IndexedLogPk(301, 0)) {
+		t.Errorf("log identifiers not sorted in the chain order")
+	}
+}
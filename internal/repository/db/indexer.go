@@ -0,0 +1,51 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// keyConfigIndexerCheckpoint is the prefix of the primary key of an indexer checkpoint.
+const keyConfigIndexerCheckpoint = "idx_"
+
+// UpdateIndexerCheckpoint stores the last block processed by the given indexer into the config collection.
+func (db *MongoDbBridge) UpdateIndexerCheckpoint(name string, blk uint64) error {
+	col := db.client.Database(db.dbName).Collection(coConfiguration)
+	key := keyConfigIndexerCheckpoint + name
+
+	_, err := col.UpdateByID(context.Background(), key, bson.D{{Key: "$set", Value: bson.D{
+		{Key: fiConfigPk, Value: key},
+		{Key: fiConfigValue, Value: hexutil.Uint64(blk).String()},
+	}}}, new(options.UpdateOptions).SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("can not update %s indexer checkpoint; %s", name, err.Error())
+		return err
+	}
+	return nil
+}
+
+// IndexerCheckpoint returns the last block processed by the given indexer.
+// Zero is returned if the indexer did not store any checkpoint yet.
+func (db *MongoDbBridge) IndexerCheckpoint(name string) (uint64, error) {
+	col := db.client.Database(db.dbName).Collection(coConfiguration)
+
+	res := col.FindOne(context.Background(), bson.D{{Key: fiConfigPk, Value: keyConfigIndexerCheckpoint + name}})
+	if res.Err() != nil {
+		if res.Err() == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		db.log.Errorf("can not load %s indexer checkpoint; %s", name, res.Err().Error())
+		return 0, res.Err()
+	}
+
+	var row ConfigRow
+	if err := res.Decode(&row); err != nil {
+		db.log.Errorf("can not decode %s indexer checkpoint; %s", name, err.Error())
+		return 0, err
+	}
+	return hexutil.DecodeUint64(row.Value)
+}
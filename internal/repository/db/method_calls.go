@@ -0,0 +1,164 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+)
+
+// colMethodCalls represents the name of the watched method calls collection in database.
+const colMethodCalls = "method_calls"
+
+// initMethodCallsCollection initializes the method calls collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initMethodCallsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiMethodCallContract, Value: 1}, {Key: types.FiMethodCallSelector, Value: 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiMethodCallContract, Value: 1}, {Key: types.FiMethodCallName, Value: 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for method calls collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("method calls collection initialized")
+}
+
+// MethodCallsCount calculates total number of recorded method calls in the database.
+func (db *MongoDbBridge) MethodCallsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colMethodCalls))
+}
+
+// AddMethodCall stores a call of a watched method in the database;
+// an existing record of the same call is replaced.
+func (db *MongoDbBridge) AddMethodCall(call *types.MethodCall) error {
+	if call == nil {
+		return fmt.Errorf("empty method call received")
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colMethodCalls)
+	if _, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: types.FiMethodCallPk, Value: call.Pk()}},
+		call,
+		options.Replace().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not store method call of %s; %s", call.Transaction.String(), err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initMethodCalls != nil {
+		db.initMethodCalls.Do(func() { db.initMethodCallsCollection(col); db.initMethodCalls = nil })
+	}
+	return nil
+}
+
+// EraseMethodCalls removes method calls recorded in the given range of blocks.
+func (db *MongoDbBridge) EraseMethodCalls(fromBlock uint64, toBlock uint64) error {
+	col := db.client.Database(db.dbName).Collection(colMethodCalls)
+
+	// the primary key starts with the block number
+	res, err := col.DeleteMany(context.Background(), bson.D{{Key: types.FiMethodCallPk, Value: bson.D{
+		{Key: "$gte", Value: types.MethodCallPk(fromBlock, 0)},
+		{Key: "$lt", Value: types.MethodCallPk(toBlock+1, 0)},
+	}}})
+	if err != nil {
+		db.log.Errorf("can not erase method calls of blocks <#%d, #%d>; %s", fromBlock, toBlock, err.Error())
+		return err
+	}
+
+	if res.DeletedCount > 0 {
+		db.log.Noticef("%d method calls of blocks <#%d, #%d> erased", res.DeletedCount, fromBlock, toBlock)
+	}
+	return nil
+}
+
+// MethodCalls pulls list of recorded calls of the given contract starting at the specified cursor.
+// The method can be given either by its name, or by its selector.
+// Positive count loads older calls below the cursor starting from the newest one,
+// negative count loads newer calls above the cursor starting from the oldest one.
+func (db *MongoDbBridge) MethodCalls(contract *common.Address, method *string, cursor *string, count int32) (*types.MethodCallList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero method calls requested")
+	}
+
+	// filter the contract and the method
+	filter := bson.D{{Key: types.FiMethodCallContract, Value: contract.String()}}
+	if method != nil && *method != "" {
+		if strings.HasPrefix(*method, "0x") {
+			filter = append(filter, bson.E{Key: types.FiMethodCallSelector, Value: strings.ToLower(*method)})
+		} else {
+			filter = append(filter, bson.E{Key: types.FiMethodCallName, Value: *method})
+		}
+	}
+
+	col := db.client.Database(db.dbName).Collection(colMethodCalls)
+	total, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count method calls; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.MethodCallList{
+		Collection: make([]*types.MethodCall, 0),
+		Total:      uint64(total),
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+	}
+	if total == 0 {
+		return &list, nil
+	}
+
+	// apply the cursor; the list is sorted from new to old
+	var after interface{}
+	if cursor != nil {
+		after = *cursor
+	}
+	opt := pageQuery(&filter, types.FiMethodCallPk, after, count)
+	if err := db.methodCallsLoad(col, &filter, opt, &list); err != nil {
+		return nil, err
+	}
+
+	keep, isStart, isEnd, reverse := pageBounds(len(list.Collection), cursor != nil, count)
+	list.Collection, list.IsStart, list.IsEnd = list.Collection[:keep], isStart, isEnd
+	if reverse {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// methodCallsLoad loads method calls of the given query into the list.
+func (db *MongoDbBridge) methodCallsLoad(col *mongo.Collection, filter *bson.D, opt *options.FindOptions, list *types.MethodCallList) error {
+	ctx := context.Background()
+	ld, err := col.Find(ctx, filter, opt)
+	if err != nil {
+		db.log.Errorf("error loading method calls; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing method calls cursor; %s", err.Error())
+		}
+	}()
+
+	for ld.Next(ctx) {
+		var row types.MethodCall
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode method call; %s", err.Error())
+			return err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+	return nil
+}
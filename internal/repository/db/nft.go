@@ -0,0 +1,366 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+)
+
+// colNftHoldings represents the name of the NFT ownership collection in database.
+const colNftHoldings = "nft_holdings"
+
+// initNftHoldingsCollection initializes the NFT ownership collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initNftHoldingsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiNftHoldingOwner, Value: 1}, {Key: types.FiNftHoldingToken, Value: 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiNftHoldingToken, Value: 1}, {Key: types.FiNftHoldingTokenId, Value: 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for NFT holdings collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("NFT holdings collection initialized")
+}
+
+// NftHoldingsCount calculates total number of NFT holdings in the database.
+func (db *MongoDbBridge) NftHoldingsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colNftHoldings))
+}
+
+// fiNftTransferApplied is the flag of the token transfers already applied to the NFT holdings.
+const fiNftTransferApplied = "nho"
+
+// nftHoldingDelta represents a change of the quantity of an NFT held by an owner.
+type nftHoldingDelta struct {
+	owner common.Address
+	diff  *big.Int
+}
+
+// isNftTransfer checks if the given token transaction changes ownership of an ERC721/ERC1155 token.
+func isNftTransfer(trx *types.TokenTransaction) bool {
+	if trx.TokenType != types.AccountTypeERC721Contract && trx.TokenType != types.AccountTypeERC1155Contract {
+		return false
+	}
+	return trx.Type == types.TokenTrxTypeTransfer || trx.Type == types.TokenTrxTypeMint || trx.Type == types.TokenTrxTypeBurn
+}
+
+// nftHoldingDeltas provides the changes of the NFT holdings made by the given transfer.
+// The transfer is reverted by the negative direction, e.g. on a chain reorg.
+// ERC721 transfers always move a single token; the zero address does not hold anything.
+func nftHoldingDeltas(trx *types.TokenTransaction, dir int) []nftHoldingDelta {
+	if trx.Sender == trx.Recipient {
+		return nil
+	}
+
+	amount := big.NewInt(1)
+	if trx.TokenType == types.AccountTypeERC1155Contract {
+		amount.Set(trx.Amount.ToInt())
+	}
+	if dir < 0 {
+		amount.Neg(amount)
+	}
+
+	list := make([]nftHoldingDelta, 0, 2)
+	if trx.Sender != (common.Address{}) {
+		list = append(list, nftHoldingDelta{owner: trx.Sender, diff: new(big.Int).Neg(amount)})
+	}
+	if trx.Recipient != (common.Address{}) {
+		list = append(list, nftHoldingDelta{owner: trx.Recipient, diff: amount})
+	}
+	return list
+}
+
+// updateNftHoldings applies the given NFT transfer to the holdings of the sender and the recipient,
+// unless the transfer has been applied already. The transfer is claimed by a flag stored with it,
+// so the live indexing and the backfill never apply the same transfer twice.
+func (db *MongoDbBridge) updateNftHoldings(trx *types.TokenTransaction) {
+	if !isNftTransfer(trx) {
+		return
+	}
+
+	res, err := db.client.Database(db.dbName).Collection(colErcTransactions).UpdateOne(context.Background(), bson.D{
+		{Key: types.FiTokenTransactionPk, Value: trx.Pk()},
+		{Key: fiNftTransferApplied, Value: bson.D{{Key: "$ne", Value: true}}},
+	}, bson.D{{Key: "$set", Value: bson.D{{Key: fiNftTransferApplied, Value: true}}}})
+	if err != nil {
+		db.log.Errorf("can not claim NFT transfer %s; %s", trx.Pk(), err.Error())
+		return
+	}
+	if res.ModifiedCount == 0 {
+		return
+	}
+	db.applyNftHoldingDeltas(trx, 1)
+}
+
+// applyNftHoldingDeltas adds the changes made by the given NFT transfer to the holdings.
+func (db *MongoDbBridge) applyNftHoldingDeltas(trx *types.TokenTransaction, dir int) {
+	col := db.client.Database(db.dbName).Collection(colNftHoldings)
+	for _, d := range nftHoldingDeltas(trx, dir) {
+		db.incNftHolding(col, trx, &d)
+	}
+
+	// make sure the collection is initialized
+	if db.initNftHoldings != nil {
+		db.initNftHoldings.Do(func() { db.initNftHoldingsCollection(col); db.initNftHoldings = nil })
+	}
+}
+
+// incNftHolding adds the difference to the quantity of the NFT held by the owner.
+// Negative quantities are kept since the backfill applies older transfers after the live ones;
+// the holding is removed once its quantity drops to zero.
+func (db *MongoDbBridge) incNftHolding(col *mongo.Collection, trx *types.TokenTransaction, d *nftHoldingDelta) {
+	diff, err := types.NftQuantityDecimal(d.diff)
+	if err != nil {
+		db.log.Errorf("can not update NFT %s #%s holder %s; %s", trx.TokenAddress.String(), trx.TokenId.String(), d.owner.String(), err.Error())
+		return
+	}
+
+	ctx := context.Background()
+	pk := types.NftHoldingPk(&d.owner, &trx.TokenAddress, trx.TokenId.ToInt())
+	_, err = col.UpdateOne(ctx, bson.D{{Key: types.FiNftHoldingPk, Value: pk}}, bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: types.FiNftHoldingOwner, Value: d.owner.String()},
+			{Key: types.FiNftHoldingToken, Value: trx.TokenAddress.String()},
+			{Key: types.FiNftHoldingTokenType, Value: trx.TokenType},
+			{Key: types.FiNftHoldingTokenId, Value: trx.TokenId.String()},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: types.FiNftHoldingQuantity, Value: diff}}},
+		{Key: "$max", Value: bson.D{{Key: types.FiNftHoldingUpdated, Value: uint64(trx.TimeStamp)}}},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("can not update NFT %s #%s holder %s; %s", trx.TokenAddress.String(), trx.TokenId.String(), d.owner.String(), err.Error())
+		return
+	}
+
+	if _, err := col.DeleteOne(ctx, bson.D{
+		{Key: types.FiNftHoldingPk, Value: pk},
+		{Key: types.FiNftHoldingQuantity, Value: 0},
+	}); err != nil {
+		db.log.Errorf("can not remove NFT %s #%s holder %s; %s", trx.TokenAddress.String(), trx.TokenId.String(), d.owner.String(), err.Error())
+	}
+}
+
+// BackfillNftHoldings applies up to the given number of NFT transfers not applied to the holdings
+// yet, i.e. the transfers indexed before the holdings were tracked, following the given cursor.
+// The cursor of the next batch is provided; nil if there are no more transfers to be applied.
+func (db *MongoDbBridge) BackfillNftHoldings(cursor *string, count int64) (*string, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+
+	filter := bson.D{
+		{Key: types.FiTokenTransactionTokenType, Value: bson.D{{Key: "$in", Value: bson.A{types.AccountTypeERC721Contract, types.AccountTypeERC1155Contract}}}},
+		{Key: types.FiTokenTransactionType, Value: bson.D{{Key: "$in", Value: bson.A{types.TokenTrxTypeTransfer, types.TokenTrxTypeMint, types.TokenTrxTypeBurn}}}},
+		{Key: fiNftTransferApplied, Value: bson.D{{Key: "$ne", Value: true}}},
+	}
+	if cursor != nil {
+		filter = append(filter, bson.E{Key: types.FiTokenTransactionPk, Value: bson.D{{Key: "$gt", Value: *cursor}}})
+	}
+
+	ld, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: types.FiTokenTransactionPk, Value: 1}}).SetLimit(count))
+	if err != nil {
+		db.log.Errorf("can not load NFT transfers to backfill; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing NFT transfers cursor; %s", err.Error())
+		}
+	}()
+
+	var last *string
+	var loaded int64
+	for ld.Next(ctx) {
+		var row types.TokenTransaction
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode NFT transfer; %s", err.Error())
+			return nil, err
+		}
+		db.updateNftHoldings(&row)
+
+		pk := row.Pk()
+		last = &pk
+		loaded++
+	}
+
+	if loaded < count {
+		return nil, nil
+	}
+	return last, nil
+}
+
+// dropLegacyNftHoldings removes the NFT holdings stored with textual quantities by the previous
+// versions, which can not be updated by increments. The holdings are rebuilt by the backfill.
+func (db *MongoDbBridge) dropLegacyNftHoldings() {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colNftHoldings)
+
+	legacy, err := col.CountDocuments(ctx, bson.D{{Key: types.FiNftHoldingQuantity, Value: bson.D{{Key: "$type", Value: "string"}}}}, options.Count().SetLimit(1))
+	if err != nil {
+		db.log.Errorf("can not check legacy NFT holdings; %s", err.Error())
+		return
+	}
+	if legacy == 0 {
+		return
+	}
+
+	if err := col.Drop(ctx); err != nil {
+		db.log.Errorf("can not drop legacy NFT holdings; %s", err.Error())
+		return
+	}
+	db.log.Noticef("legacy NFT holdings dropped, the holdings will be backfilled")
+}
+
+// EraseTokenTransactions removes token transactions emitted in the given range of blocks,
+// e.g. by blocks replaced by a chain reorg. The removed transactions are returned.
+func (db *MongoDbBridge) EraseTokenTransactions(fromBlock uint64, toBlock uint64) ([]*types.TokenTransaction, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+
+	// the primary key starts with the block number
+	lo, hi := types.TokenTransaction{BlockNumber: fromBlock}, types.TokenTransaction{BlockNumber: toBlock + 1}
+	filter := bson.D{{Key: types.FiTokenTransactionPk, Value: bson.D{
+		{Key: "$gte", Value: lo.Pk()},
+		{Key: "$lt", Value: hi.Pk()},
+	}}}
+
+	ld, err := col.Find(ctx, filter)
+	if err != nil {
+		db.log.Errorf("can not load token transactions of blocks <#%d, #%d>; %s", fromBlock, toBlock, err.Error())
+		return nil, err
+	}
+
+	list := make([]*types.TokenTransaction, 0)
+	applied := make([]*types.TokenTransaction, 0)
+	for ld.Next(ctx) {
+		var row types.TokenTransaction
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode token transaction; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+
+		// only the transfers applied to the NFT holdings are reverted there
+		if flag, ok := ld.Current.Lookup(fiNftTransferApplied).BooleanOK(); ok && flag {
+			applied = append(applied, &row)
+		}
+	}
+	if err := ld.Close(ctx); err != nil {
+		db.log.Errorf("error closing token transactions cursor; %s", err.Error())
+	}
+
+	if _, err := col.DeleteMany(ctx, filter); err != nil {
+		db.log.Errorf("can not erase token transactions of blocks <#%d, #%d>; %s", fromBlock, toBlock, err.Error())
+		return nil, err
+	}
+
+	// the erased transactions do not count into the token activity and the holders balance anymore
+	for _, trx := range list {
+		db.updateTokenActivity(trx, -1)
+		db.updateErc20Holders(trx, -1)
+	}
+	for _, trx := range applied {
+		db.applyNftHoldingDeltas(trx, -1)
+	}
+	return list, nil
+}
+
+// NftHoldings pulls list of NFT holdings of the given owner starting at the specified cursor.
+// The list can be limited to a single ERC721/ERC1155 contract.
+func (db *MongoDbBridge) NftHoldings(owner *common.Address, token *common.Address, cursor *string, count int32) (*types.NftHoldingList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero NFT holdings requested")
+	}
+
+	// filter the owner and the contract; the holdings may be negative while backfilled
+	filter := bson.D{
+		{Key: types.FiNftHoldingOwner, Value: owner.String()},
+		{Key: types.FiNftHoldingQuantity, Value: bson.D{{Key: "$gt", Value: 0}}},
+	}
+	if token != nil {
+		filter = append(filter, bson.E{Key: types.FiNftHoldingToken, Value: token.String()})
+	}
+
+	col := db.client.Database(db.dbName).Collection(colNftHoldings)
+	total, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count NFT holdings; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.NftHoldingList{
+		Collection: make([]*types.NftHolding, 0),
+		Total:      uint64(total),
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+	}
+	if total == 0 {
+		return &list, nil
+	}
+
+	// apply the cursor; the list is sorted by the primary key
+	op, sd, limit := "$gt", 1, int64(count)
+	if count < 0 {
+		op, sd, limit = "$lt", -1, -limit
+	}
+	if cursor != nil {
+		filter = append(filter, bson.E{Key: types.FiNftHoldingPk, Value: bson.D{{Key: op, Value: *cursor}}})
+	}
+
+	// try to get one more record so we can detect list end
+	if err := db.nftHoldingsLoad(col, &filter, options.Find().SetSort(bson.D{{Key: types.FiNftHoldingPk, Value: sd}}).SetLimit(limit+1), &list); err != nil {
+		return nil, err
+	}
+
+	more := int64(len(list.Collection)) > limit
+	if more {
+		list.Collection = list.Collection[:limit]
+	}
+
+	if count > 0 {
+		list.IsStart, list.IsEnd = cursor == nil, !more
+	} else {
+		list.IsStart, list.IsEnd = !more, cursor == nil
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// nftHoldingsLoad loads NFT holdings of the given query into the list.
+func (db *MongoDbBridge) nftHoldingsLoad(col *mongo.Collection, filter *bson.D, opt *options.FindOptions, list *types.NftHoldingList) error {
+	ctx := context.Background()
+	ld, err := col.Find(ctx, filter, opt)
+	if err != nil {
+		db.log.Errorf("error loading NFT holdings; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing NFT holdings cursor; %s", err.Error())
+		}
+	}()
+
+	for ld.Next(ctx) {
+		var row types.NftHolding
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode NFT holding; %s", err.Error())
+			return err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+	return nil
+}
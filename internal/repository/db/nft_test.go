@@ -0,0 +1,140 @@
+package db
+
+import (
+	"math/big"
+	"testing"
+
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// testNftTransfer creates an NFT transfer of the given type, parties and amount.
+func testNftTransfer(tokenType string, trxType int32, from byte, to byte, amount int64) *types.TokenTransaction {
+	return &types.TokenTransaction{
+		TokenAddress: common.HexToAddress("0xff"),
+		TokenType:    tokenType,
+		Type:         trxType,
+		Sender:       common.Address{from},
+		Recipient:    common.Address{to},
+		Amount:       hexutil.Big(*big.NewInt(amount)),
+		TokenId:      hexutil.Big(*big.NewInt(7)),
+	}
+}
+
+// testApplyDeltas sums the holding changes of the given transfers by the owner.
+func testApplyDeltas(held map[common.Address]int64, dir int, list ...*types.TokenTransaction) {
+	for _, trx := range list {
+		for _, d := range nftHoldingDeltas(trx, dir) {
+			held[d.owner] += d.diff.Int64()
+		}
+	}
+}
+
+// TestNftHoldingDeltas tests the changes of holdings made by the NFT transfers.
+func TestNftHoldingDeltas(t *testing.T) {
+	tests := []struct {
+		name string
+		trx  *types.TokenTransaction
+		dir  int
+		want map[common.Address]int64
+	}{
+		{"erc721 mint", testNftTransfer(types.AccountTypeERC721Contract, types.TokenTrxTypeMint, 0, 1, 0), 1, map[common.Address]int64{{1}: 1}},
+		{"erc721 transfer", testNftTransfer(types.AccountTypeERC721Contract, types.TokenTrxTypeTransfer, 1, 2, 5), 1, map[common.Address]int64{{1}: -1, {2}: 1}},
+		{"erc721 burn", testNftTransfer(types.AccountTypeERC721Contract, types.TokenTrxTypeBurn, 2, 0, 1), 1, map[common.Address]int64{{2}: -1}},
+		{"erc1155 transfer", testNftTransfer(types.AccountTypeERC1155Contract, types.TokenTrxTypeTransfer, 1, 2, 5), 1, map[common.Address]int64{{1}: -5, {2}: 5}},
+		{"erc1155 revert", testNftTransfer(types.AccountTypeERC1155Contract, types.TokenTrxTypeTransfer, 1, 2, 5), -1, map[common.Address]int64{{1}: 5, {2}: -5}},
+		{"self transfer", testNftTransfer(types.AccountTypeERC1155Contract, types.TokenTrxTypeTransfer, 1, 1, 5), 1, map[common.Address]int64{}},
+	}
+	for _, tc := range tests {
+		got := make(map[common.Address]int64)
+		for _, d := range nftHoldingDeltas(tc.trx, tc.dir) {
+			got[d.owner] = d.diff.Int64()
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+			continue
+		}
+		for adr, qty := range tc.want {
+			if got[adr] != qty {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+			}
+		}
+	}
+
+	// the transfer amount is not changed by the deltas
+	trx := testNftTransfer(types.AccountTypeERC1155Contract, types.TokenTrxTypeTransfer, 1, 2, 5)
+	nftHoldingDeltas(trx, -1)
+	if trx.Amount.ToInt().Int64() != 5 {
+		t.Errorf("expected transfer amount kept, got %s", trx.Amount.String())
+	}
+}
+
+// TestNftHoldingDeltasOrder tests the holdings do not depend on the order the transfers
+// are applied in, so the backfill can apply old transfers after the live ones,
+// and a reverted transfer restores the previous holdings.
+func TestNftHoldingDeltasOrder(t *testing.T) {
+	chain := []*types.TokenTransaction{
+		testNftTransfer(types.AccountTypeERC1155Contract, types.TokenTrxTypeMint, 0, 1, 10),
+		testNftTransfer(types.AccountTypeERC1155Contract, types.TokenTrxTypeTransfer, 1, 2, 4),
+		testNftTransfer(types.AccountTypeERC1155Contract, types.TokenTrxTypeTransfer, 2, 3, 1),
+		testNftTransfer(types.AccountTypeERC1155Contract, types.TokenTrxTypeBurn, 1, 0, 6),
+	}
+	want := map[common.Address]int64{{1}: 0, {2}: 3, {3}: 1}
+
+	// the live transfers first, then the backfilled ones
+	held := make(map[common.Address]int64)
+	testApplyDeltas(held, 1, chain[2], chain[3])
+	if held[common.Address{1}] >= 0 {
+		t.Errorf("expected negative holding before the backfill, got %v", held)
+	}
+	testApplyDeltas(held, 1, chain[0], chain[1])
+	for adr, qty := range want {
+		if held[adr] != qty {
+			t.Errorf("expected %v, got %v", want, held)
+		}
+	}
+
+	// revert the last two transfers
+	testApplyDeltas(held, -1, chain[3], chain[2])
+	want = map[common.Address]int64{{1}: 6, {2}: 4, {3}: 0}
+	for adr, qty := range want {
+		if held[adr] != qty {
+			t.Errorf("expected %v after revert, got %v", want, held)
+		}
+	}
+}
+
+// TestNftHoldingQuantity tests the quantities updated by increments decode into the holdings.
+func TestNftHoldingQuantity(t *testing.T) {
+	big1155, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+	for _, qty := range []*big.Int{big.NewInt(1), big1155} {
+		nh := types.NftHolding{
+			Owner:     common.HexToAddress("0x01"),
+			Token:     common.HexToAddress("0xff"),
+			TokenType: types.AccountTypeERC1155Contract,
+			TokenId:   hexutil.Big(*big.NewInt(7)),
+			Quantity:  hexutil.Big(*qty),
+		}
+		data, err := bson.Marshal(&nh)
+		if err != nil {
+			t.Fatalf("can not encode holding; %s", err.Error())
+		}
+
+		var got types.NftHolding
+		if err := bson.Unmarshal(data, &got); err != nil {
+			t.Fatalf("can not decode holding; %s", err.Error())
+		}
+		if got.Quantity.ToInt().Cmp(qty) != 0 || got.TokenId.ToInt().Int64() != 7 || got.Owner != nh.Owner {
+			t.Errorf("expected %+v, got %+v", nh, got)
+		}
+	}
+
+	// quantities of 256 bits do not fit the decimal
+	huge := new(big.Int).Lsh(big.NewInt(1), 255)
+	huge.Add(huge, big.NewInt(1))
+	if _, err := types.NftQuantityDecimal(huge); err == nil {
+		t.Errorf("expected huge quantity refused")
+	}
+}
@@ -0,0 +1,45 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// pageQuery adds the cursor condition of a list page into the given filter and provides
+// the find options of the page; the list is sorted by the given key from new to old.
+// Positive count loads older rows below the cursor starting from the newest one,
+// negative count loads newer rows above the cursor starting from the oldest one.
+// The cursor is nil if the page starts at the list boundary. One extra row is loaded
+// so the list end can be detected by pageBounds.
+func pageQuery(filter *bson.D, key string, cursor interface{}, count int32) *options.FindOptions {
+	op, sd, limit := "$lt", -1, int64(count)
+	if count < 0 {
+		op, sd, limit = "$gt", 1, -limit
+	}
+	if cursor != nil {
+		*filter = append(*filter, bson.E{Key: key, Value: bson.D{{Key: op, Value: cursor}}})
+	}
+	return options.Find().SetSort(bson.D{{Key: key, Value: sd}}).SetLimit(limit + 1)
+}
+
+// pageBounds detects the boundaries of a list page loaded with the pageQuery options.
+// It provides the number of the loaded rows to keep, the list start and end flags,
+// and if the rows must be reversed into the list order.
+func pageBounds(rows int, hasCursor bool, count int32) (keep int, isStart bool, isEnd bool, reverse bool) {
+	limit := int(count)
+	if limit < 0 {
+		limit = -limit
+	}
+
+	keep = rows
+	more := rows > limit
+	if more {
+		keep = limit
+	}
+
+	if count > 0 {
+		return keep, !hasCursor, !more, false
+	}
+	return keep, !more, !hasCursor, true
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestPageQuery tests the cursor condition, the sorting and the limit of a list page.
+func TestPageQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor interface{}
+		count  int32
+		filter bson.D
+		sort   bson.D
+		limit  int64
+	}{
+		{"top", nil, 10, bson.D{}, bson.D{{Key: "_id", Value: -1}}, 11},
+		{"bottom", nil, -10, bson.D{}, bson.D{{Key: "_id", Value: 1}}, 11},
+		{"older", "0x05", 3, bson.D{{Key: "_id", Value: bson.D{{Key: "$lt", Value: "0x05"}}}}, bson.D{{Key: "_id", Value: -1}}, 4},
+		{"newer", int64(5), -3, bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: int64(5)}}}}, bson.D{{Key: "_id", Value: 1}}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := bson.D{}
+			opt := pageQuery(&filter, "_id", tt.cursor, tt.count)
+			if !reflect.DeepEqual(filter, tt.filter) {
+				t.Errorf("expected filter %v, got %v", tt.filter, filter)
+			}
+			if !reflect.DeepEqual(opt.Sort, tt.sort) {
+				t.Errorf("expected sort %v, got %v", tt.sort, opt.Sort)
+			}
+			if opt.Limit == nil || *opt.Limit != tt.limit {
+				t.Errorf("expected limit %d, got %v", tt.limit, opt.Limit)
+			}
+		})
+	}
+}
+
+// TestPageBounds tests the extra row is dropped and the list boundaries are detected.
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      int
+		hasCursor bool
+		count     int32
+		keep      int
+		isStart   bool
+		isEnd     bool
+		reverse   bool
+	}{
+		{"top with more", 4, false, 3, 3, true, false, false},
+		{"top only", 2, false, 3, 2, true, true, false},
+		{"middle", 4, true, 3, 3, false, false, false},
+		{"tail", 3, true, 3, 3, false, true, false},
+		{"bottom with more", 4, false, -3, 3, false, true, true},
+		{"above the cursor", 4, true, -3, 3, false, false, true},
+		{"head above the cursor", 1, true, -3, 1, true, false, true},
+		{"empty", 0, false, 3, 0, true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep, isStart, isEnd, reverse := pageBounds(tt.rows, tt.hasCursor, tt.count)
+			if keep != tt.keep || isStart != tt.isStart || isEnd != tt.isEnd || reverse != tt.reverse {
+				t.Errorf("expected %d/%t/%t/%t, got %d/%t/%t/%t", tt.keep, tt.isStart, tt.isEnd, tt.reverse, keep, isStart, isEnd, reverse)
+			}
+		})
+	}
+}
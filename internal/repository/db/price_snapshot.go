@@ -0,0 +1,96 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// colPriceSnapshots represents the name of the token price snapshots collection in database.
+const colPriceSnapshots = "price_snapshots"
+
+// initPriceSnapshotsCollection initializes the token price snapshots collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initPriceSnapshotsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiPriceSnapshotToken, Value: 1}, {Key: types.FiPriceSnapshotTime, Value: -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for price snapshots collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("price snapshots collection initialized")
+}
+
+// PriceSnapshotsCount calculates total number of token price snapshots in the database.
+func (db *MongoDbBridge) PriceSnapshotsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colPriceSnapshots))
+}
+
+// AddPriceSnapshot stores a token price snapshot in the database.
+func (db *MongoDbBridge) AddPriceSnapshot(ps *types.PriceSnapshot) error {
+	if ps == nil {
+		return fmt.Errorf("empty price snapshot received")
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colPriceSnapshots)
+	if _, err := col.InsertOne(context.Background(), ps); err != nil {
+		db.log.Errorf("can not store price snapshot of %s; %s", ps.Token.String(), err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initPriceSnapshots != nil {
+		db.initPriceSnapshots.Do(func() { db.initPriceSnapshotsCollection(col); db.initPriceSnapshots = nil })
+	}
+	return nil
+}
+
+// PriceSnapshots loads up to the given number of the most recent price snapshots of the token
+// taken in the given time range. The snapshots are sorted from the oldest one.
+func (db *MongoDbBridge) PriceSnapshots(token *common.Address, from time.Time, to time.Time, count int64) ([]*types.PriceSnapshot, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colPriceSnapshots)
+
+	ld, err := col.Find(ctx, bson.D{
+		{Key: types.FiPriceSnapshotToken, Value: token.String()},
+		{Key: types.FiPriceSnapshotTime, Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lte", Value: to}}},
+	}, options.Find().SetSort(bson.D{{Key: types.FiPriceSnapshotTime, Value: -1}}).SetLimit(count))
+	if err != nil {
+		db.log.Errorf("can not load price snapshots of %s; %s", token.String(), err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing price snapshots cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.PriceSnapshot, 0)
+	for ld.Next(ctx) {
+		var row types.PriceSnapshot
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode price snapshot; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+
+	// we loaded from the newest
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list, nil
+}
@@ -0,0 +1,109 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"math/big"
+	"time"
+)
+
+// FMintFeesCollected aggregates fees paid on fMint minting since the given time by the minted token.
+// The fees are summed from the indexed values, so they are precise to 6 decimals.
+func (db *MongoDbBridge) FMintFeesCollected(since time.Time) (map[common.Address]*big.Int, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colFMintTransactions)
+
+	ld, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "typ", Value: types.FMintTrxTypeMint},
+			{Key: types.FiFMintTransactionTimestamp, Value: bson.D{{Key: "$gte", Value: since}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + types.FiFMintTransactionToken},
+			{Key: "fee", Value: bson.D{{Key: "$sum", Value: "$fee_val"}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate fMint fees; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing fMint fees cursor; %s", err.Error())
+		}
+	}()
+
+	res := make(map[common.Address]*big.Int)
+	for ld.Next(ctx) {
+		var row struct {
+			Token string `bson:"_id"`
+			Fee   int64  `bson:"fee"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode fMint fees; %s", err.Error())
+			return nil, err
+		}
+		res[common.HexToAddress(row.Token)] = new(big.Int).Mul(big.NewInt(row.Fee), types.FMintAmountDecimalsCorrection)
+	}
+	return res, nil
+}
+
+// UniswapSwapInputs aggregates input amounts of swaps made since the given time by the pair.
+// Liquidity additions share the swap type, but they don't have any output amounts.
+// The amounts are summed from the indexed values, so they are precise to 9 decimals.
+func (db *MongoDbBridge) UniswapSwapInputs(since time.Time) (map[common.Address][2]*big.Int, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(coUniswap)
+
+	ld, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: fiSwapType, Value: types.SwapMint},
+			{Key: fiSwapDate, Value: bson.D{{Key: "$gte", Value: primitive.NewDateTimeFromTime(since)}}},
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: fiSwapAmount0out, Value: bson.D{{Key: "$gt", Value: 0}}}},
+				bson.D{{Key: fiSwapAmount1out, Value: bson.D{{Key: "$gt", Value: 0}}}},
+			}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + fiSwapPair},
+			{Key: "in0", Value: bson.D{{Key: "$sum", Value: "$" + fiSwapAmount0in}}},
+			{Key: "in1", Value: bson.D{{Key: "$sum", Value: "$" + fiSwapAmount1in}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate swap inputs; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing swap inputs cursor; %s", err.Error())
+		}
+	}()
+
+	res := make(map[common.Address][2]*big.Int)
+	for ld.Next(ctx) {
+		var row struct {
+			Pair string `bson:"_id"`
+			In0  int64  `bson:"in0"`
+			In1  int64  `bson:"in1"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode swap inputs; %s", err.Error())
+			return nil, err
+		}
+		res[common.HexToAddress(row.Pair)] = [2]*big.Int{
+			returnDecimals(big.NewInt(row.In0), swapAmountDecimalsCorrection),
+			returnDecimals(big.NewInt(row.In1), swapAmountDecimalsCorrection),
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,155 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// colReorgs represents the name of the chain reorgs collection in database.
+	colReorgs = "reorgs"
+
+	// fiReorgPk is the name of the primary key of the collection.
+	fiReorgPk = "_id"
+)
+
+// AddReorg stores a chain reorg record in connected persistent storage.
+// The oldest records above the given retention limit are removed.
+func (db *MongoDbBridge) AddReorg(r *types.Reorg, retention int64) error {
+	if r == nil {
+		return fmt.Errorf("empty reorg received")
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colReorgs)
+	if _, err := col.InsertOne(context.Background(), r); err != nil {
+		db.log.Errorf("can not store reorg; %s", err.Error())
+		return err
+	}
+
+	db.log.Debugf("reorg of %d blocks at #%d added to database", r.Depth, r.ToBlock)
+	return db.pruneReorgs(col, retention)
+}
+
+// pruneReorgs removes the oldest reorg records above the retention limit.
+func (db *MongoDbBridge) pruneReorgs(col *mongo.Collection, retention int64) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	// find the oldest record we keep
+	var row struct {
+		Value int64 `bson:"_id"`
+	}
+	sr := col.FindOne(context.Background(), bson.D{}, options.FindOne().
+		SetSort(bson.D{{Key: fiReorgPk, Value: -1}}).
+		SetSkip(retention-1).
+		SetProjection(bson.D{{Key: fiReorgPk, Value: true}}))
+	if err := sr.Decode(&row); err != nil {
+		// not enough records to prune anything
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		db.log.Errorf("can not find reorg retention border; %s", err.Error())
+		return err
+	}
+
+	// drop everything older
+	res, err := col.DeleteMany(context.Background(), bson.D{{Key: fiReorgPk, Value: bson.D{{Key: "$lt", Value: row.Value}}}})
+	if err != nil {
+		db.log.Errorf("can not prune reorgs; %s", err.Error())
+		return err
+	}
+	if res.DeletedCount > 0 {
+		db.log.Debugf("%d old reorgs pruned", res.DeletedCount)
+	}
+	return nil
+}
+
+// ReorgsCount calculates total number of reorg records in the database.
+func (db *MongoDbBridge) ReorgsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colReorgs))
+}
+
+// Reorgs pulls list of chain reorgs starting at the specified cursor.
+// Positive count loads older reorgs below the cursor starting from the newest one,
+// negative count loads newer reorgs above the cursor starting from the oldest one.
+func (db *MongoDbBridge) Reorgs(cursor *string, count int32) (*types.ReorgList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero reorgs requested")
+	}
+
+	total, err := db.ReorgsCount()
+	if err != nil {
+		return nil, err
+	}
+
+	list := types.ReorgList{
+		Collection: make([]*types.Reorg, 0),
+		Total:      total,
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+	}
+	if total == 0 {
+		return &list, nil
+	}
+
+	// apply the cursor; the list is sorted from new to old
+	var after interface{}
+	if cursor != nil {
+		pk, err := hexutil.DecodeUint64(*cursor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %s; %s", *cursor, err.Error())
+		}
+		after = int64(pk)
+	}
+
+	filter := bson.D{}
+	opt := pageQuery(&filter, fiReorgPk, after, count)
+	if err := db.reorgListLoad(&filter, opt, &list); err != nil {
+		return nil, err
+	}
+
+	keep, isStart, isEnd, reverse := pageBounds(len(list.Collection), cursor != nil, count)
+	list.Collection, list.IsStart, list.IsEnd = list.Collection[:keep], isStart, isEnd
+	if reverse {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// reorgListLoad loads reorg records of the given query into the list.
+func (db *MongoDbBridge) reorgListLoad(filter *bson.D, opt *options.FindOptions, list *types.ReorgList) (err error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colReorgs)
+
+	ld, err := col.Find(ctx, filter, opt)
+	if err != nil {
+		db.log.Errorf("error loading reorg list; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing reorg list cursor; %s", err.Error())
+		}
+	}()
+
+	for ld.Next(ctx) {
+		var row types.Reorg
+		if err = ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode reorg list row; %s", err.Error())
+			return err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+	return nil
+}
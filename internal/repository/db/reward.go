@@ -5,10 +5,12 @@ import (
 	"context"
 	"motif-api/internal/types"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"math/big"
+	"time"
 )
 
 // colRewards represents the name of the reward claim collection in database.
@@ -326,3 +328,36 @@ func (db *MongoDbBridge) RewardsSumValue(filter *bson.D) (*big.Int, error) {
 		filter,
 		types.RewardDecimalsCorrection)
 }
+
+// RewardClaimsSince loads all the reward claims of the given delegator made since the given time.
+func (db *MongoDbBridge) RewardClaimsSince(addr *common.Address, since time.Time) ([]types.RewardClaim, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colRewards)
+
+	ld, err := col.Find(ctx, bson.D{
+		{Key: types.FiRewardClaimAddress, Value: addr.String()},
+		{Key: types.FiRewardClaimedTimeStamp, Value: bson.D{{Key: "$gte", Value: since}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not load reward claims of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing reward claims cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]types.RewardClaim, 0)
+	for ld.Next(ctx) {
+		var row types.RewardClaim
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the reward claim; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, row)
+	}
+	return list, nil
+}
@@ -0,0 +1,160 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colStakeChanges represents the name of the stake changes collection in database.
+const colStakeChanges = "stake_changes"
+
+// initStakeChangesCollection initializes the stake changes collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initStakeChangesCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiStakeChangeDelegator, Value: 1}, {Key: types.FiStakeChangePk, Value: -1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiStakeChangeDelegator, Value: 1}, {Key: types.FiStakeChangeValidator, Value: 1}, {Key: types.FiStakeChangePk, Value: -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for stake changes collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("stake changes collection initialized")
+}
+
+// StakeChangesCount calculates total number of stake changes in the database.
+func (db *MongoDbBridge) StakeChangesCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colStakeChanges))
+}
+
+// AddStakeChange stores a stake change in the database;
+// an existing record of the same change is replaced.
+func (db *MongoDbBridge) AddStakeChange(sc *types.StakeChange) error {
+	if sc == nil {
+		return fmt.Errorf("empty stake change received")
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colStakeChanges)
+	if _, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: types.FiStakeChangePk, Value: sc.Pk()}},
+		sc,
+		options.Replace().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not store stake change of %s; %s", sc.Transaction.String(), err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initStakeChanges != nil {
+		db.initStakeChanges.Do(func() { db.initStakeChangesCollection(col); db.initStakeChanges = nil })
+	}
+	return nil
+}
+
+// EraseStakeChanges removes stake changes recorded in the given range of blocks.
+func (db *MongoDbBridge) EraseStakeChanges(fromBlock uint64, toBlock uint64) error {
+	col := db.client.Database(db.dbName).Collection(colStakeChanges)
+
+	// the primary key starts with the block number
+	res, err := col.DeleteMany(context.Background(), bson.D{{Key: types.FiStakeChangePk, Value: bson.D{
+		{Key: "$gte", Value: types.StakeChangePk(fromBlock, 0)},
+		{Key: "$lt", Value: types.StakeChangePk(toBlock+1, 0)},
+	}}})
+	if err != nil {
+		db.log.Errorf("can not erase stake changes of blocks <#%d, #%d>; %s", fromBlock, toBlock, err.Error())
+		return err
+	}
+
+	if res.DeletedCount > 0 {
+		db.log.Noticef("%d stake changes of blocks <#%d, #%d> erased", res.DeletedCount, fromBlock, toBlock)
+	}
+	return nil
+}
+
+// StakeChanges pulls list of stake changes of the given delegator starting at the specified cursor,
+// optionally limited to the given validator.
+// Positive count loads older changes below the cursor starting from the newest one,
+// negative count loads newer changes above the cursor starting from the oldest one.
+func (db *MongoDbBridge) StakeChanges(delegator *common.Address, validator *hexutil.Big, cursor *string, count int32) (*types.StakeChangeList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero stake changes requested")
+	}
+
+	// filter the delegator and the validator
+	filter := bson.D{{Key: types.FiStakeChangeDelegator, Value: delegator.String()}}
+	if validator != nil {
+		filter = append(filter, bson.E{Key: types.FiStakeChangeValidator, Value: validator.String()})
+	}
+
+	col := db.client.Database(db.dbName).Collection(colStakeChanges)
+	total, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count stake changes; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.StakeChangeList{
+		Collection: make([]*types.StakeChange, 0),
+		Total:      uint64(total),
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+	}
+	if total == 0 {
+		return &list, nil
+	}
+
+	// apply the cursor; the list is sorted from new to old
+	var after interface{}
+	if cursor != nil {
+		after = *cursor
+	}
+	opt := pageQuery(&filter, types.FiStakeChangePk, after, count)
+	if err := db.stakeChangesLoad(col, &filter, opt, &list); err != nil {
+		return nil, err
+	}
+
+	keep, isStart, isEnd, reverse := pageBounds(len(list.Collection), cursor != nil, count)
+	list.Collection, list.IsStart, list.IsEnd = list.Collection[:keep], isStart, isEnd
+	if reverse {
+		list.Reverse()
+	}
+	return &list, nil
+}
+
+// stakeChangesLoad loads stake changes of the given query into the list.
+func (db *MongoDbBridge) stakeChangesLoad(col *mongo.Collection, filter *bson.D, opt *options.FindOptions, list *types.StakeChangeList) error {
+	ctx := context.Background()
+	ld, err := col.Find(ctx, filter, opt)
+	if err != nil {
+		db.log.Errorf("error loading stake changes; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing stake changes cursor; %s", err.Error())
+		}
+	}()
+
+	for ld.Next(ctx) {
+		var row types.StakeChange
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode stake change; %s", err.Error())
+			return err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colSupplySnapshots represents the name of the token supply snapshots collection in database.
+const colSupplySnapshots = "supply_snapshots"
+
+// initSupplySnapshotsCollection initializes the token supply snapshots collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initSupplySnapshotsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiSupplySnapshotToken, Value: 1}, {Key: types.FiSupplySnapshotBlock, Value: -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for supply snapshots collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("supply snapshots collection initialized")
+}
+
+// SupplySnapshotsCount calculates total number of token supply snapshots in the database.
+func (db *MongoDbBridge) SupplySnapshotsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colSupplySnapshots))
+}
+
+// AddSupplySnapshot stores a token supply snapshot in the database.
+// A snapshot of the same token and block, e.g. after a chain reorg, is replaced.
+func (db *MongoDbBridge) AddSupplySnapshot(ss *types.SupplySnapshot) error {
+	if ss == nil {
+		return fmt.Errorf("empty supply snapshot received")
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colSupplySnapshots)
+	pk := types.SupplySnapshotPk(&ss.Token, uint64(ss.BlockNumber))
+	if _, err := col.ReplaceOne(context.Background(), bson.D{{Key: types.FiSupplySnapshotPk, Value: pk}}, ss, options.Replace().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not store supply snapshot of %s; %s", ss.Token.String(), err.Error())
+		return err
+	}
+
+	// make sure the collection is initialized
+	if db.initSupplySnapshots != nil {
+		db.initSupplySnapshots.Do(func() { db.initSupplySnapshotsCollection(col); db.initSupplySnapshots = nil })
+	}
+	return nil
+}
+
+// SupplySnapshots loads up to the given number of the supply snapshots of the token
+// taken in the given inclusive block range. The snapshots are sorted from the oldest one.
+func (db *MongoDbBridge) SupplySnapshots(token *common.Address, from uint64, to uint64, count int64) ([]*types.SupplySnapshot, error) {
+	return db.supplySnapshots(token, bson.D{{Key: "$gte", Value: from}, {Key: "$lte", Value: to}}, 1, count)
+}
+
+// SupplySnapshotBefore loads the most recent supply snapshot of the token
+// taken before the given block, if any.
+func (db *MongoDbBridge) SupplySnapshotBefore(token *common.Address, blk uint64) (*types.SupplySnapshot, error) {
+	list, err := db.supplySnapshots(token, bson.D{{Key: "$lt", Value: blk}}, -1, 1)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+// supplySnapshots loads up to the given number of the supply snapshots of the token
+// matching the block condition in the given block order.
+func (db *MongoDbBridge) supplySnapshots(token *common.Address, blk bson.D, order int, count int64) ([]*types.SupplySnapshot, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colSupplySnapshots)
+
+	ld, err := col.Find(ctx, bson.D{
+		{Key: types.FiSupplySnapshotToken, Value: token.String()},
+		{Key: types.FiSupplySnapshotBlock, Value: blk},
+	}, options.Find().SetSort(bson.D{{Key: types.FiSupplySnapshotBlock, Value: order}}).SetLimit(count))
+	if err != nil {
+		db.log.Errorf("can not load supply snapshots of %s; %s", token.String(), err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing supply snapshots cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.SupplySnapshot, 0)
+	for ld.Next(ctx) {
+		var row types.SupplySnapshot
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode supply snapshot; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -0,0 +1,118 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// colTokenActivity represents the name of the token activity counters collection in database.
+// Each document counts transactions of a single token in a single time bucket,
+// so trailing window activity is summed from a few buckets per token instead
+// of scanning the token transactions collection.
+const colTokenActivity = "token_activity"
+
+// initTokenActivityCollection initializes the token activity collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initTokenActivityCollection(col *mongo.Collection) {
+	// buckets out of the longest window expire
+	ttl := int32((types.TokenActivityMaxWindow + types.TokenActivityBucket) / time.Second)
+
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{
+		Keys:    bson.D{{Key: types.FiTokenActivityBucket, Value: 1}},
+		Options: &options.IndexOptions{ExpireAfterSeconds: &ttl},
+	})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for token activity collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("token activity collection initialized")
+}
+
+// TokenActivityCount calculates total number of token activity counters in the database.
+func (db *MongoDbBridge) TokenActivityCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colTokenActivity))
+}
+
+// updateTokenActivity adjusts the activity counter of the token
+// in the time bucket of the given token transaction.
+func (db *MongoDbBridge) updateTokenActivity(trx *types.TokenTransaction, diff int64) {
+	bucket := time.Unix(int64(trx.TimeStamp), 0).UTC().Truncate(types.TokenActivityBucket)
+	col := db.client.Database(db.dbName).Collection(colTokenActivity)
+
+	_, err := col.UpdateOne(context.Background(), bson.D{
+		{Key: "_id", Value: fmt.Sprintf("%s-%d", trx.TokenAddress.String(), bucket.Unix())},
+	}, bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: types.FiTokenActivityToken, Value: trx.TokenAddress.String()},
+			{Key: types.FiTokenTransactionTokenType, Value: trx.TokenType},
+			{Key: types.FiTokenActivityBucket, Value: bucket},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: types.FiTokenActivityCount, Value: diff}}},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("can not update activity of token %s; %s", trx.TokenAddress.String(), err.Error())
+		return
+	}
+
+	// make sure the collection is initialized
+	if db.initTokenActivity != nil {
+		db.initTokenActivity.Do(func() { db.initTokenActivityCollection(col); db.initTokenActivity = nil })
+	}
+}
+
+// TrendingTokens loads the given number of tokens with the highest number
+// of transactions in buckets starting at, or after the given time.
+func (db *MongoDbBridge) TrendingTokens(since time.Time, count int64) ([]*types.TokenActivity, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colTokenActivity)
+
+	ld, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: types.FiTokenActivityBucket, Value: bson.D{{Key: "$gte", Value: since}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + types.FiTokenActivityToken},
+			{Key: types.FiTokenTransactionTokenType, Value: bson.D{{Key: "$first", Value: "$" + types.FiTokenTransactionTokenType}}},
+			{Key: types.FiTokenActivityCount, Value: bson.D{{Key: "$sum", Value: "$" + types.FiTokenActivityCount}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: types.FiTokenActivityCount, Value: bson.D{{Key: "$gt", Value: 0}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: types.FiTokenActivityCount, Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: count}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate token activity; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing token activity cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.TokenActivity, 0, count)
+	for ld.Next(ctx) {
+		var row struct {
+			Token string `bson:"_id"`
+			types.TokenActivity `bson:",inline"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode token activity; %s", err.Error())
+			return nil, err
+		}
+		row.TokenActivity.Token = common.HexToAddress(row.Token)
+		list = append(list, &row.TokenActivity)
+	}
+	return list, nil
+}
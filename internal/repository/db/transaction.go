@@ -25,6 +25,9 @@ const (
 	// fiTransactionBlock is the name of the block number field of the transaction.
 	fiTransactionBlock = "blk"
 
+	// fiTransactionBlockHash is the name of the block hash field of the transaction.
+	fiTransactionBlockHash = "blk_h"
+
 	// fiTransactionSender is the name of the address field of the sender account.
 	// db.transaction.createIndex({from:1}).
 	fiTransactionSender = "from"
@@ -39,6 +42,9 @@ const (
 
 	// fiTransactionTimeStamp is the name of the field of the transaction time stamp.
 	fiTransactionTimeStamp = "stamp"
+
+	// fiTransactionStatus is the name of the field of the transaction receipt status.
+	fiTransactionStatus = "stat"
 )
 
 // initTransactionsCollection initializes the transaction collection with
@@ -61,6 +67,13 @@ func (db *MongoDbBridge) initTransactionsCollection(col *mongo.Collection) {
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: fiTransactionRecipient, Value: 1}}})
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: fiTransactionTimeStamp, Value: 1}}})
 
+	// index failed transactions of the sender
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{
+		{Key: fiTransactionSender, Value: 1},
+		{Key: fiTransactionStatus, Value: 1},
+		{Key: fiTransactionOrdinalIndex, Value: -1},
+	}})
+
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
 		db.log.Panicf("can not create indexes for transaction collection; %s", err.Error())
@@ -171,14 +184,21 @@ func (db *MongoDbBridge) IsTransactionKnown(col *mongo.Collection, hash *common.
 }
 
 // initTrxList initializes list of transactions based on provided cursor and count.
-func (db *MongoDbBridge) initTrxList(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
+func (db *MongoDbBridge) initTrxList(col *mongo.Collection, cursor *string, count int32, filter *bson.D, skipTotal bool) (*types.TransactionList, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
-	// find how many transactions do we have in the database
-	total, err := db.listDocumentsCount(col, filter)
+	// find how many transactions do we have in the database;
+	// just check there are some if the total is not needed
+	var total int64
+	var err error
+	if skipTotal {
+		total, err = db.listDocumentsProbe(col, filter)
+	} else {
+		total, err = db.listDocumentsCount(col, filter)
+	}
 	if err != nil {
 		db.log.Errorf("can not count transactions")
 		return nil, err
@@ -188,12 +208,13 @@ func (db *MongoDbBridge) initTrxList(col *mongo.Collection, cursor *string, coun
 	db.log.Debugf("found %d filtered transactions", total)
 	list := types.TransactionList{
 		Collection: make([]*types.Transaction, 0),
-		Total:      uint64(total),
-		First:      0,
-		Last:       0,
-		IsStart:    total == 0,
-		IsEnd:      total == 0,
-		Filter:     *filter,
+		Total:        uint64(total),
+		TotalSkipped: skipTotal,
+		First:        0,
+		Last:         0,
+		IsStart:      total == 0,
+		IsEnd:        total == 0,
+		Filter:       *filter,
 	}
 
 	// is the list non-empty? return the list with properly calculated range marks
@@ -376,7 +397,8 @@ func (db *MongoDbBridge) TransactionsCount() (uint64, error) {
 }
 
 // Transactions pulls list of transaction hashes starting on the specified cursor.
-func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
+// The total number of transactions is not calculated if skipTotal is set.
+func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.D, skipTotal bool) (*types.TransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero transactions requested")
@@ -386,7 +408,7 @@ func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.
 	col := db.client.Database(db.dbName).Collection(coTransactions)
 
 	// init the list
-	list, err := db.initTrxList(col, cursor, count, filter)
+	list, err := db.initTrxList(col, cursor, count, filter, skipTotal)
 	if err != nil {
 		db.log.Errorf("can not build transactions list; %s", err.Error())
 		return nil, err
@@ -414,3 +436,74 @@ func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.
 
 	return list, nil
 }
+
+// blockRangeFilter provides the transactions filter of the given range of blocks.
+// The ordinal index starts with the block number, so the range uses the index.
+func blockRangeFilter(fromBlock uint64, toBlock uint64) bson.D {
+	return bson.D{
+		{Key: fiTransactionOrdinalIndex, Value: bson.D{
+			{Key: "$gte", Value: fromBlock << 14},
+			{Key: "$lt", Value: (toBlock + 1) << 14},
+		}},
+		{Key: fiTransactionBlock, Value: bson.D{
+			{Key: "$gte", Value: fromBlock},
+			{Key: "$lte", Value: toBlock},
+		}},
+	}
+}
+
+// BlockHashes loads hashes of the blocks in the given range known from the stored transactions.
+// Blocks without transactions are not included.
+func (db *MongoDbBridge) BlockHashes(fromBlock uint64, toBlock uint64) (map[uint64]common.Hash, error) {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	ld, err := col.Find(ctx, blockRangeFilter(fromBlock, toBlock), options.Find().SetProjection(bson.D{
+		{Key: fiTransactionBlock, Value: true},
+		{Key: fiTransactionBlockHash, Value: true},
+	}))
+	if err != nil {
+		db.log.Errorf("can not load hashes of blocks <#%d, #%d>; %s", fromBlock, toBlock, err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing block hashes cursor; %s", err.Error())
+		}
+	}()
+
+	list := make(map[uint64]common.Hash)
+	for ld.Next(ctx) {
+		var row struct {
+			Block *uint64 `bson:"blk"`
+			Hash  *string `bson:"blk_h"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode block hash; %s", err.Error())
+			return nil, err
+		}
+		if row.Block != nil && row.Hash != nil {
+			list[*row.Block] = common.HexToHash(*row.Hash)
+		}
+	}
+	return list, nil
+}
+
+// EraseTransactions removes transactions of the given range of blocks,
+// e.g. of blocks replaced by a chain reorg.
+func (db *MongoDbBridge) EraseTransactions(fromBlock uint64, toBlock uint64) error {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	res, err := col.DeleteMany(context.Background(), blockRangeFilter(fromBlock, toBlock))
+	if err != nil {
+		db.log.Errorf("can not erase transactions of blocks <#%d, #%d>; %s", fromBlock, toBlock, err.Error())
+		return err
+	}
+
+	if res.DeletedCount > 0 {
+		db.log.Noticef("%d transactions of blocks <#%d, #%d> erased", res.DeletedCount, fromBlock, toBlock)
+	}
+	return nil
+}
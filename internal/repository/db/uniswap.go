@@ -38,6 +38,11 @@ const (
 	fiSwapAmount1out = "am1out"
 	fiSwapReserve0   = "reserve0"
 	fiSwapReserve1   = "reserve1"
+
+	// fiSwapSuspicious flags swaps with an amount beyond the sanity bound;
+	// their raw amounts are kept in fiSwapRawAmounts instead of the aggregated fields.
+	fiSwapSuspicious = "sus"
+	fiSwapRawAmounts = "raw"
 )
 
 // swapAmountDecimalsCorrection represents the decimal correction on swap value.
@@ -46,6 +51,9 @@ var swapAmountDecimalsCorrection = new(big.Int).SetUint64(1000000000)
 // swapReserveDecimalsCorrection represents the decimal correction on swap reserve amount.
 var swapReserveDecimalsCorrection = new(big.Int).SetUint64(1000000000000)
 
+// notSuspiciousSwap is the aggregation filter leaving out swaps flagged as suspicious.
+var notSuspiciousSwap = bson.E{Key: fiSwapSuspicious, Value: bson.D{{Key: "$ne", Value: true}}}
+
 // getHash generates hash for swap from transaction hash and pair address
 func getHash(swap *types.Swap) *common.Hash {
 	hashBytes := swap.Hash.Big().Bytes()
@@ -125,7 +133,8 @@ func (db *MongoDbBridge) UniswapAdd(swap *types.Swap) error {
 	col := db.client.Database(db.dbName).Collection(coUniswap)
 
 	// check for zero amounts in the swap, because of future div by 0 during aggregation in db
-	if isZeroSwap(swap) {
+	// suspicious swaps are never aggregated
+	if !swap.Suspicious && isZeroSwap(swap) {
 		db.log.Debugf("swap from block %d will not be added, because swap amount is 0 after removing decimals", uint64(*swap.BlockNumber))
 		return nil
 	}
@@ -175,6 +184,28 @@ func swapData(base *bson.D, swap *types.Swap) bson.D {
 		bson.E{Key: fiSwapTxHash, Value: swap.Hash.String()},
 		bson.E{Key: fiSwapPair, Value: swap.Pair.String()},
 		bson.E{Key: fiSwapSender, Value: swap.Sender.String()},
+	)
+
+	// absurd amounts do not fit the aggregated fields; keep them raw
+	if swap.Suspicious {
+		raw := bson.A{}
+		for _, val := range []*big.Int{swap.Amount0In, swap.Amount0Out, swap.Amount1In, swap.Amount1Out, swap.Reserve0, swap.Reserve1} {
+			raw = append(raw, (*hexutil.Big)(val).String())
+		}
+		*base = append(*base,
+			bson.E{Key: fiSwapSuspicious, Value: true},
+			bson.E{Key: fiSwapRawAmounts, Value: raw},
+			bson.E{Key: fiSwapAmount0in, Value: uint64(0)},
+			bson.E{Key: fiSwapAmount0out, Value: uint64(0)},
+			bson.E{Key: fiSwapAmount1in, Value: uint64(0)},
+			bson.E{Key: fiSwapAmount1out, Value: uint64(0)},
+			bson.E{Key: fiSwapReserve0, Value: uint64(0)},
+			bson.E{Key: fiSwapReserve1, Value: uint64(0)},
+		)
+		return *base
+	}
+
+	*base = append(*base,
 		bson.E{Key: fiSwapAmount0in, Value: removeDecimals(swap.Amount0In, swapAmountDecimalsCorrection)},
 		bson.E{Key: fiSwapAmount0out, Value: removeDecimals(swap.Amount0Out, swapAmountDecimalsCorrection)},
 		bson.E{Key: fiSwapAmount1in, Value: removeDecimals(swap.Amount1In, swapAmountDecimalsCorrection)},
@@ -350,7 +381,8 @@ func (db *MongoDbBridge) UniswapVolume(pairAddress *common.Address, fromTime int
 	pipe := mongo.Pipeline{
 		{{Key: "$match", Value: bson.D{
 			{Key: "date", Value: dt},
-			{Key: "pair", Value: pairAddress.String()}}}},
+			{Key: "pair", Value: pairAddress.String()},
+			notSuspiciousSwap}}},
 		{{Key: "$group", Value: bson.D{
 			{Key: "_id", Value: "$pair"},
 			{Key: "total", Value: bson.M{"$sum": bson.D{
@@ -411,7 +443,8 @@ func (db *MongoDbBridge) UniswapTimeVolumes(pairAddress *common.Address, resolut
 	pipe := mongo.Pipeline{
 		{{Key: "$match", Value: bson.D{
 			{Key: "date", Value: dt},
-			{Key: "pair", Value: pairAddress.String()}}}},
+			{Key: "pair", Value: pairAddress.String()},
+			notSuspiciousSwap}}},
 		{{Key: "$group", Value: bson.D{
 			{Key: "_id", Value: getGroupBsonD(resolution)},
 			{Key: "total", Value: bson.M{"$sum": bson.D{
@@ -542,7 +575,8 @@ func (db *MongoDbBridge) UniswapTimePrices(pairAddress *common.Address, resoluti
 			{Key: "type", Value: bson.D{
 				{Key: "$not", Value: bson.D{
 					{Key: "$eq", Value: types.SwapSync}}}}},
-			{Key: "pair", Value: pairAddress.String()}}}},
+			{Key: "pair", Value: pairAddress.String()},
+			notSuspiciousSwap}}},
 		{{Key: "$sort", Value: bson.D{
 			{Key: "date", Value: 1},
 		}}},
@@ -602,7 +636,8 @@ func (db *MongoDbBridge) UniswapTimeReserves(pairAddress *common.Address, resolu
 	pipe := mongo.Pipeline{
 		{{Key: "$match", Value: bson.D{
 			{Key: "date", Value: getDateBsonD(fromTime, toTime)},
-			{Key: "pair", Value: pairAddress.String()}}}},
+			{Key: "pair", Value: pairAddress.String()},
+			notSuspiciousSwap}}},
 		{{Key: "$sort", Value: bson.D{
 			{Key: "date", Value: 1},
 		}}},
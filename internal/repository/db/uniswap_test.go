@@ -0,0 +1,62 @@
+package db
+
+import (
+	"math/big"
+	"testing"
+
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// testSwapField provides the value of the given field of the swap document.
+func testSwapField(doc bson.D, key string) interface{} {
+	for _, e := range doc {
+		if e.Key == key {
+			return e.Value
+		}
+	}
+	return nil
+}
+
+// TestSwapDataSuspicious tests swaps with absurd amounts are stored flagged,
+// with raw amounts and zero aggregated amounts.
+func TestSwapDataSuspicious(t *testing.T) {
+	one := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	swap := types.Swap{
+		Pair:       common.HexToAddress("0x1"),
+		Amount0In:  math.MaxBig256,
+		Amount0Out: big.NewInt(0),
+		Amount1In:  big.NewInt(0),
+		Amount1Out: one,
+		Reserve0:   one,
+		Reserve1:   one,
+	}
+
+	regular := swapData(nil, &swap)
+	if testSwapField(regular, fiSwapSuspicious) != nil || testSwapField(regular, fiSwapRawAmounts) != nil {
+		t.Errorf("expected regular swap not flagged")
+	}
+
+	swap.Suspicious = true
+	doc := swapData(nil, &swap)
+	if testSwapField(doc, fiSwapSuspicious) != true {
+		t.Errorf("expected suspicious swap flagged")
+	}
+	for _, key := range []string{fiSwapAmount0in, fiSwapAmount0out, fiSwapAmount1in, fiSwapAmount1out, fiSwapReserve0, fiSwapReserve1} {
+		if v, ok := testSwapField(doc, key).(uint64); !ok || v != 0 {
+			t.Errorf("expected zero aggregated %s, got %v", key, testSwapField(doc, key))
+		}
+	}
+
+	raw, ok := testSwapField(doc, fiSwapRawAmounts).(bson.A)
+	if !ok || len(raw) != 6 || raw[0] != "0x"+math.MaxBig256.Text(16) || raw[3] != "0xde0b6b3a7640000" {
+		t.Errorf("unexpected raw amounts %v", raw)
+	}
+
+	// the document must encode, the regular amounts fields would overflow
+	if _, err := bson.Marshal(doc); err != nil {
+		t.Errorf("can not encode suspicious swap; %s", err.Error())
+	}
+}
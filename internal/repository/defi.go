@@ -49,6 +49,12 @@ func (p *proxy) FMintTokenValue(owner *common.Address, token *common.Address, tp
 	return p.rpc.FMintTokenValue(owner, token, tp)
 }
 
+// FMintAccountTokens loads the list of token positions of the given fMint account
+// on the given side, collateral or debt.
+func (p *proxy) FMintAccountTokens(owner *common.Address, tp types.DefiTokenType) ([]*types.FMintTokenPosition, error) {
+	return p.rpc.FMintAccountTokens(owner, tp)
+}
+
 // FMintRewardsEarned represents the total amount of rewards
 // accumulated on the account for the excessive collateral deposits.
 func (p *proxy) FMintRewardsEarned(addr *common.Address) (hexutil.Big, error) {
@@ -61,6 +67,12 @@ func (p *proxy) FMintRewardsStashed(addr *common.Address) (hexutil.Big, error) {
 	return p.rpc.FMintRewardsStashed(addr)
 }
 
+// FMintRewards provides the pending rewards of the given fMint account
+// along with the reward token and the reward rate.
+func (p *proxy) FMintRewards(addr *common.Address) (*types.FMintRewards, error) {
+	return p.rpc.FMintRewards(addr)
+}
+
 // FMintCanClaimRewards resolves the fMint account flag for being allowed
 // to claim earned rewards.
 func (p *proxy) FMintCanClaimRewards(addr *common.Address) (bool, error) {
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"fmt"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// fMintGasReservePct represents the reserve added on top of the estimated gas, in percent.
+const fMintGasReservePct = 20
+
+// fMintDefaultGasLimit represents the gas limits suggested for fMint operations
+// if the gas could not be estimated for the given account.
+var fMintDefaultGasLimit = map[int]uint64{
+	types.FMintTrxTypeDeposit:  400000,
+	types.FMintTrxTypeWithdraw: 600000,
+	types.FMintTrxTypeMint:     700000,
+	types.FMintTrxTypeRepay:    400000,
+}
+
+// fMintLiquidatableWarning represents the warning of an operation breaching the collateral limit.
+const fMintLiquidatableWarning = "the operation would make the position liquidatable"
+
+// FMintCallData builds an unsigned call of the fMint minter contract performing the given
+// operation with the token and amount. If the owner is known, withdrawals and mints are checked
+// against the collateral limit and the gas limit is estimated for the owner.
+func (p *proxy) FMintCallData(op int, owner *common.Address, token *common.Address, amount *big.Int) (*types.FMintCallData, error) {
+	if err := validateFMintAmount(amount); err != nil {
+		return nil, err
+	}
+
+	ds, err := p.DefiConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := p.rpc.FMintCallData(op, token, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	cd := types.FMintCallData{
+		To:       ds.FMintContract,
+		Data:     data,
+		GasLimit: fMintGasLimit(op, nil),
+	}
+	if owner == nil {
+		return &cd, nil
+	}
+
+	cd.Warning = p.fMintCallWarning(op, owner, token, amount)
+	if cd.Warning == nil {
+		input := data.String()
+		gas, err := p.rpc.GasEstimate(&struct {
+			From  *common.Address
+			To    *common.Address
+			Value *hexutil.Big
+			Data  *string
+		}{From: owner, To: &cd.To, Data: &input})
+		if err == nil {
+			cd.GasLimit = fMintGasLimit(op, gas)
+		}
+	}
+	return &cd, nil
+}
+
+// fMintCallWarning checks if the withdrawal, or mint would make the owner position liquidatable.
+func (p *proxy) fMintCallWarning(op int, owner *common.Address, token *common.Address, amount *big.Int) *string {
+	var ok bool
+	var err error
+
+	switch op {
+	case types.FMintTrxTypeWithdraw:
+		ok, err = p.rpc.FMintCollateralCanDecrease(owner, token, amount)
+	case types.FMintTrxTypeMint:
+		ok, err = p.rpc.FMintDebtCanIncrease(owner, token, amount)
+	default:
+		return nil
+	}
+
+	// the check is only advisory, the contract enforces the limit anyway
+	if err != nil {
+		p.log.Debugf("fMint position of %s not checked; %s", owner.String(), err.Error())
+		return nil
+	}
+	if !ok {
+		w := fMintLiquidatableWarning
+		return &w
+	}
+	return nil
+}
+
+// validateFMintAmount checks the amount of an fMint operation is valid.
+func validateFMintAmount(amount *big.Int) error {
+	if amount == nil || amount.Sign() <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	if amount.BitLen() > 256 {
+		return fmt.Errorf("amount out of range")
+	}
+	return nil
+}
+
+// fMintGasLimit suggests the gas limit of an fMint operation from the estimated gas, if any.
+func fMintGasLimit(op int, estimate *hexutil.Uint64) hexutil.Uint64 {
+	if estimate == nil || *estimate == 0 {
+		return hexutil.Uint64(fMintDefaultGasLimit[op])
+	}
+	return *estimate + *estimate*fMintGasReservePct/100
+}
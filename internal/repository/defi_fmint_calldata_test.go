@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestValidateFMintAmount tests invalid fMint amounts are rejected.
+func TestValidateFMintAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  *big.Int
+		wantErr bool
+	}{
+		{"missing", nil, true},
+		{"zero", new(big.Int), true},
+		{"negative", big.NewInt(-1), true},
+		{"too big", new(big.Int).Lsh(big.NewInt(1), 256), true},
+		{"positive", big.NewInt(1000), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFMintAmount(tt.amount); (err != nil) != tt.wantErr {
+				t.Errorf("validateFMintAmount() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestFMintGasLimit tests the suggested gas limit falls back to the operation default.
+func TestFMintGasLimit(t *testing.T) {
+	if got := fMintGasLimit(types.FMintTrxTypeMint, nil); uint64(got) != fMintDefaultGasLimit[types.FMintTrxTypeMint] {
+		t.Errorf("expected default mint gas limit, got %d", got)
+	}
+
+	est := hexutil.Uint64(100000)
+	if got := fMintGasLimit(types.FMintTrxTypeMint, &est); got != 120000 {
+		t.Errorf("expected estimate with reserve, got %d", got)
+	}
+}
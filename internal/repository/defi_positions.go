@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync"
+)
+
+// DefiPositions provides the positions of the given account across the DeFi protocols,
+// e.g. the fMint position and the liquidity positions on the whitelisted DEX pairs,
+// if the DEX is configured. The protocols are loaded in parallel.
+func (p *proxy) DefiPositions(owner *common.Address) (*types.DefiPositions, error) {
+	tokens, err := p.DefiTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	var fm *types.FMintPosition
+	var lp []types.LiquidityPosition
+	var fmPartial, lpPartial bool
+	var fmErr, lpErr error
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fm, fmPartial, fmErr = p.fMintPosition(owner)
+	}()
+
+	if p.cfg.DeFi.Uniswap.Core.String() != config.EmptyAddress {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lp, lpPartial, lpErr = p.liquidityPositions(owner, defiTokenMap(tokens))
+		}()
+	}
+	wg.Wait()
+
+	if fmErr != nil {
+		return nil, fmErr
+	}
+	if lpErr != nil {
+		return nil, lpErr
+	}
+
+	if lp == nil {
+		lp = make([]types.LiquidityPosition, 0)
+	}
+	return &types.DefiPositions{FMint: fm, Liquidity: lp, IsPartial: fmPartial || lpPartial}, nil
+}
+
+// fMintPosition loads the fMint position of the account; nil if the account has no position.
+func (p *proxy) fMintPosition(owner *common.Address) (*types.FMintPosition, bool, error) {
+	coll, err := p.FMintAccountTokens(owner, types.DefiTokenTypeCollateral)
+	if err != nil {
+		return nil, false, err
+	}
+	debt, err := p.FMintAccountTokens(owner, types.DefiTokenTypeDebt)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(coll) == 0 && len(debt) == 0 {
+		return nil, false, nil
+	}
+
+	ds, err := p.DefiConfiguration()
+	if err != nil {
+		return nil, false, err
+	}
+
+	fm, partial := fMintPosition(coll, debt, ds.MinCollateralRatio4.ToInt(), ds.Decimals)
+	return fm, partial, nil
+}
+
+// liquidityPositions loads the liquidity positions of the account on the whitelisted DEX pairs.
+// The pairs are checked in parallel.
+func (p *proxy) liquidityPositions(owner *common.Address, tokens map[common.Address]*types.DefiToken) ([]types.LiquidityPosition, bool, error) {
+	pairs, err := p.UniswapKnownPairs()
+	if err != nil {
+		return nil, false, err
+	}
+
+	list := make([]*types.LiquidityPosition, len(pairs))
+	errs := make([]error, len(pairs))
+	var wg sync.WaitGroup
+	for i := range pairs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			list[i], errs[i] = p.liquidityPosition(owner, &pairs[i], tokens)
+		}(i)
+	}
+	wg.Wait()
+
+	res := make([]types.LiquidityPosition, 0)
+	var partial bool
+	for i, lp := range list {
+		if errs[i] != nil {
+			return nil, false, errs[i]
+		}
+		if lp != nil {
+			res = append(res, *lp)
+			partial = partial || lp.ValueUsd == nil
+		}
+	}
+	return res, partial, nil
+}
+
+// liquidityPosition loads the liquidity position of the account on the given pair;
+// nil if the account does not provide liquidity to the pair.
+func (p *proxy) liquidityPosition(owner *common.Address, pair *common.Address, tokens map[common.Address]*types.DefiToken) (*types.LiquidityPosition, error) {
+	bal, err := p.Erc20BalanceOf(pair, owner)
+	if err != nil {
+		return nil, err
+	}
+	if bal.ToInt().Sign() <= 0 {
+		return nil, nil
+	}
+
+	supply, err := p.Erc20TotalSupply(pair)
+	if err != nil {
+		return nil, err
+	}
+	pt, err := p.UniswapTokens(pair)
+	if err != nil {
+		return nil, err
+	}
+	reserves, err := p.UniswapReserves(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	lp := types.LiquidityPosition{Pair: *pair, Tokens: pt, Balance: bal}
+	lp.Share, lp.Amounts = liquidityShare(bal.ToInt(), supply.ToInt(), reserves)
+	lp.ValueUsd = p.liquidityValue(pt, lp.Amounts, tokens)
+	return &lp, nil
+}
+
+// liquidityValue calculates the USD value of the underlying amounts of a liquidity position;
+// nil is provided if any of the tokens is not priced by the oracle.
+func (p *proxy) liquidityValue(tokens []common.Address, amounts []hexutil.Big, known map[common.Address]*types.DefiToken) *float64 {
+	var total float64
+	for i := range tokens {
+		dt, ok := known[tokens[i]]
+		if !ok || i >= len(amounts) {
+			return nil
+		}
+
+		price, err := p.DefiTokenPrice(&tokens[i])
+		if err != nil || price.ToInt().Sign() <= 0 {
+			return nil
+		}
+		total += usdValue(amounts[i].ToInt(), dt.Decimals, price.ToInt(), dt.PriceDecimals)
+	}
+	return &total
+}
+
+// defiTokenMap indexes the given DeFi tokens by the address.
+func defiTokenMap(list []types.DefiToken) map[common.Address]*types.DefiToken {
+	res := make(map[common.Address]*types.DefiToken, len(list))
+	for i := range list {
+		res[list[i].Address] = &list[i]
+	}
+	return res
+}
+
+// fMintPosition combines the fMint token positions of an account. The position is partial
+// if any of the tokens has no value, e.g. the oracle does not provide its price.
+func fMintPosition(coll []*types.FMintTokenPosition, debt []*types.FMintTokenPosition, minRatio4 *big.Int, decimals int32) (*types.FMintPosition, bool) {
+	fm := types.FMintPosition{
+		Collateral:         coll,
+		Debt:               debt,
+		MinCollateralRatio: decimalValue(minRatio4, decimals),
+	}
+
+	var partial bool
+	for _, pos := range coll {
+		fm.CollateralUsd += decimalValue(pos.Value.ToInt(), pos.ValueDecimals)
+		partial = partial || (pos.Value.ToInt().Sign() == 0 && pos.Amount.ToInt().Sign() > 0)
+	}
+	for _, pos := range debt {
+		fm.DebtUsd += decimalValue(pos.Value.ToInt(), pos.ValueDecimals)
+		partial = partial || (pos.Value.ToInt().Sign() == 0 && pos.Amount.ToInt().Sign() > 0)
+	}
+
+	if fm.DebtUsd > 0 {
+		health := fm.CollateralUsd / fm.DebtUsd
+		fm.HealthRatio = &health
+	}
+	return &fm, partial
+}
+
+// liquidityShare calculates the percentage share of the pair liquidity represented by the balance
+// of the liquidity tokens and the amounts of the underlying tokens.
+func liquidityShare(bal *big.Int, supply *big.Int, reserves []hexutil.Big) (float64, []hexutil.Big) {
+	amounts := make([]hexutil.Big, len(reserves))
+	if supply.Sign() <= 0 {
+		return 0, amounts
+	}
+
+	for i := range reserves {
+		amo := new(big.Int).Div(new(big.Int).Mul(reserves[i].ToInt(), bal), supply)
+		amounts[i] = hexutil.Big(*amo)
+	}
+
+	share, _ := new(big.Float).Quo(new(big.Float).SetInt(bal), new(big.Float).SetInt(supply)).Float64()
+	return share * 100, amounts
+}
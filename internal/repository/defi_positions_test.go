@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testTokenPosition builds an fMint token position of the given amount and value with 2 value decimals.
+func testTokenPosition(amount int64, value int64) *types.FMintTokenPosition {
+	return &types.FMintTokenPosition{
+		Amount:        hexutil.Big(*big.NewInt(amount)),
+		Value:         hexutil.Big(*big.NewInt(value)),
+		ValueDecimals: 2,
+	}
+}
+
+// TestFMintPosition tests the fMint position values and health.
+func TestFMintPosition(t *testing.T) {
+	fm, partial := fMintPosition(
+		[]*types.FMintTokenPosition{testTokenPosition(10, 30000), testTokenPosition(5, 15000)},
+		[]*types.FMintTokenPosition{testTokenPosition(100, 15000)},
+		big.NewInt(30000), 4,
+	)
+	if partial {
+		t.Errorf("unexpected partial position")
+	}
+	if fm.CollateralUsd != 450 || fm.DebtUsd != 150 || fm.MinCollateralRatio != 3 {
+		t.Errorf("unexpected position %+v", fm)
+	}
+	if fm.HealthRatio == nil || *fm.HealthRatio != 3 {
+		t.Errorf("expected health 3, got %v", fm.HealthRatio)
+	}
+
+	// no debt, no health; unpriced collateral makes the position partial
+	fm, partial = fMintPosition([]*types.FMintTokenPosition{testTokenPosition(10, 0)}, nil, big.NewInt(30000), 4)
+	if !partial || fm.HealthRatio != nil {
+		t.Errorf("expected partial position without health, got %t %v", partial, fm.HealthRatio)
+	}
+}
+
+// TestLiquidityShare tests the underlying amounts of a liquidity position.
+func TestLiquidityShare(t *testing.T) {
+	share, amounts := liquidityShare(big.NewInt(25), big.NewInt(100), []hexutil.Big{
+		hexutil.Big(*big.NewInt(1000)),
+		hexutil.Big(*big.NewInt(4000)),
+	})
+	if share != 25 {
+		t.Errorf("expected 25%% share, got %f", share)
+	}
+	if amounts[0].ToInt().Int64() != 250 || amounts[1].ToInt().Int64() != 1000 {
+		t.Errorf("unexpected amounts %v", amounts)
+	}
+
+	// empty pair
+	if share, amounts := liquidityShare(big.NewInt(25), new(big.Int), make([]hexutil.Big, 2)); share != 0 || len(amounts) != 2 {
+		t.Errorf("unexpected empty pair share %f", share)
+	}
+}
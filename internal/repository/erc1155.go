@@ -2,12 +2,22 @@ package repository
 
 import (
 	"motif-api/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"math/big"
 )
 
 // Erc1155Contract returns an ERC1155 contract for the given address, if available.
+// The contract must declare the ERC1155 interface via ERC165.
 func (p *proxy) Erc1155Contract(addr *common.Address) (*types.Erc1155Contract, error) {
+	ok, err := p.rpc.Erc165SupportsInterface(addr, erc1155InterfaceId)
+	if err != nil {
+		p.log.Errorf("can not check ERC1155 interface of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("contract %s is not an ERC1155 multi-token", addr.String())
+	}
 	return &types.Erc1155Contract{Address: *addr}, nil
 }
 
@@ -22,7 +32,11 @@ func (p *proxy) Erc1155BalanceOf(token *common.Address, owner *common.Address, t
 }
 
 // Erc1155BalanceOfBatch provides amount of NFT tokens owned by given owner.
+// Each owner is paired with the token id on the same position.
 func (p *proxy) Erc1155BalanceOfBatch(token *common.Address, owners *[]common.Address, tokenIds []*big.Int) ([]*big.Int, error) {
+	if len(*owners) != len(tokenIds) {
+		return nil, fmt.Errorf("ERC1155 batch balance needs one token id per owner; got %d owners and %d token ids", len(*owners), len(tokenIds))
+	}
 	return p.rpc.Erc1155BalanceOfBatch(token, owners, tokenIds)
 }
 
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testSelBalanceOfBatch is the ERC1155 balanceOfBatch method selector.
+const testSelBalanceOfBatch = "0x4e1273f4"
+
+// testBalanceBatch is an ABI encoded array of balances 5 and 7.
+const testBalanceBatch = "0x" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"0000000000000000000000000000000000000000000000000000000000000002" +
+	"0000000000000000000000000000000000000000000000000000000000000005" +
+	"0000000000000000000000000000000000000000000000000000000000000007"
+
+// TestErc1155ContractInterface tests the ERC1155 contract is validated by the ERC165 interface.
+func TestErc1155ContractInterface(t *testing.T) {
+	tests := []struct {
+		name     string
+		supports string
+		wantErr  bool
+	}{
+		{"ERC1155 interface", "0x0000000000000000000000000000000000000000000000000000000000000001", false},
+		{"other interface", "0x0000000000000000000000000000000000000000000000000000000000000000", true},
+		{"no ERC165", "0x", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+				testSelSupportsInterface: tt.supports,
+			})), true)
+
+			addr := common.HexToAddress("0x05")
+			con, err := p.Erc1155Contract(&addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if err == nil && con.Address != addr {
+				t.Errorf("unexpected contract %s", con.Address.String())
+			}
+		})
+	}
+}
+
+// TestErc1155BalanceOfBatch tests the batch balance pairs owners with token ids.
+func TestErc1155BalanceOfBatch(t *testing.T) {
+	p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+		testSelBalanceOfBatch: testBalanceBatch,
+	})), true)
+
+	token := common.HexToAddress("0x05")
+	owners := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+
+	if _, err := p.Erc1155BalanceOfBatch(&token, &owners, []*big.Int{big.NewInt(1)}); err == nil {
+		t.Errorf("expected error on mismatched owners and token ids")
+	}
+
+	bal, err := p.Erc1155BalanceOfBatch(&token, &owners, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	if len(bal) != 2 || bal[0].Int64() != 5 || bal[1].Int64() != 7 {
+		t.Errorf("unexpected balances %v", bal)
+	}
+}
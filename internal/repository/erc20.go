@@ -4,10 +4,14 @@ import (
 	"motif-api/internal/config"
 	"motif-api/internal/repository/cache"
 	"motif-api/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+// erc20DefaultDecimals represents the decimals of ERC20 tokens not providing their own.
+const erc20DefaultDecimals = 18
+
 // Erc20Token returns an ERC20 token rfor the given address, if available.
 func (p *proxy) Erc20Token(addr *common.Address) (*types.Erc20Token, error) {
 	// get the token
@@ -63,13 +67,25 @@ func (p *proxy) loadErc20TokenDetails(token *types.Erc20Token) (*types.Erc20Toke
 	}
 
 	// get decimals
-	token.Decimals, err = p.rpc.Erc20Decimals(&token.Address)
+	token.Decimals = p.loadErc20Decimals(&token.Address)
+	return token, nil
+}
+
+// loadErc20Decimals loads the decimals of the given ERC20 token. The decimals are optional
+// in ERC20; tokens failing to provide them are assumed to use the common 18 decimals.
+func (p *proxy) loadErc20Decimals(addr *common.Address) int32 {
+	if val, ok := p.erc20Decimals.Load(*addr); ok {
+		return val.(int32)
+	}
+
+	dec, err := p.rpc.Erc20Decimals(addr)
 	if err != nil {
-		p.log.Errorf("ERC20 token not recognized at %s; %s", token.Address.String(), err.Error())
-		return nil, err
+		p.log.Warningf("ERC20 token %s decimals not available, using %d; %s", addr.String(), erc20DefaultDecimals, err.Error())
+		return erc20DefaultDecimals
 	}
 
-	return token, nil
+	p.erc20Decimals.Store(*addr, dec)
+	return dec
 }
 
 // Erc20Name provides information about the name of the ERC20 token.
@@ -92,6 +108,10 @@ func (p *proxy) Erc20Symbol(token *common.Address) (string, error) {
 
 // Erc20Decimals provides information about the decimals of the ERC20 token.
 func (p *proxy) Erc20Decimals(token *common.Address) (int32, error) {
+	if val, ok := p.erc20Decimals.Load(*token); ok {
+		return val.(int32), nil
+	}
+
 	tk, err := p.Erc20Token(token)
 	if err != nil {
 		return 0, err
@@ -99,10 +119,28 @@ func (p *proxy) Erc20Decimals(token *common.Address) (int32, error) {
 	return tk.Decimals, nil
 }
 
+// Erc20NonStandardTokens provides the list of ERC20 tokens which required
+// a non-standard decoding of their return values since the API server started.
+func (p *proxy) Erc20NonStandardTokens() []types.Erc20NonStandardToken {
+	return p.rpc.Erc20NonStandardTokens()
+}
+
 // Erc20BalanceOf load the current available balance of and ERC20 token identified by the token
 // contract address for an identified owner address.
 func (p *proxy) Erc20BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error) {
-	return p.rpc.Erc20BalanceOf(token, owner)
+	if val := p.cache.PullErc20Balance(token, owner); val != nil {
+		return *val, nil
+	}
+
+	val, err := p.rpc.Erc20BalanceOf(token, owner)
+	if err != nil {
+		return val, err
+	}
+
+	if err := p.cache.PushErc20Balance(token, owner, &val); err != nil {
+		p.log.Errorf("can not cache ERC20 token %s balance of %s; %s", token.String(), owner.String(), err.Error())
+	}
+	return val, nil
 }
 
 // Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
@@ -123,10 +161,44 @@ func (p *proxy) Erc20TokensList(count int32) ([]common.Address, error) {
 
 // Erc20LogoURL provides URL address of a logo of the ERC20 token.
 func (p *proxy) Erc20LogoURL(addr *common.Address) string {
+	logos := p.erc20LogoMap()
+
 	// do we know the token?
-	logo, ok := p.cfg.TokenLogo[*addr]
+	logo, ok := logos[*addr]
 	if !ok {
-		logo = p.cfg.TokenLogo[common.HexToAddress(config.EmptyAddress)]
+		logo = logos[common.HexToAddress(config.EmptyAddress)]
 	}
 	return logo
 }
+
+// erc20LogoMap provides the current map of ERC20 token logos.
+// The map is replaced as a whole on reload, it's never modified.
+func (p *proxy) erc20LogoMap() map[common.Address]string {
+	p.tokenLogoLock.RLock()
+	defer p.tokenLogoLock.RUnlock()
+	return p.cfg.TokenLogo
+}
+
+// ReloadErc20LogoMap reads the map of ERC20 token logos from the configured file again
+// and replaces the current map. It returns the number of tokens in the new map.
+func (p *proxy) ReloadErc20LogoMap() (int, error) {
+	if p.cfg.TokenLogoFilePath == "" {
+		return 0, fmt.Errorf("ERC20 tokens map file path not available")
+	}
+
+	logos, err := config.ReadErc20LogoMap(p.cfg.TokenLogoFilePath)
+	if err != nil {
+		p.log.Errorf("can not reload ERC20 logos; %s", err.Error())
+		return 0, err
+	}
+
+	p.tokenLogoLock.Lock()
+	p.cfg.TokenLogo = logos
+	p.tokenLogoLock.Unlock()
+
+	// the known tokens of the risk heuristics include the logo tokens
+	p.erc20Known.Store(&erc20KnownList{})
+
+	p.log.Noticef("reloaded %d ERC20 token logos from %s", len(logos), p.cfg.TokenLogoFilePath)
+	return len(logos), nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"fmt"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// erc20ApproveGasReservePct represents the reserve added on top of the estimated approve gas, in percent.
+const erc20ApproveGasReservePct = 20
+
+// erc20ApproveDefaultGasLimit represents the gas limit suggested for an ERC20 approve call
+// if the gas could not be estimated, e.g. for an unknown owner.
+const erc20ApproveDefaultGasLimit = 100000
+
+// Erc20ApproveCallData builds an unsigned ERC20 approve call unlocking the given amount
+// of the token for the spender. Nil, or zero amount builds a revocation of the allowance.
+// If the owner is known, the gas limit is estimated for the owner.
+func (p *proxy) Erc20ApproveCallData(token *common.Address, spender *common.Address, owner *common.Address, amount *big.Int) (*types.Erc20ApproveCallData, error) {
+	if amount == nil {
+		amount = new(big.Int)
+	}
+	if err := validateErc20ApproveAmount(amount); err != nil {
+		return nil, err
+	}
+
+	data, err := p.rpc.Erc20ApproveCallData(spender, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	cd := types.Erc20ApproveCallData{
+		To:       *token,
+		Data:     data,
+		GasLimit: erc20ApproveGasLimit(nil),
+		IsRevoke: amount.Sign() == 0,
+	}
+	if owner == nil {
+		return &cd, nil
+	}
+
+	input := data.String()
+	gas, err := p.rpc.GasEstimate(&struct {
+		From  *common.Address
+		To    *common.Address
+		Value *hexutil.Big
+		Data  *string
+	}{From: owner, To: token, Data: &input})
+	if err == nil {
+		cd.GasLimit = erc20ApproveGasLimit(gas)
+	}
+	return &cd, nil
+}
+
+// validateErc20ApproveAmount checks the amount of an ERC20 approve call is valid.
+func validateErc20ApproveAmount(amount *big.Int) error {
+	if amount.Sign() < 0 {
+		return fmt.Errorf("amount must not be negative")
+	}
+	if amount.BitLen() > 256 {
+		return fmt.Errorf("amount out of range")
+	}
+	return nil
+}
+
+// erc20ApproveGasLimit suggests the gas limit of an ERC20 approve call from the estimated gas, if any.
+func erc20ApproveGasLimit(estimate *hexutil.Uint64) hexutil.Uint64 {
+	if estimate == nil || *estimate == 0 {
+		return erc20ApproveDefaultGasLimit
+	}
+	return *estimate + *estimate*erc20ApproveGasReservePct/100
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestErc20ApproveCallData tests the approve call encoding and the revocation on missing amount.
+func TestErc20ApproveCallData(t *testing.T) {
+	p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{})), false)
+	token := common.HexToAddress("0x01")
+	spender := common.HexToAddress("0x02")
+
+	cd, err := p.Erc20ApproveCallData(&token, &spender, nil, big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("can not build approve call; %s", err.Error())
+	}
+	want := "0x095ea7b3" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+	if cd.To != token || cd.Data.String() != want || cd.IsRevoke || cd.GasLimit != erc20ApproveDefaultGasLimit {
+		t.Errorf("unexpected approve call %s / %s / %t / %d", cd.To.String(), cd.Data.String(), cd.IsRevoke, cd.GasLimit)
+	}
+
+	cd, err = p.Erc20ApproveCallData(&token, &spender, nil, nil)
+	if err != nil || !cd.IsRevoke || cd.Data.String()[74:] != "0000000000000000000000000000000000000000000000000000000000000000" {
+		t.Errorf("expected revocation, got %v; %v", cd, err)
+	}
+
+	if _, err := p.Erc20ApproveCallData(&token, &spender, nil, big.NewInt(-1)); err == nil {
+		t.Errorf("expected negative amount refused")
+	}
+}
+
+// TestErc20ApproveGasLimit tests the suggested gas limit falls back to the default.
+func TestErc20ApproveGasLimit(t *testing.T) {
+	if got := erc20ApproveGasLimit(nil); got != erc20ApproveDefaultGasLimit {
+		t.Errorf("expected default gas limit, got %d", got)
+	}
+
+	est := hexutil.Uint64(50000)
+	if got := erc20ApproveGasLimit(&est); got != 60000 {
+		t.Errorf("expected estimate with reserve, got %d", got)
+	}
+}
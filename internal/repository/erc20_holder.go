@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Erc20TokenHolders provides the given number of the top holders of the ERC20 token
+// sorted by their balance from the highest. The balances are accumulated from
+// the indexed token transfers.
+func (p *proxy) Erc20TokenHolders(token *common.Address, count int32) ([]*types.Erc20Holder, error) {
+	if count <= 0 || count > types.Erc20HoldersMaxCount {
+		count = types.Erc20HoldersMaxCount
+	}
+	return p.db.Erc20Holders(token, int64(count))
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Erc20TokenInfo provides the name, the symbol, the decimals and the total supply
+// of the given ERC20 token loaded from the node at once.
+func (p *proxy) Erc20TokenInfo(token *common.Address) (*types.Erc20TokenInfo, error) {
+	return p.rpc.Erc20TokenInfo(token)
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testSelTotalSupply is the ERC20 totalSupply method selector
+const testSelTotalSupply = "0x18160ddd"
+
+// TestErc20TokenInfo tests the token metadata is loaded in a single batch.
+func TestErc20TokenInfo(t *testing.T) {
+	node := newTestNode(t, testResults(map[string]string{
+		testSelName:        testAbiString,
+		testSelSymbol:      testAbiString,
+		testSelDecimals:    "0x0000000000000000000000000000000000000000000000000000000000000012",
+		testSelTotalSupply: "0x00000000000000000000000000000000000000000000000000000000000003e8",
+	}))
+	p := testErc20Proxy(t, node, false)
+	token := common.HexToAddress("0x01")
+
+	info, err := p.Erc20TokenInfo(&token)
+	if err != nil {
+		t.Fatalf("can not load token info; %s", err.Error())
+	}
+	if node.requests.Load() != 1 {
+		t.Errorf("expected a single request, got %d", node.requests.Load())
+	}
+	if info.Address != token {
+		t.Errorf("expected token %s, got %s", token.String(), info.Address.String())
+	}
+	if info.Name == nil || *info.Name != "Wrapped Token" || info.Symbol == nil || *info.Symbol != "Wrapped Token" {
+		t.Errorf("expected name and symbol, got %v, %v", info.Name, info.Symbol)
+	}
+	if info.Decimals == nil || *info.Decimals != 18 {
+		t.Errorf("expected 18 decimals, got %v", info.Decimals)
+	}
+	if info.TotalSupply == nil || info.TotalSupply.ToInt().Int64() != 1000 {
+		t.Errorf("expected total supply 1000, got %v", info.TotalSupply)
+	}
+}
+
+// TestErc20TokenInfoMissing tests values the token does not provide are left empty.
+func TestErc20TokenInfoMissing(t *testing.T) {
+	node := newTestNode(t, testResults(map[string]string{
+		testSelName:        testRevert,
+		testSelTotalSupply: "0x00000000000000000000000000000000000000000000000000000000000003e8",
+	}))
+	p := testErc20Proxy(t, node, false)
+	token := common.HexToAddress("0x02")
+
+	info, err := p.Erc20TokenInfo(&token)
+	if err != nil {
+		t.Fatalf("can not load token info; %s", err.Error())
+	}
+	if info.Name != nil || info.Symbol != nil || info.Decimals != nil {
+		t.Errorf("expected missing values empty, got %v, %v, %v", info.Name, info.Symbol, info.Decimals)
+	}
+	if info.TotalSupply == nil || info.TotalSupply.ToInt().Int64() != 1000 {
+		t.Errorf("expected total supply 1000, got %v", info.TotalSupply)
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestReloadErc20LogoMap tests the logo map is replaced safely while being read.
+func TestReloadErc20LogoMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	token := common.HexToAddress("0x01")
+
+	cfg := config.Config{
+		Log:               config.Log{Level: "CRITICAL", Format: "%{message}"},
+		TokenLogoFilePath: path,
+		TokenLogo: map[common.Address]string{
+			common.HexToAddress(config.EmptyAddress): "generic.svg",
+		},
+	}
+	p := &proxy{cfg: &cfg, log: logger.New(&cfg)}
+
+	if logo := p.Erc20LogoURL(&token); logo != "generic.svg" {
+		t.Fatalf("expected generic logo, got %s", logo)
+	}
+
+	data := `{"0x0000000000000000000000000000000000000000":"generic.svg","0x0000000000000000000000000000000000000001":"token.svg"}`
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("can not write logo map; %s", err.Error())
+	}
+
+	// read the logos while reloading
+	var wg sync.WaitGroup
+	wg.Add(4)
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = p.Erc20LogoURL(&token)
+			}
+		}()
+	}
+
+	count, err := p.ReloadErc20LogoMap()
+	wg.Wait()
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 tokens, got %d; %v", count, err)
+	}
+	if logo := p.Erc20LogoURL(&token); logo != "token.svg" {
+		t.Errorf("expected token logo, got %s", logo)
+	}
+
+	// broken file keeps the current map
+	if err := ioutil.WriteFile(path, []byte("{broken"), 0600); err != nil {
+		t.Fatalf("can not write logo map; %s", err.Error())
+	}
+	if _, err := p.ReloadErc20LogoMap(); err == nil {
+		t.Errorf("expected error on broken logo map")
+	}
+	if logo := p.Erc20LogoURL(&token); logo != "token.svg" {
+		t.Errorf("expected kept token logo, got %s", logo)
+	}
+}
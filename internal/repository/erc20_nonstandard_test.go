@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"motif-api/internal/repository/rpc"
+	"motif-api/internal/types"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testErc20Proxy creates a repository connected to the mock node.
+func testErc20Proxy(t *testing.T, node *testNode, tolerant bool) *proxy {
+	cfg := config.Config{
+		Log:        config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Lachesis:   config.Lachesis{Url: node.URL, MaxConcurrency: 4, RetryAttempts: 3, RetryBackoff: time.Millisecond},
+		Repository: config.Repository{TolerantErc20: tolerant},
+	}
+	log := logger.New(&cfg)
+
+	br, err := rpc.New(&cfg, log)
+	if err != nil {
+		t.Fatalf("can not connect mock node; %s", err.Error())
+	}
+	t.Cleanup(br.Close)
+	return &proxy{rpc: br, log: log, cfg: &cfg}
+}
+
+const (
+	// ERC20 method selectors
+	testSelName     = "0x06fdde03"
+	testSelSymbol   = "0x95d89b41"
+	testSelDecimals = "0x313ce567"
+
+	// testAbiString is the ABI encoded string "Wrapped Token"
+	testAbiString = "0x" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000000d" +
+		"5772617070656420546f6b656e00000000000000000000000000000000000000"
+
+	// testBytes32String is the bytes32 encoded string "MKR"
+	testBytes32String = "0x4d4b520000000000000000000000000000000000000000000000000000000000"
+)
+
+// TestErc20StandardValues tests the standard ERC20 values are not flagged.
+func TestErc20StandardValues(t *testing.T) {
+	br := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+		testSelName:     testAbiString,
+		testSelDecimals: "0x0000000000000000000000000000000000000000000000000000000000000012",
+	})), true).rpc
+	token := common.HexToAddress("0x01")
+
+	if name, err := br.Erc20Name(&token); err != nil || name != "Wrapped Token" {
+		t.Errorf("expected standard name, got %q; %v", name, err)
+	}
+	if deci, err := br.Erc20Decimals(&token); err != nil || deci != 18 {
+		t.Errorf("expected 18 decimals, got %d; %v", deci, err)
+	}
+	if list := br.Erc20NonStandardTokens(); len(list) != 0 {
+		t.Errorf("expected no non-standard tokens, got %v", list)
+	}
+}
+
+// TestErc20Bytes32Strings tests tokens returning name and symbol as bytes32.
+func TestErc20Bytes32Strings(t *testing.T) {
+	node := newTestNode(t, testResults(map[string]string{
+		testSelName:   testBytes32String,
+		testSelSymbol: testBytes32String,
+	}))
+	token := common.HexToAddress("0x02")
+
+	br := testErc20Proxy(t, node, true).rpc
+	if name, err := br.Erc20Name(&token); err != nil || name != "MKR" {
+		t.Errorf("expected bytes32 name, got %q; %v", name, err)
+	}
+	if symbol, err := br.Erc20Symbol(&token); err != nil || symbol != "MKR" {
+		t.Errorf("expected bytes32 symbol, got %q; %v", symbol, err)
+	}
+
+	list := br.Erc20NonStandardTokens()
+	if len(list) != 1 || list[0].Token != token || len(list[0].Variants) != 1 || list[0].Variants[0] != types.Erc20VariantBytes32String {
+		t.Errorf("expected flagged bytes32 token, got %v", list)
+	}
+
+	// the strict decoding rejects the value
+	strict := testErc20Proxy(t, node, false).rpc
+	if _, err := strict.Erc20Name(&token); err == nil {
+		t.Errorf("expected strict decoding to fail")
+	}
+}
+
+// TestErc20Decimals tests decoding of the non-standard decimals.
+func TestErc20Decimals(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int32
+		variant string
+	}{
+		{"uint256 word", "0x0000000000000000000000000000000000000000000000000000000000000006", 6, ""},
+		{"short value", "0x08", 8, types.Erc20VariantShortDecimals},
+		{"out of range", "0x0000000000000000000000000000000000000000000000000000000000000112", 0, ""},
+		{"missing value", "0x", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			br := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{testSelDecimals: tt.data})), true).rpc
+			token := common.HexToAddress("0x03")
+
+			deci, err := br.Erc20Decimals(&token)
+			if err != nil || deci != tt.want {
+				t.Errorf("expected %d decimals, got %d; %v", tt.want, deci, err)
+			}
+
+			list := br.Erc20NonStandardTokens()
+			if tt.variant == "" && len(list) != 0 {
+				t.Errorf("unexpected non-standard token %v", list)
+			}
+			if tt.variant != "" && (len(list) != 1 || list[0].Variants[0] != tt.variant) {
+				t.Errorf("expected %s token, got %v", tt.variant, list)
+			}
+		})
+	}
+}
+
+// TestErc20TokenDecimals tests the token decimals are kept once loaded
+// and tokens reverting the decimals call use the default decimals.
+func TestErc20TokenDecimals(t *testing.T) {
+	token := common.HexToAddress("0x03")
+
+	p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+		testSelName:     testAbiString,
+		testSelSymbol:   testAbiString,
+		testSelDecimals: testRevert,
+	})), true)
+	tok, err := p.loadErc20TokenDetails(&types.Erc20Token{Address: token})
+	if err != nil || tok.Decimals != erc20DefaultDecimals {
+		t.Fatalf("expected default decimals, got %v; %v", tok, err)
+	}
+	if _, ok := p.erc20Decimals.Load(token); ok {
+		t.Errorf("default decimals must not be kept")
+	}
+
+	p = testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+		testSelName:     testAbiString,
+		testSelSymbol:   testAbiString,
+		testSelDecimals: "0x0000000000000000000000000000000000000000000000000000000000000006",
+	})), true)
+	tok, err = p.loadErc20TokenDetails(&types.Erc20Token{Address: token})
+	if err != nil || tok.Decimals != 6 {
+		t.Fatalf("expected 6 decimals, got %v; %v", tok, err)
+	}
+
+	deci, err := p.Erc20Decimals(&token)
+	if err != nil || deci != 6 {
+		t.Errorf("expected kept 6 decimals, got %d; %v", deci, err)
+	}
+}
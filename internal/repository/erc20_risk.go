@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"bytes"
+	"github.com/ethereum/go-ethereum/common"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Erc20RiskFlags provides a set of heuristic flags signaling the ERC20 token
+// may be a scam, or a spam token. The flags are heuristics, not a definitive verdict.
+func (p *proxy) Erc20RiskFlags(addr *common.Address) (*types.Erc20RiskFlags, error) {
+	// get the token; we need the metadata
+	token, err := p.Erc20Token(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the top sender stats from the transfer index
+	st, err := p.db.Erc20TopSender(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// prep the flags
+	flags := types.Erc20RiskFlags{
+		MissingMetadata: isErc20MetadataMissing(token),
+		MassAirdrop:     isErc20MassAirdrop(st, &p.cfg.TokenRisk),
+	}
+
+	// add the sender details
+	if st != nil {
+		flags.AirdropSender = &st.Sender
+		flags.AirdropRecipients = st.Recipients
+	}
+
+	// do we mimic any of the known tokens?
+	flags.MimickedToken = erc20MimickedToken(token, p.erc20KnownTokens())
+	flags.MimicsKnownToken = flags.MimickedToken != nil
+	return &flags, nil
+}
+
+// erc20KnownList represents the list of known tokens along with the time it was loaded.
+type erc20KnownList struct {
+	tokens []*types.Erc20Token
+	loaded time.Time
+}
+
+// erc20KnownTokens provides a list of known tokens used for mimic detection.
+// The list is kept for the cache eviction time and dropped when the logo map is reloaded.
+func (p *proxy) erc20KnownTokens() []*types.Erc20Token {
+	if kl, ok := p.erc20Known.Load().(*erc20KnownList); ok && time.Since(kl.loaded) < p.cfg.Cache.Eviction {
+		return kl.tokens
+	}
+
+	// concurrent loads may race here; the lists are equivalent and the last one wins
+	list := p.loadErc20KnownTokens()
+	p.erc20Known.Store(&erc20KnownList{tokens: list, loaded: time.Now()})
+	return list
+}
+
+// loadErc20KnownTokens loads the list of known tokens used for mimic detection.
+// The list contains configured known tokens and the tokens with a known logo.
+func (p *proxy) loadErc20KnownTokens() []*types.Erc20Token {
+	// collect unique addresses
+	logos := p.erc20LogoMap()
+	adr := make(map[common.Address]bool, len(p.cfg.TokenRisk.KnownTokens)+len(logos))
+	for _, a := range p.cfg.TokenRisk.KnownTokens {
+		adr[a] = true
+	}
+	for a := range logos {
+		adr[a] = true
+	}
+	delete(adr, common.HexToAddress(config.EmptyAddress))
+
+	// load the tokens; skip those we can not load
+	list := make([]*types.Erc20Token, 0, len(adr))
+	for a := range adr {
+		a := a
+		token, err := p.Erc20Token(&a)
+		if err != nil {
+			p.log.Debugf("known token %s not available; %s", a.String(), err.Error())
+			continue
+		}
+		list = append(list, token)
+	}
+
+	// keep the order stable so the mimicked token does not depend on the map iteration
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].Address.Bytes(), list[j].Address.Bytes()) < 0
+	})
+	return list
+}
+
+// isErc20MetadataMissing checks if the token lacks standard metadata.
+// Zero decimals are legitimate for indivisible tokens, so only the name and symbol are checked.
+func isErc20MetadataMissing(token *types.Erc20Token) bool {
+	return strings.TrimSpace(token.Name) == "" || strings.TrimSpace(token.Symbol) == ""
+}
+
+// isErc20MassAirdrop checks if the top sender stats suggest the token
+// has been distributed by an airdrop-style mass transfer.
+func isErc20MassAirdrop(st *types.Erc20SenderStats, cfg *config.TokenRisk) bool {
+	// no transfers, no airdrop
+	if st == nil || st.Total == 0 {
+		return false
+	}
+
+	// the sender must reach enough recipients and make the majority of the transfers
+	return st.Recipients >= cfg.AirdropRecipients &&
+		float64(st.Transfers)/float64(st.Total) >= cfg.AirdropShare
+}
+
+// erc20MimickedToken finds a known token the given token mimics by its name, or symbol.
+// It returns nil if the token does not mimic any of the known tokens, or if it is a known token itself.
+// If several known tokens match, the first one in the given list is provided.
+func erc20MimickedToken(token *types.Erc20Token, known []*types.Erc20Token) *common.Address {
+	// the known token itself is not mimicking anything
+	for _, kt := range known {
+		if kt.Address == token.Address {
+			return nil
+		}
+	}
+
+	name, sym := erc20NormalizedLabel(token.Name), erc20NormalizedLabel(token.Symbol)
+	for _, kt := range known {
+		if kt.Address == token.Address {
+			continue
+		}
+
+		// same name, or symbol on a different address
+		if (sym != "" && sym == erc20NormalizedLabel(kt.Symbol)) || (name != "" && name == erc20NormalizedLabel(kt.Name)) {
+			return &kt.Address
+		}
+	}
+	return nil
+}
+
+// erc20NormalizedLabel converts the given token name, or symbol into a normalized
+// form so small cosmetic differences do not hide a mimicking label.
+func erc20NormalizedLabel(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"testing"
+	"time"
+)
+
+// TestIsErc20MassAirdrop tests the mass airdrop detection heuristic.
+func TestIsErc20MassAirdrop(t *testing.T) {
+	rc := config.TokenRisk{AirdropRecipients: 500, AirdropShare: 0.8}
+	sender := common.HexToAddress("0x1")
+
+	tests := []struct {
+		name string
+		st   *types.Erc20SenderStats
+		want bool
+	}{
+		{"no transfers", nil, false},
+		{"empty stats", &types.Erc20SenderStats{Sender: sender}, false},
+		{"airdrop", &types.Erc20SenderStats{Sender: sender, Recipients: 1000, Transfers: 1000, Total: 1100}, true},
+		{"threshold", &types.Erc20SenderStats{Sender: sender, Recipients: 500, Transfers: 800, Total: 1000}, true},
+		{"few recipients", &types.Erc20SenderStats{Sender: sender, Recipients: 499, Transfers: 499, Total: 500}, false},
+		{"organic", &types.Erc20SenderStats{Sender: sender, Recipients: 600, Transfers: 600, Total: 5000}, false},
+	}
+
+	for _, tc := range tests {
+		if got := isErc20MassAirdrop(tc.st, &rc); got != tc.want {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.want, got)
+		}
+	}
+}
+
+// TestErc20MimickedToken tests the known token mimic detection heuristic.
+func TestErc20MimickedToken(t *testing.T) {
+	known := []*types.Erc20Token{
+		{Address: common.HexToAddress("0xa"), Name: "Wrapped Motif", Symbol: "wMOTIF", Decimals: 18},
+	}
+
+	if adr := erc20MimickedToken(&types.Erc20Token{Address: common.HexToAddress("0xb"), Name: "Free Coin", Symbol: "W-MOTIF"}, known); adr == nil || *adr != known[0].Address {
+		t.Errorf("expected mimicked token %s, got %v", known[0].Address.String(), adr)
+	}
+	if adr := erc20MimickedToken(known[0], known); adr != nil {
+		t.Errorf("known token must not mimic itself, got %s", adr.String())
+	}
+
+	// the known token is not flagged regardless of its position in the list
+	twin := &types.Erc20Token{Address: common.HexToAddress("0xd"), Name: "Motif Wrapped", Symbol: "WMOTIF"}
+	both := []*types.Erc20Token{known[0], twin}
+	for i := 0; i < 2; i++ {
+		if adr := erc20MimickedToken(twin, both); adr != nil {
+			t.Errorf("known token must not be flagged, got %s", adr.String())
+		}
+		both[0], both[1] = both[1], both[0]
+	}
+	if adr := erc20MimickedToken(&types.Erc20Token{Address: common.HexToAddress("0xc"), Name: "Other", Symbol: "OTH"}, known); adr != nil {
+		t.Errorf("unexpected mimicked token %s", adr.String())
+	}
+}
+
+// TestIsErc20MetadataMissing tests the missing metadata heuristic.
+func TestIsErc20MetadataMissing(t *testing.T) {
+	tests := []struct {
+		name  string
+		token types.Erc20Token
+		want  bool
+	}{
+		{"complete", types.Erc20Token{Name: "Wrapped Motif", Symbol: "wMOTIF", Decimals: 18}, false},
+		{"zero decimals", types.Erc20Token{Name: "Ticket", Symbol: "TKT", Decimals: 0}, false},
+		{"no name", types.Erc20Token{Name: " ", Symbol: "TKT", Decimals: 18}, true},
+		{"no symbol", types.Erc20Token{Name: "Ticket", Decimals: 18}, true},
+	}
+
+	for _, tc := range tests {
+		if got := isErc20MetadataMissing(&tc.token); got != tc.want {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.want, got)
+		}
+	}
+}
+
+// TestErc20KnownTokensCache tests the known tokens are loaded once per cache eviction time.
+func TestErc20KnownTokensCache(t *testing.T) {
+	p := proxy{cfg: &config.Config{Cache: config.Cache{Eviction: time.Minute}}}
+	cached := []*types.Erc20Token{{Address: common.HexToAddress("0xa"), Name: "Wrapped Motif", Symbol: "wMOTIF"}}
+	p.erc20Known.Store(&erc20KnownList{tokens: cached, loaded: time.Now()})
+
+	// the fresh list is served without loading anything
+	if list := p.erc20KnownTokens(); len(list) != 1 || list[0] != cached[0] {
+		t.Fatalf("expected cached list, got %v", list)
+	}
+
+	// the stale list is reloaded; nothing is configured so the new list is empty
+	p.erc20Known.Store(&erc20KnownList{tokens: cached, loaded: time.Now().Add(-2 * time.Minute)})
+	if list := p.erc20KnownTokens(); len(list) != 0 {
+		t.Fatalf("expected reloaded empty list, got %v", list)
+	}
+}
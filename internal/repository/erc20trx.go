@@ -28,7 +28,8 @@ func (p *proxy) TokenTransactionsByCall(trxHash *common.Hash) ([]*types.TokenTra
 }
 
 // TokenTransactions provides list of ERC20/ERC721/ERC1155 transactions based on given filters.
-func (p *proxy) TokenTransactions(tokenType string, token *common.Address, tokenId *big.Int, acc *common.Address, txType *int32, cursor *string, count int32) (*types.TokenTransactionList, error) {
+// The total is not calculated if skipTotal is set.
+func (p *proxy) TokenTransactions(tokenType string, token *common.Address, tokenId *big.Int, acc *common.Address, txType *int32, cursor *string, count int32, skipTotal bool) (*types.TokenTransactionList, error) {
 	// prep the filter
 	fi := bson.D{}
 
@@ -77,10 +78,39 @@ func (p *proxy) TokenTransactions(tokenType string, token *common.Address, token
 	}
 
 	// do loading
-	return p.db.Erc20Transactions(cursor, count, &fi)
+	return p.db.Erc20Transactions(cursor, count, &fi, skipTotal)
 }
 
 // Erc20Assets provides a list of known assets for the given owner.
 func (p *proxy) Erc20Assets(owner common.Address, count int32) ([]common.Address, error) {
 	return p.db.Erc20Assets(owner, count)
 }
+
+// Erc20Transfers provides list of Transfer events of the given ERC20 token, including mints and burns,
+// optionally filtered by the sender and/or the recipient.
+func (p *proxy) Erc20Transfers(token common.Address, from *common.Address, to *common.Address, cursor *string, count int32) (*types.TokenTransactionList, error) {
+	fi := erc20TransferFilter(token, from, to)
+	return p.db.Erc20Transactions(cursor, count, &fi, false)
+}
+
+// erc20TransferFilter builds the filter of Transfer events of the given ERC20 token
+// sent by the given sender and received by the given recipient, if any.
+func erc20TransferFilter(token common.Address, from *common.Address, to *common.Address) bson.D {
+	fi := bson.D{
+		{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token},
+		{Key: types.FiTokenTransactionToken, Value: token.String()},
+		{Key: types.FiTokenTransactionType, Value: bson.D{{Key: "$in", Value: bson.A{
+			types.TokenTrxTypeTransfer,
+			types.TokenTrxTypeMint,
+			types.TokenTrxTypeBurn,
+		}}}},
+	}
+
+	if from != nil {
+		fi = append(fi, bson.E{Key: types.FiTokenTransactionSender, Value: from.String()})
+	}
+	if to != nil {
+		fi = append(fi, bson.E{Key: types.FiTokenTransactionRecipient, Value: to.String()})
+	}
+	return fi
+}
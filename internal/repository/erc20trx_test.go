@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"testing"
+)
+
+// TestErc20TransferFilter tests the transfer filter applies only the provided addresses.
+func TestErc20TransferFilter(t *testing.T) {
+	token := common.HexToAddress("0x01")
+	from := common.HexToAddress("0x02")
+	to := common.HexToAddress("0x03")
+
+	tests := []struct {
+		name     string
+		from, to *common.Address
+		want     map[string]string
+	}{
+		{"full history", nil, nil, map[string]string{}},
+		{"sender", &from, nil, map[string]string{types.FiTokenTransactionSender: from.String()}},
+		{"recipient", nil, &to, map[string]string{types.FiTokenTransactionRecipient: to.String()}},
+		{"both", &from, &to, map[string]string{types.FiTokenTransactionSender: from.String(), types.FiTokenTransactionRecipient: to.String()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fi := erc20TransferFilter(token, tt.from, tt.to)
+			m := fi.Map()
+
+			if m[types.FiTokenTransactionToken] != token.String() || m[types.FiTokenTransactionTokenType] != types.AccountTypeERC20Token {
+				t.Errorf("expected token filter, got %v", fi)
+			}
+			if _, ok := m[types.FiTokenTransactionType].(bson.D); !ok {
+				t.Errorf("expected transfer types filter, got %v", fi)
+			}
+			for _, key := range []string{types.FiTokenTransactionSender, types.FiTokenTransactionRecipient} {
+				want, ok := tt.want[key]
+				got, has := m[key]
+				if ok != has || (ok && got != want) {
+					t.Errorf("expected %s filter %q, got %v", key, want, got)
+				}
+			}
+		})
+	}
+}
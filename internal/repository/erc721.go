@@ -3,6 +3,7 @@ package repository
 import (
 	"motif-api/internal/repository/cache"
 	"motif-api/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -41,7 +42,14 @@ func (p *proxy) Erc721Contract(addr *common.Address) (*types.Erc721Contract, err
 }
 
 func (p *proxy) loadErc721ContractDetails(token *types.Erc721Contract) (*types.Erc721Contract, error) {
-	var err error
+	// the contract must declare the ERC721 interface
+	ok, err := p.rpc.Erc165SupportsInterface(&token.Address, erc721InterfaceId)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("contract %s is not an ERC721 token", token.Address.String())
+	}
 
 	// get the name (ignore fail - name is optional in ERC721)
 	token.Name, err = p.rpc.Erc721Name(&token.Address)
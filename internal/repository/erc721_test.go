@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testSelSupportsInterface is the ERC165 supportsInterface method selector.
+const testSelSupportsInterface = "0x01ffc9a7"
+
+// TestErc721ContractInterface tests the ERC721 contract loader validates the ERC165 interface.
+func TestErc721ContractInterface(t *testing.T) {
+	tests := []struct {
+		name     string
+		supports string
+		wantErr  bool
+	}{
+		{"ERC721 interface", "0x0000000000000000000000000000000000000000000000000000000000000001", false},
+		{"other interface", "0x0000000000000000000000000000000000000000000000000000000000000000", true},
+		{"no ERC165", "0x", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+				testSelSupportsInterface: tt.supports,
+				testSelName:              testAbiString,
+				testSelSymbol:            testAbiString,
+			})), true)
+
+			tok, err := p.loadErc721ContractDetails(&types.Erc721Contract{Address: common.HexToAddress("0x04")})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if err == nil && tok.Name != "Wrapped Token" {
+				t.Errorf("unexpected token name %q", tok.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"motif-api/internal/repository/rpc"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testFailoverBridge creates an RPC bridge connected to the given node endpoints.
+func testFailoverBridge(t *testing.T, healthCheck time.Duration, urls ...string) *rpc.FtmBridge {
+	cfg := config.Config{
+		Log:      config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Lachesis: config.Lachesis{Urls: urls, MaxConcurrency: 4, RetryAttempts: 3, RetryBackoff: time.Millisecond, HealthCheck: healthCheck},
+	}
+
+	br, err := rpc.New(&cfg, logger.New(&cfg))
+	if err != nil {
+		t.Fatalf("can not connect mock nodes; %s", err.Error())
+	}
+	t.Cleanup(br.Close)
+	return br
+}
+
+// TestNodeFailoverOnCall tests a call failed on a dropped node is retried on the next node.
+func TestNodeFailoverOnCall(t *testing.T) {
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+	live := newTestNode(t, testResults(map[string]string{"ftm_gasPrice": `"0x2a"`, "ftm_blockNumber": `"0x1"`}))
+
+	br := testFailoverBridge(t, time.Hour, dead.URL, live.URL)
+	for i := 0; i < 2; i++ {
+		gp, err := br.GasPrice()
+		if err != nil || gp.ToInt().Int64() != 42 {
+			t.Fatalf("expected gas price from the live node, got %s; %v", gp.String(), err)
+		}
+	}
+}
+
+// TestNodeFailoverOnHealthCheck tests the calls are routed to a healthy node
+// once the serving node fails the health check.
+func TestNodeFailoverOnHealthCheck(t *testing.T) {
+	// the first node answers calls, but not the health check ping
+	sick := newTestNode(t, testResults(map[string]string{"ftm_gasPrice": `"0x1"`}))
+	healthy := newTestNode(t, testResults(map[string]string{"ftm_gasPrice": `"0x2"`, "ftm_blockNumber": `"0x1"`}))
+
+	br := testFailoverBridge(t, 10*time.Millisecond, sick.URL, healthy.URL)
+	if gp, err := br.GasPrice(); err != nil || gp.ToInt().Int64() != 1 {
+		t.Fatalf("expected gas price from the first node, got %s; %v", gp.String(), err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if gp, err := br.GasPrice(); err == nil && gp.ToInt().Int64() == 2 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("calls did not fail over to the healthy node")
+}
+
+// TestNodeSingleUrl tests the bridge connects the single node Url
+// if the list of node endpoints is not configured.
+func TestNodeSingleUrl(t *testing.T) {
+	live := newTestNode(t, testResults(map[string]string{"ftm_gasPrice": `"0x2a"`, "ftm_blockNumber": `"0x1"`}))
+	cfg := config.Config{
+		Log:      config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Lachesis: config.Lachesis{Url: live.URL, MaxConcurrency: 4, RetryAttempts: 3, RetryBackoff: time.Millisecond, HealthCheck: time.Hour},
+	}
+
+	br, err := rpc.New(&cfg, logger.New(&cfg))
+	if err != nil {
+		t.Fatalf("can not connect the single node; %s", err.Error())
+	}
+	t.Cleanup(br.Close)
+
+	if gp, err := br.GasPrice(); err != nil || gp.ToInt().Int64() != 42 {
+		t.Fatalf("expected gas price from the single node, got %s; %v", gp.String(), err)
+	}
+}
+
+// testIpcNode implements the node calls served by the IPC test node.
+type testIpcNode struct{}
+
+// BlockNumber provides the head of the test chain.
+func (testIpcNode) BlockNumber() hexutil.Uint64 {
+	return 1
+}
+
+// GasPrice provides the gas price of the IPC test node.
+func (testIpcNode) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(7))
+}
+
+// TestNodeReconnect tests a node endpoint failed to connect on start is kept down,
+// and the health check connects it and routes the calls to it once it's available.
+func TestNodeReconnect(t *testing.T) {
+	ipc := filepath.Join(t.TempDir(), "node.ipc")
+	live := newTestNode(t, testResults(map[string]string{"ftm_gasPrice": `"0x2a"`, "ftm_blockNumber": `"0x1"`}))
+
+	br := testFailoverBridge(t, 10*time.Millisecond, ipc, live.URL)
+	if gp, err := br.GasPrice(); err != nil || gp.ToInt().Int64() != 42 {
+		t.Fatalf("expected gas price from the live node, got %s; %v", gp.String(), err)
+	}
+
+	// start the preferred node
+	srv := ftm.NewServer()
+	if err := srv.RegisterName("ftm", testIpcNode{}); err != nil {
+		t.Fatalf("can not register IPC node service; %s", err.Error())
+	}
+	l, err := net.Listen("unix", ipc)
+	if err != nil {
+		t.Fatalf("can not listen on %s; %s", ipc, err.Error())
+	}
+	go func() { _ = srv.ServeListener(l) }()
+	t.Cleanup(func() {
+		_ = l.Close()
+		srv.Stop()
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if gp, err := br.GasPrice(); err == nil && gp.ToInt().Int64() == 7 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("calls not routed to the reconnected node")
+}
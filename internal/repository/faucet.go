@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"time"
+)
+
+// FaucetSend sends the configured amount of native tokens from the server signature key
+// to the given address, if the faucet is enabled and the address was not funded
+// in the configured cooldown period.
+func (p *proxy) FaucetSend(to *common.Address) (*types.Transaction, error) {
+	if !p.cfg.Faucet.Enabled {
+		return nil, fmt.Errorf("faucet is not enabled")
+	}
+
+	amount, ok := new(big.Int).SetString(p.cfg.Faucet.Amount, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid faucet amount %s", p.cfg.Faucet.Amount)
+	}
+
+	// the database keeps the time in milliseconds; the release must match it
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	ok, err := p.db.FaucetReserve(to, now, p.cfg.Faucet.Cooldown)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("address %s was funded recently, try again later", to.String())
+	}
+
+	hash, err := p.rpc.FaucetSend(to, amount)
+	if err != nil {
+		// the address did not get anything, let it try again
+		if err := p.db.FaucetRelease(to, now); err != nil {
+			p.log.Errorf("faucet request of %s not released; %s", to.String(), err.Error())
+		}
+		return nil, err
+	}
+	return p.rpc.Transaction(hash)
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"motif-api/internal/repository/rpc"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestFaucetDisabled tests the faucet requests are rejected unless the faucet is enabled.
+func TestFaucetDisabled(t *testing.T) {
+	p := proxy{cfg: &config.Config{Faucet: config.Faucet{Amount: "1000", Cooldown: time.Hour}}}
+
+	to := common.HexToAddress("0x01")
+	if _, err := p.FaucetSend(&to); err == nil {
+		t.Errorf("expected disabled faucet to reject the request")
+	}
+}
+
+// TestFaucetTransfer tests the faucet transfer is signed by the server key for the connected chain.
+func TestFaucetTransfer(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("can not generate key; %s", err.Error())
+	}
+
+	node := newTestNode(t, testResults(map[string]string{
+		"eth_getTransactionCount": `"0x5"`,
+		"eth_gasPrice":            `"0x3b9aca00"`,
+		"eth_chainId":             `"0xfa2"`,
+		"eth_sendRawTransaction":  `"0x0000000000000000000000000000000000000000000000000000000000000000"`,
+	}))
+	cfg := config.Config{
+		Log:         config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Lachesis:    config.Lachesis{Url: node.URL, MaxConcurrency: 4, RetryAttempts: 3, RetryBackoff: time.Millisecond},
+		MySignature: config.ServerSignature{PrivateKey: *key},
+	}
+	br, err := rpc.New(&cfg, logger.New(&cfg))
+	if err != nil {
+		t.Fatalf("can not connect mock node; %s", err.Error())
+	}
+	t.Cleanup(br.Close)
+
+	to := common.HexToAddress("0x01")
+	hash, err := br.FaucetSend(&to, big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+
+	// the signature is deterministic, the expected transfer has the same hash
+	want, err := etc.SignTx(etc.NewTransaction(5, to, big.NewInt(1000), 21000, big.NewInt(1000000000), nil), etc.LatestSignerForChainID(big.NewInt(0xfa2)), key)
+	if err != nil {
+		t.Fatalf("can not sign; %s", err.Error())
+	}
+	if *hash != want.Hash() {
+		t.Errorf("expected transfer %s, got %s", want.Hash().String(), hash.String())
+	}
+}
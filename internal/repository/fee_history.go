@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FeeHistory provides the base fee and the priority fee rewards at the given percentiles
+// of the given number of the most recent blocks.
+func (p *proxy) FeeHistory(blockCount hexutil.Uint64, rewardPercentiles []float64) (*types.FeeHistory, error) {
+	if err := validateFeeHistory(blockCount, rewardPercentiles); err != nil {
+		return nil, err
+	}
+	return p.rpc.FeeHistory(blockCount, rewardPercentiles)
+}
+
+// validateFeeHistory checks the range and the percentiles of a fee history query.
+// The percentiles must be in the range of 0 to 100 in ascending order.
+func validateFeeHistory(blockCount hexutil.Uint64, rewardPercentiles []float64) error {
+	if blockCount < 1 || blockCount > types.FeeHistoryMaxBlocks {
+		return fmt.Errorf("block count must be between 1 and %d, %d given", types.FeeHistoryMaxBlocks, uint64(blockCount))
+	}
+	if len(rewardPercentiles) == 0 {
+		return fmt.Errorf("at least one reward percentile is required")
+	}
+	for i, pc := range rewardPercentiles {
+		if pc < 0 || pc > 100 {
+			return fmt.Errorf("reward percentile %f out of range of 0 to 100", pc)
+		}
+		if i > 0 && pc < rewardPercentiles[i-1] {
+			return fmt.Errorf("reward percentiles must be in ascending order")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestValidateFeeHistory tests the fee history query arguments validation.
+func TestValidateFeeHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		count       hexutil.Uint64
+		percentiles []float64
+		wantErr     bool
+	}{
+		{"valid", 20, []float64{10, 50, 90}, false},
+		{"max blocks", 1024, []float64{50}, false},
+		{"no blocks", 0, []float64{50}, true},
+		{"too many blocks", 1025, []float64{50}, true},
+		{"no percentiles", 20, []float64{}, true},
+		{"percentile out of range", 20, []float64{50, 101}, true},
+		{"descending percentiles", 20, []float64{90, 10}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFeeHistory(tt.count, tt.percentiles); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
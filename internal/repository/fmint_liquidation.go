@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FMintLiquidationPrices provides the liquidation price of each collateral token
+// of the given fMint account. Each price is calculated assuming the prices of the other
+// tokens of the position, both collateral and debt, stay at their current level.
+func (p *proxy) FMintLiquidationPrices(owner *common.Address) ([]types.FMintLiquidationPrice, error) {
+	coll, err := p.FMintAccountTokens(owner, types.DefiTokenTypeCollateral)
+	if err != nil {
+		return nil, err
+	}
+	debt, err := p.FMintAccountTokens(owner, types.DefiTokenTypeDebt)
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := p.DefiConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	fm, _ := fMintPosition(coll, debt, ds.MinCollateralRatio4.ToInt(), ds.Decimals)
+
+	list := make([]types.FMintLiquidationPrice, len(coll))
+	for i, pos := range coll {
+		price, err := p.defiTokenUsdPrice(&pos.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		value := decimalValue(pos.Value.ToInt(), pos.ValueDecimals)
+		list[i] = types.FMintLiquidationPrice{
+			Token:        pos.Token,
+			CurrentPrice: price,
+			Price:        fMintLiquidationPrice(value, price, fm.CollateralUsd-value, fm.DebtUsd, fm.MinCollateralRatio),
+		}
+	}
+	return list, nil
+}
+
+// FMintLiquidationRisk provides the distance of the collateral ratio of the given fMint account
+// from the minimal collateral ratio at the current oracle prices. It returns nil if the account
+// has no debt.
+func (p *proxy) FMintLiquidationRisk(owner *common.Address) (*types.FMintLiquidationRisk, error) {
+	coll, err := p.FMintAccountTokens(owner, types.DefiTokenTypeCollateral)
+	if err != nil {
+		return nil, err
+	}
+	debt, err := p.FMintAccountTokens(owner, types.DefiTokenTypeDebt)
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := p.DefiConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	// a missing price would skew the ratio either way
+	fm, partial := fMintPosition(coll, debt, ds.MinCollateralRatio4.ToInt(), ds.Decimals)
+	if partial {
+		return nil, fmt.Errorf("prices of fMint tokens of %s not available", owner.String())
+	}
+	return fMintLiquidationRisk(fm, p.cfg.DeFi.FMint.RiskBuffer), nil
+}
+
+// fMintLiquidationRisk calculates the margin of the position collateral ratio
+// above the minimal ratio and checks it against the given risk buffer in percent.
+// It returns nil if there is no debt.
+func fMintLiquidationRisk(fm *types.FMintPosition, buffer float64) *types.FMintLiquidationRisk {
+	if fm.HealthRatio == nil {
+		return nil
+	}
+
+	risk := types.FMintLiquidationRisk{
+		CollateralRatio:    *fm.HealthRatio,
+		MinCollateralRatio: fm.MinCollateralRatio,
+	}
+	if fm.MinCollateralRatio > 0 {
+		risk.Margin = (risk.CollateralRatio - fm.MinCollateralRatio) / fm.MinCollateralRatio * 100
+	}
+	risk.AtRisk = risk.Margin <= buffer
+	return &risk
+}
+
+// defiTokenUsdPrice provides the current USD price of the given DeFi token
+// from the on-chain price oracle, corrected for the price decimals.
+func (p *proxy) defiTokenUsdPrice(token *common.Address) (float64, error) {
+	dt, err := p.DefiToken(token)
+	if err != nil {
+		return 0, err
+	}
+	price, err := p.DefiTokenPrice(token)
+	if err != nil {
+		return 0, err
+	}
+	return decimalValue(price.ToInt(), dt.PriceDecimals), nil
+}
+
+// fMintLiquidationPrice calculates the price of a collateral token at which the position
+// drops below the minimal collateral ratio, if the value of the other collateral and the debt
+// does not change. The value of the token scales linearly with its price. It returns nil
+// if there is no debt, or the other collateral alone keeps the position above the ratio.
+// The price is above the current one if the position is already below the ratio.
+func fMintLiquidationPrice(value float64, price float64, otherValue float64, debtValue float64, minRatio float64) *float64 {
+	if debtValue <= 0 || value <= 0 || price <= 0 {
+		return nil
+	}
+
+	required := minRatio*debtValue - otherValue
+	if required <= 0 {
+		return nil
+	}
+
+	lp := price * required / value
+	return &lp
+}
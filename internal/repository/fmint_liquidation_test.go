@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"math"
+	"motif-api/internal/types"
+	"testing"
+)
+
+// TestFMintLiquidationPrice tests the liquidation price of a collateral token.
+func TestFMintLiquidationPrice(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      float64
+		price      float64
+		otherValue float64
+		debtValue  float64
+		minRatio   float64
+		want       *float64
+	}{
+		// 100 tokens at $3 backing $100 debt at 300%; liquidated at $3
+		{"single collateral at the ratio", 300, 3, 0, 100, 3, floatPtr(3)},
+		// 100 tokens at $6 backing $100 debt at 300%; liquidated at $3
+		{"single collateral above the ratio", 600, 6, 0, 100, 3, floatPtr(3)},
+		// other collateral of $150 covers half of the required $300
+		{"multi collateral", 600, 6, 150, 100, 3, floatPtr(1.5)},
+		// already below the ratio; liquidated above the current price
+		{"below the ratio", 200, 2, 0, 100, 3, floatPtr(3)},
+		{"other collateral covers the debt", 600, 6, 300, 100, 3, nil},
+		{"no debt", 600, 6, 0, 0, 3, nil},
+		{"no value", 0, 6, 0, 100, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fMintLiquidationPrice(tt.value, tt.price, tt.otherValue, tt.debtValue, tt.minRatio)
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("fMintLiquidationPrice() = %v, want %v", got, tt.want)
+			}
+			if got != nil && math.Abs(*got-*tt.want) > 1e-9 {
+				t.Errorf("fMintLiquidationPrice() = %f, want %f", *got, *tt.want)
+			}
+		})
+	}
+}
+
+// TestFMintLiquidationRisk tests the margin of an fMint position above the minimal collateral ratio.
+func TestFMintLiquidationRisk(t *testing.T) {
+	tests := []struct {
+		name       string
+		health     *float64
+		minRatio   float64
+		wantMargin float64
+		wantRisk   bool
+	}{
+		{"well above the ratio", floatPtr(6), 3, 100, false},
+		{"near the buffer edge", floatPtr(3.27), 3, 9, true},
+		{"within the buffer", floatPtr(3.15), 3, 5, true},
+		{"below the ratio", floatPtr(2.4), 3, -20, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fMintLiquidationRisk(&types.FMintPosition{HealthRatio: tt.health, MinCollateralRatio: tt.minRatio}, 10)
+			if got == nil {
+				t.Fatalf("fMintLiquidationRisk() = nil")
+			}
+			if math.Abs(got.Margin-tt.wantMargin) > 1e-9 {
+				t.Errorf("margin = %f, want %f", got.Margin, tt.wantMargin)
+			}
+			if got.AtRisk != tt.wantRisk {
+				t.Errorf("at risk = %t, want %t", got.AtRisk, tt.wantRisk)
+			}
+		})
+	}
+
+	if got := fMintLiquidationRisk(&types.FMintPosition{MinCollateralRatio: 3}, 10); got != nil {
+		t.Errorf("expected no risk without a debt, got %v", got)
+	}
+}
+
+// floatPtr provides a pointer to the given float value.
+func floatPtr(v float64) *float64 {
+	return &v
+}
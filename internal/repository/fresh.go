@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// freshReadKey represents the context key used to mark requests
+// demanding a fresh read bypassing the in-memory cache.
+type freshReadKey struct{}
+
+// WithFreshRead creates a derived context marking the request as demanding
+// a fresh read; cached read-only fields are loaded live and the cache is refreshed.
+func WithFreshRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadKey{}, true)
+}
+
+// IsFreshRead checks if the given request context demands a fresh read.
+func IsFreshRead(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	fr, ok := ctx.Value(freshReadKey{}).(bool)
+	return ok && fr
+}
+
+// RefreshAccount drops the cached account so the next access loads it live.
+func (p *proxy) RefreshAccount(addr *common.Address) {
+	p.log.Debugf("account %s cache refresh requested", addr.String())
+	p.cache.EvictAccount(addr)
+}
+
+// RefreshDelegation drops the cached delegation so the next access loads it live.
+func (p *proxy) RefreshDelegation(adr *common.Address, valID *hexutil.Big) {
+	p.log.Debugf("delegation of %s to #%d cache refresh requested", adr.String(), valID.ToInt().Uint64())
+	p.cache.EvictDelegation(*adr, valID)
+}
+
+// RefreshErc20Balance drops the cached ERC20 token balance of the owner so the next access loads it live.
+func (p *proxy) RefreshErc20Balance(token *common.Address, owner *common.Address) {
+	p.log.Debugf("ERC20 token %s balance of %s cache refresh requested", token.String(), owner.String())
+	p.cache.EvictErc20Balance(token, owner)
+}
+
+// RefreshPortfolio drops the cached portfolio distribution of the account and the cached
+// token balances it's built from so the next access loads them live.
+func (p *proxy) RefreshPortfolio(addr *common.Address) {
+	p.log.Debugf("portfolio of %s cache refresh requested", addr.String())
+	p.cache.EvictPortfolioDistribution(addr)
+
+	tokens, err := p.DefiTokens()
+	if err != nil {
+		p.log.Errorf("can not refresh portfolio balances of %s; %s", addr.String(), err.Error())
+		return
+	}
+	for i := range tokens {
+		p.cache.EvictErc20Balance(&tokens[i].Address, addr)
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"motif-api/internal/repository/cache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// TestRefreshErc20Balance tests the fresh read drops the cached token balance.
+func TestRefreshErc20Balance(t *testing.T) {
+	cfg := config.Config{
+		Log:   config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Cache: config.Cache{Eviction: time.Minute, MaxSize: 16, BalanceTTL: time.Minute},
+	}
+	p := proxy{cfg: &cfg, log: logger.New(&cfg)}
+
+	var err error
+	p.cache, err = cache.New(p.cfg, p.log)
+	if err != nil {
+		t.Fatalf("can not create cache; %s", err.Error())
+	}
+
+	token, owner, other := common.HexToAddress("0x0a"), common.HexToAddress("0x0b"), common.HexToAddress("0x0c")
+	val := hexutil.Big(*big.NewInt(100))
+	for _, adr := range []*common.Address{&owner, &other} {
+		if err := p.cache.PushErc20Balance(&token, adr, &val); err != nil {
+			t.Fatalf("can not cache balance; %s", err.Error())
+		}
+	}
+
+	p.RefreshErc20Balance(&token, &owner)
+	if bal := p.cache.PullErc20Balance(&token, &owner); bal != nil {
+		t.Errorf("expected the balance to be dropped, got %s", bal.String())
+	}
+	if bal := p.cache.PullErc20Balance(&token, &other); bal == nil || bal.ToInt().Cmp(val.ToInt()) != 0 {
+		t.Errorf("expected the balance of another owner to stay cached, got %v", bal)
+	}
+}
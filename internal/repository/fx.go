@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"motif-api/internal/types"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// fxFeedMaxSize represents the max size of the remote exchange rates response we accept.
+const fxFeedMaxSize = 1 << 20
+
+// FxRate provides the exchange rate of USD to the given currency, e.g. the value
+// of one USD in the currency. The rate of USD, or an empty currency, is one.
+func (p *proxy) FxRate(currency string) (float64, error) {
+	return fxRate(currency, p.cfg.Fx.Rates, p.remoteFxRates())
+}
+
+// remoteFxRates provides the exchange rates of the remote feed, if configured.
+// The rates are kept in the cache for the configured refresh period.
+func (p *proxy) remoteFxRates() map[string]float64 {
+	if p.cfg.Fx.Url == "" {
+		return nil
+	}
+
+	if fx := p.cache.PullFxRates(p.cfg.Fx.Refresh); fx != nil {
+		return fx.Rates
+	}
+
+	fx, err, _ := p.apiRequestGroup.Do("fx_rates", func() (interface{}, error) {
+		rates, err := p.fetchFxRates()
+		if err != nil {
+			return nil, err
+		}
+
+		fx := &types.FxRates{Rates: rates, Updated: time.Now().UTC()}
+		if err := p.cache.PushFxRates(fx); err != nil {
+			p.log.Errorf("can not cache exchange rates; %s", err.Error())
+		}
+		return fx, nil
+	})
+	if err != nil {
+		// the static rates are still available
+		p.log.Errorf("remote exchange rates not available; %s", err.Error())
+		return nil
+	}
+	return fx.(*types.FxRates).Rates
+}
+
+// fetchFxRates loads the exchange rates from the remote feed.
+func (p *proxy) fetchFxRates() (map[string]float64, error) {
+	client := http.Client{Timeout: p.cfg.Fx.Timeout}
+	res, err := client.Get(p.cfg.Fx.Url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			p.log.Errorf("can not close exchange rates response; %s", err.Error())
+		}
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rates feed responded with %s", res.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, fxFeedMaxSize))
+	if err != nil {
+		return nil, err
+	}
+	return parseFxResponse(data)
+}
+
+// parseFxResponse extracts the exchange rates from the remote feed response.
+// Currency codes are upper cased; invalid rates are ignored.
+func parseFxResponse(data []byte) (map[string]float64, error) {
+	var res struct {
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("invalid exchange rates response; %s", err.Error())
+	}
+
+	rates := make(map[string]float64, len(res.Rates))
+	for cur, rate := range res.Rates {
+		if rate > 0 {
+			rates[strings.ToUpper(cur)] = rate
+		}
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no exchange rates available")
+	}
+	return rates, nil
+}
+
+// fxRate finds the rate of the given currency; the remote rates take precedence
+// over the static ones. The static rates may come with lower case codes from the config.
+func fxRate(currency string, static map[string]float64, remote map[string]float64) (float64, error) {
+	cur := strings.ToUpper(strings.TrimSpace(currency))
+	if cur == "" || cur == types.FxBaseCurrency {
+		return 1, nil
+	}
+
+	if rate, ok := remote[cur]; ok {
+		return rate, nil
+	}
+	for code, rate := range static {
+		if strings.ToUpper(code) == cur {
+			return rate, nil
+		}
+	}
+	return 0, fmt.Errorf("currency %s is not supported", currency)
+}
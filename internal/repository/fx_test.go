@@ -0,0 +1,52 @@
+package repository
+
+import "testing"
+
+// TestParseFxResponse tests the remote exchange rates are decoded with upper case codes.
+func TestParseFxResponse(t *testing.T) {
+	rates, err := parseFxResponse([]byte(`{"base":"USD","rates":{"eur":0.92,"CZK":23.1,"XXX":0}}`))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if rates["EUR"] != 0.92 || rates["CZK"] != 23.1 {
+		t.Errorf("unexpected rates %v", rates)
+	}
+	if _, ok := rates["XXX"]; ok {
+		t.Errorf("invalid rate accepted")
+	}
+
+	if _, err := parseFxResponse([]byte(`{"error":"rate limit"}`)); err == nil {
+		t.Errorf("expected error on response without rates")
+	}
+}
+
+// TestFxRate tests the rate lookup in the static and remote rates.
+func TestFxRate(t *testing.T) {
+	static := map[string]float64{"eur": 0.9, "gbp": 0.8}
+	remote := map[string]float64{"EUR": 0.92}
+
+	tests := []struct {
+		currency string
+		want     float64
+	}{
+		{"", 1},
+		{"usd", 1},
+		{"EUR", 0.92},
+		{"gbp", 0.8},
+	}
+	for _, tt := range tests {
+		got, err := fxRate(tt.currency, static, remote)
+		if err != nil || got != tt.want {
+			t.Errorf("fxRate(%q) = %f, %v; expected %f", tt.currency, got, err, tt.want)
+		}
+	}
+
+	// the static rates are used if the remote feed is not available
+	if got, err := fxRate("EUR", static, nil); err != nil || got != 0.9 {
+		t.Errorf("expected static EUR rate, got %f, %v", got, err)
+	}
+
+	if _, err := fxRate("JPY", static, remote); err == nil || err.Error() != "currency JPY is not supported" {
+		t.Errorf("expected unsupported currency error, got %v", err)
+	}
+}
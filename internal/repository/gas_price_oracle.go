@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+)
+
+// suggestGasPrice provides the suggested gas price by the configured mode.
+// The price derived from the fee history falls back to the node suggestion
+// if the fee history is not available.
+func (p *proxy) suggestGasPrice() (hexutil.Big, error) {
+	pct := 50.0
+	switch p.cfg.Repository.GasPriceMode {
+	case config.GasPriceModeMedian:
+	case config.GasPriceModePercentile:
+		pct = p.cfg.Repository.GasPricePercentile
+	default:
+		return p.rpc.GasPrice()
+	}
+
+	fh, err := p.rpc.FeeHistory(hexutil.Uint64(p.cfg.Repository.GasPriceBlocks), []float64{pct})
+	if err == nil {
+		var gp *big.Int
+		if gp, err = feeHistoryGasPrice(fh); err == nil {
+			return hexutil.Big(*gp), nil
+		}
+	}
+
+	p.log.Warningf("gas price oracle not available, using the node suggestion; %s", err.Error())
+	return p.rpc.GasPrice()
+}
+
+// feeHistoryGasPrice calculates the median of the gas prices of the blocks of the fee history.
+// The gas price of a block is its base fee and the priority fee reward at the requested percentile,
+// so a single block with a spike of fees does not move the suggestion.
+func feeHistoryGasPrice(fh *types.FeeHistory) (*big.Int, error) {
+	if len(fh.Reward) == 0 || len(fh.BaseFeePerGas) < len(fh.Reward) {
+		return nil, fmt.Errorf("empty fee history")
+	}
+
+	prices := make([]*big.Int, 0, len(fh.Reward))
+	for i, rw := range fh.Reward {
+		if len(rw) == 0 {
+			continue
+		}
+		prices = append(prices, new(big.Int).Add(fh.BaseFeePerGas[i].ToInt(), rw[0].ToInt()))
+	}
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no rewards in fee history")
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+	mid := len(prices) / 2
+	if len(prices)%2 == 1 {
+		return prices[mid], nil
+	}
+	return new(big.Int).Rsh(new(big.Int).Add(prices[mid-1], prices[mid]), 1), nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestFeeHistoryGasPrice tests the gas price is derived from the fee history of the recent blocks.
+func TestFeeHistoryGasPrice(t *testing.T) {
+	wei := func(v int64) hexutil.Big {
+		return *(*hexutil.Big)(hexutil.MustDecodeBig(hexutil.EncodeUint64(uint64(v))))
+	}
+
+	tests := []struct {
+		name    string
+		fh      types.FeeHistory
+		want    int64
+		wantErr bool
+	}{
+		{"odd blocks", types.FeeHistory{
+			BaseFeePerGas: []hexutil.Big{wei(100), wei(100), wei(100), wei(100)},
+			Reward:        [][]hexutil.Big{{wei(10)}, {wei(1000)}, {wei(20)}},
+		}, 120, false},
+		{"even blocks", types.FeeHistory{
+			BaseFeePerGas: []hexutil.Big{wei(100), wei(200), wei(100), wei(100), wei(100)},
+			Reward:        [][]hexutil.Big{{wei(10)}, {wei(10)}, {wei(20)}, {wei(5000)}},
+		}, 165, false},
+		{"missing rewards skipped", types.FeeHistory{
+			BaseFeePerGas: []hexutil.Big{wei(100), wei(100), wei(100)},
+			Reward:        [][]hexutil.Big{{}, {wei(30)}},
+		}, 130, false},
+		{"empty", types.FeeHistory{}, 0, true},
+		{"no rewards", types.FeeHistory{
+			BaseFeePerGas: []hexutil.Big{wei(100), wei(100)},
+			Reward:        [][]hexutil.Big{{}},
+		}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gp, err := feeHistoryGasPrice(&tt.fh)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+			if err == nil && gp.Int64() != tt.want {
+				t.Errorf("expected gas price %d, got %s", tt.want, gp.String())
+			}
+		})
+	}
+}
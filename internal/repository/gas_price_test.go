@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/repository/cache"
+	"testing"
+	"time"
+)
+
+// TestGasPriceCache tests the suggested gas price is cached for the configured time.
+func TestGasPriceCache(t *testing.T) {
+	node := newTestNode(t, testResults(map[string]string{"ftm_gasPrice": `"0x3b9aca00"`}))
+	p := testErc20Proxy(t, node, true)
+	p.cfg.Cache = config.Cache{Eviction: time.Minute, MaxSize: 16}
+	p.cfg.Repository.GasPriceCache = 50 * time.Millisecond
+
+	var err error
+	p.cache, err = cache.New(p.cfg, p.log)
+	if err != nil {
+		t.Fatalf("can not create cache; %s", err.Error())
+	}
+
+	for i := 0; i < 3; i++ {
+		gp, err := p.GasPrice()
+		if err != nil || gp.ToInt().Int64() != 1000000000 {
+			t.Fatalf("unexpected gas price %s; %v", gp.String(), err)
+		}
+	}
+	if node.requests.Load() != 1 {
+		t.Errorf("expected 1 node call, got %d", node.requests.Load())
+	}
+
+	// stale price is loaded again
+	time.Sleep(60 * time.Millisecond)
+	if _, err := p.GasPrice(); err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	if node.requests.Load() != 2 {
+		t.Errorf("expected 2 node calls, got %d", node.requests.Load())
+	}
+}
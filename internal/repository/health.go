@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+)
+
+// Health provides the state of the dependencies of the API server. The server is healthy
+// if the node is reachable and synced, and the database responds.
+func (p *proxy) Health() *types.Health {
+	h := types.Health{}
+
+	sp, err := p.rpc.SyncProgress()
+	if err != nil {
+		msg := err.Error()
+		h.NodeError = &msg
+	} else {
+		h.Node = sp
+		if sp.IsSyncing {
+			msg := "node is syncing"
+			h.NodeError = &msg
+		}
+	}
+
+	if err := p.db.Ping(); err != nil {
+		msg := err.Error()
+		h.DatabaseError = &msg
+	}
+
+	h.Healthy = h.NodeError == nil && h.DatabaseError == nil
+	return &h
+}
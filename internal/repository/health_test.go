@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"testing"
+)
+
+// TestSyncProgress tests the sync progress of synced and syncing nodes.
+func TestSyncProgress(t *testing.T) {
+	p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+		"ftm_syncing":     `false`,
+		"ftm_blockNumber": `"0x64"`,
+	})), true)
+
+	sp, err := p.rpc.SyncProgress()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	if sp.IsSyncing || sp.CurrentBlock != 100 || sp.HighestBlock != 100 {
+		t.Errorf("unexpected synced node progress %+v", sp)
+	}
+
+	p = testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+		"ftm_syncing": `{"startingBlock":"0x0","currentBlock":"0x32","highestBlock":"0x64"}`,
+	})), true)
+
+	sp, err = p.rpc.SyncProgress()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	if !sp.IsSyncing || sp.CurrentBlock != 50 || sp.HighestBlock != 100 {
+		t.Errorf("unexpected syncing node progress %+v", sp)
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UpdateIndexerCheckpoint stores the last block processed by the given indexer.
+func (p *proxy) UpdateIndexerCheckpoint(name string, blk uint64) error {
+	return p.db.UpdateIndexerCheckpoint(name, blk)
+}
+
+// IndexProgress provides the indexing progress of the scanner indexers
+// compared to the current height of the chain.
+func (p *proxy) IndexProgress() ([]types.IndexProgress, error) {
+	height, err := p.BlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := map[string]bool{
+		types.IndexerTransactions: true,
+		types.IndexerTransfers:    true,
+		types.IndexerStaking:      true,
+		types.IndexerPrices:       p.cfg.PriceSnapshot.Enabled,
+	}
+
+	list := make([]types.IndexProgress, 0, len(indexers))
+	for _, name := range indexers {
+		var blk uint64
+		if enabled[name] {
+			blk, err = p.db.IndexerCheckpoint(name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		list = append(list, indexProgress(name, enabled[name], blk, height.ToInt().Uint64()))
+	}
+	return list, nil
+}
+
+// indexers is the list of indexers reported by the index progress.
+var indexers = []string{
+	types.IndexerTransactions,
+	types.IndexerTransfers,
+	types.IndexerStaking,
+	types.IndexerPrices,
+}
+
+// indexProgress builds the progress of an indexer from its checkpoint and the chain height.
+// Disabled indexers, and indexers without a checkpoint, don't report the last block and lag.
+func indexProgress(name string, enabled bool, blk uint64, height uint64) types.IndexProgress {
+	ip := types.IndexProgress{Name: name, Enabled: enabled}
+	if !enabled || blk == 0 {
+		return ip
+	}
+
+	var lag uint64
+	if height > blk {
+		lag = height - blk
+	}
+
+	ip.LastBlock = (*hexutil.Uint64)(&blk)
+	ip.Lag = (*hexutil.Uint64)(&lag)
+	return ip
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"testing"
+)
+
+// TestIndexProgress tests the progress of an indexer is derived from its checkpoint.
+func TestIndexProgress(t *testing.T) {
+	ip := indexProgress(types.IndexerTransfers, true, 90, 100)
+	if !ip.Enabled || ip.LastBlock == nil || uint64(*ip.LastBlock) != 90 {
+		t.Fatalf("unexpected progress %+v", ip)
+	}
+	if ip.Lag == nil || uint64(*ip.Lag) != 10 {
+		t.Errorf("expected lag 10, got %v", ip.Lag)
+	}
+
+	// the checkpoint may be ahead of a lagging node
+	ip = indexProgress(types.IndexerTransfers, true, 110, 100)
+	if ip.Lag == nil || uint64(*ip.Lag) != 0 {
+		t.Errorf("expected no lag, got %v", ip.Lag)
+	}
+
+	// disabled indexer
+	ip = indexProgress(types.IndexerPrices, false, 90, 100)
+	if ip.Enabled || ip.LastBlock != nil || ip.Lag != nil {
+		t.Errorf("expected disabled indexer, got %+v", ip)
+	}
+
+	// no checkpoint yet
+	ip = indexProgress(types.IndexerStaking, true, 0, 100)
+	if !ip.Enabled || ip.LastBlock != nil || ip.Lag != nil {
+		t.Errorf("expected unknown progress, got %+v", ip)
+	}
+}
@@ -9,6 +9,8 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
+	"encoding/json"
 	"motif-api/internal/config"
 	"motif-api/internal/repository/rpc/contracts"
 	"motif-api/internal/types"
@@ -25,12 +27,30 @@ type Repository interface {
 	// Account returns account at Opera blockchain for an address, nil if not found.
 	Account(*common.Address) (*types.Account, error)
 
+	// RefreshAccount drops the cached account so the next access loads it live.
+	RefreshAccount(*common.Address)
+
 	// AccountBalance returns the current balance of an account at Opera blockchain.
 	AccountBalance(*common.Address) (*hexutil.Big, error)
 
+	// AccountBalanceAt returns the balance of an account at the given block of Opera blockchain.
+	AccountBalanceAt(*common.Address, *hexutil.Uint64) (*hexutil.Big, error)
+
+	// AccountBalanceAtContext returns the balance of an account as AccountBalanceAt does
+	// within the request context; failures are logged with the request correlation ID.
+	AccountBalanceAtContext(context.Context, *common.Address, *hexutil.Uint64) (*hexutil.Big, error)
+
+	// AccountBalances returns the current balances of the given accounts loaded concurrently.
+	// Balances failed to load are reported by the errors map instead.
+	AccountBalances([]common.Address) (map[common.Address]*hexutil.Big, map[common.Address]error, error)
+
 	// AccountNonce returns the current number of sent transactions of an account at Opera blockchain.
 	AccountNonce(*common.Address) (*hexutil.Uint64, error)
 
+	// AccountPendingNonce returns the number of sent transactions of an account at Opera blockchain
+	// including the transactions pending in the transaction pool of the node.
+	AccountPendingNonce(*common.Address) (*hexutil.Uint64, error)
+
 	// AccountTransactions returns list of transaction hashes for account at Opera blockchain.
 	//
 	// String cursor represents cursor based on which the list is loaded. If null,
@@ -45,7 +65,16 @@ type Repository interface {
 	// of transactions newer than that.
 	//
 	// Transactions are always sorted from newer to older.
-	AccountTransactions(*common.Address, *string, int32) (*types.TransactionList, error)
+	// The total is not calculated if skipTotal is set.
+	AccountTransactions(addr *common.Address, cursor *string, count int32, skipTotal bool) (*types.TransactionList, error)
+
+	// AccountFailedTransactions returns list of outbound transactions of the account which failed,
+	// sorted from newer to older.
+	AccountFailedTransactions(addr *common.Address, cursor *string, count int32) (*types.TransactionList, error)
+
+	// AccountOverviews provides the live overviews of the given accounts loaded
+	// from the node in a single batch. Each overview degrades independently on failure.
+	AccountOverviews([]common.Address) ([]*types.AccountOverview, error)
 
 	// AccountsActive total number of accounts known to repository.
 	AccountsActive() (hexutil.Uint64, error)
@@ -68,6 +97,12 @@ type Repository interface {
 	// UpdateLastKnownBlock update record about last known block.
 	UpdateLastKnownBlock(blockNo *hexutil.Uint64) error
 
+	// UpdateIndexerCheckpoint stores the last block processed by the given indexer.
+	UpdateIndexerCheckpoint(name string, blk uint64) error
+
+	// IndexProgress provides the indexing progress of the scanner indexers.
+	IndexProgress() ([]types.IndexProgress, error)
+
 	// ObservedHeaders provides a channel fed with new headers observed
 	// by the connected blockchain node.
 	ObservedHeaders() chan *etc.Header
@@ -89,16 +124,62 @@ type Repository interface {
 	// CacheBlock puts a block to the internal block ring cache.
 	CacheBlock(blk *types.Block)
 
+	// AddReorg stores a chain reorg record in the persistent storage.
+	AddReorg(*types.Reorg) error
+
+	// BlockHashes provides hashes of the blocks in the given range known from the stored transactions.
+	// Blocks without transactions are not included.
+	BlockHashes(fromBlock uint64, toBlock uint64) (map[uint64]common.Hash, error)
+
+	// RevertTransactions removes transactions of the given range of blocks replaced by a chain reorg.
+	RevertTransactions(fromBlock uint64, toBlock uint64) error
+
+	// Reorgs pulls list of chain reorgs starting at the specified cursor.
+	Reorgs(*string, int32) (*types.ReorgList, error)
+
+	// StoreStakeChange stores a change of a delegator stake.
+	StoreStakeChange(*types.StakeChange) error
+
+	// StakeChanges provides list of stake changes of the given delegator,
+	// optionally limited to the given validator.
+	StakeChanges(delegator *common.Address, validator *hexutil.Big, cursor *string, count int32) (*types.StakeChangeList, error)
+
+	// RevertStakeChanges removes stake changes of the given range of blocks replaced by a chain reorg.
+	RevertStakeChanges(fromBlock uint64, toBlock uint64) error
+
+	// StoreMethodCall stores a call of a watched contract method.
+	StoreMethodCall(*types.MethodCall) error
+
+	// MethodCalls provides list of recorded calls of watched methods of the given contract,
+	// optionally limited to the method given by its name, or its selector.
+	MethodCalls(contract *common.Address, method *string, cursor *string, count int32) (*types.MethodCallList, error)
+
+	// RevertMethodCalls removes method calls of the given range of blocks replaced by a chain reorg.
+	RevertMethodCalls(fromBlock uint64, toBlock uint64) error
+
+	// UpdateChainMetrics updates the chain throughput metrics of the recent blocks window.
+	UpdateChainMetrics(*types.ChainMetrics)
+
+	// ChainMetrics provides the chain throughput metrics of the recent blocks window.
+	// It returns nil if not enough blocks have been indexed yet.
+	ChainMetrics() *types.ChainMetrics
+
 	// Contract extract a smart contract information by address if available.
 	Contract(*common.Address) (*types.Contract, error)
 
 	// Contracts returns list of smart contracts at Opera blockchain.
 	Contracts(bool, *string, int32) (*types.ContractList, error)
 
+	// ContractAbi provides the ABI of the contract at the given address; the ABI
+	// of a locally validated contract is preferred, the remote source is used otherwise,
+	// if configured. Empty ABI is provided if the ABI is not known.
+	ContractAbi(*common.Address) (string, error)
+
 	// ValidateContract tries to validate contract byte code using
-	// provided source code. If successful, the contract information
-	// is updated the the repository.
-	ValidateContract(*types.Contract) error
+	// provided source code and the Solidity compiler of the given version;
+	// the default compiler is used for an empty version. If successful, the contract
+	// information is updated the the repository.
+	ValidateContract(*types.Contract, string) error
 
 	// StoreContract updates the contract in repository.
 	StoreContract(*types.Contract) error
@@ -153,7 +234,8 @@ type Repository interface {
 	Transaction(*common.Hash) (*types.Transaction, error)
 
 	// Transactions returns list of transaction hashes at Opera blockchain.
-	Transactions(*string, int32) (*types.TransactionList, error)
+	// The total is not calculated if skipTotal is set, unless it's available for free.
+	Transactions(cursor *string, count int32, skipTotal bool) (*types.TransactionList, error)
 
 	// TransactionsCount returns total number of transactions in the block chain.
 	TransactionsCount() (uint64, error)
@@ -170,9 +252,39 @@ type Repository interface {
 	// CacheTransaction puts a transaction to the internal ring cache.
 	CacheTransaction(trx *types.Transaction)
 
+	// TransactionReceipt provides the receipt of the given transaction loaded from the node.
+	// Nil receipt is provided for a pending transaction.
+	TransactionReceipt(*common.Hash) (*types.TransactionReceipt, error)
+
+	// TransactionStatus provides the processing status of the given transaction
+	// along with the number of its confirmations.
+	TransactionStatus(*common.Hash) (*types.TrxStatus, error)
+
+	// FaucetSend sends the configured amount of native tokens from the server key
+	// to the given address, if the address was not funded in the cooldown period.
+	FaucetSend(*common.Address) (*types.Transaction, error)
+
+	// ResolveName provides the address registered for the given name
+	// in the configured name registry; nil if not registered.
+	ResolveName(string) (*common.Address, error)
+
+	// LookupAddress provides the name of the given address by the reverse record
+	// in the configured name registry; nil if there is none.
+	LookupAddress(*common.Address) (*string, error)
+
+	// DecodeLog decodes the given log record by the ABI of the emitting contract,
+	// if the contract is validated. Nil event is provided if the ABI is not known.
+	DecodeLog(*etc.Log) (*types.DecodedEvent, error)
+
 	// SendTransaction sends raw signed and RLP encoded transaction to the block chain.
 	SendTransaction(hexutil.Bytes) (*types.Transaction, error)
 
+	// TransactionRevertReason provides the decoded revert reason of a failed transaction, if recoverable.
+	TransactionRevertReason(trx *types.Transaction) (*string, error)
+
+	// TransactionTrace provides the internal call trace of the given transaction.
+	TransactionTrace(*common.Hash) (json.RawMessage, error)
+
 	// LastValidatorId returns the last validator id in Opera blockchain.
 	LastValidatorId() (uint64, error)
 
@@ -191,6 +303,10 @@ type Repository interface {
 	// ValidatorByAddress extract a staker information by address.
 	ValidatorByAddress(*common.Address) (*types.Validator, error)
 
+	// Validators provides the list of all validators registered in SFC smart contract.
+	// The list is cached for the configured cache eviction time.
+	Validators() ([]*types.Validator, error)
+
 	// ValidatorDowntime pulls information about validator downtime from the RPC interface.
 	ValidatorDowntime(*hexutil.Big) (uint64, uint64, error)
 
@@ -200,6 +316,12 @@ type Repository interface {
 	// SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
 	SfcMaxDelegatedRatio() (*big.Int, error)
 
+	// SfcValidatorCommission extracts the share of delegators' rewards taken by validators.
+	SfcValidatorCommission() (*big.Int, error)
+
+	// SfcUnlockedRewardRatio extracts the share of the full reward paid to delegations without lock-up.
+	SfcUnlockedRewardRatio() (*big.Int, error)
+
 	// PullStakerInfo extracts an extended staker information from smart contact.
 	PullStakerInfo(*hexutil.Big) (*types.StakerInfo, error)
 
@@ -221,6 +343,9 @@ type Repository interface {
 	// Delegation returns a detail of delegation for the given address and validator ID.
 	Delegation(*common.Address, *hexutil.Big) (*types.Delegation, error)
 
+	// RefreshDelegation drops the cached delegation so the next access loads it live.
+	RefreshDelegation(*common.Address, *hexutil.Big)
+
 	// DelegationAmountStaked returns the current amount of staked tokens
 	// for the given delegation.
 	DelegationAmountStaked(*common.Address, *hexutil.Big) (*big.Int, error)
@@ -283,18 +408,54 @@ type Repository interface {
 	// RewardClaims provides list of reward claims for the given criteria.
 	RewardClaims(*common.Address, *big.Int, *string, int32) (*types.RewardClaimsList, error)
 
+	// RewardSources provides the rewards claimed by the given delegator over the trailing window
+	// grouped by the validator they were earned on, ordered by the claimed amount.
+	RewardSources(addr *common.Address, window time.Duration, ascending bool) (*types.RewardSources, error)
+
 	// Price returns a price information for the given target symbol.
 	Price(sym string) (types.Price, error)
 
 	// GasPrice provides the raw suggested value for the gas price.
 	GasPrice() (hexutil.Big, error)
 
+	// FeeHistory provides the base fee and the priority fee rewards at the given percentiles
+	// of the given number of the most recent blocks.
+	FeeHistory(hexutil.Uint64, []float64) (*types.FeeHistory, error)
+
+	// Health provides the state of the dependencies of the API server.
+	Health() *types.Health
+
+	// CacheStats provides the usage statistics of the in-memory cache.
+	CacheStats() *types.CacheStats
+
+	// ResetCacheStats provides the usage statistics of the in-memory cache and restarts the counters.
+	ResetCacheStats() *types.CacheStats
+
 	// GasPriceExtended provides extended gas price information.
 	GasPriceExtended() (*types.GasPrice, error)
 
 	// StoreGasPricePeriod stores gas price period data into the persistent storage.
 	StoreGasPricePeriod(*types.GasPricePeriod) error
 
+	// Logs provides the logs of the given block range matching the given addresses and topics.
+	// The node is queried by sub-ranges of the configured chunk size and the results are concatenated;
+	// the number of sub-requests made is returned along with the logs.
+	Logs(ctx context.Context, from uint64, to uint64, addresses []common.Address, topics [][]common.Hash) ([]etc.Log, int, error)
+
+	// StoreLog stores a log record in the logs index.
+	StoreLog(*types.IndexedLog) error
+
+	// IndexedLogs provides list of indexed log records of the given block range
+	// matching the given addresses and topics.
+	IndexedLogs(from uint64, to uint64, addresses []common.Address, topics [][]common.Hash, cursor *string, count int32) (*types.IndexedLogList, error)
+
+	// RevertLogs removes indexed log records of the given range of blocks replaced by a chain reorg.
+	RevertLogs(fromBlock uint64, toBlock uint64) error
+
+	// TransactionEta provides a best-effort estimate of the time to confirmation
+	// of the given pending transaction. Confirmed transactions don't have any estimate.
+	TransactionEta(*types.Transaction) (*types.TrxEta, error)
+
 	// GasEstimate calculates the estimated amount of Gas required to perform
 	// transaction described by the input params.
 	GasEstimate(*struct {
@@ -310,16 +471,60 @@ type Repository interface {
 	// DefiTokens resolves list of DeFi tokens available for the DeFi functions.
 	DefiTokens() ([]types.DefiToken, error)
 
+	// StorePriceSnapshot stores a token price snapshot.
+	StorePriceSnapshot(*types.PriceSnapshot) error
+
+	// StoreSupplySnapshot stores a token total supply snapshot.
+	StoreSupplySnapshot(*types.SupplySnapshot) error
+
+	// Erc20SupplyHistory provides the total supply snapshots of the token taken in the given
+	// inclusive block range, including the nearest snapshot preceding the range, sorted from the oldest one.
+	Erc20SupplyHistory(token *common.Address, from uint64, to uint64) ([]*types.SupplySnapshot, error)
+
+	// PriceHistory provides up to the given number of the most recent price snapshots
+	// of the token taken in the given time range, sorted from the oldest one.
+	PriceHistory(token *common.Address, from time.Time, to time.Time, count int32) ([]*types.PriceSnapshot, error)
+
 	// DefiToken loads details of a single DeFi token by it's address.
 	DefiToken(*common.Address) (*types.DefiToken, error)
 
+	// FMintFeesCollected provides the fees paid on fMint minting over the trailing window.
+	FMintFeesCollected(window time.Duration) (*types.ProtocolFees, error)
+
+	// UniswapFeesCollected provides the trading fees paid on Uniswap swaps over the trailing window.
+	UniswapFeesCollected(window time.Duration) (*types.ProtocolFees, error)
+
+	// DefiPositions provides the positions of the account across the DeFi protocols.
+	DefiPositions(owner *common.Address) (*types.DefiPositions, error)
+
+	// FxRate provides the exchange rate of USD to the given currency.
+	// Unsupported currencies are reported as an error.
+	FxRate(currency string) (float64, error)
+
 	// DefiTokenPrice loads the current price of the given token
 	// from on-chain price oracle.
 	DefiTokenPrice(*common.Address) (hexutil.Big, error)
 
+	// TokenPrice provides the current oracle price of the given token;
+	// nil if the oracle has no price feed for the token.
+	TokenPrice(*common.Address) (*types.TokenPrice, error)
+
+	// ConvertAmount values the given amount of the source token in the target token
+	// by the current oracle prices; nil if any of the tokens has no price feed.
+	ConvertAmount(*common.Address, *common.Address, *big.Int) (*big.Int, error)
+
 	// FMintAccount loads details of a DeFi/fMint account identified by the owner address.
 	FMintAccount(common.Address) (*types.FMintAccount, error)
 
+	// Erc20ApproveCallData builds an unsigned ERC20 approve call unlocking the amount of the token
+	// for the spender; nil, or zero amount revokes the allowance. The optional owner is used to estimate the gas.
+	Erc20ApproveCallData(*common.Address, *common.Address, *common.Address, *big.Int) (*types.Erc20ApproveCallData, error)
+
+	// FMintCallData builds an unsigned call of the fMint minter contract performing
+	// the given operation (see types.FMintTrxType*) with the token and amount.
+	// The optional owner is used to check the resulting position and estimate the gas.
+	FMintCallData(int, *common.Address, *common.Address, *big.Int) (*types.FMintCallData, error)
+
 	// FMintTokenBalance loads balance of a single DeFi token by it's address.
 	FMintTokenBalance(*common.Address, *common.Address, types.DefiTokenType) (hexutil.Big, error)
 
@@ -329,10 +534,26 @@ type Repository interface {
 	// FMintTokenValue loads value of a single DeFi token by it's address in fUSD.
 	FMintTokenValue(*common.Address, *common.Address, types.DefiTokenType) (hexutil.Big, error)
 
+	// FMintAccountTokens loads the list of token positions of the given fMint account
+	// on the given side, collateral or debt.
+	FMintAccountTokens(*common.Address, types.DefiTokenType) ([]*types.FMintTokenPosition, error)
+
+	// FMintLiquidationPrices provides the liquidation price of each collateral token
+	// of the given fMint account, assuming the prices of the other tokens do not change.
+	FMintLiquidationPrices(*common.Address) ([]types.FMintLiquidationPrice, error)
+
+	// FMintLiquidationRisk provides the distance of the collateral ratio of the given fMint account
+	// from the minimal collateral ratio; nil if the account has no debt.
+	FMintLiquidationRisk(*common.Address) (*types.FMintLiquidationRisk, error)
+
 	// FMintRewardsEarned resolves the total amount of rewards
 	// accumulated on the account for the excessive collateral deposits.
 	FMintRewardsEarned(*common.Address) (hexutil.Big, error)
 
+	// FMintRewards provides the pending rewards of the given fMint account
+	// along with the reward token and the reward rate.
+	FMintRewards(*common.Address) (*types.FMintRewards, error)
+
 	// FMintRewardsStashed represents the total amount of rewards
 	// accumulated on the account in stash.
 	FMintRewardsStashed(*common.Address) (hexutil.Big, error)
@@ -362,6 +583,10 @@ type Repository interface {
 	// UniswapKnownPairs returns list of all known and whitelisted token pairs managed by Uniswap core.
 	UniswapKnownPairs() ([]common.Address, error)
 
+	// PriceComparison provides the oracle price of the given token cross-referenced
+	// with the DEX spot price against the configured base token.
+	PriceComparison(*common.Address) (*types.PriceComparison, error)
+
 	// UniswapPair returns an address of an Uniswap pair for the given tokens.
 	UniswapPair(*common.Address, *common.Address) (*common.Address, error)
 
@@ -373,6 +598,10 @@ type Repository interface {
 	// output amount and a list of tokens to be used to make the swap operation.
 	UniswapAmountsIn(amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
 
+	// UniswapQuote calculates a slippage adjusted quote of a swap of the given
+	// input amount along the given path of tokens.
+	UniswapQuote(amountIn hexutil.Big, path []common.Address, slippageBps int32) (*types.UniswapQuote, error)
+
 	// UniswapQuoteInput calculates optimal input on sibling token based on input amount and
 	// self reserves of the analyzed token.
 	UniswapQuoteInput(amountIn hexutil.Big, reserveMy hexutil.Big, reserveSibling hexutil.Big) (hexutil.Big, error)
@@ -426,15 +655,44 @@ type Repository interface {
 	NativeTokenAddress() (*common.Address, error)
 
 	// TokenTransactions provides list of ERC20/ERC721/ERC1155 transactions based on given filters.
-	TokenTransactions(tokenType string, token *common.Address, tokenId *big.Int, acc *common.Address, txType *int32, cursor *string, count int32) (*types.TokenTransactionList, error)
+	// The total is not calculated if skipTotal is set.
+	TokenTransactions(tokenType string, token *common.Address, tokenId *big.Int, acc *common.Address, txType *int32, cursor *string, count int32, skipTotal bool) (*types.TokenTransactionList, error)
 
 	// TokenTransactionsByCall provides a list of token transaction made inside a specific
 	// transaction call (blockchain transaction).
 	TokenTransactionsByCall(*common.Hash) ([]*types.TokenTransaction, error)
 
+	// Erc20TokenInfo provides the name, the symbol, the decimals and the total supply
+	// of the given ERC20 token loaded from the node at once.
+	Erc20TokenInfo(*common.Address) (*types.Erc20TokenInfo, error)
+
+	// Erc20Transfers provides list of Transfer events of the given ERC20 token
+	// optionally filtered by the sender and/or the recipient.
+	Erc20Transfers(token common.Address, from *common.Address, to *common.Address, cursor *string, count int32) (*types.TokenTransactionList, error)
+
 	// Erc20Token returns an ERC20 token for the given address, if available.
 	Erc20Token(*common.Address) (*types.Erc20Token, error)
 
+	// PortfolioDistribution provides the breakdown of the current USD value
+	// of the account portfolio by tokens.
+	PortfolioDistribution(*common.Address) (*types.PortfolioDistribution, error)
+
+	// RefreshPortfolio drops the cached portfolio distribution of the account
+	// and the balances it's built from so the next access loads them live.
+	RefreshPortfolio(*common.Address)
+
+	// NetWorthHistory provides the USD value of the account portfolio at blocks
+	// in the given range separated by the given interval.
+	NetWorthHistory(addr *common.Address, fromBlock uint64, toBlock uint64, interval uint64) ([]*types.NetWorthPoint, error)
+
+	// TrendingTokens provides the given number of tokens with the highest number
+	// of transactions over the trailing time window.
+	TrendingTokens(window time.Duration, count int32) ([]*types.TokenActivity, error)
+
+	// Erc20TokenHolders provides the given number of the top holders of the ERC20 token
+	// sorted by their balance accumulated from the indexed token transfers.
+	Erc20TokenHolders(token *common.Address, count int32) ([]*types.Erc20Holder, error)
+
 	// Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
 	Erc20TokensList(int32) ([]common.Address, error)
 
@@ -445,13 +703,24 @@ type Repository interface {
 	// contract address for an identified owner address.
 	Erc20BalanceOf(*common.Address, *common.Address) (hexutil.Big, error)
 
+	// RefreshErc20Balance drops the cached ERC20 token balance of the owner
+	// so the next access loads it live.
+	RefreshErc20Balance(*common.Address, *common.Address)
+
 	// Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
 	// contract by the token owner.
 	Erc20Allowance(*common.Address, *common.Address, *common.Address) (hexutil.Big, error)
 
+	// IsSuspiciousValue checks if the given token amount, balance, or supply
+	// exceeds the configured sanity bound.
+	IsSuspiciousValue(*big.Int) bool
+
 	// Erc20TotalSupply provides information about all available tokens
 	Erc20TotalSupply(*common.Address) (hexutil.Big, error)
 
+	// Erc20TotalSupplyAt provides the total supply of the ERC20 token at the given block.
+	Erc20TotalSupplyAt(*common.Address, uint64) (*big.Int, error)
+
 	// Erc20Name provides information about the name of the ERC20 token.
 	Erc20Name(*common.Address) (string, error)
 
@@ -461,12 +730,42 @@ type Repository interface {
 	// Erc20Decimals provides information about the decimals of the ERC20 token.
 	Erc20Decimals(*common.Address) (int32, error)
 
+	// Erc20NonStandardTokens provides the list of ERC20 tokens which required
+	// a non-standard decoding of their return values since the API server started.
+	Erc20NonStandardTokens() []types.Erc20NonStandardToken
+
+	// ReloadErc20LogoMap reads the map of ERC20 token logos from the configured file again
+	// and replaces the current map. It returns the number of tokens in the new map.
+	ReloadErc20LogoMap() (int, error)
+
 	// Erc20LogoURL provides URL address of a logo of the ERC20 token.
 	Erc20LogoURL(*common.Address) string
 
+	// Erc20RiskFlags provides a set of heuristic flags signaling the ERC20 token
+	// may be a scam, or a spam token.
+	Erc20RiskFlags(*common.Address) (*types.Erc20RiskFlags, error)
+
+	// ApprovalRisk provides a heuristic risk score of the active ERC20 approvals
+	// granted by the given owner.
+	ApprovalRisk(*common.Address) (*types.ApprovalRisk, error)
+
 	// StoreTokenTransaction stores ERC20/ERC721/ERC1155 transaction into the repository.
 	StoreTokenTransaction(*types.TokenTransaction) error
 
+	// ContractCall executes a read only call of the given contract with the given input data
+	// on the state of the given block, or the latest one, and provides the raw return data.
+	ContractCall(*common.Address, hexutil.Bytes, *hexutil.Uint64) (hexutil.Bytes, error)
+
+	// AccountCode provides the code deployed at the given address; empty for externally owned accounts.
+	AccountCode(*common.Address) ([]byte, error)
+
+	// AccountStorageAt provides the raw value of the given storage slot of the given account.
+	AccountStorageAt(*common.Address, common.Hash) (common.Hash, error)
+
+	// ContractType provides classification of the given address by its code.
+	// Proxies are classified along with their implementation.
+	ContractType(*common.Address) (*types.ContractType, error)
+
 	// Erc165SupportsInterface provides information about support of the interface by the contract.
 	Erc165SupportsInterface(contract *common.Address, interfaceID [4]byte) (bool, error)
 
@@ -500,6 +799,21 @@ type Repository interface {
 	// Erc721IsApprovedForAll provides information about operator approved to manipulate with NFT tokens of given owner.
 	Erc721IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error)
 
+	// NftHoldings provides list of ERC721/ERC1155 tokens held by the given owner,
+	// optionally limited to the given contract.
+	NftHoldings(owner *common.Address, token *common.Address, cursor *string, count int32) (*types.NftHoldingList, error)
+
+	// RevertTokenTransactions removes token transactions of the given range of blocks
+	// replaced by a chain reorg and restores the affected NFT ownership.
+	RevertTokenTransactions(fromBlock uint64, toBlock uint64) error
+
+	// BackfillNftHoldings applies a batch of the NFT transfers indexed before the ownership
+	// was tracked following the given cursor and provides the cursor of the next batch, if any.
+	BackfillNftHoldings(cursor *string, count int64) (*string, error)
+
+	// Erc1155Contract returns an ERC1155 contract for the given address, if available.
+	Erc1155Contract(*common.Address) (*types.Erc1155Contract, error)
+
 	// Erc1155ContractsList returns a list of known ERC1155 contracts ordered by their activity.
 	Erc1155ContractsList(int32) ([]common.Address, error)
 
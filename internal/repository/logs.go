@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	etc "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Logs provides the logs of the given block range matching the given addresses and topics.
+// The node is queried by sub-ranges of the configured chunk size and the results are concatenated;
+// the number of sub-requests made is returned along with the logs.
+func (p *proxy) Logs(ctx context.Context, from uint64, to uint64, addresses []common.Address, topics [][]common.Hash) ([]etc.Log, int, error) {
+	if to < from {
+		return nil, 0, fmt.Errorf("invalid block range <#%d, #%d>", from, to)
+	}
+	if p.cfg.Repository.LogsMaxRange > 0 && to-from >= p.cfg.Repository.LogsMaxRange {
+		return nil, 0, fmt.Errorf("block range of %d blocks too wide, max %d blocks allowed; split the query into smaller ranges", to-from+1, p.cfg.Repository.LogsMaxRange)
+	}
+
+	list := make([]etc.Log, 0)
+	ranges := logRanges(from, to, p.cfg.Repository.LogsChunkSize)
+	for i, r := range ranges {
+		// the client may have given up already
+		if err := ctx.Err(); err != nil {
+			return nil, i, err
+		}
+
+		logs, err := p.rpc.Logs(ctx, r[0], r[1], addresses, topics)
+		if err != nil {
+			return nil, i + 1, err
+		}
+		list = append(list, logs...)
+	}
+	return list, len(ranges), nil
+}
+
+// logRanges splits the given inclusive block range into sub-ranges
+// of the given size. Zero size keeps the range whole.
+func logRanges(from uint64, to uint64, size uint64) [][2]uint64 {
+	if size == 0 || to-from < size {
+		return [][2]uint64{{from, to}}
+	}
+
+	ranges := make([][2]uint64, 0, (to-from)/size+1)
+	for start := from; ; start += size {
+		// the last sub-range may be shorter; watch for the end of the number space
+		if to-start < size {
+			return append(ranges, [2]uint64{start, to})
+		}
+		ranges = append(ranges, [2]uint64{start, start + size - 1})
+	}
+}
+
+// StoreLog stores a log record in the logs index.
+func (p *proxy) StoreLog(il *types.IndexedLog) error {
+	return p.db.AddLog(il)
+}
+
+// IndexedLogs provides list of indexed log records of the given block range
+// matching the given addresses and topics.
+func (p *proxy) IndexedLogs(from uint64, to uint64, addresses []common.Address, topics [][]common.Hash, cursor *string, count int32) (*types.IndexedLogList, error) {
+	if to < from {
+		return nil, fmt.Errorf("invalid block range <#%d, #%d>", from, to)
+	}
+	return p.db.IndexedLogs(from, to, addresses, topics, cursor, count)
+}
+
+// RevertLogs removes indexed log records of the given range of blocks replaced by a chain reorg.
+func (p *proxy) RevertLogs(fromBlock uint64, toBlock uint64) error {
+	return p.db.EraseLogs(fromBlock, toBlock)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestLogRanges tests the chunking of logs queries into block sub-ranges.
+func TestLogRanges(t *testing.T) {
+	tests := []struct {
+		name string
+		from uint64
+		to   uint64
+		size uint64
+		want [][2]uint64
+	}{
+		{"single block", 10, 10, 5, [][2]uint64{{10, 10}}},
+		{"chunking disabled", 0, 100, 0, [][2]uint64{{0, 100}}},
+		{"below chunk size", 0, 3, 5, [][2]uint64{{0, 3}}},
+		{"exact chunk size", 0, 4, 5, [][2]uint64{{0, 4}}},
+		{"one over chunk size", 0, 5, 5, [][2]uint64{{0, 4}, {5, 5}}},
+		{"exact multiple", 1, 10, 5, [][2]uint64{{1, 5}, {6, 10}}},
+		{"remainder", 1, 12, 5, [][2]uint64{{1, 5}, {6, 10}, {11, 12}}},
+		{"unit chunks", 7, 9, 1, [][2]uint64{{7, 7}, {8, 8}, {9, 9}}},
+		{"end of number space", math.MaxUint64 - 6, math.MaxUint64, 4, [][2]uint64{
+			{math.MaxUint64 - 6, math.MaxUint64 - 3},
+			{math.MaxUint64 - 2, math.MaxUint64},
+		}},
+	}
+
+	for _, tc := range tests {
+		if got := logRanges(tc.from, tc.to, tc.size); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+// TestLogsRange tests logs queries of invalid, or too wide block ranges are refused.
+func TestLogsRange(t *testing.T) {
+	p := proxy{cfg: &config.Config{Repository: config.Repository{LogsMaxRange: 100}}}
+
+	if _, _, err := p.Logs(context.Background(), 10, 9, nil, nil); err == nil {
+		t.Errorf("expected reversed range refused")
+	}
+	if _, _, err := p.Logs(context.Background(), 0, 100, nil, nil); err == nil || !strings.Contains(err.Error(), "smaller ranges") {
+		t.Errorf("expected too wide range refused, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StoreMethodCall stores a call of a watched contract method.
+func (p *proxy) StoreMethodCall(call *types.MethodCall) error {
+	return p.db.AddMethodCall(call)
+}
+
+// MethodCalls provides list of recorded calls of watched methods of the given contract,
+// optionally limited to the method given by its name, or its selector.
+func (p *proxy) MethodCalls(contract *common.Address, method *string, cursor *string, count int32) (*types.MethodCallList, error) {
+	return p.db.MethodCalls(contract, method, cursor, count)
+}
+
+// RevertMethodCalls removes method calls of the given range of blocks replaced by a chain reorg.
+func (p *proxy) RevertMethodCalls(fromBlock uint64, toBlock uint64) error {
+	return p.db.EraseMethodCalls(fromBlock, toBlock)
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/atomic"
+)
+
+// testRevert makes the mock node revert the call
+const testRevert = "revert"
+
+// testCall resolves a single call received by the mock node.
+// An error of the testRpcError type is responded as is, other errors
+// are responded as a generic server error.
+type testCall func(method string, params []json.RawMessage) (interface{}, error)
+
+// testRpcError represents a JSON-RPC error responded by the mock node.
+type testRpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Error returns the message of the error.
+func (e *testRpcError) Error() string {
+	return e.Message
+}
+
+// testRpcRequest represents a single JSON-RPC request received by the mock node.
+type testRpcRequest struct {
+	Id     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// testNode represents a mock JSON-RPC node serving single calls and batches of calls.
+type testNode struct {
+	*httptest.Server
+
+	// drop is the number of the first HTTP requests dropped without any response
+	drop atomic.Int64
+
+	// requests counts the HTTP requests received; a batch is a single request
+	requests atomic.Int64
+}
+
+// newTestNode starts a mock node answering the calls by the given resolver.
+func newTestNode(t *testing.T, call testCall) *testNode {
+	node := new(testNode)
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// drop the connection without any response
+		if node.requests.Inc() <= node.drop.Load() {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+
+		var out interface{}
+		if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			var batch []testRpcRequest
+			if err := json.Unmarshal(body, &batch); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			res := make([]map[string]interface{}, len(batch))
+			for i := range batch {
+				res[i] = testRespond(&batch[i], call)
+			}
+			out = res
+		} else {
+			var req testRpcRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			out = testRespond(&req, call)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+	t.Cleanup(node.Close)
+	return node
+}
+
+// testRespond builds the response of a single call by the given resolver.
+func testRespond(req *testRpcRequest, call testCall) map[string]interface{} {
+	res := map[string]interface{}{"jsonrpc": "2.0", "id": req.Id}
+
+	out, err := call(req.Method, req.Params)
+	if err == nil {
+		res["result"] = out
+		return res
+	}
+
+	if re, ok := err.(*testRpcError); ok {
+		res["error"] = re
+	} else {
+		res["error"] = &testRpcError{Code: -32000, Message: err.Error()}
+	}
+	return res
+}
+
+// testResults provides a resolver answering contract calls by the called method selector
+// with the given hex encoded return data, or the testRevert marker; unknown selectors
+// return empty data. Other calls are answered by the method with the given raw JSON results.
+func testResults(results map[string]string) testCall {
+	return func(method string, params []json.RawMessage) (interface{}, error) {
+		if sel, ok := testSelector(method, params); ok {
+			out, ok := results[sel]
+			switch {
+			case ok && out == testRevert:
+				return nil, &testRpcError{Code: 3, Message: "execution reverted"}
+			case ok:
+				return out, nil
+			}
+			if _, ok := results[method]; !ok {
+				return "0x", nil
+			}
+		}
+
+		if out, ok := results[method]; ok {
+			return json.RawMessage(out), nil
+		}
+		return nil, testMethodNotFound(method)
+	}
+}
+
+// testFailing provides a resolver failing the calls of the given method with the given error.
+func testFailing(method string, err error) testCall {
+	return func(m string, _ []json.RawMessage) (interface{}, error) {
+		if m != method {
+			return nil, testMethodNotFound(m)
+		}
+		return nil, err
+	}
+}
+
+// testSelector extracts the method selector of a contract call.
+func testSelector(method string, params []json.RawMessage) (string, bool) {
+	if (method != "eth_call" && method != "ftm_call") || len(params) == 0 {
+		return "", false
+	}
+
+	var call struct {
+		Data hexutil.Bytes `json:"data"`
+	}
+	if err := json.Unmarshal(params[0], &call); err != nil || len(call.Data) < 4 {
+		return "", false
+	}
+	return hexutil.Encode(call.Data[:4]), true
+}
+
+// testMethodNotFound provides the error of a method not served by the mock node.
+func testMethodNotFound(method string) error {
+	return &testRpcError{Code: -32601, Message: fmt.Sprintf("the method %s does not exist/is not available", method)}
+}
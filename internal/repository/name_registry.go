@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"strings"
+)
+
+// reverseNameSuffix is the suffix of the reverse resolution names of addresses.
+const reverseNameSuffix = ".addr.reverse"
+
+// ResolveName provides the address registered for the given name in the configured
+// name registry; nil is provided if the name is not registered, or the registry is not configured.
+// Resolutions are cached, including the unregistered names.
+func (p *proxy) ResolveName(name string) (*common.Address, error) {
+	name = normalizeName(name)
+	if name == "" || p.cfg.NameRegistry.Contract == (common.Address{}) {
+		return nil, nil
+	}
+
+	if addr, ok := p.cache.PullNameAddress(name); ok {
+		return addr, nil
+	}
+
+	addr, err := p.rpc.NameAddress(&p.cfg.NameRegistry.Contract, nameHash(name))
+	if err != nil {
+		return nil, err
+	}
+	if err := p.cache.PushNameAddress(name, addr); err != nil {
+		p.log.Errorf("can not cache resolution of name %s; %s", name, err.Error())
+	}
+	return addr, nil
+}
+
+// LookupAddress provides the name of the given address by the reverse record in the configured
+// name registry; nil is provided if there is no reverse record, or the registry is not configured.
+// The name is provided only if it resolves back to the address, so a reverse record
+// can not claim a name of someone else. Resolutions are cached, including the missing names.
+func (p *proxy) LookupAddress(addr *common.Address) (*string, error) {
+	if p.cfg.NameRegistry.Contract == (common.Address{}) {
+		return nil, nil
+	}
+
+	if name, ok := p.cache.PullAddressName(addr); ok {
+		return name, nil
+	}
+
+	name, err := p.lookupAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.cache.PushAddressName(addr, name); err != nil {
+		p.log.Errorf("can not cache name of %s; %s", addr.String(), err.Error())
+	}
+	return name, nil
+}
+
+// lookupAddress loads the name of the given address from the reverse record
+// and verifies it resolves back to the address.
+func (p *proxy) lookupAddress(addr *common.Address) (*string, error) {
+	name, err := p.rpc.NameOf(&p.cfg.NameRegistry.Contract, nameHash(reverseName(addr)))
+	if err != nil {
+		return nil, err
+	}
+
+	name = normalizeName(name)
+	if name == "" {
+		return nil, nil
+	}
+
+	fwd, err := p.ResolveName(name)
+	if err != nil {
+		return nil, err
+	}
+	if fwd == nil || *fwd != *addr {
+		p.log.Debugf("name %s of %s does not resolve back", name, addr.String())
+		return nil, nil
+	}
+	return &name, nil
+}
+
+// normalizeName brings the name into the canonical form used by the registry.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// reverseName provides the reverse resolution name of the given address.
+func reverseName(addr *common.Address) string {
+	return strings.ToLower(addr.Hex()[2:]) + reverseNameSuffix
+}
+
+// nameHash calculates the registry node of the given name; the node is the recursive
+// hash of the labels of the name starting from the top level one, see EIP-137.
+func nameHash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+	return node
+}
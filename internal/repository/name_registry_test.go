@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/repository/cache"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// name registry method selectors
+	testSelNameResolver = "0x0178b8bf"
+	testSelNameAddr     = "0x3b3b57de"
+	testSelNameOf       = "0x691f3431"
+)
+
+// testAbiAddress provides the ABI encoded address.
+func testAbiAddress(addr common.Address) string {
+	return hexutil.Encode(common.LeftPadBytes(addr.Bytes(), 32))
+}
+
+// testAbiText provides the ABI encoded string; the value must fit into a single word.
+func testAbiText(s string) string {
+	return fmt.Sprintf("0x%064x%064x%s", 32, len(s), hexutil.Encode(common.RightPadBytes([]byte(s), 32))[2:])
+}
+
+// testNameProxy creates a repository proxy with the name registry and the cache configured.
+func testNameProxy(t *testing.T, results map[string]string) *proxy {
+	p := testErc20Proxy(t, newTestNode(t, testResults(results)), false)
+	p.cfg.Cache = config.Cache{Eviction: time.Minute, MaxSize: 16}
+	p.cfg.NameRegistry.Contract = common.HexToAddress("0x0e")
+
+	var err error
+	p.cache, err = cache.New(p.cfg, p.log)
+	if err != nil {
+		t.Fatalf("can not create cache; %s", err.Error())
+	}
+	return p
+}
+
+// TestNameHash tests the name nodes against the EIP-137 vectors.
+func TestNameHash(t *testing.T) {
+	tests := map[string]string{
+		"":        "0x0000000000000000000000000000000000000000000000000000000000000000",
+		"eth":     "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae",
+		"foo.eth": "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f",
+	}
+	for name, want := range tests {
+		if got := nameHash(name).Hex(); got != want {
+			t.Errorf("name %q: expected %s, got %s", name, want, got)
+		}
+	}
+}
+
+// TestResolveName tests names are resolved through the resolver of the name node.
+func TestResolveName(t *testing.T) {
+	owner := common.HexToAddress("0xa11ce")
+	p := testNameProxy(t, map[string]string{
+		testSelNameResolver: testAbiAddress(common.HexToAddress("0x0f")),
+		testSelNameAddr:     testAbiAddress(owner),
+	})
+
+	addr, err := p.ResolveName(" Alice.Motif ")
+	if err != nil || addr == nil || *addr != owner {
+		t.Fatalf("expected %s, got %v; %v", owner.String(), addr, err)
+	}
+
+	// the resolution is served from the cache
+	if cached, ok := p.cache.PullNameAddress("alice.motif"); !ok || cached == nil || *cached != owner {
+		t.Errorf("expected cached %s, got %v", owner.String(), cached)
+	}
+}
+
+// TestResolveNameUnregistered tests unregistered names resolve to nil.
+func TestResolveNameUnregistered(t *testing.T) {
+	p := testNameProxy(t, map[string]string{
+		testSelNameResolver: testAbiAddress(common.Address{}),
+	})
+
+	addr, err := p.ResolveName("nobody.motif")
+	if err != nil || addr != nil {
+		t.Fatalf("expected nil, got %v; %v", addr, err)
+	}
+
+	// the missing name is cached too
+	if cached, ok := p.cache.PullNameAddress("nobody.motif"); !ok || cached != nil {
+		t.Errorf("expected cached nil, got %v, %v", cached, ok)
+	}
+}
+
+// TestLookupAddress tests the reverse name is provided only if it resolves back to the address.
+func TestLookupAddress(t *testing.T) {
+	owner := common.HexToAddress("0xa11ce")
+	other := common.HexToAddress("0xb0b")
+
+	p := testNameProxy(t, map[string]string{
+		testSelNameResolver: testAbiAddress(common.HexToAddress("0x0f")),
+		testSelNameAddr:     testAbiAddress(owner),
+		testSelNameOf:       testAbiText("Alice.motif"),
+	})
+
+	name, err := p.LookupAddress(&owner)
+	if err != nil || name == nil || *name != "alice.motif" {
+		t.Fatalf("expected alice.motif, got %v; %v", name, err)
+	}
+
+	// the name claimed by the reverse record of another address does not resolve to it
+	name, err = p.LookupAddress(&other)
+	if err != nil || name != nil {
+		t.Fatalf("expected nil, got %v; %v", name, err)
+	}
+}
+
+// TestNameRegistryNotConfigured tests nothing is resolved without the registry.
+func TestNameRegistryNotConfigured(t *testing.T) {
+	p := testNameProxy(t, map[string]string{})
+	p.cfg.NameRegistry.Contract = common.Address{}
+
+	if addr, err := p.ResolveName("alice.motif"); err != nil || addr != nil {
+		t.Errorf("expected nil address, got %v; %v", addr, err)
+	}
+
+	owner := common.HexToAddress("0xa11ce")
+	if name, err := p.LookupAddress(&owner); err != nil || name != nil {
+		t.Errorf("expected nil name, got %v; %v", name, err)
+	}
+}
+
+// TestReverseName tests the reverse resolution name of an address.
+func TestReverseName(t *testing.T) {
+	addr := common.HexToAddress("0x314159265dD8dbb310642f98f50C066173C1259b")
+	if got := reverseName(&addr); got != strings.ToLower("314159265dD8dbb310642f98f50C066173C1259b")+".addr.reverse" {
+		t.Errorf("unexpected reverse name %s", got)
+	}
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// nativeTokenDecimals represents the number of decimals of the native token balance.
+const nativeTokenDecimals = 18
+
+// NetWorthHistory provides the USD value of the account portfolio at blocks in the given
+// range separated by the given interval. The portfolio consists of the native balance
+// and balances of the active DeFi tokens. Historical balances need the node to keep
+// the state of the blocks, i.e. an archive node; historical prices need the price
+// snapshots to be enabled and collected over the range. Points missing any of these
+// are provided with partial values and flags.
+func (p *proxy) NetWorthHistory(addr *common.Address, fromBlock uint64, toBlock uint64, interval uint64) ([]*types.NetWorthPoint, error) {
+	blocks, err := netWorthBlocks(fromBlock, toBlock, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := p.DefiTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	// the native balance is priced by the native token wrapper
+	native, err := p.NativeTokenAddress()
+	if err != nil {
+		p.log.Errorf("native token wrapper not available; %s", err.Error())
+	}
+
+	list := make([]*types.NetWorthPoint, 0, len(blocks))
+	for _, blk := range blocks {
+		pt, err := p.netWorthAt(addr, blk, tokens, native)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, pt)
+	}
+	return list, nil
+}
+
+// netWorthBlocks provides the list of block numbers of the net worth history points.
+func netWorthBlocks(fromBlock uint64, toBlock uint64, interval uint64) ([]uint64, error) {
+	if interval == 0 {
+		return nil, fmt.Errorf("invalid zero interval")
+	}
+	if toBlock < fromBlock {
+		return nil, fmt.Errorf("invalid block range <#%d, #%d>", fromBlock, toBlock)
+	}
+	if (toBlock-fromBlock)/interval >= types.NetWorthMaxPoints {
+		return nil, fmt.Errorf("too many points requested, max %d points allowed", types.NetWorthMaxPoints)
+	}
+
+	list := make([]uint64, 0, (toBlock-fromBlock)/interval+1)
+	for blk := fromBlock; blk <= toBlock; blk += interval {
+		list = append(list, blk)
+
+		// do not overflow past the top
+		if toBlock-blk < interval {
+			break
+		}
+	}
+	return list, nil
+}
+
+// netWorthAt calculates the net worth point of the account at the given block.
+func (p *proxy) netWorthAt(addr *common.Address, blk uint64, tokens []types.DefiToken, native *common.Address) (*types.NetWorthPoint, error) {
+	num := hexutil.Uint64(blk)
+	block, err := p.BlockByNumber(&num)
+	if err != nil {
+		return nil, err
+	}
+
+	pt := types.NetWorthPoint{BlockNumber: num, Time: time.Unix(int64(block.TimeStamp), 0).UTC()}
+
+	// native balance
+	bal, err := p.rpc.AccountBalanceAt(addr, &num)
+	if err != nil {
+		pt.BalancesMissing = true
+	} else {
+		p.addNetWorth(&pt, bal.ToInt(), nativeTokenDecimals, native)
+	}
+
+	// token balances
+	for i := range tokens {
+		if !tokens[i].IsActive {
+			continue
+		}
+
+		bal, err := p.rpc.Erc20BalanceAt(&tokens[i].Address, addr, blk)
+		if err != nil {
+			pt.BalancesMissing = true
+			continue
+		}
+		p.addNetWorth(&pt, bal, tokens[i].Decimals, &tokens[i].Address)
+	}
+	return &pt, nil
+}
+
+// addNetWorth adds the value of the given balance of the token to the net worth point
+// using the most recent price snapshot of the token before the point.
+func (p *proxy) addNetWorth(pt *types.NetWorthPoint, bal *big.Int, decimals int32, token *common.Address) {
+	// nothing to add; absurd balances would poison the value
+	if bal.Sign() == 0 || p.IsSuspiciousValue(bal) {
+		return
+	}
+
+	if token == nil {
+		pt.PricesMissing = true
+		return
+	}
+
+	price, err := p.db.PriceSnapshots(token, pt.Time.Add(-p.priceSnapshotTolerance()), pt.Time, 1)
+	if err != nil || len(price) == 0 {
+		pt.PricesMissing = true
+		return
+	}
+	pt.Value += usdValue(bal, decimals, price[0].Price.ToInt(), price[0].PriceDecimals)
+}
+
+// priceSnapshotTolerance provides the max age of a price snapshot
+// to be used as the price at a point in time.
+func (p *proxy) priceSnapshotTolerance() time.Duration {
+	if p.cfg.PriceSnapshot.Interval <= 0 {
+		return time.Hour
+	}
+	return 2 * p.cfg.PriceSnapshot.Interval
+}
+
+// usdValue calculates the USD value of the given amount of a token with the given price.
+func usdValue(amount *big.Int, decimals int32, price *big.Int, priceDecimals int32) float64 {
+	val := new(big.Float).Mul(new(big.Float).SetInt(amount), new(big.Float).SetInt(price))
+	div := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals+priceDecimals)), nil))
+	res, _ := new(big.Float).Quo(val, div).Float64()
+	return res
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestNetWorthBlocks tests the block numbers of net worth history points.
+func TestNetWorthBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     uint64
+		to       uint64
+		interval uint64
+		want     []uint64
+		fail     bool
+	}{
+		{"single block", 10, 10, 5, []uint64{10}, false},
+		{"exact range", 10, 20, 5, []uint64{10, 15, 20}, false},
+		{"partial interval", 10, 22, 5, []uint64{10, 15, 20}, false},
+		{"top of range", ^uint64(0) - 4, ^uint64(0), 3, []uint64{^uint64(0) - 4, ^uint64(0) - 1}, false},
+		{"zero interval", 10, 20, 0, nil, true},
+		{"reversed range", 20, 10, 1, nil, true},
+		{"too many points", 0, 1000, 1, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := netWorthBlocks(tt.from, tt.to, tt.interval)
+			if (err != nil) != tt.fail {
+				t.Fatalf("netWorthBlocks() error = %v, want failure %v", err, tt.fail)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("netWorthBlocks() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("netWorthBlocks() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestUsdValue tests the USD value of a token amount.
+func TestUsdValue(t *testing.T) {
+	// 2.5 tokens with 18 decimals priced 1.2 USD with 8 decimals
+	amount, _ := new(big.Int).SetString("2500000000000000000", 10)
+	if got := usdValue(amount, 18, big.NewInt(120000000), 8); got != 3.0 {
+		t.Errorf("usdValue() = %f, want 3.0", got)
+	}
+
+	// 100 tokens with 6 decimals priced 0.5 USD with 18 decimals
+	price, _ := new(big.Int).SetString("500000000000000000", 10)
+	if got := usdValue(big.NewInt(100000000), 6, price, 18); got != 50.0 {
+		t.Errorf("usdValue() = %f, want 50.0", got)
+	}
+}
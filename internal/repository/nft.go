@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NftHoldings provides list of ERC721/ERC1155 tokens held by the given owner,
+// optionally limited to the given contract.
+func (p *proxy) NftHoldings(owner *common.Address, token *common.Address, cursor *string, count int32) (*types.NftHoldingList, error) {
+	return p.db.NftHoldings(owner, token, cursor, count)
+}
+
+// RevertTokenTransactions removes token transactions of the given range of blocks
+// replaced by a chain reorg and restores the affected NFT ownership.
+func (p *proxy) RevertTokenTransactions(fromBlock uint64, toBlock uint64) error {
+	list, err := p.db.EraseTokenTransactions(fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+
+	p.log.Noticef("%d token transactions of blocks <#%d, #%d> reverted", len(list), fromBlock, toBlock)
+	return nil
+}
+
+// BackfillNftHoldings applies a batch of the NFT transfers indexed before the ownership
+// was tracked following the given cursor and provides the cursor of the next batch, if any.
+func (p *proxy) BackfillNftHoldings(cursor *string, count int64) (*string, error) {
+	return p.db.BackfillNftHoldings(cursor, count)
+}
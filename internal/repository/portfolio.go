@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// portfolioCacheMaxAge represents the max age of a cached portfolio distribution.
+const portfolioCacheMaxAge = 30 * time.Second
+
+// nativeTokenSymbol represents the symbol of the native balance.
+const nativeTokenSymbol = "FTM"
+
+// PortfolioDistribution provides the breakdown of the current USD value of the account
+// portfolio by tokens. The portfolio consists of the native balance and balances
+// of the active DeFi tokens, the same as the net worth history; the values use
+// the current oracle prices. Holdings without a price are listed separately.
+func (p *proxy) PortfolioDistribution(addr *common.Address) (*types.PortfolioDistribution, error) {
+	if pd := p.cache.PullPortfolioDistribution(addr, portfolioCacheMaxAge); pd != nil {
+		return pd, nil
+	}
+
+	tokens, err := p.DefiTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	// the native balance is priced by the native token wrapper
+	native, err := p.NativeTokenAddress()
+	if err != nil {
+		p.log.Errorf("native token wrapper not available; %s", err.Error())
+	}
+
+	bal, err := p.AccountBalance(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := make([]types.PortfolioHolding, 0, len(tokens)+1)
+	holdings = append(holdings, p.portfolioHolding(nil, nativeTokenSymbol, bal.ToInt(), nativeTokenDecimals, native))
+
+	for i := range tokens {
+		if !tokens[i].IsActive {
+			continue
+		}
+
+		bal, err := p.Erc20BalanceOf(&tokens[i].Address, addr)
+		if err != nil {
+			return nil, err
+		}
+		holdings = append(holdings, p.portfolioHolding(&tokens[i].Address, tokens[i].Symbol, bal.ToInt(), tokens[i].Decimals, &tokens[i].Address))
+	}
+
+	pd := portfolioDistribution(holdings)
+	pd.Computed = time.Now().UTC()
+	if err := p.cache.PushPortfolioDistribution(addr, pd); err != nil {
+		p.log.Errorf("can not cache portfolio distribution of %s; %s", addr.String(), err.Error())
+	}
+	return pd, nil
+}
+
+// portfolioHolding builds a holding of the given balance priced by the oracle price of the given token.
+// The value of a holding without a price is negative.
+func (p *proxy) portfolioHolding(token *common.Address, symbol string, bal *big.Int, decimals int32, priceToken *common.Address) types.PortfolioHolding {
+	ph := types.PortfolioHolding{
+		Token:    token,
+		Symbol:   symbol,
+		Balance:  hexutil.Big(*bal),
+		Decimals: decimals,
+		Value:    -1,
+	}
+	if priceToken == nil || bal.Sign() == 0 {
+		return ph
+	}
+
+	dt, err := p.DefiToken(priceToken)
+	if err != nil {
+		return ph
+	}
+	price, err := p.DefiTokenPrice(priceToken)
+	if err != nil || price.ToInt().Sign() <= 0 {
+		return ph
+	}
+
+	ph.Value = usdValue(bal, decimals, price.ToInt(), dt.PriceDecimals)
+	return ph
+}
+
+// portfolioDistribution calculates the shares of the priced holdings and orders them by value.
+// Empty holdings are skipped, holdings without a price are listed separately.
+func portfolioDistribution(holdings []types.PortfolioHolding) *types.PortfolioDistribution {
+	pd := types.PortfolioDistribution{
+		Holdings: make([]types.PortfolioHolding, 0, len(holdings)),
+		Unpriced: make([]types.PortfolioHolding, 0),
+	}
+
+	for _, ph := range holdings {
+		switch {
+		case ph.Balance.ToInt().Sign() == 0:
+			continue
+		case ph.Value < 0:
+			ph.Value = 0
+			pd.Unpriced = append(pd.Unpriced, ph)
+		default:
+			pd.Total += ph.Value
+			pd.Holdings = append(pd.Holdings, ph)
+		}
+	}
+
+	for i := range pd.Holdings {
+		if pd.Total > 0 {
+			pd.Holdings[i].Share = pd.Holdings[i].Value / pd.Total * 100
+		}
+	}
+	sort.SliceStable(pd.Holdings, func(i, j int) bool {
+		return pd.Holdings[i].Value > pd.Holdings[j].Value
+	})
+	return &pd
+}
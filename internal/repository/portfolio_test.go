@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testHolding builds a portfolio holding of the given symbol, balance and value.
+func testHolding(symbol string, balance int64, value float64) types.PortfolioHolding {
+	return types.PortfolioHolding{Symbol: symbol, Balance: hexutil.Big(*big.NewInt(balance)), Value: value}
+}
+
+// TestPortfolioDistribution tests the shares, the order and the unpriced bucket of the distribution.
+func TestPortfolioDistribution(t *testing.T) {
+	pd := portfolioDistribution([]types.PortfolioHolding{
+		testHolding("FTM", 100, 25),
+		testHolding("fUSD", 50, 75),
+		testHolding("SCAM", 1000, -1),
+		testHolding("EMPTY", 0, 10),
+	})
+
+	if pd.Total != 100 {
+		t.Errorf("expected total 100, got %f", pd.Total)
+	}
+	if len(pd.Holdings) != 2 || pd.Holdings[0].Symbol != "fUSD" || pd.Holdings[1].Symbol != "FTM" {
+		t.Fatalf("unexpected holdings %v", pd.Holdings)
+	}
+	if math.Abs(pd.Holdings[0].Share-75) > 1e-9 || math.Abs(pd.Holdings[1].Share-25) > 1e-9 {
+		t.Errorf("unexpected shares %f, %f", pd.Holdings[0].Share, pd.Holdings[1].Share)
+	}
+	if len(pd.Unpriced) != 1 || pd.Unpriced[0].Symbol != "SCAM" || pd.Unpriced[0].Share != 0 || pd.Unpriced[0].Value != 0 {
+		t.Errorf("unexpected unpriced holdings %v", pd.Unpriced)
+	}
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// PriceComparison provides the oracle price of the given token cross-referenced
+// with the DEX spot price against the configured base token. A large divergence
+// may signal a stale oracle, or a thin liquidity of the DEX pair.
+func (p *proxy) PriceComparison(token *common.Address) (*types.PriceComparison, error) {
+	pc := types.PriceComparison{
+		OraclePrice: p.oraclePrice(token),
+		DexPrice:    p.dexSpotPrice(token),
+	}
+	pc.Divergence = priceDivergence(pc.OraclePrice, pc.DexPrice)
+	return &pc, nil
+}
+
+// oraclePrice provides the price of the token from the on-chain price oracle, if available.
+func (p *proxy) oraclePrice(token *common.Address) *float64 {
+	dt, err := p.DefiToken(token)
+	if err != nil {
+		p.log.Debugf("oracle price of %s not available; %s", token.String(), err.Error())
+		return nil
+	}
+
+	price, err := p.DefiTokenPrice(token)
+	if err != nil || price.ToInt().Sign() <= 0 {
+		return nil
+	}
+
+	val := decimalValue(price.ToInt(), dt.PriceDecimals)
+	return &val
+}
+
+// dexSpotPrice provides the spot price of the token derived from the reserves
+// of the DEX pair against the configured base token, if the pair exists.
+func (p *proxy) dexSpotPrice(token *common.Address) *float64 {
+	base := p.cfg.DeFi.Uniswap.PriceBase
+	if base == common.HexToAddress(config.EmptyAddress) {
+		return nil
+	}
+	if *token == base {
+		one := 1.0
+		return &one
+	}
+
+	pair, err := p.UniswapPair(token, &base)
+	if err != nil || *pair == common.HexToAddress(config.EmptyAddress) {
+		return nil
+	}
+
+	tokens, err := p.UniswapTokens(pair)
+	if err != nil || len(tokens) != 2 {
+		return nil
+	}
+	reserves, err := p.UniswapReserves(pair)
+	if err != nil || len(reserves) != 2 {
+		return nil
+	}
+
+	// the reserves are ordered by the pair tokens
+	tokenRes, baseRes := reserves[0].ToInt(), reserves[1].ToInt()
+	if tokens[0] == base {
+		tokenRes, baseRes = baseRes, tokenRes
+	}
+
+	tk, err := p.Erc20Token(token)
+	if err != nil {
+		return nil
+	}
+	bt, err := p.Erc20Token(&base)
+	if err != nil {
+		return nil
+	}
+	return spotPrice(tokenRes, tk.Decimals, baseRes, bt.Decimals)
+}
+
+// spotPrice calculates the price of the token in the base token from the pair reserves.
+func spotPrice(tokenRes *big.Int, tokenDecimals int32, baseRes *big.Int, baseDecimals int32) *float64 {
+	if tokenRes.Sign() <= 0 || baseRes.Sign() <= 0 {
+		return nil
+	}
+	val := decimalValue(baseRes, baseDecimals) / decimalValue(tokenRes, tokenDecimals)
+	return &val
+}
+
+// priceDivergence calculates the difference of the DEX price from the oracle price in percent.
+func priceDivergence(oracle *float64, dex *float64) *float64 {
+	if oracle == nil || dex == nil || *oracle == 0 {
+		return nil
+	}
+	val := (*dex - *oracle) / *oracle * 100
+	return &val
+}
+
+// decimalValue converts the given integer amount with the given number of decimals to float.
+func decimalValue(amount *big.Int, decimals int32) float64 {
+	div := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), div).Float64()
+	return val
+}
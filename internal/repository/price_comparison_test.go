@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestSpotPrice tests the DEX spot price derived from the pair reserves respects the token decimals.
+func TestSpotPrice(t *testing.T) {
+	// 1000 tokens of 18 decimals against 2500 base tokens of 6 decimals
+	tokenRes := new(big.Int).Mul(big.NewInt(1000), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	baseRes := big.NewInt(2500000000)
+
+	price := spotPrice(tokenRes, 18, baseRes, 6)
+	if price == nil || math.Abs(*price-2.5) > 1e-9 {
+		t.Errorf("expected spot price 2.5, got %v", price)
+	}
+
+	if spotPrice(new(big.Int), 18, baseRes, 6) != nil {
+		t.Errorf("expected no spot price of an empty pair")
+	}
+}
+
+// TestPriceDivergence tests the divergence of the DEX price from the oracle price.
+func TestPriceDivergence(t *testing.T) {
+	oracle, dex := 2.0, 2.5
+	if d := priceDivergence(&oracle, &dex); d == nil || math.Abs(*d-25) > 1e-9 {
+		t.Errorf("expected divergence 25%%, got %v", d)
+	}
+	if priceDivergence(nil, &dex) != nil || priceDivergence(&oracle, nil) != nil {
+		t.Errorf("expected no divergence with a price missing")
+	}
+}
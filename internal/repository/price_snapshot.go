@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// StorePriceSnapshot stores a token price snapshot.
+func (p *proxy) StorePriceSnapshot(ps *types.PriceSnapshot) error {
+	return p.db.AddPriceSnapshot(ps)
+}
+
+// PriceHistory provides up to the given number of the most recent price snapshots
+// of the token taken in the given time range, sorted from the oldest one.
+func (p *proxy) PriceHistory(token *common.Address, from time.Time, to time.Time, count int32) ([]*types.PriceSnapshot, error) {
+	return p.db.PriceSnapshots(token, from, to, int64(count))
+}
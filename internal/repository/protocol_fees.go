@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// FMintFeesCollected provides the fees paid on fMint minting over the trailing window.
+// The fee of each mint is charged in the minted token by the fee rate effective at the time,
+// see the fMint settings MintFee4; the indexed fees are aggregated by the minted token.
+func (p *proxy) FMintFeesCollected(window time.Duration) (*types.ProtocolFees, error) {
+	since := feesWindowStart(window)
+	fees, err := p.db.FMintFeesCollected(since)
+	if err != nil {
+		return nil, err
+	}
+	return p.protocolFees(since, fees), nil
+}
+
+// UniswapFeesCollected provides the trading fees paid on Uniswap swaps over the trailing window.
+// The pairs do not expose the accumulated fees, the fee is charged on the input amount
+// of each swap and stays in the pair reserves, so the fees are derived from the indexed
+// swap inputs and the fixed fee rate of the pairs.
+func (p *proxy) UniswapFeesCollected(window time.Duration) (*types.ProtocolFees, error) {
+	since := feesWindowStart(window)
+	inputs, err := p.db.UniswapSwapInputs(since)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := make(map[common.Address]*big.Int)
+	for pair, in := range inputs {
+		tokens, err := p.UniswapTokens(&pair)
+		if err != nil || len(tokens) != 2 {
+			p.log.Errorf("can not get tokens of pair %s", pair.String())
+			continue
+		}
+		for i, tok := range tokens {
+			if _, ok := fees[tok]; !ok {
+				fees[tok] = new(big.Int)
+			}
+			fees[tok].Add(fees[tok], uniswapFee(in[i]))
+		}
+	}
+	return p.protocolFees(since, fees), nil
+}
+
+// feesWindowStart provides the start of the fees aggregation window.
+func feesWindowStart(window time.Duration) time.Time {
+	if window > types.ProtocolFeesMaxWindow {
+		window = types.ProtocolFeesMaxWindow
+	}
+	return time.Now().UTC().Add(-window)
+}
+
+// uniswapFee calculates the trading fee charged on the given swap input amount.
+func uniswapFee(in *big.Int) *big.Int {
+	fee := new(big.Int).Mul(in, big.NewInt(uniswapFeeDenominator-uniswapFeeNumerator))
+	return fee.Div(fee, big.NewInt(uniswapFeeDenominator))
+}
+
+// protocolFees builds the protocol fees summary pricing the collected tokens
+// by their current oracle price.
+func (p *proxy) protocolFees(since time.Time, fees map[common.Address]*big.Int) *types.ProtocolFees {
+	res := types.ProtocolFees{Since: since, Fees: make([]types.TokenFee, 0, len(fees))}
+	for tok, amount := range fees {
+		if amount.Sign() == 0 {
+			continue
+		}
+
+		tf := types.TokenFee{Token: tok, Amount: hexutil.Big(*amount)}
+		tf.UsdValue = p.feeUsdValue(&tok, amount)
+		if tf.UsdValue != nil {
+			res.TotalUsd += *tf.UsdValue
+		} else {
+			res.IsPartial = true
+		}
+		res.Fees = append(res.Fees, tf)
+	}
+
+	sort.Slice(res.Fees, func(i, j int) bool {
+		return res.Fees[i].Amount.ToInt().Cmp(res.Fees[j].Amount.ToInt()) > 0
+	})
+	return &res
+}
+
+// feeUsdValue calculates the USD value of the given amount of the token; nil if the token is not priced.
+func (p *proxy) feeUsdValue(token *common.Address, amount *big.Int) *float64 {
+	dt, err := p.DefiToken(token)
+	if err != nil {
+		return nil
+	}
+
+	price, err := p.DefiTokenPrice(token)
+	if err != nil || price.ToInt().Sign() == 0 {
+		return nil
+	}
+
+	val := usdValue(amount, dt.Decimals, price.ToInt(), dt.PriceDecimals)
+	return &val
+}
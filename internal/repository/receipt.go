@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// TransactionReceipt provides the receipt of the given transaction loaded from the node.
+// Nil receipt is provided for a pending transaction. The effective gas price is taken
+// from the transaction, if the node does not provide it.
+func (p *proxy) TransactionReceipt(hash *common.Hash) (*types.TransactionReceipt, error) {
+	rec, err := p.rpc.TransactionReceipt(hash)
+	if err != nil || rec == nil {
+		return nil, err
+	}
+
+	if rec.EffectiveGasPrice == nil {
+		trx, err := p.Transaction(hash)
+		if err != nil {
+			return nil, err
+		}
+		rec.EffectiveGasPrice = &trx.GasPrice
+	}
+	return rec, nil
+}
+
+// DecodeLog decodes the given log record by the ABI of the emitting contract,
+// if the contract is validated. Nil event is provided if the ABI is not known,
+// or it does not match the log record.
+func (p *proxy) DecodeLog(lg *etc.Log) (*types.DecodedEvent, error) {
+	sc, err := p.Contract(&lg.Address)
+	if err != nil || sc == nil || sc.Abi == "" {
+		return nil, err
+	}
+
+	ab, err := abi.JSON(strings.NewReader(sc.Abi))
+	if err != nil {
+		p.log.Errorf("invalid ABI of contract %s; %s", lg.Address.String(), err.Error())
+		return nil, nil
+	}
+	return decodeEvent(&ab, lg), nil
+}
+
+// decodeEvent decodes the given log record by the given contract ABI.
+// Nil is provided if the log record does not match any event of the ABI.
+func decodeEvent(ab *abi.ABI, lg *etc.Log) *types.DecodedEvent {
+	if len(lg.Topics) == 0 {
+		return nil
+	}
+
+	ev, err := ab.EventByID(lg.Topics[0])
+	if err != nil {
+		return nil
+	}
+
+	// the same event signature may have different indexed arguments, e.g. ERC20 vs. ERC721 Transfer
+	var indexed abi.Arguments
+	for _, in := range ev.Inputs {
+		if in.Indexed {
+			indexed = append(indexed, in)
+		}
+	}
+	if len(indexed) != len(lg.Topics)-1 {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(ev.Inputs))
+	if err := ev.Inputs.NonIndexed().UnpackIntoMap(values, lg.Data); err != nil {
+		return nil
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, lg.Topics[1:]); err != nil {
+		return nil
+	}
+
+	de := types.DecodedEvent{Name: ev.Name, Signature: ev.Sig, Fields: make([]types.DecodedEventField, len(ev.Inputs))}
+	for i, in := range ev.Inputs {
+		de.Fields[i] = types.DecodedEventField{
+			Name:    in.Name,
+			Type:    in.Type.String(),
+			Value:   abiValueString(values[in.Name]),
+			Indexed: in.Indexed,
+		}
+	}
+	return &de
+}
+
+// abiValueString provides the string representation of a decoded ABI value.
+// Addresses and byte values are provided in hex, numbers in decimal.
+func abiValueString(val interface{}) string {
+	switch v := val.(type) {
+	case common.Address:
+		return v.String()
+	case common.Hash:
+		return v.String()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+
+	// fixed size byte arrays
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprintf("%v", val)
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testTransferAbi is the ABI of the ERC20 Transfer event.
+const testTransferAbi = `[{"anonymous":false,"inputs":[
+	{"indexed":true,"name":"from","type":"address"},
+	{"indexed":true,"name":"to","type":"address"},
+	{"indexed":false,"name":"value","type":"uint256"}],
+	"name":"Transfer","type":"event"}]`
+
+// TestDecodeEvent tests the log records are decoded by the contract ABI.
+func TestDecodeEvent(t *testing.T) {
+	ab, err := abi.JSON(strings.NewReader(testTransferAbi))
+	if err != nil {
+		t.Fatalf("invalid ABI; %s", err.Error())
+	}
+
+	from := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+	sig := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+	lg := etc.Log{
+		Topics: []common.Hash{sig, from.Hash(), to.Hash()},
+		Data:   common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+	}
+	ev := decodeEvent(&ab, &lg)
+	if ev == nil {
+		t.Fatalf("expected decoded event")
+	}
+	if ev.Name != "Transfer" || ev.Signature != "Transfer(address,address,uint256)" || len(ev.Fields) != 3 {
+		t.Fatalf("unexpected event %v", ev)
+	}
+	if ev.Fields[0].Value != from.String() || !ev.Fields[0].Indexed {
+		t.Errorf("unexpected from field %v", ev.Fields[0])
+	}
+	if ev.Fields[1].Value != to.String() || !ev.Fields[1].Indexed {
+		t.Errorf("unexpected to field %v", ev.Fields[1])
+	}
+	if ev.Fields[2].Value != "1000" || ev.Fields[2].Type != "uint256" || ev.Fields[2].Indexed {
+		t.Errorf("unexpected value field %v", ev.Fields[2])
+	}
+
+	// ERC721 Transfer shares the signature, but indexes the token id
+	nft := etc.Log{Topics: []common.Hash{sig, from.Hash(), to.Hash(), common.BigToHash(big.NewInt(5))}}
+	if ev := decodeEvent(&ab, &nft); ev != nil {
+		t.Errorf("expected no event on mismatched topics, got %v", ev)
+	}
+
+	// unknown event
+	if ev := decodeEvent(&ab, &etc.Log{Topics: []common.Hash{common.HexToHash("0x01")}}); ev != nil {
+		t.Errorf("expected no event on unknown signature, got %v", ev)
+	}
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "motif-api/internal/types"
+
+// AddReorg stores a chain reorg record in the persistent storage.
+func (p *proxy) AddReorg(r *types.Reorg) error {
+	return p.db.AddReorg(r, p.cfg.Repository.ReorgRetention)
+}
+
+// Reorgs pulls list of chain reorgs starting at the specified cursor.
+func (p *proxy) Reorgs(cursor *string, count int32) (*types.ReorgList, error) {
+	return p.db.Reorgs(cursor, count)
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"golang.org/x/sync/singleflight"
 	"sync"
+	"sync/atomic"
 )
 
 // repo represents an instance of the Repository manager.
@@ -74,6 +75,18 @@ type proxy struct {
 
 	// smart contract compilers
 	solCompiler string
+
+	// chain metrics of the recent blocks window
+	chainMetrics atomic.Value
+
+	// decimals of ERC20 tokens never change; they are kept for the process lifetime
+	erc20Decimals sync.Map
+
+	// guards the map of ERC20 token logos against reloads
+	tokenLogoLock sync.RWMutex
+
+	// known ERC20 tokens used by the risk heuristics, see erc20KnownTokens
+	erc20Known atomic.Value
 }
 
 // newRepository creates new instance of Repository implementation, namely proxy structure.
@@ -157,6 +170,7 @@ func (p *proxy) Close() {
 	// close connections
 	p.db.Close()
 	p.rpc.Close()
+	p.cache.Close()
 
 	// inform about actions
 	p.log.Notice("repository done")
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// RewardSources provides the rewards claimed by the given delegator over the trailing window
+// grouped by the validator they were earned on. The amounts are summed exactly
+// from the claimed amounts, not from the indexed values.
+func (p *proxy) RewardSources(addr *common.Address, window time.Duration, ascending bool) (*types.RewardSources, error) {
+	if window > types.RewardSourcesMaxWindow {
+		window = types.RewardSourcesMaxWindow
+	}
+	since := time.Now().UTC().Add(-window)
+
+	claims, err := p.db.RewardClaimsSince(addr, since)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := rewardSources(claims, ascending)
+	rs.Since = since
+	return rs, nil
+}
+
+// rewardSources groups the reward claims by the validator, calculates the share
+// of each validator on the total and orders the validators by the claimed amount.
+func rewardSources(claims []types.RewardClaim, ascending bool) *types.RewardSources {
+	total := new(big.Int)
+	index := make(map[string]int)
+	list := make([]types.RewardSource, 0)
+
+	for _, rc := range claims {
+		key := rc.ToValidatorId.String()
+		i, ok := index[key]
+		if !ok {
+			i = len(list)
+			index[key] = i
+			list = append(list, types.RewardSource{ValidatorId: rc.ToValidatorId})
+		}
+
+		list[i].Amount = hexutil.Big(*new(big.Int).Add(list[i].Amount.ToInt(), rc.Amount.ToInt()))
+		list[i].Claims++
+		total.Add(total, rc.Amount.ToInt())
+	}
+
+	// the share in percent of the total
+	if total.Sign() > 0 {
+		ft := new(big.Float).SetInt(total)
+		for i := range list {
+			list[i].Share, _ = new(big.Float).Quo(new(big.Float).Mul(new(big.Float).SetInt(list[i].Amount.ToInt()), big.NewFloat(100)), ft).Float64()
+		}
+	}
+
+	// order by the amount; the validator ID breaks ties to keep the order stable
+	sort.Slice(list, func(i, j int) bool {
+		c := list[i].Amount.ToInt().Cmp(list[j].Amount.ToInt())
+		if c == 0 {
+			return list[i].ValidatorId.ToInt().Cmp(list[j].ValidatorId.ToInt()) < 0
+		}
+		return (c < 0) == ascending
+	})
+	return &types.RewardSources{Total: hexutil.Big(*total), Sources: list}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testRewardClaim builds a reward claim of the given amount from the given validator.
+func testRewardClaim(validator int64, amount *big.Int) types.RewardClaim {
+	return types.RewardClaim{
+		ToValidatorId: hexutil.Big(*big.NewInt(validator)),
+		Amount:        hexutil.Big(*amount),
+	}
+}
+
+// TestRewardSources tests grouping of reward claims by the validator.
+func TestRewardSources(t *testing.T) {
+	// amounts beyond the indexed value precision must be summed exactly
+	wei, _ := new(big.Int).SetString("1000000000000000001", 10)
+	claims := []types.RewardClaim{
+		testRewardClaim(1, wei),
+		testRewardClaim(2, big.NewInt(500)),
+		testRewardClaim(1, wei),
+		testRewardClaim(3, new(big.Int).Mul(wei, big.NewInt(2))),
+	}
+
+	rs := rewardSources(claims, false)
+	want, _ := new(big.Int).SetString("4000000000000000504", 10)
+	if rs.Total.ToInt().Cmp(want) != 0 {
+		t.Errorf("expected total %s, got %s", want.String(), rs.Total.ToInt().String())
+	}
+	if len(rs.Sources) != 3 {
+		t.Fatalf("expected 3 validators, got %d", len(rs.Sources))
+	}
+
+	// validators 1 and 3 tie on the amount, the ID breaks the tie
+	order := []int64{1, 3, 2}
+	for i, id := range order {
+		if rs.Sources[i].ValidatorId.ToInt().Int64() != id {
+			t.Errorf("expected validator #%d at %d, got #%d", id, i, rs.Sources[i].ValidatorId.ToInt().Int64())
+		}
+	}
+	if rs.Sources[0].Claims != 2 || rs.Sources[0].Amount.ToInt().Cmp(new(big.Int).Mul(wei, big.NewInt(2))) != 0 {
+		t.Errorf("unexpected validator #1 rewards %+v", rs.Sources[0])
+	}
+
+	var share float64
+	for _, src := range rs.Sources {
+		share += src.Share
+	}
+	if share < 99.999 || share > 100.001 {
+		t.Errorf("expected shares to add up to 100%%, got %f", share)
+	}
+
+	// ascending order
+	rs = rewardSources(claims, true)
+	if rs.Sources[0].ValidatorId.ToInt().Int64() != 2 {
+		t.Errorf("expected validator #2 first, got #%d", rs.Sources[0].ValidatorId.ToInt().Int64())
+	}
+
+	// no claims
+	if rs = rewardSources(nil, false); len(rs.Sources) != 0 || rs.Total.ToInt().Sign() != 0 {
+		t.Errorf("expected empty sources, got %+v", rs)
+	}
+}
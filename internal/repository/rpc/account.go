@@ -14,35 +14,81 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"motif-api/internal/logger"
+	"context"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strings"
 )
 
+// stateNotAvailableMessages are fragments of node errors signaling the state
+// of the requested block is not available, e.g. it has been pruned.
+var stateNotAvailableMessages = []string{
+	"missing trie node",
+	"header not found",
+	"state not available",
+	"state is not available",
+}
+
 // AccountBalance reads balance of account from Lachesis node.
 func (ftm *FtmBridge) AccountBalance(addr *common.Address) (*hexutil.Big, error) {
+	return ftm.AccountBalanceAt(addr, nil)
+}
+
+// AccountBalanceAt reads balance of account from Lachesis node at the given block.
+// The latest block is used if the block is not specified. The state of older blocks
+// is available only on archive nodes.
+func (ftm *FtmBridge) AccountBalanceAt(addr *common.Address, block *hexutil.Uint64) (*hexutil.Big, error) {
+	return ftm.AccountBalanceAtContext(context.Background(), addr, block)
+}
+
+// AccountBalanceAtContext reads balance of account as AccountBalanceAt does within the request context.
+func (ftm *FtmBridge) AccountBalanceAtContext(ctx context.Context, addr *common.Address, block *hexutil.Uint64) (*hexutil.Big, error) {
+	log := logger.WithContext(ctx, ftm.log)
+	tag := BlockTypeLatest
+	if block != nil {
+		tag = block.String()
+	}
+
 	// use RPC to make the call
 	var balance string
-	err := ftm.rpc.Call(&balance, "ftm_getBalance", addr.Hex(), "latest")
+	err := ftm.callContext(ctx, &balance, "ftm_getBalance", addr.Hex(), tag)
 	if err != nil {
-		ftm.log.Errorf("can not get balance of account [%s]", addr.Hex())
+		log.Errorf("can not get balance of account [%s] at %s; %s", addr.Hex(), tag, err.Error())
+		if block != nil && isStateNotAvailable(err) {
+			return nil, fmt.Errorf("state of block #%d is not available on the connected node; %s", uint64(*block), err.Error())
+		}
 		return nil, err
 	}
 
 	// decode the response from remote server
 	val, err := hexutil.DecodeBig(balance)
 	if err != nil {
-		ftm.log.Errorf("can not decode balance of account [%s]", addr.Hex())
+		log.Errorf("can not decode balance of account [%s]", addr.Hex())
 		return nil, err
 	}
 
 	return (*hexutil.Big)(val), nil
 }
 
+// isStateNotAvailable checks if the RPC error signals the state of the requested block
+// has been pruned from the node, or the block is not known to the node at all.
+func isStateNotAvailable(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, m := range stateNotAvailableMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
 // AccountNonce returns the total number of transaction of account from Lachesis node.
 func (ftm *FtmBridge) AccountNonce(addr *common.Address) (uint64, error) {
 	// use RPC to make the call
 	var nonce string
-	err := ftm.rpc.Call(&nonce, "ftm_getTransactionCount", addr.Hex(), "latest")
+	err := ftm.call(&nonce, "ftm_getTransactionCount", addr.Hex(), "latest")
 	if err != nil {
 		ftm.log.Errorf("can not get number of transaction of account [%s]", addr.Hex())
 		return 0, err
@@ -57,3 +103,24 @@ func (ftm *FtmBridge) AccountNonce(addr *common.Address) (uint64, error) {
 
 	return val, nil
 }
+
+// AccountPendingNonce returns the total number of transaction of account from Lachesis node
+// including the transactions waiting in the pending pool of the node.
+func (ftm *FtmBridge) AccountPendingNonce(addr *common.Address) (uint64, error) {
+	// use RPC to make the call
+	var nonce string
+	err := ftm.call(&nonce, "ftm_getTransactionCount", addr.Hex(), BlockTypePending)
+	if err != nil {
+		ftm.log.Errorf("can not get number of pending transaction of account [%s]", addr.Hex())
+		return 0, err
+	}
+
+	// decode the response from remote server
+	val, err := hexutil.DecodeUint64(nonce)
+	if err != nil {
+		ftm.log.Errorf("can not decode number of pending transaction of account [%s]", addr.Hex())
+		return 0, err
+	}
+
+	return val, nil
+}
@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync"
+)
+
+// AccountBalances reads the current balances of the given accounts from Lachesis node.
+// The calls are issued concurrently by a bounded pool of workers. Balances failed to load
+// are not included in the balances map and the failure is reported by the errors map instead.
+func (ftm *FtmBridge) AccountBalances(addrs []common.Address) (map[common.Address]*hexutil.Big, map[common.Address]error) {
+	balances := make(map[common.Address]*hexutil.Big, len(addrs))
+	failed := make(map[common.Address]error)
+
+	// feed unique addresses to the workers
+	queue := make(chan common.Address, len(addrs))
+	for _, adr := range addrs {
+		if _, ok := balances[adr]; ok {
+			continue
+		}
+		balances[adr] = nil
+		queue <- adr
+	}
+	close(queue)
+
+	workers := ftm.maxConcurrency
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(queue) {
+		workers = len(queue)
+	}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for adr := range queue {
+				val, err := ftm.AccountBalance(&adr)
+
+				lock.Lock()
+				if err != nil {
+					failed[adr] = err
+					delete(balances, adr)
+				} else {
+					balances[adr] = val
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return balances, failed
+}
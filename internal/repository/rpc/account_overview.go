@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"fmt"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"strings"
+)
+
+// AccountOverviews loads balances, nonces and code of the given accounts
+// in a single JSON-RPC batch. Each account degrades independently; values failed
+// to load are left empty and described in the account error.
+func (ftm *FtmBridge) AccountOverviews(addrs []common.Address) []*types.AccountOverview {
+	// three calls per account; balance, nonce and code
+	balances := make([]hexutil.Big, len(addrs))
+	nonces := make([]hexutil.Uint64, len(addrs))
+	codes := make([]hexutil.Bytes, len(addrs))
+
+	batch := make([]ethrpc.BatchElem, 0, 3*len(addrs))
+	for i := range addrs {
+		adr := addrs[i].Hex()
+		batch = append(batch,
+			ethrpc.BatchElem{Method: "ftm_getBalance", Args: []interface{}{adr, BlockTypeLatest}, Result: &balances[i]},
+			ethrpc.BatchElem{Method: "ftm_getTransactionCount", Args: []interface{}{adr, BlockTypeLatest}, Result: &nonces[i]},
+			ethrpc.BatchElem{Method: "ftm_getCode", Args: []interface{}{adr, BlockTypeLatest}, Result: &codes[i]},
+		)
+	}
+
+	// the batch failed as a whole?
+	err := ftm.batchCall("account_overview", batch)
+	if err != nil {
+		ftm.log.Errorf("can not load accounts overview batch; %s", err.Error())
+	}
+
+	list := make([]*types.AccountOverview, len(addrs))
+	for i := range addrs {
+		list[i] = accountOverview(addrs[i], batch[3*i:3*i+3], err)
+	}
+	return list
+}
+
+// accountOverview builds the account overview from the results of its batch calls.
+func accountOverview(addr common.Address, calls []ethrpc.BatchElem, batchErr error) *types.AccountOverview {
+	ao := types.AccountOverview{Address: addr}
+	if batchErr != nil {
+		msg := batchErr.Error()
+		ao.Error = &msg
+		return &ao
+	}
+
+	failed := make([]string, 0)
+	for i, call := range calls {
+		if call.Error != nil {
+			failed = append(failed, fmt.Sprintf("%s; %s", call.Method, call.Error.Error()))
+			continue
+		}
+
+		switch i {
+		case 0:
+			ao.Balance = call.Result.(*hexutil.Big)
+		case 1:
+			ao.Nonce = call.Result.(*hexutil.Uint64)
+		case 2:
+			size := hexutil.Uint64(len(*call.Result.(*hexutil.Bytes)))
+			ao.CodeSize = &size
+		}
+	}
+
+	if len(failed) > 0 {
+		msg := strings.Join(failed, ", ")
+		ao.Error = &msg
+	}
+	return &ao
+}
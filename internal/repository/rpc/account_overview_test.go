@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testOverviewCalls creates the balance, nonce and code batch calls of an account
+// with the given results; a call with the error set failed.
+func testOverviewCalls(errs [3]error) []ethrpc.BatchElem {
+	balance := hexutil.Big(*big.NewInt(1000))
+	nonce := hexutil.Uint64(5)
+	code := hexutil.Bytes{0x60, 0x80, 0x60, 0x40}
+	return []ethrpc.BatchElem{
+		{Method: "ftm_getBalance", Result: &balance, Error: errs[0]},
+		{Method: "ftm_getTransactionCount", Result: &nonce, Error: errs[1]},
+		{Method: "ftm_getCode", Result: &code, Error: errs[2]},
+	}
+}
+
+// TestAccountOverview tests the account overview is built from its batch calls
+// and each failure is reported in the account error.
+func TestAccountOverview(t *testing.T) {
+	tests := []struct {
+		name     string
+		calls    []ethrpc.BatchElem
+		batchErr error
+		balance  int64
+		nonce    int64
+		codeSize int64
+		err      string
+	}{
+		{"all loaded", testOverviewCalls([3]error{}), nil, 1000, 5, 4, ""},
+		{"nonce failed", testOverviewCalls([3]error{nil, fmt.Errorf("timeout"), nil}), nil, 1000, -1, 4, "ftm_getTransactionCount; timeout"},
+		{"batch failed", testOverviewCalls([3]error{}), fmt.Errorf("connection refused"), -1, -1, -1, "connection refused"},
+	}
+
+	addr := common.HexToAddress("0xa1")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ao := accountOverview(addr, tt.calls, tt.batchErr)
+			if ao.Address != addr {
+				t.Errorf("expected address %s, got %s", addr.String(), ao.Address.String())
+			}
+
+			balance, nonce, codeSize := int64(-1), int64(-1), int64(-1)
+			if ao.Balance != nil {
+				balance = ao.Balance.ToInt().Int64()
+			}
+			if ao.Nonce != nil {
+				nonce = int64(*ao.Nonce)
+			}
+			if ao.CodeSize != nil {
+				codeSize = int64(*ao.CodeSize)
+			}
+			if balance != tt.balance || nonce != tt.nonce || codeSize != tt.codeSize {
+				t.Errorf("expected %d/%d/%d, got %d/%d/%d", tt.balance, tt.nonce, tt.codeSize, balance, nonce, codeSize)
+			}
+
+			var msg string
+			if ao.Error != nil {
+				msg = *ao.Error
+			}
+			if msg != tt.err {
+				t.Errorf("expected error %q, got %q", tt.err, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"motif-api/internal/repository/rpc/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// Erc20BalanceAt provides the balance of the ERC20 token of the owner at the given block.
+// Tokens not deployed yet at the given block have zero balance.
+func (ftm *FtmBridge) Erc20BalanceAt(token *common.Address, owner *common.Address, block uint64) (*big.Int, error) {
+	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not contact ERC20 contract; %s", err.Error())
+		return nil, err
+	}
+
+	val, err := contract.BalanceOf(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(block)}, *owner)
+	if err == bind.ErrNoCode {
+		return new(big.Int), nil
+	}
+	if err != nil {
+		ftm.log.Errorf("can not get ERC20 %s balance of %s at #%d; %s", token.String(), owner.String(), block, err.Error())
+		return nil, err
+	}
+	return val, nil
+}
+
+// Erc20TotalSupplyAt provides the total supply of the ERC20 token at the given block.
+// Tokens not deployed yet at the given block have zero supply.
+func (ftm *FtmBridge) Erc20TotalSupplyAt(token *common.Address, block uint64) (*big.Int, error) {
+	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not contact ERC20 contract; %s", err.Error())
+		return nil, err
+	}
+
+	val, err := contract.TotalSupply(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(block)})
+	if err == bind.ErrNoCode {
+		return new(big.Int), nil
+	}
+	if err != nil {
+		ftm.log.Errorf("can not get ERC20 %s total supply at #%d; %s", token.String(), block, err.Error())
+		return nil, err
+	}
+	return val, nil
+}
@@ -55,6 +55,10 @@ func (ftm *FtmBridge) observeBlocks() {
 		case err := <-sub.Err():
 			ftm.log.Errorf("block subscription failed; %s", err.Error())
 			sub = nil
+		case <-ftm.nodeSwitch:
+			// the calls failed over to another node, follow its blocks
+			sub.Unsubscribe()
+			sub = ftm.blockSubscription()
 		}
 	}
 }
@@ -62,7 +66,7 @@ func (ftm *FtmBridge) observeBlocks() {
 // blockSubscription provides a subscription for new blocks received
 // by the connected blockchain node.
 func (ftm *FtmBridge) blockSubscription() ethereum.Subscription {
-	sub, err := ftm.rpc.EthSubscribe(context.Background(), ftm.headers, "newHeads")
+	sub, err := ftm.node().rpc.EthSubscribe(context.Background(), ftm.headers, "newHeads")
 	if err != nil {
 		ftm.log.Criticalf("can not observe new blocks; %s", err.Error())
 		return nil
@@ -20,12 +20,13 @@ import (
 	"motif-api/internal/repository/rpc/contracts"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	etc "github.com/ethereum/go-ethereum/core/types"
-	eth "github.com/ethereum/go-ethereum/ethclient"
 	ftm "github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/sync/singleflight"
 	"strings"
 	"sync"
+	"time"
 )
 
 // rpcHeadProxyChannelCapacity represents the capacity of the new received blocks proxy channel.
@@ -33,16 +34,36 @@ const rpcHeadProxyChannelCapacity = 10000
 
 // FtmBridge represents Lachesis RPC abstraction layer.
 type FtmBridge struct {
-	rpc *ftm.Client
-	eth *eth.Client
+	eth *nodeBackend
 	log logger.Logger
 	cg  *singleflight.Group
 
+	// connected nodes and the index of the node serving calls
+	nodes      []*node
+	active     int
+	nodeLock   sync.RWMutex
+	nodeSwitch chan struct{}
+
 	// fMintCfg represents the configuration of the fMint protocol
 	sigConfig     *config.ServerSignature
 	sfcConfig     *config.Staking
 	uniswapConfig *config.DeFiUniswap
 
+	// max number of concurrent calls of a batch
+	maxConcurrency int
+
+	// retry of calls failed on transient errors
+	retryAttempts int
+	retryBackoff  time.Duration
+
+	// tolerant ERC20 decoding and the registry of non-standard tokens found
+	tolerantErc20 bool
+	nonStdErc20   map[common.Address]map[string]bool
+	nonStdLock    sync.Mutex
+
+	// faucet transfers are serialized to get consecutive nonces
+	faucetLock sync.Mutex
+
 	// extended minter config
 	fMintCfg fMintConfig
 	fLendCfg fLendConfig
@@ -53,29 +74,35 @@ type FtmBridge struct {
 
 	// received blocks proxy
 	wg       *sync.WaitGroup
-	sigClose chan bool
+	sigClose chan struct{}
 	headers  chan *etc.Header
 }
 
 // New creates new Lachesis RPC connection bridge.
 func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
-	cli, con, err := connect(cfg, log)
+	nodes, active, err := connect(cfg, log)
 	if err != nil {
 		log.Criticalf("can not open connection; %s", err.Error())
 		return nil, err
 	}
 
-	// build the bridge structure using the con we have
+	// build the bridge structure using the nodes we have
 	br := &FtmBridge{
-		rpc: cli,
-		eth: con,
-		log: log,
-		cg:  new(singleflight.Group),
+		log:        log,
+		cg:         new(singleflight.Group),
+		nodes:      nodes,
+		active:     active,
+		nodeSwitch: make(chan struct{}, 1),
 
 		// special configuration options below this line
-		sigConfig:     &cfg.MySignature,
-		sfcConfig:     &cfg.Staking,
-		uniswapConfig: &cfg.DeFi.Uniswap,
+		sigConfig:      &cfg.MySignature,
+		sfcConfig:      &cfg.Staking,
+		uniswapConfig:  &cfg.DeFi.Uniswap,
+		tolerantErc20:  cfg.Repository.TolerantErc20,
+		maxConcurrency: cfg.Lachesis.MaxConcurrency,
+		retryAttempts:  cfg.Lachesis.RetryAttempts,
+		retryBackoff:   cfg.Lachesis.RetryBackoff,
+		nonStdErc20:    make(map[common.Address]map[string]bool),
 		fMintCfg: fMintConfig{
 			addressProvider: cfg.DeFi.FMint.AddressProvider,
 		},
@@ -83,52 +110,35 @@ func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
 
 		// configure block observation loop
 		wg:       new(sync.WaitGroup),
-		sigClose: make(chan bool, 1),
+		sigClose: make(chan struct{}),
 		headers:  make(chan *etc.Header, rpcHeadProxyChannelCapacity),
 	}
 
 	// inform about the local address of the API node
 	log.Noticef("using signature address %s", br.sigConfig.Address.String())
 
-	// add the bridge ref to the fMintCfg and return the instance
+	// add the bridge ref to the fMintCfg and the contracts backend and return the instance
 	br.fMintCfg.bridge = br
-	br.run()
+	br.eth = &nodeBackend{bridge: br}
+	br.run(cfg.Lachesis.HealthCheck)
 	return br, nil
 }
 
-// connect opens connections we need to communicate with the blockchain node.
-func connect(cfg *config.Config, log logger.Logger) (*ftm.Client, *eth.Client, error) {
-	// log what we do
-	log.Debugf("connecting blockchain node at %s", cfg.Lachesis.Url)
-
-	// try to establish a connection
-	client, err := ftm.Dial(cfg.Lachesis.Url)
-	if err != nil {
-		log.Critical(err)
-		return nil, nil, err
-	}
-
-	// try to establish a for smart contract interaction
-	con, err := eth.Dial(cfg.Lachesis.Url)
-	if err != nil {
-		log.Critical(err)
-		return nil, nil, err
-	}
-
-	// log
-	log.Notice("node connection open")
-	return client, con, nil
-}
-
 // run starts the bridge threads required to collect blockchain data.
-func (ftm *FtmBridge) run() {
+func (ftm *FtmBridge) run(healthCheck time.Duration) {
 	ftm.wg.Add(1)
 	go ftm.observeBlocks()
+
+	// track health of the nodes, if we have a choice
+	if len(ftm.nodes) > 1 && healthCheck > 0 {
+		ftm.wg.Add(1)
+		go ftm.monitorNodes(healthCheck)
+	}
 }
 
 // terminate kills the bridge threads to end the bridge gracefully.
 func (ftm *FtmBridge) terminate() {
-	ftm.sigClose <- true
+	close(ftm.sigClose)
 	ftm.wg.Wait()
 	ftm.log.Noticef("rpc threads terminated")
 }
@@ -138,17 +148,18 @@ func (ftm *FtmBridge) Close() {
 	// terminate threads before we close connections
 	ftm.terminate()
 
-	// do we have a connection?
-	if ftm.rpc != nil {
-		ftm.rpc.Close()
-		ftm.eth.Close()
-		ftm.log.Info("blockchain connections are closed")
+	// close all the node connections
+	for _, n := range ftm.nodes {
+		if n.rpc != nil {
+			n.rpc.Close()
+		}
 	}
+	ftm.log.Info("blockchain connections are closed")
 }
 
-// Connection returns open Opera/Lachesis connection.
+// Connection returns open Opera/Lachesis connection of the node serving calls.
 func (ftm *FtmBridge) Connection() *ftm.Client {
-	return ftm.rpc
+	return ftm.node().rpc
 }
 
 // DefaultCallOpts creates a default record for call options.
@@ -0,0 +1,39 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Code provides the code deployed at the given address; empty for externally owned accounts.
+func (ftm *FtmBridge) Code(addr *common.Address) ([]byte, error) {
+	code, err := ftm.eth.CodeAt(context.Background(), *addr, nil)
+	if err != nil {
+		ftm.log.Errorf("can not get code of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return code, nil
+}
+
+// StorageAt provides the value of the given storage slot of the given contract.
+func (ftm *FtmBridge) StorageAt(addr *common.Address, slot common.Hash) (common.Hash, error) {
+	val, err := ftm.eth.StorageAt(context.Background(), *addr, slot, nil)
+	if err != nil {
+		ftm.log.Errorf("can not get storage slot %s of %s; %s", slot.String(), addr.String(), err.Error())
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(val), nil
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import "sync"
+
+// forEachConcurrent calls the given job for each index below the given count
+// with at most the configured max number of node calls running concurrently.
+// It returns when all the jobs are done.
+func (ftm *FtmBridge) forEachConcurrent(count int, job func(i int)) {
+	workers := ftm.maxConcurrency
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > count {
+		workers = count
+	}
+
+	// feed the indexes to the workers
+	queue := make(chan int, count)
+	for i := 0; i < count; i++ {
+		queue <- i
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				job(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
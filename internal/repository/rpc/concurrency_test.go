@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestForEachConcurrent tests all the jobs are done with the concurrency limited.
+func TestForEachConcurrent(t *testing.T) {
+	for _, limit := range []int{0, 1, 3, 16} {
+		ftm := FtmBridge{maxConcurrency: limit}
+
+		var running, peak int32
+		done := make([]int32, 10)
+		ftm.forEachConcurrent(len(done), func(i int) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&done[i], 1)
+			atomic.AddInt32(&running, -1)
+		})
+
+		want := int32(limit)
+		if limit <= 0 {
+			want = 1
+		}
+		if want > int32(len(done)) {
+			want = int32(len(done))
+		}
+		if peak > want {
+			t.Errorf("limit %d: expected at most %d concurrent jobs, got %d", limit, want, peak)
+		}
+		for i, n := range done {
+			if n != 1 {
+				t.Errorf("limit %d: job %d done %d times", limit, i, n)
+			}
+		}
+	}
+
+	// no jobs, no workers
+	(&FtmBridge{maxConcurrency: 4}).forEachConcurrent(0, func(int) {
+		t.Errorf("unexpected job")
+	})
+}
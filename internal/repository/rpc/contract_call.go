@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractCall executes a read only call of the given contract with the given input data
+// on the state of the given block, or the latest one, and provides the raw return data.
+// The decoded revert reason is provided as the error if the call reverts.
+func (ftm *FtmBridge) ContractCall(to *common.Address, data hexutil.Bytes, block *hexutil.Uint64) (hexutil.Bytes, error) {
+	tag := BlockTypeLatest
+	if block != nil {
+		tag = block.String()
+	}
+
+	call := struct {
+		To   *common.Address `json:"to"`
+		Data hexutil.Bytes   `json:"data"`
+	}{
+		To:   to,
+		Data: data,
+	}
+
+	var res hexutil.Bytes
+	if err := ftm.call(&res, "ftm_call", call, tag); err != nil {
+		if reason := revertReason(err); reason != nil {
+			return nil, fmt.Errorf("%s%s", revertErrorPrefix, *reason)
+		}
+		if block != nil && isStateNotAvailable(err) {
+			return nil, fmt.Errorf("state of block #%d is not available on the connected node; %s", uint64(*block), err.Error())
+		}
+
+		ftm.log.Errorf("can not call contract %s at %s; %s", to.String(), tag, err.Error())
+		return nil, err
+	}
+	return res, nil
+}
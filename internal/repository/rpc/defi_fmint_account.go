@@ -336,3 +336,81 @@ func (ftm *FtmBridge) FMintCanPushRewards() (bool, error) {
 	// @todo Check the amount of rewards available so we know that it will push.
 	return true, nil
 }
+
+// FMintAccountTokens loads the list of token positions of the given fMint account
+// on the given side, collateral or debt. Tokens with no balance are skipped.
+func (ftm *FtmBridge) FMintAccountTokens(owner *common.Address, tp types.DefiTokenType) ([]*types.FMintTokenPosition, error) {
+	// get the pool of the requested side
+	var err error
+	var pool *contracts.DeFiTokenStorage
+	switch tp {
+	case types.DefiTokenTypeCollateral:
+		pool, err = ftm.fMintCfg.fMintCollateralPool()
+	case types.DefiTokenTypeDebt:
+		pool, err = ftm.fMintCfg.fMintDebtPool()
+	}
+	if err != nil {
+		ftm.log.Debugf("token storage pool failed to load; %s", err.Error())
+		return nil, err
+	}
+	if pool == nil {
+		return make([]*types.FMintTokenPosition, 0), nil
+	}
+
+	// get the list of tokens
+	tokens, err := ftm.DefiTokens()
+	if err != nil {
+		ftm.log.Errorf("defi tokens list loader failed; %s", err.Error())
+		return nil, err
+	}
+
+	// load the positions in parallel, limited by the node calls concurrency
+	list := make([]*types.FMintTokenPosition, len(tokens))
+	errs := make([]error, len(tokens))
+	ftm.forEachConcurrent(len(tokens), func(i int) {
+		list[i], errs[i] = ftm.fMintTokenPosition(pool, owner, &tokens[i], tp)
+	})
+
+	// collect non-empty positions
+	res := make([]*types.FMintTokenPosition, 0)
+	for i, pos := range list {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if pos != nil {
+			res = append(res, pos)
+		}
+	}
+	return res, nil
+}
+
+// fMintTokenPosition loads a single token position of an fMint account from the given pool.
+// It returns nil if the account has no balance of the token.
+func (ftm *FtmBridge) fMintTokenPosition(pool *contracts.DeFiTokenStorage, owner *common.Address, token *types.DefiToken, tp types.DefiTokenType) (*types.FMintTokenPosition, error) {
+	// get the balance
+	balance, err := ftm.FMintPoolBalance(pool, owner, &token.Address)
+	if err != nil {
+		return nil, err
+	}
+	if balance.ToInt().Sign() == 0 {
+		return nil, nil
+	}
+
+	// get the price from oracle
+	price, err := ftm.FMintTokenPrice(&token.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// value = amount * price / 10^decimals
+	value := new(big.Int).Mul(balance.ToInt(), price.ToInt())
+	value.Div(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(token.Decimals)), nil))
+
+	return &types.FMintTokenPosition{
+		Token:         token.Address,
+		Type:          tp,
+		Amount:        balance,
+		Value:         hexutil.Big(*value),
+		ValueDecimals: token.PriceDecimals,
+	}, nil
+}
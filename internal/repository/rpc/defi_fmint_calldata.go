@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"fmt"
+	"motif-api/internal/repository/rpc/contracts"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// fMintCallMethods maps fMint operations to the minter contract methods building the call.
+var fMintCallMethods = map[int]string{
+	types.FMintTrxTypeDeposit:  "mustDeposit",
+	types.FMintTrxTypeWithdraw: "mustWithdraw",
+	types.FMintTrxTypeMint:     "mustMint",
+	types.FMintTrxTypeRepay:    "mustRepay",
+}
+
+// FMintCallData encodes the input of the fMint minter contract call
+// performing the given operation with the token and amount.
+func (ftm *FtmBridge) FMintCallData(op int, token *common.Address, amount *big.Int) (hexutil.Bytes, error) {
+	method, ok := fMintCallMethods[op]
+	if !ok {
+		return nil, fmt.Errorf("unknown fMint operation %d", op)
+	}
+
+	// the parsed ABI is kept by the binding meta data
+	mAbi, err := contracts.DefiFMintMinterMetaData.GetAbi()
+	if err != nil {
+		ftm.log.Errorf("can not parse fMint minter ABI; %s", err.Error())
+		return nil, err
+	}
+
+	data, err := mAbi.Pack(method, *token, amount)
+	if err != nil {
+		ftm.log.Errorf("can not encode fMint %s call; %s", method, err.Error())
+		return nil, err
+	}
+	return data, nil
+}
+
+// FMintCollateralCanDecrease checks if the collateral of the given token
+// can be decreased by the amount without the account becoming liquidatable.
+func (ftm *FtmBridge) FMintCollateralCanDecrease(owner *common.Address, token *common.Address, amount *big.Int) (bool, error) {
+	// connect the contract
+	contract, err := ftm.fMintCfg.fMintMinterContract()
+	if err != nil {
+		return false, err
+	}
+
+	flag, err := contract.CollateralCanDecrease(nil, *owner, *token, amount)
+	if err != nil {
+		ftm.log.Errorf("can not check collateral decrease of %s; %s", owner.String(), err.Error())
+		return false, err
+	}
+	return flag, nil
+}
+
+// FMintDebtCanIncrease checks if the debt of the given token
+// can be increased by the amount without the account becoming liquidatable.
+func (ftm *FtmBridge) FMintDebtCanIncrease(owner *common.Address, token *common.Address, amount *big.Int) (bool, error) {
+	// connect the contract
+	contract, err := ftm.fMintCfg.fMintMinterContract()
+	if err != nil {
+		return false, err
+	}
+
+	flag, err := contract.DebtCanIncrease(nil, *owner, *token, amount)
+	if err != nil {
+		ftm.log.Errorf("can not check debt increase of %s; %s", owner.String(), err.Error())
+		return false, err
+	}
+	return flag, nil
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FMintRewards loads the pending rewards of the given fMint account along with the reward token
+// and the reward rate of the fMint reward distribution contract. All the values are loaded
+// at the same block. Accounts without any principal balance have only the stashed rewards, if any.
+func (ftm *FtmBridge) FMintRewards(owner *common.Address) (*types.FMintRewards, error) {
+	contract, err := ftm.fMintCfg.fMintRewardsDistribution()
+	if err != nil {
+		return nil, err
+	}
+
+	bl, err := ftm.BlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	co := bind.CallOpts{BlockNumber: bl.ToInt(), Context: context.Background()}
+
+	rw := types.FMintRewards{Owner: *owner}
+	if rw.RewardToken, err = contract.RewardTokenAddress(&co); err != nil {
+		ftm.log.Errorf("can not load fMint reward token; %s", err.Error())
+		return nil, err
+	}
+
+	rate, err := contract.RewardRate(&co)
+	if err != nil {
+		ftm.log.Errorf("can not load fMint reward rate; %s", err.Error())
+		return nil, err
+	}
+	rw.RewardRate = hexutil.Big(*rate)
+
+	total, err := contract.PrincipalBalance(&co)
+	if err != nil {
+		ftm.log.Errorf("can not load fMint principal balance; %s", err.Error())
+		return nil, err
+	}
+	rw.TotalPrincipalBalance = hexutil.Big(*total)
+
+	principal, err := contract.PrincipalBalanceOf(&co, *owner)
+	if err != nil {
+		ftm.log.Errorf("can not load fMint principal balance of %s; %s", owner.String(), err.Error())
+		return nil, err
+	}
+	rw.PrincipalBalance = hexutil.Big(*principal)
+
+	// accounts which never minted don't earn anything, skip the calculation
+	pending := contract.RewardEarned
+	if principal.Sign() == 0 {
+		pending = contract.RewardStash
+	}
+	val, err := pending(&co, *owner)
+	if err != nil {
+		ftm.log.Errorf("can not load fMint pending rewards of %s; %s", owner.String(), err.Error())
+		return nil, err
+	}
+	rw.Pending = hexutil.Big(*val)
+	return &rw, nil
+}
@@ -25,59 +25,40 @@ import (
 
 // Erc20Name provides information about the name of the ERC20 token.
 func (ftm *FtmBridge) Erc20Name(token *common.Address) (string, error) {
-	// connect the contract
-	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
-	if err != nil {
-		ftm.log.Errorf("can not contact ERC20 contract; %s", err.Error())
-		return "", err
-	}
-
-	// get the token name
-	name, err := contract.Name(nil)
+	name, err := ftm.erc20String(token, "name")
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s name not available; %s", token.String(), err.Error())
 		return "", err
 	}
-
 	return name, nil
 }
 
 // Erc20Symbol provides information about the symbol of the ERC20 token.
 func (ftm *FtmBridge) Erc20Symbol(token *common.Address) (string, error) {
-	// connect the contract
-	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
-	if err != nil {
-		ftm.log.Errorf("can not contact ERC20 contract; %s", err.Error())
-		return "", err
-	}
-
-	// get the token name
-	symbol, err := contract.Symbol(nil)
+	symbol, err := ftm.erc20String(token, "symbol")
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s symbol not available; %s", token.String(), err.Error())
 		return "", err
 	}
-
 	return symbol, nil
 }
 
 // Erc20Decimals provides information about the decimals of the ERC20 token.
+// A failed call, e.g. a reverted one, is reported; undecodable values resolve to zero.
 func (ftm *FtmBridge) Erc20Decimals(token *common.Address) (int32, error) {
-	// connect the contract
-	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
+	data, err := ftm.erc20Call(token, "decimals")
 	if err != nil {
-		ftm.log.Errorf("can not contact ERC20 contract; %s", err.Error())
+		ftm.log.Errorf("ERC20 token %s decimals not available; %s", token.String(), err.Error())
 		return 0, err
 	}
 
-	// get the token name
-	deci, err := contract.Decimals(nil)
+	deci, variant, err := decodeErc20Decimals(data, ftm.tolerantErc20)
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s decimals not available; %s", token.String(), err.Error())
 		return 0, nil
 	}
-
-	return int32(deci), nil
+	ftm.markNonStdErc20(token, variant)
+	return deci, nil
 }
 
 // Erc20BalanceOf loads the current available balance of and ERC20 token identified by the token
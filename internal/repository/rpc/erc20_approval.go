@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"fmt"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+)
+
+// Erc20ApprovalStates loads the allowances of the given approvals of the owner, and the balances
+// and the total supplies of the approved tokens in a single JSON-RPC batch. The balance and the supply
+// are loaded once per token. Each approval degrades independently; only the failure
+// of the batch as a whole is reported.
+func (ftm *FtmBridge) Erc20ApprovalStates(owner *common.Address, list []types.Erc20Approval) ([]types.Erc20ApprovalState, error) {
+	if len(list) == 0 {
+		return []types.Erc20ApprovalState{}, nil
+	}
+
+	// one allowance call per approval, followed by the balance and the supply calls of each token
+	batch := make([]ethrpc.BatchElem, 0, 3*len(list))
+	for i := range list {
+		elem, err := erc20CallElem(&list[i].Token, "allowance", *owner, list[i].Spender)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, elem)
+	}
+
+	tokens := make(map[common.Address]int)
+	for i := range list {
+		if _, ok := tokens[list[i].Token]; ok {
+			continue
+		}
+		tokens[list[i].Token] = len(batch)
+
+		balance, err := erc20CallElem(&list[i].Token, "balanceOf", *owner)
+		if err != nil {
+			return nil, err
+		}
+		supply, err := erc20CallElem(&list[i].Token, "totalSupply")
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, balance, supply)
+	}
+
+	if err := ftm.batchCall("erc20_approvals", batch); err != nil {
+		ftm.log.Errorf("can not load ERC20 approvals of %s batch; %s", owner.String(), err.Error())
+		return nil, err
+	}
+
+	states := make([]types.Erc20ApprovalState, len(list))
+	for i := range list {
+		at := tokens[list[i].Token]
+		states[i] = erc20ApprovalState(&batch[i], &batch[at], &batch[at+1])
+	}
+	return states, nil
+}
+
+// erc20CallElem creates a batch element calling the given ERC20 method of the token.
+func erc20CallElem(token *common.Address, method string, args ...interface{}) (ethrpc.BatchElem, error) {
+	data, err := erc20Abi.Pack(method, args...)
+	if err != nil {
+		return ethrpc.BatchElem{}, err
+	}
+
+	call := struct {
+		To   *common.Address `json:"to"`
+		Data hexutil.Bytes   `json:"data"`
+	}{To: token, Data: data}
+	return ethrpc.BatchElem{Method: "ftm_call", Args: []interface{}{call, BlockTypeLatest}, Result: new(hexutil.Bytes)}, nil
+}
+
+// erc20ApprovalState builds the approval state from the results of its batch calls;
+// the first failed call is reported in the state error.
+func erc20ApprovalState(allowance *ethrpc.BatchElem, balance *ethrpc.BatchElem, supply *ethrpc.BatchElem) types.Erc20ApprovalState {
+	var st types.Erc20ApprovalState
+	for _, c := range []struct {
+		name string
+		call *ethrpc.BatchElem
+		val  *hexutil.Big
+	}{
+		{"allowance", allowance, &st.Allowance},
+		{"balanceOf", balance, &st.Balance},
+		{"totalSupply", supply, &st.Supply},
+	} {
+		if c.call.Error != nil {
+			st.Error = fmt.Errorf("%s; %s", c.name, c.call.Error.Error())
+			return st
+		}
+
+		data := *c.call.Result.(*hexutil.Bytes)
+		if len(data) < 32 {
+			st.Error = fmt.Errorf("%s; invalid result of %d bytes", c.name, len(data))
+			return st
+		}
+		*c.val = hexutil.Big(*new(big.Int).SetBytes(data[:32]))
+	}
+	return st
+}
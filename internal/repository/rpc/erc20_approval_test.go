@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testUintElem creates a batch element with the given uint256 result, or the given error.
+func testUintElem(val int64, err error) *ethrpc.BatchElem {
+	res := hexutil.Bytes(common.BigToHash(big.NewInt(val)).Bytes())
+	return &ethrpc.BatchElem{Method: "ftm_call", Result: &res, Error: err}
+}
+
+// TestErc20ApprovalState tests the approval state is decoded from its batch calls
+// and the first failed call is reported.
+func TestErc20ApprovalState(t *testing.T) {
+	short := hexutil.Bytes{1, 2}
+	tests := []struct {
+		name      string
+		allowance *ethrpc.BatchElem
+		balance   *ethrpc.BatchElem
+		supply    *ethrpc.BatchElem
+		want      [3]int64
+		err       string
+	}{
+		{"all loaded", testUintElem(5, nil), testUintElem(7, nil), testUintElem(100, nil), [3]int64{5, 7, 100}, ""},
+		{"allowance failed", testUintElem(5, fmt.Errorf("reverted")), testUintElem(7, nil), testUintElem(100, nil), [3]int64{}, "allowance; reverted"},
+		{"supply failed", testUintElem(5, nil), testUintElem(7, nil), testUintElem(100, fmt.Errorf("no code")), [3]int64{5, 7, 0}, "totalSupply; no code"},
+		{"short result", testUintElem(5, nil), &ethrpc.BatchElem{Result: &short}, testUintElem(100, nil), [3]int64{5, 0, 0}, "balanceOf; invalid result of 2 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := erc20ApprovalState(tt.allowance, tt.balance, tt.supply)
+			got := [3]int64{st.Allowance.ToInt().Int64(), st.Balance.ToInt().Int64(), st.Supply.ToInt().Int64()}
+			if got != tt.want {
+				t.Errorf("expected values %v, got %v", tt.want, got)
+			}
+
+			var msg string
+			if st.Error != nil {
+				msg = st.Error.Error()
+			}
+			if msg != tt.err {
+				t.Errorf("expected error %q, got %q", tt.err, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// Erc20ApproveCallData encodes the ERC20 approve call unlocking the given amount
+// of tokens for the spender. Zero amount revokes the allowance.
+func (ftm *FtmBridge) Erc20ApproveCallData(spender *common.Address, amount *big.Int) (hexutil.Bytes, error) {
+	data, err := erc20Abi.Pack("approve", *spender, amount)
+	if err != nil {
+		ftm.log.Errorf("can not encode ERC20 approve call; %s", err.Error())
+		return nil, err
+	}
+	return data, nil
+}
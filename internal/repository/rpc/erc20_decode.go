@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"motif-api/internal/repository/rpc/contracts"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// erc20Abi represents the parsed ABI of the standard ERC20 token.
+var erc20Abi = func() abi.ABI {
+	a, err := abi.JSON(strings.NewReader(contracts.ERCTwentyABI))
+	if err != nil {
+		panic(fmt.Errorf("invalid ERC20 ABI; %s", err.Error()))
+	}
+	return a
+}()
+
+// erc20Call calls the given argument-less method of the ERC20 token
+// and provides the raw return data.
+func (ftm *FtmBridge) erc20Call(token *common.Address, method string) ([]byte, error) {
+	data, err := erc20Abi.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+	return ftm.eth.CallContract(context.Background(), ethereum.CallMsg{To: token, Data: data}, nil)
+}
+
+// erc20String loads a string value of the ERC20 token, e.g. its name, or symbol.
+// Tokens returning a non-standard value are recorded, if the tolerant decoding is enabled.
+func (ftm *FtmBridge) erc20String(token *common.Address, method string) (string, error) {
+	data, err := ftm.erc20Call(token, method)
+	if err != nil {
+		return "", err
+	}
+
+	val, variant, err := decodeErc20String(method, data, ftm.tolerantErc20)
+	if err != nil {
+		return "", err
+	}
+	ftm.markNonStdErc20(token, variant)
+	return val, nil
+}
+
+// markNonStdErc20 records the token required the given non-standard decoding.
+func (ftm *FtmBridge) markNonStdErc20(token *common.Address, variant string) {
+	if variant == "" {
+		return
+	}
+
+	ftm.nonStdLock.Lock()
+	defer ftm.nonStdLock.Unlock()
+
+	vs, ok := ftm.nonStdErc20[*token]
+	if !ok {
+		vs = make(map[string]bool)
+		ftm.nonStdErc20[*token] = vs
+	}
+	if !vs[variant] {
+		ftm.log.Noticef("non-standard ERC20 token %s found; %s", token.String(), variant)
+		vs[variant] = true
+	}
+}
+
+// Erc20NonStandardTokens provides the list of ERC20 tokens which required
+// a non-standard decoding of their return values since the API server started.
+func (ftm *FtmBridge) Erc20NonStandardTokens() []types.Erc20NonStandardToken {
+	ftm.nonStdLock.Lock()
+	defer ftm.nonStdLock.Unlock()
+
+	list := make([]types.Erc20NonStandardToken, 0, len(ftm.nonStdErc20))
+	for adr, vs := range ftm.nonStdErc20 {
+		tok := types.Erc20NonStandardToken{Token: adr, Variants: make([]string, 0, len(vs))}
+		for v := range vs {
+			tok.Variants = append(tok.Variants, v)
+		}
+		sort.Strings(tok.Variants)
+		list = append(list, tok)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].Token.Bytes(), list[j].Token.Bytes()) < 0
+	})
+	return list
+}
+
+// decodeErc20String decodes a string returned by the given ERC20 method. Some older tokens
+// return their name and symbol as a zero padded bytes32 value instead of the ABI string;
+// the variant of such a value is provided if the tolerant decoding is enabled.
+func decodeErc20String(method string, data []byte, tolerant bool) (string, string, error) {
+	res, err := erc20Abi.Unpack(method, data)
+	if err == nil {
+		if s, ok := res[0].(string); ok {
+			return s, "", nil
+		}
+		err = fmt.Errorf("unexpected %s value type %T", method, res[0])
+	}
+
+	if !tolerant || len(data) != 32 {
+		return "", "", err
+	}
+	return string(bytes.TrimRight(data, "\x00")), types.Erc20VariantBytes32String, nil
+}
+
+// decodeErc20Decimals decodes the decimals returned by an ERC20 token. Tokens declaring
+// the decimals as uint256 return the same ABI word as the standard uint8, but the value
+// is checked to fit the uint8 range instead of being silently truncated. Tokens returning
+// less than the full ABI word are decoded if the tolerant decoding is enabled.
+func decodeErc20Decimals(data []byte, tolerant bool) (int32, string, error) {
+	var variant string
+	if len(data) < 32 {
+		if !tolerant || len(data) == 0 {
+			return 0, "", fmt.Errorf("invalid decimals value length %d", len(data))
+		}
+		variant = types.Erc20VariantShortDecimals
+	} else {
+		data = data[:32]
+	}
+
+	val := new(big.Int).SetBytes(data)
+	if !val.IsUint64() || val.Uint64() > math.MaxUint8 {
+		return 0, "", fmt.Errorf("decimals %s out of range", val.String())
+	}
+	return int32(val.Uint64()), variant, nil
+}
@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+)
+
+// erc20InfoMethods are the ERC20 methods called to load the token metadata, in the batch order.
+var erc20InfoMethods = []string{"name", "symbol", "decimals", "totalSupply"}
+
+// Erc20TokenInfo loads the name, the symbol, the decimals and the total supply
+// of the given ERC20 token in a single JSON-RPC batch. Values the token does not provide
+// are left empty; only the failure of the batch as a whole is reported.
+func (ftm *FtmBridge) Erc20TokenInfo(token *common.Address) (*types.Erc20TokenInfo, error) {
+	results := make([]hexutil.Bytes, len(erc20InfoMethods))
+	batch := make([]ethrpc.BatchElem, len(erc20InfoMethods))
+	for i, method := range erc20InfoMethods {
+		data, err := erc20Abi.Pack(method)
+		if err != nil {
+			return nil, err
+		}
+
+		call := struct {
+			To   *common.Address `json:"to"`
+			Data hexutil.Bytes   `json:"data"`
+		}{To: token, Data: data}
+		batch[i] = ethrpc.BatchElem{Method: "ftm_call", Args: []interface{}{call, BlockTypeLatest}, Result: &results[i]}
+	}
+
+	if err := ftm.batchCall("erc20_info", batch); err != nil {
+		ftm.log.Errorf("can not load ERC20 token %s info batch; %s", token.String(), err.Error())
+		return nil, err
+	}
+	return ftm.erc20TokenInfo(token, batch), nil
+}
+
+// erc20TokenInfo decodes the ERC20 token metadata from the results of its batch calls.
+func (ftm *FtmBridge) erc20TokenInfo(token *common.Address, calls []ethrpc.BatchElem) *types.Erc20TokenInfo {
+	info := types.Erc20TokenInfo{Address: *token}
+	for i, call := range calls {
+		if call.Error != nil {
+			ftm.log.Debugf("ERC20 token %s %s not available; %s", token.String(), erc20InfoMethods[i], call.Error.Error())
+			continue
+		}
+		data := []byte(*call.Result.(*hexutil.Bytes))
+
+		switch erc20InfoMethods[i] {
+		case "name", "symbol":
+			val, variant, err := decodeErc20String(erc20InfoMethods[i], data, ftm.tolerantErc20)
+			if err != nil {
+				continue
+			}
+			ftm.markNonStdErc20(token, variant)
+			if erc20InfoMethods[i] == "name" {
+				info.Name = &val
+			} else {
+				info.Symbol = &val
+			}
+		case "decimals":
+			deci, variant, err := decodeErc20Decimals(data, ftm.tolerantErc20)
+			if err != nil {
+				continue
+			}
+			ftm.markNonStdErc20(token, variant)
+			info.Decimals = &deci
+		case "totalSupply":
+			if len(data) < 32 {
+				continue
+			}
+			info.TotalSupply = (*hexutil.Big)(new(big.Int).SetBytes(data[:32]))
+		}
+	}
+	return &info
+}
@@ -0,0 +1,277 @@
+package rpc
+
+import (
+	"context"
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"motif-api/internal/metrics"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	eth "github.com/ethereum/go-ethereum/ethclient"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+	"time"
+)
+
+// node represents a connection to a single Lachesis node endpoint.
+// The clients are nil until the node endpoint is connected.
+type node struct {
+	url string
+	rpc *ftm.Client
+	eth *eth.Client
+
+	// healthy signals the node responded to the last health check ping
+	healthy bool
+}
+
+// connect opens connections to all the configured node endpoints and provides the index
+// of the first connected node. Endpoints failed to connect are kept in the list as down,
+// so the health check can connect them later; the connection fails only if no endpoint is available.
+func connect(cfg *config.Config, log logger.Logger) ([]*node, int, error) {
+	urls := cfg.Lachesis.Endpoints()
+	nodes := make([]*node, len(urls))
+	active := -1
+	for i, url := range urls {
+		n, err := dialNode(url, log)
+		if err != nil {
+			log.Errorf("can not connect node %s; %s", url, err.Error())
+			nodes[i] = &node{url: url}
+			continue
+		}
+
+		nodes[i] = n
+		if active < 0 {
+			active = i
+		}
+	}
+
+	if active < 0 {
+		return nil, 0, fmt.Errorf("no node endpoint available")
+	}
+	return nodes, active, nil
+}
+
+// dialNode opens connections we need to communicate with the blockchain node at the given endpoint.
+func dialNode(url string, log logger.Logger) (*node, error) {
+	// log what we do
+	log.Debugf("connecting blockchain node at %s", url)
+
+	// try to establish a connection
+	client, err := ftm.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	// log
+	log.Noticef("node connection to %s open", url)
+	return &node{url: url, rpc: client, eth: eth.NewClient(client), healthy: true}, nil
+}
+
+// node provides the node currently serving calls.
+func (ftm *FtmBridge) node() *node {
+	ftm.nodeLock.RLock()
+	defer ftm.nodeLock.RUnlock()
+	return ftm.nodes[ftm.active]
+}
+
+// dropNode marks the given node unhealthy, e.g. after a failed call, and fails over
+// to the next healthy node if the node was serving calls.
+func (ftm *FtmBridge) dropNode(n *node, reason error) {
+	ftm.nodeLock.Lock()
+	defer ftm.nodeLock.Unlock()
+
+	if !n.healthy {
+		return
+	}
+	n.healthy = false
+	ftm.log.Warningf("node %s is not responding; %s", n.url, reason.Error())
+
+	if ftm.nodes[ftm.active] == n {
+		ftm.selectNode()
+	}
+}
+
+// selectNode switches calls to the first healthy node of the list; nodes listed first are preferred.
+// The caller must hold the nodes lock.
+func (ftm *FtmBridge) selectNode() {
+	current := ftm.nodes[ftm.active]
+	for i, n := range ftm.nodes {
+		if !n.healthy {
+			continue
+		}
+		if i == ftm.active {
+			return
+		}
+
+		ftm.active = i
+		if current.healthy {
+			ftm.log.Noticef("node %s recovered; switching from %s", n.url, current.url)
+		} else {
+			ftm.log.Warningf("node %s dropped; failing over to %s", current.url, n.url)
+		}
+
+		// let the block observer subscribe to the new node
+		select {
+		case ftm.nodeSwitch <- struct{}{}:
+		default:
+		}
+		return
+	}
+	ftm.log.Errorf("no healthy node available; staying on %s", current.url)
+}
+
+// monitorNodes periodically pings all the nodes to track their health
+// and routes calls to a healthy node.
+func (ftm *FtmBridge) monitorNodes(interval time.Duration) {
+	defer func() {
+		ftm.log.Noticef("node health monitor done")
+		ftm.wg.Done()
+	}()
+
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ftm.sigClose:
+			return
+		case <-tick.C:
+			ftm.checkNodes(interval)
+		}
+	}
+}
+
+// checkNodes pings all the nodes and updates their health;
+// nodes failed to connect before are connected first.
+func (ftm *FtmBridge) checkNodes(timeout time.Duration) {
+	health := make([]bool, len(ftm.nodes))
+	for i, n := range ftm.nodes {
+		if n.rpc == nil {
+			if err := ftm.reconnect(n); err != nil {
+				ftm.log.Debugf("can not connect node %s; %s", n.url, err.Error())
+				continue
+			}
+		}
+		health[i] = pingNode(n, timeout) == nil
+	}
+
+	ftm.nodeLock.Lock()
+	defer ftm.nodeLock.Unlock()
+
+	for i, n := range ftm.nodes {
+		if n.healthy && !health[i] {
+			ftm.log.Warningf("node %s failed health check", n.url)
+		}
+		n.healthy = health[i]
+	}
+	ftm.selectNode()
+}
+
+// reconnect opens the connections of a node failed to connect before.
+func (ftm *FtmBridge) reconnect(n *node) error {
+	dn, err := dialNode(n.url, ftm.log)
+	if err != nil {
+		return err
+	}
+
+	ftm.nodeLock.Lock()
+	defer ftm.nodeLock.Unlock()
+	n.rpc, n.eth = dn.rpc, dn.eth
+	return nil
+}
+
+// pingNode checks the given node responds to the block number request in the given time.
+func pingNode(n *node, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var num hexutil.Uint64
+	return n.rpc.CallContext(ctx, &num, "ftm_blockNumber")
+}
+
+// nodeBackend represents the smart contract backend routing the calls
+// to the node currently serving calls.
+type nodeBackend struct {
+	bridge *FtmBridge
+}
+
+// CodeAt returns the code of the given account.
+func (nb *nodeBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_getCode", time.Now())
+	return nb.bridge.node().eth.CodeAt(ctx, account, blockNumber)
+}
+
+// StorageAt returns the value of key in the contract storage of the given account.
+func (nb *nodeBackend) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_getStorageAt", time.Now())
+	return nb.bridge.node().eth.StorageAt(ctx, account, key, blockNumber)
+}
+
+// CallContract executes a message call transaction on the node.
+func (nb *nodeBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_call", time.Now())
+	return nb.bridge.node().eth.CallContract(ctx, call, blockNumber)
+}
+
+// HeaderByNumber returns a block header from the current canonical chain.
+func (nb *nodeBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*etc.Header, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_getBlockByNumber", time.Now())
+	return nb.bridge.node().eth.HeaderByNumber(ctx, number)
+}
+
+// PendingCodeAt returns the code of the given account in the pending state.
+func (nb *nodeBackend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_getCode", time.Now())
+	return nb.bridge.node().eth.PendingCodeAt(ctx, account)
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+func (nb *nodeBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_getTransactionCount", time.Now())
+	return nb.bridge.node().eth.PendingNonceAt(ctx, account)
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price.
+func (nb *nodeBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_gasPrice", time.Now())
+	return nb.bridge.node().eth.SuggestGasPrice(ctx)
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap.
+func (nb *nodeBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_maxPriorityFeePerGas", time.Now())
+	return nb.bridge.node().eth.SuggestGasTipCap(ctx)
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction.
+func (nb *nodeBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_estimateGas", time.Now())
+	return nb.bridge.node().eth.EstimateGas(ctx, call)
+}
+
+// SendTransaction injects the transaction into the pending pool for execution.
+func (nb *nodeBackend) SendTransaction(ctx context.Context, tx *etc.Transaction) error {
+	defer metrics.RpcCallDuration.ObserveSince("eth_sendRawTransaction", time.Now())
+	return nb.bridge.node().eth.SendTransaction(ctx, tx)
+}
+
+// FilterLogs executes a filter query.
+func (nb *nodeBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]etc.Log, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_getLogs", time.Now())
+	return nb.bridge.node().eth.FilterLogs(ctx, q)
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query.
+func (nb *nodeBackend) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- etc.Log) (ethereum.Subscription, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_subscribe", time.Now())
+	return nb.bridge.node().eth.SubscribeFilterLogs(ctx, q, ch)
+}
+
+// ChainID returns the chain id of the network the node is connected to.
+func (nb *nodeBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	defer metrics.RpcCallDuration.ObserveSince("eth_chainId", time.Now())
+	return nb.bridge.node().eth.ChainID(ctx)
+}
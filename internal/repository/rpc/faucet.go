@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+)
+
+// faucetGasLimit represents the gas limit of a plain native tokens transfer sent by the faucet.
+const faucetGasLimit = 21000
+
+// FaucetSend signs a transfer of the given amount of native tokens to the given address
+// with the server signature key and sends it to the node. The transfers are serialized
+// so each one gets its own nonce.
+func (ftm *FtmBridge) FaucetSend(to *common.Address, amount *big.Int) (*common.Hash, error) {
+	ftm.faucetLock.Lock()
+	defer ftm.faucetLock.Unlock()
+
+	ctx := context.Background()
+	from := crypto.PubkeyToAddress(ftm.sigConfig.PrivateKey.PublicKey)
+	nonce, err := ftm.eth.PendingNonceAt(ctx, from)
+	if err != nil {
+		ftm.log.Errorf("can not get faucet nonce of %s; %s", from.String(), err.Error())
+		return nil, err
+	}
+
+	price, err := ftm.eth.SuggestGasPrice(ctx)
+	if err != nil {
+		ftm.log.Errorf("can not get faucet gas price; %s", err.Error())
+		return nil, err
+	}
+
+	chain, err := ftm.eth.ChainID(ctx)
+	if err != nil {
+		ftm.log.Errorf("can not get chain id; %s", err.Error())
+		return nil, err
+	}
+
+	tx, err := etc.SignTx(etc.NewTransaction(nonce, *to, amount, faucetGasLimit, price, nil), etc.LatestSignerForChainID(chain), &ftm.sigConfig.PrivateKey)
+	if err != nil {
+		ftm.log.Errorf("can not sign faucet transfer; %s", err.Error())
+		return nil, err
+	}
+
+	if err := ftm.eth.SendTransaction(ctx, tx); err != nil {
+		ftm.log.Errorf("can not send faucet transfer to %s; %s", to.String(), err.Error())
+		return nil, err
+	}
+
+	hash := tx.Hash()
+	ftm.log.Noticef("faucet sent %s WEI to %s in %s", amount.String(), to.String(), hash.String())
+	return &hash, nil
+}
@@ -0,0 +1,18 @@
+package rpc
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FeeHistory pulls the base fee and the priority fee rewards at the given percentiles
+// of the given number of the most recent blocks.
+func (ftm *FtmBridge) FeeHistory(blockCount hexutil.Uint64, rewardPercentiles []float64) (*types.FeeHistory, error) {
+	var fh types.FeeHistory
+	err := ftm.call(&fh, "ftm_feeHistory", blockCount, BlockTypeLatest, rewardPercentiles)
+	if err != nil {
+		ftm.log.Errorf("can not get fee history of %d blocks; %s", uint64(blockCount), err.Error())
+		return nil, err
+	}
+	return &fh, nil
+}
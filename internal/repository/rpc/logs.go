@@ -0,0 +1,37 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+)
+
+// Logs pulls the logs of the given block range matching the given addresses and topics from the node.
+func (ftm *FtmBridge) Logs(ctx context.Context, from uint64, to uint64, addresses []common.Address, topics [][]common.Hash) ([]etc.Log, error) {
+	logs, err := ftm.eth.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: addresses,
+		Topics:    topics,
+	})
+	if err != nil {
+		ftm.log.Errorf("can not pull logs of blocks <#%d, #%d>; %s", from, to, err.Error())
+		return nil, err
+	}
+	return logs, nil
+}
@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+)
+
+// nameRegistryAbi represents the ABI of the ENS-style name registry and its resolvers.
+var nameRegistryAbi = func() abi.ABI {
+	a, err := abi.JSON(strings.NewReader(`[
+	{"name":"resolver","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"name":"addr","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"name":"name","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"string"}]}]`))
+	if err != nil {
+		panic(fmt.Errorf("invalid name registry ABI; %s", err.Error()))
+	}
+	return a
+}()
+
+// nameCall calls the given method of the name registry, or a resolver, for the given name node
+// and provides the single decoded return value.
+func (ftm *FtmBridge) nameCall(contract *common.Address, method string, node common.Hash) (interface{}, error) {
+	data, err := nameRegistryAbi.Pack(method, node)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := ftm.eth.CallContract(context.Background(), ethereum.CallMsg{To: contract, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := nameRegistryAbi.Unpack(method, out)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected %s result", method)
+	}
+	return res[0], nil
+}
+
+// nameResolver provides the resolver of the given name node set in the registry; nil if not set.
+func (ftm *FtmBridge) nameResolver(registry *common.Address, node common.Hash) (*common.Address, error) {
+	res, err := ftm.nameCall(registry, "resolver", node)
+	if err != nil {
+		ftm.log.Errorf("can not load resolver of name node %s; %s", node.String(), err.Error())
+		return nil, err
+	}
+
+	resolver, ok := res.(common.Address)
+	if !ok || resolver == (common.Address{}) {
+		return nil, nil
+	}
+	return &resolver, nil
+}
+
+// NameAddress resolves the address of the given name node in the registry;
+// nil is provided if the name is not registered.
+func (ftm *FtmBridge) NameAddress(registry *common.Address, node common.Hash) (*common.Address, error) {
+	resolver, err := ftm.nameResolver(registry, node)
+	if err != nil || resolver == nil {
+		return nil, err
+	}
+
+	res, err := ftm.nameCall(resolver, "addr", node)
+	if err != nil {
+		ftm.log.Errorf("can not resolve address of name node %s; %s", node.String(), err.Error())
+		return nil, err
+	}
+
+	addr, ok := res.(common.Address)
+	if !ok || addr == (common.Address{}) {
+		return nil, nil
+	}
+	return &addr, nil
+}
+
+// NameOf resolves the name of the given reverse name node in the registry;
+// empty name is provided if the reverse record is not set.
+func (ftm *FtmBridge) NameOf(registry *common.Address, node common.Hash) (string, error) {
+	resolver, err := ftm.nameResolver(registry, node)
+	if err != nil || resolver == nil {
+		return "", err
+	}
+
+	res, err := ftm.nameCall(resolver, "name", node)
+	if err != nil {
+		ftm.log.Errorf("can not resolve name of reverse node %s; %s", node.String(), err.Error())
+		return "", err
+	}
+
+	name, _ := res.(string)
+	return name, nil
+}
@@ -0,0 +1,17 @@
+package rpc
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TransactionReceipt loads the receipt of the given transaction from the node.
+// Nil receipt is provided for a pending transaction, or if the transaction is not known.
+func (ftm *FtmBridge) TransactionReceipt(hash *common.Hash) (*types.TransactionReceipt, error) {
+	var rec *types.TransactionReceipt
+	if err := ftm.call(&rec, "ftm_getTransactionReceipt", hash); err != nil {
+		ftm.log.Errorf("can not get receipt for transaction %s; %s", hash.String(), err.Error())
+		return nil, err
+	}
+	return rec, nil
+}
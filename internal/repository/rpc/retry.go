@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"motif-api/internal/logger"
+	"motif-api/internal/metrics"
+	"context"
+	"errors"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// transientErrorMessages are fragments of transport errors worth retrying
+// if the error itself is not recognized by its type.
+var transientErrorMessages = []string{
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"eof",
+	"timeout",
+}
+
+// call performs the RPC call of the given method on the node. Calls failed
+// on a transient error, e.g. a dropped connection, are retried with exponential backoff.
+// If more nodes are connected, the failed node is dropped and the retry goes to the next one.
+func (ftm *FtmBridge) call(result interface{}, method string, args ...interface{}) error {
+	return ftm.callContext(context.Background(), result, method, args...)
+}
+
+// callContext performs the RPC call as call does; the call is aborted if the context is done
+// and the retries are logged with the request correlation ID carried by the context.
+func (ftm *FtmBridge) callContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	delay := ftm.retryBackoff
+	for attempt := 1; ; attempt++ {
+		n := ftm.node()
+		start := time.Now()
+		err := n.rpc.CallContext(ctx, result, method, args...)
+		metrics.RpcCallDuration.ObserveSince(method, start)
+		if err == nil || ctx.Err() != nil || !isTransientError(err) {
+			return err
+		}
+		if len(ftm.nodes) > 1 {
+			ftm.dropNode(n, err)
+		}
+		if attempt >= ftm.retryAttempts {
+			return err
+		}
+
+		logger.WithContext(ctx, ftm.log).Warningf("%s failed on attempt %d of %d, retrying in %s; %s", method, attempt, ftm.retryAttempts, delay, err.Error())
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// batchCall performs the batch of RPC calls on the node serving calls. Batches are not retried
+// since elements may have failed independently; the batch latency is recorded under the given name.
+func (ftm *FtmBridge) batchCall(name string, batch []ftm.BatchElem) error {
+	defer metrics.RpcCallDuration.ObserveSince("batch_"+name, time.Now())
+	return ftm.node().rpc.BatchCall(batch)
+}
+
+// isTransientError checks if the error of an RPC call is caused by the transport
+// and the call may succeed if retried. Errors responded by the node are permanent.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// the node responded with an error, e.g. method not found, or execution reverted
+	var rpcErr ftm.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+
+	// the node, or a proxy in front of it, is overloaded
+	var httpErr ftm.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, m := range transientErrorMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"strings"
+)
+
+// revertErrorPrefix is the prefix of the node error message carrying a revert reason.
+const revertErrorPrefix = "execution reverted: "
+
+// RevertReason replays the given mined transaction as a call on the state of the parent block
+// and decodes the revert reason the call failed with. The replay does not include transactions
+// executed earlier in the same block, so the reason may not be recoverable; nil is returned then.
+func (ftm *FtmBridge) RevertReason(trx *types.Transaction) (*string, error) {
+	if trx.BlockNumber == nil || *trx.BlockNumber == 0 {
+		return nil, nil
+	}
+
+	call := struct {
+		From     common.Address  `json:"from"`
+		To       *common.Address `json:"to"`
+		Gas      hexutil.Uint64  `json:"gas"`
+		GasPrice hexutil.Big     `json:"gasPrice"`
+		Value    hexutil.Big     `json:"value"`
+		Data     hexutil.Bytes   `json:"data"`
+	}{
+		From:     trx.From,
+		To:       trx.To,
+		Gas:      trx.Gas,
+		GasPrice: trx.GasPrice,
+		Value:    trx.Value,
+		Data:     trx.InputData,
+	}
+
+	var res hexutil.Bytes
+	err := ftm.call(&res, "ftm_call", call, *trx.BlockNumber-1)
+	if err == nil {
+		// the call passed on replay, the reason is lost
+		return nil, nil
+	}
+
+	reason := revertReason(err)
+	if reason == nil {
+		ftm.log.Debugf("revert reason of %s not recoverable; %s", trx.Hash.String(), err.Error())
+	}
+	return reason, nil
+}
+
+// revertReason decodes the revert reason from the given call error. The error data
+// are decoded if the node provides them, the error message is used otherwise.
+func revertReason(err error) *string {
+	if de, ok := err.(ethrpc.DataError); ok {
+		if data, ok := de.ErrorData().(string); ok {
+			if raw, err := hexutil.Decode(data); err == nil {
+				if reason, err := abi.UnpackRevert(raw); err == nil {
+					return &reason
+				}
+			}
+		}
+	}
+
+	if msg := err.Error(); strings.HasPrefix(msg, revertErrorPrefix) {
+		reason := strings.TrimPrefix(msg, revertErrorPrefix)
+		return &reason
+	}
+	return nil
+}
@@ -116,6 +116,16 @@ func (ftm *FtmBridge) SfcMaxDelegatedRatio() (*big.Int, error) {
 	return ftm.SfcContract().MaxDelegatedRatio(ftm.DefaultCallOpts())
 }
 
+// SfcValidatorCommission extracts the share of delegators' rewards taken by validators.
+func (ftm *FtmBridge) SfcValidatorCommission() (*big.Int, error) {
+	return ftm.SfcContract().ValidatorCommission(ftm.DefaultCallOpts())
+}
+
+// SfcUnlockedRewardRatio extracts the share of the full reward paid to delegations without lock-up.
+func (ftm *FtmBridge) SfcUnlockedRewardRatio() (*big.Int, error) {
+	return ftm.SfcContract().UnlockedRewardRatio(ftm.DefaultCallOpts())
+}
+
 // SfcMinLockupDuration extracts a minimal lockup duration.
 func (ftm *FtmBridge) SfcMinLockupDuration() (*big.Int, error) {
 	return ftm.SfcContract().MinLockupDuration(ftm.DefaultCallOpts())
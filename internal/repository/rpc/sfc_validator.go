@@ -28,7 +28,7 @@ func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, err
 		Blocks hexutil.Uint64 `json:"offlineBlocks"`
 		Time   hexutil.Uint64 `json:"offlineTime"`
 	}
-	if err := ftm.rpc.Call(&dt, "abft_getDowntime", valID); err != nil {
+	if err := ftm.call(&dt, "abft_getDowntime", valID); err != nil {
 		ftm.log.Errorf("failed to get downtime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return 0, 0, err
 	}
@@ -40,7 +40,7 @@ func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, err
 func (ftm *FtmBridge) ValidatorEpochUptime(valID *hexutil.Big) (uint64, error) {
 	// use rather the public API, it should be faster since it does not involve contract call
 	var ut hexutil.Uint64
-	if err := ftm.rpc.Call(&ut, "abft_getEpochUptime", valID); err != nil {
+	if err := ftm.call(&ut, "abft_getEpochUptime", valID); err != nil {
 		ftm.log.Errorf("failed to get epoch uptime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return 0, err
 	}
@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"encoding/json"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SyncProgress provides the synchronization state of the connected node.
+// A node not syncing anymore reports the current block as the highest one.
+func (ftm *FtmBridge) SyncProgress() (*types.SyncProgress, error) {
+	var raw json.RawMessage
+	if err := ftm.call(&raw, "ftm_syncing"); err != nil {
+		ftm.log.Errorf("can not get node sync progress; %s", err.Error())
+		return nil, err
+	}
+
+	// the node responds false if it's not syncing
+	var syncing bool
+	if err := json.Unmarshal(raw, &syncing); err == nil {
+		height, err := ftm.BlockHeight()
+		if err != nil {
+			return nil, err
+		}
+
+		blk := hexutil.Uint64(height.ToInt().Uint64())
+		return &types.SyncProgress{CurrentBlock: blk, HighestBlock: blk}, nil
+	}
+
+	var sp types.SyncProgress
+	if err := json.Unmarshal(raw, &sp); err != nil {
+		ftm.log.Errorf("can not decode node sync progress; %s", err.Error())
+		return nil, err
+	}
+	sp.IsSyncing = true
+	return &sp, nil
+}
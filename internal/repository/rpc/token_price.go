@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"strings"
+)
+
+// OracleTokenPrice loads the current price of the given token from the on-chain price oracle.
+// Nil price is provided if the oracle has no price feed for the token,
+// i.e. the price call reverts, or the price is zero.
+func (ftm *FtmBridge) OracleTokenPrice(token *common.Address) (*big.Int, error) {
+	oracle, err := ftm.fMintCfg.priceOracleProxyContract()
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := oracle.GetPrice(nil, *token)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "execution reverted") {
+			ftm.log.Debugf("no oracle price feed for token %s; %s", token.String(), err.Error())
+			return nil, nil
+		}
+		ftm.log.Errorf("oracle price of token %s not available; %s", token.String(), err.Error())
+		return nil, err
+	}
+
+	if val == nil || val.Sign() == 0 {
+		return nil, nil
+	}
+	return val, nil
+}
@@ -14,10 +14,13 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"encoding/json"
+	"motif-api/internal/metrics"
 	"motif-api/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	retypes "github.com/ethereum/go-ethereum/core/types"
+	"time"
 )
 
 // Transaction returns information about a blockchain transaction by hash.
@@ -27,7 +30,7 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 
 	// call for data
 	var trx types.Transaction
-	err := ftm.rpc.Call(&trx, "ftm_getTransactionByHash", hash)
+	err := ftm.call(&trx, "ftm_getTransactionByHash", hash)
 	if err != nil {
 		ftm.log.Error("transaction could not be extracted")
 		return nil, err
@@ -46,7 +49,7 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 		}
 
 		// call for the transaction receipt data
-		err := ftm.rpc.Call(&rec, "ftm_getTransactionReceipt", hash)
+		err := ftm.call(&rec, "ftm_getTransactionReceipt", hash)
 		if err != nil {
 			ftm.log.Errorf("can not get receipt for transaction %s", hash)
 			return nil, err
@@ -66,8 +69,15 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 	return &trx, nil
 }
 
-func BytesToString(data []byte) string {
-	return string(data[:])
+// TransactionTrace provides the internal call trace of the given transaction
+// as reported by the node; the node must run with the tracing API enabled.
+func (ftm *FtmBridge) TransactionTrace(hash *common.Hash) (json.RawMessage, error) {
+	var trace json.RawMessage
+	if err := ftm.call(&trace, "trace_transaction", hash); err != nil {
+		ftm.log.Errorf("can not trace transaction %s; %s", hash.String(), err.Error())
+		return nil, err
+	}
+	return trace, nil
 }
 
 // SendTransaction sends raw signed and RLP encoded transaction to the block chain.
@@ -75,10 +85,11 @@ func (ftm *FtmBridge) SendTransaction(tx hexutil.Bytes) (*common.Hash, error) {
 	// keep track of the operation
 	ftm.log.Debug("sending new transaction to block chain")
 
+	// the transaction is not retried, the node may have accepted it before the failure
 	var hash common.Hash
-	ftm.log.Noticef("SEND TRANSACTION!!!!!!!!!!!!!!!!!!!!!!")
-	ftm.log.Noticef(BytesToString(tx))
-	err := ftm.rpc.Call(&hash, "eth_sendRawTransaction", tx)
+	start := time.Now()
+	err := ftm.node().rpc.Call(&hash, "eth_sendRawTransaction", tx)
+	metrics.RpcCallDuration.ObserveSince("eth_sendRawTransaction", start)
 	if err != nil {
 		ftm.log.Error("transaction could not be sent")
 		return nil, err
@@ -0,0 +1,18 @@
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TransactionKnown checks the node knows the given transaction, either processed,
+// or waiting in the transaction pool.
+func (ftm *FtmBridge) TransactionKnown(hash *common.Hash) (bool, error) {
+	var trx *struct {
+		Hash common.Hash `json:"hash"`
+	}
+	if err := ftm.call(&trx, "ftm_getTransactionByHash", hash); err != nil {
+		ftm.log.Errorf("can not check transaction %s; %s", hash.String(), err.Error())
+		return false, err
+	}
+	return trx != nil, nil
+}
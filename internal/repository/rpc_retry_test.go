@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestRpcRetryTransient tests the calls failed on a dropped connection are retried.
+func TestRpcRetryTransient(t *testing.T) {
+	node := newTestNode(t, testResults(map[string]string{"ftm_getBalance": `"0x2a"`}))
+	node.drop.Store(2)
+	p := testErc20Proxy(t, node, true)
+
+	addr := common.HexToAddress("0x01")
+	bal, err := p.AccountBalance(&addr)
+	if err != nil || bal.ToInt().Int64() != 42 {
+		t.Fatalf("unexpected balance %v; %v", bal, err)
+	}
+	if node.requests.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", node.requests.Load())
+	}
+
+	// too many failures are not retried forever
+	node = newTestNode(t, testResults(map[string]string{"ftm_getBalance": `"0x2a"`}))
+	node.drop.Store(5)
+	p = testErc20Proxy(t, node, true)
+	if _, err := p.AccountBalance(&addr); err == nil {
+		t.Errorf("expected error after all attempts failed")
+	}
+	if node.requests.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", node.requests.Load())
+	}
+}
+
+// TestRpcRetryPermanent tests the errors responded by the node are not retried.
+func TestRpcRetryPermanent(t *testing.T) {
+	node := newTestNode(t, testResults(map[string]string{}))
+	p := testErc20Proxy(t, node, true)
+
+	addr := common.HexToAddress("0x01")
+	if _, err := p.AccountBalance(&addr); err == nil {
+		t.Fatalf("expected error")
+	}
+	if node.requests.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", node.requests.Load())
+	}
+}
@@ -59,6 +59,53 @@ func (p *proxy) ValidatorByAddress(addr *common.Address) (*types.Validator, erro
 	return p.rpc.ValidatorByAddress(addr)
 }
 
+// Validators provides the list of all validators registered in SFC smart contract.
+// The list changes slowly so it's cached for the configured cache eviction time.
+func (p *proxy) Validators() ([]*types.Validator, error) {
+	// try cache first
+	if list := p.cache.PullValidators(); list != nil {
+		return list, nil
+	}
+
+	// load the list only once for parallel requests
+	list, err, _ := p.apiRequestGroup.Do("validators", func() (interface{}, error) {
+		return p.loadValidators()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.([]*types.Validator), nil
+}
+
+// loadValidators pulls all the validators from SFC smart contract and stores the list in cache.
+func (p *proxy) loadValidators() ([]*types.Validator, error) {
+	num, err := p.rpc.LastValidatorId()
+	if err != nil {
+		p.log.Errorf("can not get the highest validator id; %s", err.Error())
+		return nil, err
+	}
+
+	list := make([]*types.Validator, 0, num)
+	for i := uint64(1); i <= num; i++ {
+		val, err := p.rpc.Validator(new(big.Int).SetUint64(i))
+		if err != nil {
+			p.log.Criticalf("can not load validator #%d; %s", i, err.Error())
+			continue
+		}
+
+		// validator not valid?
+		if val.Id.ToInt().Uint64() == 0 {
+			p.log.Debugf("validator #%d has invalid ID", i)
+			continue
+		}
+		list = append(list, val)
+	}
+
+	p.log.Debugf("found %d validators", len(list))
+	p.cache.PushValidators(list)
+	return list, nil
+}
+
 // SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
 func (p *proxy) SfcMaxDelegatedRatio() (*big.Int, error) {
 	// try cache first
@@ -82,3 +129,41 @@ func (p *proxy) SfcMaxDelegatedRatio() (*big.Int, error) {
 func (p *proxy) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, error) {
 	return p.rpc.ValidatorDowntime(valID)
 }
+
+// SfcValidatorCommission extracts the share of delegators' rewards taken by validators.
+func (p *proxy) SfcValidatorCommission() (*big.Int, error) {
+	// try cache first
+	val := p.cache.PullSfcValidatorCommission()
+	if val != nil {
+		return val, nil
+	}
+
+	// pull from the SFC contract
+	val, err := p.rpc.SfcValidatorCommission()
+	if err != nil {
+		return nil, err
+	}
+
+	// store for future use
+	p.cache.PushSfcValidatorCommission(val)
+	return val, nil
+}
+
+// SfcUnlockedRewardRatio extracts the share of the full reward paid to delegations without lock-up.
+func (p *proxy) SfcUnlockedRewardRatio() (*big.Int, error) {
+	// try cache first
+	val := p.cache.PullSfcUnlockedRewardRatio()
+	if val != nil {
+		return val, nil
+	}
+
+	// pull from the SFC contract
+	val, err := p.rpc.SfcUnlockedRewardRatio()
+	if err != nil {
+		return nil, err
+	}
+
+	// store for future use
+	p.cache.PushSfcUnlockedRewardRatio(val)
+	return val, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/compiler"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// solcVersion normalizes the Solidity compiler version to the major.minor.patch form,
+// i.e. "v0.8.4+commit.c7e474f2" is converted to "0.8.4".
+func solcVersion(ver string) string {
+	ver = strings.TrimPrefix(strings.TrimSpace(ver), "v")
+	if i := strings.Index(ver, "+"); i >= 0 {
+		ver = ver[:i]
+	}
+	return ver
+}
+
+// solCompilerPath provides the path to the installed Solidity compiler of the given version.
+// The default compiler is used if the version is not specified.
+func (p *proxy) solCompilerPath(ver string) (string, error) {
+	if ver == "" {
+		return p.solCompiler, nil
+	}
+
+	ver = solcVersion(ver)
+	for v, path := range p.cfg.Compiler.SolCompilers {
+		if solcVersion(v) == ver {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("solidity compiler %s not installed", ver)
+}
+
+// solcArgs provides the compiler arguments for the given compiler version and optimization settings.
+// It mirrors the arguments used by the go-ethereum compiler package, which always optimizes.
+func solcArgs(s *compiler.Solidity, optimize bool, runs int32) []string {
+	out := "bin,bin-runtime,srcmap,srcmap-runtime,abi,userdoc,devdoc"
+	if s.Major > 0 || s.Minor > 4 || s.Patch > 6 {
+		out += ",metadata,hashes"
+	}
+
+	args := []string{"--combined-json", out}
+	if optimize {
+		args = append(args, "--optimize", "--optimize-runs", strconv.Itoa(int(runs)))
+	}
+	return append(args, "--allow-paths", "., ./, ../")
+}
+
+// compileSolidity compiles the given Solidity source code by the compiler on the given path.
+// The compiler must be of the expected version, if specified.
+func compileSolidity(solc string, ver string, source string, optimize bool, runs int32) (map[string]*compiler.Contract, error) {
+	s, err := compiler.SolidityVersion(solc)
+	if err != nil {
+		return nil, fmt.Errorf("solidity compiler %s not available; %s", solc, err.Error())
+	}
+	if ver != "" && solcVersion(s.Version) != solcVersion(ver) {
+		return nil, fmt.Errorf("solidity compiler %s is version %s, expected %s", solc, s.Version, solcVersion(ver))
+	}
+
+	args := solcArgs(s, optimize, runs)
+	cmd := exec.Command(s.Path, append(args, "--", "-")...)
+	cmd.Stdin = strings.NewReader(source)
+
+	var stderr, stdout bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("solc: %v\n%s", err, stderr.Bytes())
+	}
+	return compiler.ParseCombinedJSON(stdout.Bytes(), source, s.Version, s.Version, strings.Join(args, " "))
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/compiler"
+)
+
+// TestSolCompilerPath tests the Solidity compiler is selected by the requested version.
+func TestSolCompilerPath(t *testing.T) {
+	p := &proxy{
+		cfg: &config.Config{Compiler: config.Compiler{SolCompilers: map[string]string{
+			"0.5.17": "/opt/solc/solc-0.5.17",
+			"v0.8.4": "/opt/solc/solc-0.8.4",
+		}}},
+		solCompiler: "/usr/bin/solc",
+	}
+
+	tests := []struct {
+		ver     string
+		want    string
+		wantErr bool
+	}{
+		{"", "/usr/bin/solc", false},
+		{"0.5.17", "/opt/solc/solc-0.5.17", false},
+		{"0.8.4+commit.c7e474f2", "/opt/solc/solc-0.8.4", false},
+		{"0.6.12", "", true},
+	}
+
+	for _, tt := range tests {
+		path, err := p.solCompilerPath(tt.ver)
+		if (err != nil) != tt.wantErr || path != tt.want {
+			t.Errorf("version %q: expected %q (error %t), got %q; %v", tt.ver, tt.want, tt.wantErr, path, err)
+		}
+	}
+}
+
+// TestSolcArgs tests the compiler arguments follow the requested optimization.
+func TestSolcArgs(t *testing.T) {
+	s := &compiler.Solidity{Major: 0, Minor: 8, Patch: 4}
+
+	args := strings.Join(solcArgs(s, true, 1000), " ")
+	if !strings.Contains(args, "--optimize --optimize-runs 1000") || !strings.Contains(args, ",metadata,hashes") {
+		t.Errorf("unexpected optimized arguments %s", args)
+	}
+
+	args = strings.Join(solcArgs(&compiler.Solidity{Major: 0, Minor: 4, Patch: 6}, false, 200), " ")
+	if strings.Contains(args, "--optimize") || strings.Contains(args, "metadata") {
+		t.Errorf("unexpected arguments %s", args)
+	}
+}
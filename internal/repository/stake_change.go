@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StoreStakeChange stores a change of a delegator stake.
+func (p *proxy) StoreStakeChange(sc *types.StakeChange) error {
+	return p.db.AddStakeChange(sc)
+}
+
+// StakeChanges provides list of stake changes of the given delegator,
+// optionally limited to the given validator.
+func (p *proxy) StakeChanges(delegator *common.Address, validator *hexutil.Big, cursor *string, count int32) (*types.StakeChangeList, error) {
+	return p.db.StakeChanges(delegator, validator, cursor, count)
+}
+
+// RevertStakeChanges removes stake changes of the given range of blocks replaced by a chain reorg.
+func (p *proxy) RevertStakeChanges(fromBlock uint64, toBlock uint64) error {
+	return p.db.EraseStakeChanges(fromBlock, toBlock)
+}
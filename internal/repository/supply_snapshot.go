@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// Erc20TotalSupplyAt provides the total supply of the ERC20 token at the given block.
+func (p *proxy) Erc20TotalSupplyAt(token *common.Address, block uint64) (*big.Int, error) {
+	return p.rpc.Erc20TotalSupplyAt(token, block)
+}
+
+// StoreSupplySnapshot stores a token supply snapshot.
+func (p *proxy) StoreSupplySnapshot(ss *types.SupplySnapshot) error {
+	return p.db.AddSupplySnapshot(ss)
+}
+
+// Erc20SupplyHistory provides the total supply snapshots of the token taken in the given
+// inclusive block range, sorted from the oldest one. The snapshots are taken on an interval
+// of blocks, so the nearest snapshot preceding the range is included, if the first block
+// of the range is not recorded, to know the supply the range starts with.
+func (p *proxy) Erc20SupplyHistory(token *common.Address, from uint64, to uint64) ([]*types.SupplySnapshot, error) {
+	list, err := p.db.SupplySnapshots(token, from, to, types.SupplyHistoryMaxCount)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) > 0 && uint64(list[0].BlockNumber) == from {
+		return list, nil
+	}
+
+	prev, err := p.db.SupplySnapshotBefore(token, from)
+	if err != nil {
+		return nil, err
+	}
+	if prev == nil {
+		return list, nil
+	}
+	if len(list) == types.SupplyHistoryMaxCount {
+		list = list[:len(list)-1]
+	}
+	return append([]*types.SupplySnapshot{prev}, list...), nil
+}
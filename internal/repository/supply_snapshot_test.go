@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestErc20TotalSupplyAt tests the historical total supply of a token is loaded from the node.
+func TestErc20TotalSupplyAt(t *testing.T) {
+	token := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+
+	p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+		testSelTotalSupply: "0x00000000000000000000000000000000000000000000000000000000000f4240",
+	})), false)
+	val, err := p.Erc20TotalSupplyAt(&token, 100)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if val.Int64() != 1000000 {
+		t.Errorf("expected total supply 1000000, got %s", val.String())
+	}
+
+	p = testErc20Proxy(t, newTestNode(t, testResults(map[string]string{testSelTotalSupply: testRevert})), false)
+	if _, err := p.Erc20TotalSupplyAt(&token, 100); err == nil {
+		t.Errorf("expected error on reverted call")
+	}
+}
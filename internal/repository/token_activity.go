@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"time"
+)
+
+// TrendingTokens provides the given number of tokens with the highest number
+// of transactions over the trailing time window. The window is rounded up
+// to whole activity buckets, see types.TokenActivityBucket.
+func (p *proxy) TrendingTokens(window time.Duration, count int32) ([]*types.TokenActivity, error) {
+	if window > types.TokenActivityMaxWindow {
+		window = types.TokenActivityMaxWindow
+	}
+	since := time.Now().UTC().Add(-window).Truncate(types.TokenActivityBucket)
+	return p.db.TrendingTokens(since, int64(count))
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// ConvertAmount values the given amount of the source token in the target token
+// by the current oracle prices of both tokens. Nil is provided if the oracle
+// has no price feed for any of the tokens.
+func (p *proxy) ConvertAmount(from *common.Address, to *common.Address, amount *big.Int) (*big.Int, error) {
+	fp, err := p.TokenPrice(from)
+	if err != nil || fp == nil {
+		return nil, err
+	}
+	tp, err := p.TokenPrice(to)
+	if err != nil || tp == nil {
+		return nil, err
+	}
+
+	fromDecimals, err := p.Erc20Decimals(from)
+	if err != nil {
+		return nil, err
+	}
+	toDecimals, err := p.Erc20Decimals(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTokenAmount(amount, fp.Price.ToInt(), fp.PriceDecimals+fromDecimals, tp.Price.ToInt(), tp.PriceDecimals+toDecimals), nil
+}
+
+// convertTokenAmount converts the amount of a token into another token by their prices.
+// The decimals of each side are the sum of the token decimals and the price decimals, so the
+// result is amount * fromPrice * 10^toDecimals / (toPrice * 10^fromDecimals), rounded down.
+func convertTokenAmount(amount *big.Int, fromPrice *big.Int, fromDecimals int32, toPrice *big.Int, toDecimals int32) *big.Int {
+	if toPrice.Sign() <= 0 {
+		return nil
+	}
+
+	num := new(big.Int).Mul(amount, fromPrice)
+	den := new(big.Int).Set(toPrice)
+
+	// apply the difference of decimals on the side it scales up
+	if diff := toDecimals - fromDecimals; diff >= 0 {
+		num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(diff)), nil))
+	} else {
+		den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-diff)), nil))
+	}
+	return num.Div(num, den)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestConvertTokenAmount tests the conversion of token amounts by the oracle prices.
+func TestConvertTokenAmount(t *testing.T) {
+	e := func(n int64) *big.Int {
+		return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+	}
+	mul := func(v int64, n int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(v), e(n))
+	}
+
+	tests := []struct {
+		name         string
+		amount       *big.Int
+		fromPrice    *big.Int
+		fromDecimals int32
+		toPrice      *big.Int
+		toDecimals   int32
+		want         *big.Int
+	}{
+		// 2 tokens of 18 decimals at $1.5 into a $1 token of 6 decimals; both prices have 18 decimals
+		{"to fewer decimals", mul(2, 18), mul(15, 17), 18 + 18, e(18), 6 + 18, mul(3, 6)},
+		// 3 tokens of 6 decimals at $1 into a $1.5 token of 18 decimals
+		{"to more decimals", mul(3, 6), e(18), 6 + 18, mul(15, 17), 18 + 18, mul(2, 18)},
+		// different price decimals; $2 with 8 decimals into $4 with 18 decimals, both tokens of 18 decimals
+		{"different price decimals", mul(10, 18), mul(2, 8), 18 + 8, mul(4, 18), 18 + 18, mul(5, 18)},
+		// the result is rounded down
+		{"rounded down", big.NewInt(10), big.NewInt(1), 0, big.NewInt(3), 0, big.NewInt(3)},
+		{"zero amount", big.NewInt(0), e(18), 36, e(18), 36, big.NewInt(0)},
+		{"no target price", e(18), e(18), 36, big.NewInt(0), 36, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertTokenAmount(tt.amount, tt.fromPrice, tt.fromDecimals, tt.toPrice, tt.toDecimals)
+			if (got == nil) != (tt.want == nil) || (got != nil && got.Cmp(tt.want) != 0) {
+				t.Errorf("convertTokenAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
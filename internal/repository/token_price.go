@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// defTokenPriceDecimals is the number of decimals of the oracle price
+// of a token not listed in the DeFi token registry.
+const defTokenPriceDecimals = 18
+
+// TokenPrice provides the current oracle price of the given token; nil is provided
+// if the oracle has no price feed for the token. The price, or its absence,
+// is cached for a short configurable time.
+func (p *proxy) TokenPrice(token *common.Address) (*types.TokenPrice, error) {
+	maxAge := p.cfg.Repository.TokenPriceCache
+	if maxAge <= 0 {
+		return p.oracleTokenPrice(token)
+	}
+
+	val, err, _ := p.apiRequestGroup.Do("token_price_"+token.String(), func() (interface{}, error) {
+		if tp, ok := p.cache.PullTokenPrice(token, maxAge); ok {
+			return tp, nil
+		}
+
+		tp, err := p.oracleTokenPrice(token)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.cache.PushTokenPrice(token, tp); err != nil {
+			p.log.Errorf("can not cache price of token %s; %s", token.String(), err.Error())
+		}
+		return tp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tp := val.(*types.TokenPrice)
+	if tp == nil {
+		return nil, nil
+	}
+
+	// the value is derived, it's not cached
+	res := *tp
+	res.Value = decimalValue(res.Price.ToInt(), res.PriceDecimals)
+	return &res, nil
+}
+
+// oracleTokenPrice loads the current oracle price of the given token. The price decimals
+// are taken from the DeFi token registry, if the token is listed there.
+func (p *proxy) oracleTokenPrice(token *common.Address) (*types.TokenPrice, error) {
+	price, err := p.rpc.OracleTokenPrice(token)
+	if err != nil || price == nil {
+		return nil, err
+	}
+
+	tp := types.TokenPrice{
+		Token:         *token,
+		Price:         hexutil.Big(*price),
+		PriceDecimals: defTokenPriceDecimals,
+	}
+	if dt, err := p.rpc.DefiToken(token); err == nil {
+		tp.PriceDecimals = dt.PriceDecimals
+	}
+	tp.Value = decimalValue(price, tp.PriceDecimals)
+	return &tp, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/repository/cache"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// fMint method selectors
+	testSelGetAddress = "0x21f8a721"
+	testSelGetPrice   = "0x41976e09"
+	testSelTokens     = "0xe4860339"
+
+	// testOracleAddress is the ABI encoded address of the price oracle
+	testOracleAddress = "0x000000000000000000000000000000000000000000000000000000000000000a"
+
+	// testOraclePrice is the ABI encoded price 1.5 with 18 decimals
+	testOraclePrice = "0x00000000000000000000000000000000000000000000000014d1120d7b160000"
+)
+
+// TestTokenPrice tests the oracle price of a token is provided with the price decimals.
+func TestTokenPrice(t *testing.T) {
+	p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+		testSelGetAddress: testOracleAddress,
+		testSelGetPrice:   testOraclePrice,
+		testSelTokens:     testRevert,
+	})), true)
+
+	token := common.HexToAddress("0x05")
+	tp, err := p.TokenPrice(&token)
+	if err != nil || tp == nil {
+		t.Fatalf("expected price, got %v; %v", tp, err)
+	}
+	if tp.Token != token || tp.PriceDecimals != defTokenPriceDecimals || tp.Value != 1.5 {
+		t.Errorf("unexpected price %v", tp)
+	}
+}
+
+// TestTokenPriceNoFeed tests the missing price feed is not reported as an error.
+func TestTokenPriceNoFeed(t *testing.T) {
+	for name, price := range map[string]string{"reverted": testRevert, "zero": "0x0000000000000000000000000000000000000000000000000000000000000000"} {
+		t.Run(name, func(t *testing.T) {
+			p := testErc20Proxy(t, newTestNode(t, testResults(map[string]string{
+				testSelGetAddress: testOracleAddress,
+				testSelGetPrice:   price,
+			})), true)
+
+			token := common.HexToAddress("0x05")
+			tp, err := p.TokenPrice(&token)
+			if err != nil || tp != nil {
+				t.Errorf("expected no price, got %v; %v", tp, err)
+			}
+		})
+	}
+}
+
+// TestTokenPriceCache tests the oracle price is served from the cache while fresh.
+func TestTokenPriceCache(t *testing.T) {
+	node := newTestNode(t, testResults(map[string]string{
+		testSelGetAddress: testOracleAddress,
+		testSelGetPrice:   testOraclePrice,
+		testSelTokens:     testRevert,
+	}))
+	p := testErc20Proxy(t, node, true)
+	p.cfg.Cache = config.Cache{Eviction: time.Minute, MaxSize: 16}
+	p.cfg.Repository.TokenPriceCache = time.Minute
+
+	var err error
+	p.cache, err = cache.New(p.cfg, p.log)
+	if err != nil {
+		t.Fatalf("can not create cache; %s", err.Error())
+	}
+
+	token := common.HexToAddress("0x05")
+	if tp, err := p.TokenPrice(&token); err != nil || tp == nil {
+		t.Fatalf("expected price, got %v; %v", tp, err)
+	}
+
+	// the node is gone, the price is still available
+	node.Close()
+	tp, err := p.TokenPrice(&token)
+	if err != nil || tp == nil || tp.Value != 1.5 || tp.PriceDecimals != defTokenPriceDecimals {
+		t.Errorf("expected cached price, got %v; %v", tp, err)
+	}
+}
@@ -9,14 +9,22 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"encoding/json"
 	"errors"
 	"motif-api/internal/repository/cache"
 	"motif-api/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	etc "github.com/ethereum/go-ethereum/core/types"
 	eth "github.com/ethereum/go-ethereum/rpc"
+	"strings"
 )
 
+// knownTrxErrors represents the node errors signaling the submitted transaction
+// is already known to the node, e.g. it's a retry of a previous submission.
+var knownTrxErrors = []string{"already known", "known transaction"}
+
 // ErrTransactionNotFound represents an error returned if a transaction can not be found.
 var ErrTransactionNotFound = errors.New("requested transaction can not be found in Opera blockchain")
 
@@ -25,6 +33,17 @@ func (p *proxy) StoreTransaction(block *types.Block, trx *types.Transaction) err
 	return p.db.AddTransaction(block, trx)
 }
 
+// BlockHashes provides hashes of the blocks in the given range known from the stored transactions.
+// Blocks without transactions are not included.
+func (p *proxy) BlockHashes(fromBlock uint64, toBlock uint64) (map[uint64]common.Hash, error) {
+	return p.db.BlockHashes(fromBlock, toBlock)
+}
+
+// RevertTransactions removes transactions of the given range of blocks replaced by a chain reorg.
+func (p *proxy) RevertTransactions(fromBlock uint64, toBlock uint64) error {
+	return p.db.EraseTransactions(fromBlock, toBlock)
+}
+
 // CacheTransaction puts a transaction to the internal ring cache.
 func (p *proxy) CacheTransaction(trx *types.Transaction) {
 	p.cache.AddTransaction(trx)
@@ -68,16 +87,30 @@ func (p *proxy) LoadTransaction(hash *common.Hash) (*types.Transaction, error) {
 	return p.rpc.Transaction(hash)
 }
 
+// TransactionTrace provides the internal call trace of the given transaction.
+func (p *proxy) TransactionTrace(hash *common.Hash) (json.RawMessage, error) {
+	return p.rpc.TransactionTrace(hash)
+}
+
 // SendTransaction sends raw signed and RLP encoded transaction to the block chain.
 func (p *proxy) SendTransaction(tx hexutil.Bytes) (*types.Transaction, error) {
 	// log
 	p.log.Debugf("requested transaction submit for %s", tx.String())
 
+	// make sure the node gets a valid transaction
+	if err := new(etc.Transaction).UnmarshalBinary(tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction encoding; %s", err.Error())
+	}
+
 	// try to send it and get the tx hash
 	hash, err := p.rpc.SendTransaction(tx)
 	if err != nil {
-		p.log.Errorf("can not send transaction to block chain; %s", err.Error())
-		return nil, err
+		// a retry of the transaction the node already has is not a failure
+		hash = p.knownTransaction(tx, err)
+		if hash == nil {
+			p.log.Errorf("can not send transaction to block chain; %s", err.Error())
+			return nil, err
+		}
 	}
 
 	// we do have the hash so we can use it to get the transaction details
@@ -101,13 +134,45 @@ func (p *proxy) SendTransaction(tx hexutil.Bytes) (*types.Transaction, error) {
 	return trx, nil
 }
 
+// knownTransaction provides the hash of the submitted transaction if the send error
+// signals the node already knows it and the relay is configured to accept it.
+// A different transaction with the same nonce is still a failure.
+func (p *proxy) knownTransaction(tx hexutil.Bytes, err error) *common.Hash {
+	if !p.cfg.Repository.AcceptKnownTrx || !isKnownTrxError(err) {
+		return nil
+	}
+
+	var trx etc.Transaction
+	if err := trx.UnmarshalBinary(tx); err != nil {
+		p.log.Errorf("can not decode known transaction; %s", err.Error())
+		return nil
+	}
+
+	hash := trx.Hash()
+	p.log.Debugf("transaction %s already known to the node", hash.String())
+	return &hash
+}
+
+// isKnownTrxError checks if the node error signals the transaction is already known.
+func isKnownTrxError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, known := range knownTrxErrors {
+		if strings.Contains(msg, known) {
+			return true
+		}
+	}
+	return false
+}
+
 // Transactions pulls list of transaction hashes starting on the specified cursor.
 // If the initial transaction cursor is not provided, we start on top, or bottom based on count value.
 //
 // No-number boundaries are handled as follows:
 // 	- For positive count we start from the most recent transaction and scan to older transactions.
 // 	- For negative count we start from the first transaction and scan to newer transactions.
-func (p *proxy) Transactions(cursor *string, count int32) (*types.TransactionList, error) {
+//
+// The total is not calculated if skipTotal is set, unless it's available for free.
+func (p *proxy) Transactions(cursor *string, count int32, skipTotal bool) (*types.TransactionList, error) {
 	// we may be able to pull the list faster than from the db
 	if cursor == nil && count > 0 && count < cache.TransactionRingCacheSize {
 		// pull the quick list
@@ -128,7 +193,7 @@ func (p *proxy) Transactions(cursor *string, count int32) (*types.TransactionLis
 	}
 
 	// use slow trx list pulling
-	return p.db.Transactions(cursor, count, nil)
+	return p.db.Transactions(cursor, count, nil, skipTotal)
 }
 
 // StoreGasPricePeriod stores the given gas price period data in the persistent storage
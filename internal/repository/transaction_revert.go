@@ -0,0 +1,21 @@
+package repository
+
+import "motif-api/internal/types"
+
+// TransactionRevertReason provides the decoded revert reason of the given failed transaction.
+// Nil is returned for successful, or pending transactions, and if the reason is not recoverable.
+func (p *proxy) TransactionRevertReason(trx *types.Transaction) (*string, error) {
+	if trx.Status == nil || *trx.Status != 0 {
+		return nil, nil
+	}
+
+	// large inputs are not kept in the off-chain database
+	if trx.LargeInput {
+		full, err := p.rpc.Transaction(&trx.Hash)
+		if err != nil {
+			return nil, err
+		}
+		trx = full
+	}
+	return p.rpc.RevertReason(trx)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testFailedTrx provides a mined transaction with the given receipt status.
+func testFailedTrx(status uint64) *types.Transaction {
+	blk := hexutil.Uint64(100)
+	return &types.Transaction{
+		BlockNumber: &blk,
+		From:        common.Address{1},
+		To:          &common.Address{2},
+		Gas:         100000,
+		InputData:   hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb},
+		Status:      (*hexutil.Uint64)(&status),
+	}
+}
+
+// TestTransactionRevertReason tests the revert reason is decoded from the replay error data,
+// or the error message.
+func TestTransactionRevertReason(t *testing.T) {
+	// Error("insufficient balance")
+	data := "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000014" +
+		"696e73756666696369656e742062616c616e6365000000000000000000000000"
+
+	tests := []struct {
+		name    string
+		callErr *testRpcError
+		want    *string
+	}{
+		{"error data", &testRpcError{Code: 3, Message: "execution reverted", Data: data}, strPtr("insufficient balance")},
+		{"error message", &testRpcError{Code: -32000, Message: "execution reverted: not owner"}, strPtr("not owner")},
+		{"unrecoverable", &testRpcError{Code: -32000, Message: "out of gas"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testRelayProxy(t, newTestNode(t, testFailing("ftm_call", tt.callErr)), true)
+			got, err := p.TransactionRevertReason(testFailedTrx(0))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err.Error())
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("expected reason %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// successful transactions don't have any reason
+	p := testRelayProxy(t, newTestNode(t, testFailing("ftm_call", &testRpcError{Code: -32000, Message: "execution reverted: x"})), true)
+	if got, err := p.TransactionRevertReason(testFailedTrx(1)); err != nil || got != nil {
+		t.Errorf("expected no reason of successful transaction, got %v, %v", got, err)
+	}
+}
+
+// strPtr provides a pointer to the given string.
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"encoding/json"
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"motif-api/internal/repository/rpc"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testRelayCall provides a mock node resolver rejecting raw transactions with the given error
+// and serving the transactions by hash.
+func testRelayCall(sendErr string) testCall {
+	return func(method string, params []json.RawMessage) (interface{}, error) {
+		switch method {
+		case "eth_sendRawTransaction":
+			return nil, &testRpcError{Code: -32000, Message: sendErr}
+		case "ftm_getTransactionByHash":
+			var hash common.Hash
+			_ = json.Unmarshal(params[0], &hash)
+			return map[string]interface{}{
+				"hash":     hash,
+				"from":     common.Address{},
+				"gas":      "0x5208",
+				"gasPrice": "0x1",
+				"nonce":    "0x0",
+				"value":    "0x1",
+				"input":    "0x",
+			}, nil
+		}
+		return nil, testMethodNotFound(method)
+	}
+}
+
+// testRelayProxy creates a repository connected to the mock node.
+func testRelayProxy(t *testing.T, node *testNode, acceptKnown bool) *proxy {
+	cfg := config.Config{
+		Log:        config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Lachesis:   config.Lachesis{Url: node.URL},
+		Repository: config.Repository{AcceptKnownTrx: acceptKnown},
+	}
+	log := logger.New(&cfg)
+
+	br, err := rpc.New(&cfg, log)
+	if err != nil {
+		t.Fatalf("can not connect mock node; %s", err.Error())
+	}
+	t.Cleanup(br.Close)
+	return &proxy{rpc: br, log: log, cfg: &cfg}
+}
+
+// testSignedTrx provides a signed and RLP encoded transaction and its hash.
+func testSignedTrx(t *testing.T) (hexutil.Bytes, common.Hash) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trx, err := etc.SignTx(etc.NewTransaction(0, common.Address{1}, big.NewInt(1), 21000, big.NewInt(1), nil), etc.NewEIP155Signer(big.NewInt(250)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := trx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw, trx.Hash()
+}
+
+// TestSendKnownTransaction tests a resubmitted transaction known to the node is reported as sent.
+func TestSendKnownTransaction(t *testing.T) {
+	p := testRelayProxy(t, newTestNode(t, testRelayCall("already known")), true)
+	raw, hash := testSignedTrx(t)
+
+	trx, err := p.SendTransaction(raw)
+	if err != nil {
+		t.Fatalf("known transaction failed; %s", err.Error())
+	}
+	if trx.Hash != hash {
+		t.Errorf("expected transaction %s, got %s", hash.String(), trx.Hash.String())
+	}
+}
+
+// TestSendTransactionFailures tests genuine failures, and known transactions
+// with the relay not configured to accept them, are still reported.
+func TestSendTransactionFailures(t *testing.T) {
+	raw, _ := testSignedTrx(t)
+
+	p := testRelayProxy(t, newTestNode(t, testRelayCall("nonce too low")), true)
+	if _, err := p.SendTransaction(raw); err == nil {
+		t.Errorf("expected nonce too low failure")
+	}
+
+	p = testRelayProxy(t, newTestNode(t, testRelayCall("already known")), false)
+	if _, err := p.SendTransaction(raw); err == nil {
+		t.Errorf("expected already known failure with the relay not accepting known transactions")
+	}
+}
+
+// TestSendTransactionValidation tests a transaction not decodable as RLP is rejected
+// before it's forwarded, and the node rejection is reported as is.
+func TestSendTransactionValidation(t *testing.T) {
+	p := testRelayProxy(t, newTestNode(t, testRelayCall("insufficient funds for gas * price + value")), true)
+
+	_, err := p.SendTransaction(hexutil.Bytes{0xde, 0xad, 0xbe, 0xef})
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid transaction encoding") {
+		t.Errorf("expected invalid encoding error, got %v", err)
+	}
+
+	raw, _ := testSignedTrx(t)
+	if _, err = p.SendTransaction(raw); err == nil || err.Error() != "insufficient funds for gas * price + value" {
+		t.Errorf("expected the node error message, got %v", err)
+	}
+}
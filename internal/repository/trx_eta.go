@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"math"
+	"math/big"
+)
+
+// TransactionEta provides a best-effort estimate of the time to confirmation
+// of the given pending transaction. Confirmed transactions don't have any estimate.
+func (p *proxy) TransactionEta(trx *types.Transaction) (*types.TrxEta, error) {
+	if trx.BlockNumber != nil {
+		return nil, nil
+	}
+
+	// get the current gas price suggestion
+	gp, err := p.GasPrice()
+	if err != nil {
+		p.log.Errorf("gas price not available for confirmation estimate; %s", err.Error())
+		return nil, err
+	}
+
+	// use the recent block interval if available
+	interval := p.cfg.TrxEta.BlockInterval
+	if cm := p.ChainMetrics(); cm != nil && cm.AvgBlockInterval > 0 {
+		interval = cm.AvgBlockInterval
+	}
+	return trxEta(trx.GasPrice.ToInt(), gp.ToInt(), interval, &p.cfg.TrxEta), nil
+}
+
+// trxEta estimates the number of blocks a transaction paying the given gas price
+// waits for confirmation. Transactions paying the suggested price wait the base number
+// of blocks, overpaying shortens the wait proportionally down to a single block,
+// underpaying prolongs the wait by the inverse ratio raised to the configured exponent.
+func trxEta(price *big.Int, suggested *big.Int, interval float64, cfg *config.TrxEta) *types.TrxEta {
+	eta := types.TrxEta{GasPriceRatio: 1}
+	if suggested.Sign() > 0 {
+		eta.GasPriceRatio, _ = new(big.Float).Quo(new(big.Float).SetInt(price), new(big.Float).SetInt(suggested)).Float64()
+	}
+
+	blocks := float64(cfg.MaxBlocks)
+	switch {
+	case eta.GasPriceRatio >= 1:
+		blocks = math.Max(1, math.Ceil(float64(cfg.BaseBlocks)/eta.GasPriceRatio))
+	case eta.GasPriceRatio > 0:
+		blocks = math.Ceil(float64(cfg.BaseBlocks) / math.Pow(eta.GasPriceRatio, cfg.Exponent))
+	}
+
+	// too far to estimate?
+	if eta.GasPriceRatio <= 0 || blocks > float64(cfg.MaxBlocks) {
+		blocks = float64(cfg.MaxBlocks)
+		eta.IsUnderpriced = true
+	}
+
+	eta.Blocks = int32(blocks)
+	eta.Seconds = blocks * interval
+	return &eta
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"math/big"
+	"testing"
+)
+
+// TestTrxEta tests the pending transaction confirmation estimate heuristic.
+func TestTrxEta(t *testing.T) {
+	cfg := config.TrxEta{BaseBlocks: 2, MaxBlocks: 100, Exponent: 2}
+	gwei := big.NewInt(1000000000)
+
+	tests := []struct {
+		name        string
+		price       int64
+		suggested   int64
+		blocks      int32
+		underpriced bool
+	}{
+		{"suggested price", 100, 100, 2, false},
+		{"double price", 200, 100, 1, false},
+		{"huge price", 10000, 100, 1, false},
+		{"half price", 50, 100, 8, false},
+		{"tenth price", 10, 100, 100, true},
+		{"low price", 5, 100, 100, true},
+		{"zero price", 0, 100, 100, true},
+		{"no suggestion", 100, 0, 2, false},
+	}
+
+	for _, tc := range tests {
+		price := new(big.Int).Mul(big.NewInt(tc.price), gwei)
+		suggested := new(big.Int).Mul(big.NewInt(tc.suggested), gwei)
+
+		eta := trxEta(price, suggested, 1.5, &cfg)
+		if eta.Blocks != tc.blocks || eta.IsUnderpriced != tc.underpriced {
+			t.Errorf("%s: expected %d blocks (%t), got %d blocks (%t)", tc.name, tc.blocks, tc.underpriced, eta.Blocks, eta.IsUnderpriced)
+		}
+		if eta.Seconds != float64(eta.Blocks)*1.5 {
+			t.Errorf("%s: expected %f seconds, got %f", tc.name, float64(eta.Blocks)*1.5, eta.Seconds)
+		}
+	}
+}
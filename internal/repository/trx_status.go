@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TransactionStatus provides the processing status of the given transaction
+// along with the number of its confirmations. Transactions without a receipt are pending
+// if the node knows them, e.g. from its transaction pool, and unknown otherwise.
+func (p *proxy) TransactionStatus(hash *common.Hash) (*types.TrxStatus, error) {
+	rec, err := p.rpc.TransactionReceipt(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec == nil {
+		known, err := p.rpc.TransactionKnown(hash)
+		if err != nil {
+			return nil, err
+		}
+		return trxStatus(hash, nil, known, 0), nil
+	}
+
+	head, err := p.BlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	return trxStatus(hash, rec, true, head.ToInt().Uint64()), nil
+}
+
+// trxStatus builds the status of a transaction from its receipt, if any,
+// and the current head block number.
+func trxStatus(hash *common.Hash, rec *types.TransactionReceipt, known bool, head uint64) *types.TrxStatus {
+	ts := types.TrxStatus{Hash: *hash, Status: types.TrxStatusUnknown}
+	switch {
+	case rec == nil && known:
+		ts.Status = types.TrxStatusPending
+	case rec == nil:
+	case rec.Status == 1:
+		ts.Status = types.TrxStatusSuccess
+	default:
+		ts.Status = types.TrxStatusFailed
+	}
+
+	if rec != nil {
+		blk := rec.BlockNumber
+		ts.BlockNumber = &blk
+
+		// the head may lag behind the receipt on a load balanced node
+		if head > uint64(blk) {
+			ts.Confirmations = hexutil.Uint64(head - uint64(blk))
+		}
+	}
+	return &ts
+}
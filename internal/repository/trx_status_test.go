@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestTrxStatus tests the status of a transaction is derived from its receipt.
+func TestTrxStatus(t *testing.T) {
+	hash := common.HexToHash("0x01")
+	tests := []struct {
+		name          string
+		rec           *types.TransactionReceipt
+		known         bool
+		head          uint64
+		status        string
+		confirmations uint64
+	}{
+		{"success", &types.TransactionReceipt{BlockNumber: 100, Status: 1}, true, 110, types.TrxStatusSuccess, 10},
+		{"failed", &types.TransactionReceipt{BlockNumber: 100, Status: 0}, true, 101, types.TrxStatusFailed, 1},
+		{"head block", &types.TransactionReceipt{BlockNumber: 100, Status: 1}, true, 100, types.TrxStatusSuccess, 0},
+		{"head behind", &types.TransactionReceipt{BlockNumber: 100, Status: 1}, true, 99, types.TrxStatusSuccess, 0},
+		{"pending", nil, true, 100, types.TrxStatusPending, 0},
+		{"unknown", nil, false, 100, types.TrxStatusUnknown, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := trxStatus(&hash, tt.rec, tt.known, tt.head)
+			if ts.Hash != hash || ts.Status != tt.status || uint64(ts.Confirmations) != tt.confirmations {
+				t.Errorf("unexpected status %v", ts)
+			}
+			if (ts.BlockNumber == nil) != (tt.rec == nil) {
+				t.Errorf("unexpected block number %v", ts.BlockNumber)
+			}
+		})
+	}
+}
@@ -107,6 +107,11 @@ func (p *proxy) UniswapPairContract(pairAddres *common.Address) (*contracts.Unis
 
 // UniswapAdd notifies a new incoming swap from blockchain to the repository.
 func (p *proxy) UniswapAdd(swap *types.Swap) error {
+	// do not let absurd amounts poison the volume aggregations
+	if isSuspiciousSwap(swap, p.cfg.Repository.ValueMaxBits) {
+		p.log.Warningf("swap of pair %s in trx %s flagged, suspicious amount", swap.Pair.String(), swap.Hash.String())
+		swap.Suspicious = true
+	}
 	return p.db.UniswapAdd(swap)
 }
 
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+const (
+	// uniswapMaxSlippageBps is the max slippage tolerance in basis points we accept for a quote.
+	uniswapMaxSlippageBps = 5000
+
+	// uniswapMaxPathLength is the max number of tokens in a swap path.
+	uniswapMaxPathLength = 5
+
+	// uniswapFeeNumerator and uniswapFeeDenominator represent the share of the input
+	// left after the swap fee is deducted; the pair charges 0.3% of the input.
+	uniswapFeeNumerator   = 997
+	uniswapFeeDenominator = 1000
+
+	// bpsDenominator represents the basis points base.
+	bpsDenominator = 10000
+)
+
+// UniswapQuote calculates a slippage adjusted quote of a swap of the given
+// input amount along the given path of tokens.
+func (p *proxy) UniswapQuote(amountIn hexutil.Big, path []common.Address, slippageBps int32) (*types.UniswapQuote, error) {
+	// we need the router to calculate anything
+	if p.cfg.DeFi.Uniswap.Router == common.HexToAddress(config.EmptyAddress) {
+		return nil, fmt.Errorf("uniswap router not configured")
+	}
+
+	// validate the input
+	if err := uniswapValidateQuote(amountIn.ToInt(), path, slippageBps); err != nil {
+		return nil, err
+	}
+
+	// collect reserves of the pairs on the path; the router would just revert
+	// on a pair without liquidity so we check it first
+	reserves, err := p.uniswapPathReserves(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := uniswapCheckLiquidity(path, reserves); err != nil {
+		return nil, err
+	}
+
+	// get the output amounts with the fees applied
+	amounts, err := p.UniswapAmountsOut(amountIn, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(amounts) != len(path) {
+		return nil, fmt.Errorf("unexpected number of swap amounts")
+	}
+
+	amountOut := amounts[len(amounts)-1].ToInt()
+	quote := types.UniswapQuote{
+		Path:         path,
+		AmountIn:     amountIn,
+		AmountOut:    amounts[len(amounts)-1],
+		AmountOutMin: hexutil.Big(*uniswapMinimumOut(amountOut, slippageBps)),
+		Amounts:      amounts,
+		SlippageBps:  slippageBps,
+		PriceImpact:  uniswapPriceImpact(amountIn.ToInt(), amountOut, reserves),
+	}
+
+	// execution price adjusted by the tokens decimals
+	quote.ExecutionPrice, err = p.uniswapExecutionPrice(amountIn.ToInt(), amountOut, &path[0], &path[len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// uniswapValidateQuote validates the input parameters of a swap quote.
+func uniswapValidateQuote(amountIn *big.Int, path []common.Address, slippageBps int32) error {
+	if amountIn.Sign() <= 0 {
+		return fmt.Errorf("input amount must be positive")
+	}
+	if slippageBps < 0 || slippageBps > uniswapMaxSlippageBps {
+		return fmt.Errorf("slippage must be between 0 and %d basis points", uniswapMaxSlippageBps)
+	}
+	if len(path) < 2 || len(path) > uniswapMaxPathLength {
+		return fmt.Errorf("swap path must contain between 2 and %d tokens", uniswapMaxPathLength)
+	}
+
+	zero := common.Address{}
+	for i, adr := range path {
+		if adr == zero {
+			return fmt.Errorf("invalid token on swap path position %d", i)
+		}
+		if i > 0 && path[i-1] == adr {
+			return fmt.Errorf("swap path can not swap token %s to itself", adr.String())
+		}
+	}
+	return nil
+}
+
+// uniswapPathReserves loads reserves of all the pairs on the path;
+// each element contains the input and the output side reserve of the step.
+func (p *proxy) uniswapPathReserves(path []common.Address) ([][2]*big.Int, error) {
+	reserves := make([][2]*big.Int, len(path)-1)
+	for i := 0; i < len(path)-1; i++ {
+		pair, err := p.UniswapPair(&path[i], &path[i+1])
+		if err != nil {
+			return nil, err
+		}
+		if *pair == (common.Address{}) {
+			return nil, fmt.Errorf("no pair for tokens %s and %s", path[i].String(), path[i+1].String())
+		}
+
+		tokens, err := p.UniswapTokens(pair)
+		if err != nil {
+			return nil, err
+		}
+		res, err := p.UniswapReserves(pair)
+		if err != nil {
+			return nil, err
+		}
+
+		// the pair keeps the tokens sorted
+		if tokens[0] == path[i] {
+			reserves[i] = [2]*big.Int{res[0].ToInt(), res[1].ToInt()}
+		} else {
+			reserves[i] = [2]*big.Int{res[1].ToInt(), res[0].ToInt()}
+		}
+	}
+	return reserves, nil
+}
+
+// uniswapCheckLiquidity makes sure all the pairs on the path have liquidity on both sides.
+func uniswapCheckLiquidity(path []common.Address, reserves [][2]*big.Int) error {
+	for i, r := range reserves {
+		if r[0].Sign() <= 0 || r[1].Sign() <= 0 {
+			return fmt.Errorf("pair of tokens %s and %s has no liquidity", path[i].String(), path[i+1].String())
+		}
+	}
+	return nil
+}
+
+// uniswapMinimumOut calculates the minimal output amount with the slippage tolerance applied.
+func uniswapMinimumOut(amountOut *big.Int, slippageBps int32) *big.Int {
+	val := new(big.Int).Mul(amountOut, big.NewInt(int64(bpsDenominator-slippageBps)))
+	return val.Quo(val, big.NewInt(bpsDenominator))
+}
+
+// uniswapPriceImpact calculates the relative difference between the given output
+// amount and the output we would get at the current mid prices of the pairs on the path
+// with the swap fees applied. The reserves contain the input and the output reserve of each step.
+func uniswapPriceImpact(amountIn *big.Int, amountOut *big.Int, reserves [][2]*big.Int) float64 {
+	// the output at mid prices, fees included
+	ideal := new(big.Rat).SetInt(amountIn)
+	for _, r := range reserves {
+		if r[0].Sign() <= 0 || r[1].Sign() <= 0 {
+			return 1
+		}
+		ideal.Mul(ideal, new(big.Rat).SetFrac(
+			new(big.Int).Mul(r[1], big.NewInt(uniswapFeeNumerator)),
+			new(big.Int).Mul(r[0], big.NewInt(uniswapFeeDenominator)),
+		))
+	}
+	if ideal.Sign() == 0 {
+		return 0
+	}
+
+	// impact = 1 - out / ideal
+	impact := new(big.Rat).Sub(big.NewRat(1, 1), new(big.Rat).Quo(new(big.Rat).SetInt(amountOut), ideal))
+	val, _ := impact.Float64()
+	if val < 0 {
+		return 0
+	}
+	return val
+}
+
+// uniswapExecutionPrice calculates the number of output tokens received per one input token.
+func (p *proxy) uniswapExecutionPrice(amountIn *big.Int, amountOut *big.Int, tokenIn *common.Address, tokenOut *common.Address) (float64, error) {
+	decIn, err := p.Erc20Decimals(tokenIn)
+	if err != nil {
+		return 0, err
+	}
+	decOut, err := p.Erc20Decimals(tokenOut)
+	if err != nil {
+		return 0, err
+	}
+	return uniswapPrice(amountIn, amountOut, decIn, decOut), nil
+}
+
+// uniswapPrice calculates the price of the output in the input token units adjusted by decimals.
+func uniswapPrice(amountIn *big.Int, amountOut *big.Int, decIn int32, decOut int32) float64 {
+	if amountIn.Sign() == 0 {
+		return 0
+	}
+
+	price := new(big.Rat).SetFrac(
+		new(big.Int).Mul(amountOut, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decIn)), nil)),
+		new(big.Int).Mul(amountIn, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decOut)), nil)),
+	)
+	val, _ := price.Float64()
+	return val
+}
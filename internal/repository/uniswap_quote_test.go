@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// testAmountOut calculates the swap output the same way the Uniswap pair does.
+func testAmountOut(amountIn *big.Int, reserveIn *big.Int, reserveOut *big.Int) *big.Int {
+	in := new(big.Int).Mul(amountIn, big.NewInt(uniswapFeeNumerator))
+	num := new(big.Int).Mul(in, reserveOut)
+	den := new(big.Int).Add(new(big.Int).Mul(reserveIn, big.NewInt(uniswapFeeDenominator)), in)
+	return num.Quo(num, den)
+}
+
+// TestUniswapPriceImpact tests the price impact math on balanced and imbalanced pools.
+func TestUniswapPriceImpact(t *testing.T) {
+	e18 := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	tokens := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), e18) }
+
+	tests := []struct {
+		name     string
+		amountIn *big.Int
+		reserves [][2]*big.Int
+		want     float64
+	}{
+		// the impact of a single step is in*fee / (reserveIn + in*fee)
+		{"tiny trade", tokens(1), [][2]*big.Int{{tokens(1000000), tokens(1000000)}}, 0.000000997},
+		{"balanced pool", tokens(1000), [][2]*big.Int{{tokens(1000), tokens(1000)}}, 0.499249},
+		{"imbalanced pool", tokens(100000), [][2]*big.Int{{tokens(1000000), tokens(1000)}}, 0.090661},
+		{"imbalanced pool reversed", tokens(100), [][2]*big.Int{{tokens(1000), tokens(1000000)}}, 0.090661},
+		{"two hops", tokens(100000), [][2]*big.Int{{tokens(1000000), tokens(1000)}, {tokens(1000), tokens(1000000)}}, 0.166042},
+	}
+
+	for _, tc := range tests {
+		// calculate the real output along the path
+		out := tc.amountIn
+		for _, r := range tc.reserves {
+			out = testAmountOut(out, r[0], r[1])
+		}
+
+		got := uniswapPriceImpact(tc.amountIn, out, tc.reserves)
+		if math.Abs(got-tc.want) > 0.000001 {
+			t.Errorf("%s: expected impact %f, got %f", tc.name, tc.want, got)
+		}
+	}
+
+	// empty pool means total loss
+	if got := uniswapPriceImpact(tokens(1), big.NewInt(0), [][2]*big.Int{{big.NewInt(0), tokens(1)}}); got != 1 {
+		t.Errorf("empty pool: expected impact 1, got %f", got)
+	}
+}
+
+// TestUniswapMinimumOut tests the slippage tolerance application.
+func TestUniswapMinimumOut(t *testing.T) {
+	tests := []struct {
+		out  int64
+		bps  int32
+		want int64
+	}{
+		{10000, 0, 10000},
+		{10000, 50, 9950},
+		{10000, 5000, 5000},
+		{999, 100, 989},
+	}
+
+	for _, tc := range tests {
+		if got := uniswapMinimumOut(big.NewInt(tc.out), tc.bps); got.Int64() != tc.want {
+			t.Errorf("%d at %d bps: expected %d, got %d", tc.out, tc.bps, tc.want, got.Int64())
+		}
+	}
+}
+
+// TestUniswapPrice tests the execution price adjustment by token decimals.
+func TestUniswapPrice(t *testing.T) {
+	// 1 token with 18 decimals for 2.5 tokens with 6 decimals
+	in := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	if got := uniswapPrice(in, big.NewInt(2500000), 18, 6); got != 2.5 {
+		t.Errorf("expected price 2.5, got %f", got)
+	}
+}
+
+// TestUniswapValidateQuote tests the quote input validation.
+func TestUniswapValidateQuote(t *testing.T) {
+	a, b, c := common.HexToAddress("0xa"), common.HexToAddress("0xb"), common.HexToAddress("0xc")
+
+	tests := []struct {
+		name  string
+		in    int64
+		path  []common.Address
+		bps   int32
+		valid bool
+	}{
+		{"valid", 1, []common.Address{a, b}, 50, true},
+		{"multi hop", 1, []common.Address{a, b, c}, 0, true},
+		{"zero amount", 0, []common.Address{a, b}, 50, false},
+		{"negative slippage", 1, []common.Address{a, b}, -1, false},
+		{"excessive slippage", 1, []common.Address{a, b}, 5001, false},
+		{"short path", 1, []common.Address{a}, 50, false},
+		{"long path", 1, []common.Address{a, b, c, a, b, c}, 50, false},
+		{"self swap", 1, []common.Address{a, a}, 50, false},
+		{"zero token", 1, []common.Address{a, {}}, 50, false},
+	}
+
+	for _, tc := range tests {
+		err := uniswapValidateQuote(big.NewInt(tc.in), tc.path, tc.bps)
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: expected valid %t, got %v", tc.name, tc.valid, err)
+		}
+	}
+}
+
+// TestUniswapFee tests the trading fee charged on swap inputs.
+func TestUniswapFee(t *testing.T) {
+	for _, tt := range []struct{ in, fee int64 }{{1000, 3}, {1000000, 3000}, {999, 2}, {0, 0}} {
+		if got := uniswapFee(big.NewInt(tt.in)); got.Int64() != tt.fee {
+			t.Errorf("uniswapFee(%d) = %d, want %d", tt.in, got.Int64(), tt.fee)
+		}
+	}
+}
+
+// TestUniswapCheckLiquidity tests pairs without liquidity on the path are reported.
+func TestUniswapCheckLiquidity(t *testing.T) {
+	path := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")}
+	full := [2]*big.Int{big.NewInt(1000), big.NewInt(2000)}
+
+	if err := uniswapCheckLiquidity(path, [][2]*big.Int{full, full}); err != nil {
+		t.Errorf("unexpected error %s", err.Error())
+	}
+
+	err := uniswapCheckLiquidity(path, [][2]*big.Int{full, {big.NewInt(1000), new(big.Int)}})
+	if err == nil {
+		t.Fatalf("expected missing liquidity error")
+	}
+	if want := "pair of tokens " + path[1].String() + " and " + path[2].String() + " has no liquidity"; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+}
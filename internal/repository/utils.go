@@ -31,15 +31,38 @@ const (
 	pricePullRequestTimeout = 5
 )
 
-// GasPrice pulls the current amount of WEI for single Gas.
+// GasPrice pulls the current amount of WEI for single Gas suggested by the configured mode.
+// The price is cached for a short configurable time.
 func (p *proxy) GasPrice() (hexutil.Big, error) {
-	return p.rpc.GasPrice()
+	maxAge := p.cfg.Repository.GasPriceCache
+	if maxAge <= 0 {
+		return p.suggestGasPrice()
+	}
+
+	val, err, _ := p.apiRequestGroup.Do("gas_price", func() (interface{}, error) {
+		if gp := p.cache.PullGasPrice(maxAge); gp != nil {
+			return *gp, nil
+		}
+
+		gp, err := p.suggestGasPrice()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.cache.PushGasPrice(&gp); err != nil {
+			p.log.Errorf("can not cache gas price; %s", err.Error())
+		}
+		return gp, nil
+	})
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return val.(hexutil.Big), nil
 }
 
 // GasPriceExtended provides extended gas price information.
 func (p *proxy) GasPriceExtended() (*types.GasPrice, error) {
 	// get the current gas price
-	gp, err := p.rpc.GasPrice()
+	gp, err := p.GasPrice()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"math/big"
+)
+
+// IsSuspiciousValue checks if the given token amount, balance, or supply exceeds
+// the configured sanity bound. Contracts may report absurd values, e.g. a balance
+// close to the max uint256 value, to break formatting and aggregations downstream.
+func (p *proxy) IsSuspiciousValue(val *big.Int) bool {
+	return isSuspiciousValue(val, p.cfg.Repository.ValueMaxBits)
+}
+
+// isSuspiciousValue checks if the absolute value of the given number
+// needs more than the given number of bits. Zero bound disables the check.
+func isSuspiciousValue(val *big.Int, maxBits int) bool {
+	return maxBits > 0 && val != nil && val.BitLen() > maxBits
+}
+
+// isSuspiciousSwap checks if any amount of the given swap exceeds the given bound.
+// Swap amounts are summed into trading volumes, a single poisoned swap
+// would dominate the volume of the pair.
+func isSuspiciousSwap(swap *types.Swap, maxBits int) bool {
+	for _, val := range []*big.Int{swap.Amount0In, swap.Amount0Out, swap.Amount1In, swap.Amount1Out, swap.Reserve0, swap.Reserve1} {
+		if isSuspiciousValue(val, maxBits) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"motif-api/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+	"testing"
+)
+
+// maxUint256 represents the max uint256 value, a common griefing value of balances.
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// TestIsSuspiciousValue tests the detection of values above the sanity bound.
+func TestIsSuspiciousValue(t *testing.T) {
+	bound := new(big.Int).Lsh(big.NewInt(1), 192)
+
+	tests := []struct {
+		name    string
+		val     *big.Int
+		maxBits int
+		want    bool
+	}{
+		{"nil value", nil, 192, false},
+		{"zero", big.NewInt(0), 192, false},
+		{"regular supply", new(big.Int).Exp(big.NewInt(10), big.NewInt(36), nil), 192, false},
+		{"max bound", new(big.Int).Sub(bound, big.NewInt(1)), 192, false},
+		{"above bound", bound, 192, true},
+		{"max uint256", maxUint256, 192, true},
+		{"negative max uint256", new(big.Int).Neg(maxUint256), 192, true},
+		{"check disabled", maxUint256, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSuspiciousValue(tt.val, tt.maxBits); got != tt.want {
+				t.Errorf("isSuspiciousValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIsSuspiciousSwap tests the detection of swaps with absurd amounts.
+func TestIsSuspiciousSwap(t *testing.T) {
+	regular := func() *types.Swap {
+		one := big.NewInt(1000000000000000000)
+		return &types.Swap{Amount0In: one, Amount0Out: big.NewInt(0), Amount1In: big.NewInt(0), Amount1Out: one, Reserve0: one, Reserve1: one}
+	}
+
+	sw := regular()
+	if isSuspiciousSwap(sw, 192) {
+		t.Errorf("regular swap flagged as suspicious")
+	}
+
+	sw.Amount1Out = maxUint256
+	if !isSuspiciousSwap(sw, 192) {
+		t.Errorf("swap with max uint256 amount not flagged")
+	}
+
+	sw = regular()
+	sw.Reserve0 = maxUint256
+	if !isSuspiciousSwap(sw, 192) {
+		t.Errorf("swap with max uint256 reserve not flagged")
+	}
+}
+
+// TestTokenTransactionMarshalMaxUint256 tests the aggregated value of absurd token amounts
+// does not overflow into a random number.
+func TestTokenTransactionMarshalMaxUint256(t *testing.T) {
+	trx := types.TokenTransaction{TokenType: types.AccountTypeERC20Token}
+	trx.Amount.ToInt().Set(maxUint256)
+
+	data, err := trx.MarshalBSON()
+	if err != nil {
+		t.Fatalf("can not marshal token transaction; %s", err.Error())
+	}
+
+	var row types.BsonErc20Transaction
+	if err := bson.Unmarshal(data, &row); err != nil {
+		t.Fatalf("can not unmarshal token transaction; %s", err.Error())
+	}
+	if row.Value != 0 {
+		t.Errorf("aggregated value of max uint256 amount = %d, want 0", row.Value)
+	}
+	if row.Amo != trx.Amount.String() {
+		t.Errorf("amount = %s, want %s", row.Amo, trx.Amount.String())
+	}
+}
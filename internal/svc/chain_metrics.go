@@ -0,0 +1,112 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// chainMetricsWindowSize represents the number of recent blocks
+// used to calculate the chain throughput metrics.
+const chainMetricsWindowSize = 100
+
+// chainMetricsSample represents a block sample of the moving window.
+type chainMetricsSample struct {
+	number   uint64
+	time     uint64
+	txs      int
+	gasUsed  uint64
+	gasLimit uint64
+}
+
+// chainMetricsWindow implements a moving window of recently processed blocks
+// used to calculate chain throughput metrics cheaply.
+type chainMetricsWindow struct {
+	samples []chainMetricsSample
+	head    int
+	size    int
+
+	// running sums of the window
+	txs         int
+	utilization float64
+}
+
+// newChainMetricsWindow creates a new chain metrics window of the given capacity.
+func newChainMetricsWindow(capacity int) *chainMetricsWindow {
+	return &chainMetricsWindow{samples: make([]chainMetricsSample, capacity)}
+}
+
+// add pushes the given block into the window. Blocks out of sequence reset the window
+// so the metrics always describe an unbroken sequence of blocks.
+func (cmw *chainMetricsWindow) add(blk *types.Block) {
+	// ignore re-scanned blocks; reset on a gap
+	if cmw.size > 0 {
+		last := cmw.samples[(cmw.head+cmw.size-1)%len(cmw.samples)].number
+		if uint64(blk.Number) <= last {
+			return
+		}
+		if uint64(blk.Number) != last+1 {
+			cmw.reset()
+		}
+	}
+
+	// drop the oldest sample if the window is full
+	if cmw.size == len(cmw.samples) {
+		old := cmw.samples[cmw.head]
+		cmw.txs -= old.txs
+		cmw.utilization -= gasUtilization(old.gasUsed, old.gasLimit)
+		cmw.head = (cmw.head + 1) % len(cmw.samples)
+		cmw.size--
+	}
+
+	// add the new sample
+	sam := chainMetricsSample{
+		number:   uint64(blk.Number),
+		time:     uint64(blk.TimeStamp),
+		txs:      len(blk.Txs),
+		gasUsed:  uint64(blk.GasUsed),
+		gasLimit: uint64(blk.GasLimit),
+	}
+	cmw.samples[(cmw.head+cmw.size)%len(cmw.samples)] = sam
+	cmw.size++
+	cmw.txs += sam.txs
+	cmw.utilization += gasUtilization(sam.gasUsed, sam.gasLimit)
+}
+
+// reset clears the window.
+func (cmw *chainMetricsWindow) reset() {
+	cmw.head, cmw.size, cmw.txs, cmw.utilization = 0, 0, 0, 0
+}
+
+// metrics calculates the chain metrics of the current window.
+// It returns nil if the window does not contain enough blocks yet.
+func (cmw *chainMetricsWindow) metrics() *types.ChainMetrics {
+	if cmw.size < 2 {
+		return nil
+	}
+
+	first := cmw.samples[cmw.head]
+	last := cmw.samples[(cmw.head+cmw.size-1)%len(cmw.samples)]
+	cm := types.ChainMetrics{
+		Blocks:            int32(cmw.size),
+		FirstBlock:        hexutil.Uint64(first.number),
+		LastBlock:         hexutil.Uint64(last.number),
+		AvgGasUtilization: cmw.utilization / float64(cmw.size),
+	}
+
+	// the first block transactions were produced before the window started
+	if last.time > first.time {
+		span := float64(last.time - first.time)
+		cm.AvgBlockInterval = span / float64(cmw.size-1)
+		cm.Tps = float64(cmw.txs-first.txs) / span
+	}
+	return &cm
+}
+
+// gasUtilization calculates the ratio of used gas to the gas limit of a block.
+func gasUtilization(used uint64, limit uint64) float64 {
+	if limit == 0 {
+		return 0
+	}
+	return float64(used) / float64(limit)
+}
@@ -25,6 +25,9 @@ type blockDispatcher struct {
 	inBlock        chan *types.Block
 	outTransaction chan *eventTrx
 	outDispatched  chan uint64
+	outSupply      chan *types.Block
+	metrics        *chainMetricsWindow
+	reorg          *reorgDetector
 }
 
 // name returns the name of the service used by orchestrator.
@@ -37,6 +40,11 @@ func (bld *blockDispatcher) init() {
 	bld.sigStop = make(chan bool, 1)
 	bld.outTransaction = make(chan *eventTrx, trxBufferCapacity)
 	bld.outDispatched = make(chan uint64, blsBlockBufferCapacity)
+	if cfg.SupplySnapshot.Interval > 0 {
+		bld.outSupply = make(chan *types.Block, supplyBlockBufferCapacity)
+	}
+	bld.metrics = newChainMetricsWindow(chainMetricsWindowSize)
+	bld.reorg = newReorgDetector()
 }
 
 // run starts the block dispatcher
@@ -60,6 +68,9 @@ func (bld *blockDispatcher) execute() {
 		close(bld.sigStop)
 		close(bld.outTransaction)
 		close(bld.outDispatched)
+		if bld.outSupply != nil {
+			close(bld.outSupply)
+		}
 
 		// signal we are done
 		bld.mgr.finished(bld)
@@ -78,8 +89,13 @@ func (bld *blockDispatcher) execute() {
 				return
 			}
 
-			// process the new block
+			// handle chain reorganization replacing already dispatched blocks
 			log.Debugf("block #%d arrived", uint64(blk.Number))
+			if !bld.handleReorg(blk) {
+				continue
+			}
+
+			// process the new block
 			if !bld.process(blk) {
 				continue
 			}
@@ -92,8 +108,39 @@ func (bld *blockDispatcher) execute() {
 
 			// add the block to the ring
 			repo.CacheBlock(blk)
+
+			// update the chain metrics window
+			bld.metrics.add(blk)
+			repo.UpdateChainMetrics(bld.metrics.metrics())
+		}
+	}
+}
+
+// handleReorg detects chain reorganization on the given block; if the block replaces
+// already dispatched blocks, the reorg is recorded and the new canonical blocks
+// below the given block are processed.
+func (bld *blockDispatcher) handleReorg(blk *types.Block) bool {
+	re, blocks := bld.reorg.check(blk)
+	if re == nil {
+		return true
+	}
+
+	// record the reorg
+	log.Warningf("chain reorg of %d blocks detected at <#%d, #%d>", uint64(re.Depth), uint64(re.FromBlock), uint64(re.ToBlock))
+	if err := repo.AddReorg(re); err != nil {
+		log.Errorf("can not record chain reorg; %s", err.Error())
+	}
+
+	// drop data of the replaced blocks; the new blocks bring their own
+	revertBlocks(uint64(re.FromBlock), uint64(re.ToBlock))
+
+	// process the new canonical blocks
+	for _, b := range blocks {
+		if !bld.process(b) {
+			return false
 		}
 	}
+	return true
 }
 
 // process the given block by loading its content and sending block transactions
@@ -108,6 +155,9 @@ func (bld *blockDispatcher) process(blk *types.Block) bool {
 		return false
 	}
 
+	// request the token supply snapshot on the interval
+	bld.snapshotSupply(blk)
+
 	if blk.Txs == nil || len(blk.Txs) == 0 {
 		log.Debugf("empty block #%d processed", blk.Number)
 		return true
@@ -122,6 +172,20 @@ func (bld *blockDispatcher) process(blk *types.Block) bool {
 	return true
 }
 
+// snapshotSupply passes the block to the supply snapshotter if it's on the snapshot interval.
+// The snapshot is skipped if the snapshotter falls behind so the dispatch is not blocked.
+func (bld *blockDispatcher) snapshotSupply(blk *types.Block) {
+	if bld.outSupply == nil || uint64(blk.Number)%cfg.SupplySnapshot.Interval != 0 {
+		return
+	}
+
+	select {
+	case bld.outSupply <- blk:
+	default:
+		log.Warningf("supply snapshot of block #%d skipped, snapshotter is busy", uint64(blk.Number))
+	}
+}
+
 // processTxs loops all the transactions in the block and pushes them
 // into the transaction dispatcher queue observing the term signal.
 func (bld *blockDispatcher) processTxs(blk *types.Block) bool {
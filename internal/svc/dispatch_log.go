@@ -12,6 +12,7 @@ type logDispatcher struct {
 	service
 	inLog       chan *types.LogRecord
 	knownTopics map[common.Hash]func(*types.LogRecord)
+	indexLogs   bool
 }
 
 // name returns the name of the service used by orchestrator.
@@ -22,6 +23,7 @@ func (lgd *logDispatcher) name() string {
 // init prepares the log dispatcher to perform its function.
 func (lgd *logDispatcher) init() {
 	lgd.sigStop = make(chan bool, 1)
+	lgd.indexLogs = cfg.Repository.IndexLogs
 	lgd.knownTopics = map[common.Hash]func(*types.LogRecord){
 		/* SFC1::CreatedDelegation(address indexed delegator, uint256 indexed toStakerID, uint256 amount) */
 		common.HexToHash("0xfd8c857fb9acd6f4ad59b8621a2a77825168b7b4b76de9586d08e00d4ed462be"): handleSfcCreatedDelegation,
@@ -89,6 +91,12 @@ func (lgd *logDispatcher) init() {
 		/* SFC3::RestakedRewards(address indexed delegator, uint256 indexed toValidatorID, uint256 lockupExtraReward, uint256 lockupBaseReward, uint256 unlockedReward) */
 		common.HexToHash("0x4119153d17a36f9597d40e3ab4148d03261a439dddbec4e91799ab7159608e26"): handleSfcRestakeRewards,
 
+		/* SFC3::LockedUpStake(address indexed delegator, uint256 indexed validatorID, uint256 duration, uint256 amount) */
+		common.HexToHash("0x138940e95abffcd789b497bf6188bba3afa5fbd22fb5c42c2f6018d1bf0f4e78"): handleSfcLockedUpStake,
+
+		/* SFC3::UnlockedStake(address indexed delegator, uint256 indexed validatorID, uint256 amount, uint256 penalty) */
+		common.HexToHash("0xef6c0c14fe9aa51af36acd791464dec3badbde668b63189b47bfa4e25be9b2b9"): handleSfcUnlockedStake,
+
 		/* ---------------- ERC20 and ERC721 contracts related event hooks below this line ---------------- */
 
 		/* ERC20::Approval(address indexed owner, address indexed spender, uint256 value) */
@@ -169,6 +177,11 @@ func (lgd *logDispatcher) execute() {
 				return
 			}
 
+			// keep the log record in the logs index, if enabled
+			if lgd.indexLogs && lr.Block != nil {
+				indexLog(lr)
+			}
+
 			// try to find the topic handler
 			handler, ok := lgd.knownTopics[lr.Topics[0]]
 			if ok && lr.Block != nil && lr.Trx != nil {
@@ -181,3 +194,10 @@ func (lgd *logDispatcher) execute() {
 		}
 	}
 }
+
+// indexLog stores the given log record in the logs index.
+func indexLog(lr *types.LogRecord) {
+	if err := repo.StoreLog(&types.IndexedLog{Log: lr.Log}); err != nil {
+		log.Errorf("can not index log #%d of block #%d; %s", lr.Index, lr.BlockNumber, err.Error())
+	}
+}
@@ -40,6 +40,7 @@ type trxDispatcher struct {
 	inTransaction chan *eventTrx
 	outAccount    chan *eventAcc
 	outLog        chan *types.LogRecord
+	calls         *methodWatcher
 }
 
 // name returns the name of the service used by orchestrator.
@@ -53,6 +54,7 @@ func (trd *trxDispatcher) init() {
 	trd.blkObserver = atomic.NewUint64(1)
 	trd.outAccount = make(chan *eventAcc, trxAddressQueueCapacity)
 	trd.outLog = make(chan *types.LogRecord, trxLogQueueCapacity)
+	trd.calls = newMethodWatcher(cfg.MethodWatch)
 }
 
 // run starts the transaction dispatcher job
@@ -96,6 +98,11 @@ func (trd *trxDispatcher) execute() {
 		// try to read next transaction
 		select {
 		case <-trd.sigStop:
+			// persist the progress so the scanner resumes from the last processed block;
+			// the observer starts at #1 before any block is processed
+			if trd.blkObserver.Load() > 1 {
+				trd.updateLastSeenBlock()
+			}
 			return
 		case <-trd.bot.C:
 			trd.updateLastSeenBlock()
@@ -127,6 +134,8 @@ func (trd *trxDispatcher) updateLastSeenBlock() {
 	if err != nil {
 		log.Errorf("could not update last seen block; %s", err.Error())
 	}
+	// persist the progress of the indexers along with it
+	flushIndexCheckpoints()
 }
 
 // process the given transaction event into the required targets.
@@ -144,6 +153,9 @@ func (trd *trxDispatcher) process(evt *eventTrx) {
 		}
 	}
 
+	// record calls of watched contract methods
+	trd.recordMethodCall(evt)
+
 	// store the transaction into the database once the processing is done
 	// we spawn a lot of go-routines here, so we should test the optimal queue length above
 	go trd.waitAndStore(evt, &wg)
@@ -155,6 +167,23 @@ func (trd *trxDispatcher) process(evt *eventTrx) {
 	}
 }
 
+// recordMethodCall stores the call of a watched contract method made by the given
+// transaction, if any. Failed transactions are not recorded.
+func (trd *trxDispatcher) recordMethodCall(evt *eventTrx) {
+	if evt.trx.Status == nil || *evt.trx.Status != 1 {
+		return
+	}
+
+	call := trd.calls.match(evt.blk, evt.trx)
+	if call == nil {
+		return
+	}
+
+	if err := repo.StoreMethodCall(call); err != nil {
+		log.Errorf("can not store method call of trx %s; %s", evt.trx.Hash.String(), err.Error())
+	}
+}
+
 // waitAndStore waits for the transaction processing to finish and stores the transaction into db.
 func (trd *trxDispatcher) waitAndStore(evt *eventTrx, wg *sync.WaitGroup) {
 	// wait until all the sub-processors finish their job
@@ -166,6 +195,7 @@ func (trd *trxDispatcher) waitAndStore(evt *eventTrx, wg *sync.WaitGroup) {
 	repo.IncTrxCountEstimate(1)
 	repo.CacheTransaction(evt.trx)
 	trd.blkObserver.Store(uint64(evt.blk.Number))
+	markIndexed(types.IndexerTransactions, uint64(evt.blk.Number))
 }
 
 // pushAccounts pushes given transaction accounts on both sides observing terminate signal on process.
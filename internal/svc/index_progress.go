@@ -0,0 +1,44 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"motif-api/internal/types"
+	"go.uber.org/atomic"
+)
+
+// indexCheckpoints keeps the highest block processed by the block based indexers
+// since the service started; zero means no progress has been made yet.
+var indexCheckpoints = map[string]*atomic.Uint64{
+	types.IndexerTransactions: atomic.NewUint64(0),
+	types.IndexerTransfers:    atomic.NewUint64(0),
+	types.IndexerStaking:      atomic.NewUint64(0),
+}
+
+// markIndexed records the given block as processed by the given indexer.
+// Blocks may be processed out of order, the highest one is kept.
+func markIndexed(name string, blk uint64) {
+	cp, ok := indexCheckpoints[name]
+	if !ok {
+		return
+	}
+	for {
+		cur := cp.Load()
+		if cur >= blk || cp.CAS(cur, blk) {
+			return
+		}
+	}
+}
+
+// flushIndexCheckpoints stores the progress of the block based indexers
+// in the persistent database.
+func flushIndexCheckpoints() {
+	for name, cp := range indexCheckpoints {
+		blk := cp.Load()
+		if blk == 0 {
+			continue
+		}
+		if err := repo.UpdateIndexerCheckpoint(name, blk); err != nil {
+			log.Errorf("could not update %s indexer checkpoint; %s", name, err.Error())
+		}
+	}
+}
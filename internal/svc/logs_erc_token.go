@@ -116,5 +116,7 @@ func storeTokenTransaction(lr *types.LogRecord, tokenType string, eventType int3
 		Seq:          seq, // sequence of erc transactions emitted by one log event - non-zero only for batch transfer events
 	}); err != nil {
 		log.Errorf("can not store token %s trx for call %s; %s", tokenType, lr.TxHash.String(), err.Error())
+		return
 	}
+	markIndexed(types.IndexerTransfers, lr.BlockNumber)
 }
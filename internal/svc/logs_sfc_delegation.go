@@ -46,12 +46,12 @@ func handleNewDelegation(lr *types.LogRecord, stakerID *big.Int, addr common.Add
 // (SFCv1, SFCv2) event CreatedDelegation(address indexed delegator, uint256 indexed toStakerID, uint256 amount)
 // (SFCv3) event Delegated(address indexed delegator, uint256 indexed toValidatorID, uint256 amount)
 func handleSfcCreatedDelegation(lr *types.LogRecord) {
-	handleNewDelegation(
-		lr,
-		new(big.Int).SetBytes(lr.Topics[2].Bytes()),
-		common.BytesToAddress(lr.Topics[1].Bytes()),
-		new(big.Int).SetBytes(lr.Data),
-	)
+	addr := common.BytesToAddress(lr.Topics[1].Bytes())
+	valID := new(big.Int).SetBytes(lr.Topics[2].Bytes())
+	amo := new(big.Int).SetBytes(lr.Data)
+
+	recordStakeChange(lr, types.StakeChangeDelegated, addr, valID, amo, nil, 0)
+	handleNewDelegation(lr, valID, addr, amo)
 }
 
 // handleSfc1IncreasedDelegation handles delegation amount increase event in SFC v1 and SFC v2.
@@ -62,6 +62,11 @@ func handleSfc1IncreasedDelegation(lr *types.LogRecord) {
 	addr := common.BytesToAddress(lr.Topics[1].Bytes())
 	valID := new(big.Int).SetBytes(lr.Topics[2].Bytes())
 
+	// record the increase; the diff follows the new amount in the data
+	if len(lr.Data) == 64 {
+		recordStakeChange(lr, types.StakeChangeDelegated, addr, valID, new(big.Int).SetBytes(lr.Data[32:]), nil, 0)
+	}
+
 	// update the balance
 	if err := repo.UpdateDelegationBalance(&addr, (*hexutil.Big)(valID), func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &addr, (*hexutil.Big)(valID), amo)
@@ -81,6 +86,14 @@ func handleSfcUndelegated(lr *types.LogRecord) {
 		return
 	}
 
+	recordStakeChange(lr, types.StakeChangeUndelegated,
+		common.BytesToAddress(lr.Topics[1].Bytes()),
+		new(big.Int).SetBytes(lr.Topics[2].Bytes()),
+		new(big.Int).SetBytes(lr.Data[:]),
+		nil,
+		0,
+	)
+
 	// create withdraw request
 	handleNewWithdrawRequest(
 		types.WithdrawTypeUndelegated,
@@ -256,6 +269,16 @@ func handleSfc1UpdatedDelegation(lr *types.LogRecord) {
 // handleSfcWithdrawn handles a withdrawal request finalization event.
 // event Withdrawn(address indexed delegator, uint256 indexed toValidatorID, uint256 indexed wrID, uint256 amount)
 func handleSfcWithdrawn(lr *types.LogRecord) {
+	if len(lr.Data) == 32 {
+		recordStakeChange(lr, types.StakeChangeWithdrawn,
+			common.BytesToAddress(lr.Topics[1].Bytes()),
+			new(big.Int).SetBytes(lr.Topics[2].Bytes()),
+			new(big.Int).SetBytes(lr.Data[:]),
+			nil,
+			0,
+		)
+	}
+
 	// finish the request
 	handleFinishedWithdrawRequest(
 		common.BytesToAddress(lr.Topics[1].Bytes()),
@@ -0,0 +1,66 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// recordStakeChange adds the change of a delegator stake emitted by the given log record
+// into the staking history of the delegator.
+func recordStakeChange(lr *types.LogRecord, typ string, addr common.Address, valID *big.Int, amo *big.Int, penalty *big.Int, duration uint64) {
+	sc := types.StakeChange{
+		Type:        typ,
+		Delegator:   addr,
+		ValidatorId: hexutil.Big(*valID),
+		Amount:      hexutil.Big(*amo),
+		Duration:    hexutil.Uint64(duration),
+		Transaction: lr.TxHash,
+		BlockNumber: hexutil.Uint64(lr.BlockNumber),
+		LogIndex:    hexutil.Uint64(lr.Index),
+		TimeStamp:   lr.Block.TimeStamp,
+	}
+	if penalty != nil {
+		sc.Penalty = hexutil.Big(*penalty)
+	}
+
+	if err := repo.StoreStakeChange(&sc); err != nil {
+		log.Errorf("can not store stake change %s of %s to #%d; %s", typ, addr.String(), valID.Uint64(), err.Error())
+		return
+	}
+	markIndexed(types.IndexerStaking, lr.BlockNumber)
+}
+
+// handleSfcLockedUpStake handles a delegation lock event.
+// event LockedUpStake(address indexed delegator, uint256 indexed validatorID, uint256 duration, uint256 amount)
+func handleSfcLockedUpStake(lr *types.LogRecord) {
+	// sanity check for data (2x uint256 = 64 bytes)
+	if len(lr.Data) != 64 {
+		log.Criticalf("%s lr invalid data length; expected 64 bytes, %d bytes given", lr.TxHash.String(), len(lr.Data))
+		return
+	}
+
+	recordStakeChange(lr, types.StakeChangeLocked,
+		common.BytesToAddress(lr.Topics[1].Bytes()),
+		new(big.Int).SetBytes(lr.Topics[2].Bytes()),
+		new(big.Int).SetBytes(lr.Data[32:]),
+		nil,
+		new(big.Int).SetBytes(lr.Data[:32]).Uint64(),
+	)
+}
+
+// handleSfcUnlockedStake handles a delegation unlock event.
+// event UnlockedStake(address indexed delegator, uint256 indexed validatorID, uint256 amount, uint256 penalty)
+func handleSfcUnlockedStake(lr *types.LogRecord) {
+	// sanity check for data (2x uint256 = 64 bytes)
+	if len(lr.Data) != 64 {
+		log.Criticalf("%s lr invalid data length; expected 64 bytes, %d bytes given", lr.TxHash.String(), len(lr.Data))
+		return
+	}
+
+	addr := common.BytesToAddress(lr.Topics[1].Bytes())
+	valID := new(big.Int).SetBytes(lr.Topics[2].Bytes())
+	recordStakeChange(lr, types.StakeChangeUnlocked, addr, valID, new(big.Int).SetBytes(lr.Data[:32]), new(big.Int).SetBytes(lr.Data[32:]), 0)
+}
@@ -0,0 +1,103 @@
+package svc
+
+import (
+	"math/big"
+	"testing"
+
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// testStakeRepo implements the repository calls of the stake history indexing.
+type testStakeRepo struct {
+	repository.Repository
+	stored []types.StakeChange
+}
+
+// StoreStakeChange records the stake change.
+func (tr *testStakeRepo) StoreStakeChange(sc *types.StakeChange) error {
+	tr.stored = append(tr.stored, *sc)
+	return nil
+}
+
+// testStakeHistory swaps the repository for a stake history recorder.
+func testStakeHistory(t *testing.T) *testStakeRepo {
+	testSvcLogger()
+	tr := new(testStakeRepo)
+	prev := repo
+	repo = tr
+	t.Cleanup(func() { repo = prev })
+	return tr
+}
+
+// testStakeLog creates an SFC log record of the given event with two uint256 data words.
+func testStakeLog(sig string, a, b int64) *types.LogRecord {
+	data := append(common.BigToHash(big.NewInt(a)).Bytes(), common.BigToHash(big.NewInt(b)).Bytes()...)
+	return &types.LogRecord{
+		Block: &types.Block{Number: 120, TimeStamp: 1650000000},
+		Log: retypes.Log{
+			Topics: []common.Hash{
+				common.HexToHash(sig),
+				common.BytesToHash(common.HexToAddress("0xd1").Bytes()),
+				common.BigToHash(big.NewInt(7)),
+			},
+			Data:        data,
+			BlockNumber: 120,
+			TxHash:      common.HexToHash("0xaa"),
+			Index:       3,
+		},
+	}
+}
+
+// TestSfcLockedUpStake tests a lock records the locked amount and the lock duration.
+func TestSfcLockedUpStake(t *testing.T) {
+	tr := testStakeHistory(t)
+	handleSfcLockedUpStake(testStakeLog("0x138940e95abffcd789b497bf6188bba3afa5fbd22fb5c42c2f6018d1bf0f4e78", 86400, 5000))
+
+	if len(tr.stored) != 1 {
+		t.Fatalf("expected 1 stake change, got %d", len(tr.stored))
+	}
+	sc := tr.stored[0]
+	if sc.Type != types.StakeChangeLocked || sc.Delegator != common.HexToAddress("0xd1") || sc.ValidatorId.ToInt().Int64() != 7 {
+		t.Errorf("unexpected stake change %s of %s to #%d", sc.Type, sc.Delegator.String(), sc.ValidatorId.ToInt().Int64())
+	}
+	if sc.Amount.ToInt().Int64() != 5000 || sc.Duration != 86400 || sc.Penalty.ToInt().Sign() != 0 {
+		t.Errorf("unexpected amount %d, duration %d, penalty %d", sc.Amount.ToInt().Int64(), sc.Duration, sc.Penalty.ToInt().Int64())
+	}
+	if sc.Transaction != common.HexToHash("0xaa") || sc.BlockNumber != 120 || sc.LogIndex != 3 || sc.TimeStamp != hexutil.Uint64(1650000000) {
+		t.Errorf("unexpected stake change origin %s, block %d, log %d, time %d", sc.Transaction.String(), sc.BlockNumber, sc.LogIndex, sc.TimeStamp)
+	}
+}
+
+// TestSfcUnlockedStake tests an unlock records the unlocked amount and the penalty.
+func TestSfcUnlockedStake(t *testing.T) {
+	tr := testStakeHistory(t)
+	handleSfcUnlockedStake(testStakeLog("0xef6c0c14fe9aa51af36acd791464dec3badbde668b63189b47bfa4e25be9b2b9", 5000, 250))
+
+	if len(tr.stored) != 1 {
+		t.Fatalf("expected 1 stake change, got %d", len(tr.stored))
+	}
+	sc := tr.stored[0]
+	if sc.Type != types.StakeChangeUnlocked || sc.Delegator != common.HexToAddress("0xd1") || sc.ValidatorId.ToInt().Int64() != 7 {
+		t.Errorf("unexpected stake change %s of %s to #%d", sc.Type, sc.Delegator.String(), sc.ValidatorId.ToInt().Int64())
+	}
+	if sc.Amount.ToInt().Int64() != 5000 || sc.Penalty.ToInt().Int64() != 250 || sc.Duration != 0 {
+		t.Errorf("unexpected amount %d, penalty %d, duration %d", sc.Amount.ToInt().Int64(), sc.Penalty.ToInt().Int64(), sc.Duration)
+	}
+}
+
+// TestSfcStakeInvalidData tests the events with malformed data are not recorded.
+func TestSfcStakeInvalidData(t *testing.T) {
+	tr := testStakeHistory(t)
+	for _, h := range []func(*types.LogRecord){handleSfcLockedUpStake, handleSfcUnlockedStake} {
+		lr := testStakeLog("0x01", 1, 2)
+		lr.Data = lr.Data[:40]
+		h(lr)
+	}
+	if len(tr.stored) != 0 {
+		t.Errorf("expected no stake change, got %d", len(tr.stored))
+	}
+}
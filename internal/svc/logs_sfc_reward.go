@@ -53,6 +53,11 @@ func handleSfcCommonRewardClaim(lr *types.LogRecord, isRestake bool) {
 	)
 	amo := new(big.Int).Add(amoA, new(big.Int).SetBytes(lr.Data[64:]))
 
+	// re-staked rewards increase the stake
+	if isRestake {
+		recordStakeChange(lr, types.StakeChangeRestaked, addr, valID.ToInt(), amo, nil, 0)
+	}
+
 	// do the handling
 	handleSfcRewardClaim(lr, addr, valID, amo, isRestake)
 }
@@ -6,9 +6,14 @@ import (
 	"motif-api/internal/repository"
 	"motif-api/internal/types"
 	"fmt"
+	"go.uber.org/atomic"
 	"sync"
 )
 
+// ErrScannerRestarting is returned if a block scanner restart is requested
+// while another restart is still in progress.
+var ErrScannerRestarting = fmt.Errorf("block scanner restart already in progress")
+
 // ServiceManager implements service manager.
 type ServiceManager struct {
 	wg *sync.WaitGroup
@@ -20,9 +25,14 @@ type ServiceManager struct {
 	acd *accDispatcher
 	lgd *logDispatcher
 	bls *blkScanner
+	vwr *viewRefresher
+	sup *supplySnapshotter
 
 	// collection of all the managed services
 	svc []Svc
+
+	// guard of the block scanner restart
+	restarting atomic.Bool
 }
 
 // newServiceManager creates a new instance of service manager.
@@ -80,6 +90,35 @@ func (mgr *ServiceManager) Close() {
 	log.Notice("svc manager closed")
 }
 
+// RestartScanner stops the block scanner and starts it again from the given block.
+// If the block is not given, the scanner resumes from the last known block.
+// Only one restart can be in progress at any time.
+func (mgr *ServiceManager) RestartScanner(from *uint64) error {
+	if !mgr.restarting.CAS(false, true) {
+		return ErrScannerRestarting
+	}
+	defer mgr.restarting.Store(false)
+
+	if from != nil {
+		log.Noticef("block scanner restart requested from #%d", *from)
+	} else {
+		log.Notice("block scanner restart requested from the last known block")
+	}
+	return mgr.bls.restart(from)
+}
+
+// Views provides the refresh state of the materialized views.
+func (mgr *ServiceManager) Views() []types.MaterializedView {
+	return mgr.vwr.list()
+}
+
+// RefreshView refreshes the materialized view of the given name right away,
+// regardless of its schedule, and provides its updated state.
+func (mgr *ServiceManager) RefreshView(name string) (*types.MaterializedView, error) {
+	log.Noticef("refresh of view %s requested", name)
+	return mgr.vwr.refreshView(name)
+}
+
 // SetBlockChannel registers a channel for notifying new block events.
 func (mgr *ServiceManager) SetBlockChannel(ch chan *types.Block) {
 	mgr.bld.onBlock = ch
@@ -112,6 +151,15 @@ func (mgr *ServiceManager) init() {
 	mgr.bls = &blkScanner{service: service{mgr: mgr}, cfg: cfg.RepoCommand}
 	mgr.svc = append(mgr.svc, mgr.bls)
 
+	// make ERC20 token supply snapshotter only if enabled
+	if cfg.SupplySnapshot.Interval > 0 {
+		mgr.sup = &supplySnapshotter{service: service{mgr: mgr}}
+		mgr.svc = append(mgr.svc, mgr.sup)
+	}
+
+	// make NFT holdings backfill
+	mgr.svc = append(mgr.svc, &nftBackfill{service: service{mgr: mgr}})
+
 	// make epoch scanner
 	mgr.svc = append(mgr.svc, &epochScanner{service: service{mgr: mgr}})
 
@@ -123,8 +171,14 @@ func (mgr *ServiceManager) init() {
 	// make gas price suggestion monitor
 	mgr.svc = append(mgr.svc, &gpsMonitor{service: service{mgr: mgr}})
 
-	// make transaction flow monitor
-	mgr.svc = append(mgr.svc, &trxFlowMonitor{service: service{mgr: mgr}})
+	// make materialized views refresher; DeFi token price snapshots are collected only if enabled
+	mgr.vwr = &viewRefresher{service: service{mgr: mgr}}
+	mgr.vwr.register(types.ViewTrxFlow, cfg.Views.TrxFlow, updateTrxFlow)
+	mgr.vwr.register(types.ViewTrxCount, cfg.Views.TrxCount, updateTrxCount)
+	if cfg.PriceSnapshot.Enabled {
+		mgr.vwr.register(types.ViewPrices, cfg.PriceSnapshot.Interval, newPriceSnapshotter().snapshot)
+	}
+	mgr.svc = append(mgr.svc, mgr.vwr)
 
 	// add orchestrator as the last service, so it can safely operate on all the other
 	mgr.ora = &orchestrator{service: service{mgr: mgr}}
@@ -0,0 +1,280 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// methodAbiRetry represents the delay after which the ABI of a watched contract is loaded
+// again if it was not available, e.g. the contract has not been validated yet.
+const methodAbiRetry = 10 * time.Minute
+
+// methodWatch represents a single watched contract method.
+type methodWatch struct {
+	selector [4]byte
+	name     string
+	args     abi.Arguments
+}
+
+// watchedAbi represents the parsed ABI of a watched contract; nil if not available.
+type watchedAbi struct {
+	abi    *abi.ABI
+	loaded time.Time
+}
+
+// methodWatcher matches transactions against the configured method watches
+// and extracts the calls to be recorded. The call arguments are decoded using
+// the ABI of the validated contract; the types of the watched method signature
+// are used if the contract ABI does not know the method.
+type methodWatcher struct {
+	watches map[common.Address]map[[4]byte]*methodWatch
+
+	// the ABI of the watched contracts
+	loadAbi func(*common.Address) (string, error)
+	mu      sync.Mutex
+	abis    map[common.Address]*watchedAbi
+}
+
+// newMethodWatcher creates a new method watcher for the given configuration.
+func newMethodWatcher(list []config.MethodWatch) *methodWatcher {
+	mw := methodWatcher{
+		watches: make(map[common.Address]map[[4]byte]*methodWatch),
+		loadAbi: storedContractAbi,
+		abis:    make(map[common.Address]*watchedAbi),
+	}
+	for _, w := range list {
+		m, err := parseMethodWatch(w.Method)
+		if err != nil {
+			log.Errorf("invalid method watch %s on %s; %s", w.Method, w.Contract.String(), err.Error())
+			continue
+		}
+
+		if _, ok := mw.watches[w.Contract]; !ok {
+			mw.watches[w.Contract] = make(map[[4]byte]*methodWatch)
+		}
+		mw.watches[w.Contract][m.selector] = m
+		log.Noticef("watching calls of %s on %s", w.Method, w.Contract.String())
+	}
+	return &mw
+}
+
+// parseMethodWatch parses the method given either by its signature, or by its selector.
+// If the signature arguments can not be parsed, the method is watched with raw arguments.
+func parseMethodWatch(method string) (*methodWatch, error) {
+	method = strings.ReplaceAll(method, " ", "")
+
+	// selector only
+	if strings.HasPrefix(method, "0x") {
+		sel, err := hexutil.Decode(method)
+		if err != nil || len(sel) != 4 {
+			return nil, fmt.Errorf("invalid method selector")
+		}
+		var m methodWatch
+		copy(m.selector[:], sel)
+		return &m, nil
+	}
+
+	// the signature
+	open := strings.Index(method, "(")
+	if open < 1 || !strings.HasSuffix(method, ")") {
+		return nil, fmt.Errorf("invalid method signature")
+	}
+
+	m := methodWatch{name: method[:open]}
+	copy(m.selector[:], crypto.Keccak256([]byte(method))[:4])
+
+	// decode argument types; unknown types leave the arguments raw
+	for _, t := range splitAbiTypes(method[open+1 : len(method)-1]) {
+		at, err := abi.NewType(t, "", nil)
+		if err != nil {
+			log.Warningf("method %s argument type %s not supported, raw arguments recorded", method, t)
+			m.args = nil
+			break
+		}
+		m.args = append(m.args, abi.Argument{Type: at})
+	}
+	return &m, nil
+}
+
+// splitAbiTypes splits the list of argument types of a method signature
+// respecting the nested tuple types.
+func splitAbiTypes(list string) []string {
+	if list == "" {
+		return nil
+	}
+
+	types := make([]string, 0)
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				types = append(types, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(types, list[start:])
+}
+
+// match checks the transaction against the method watches and provides the call
+// to be recorded, if the transaction calls a watched method. Only the calls made
+// by the transaction itself are matched; internal calls of other contracts
+// to the watched contract are not recorded.
+func (mw *methodWatcher) match(blk *types.Block, trx *types.Transaction) *types.MethodCall {
+	if trx.To == nil || len(trx.InputData) < 4 {
+		return nil
+	}
+
+	methods, ok := mw.watches[*trx.To]
+	if !ok {
+		return nil
+	}
+
+	var sel [4]byte
+	copy(sel[:], trx.InputData[:4])
+	m, ok := methods[sel]
+	if !ok {
+		return nil
+	}
+
+	call := types.MethodCall{
+		Transaction: trx.Hash,
+		Contract:    *trx.To,
+		From:        trx.From,
+		Selector:    sel[:],
+		Name:        m.name,
+		Value:       trx.Value,
+		BlockNumber: blk.Number,
+		TimeStamp:   blk.TimeStamp,
+	}
+	if trx.TrxIndex != nil {
+		call.TrxIndex = hexutil.Uint64(*trx.TrxIndex)
+	}
+
+	// prefer the contract ABI over the watched signature
+	candidates := make([]abi.Arguments, 0, 2)
+	if am := mw.abiMethod(trx.To, sel[:]); am != nil {
+		call.Name = am.RawName
+		candidates = append(candidates, am.Inputs)
+	}
+	if m.args != nil {
+		candidates = append(candidates, m.args)
+	}
+	call.Args, call.Decoded = decodeMethodArgs(call.Name, candidates, trx.InputData[4:])
+	return &call
+}
+
+// abiMethod provides the method of the given selector from the ABI of the contract, if known.
+func (mw *methodWatcher) abiMethod(contract *common.Address, sel []byte) *abi.Method {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	wa, ok := mw.abis[*contract]
+	if !ok || (wa.abi == nil && time.Since(wa.loaded) > methodAbiRetry) {
+		wa = &watchedAbi{abi: mw.parseAbi(contract), loaded: time.Now()}
+		mw.abis[*contract] = wa
+	}
+	if wa.abi == nil {
+		return nil
+	}
+
+	m, err := wa.abi.MethodById(sel)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// parseAbi loads and parses the ABI of the given contract; nil if not available.
+func (mw *methodWatcher) parseAbi(contract *common.Address) *abi.ABI {
+	src, err := mw.loadAbi(contract)
+	if err != nil {
+		log.Debugf("ABI of watched contract %s not available; %s", contract.String(), err.Error())
+		return nil
+	}
+	if src == "" {
+		return nil
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(src))
+	if err != nil {
+		log.Warningf("invalid ABI of watched contract %s; %s", contract.String(), err.Error())
+		return nil
+	}
+	return &parsed
+}
+
+// storedContractAbi provides the ABI of the validated contract stored in the repository.
+func storedContractAbi(addr *common.Address) (string, error) {
+	sc, err := repo.Contract(addr)
+	if err != nil || sc == nil {
+		return "", err
+	}
+	return sc.Abi, nil
+}
+
+// decodeMethodArgs decodes the call arguments by the first of the candidate argument lists
+// matching the call data; raw 32 bytes words are provided if the arguments are not known,
+// or the call data can not be decoded.
+func decodeMethodArgs(name string, candidates []abi.Arguments, data []byte) ([]string, bool) {
+	for _, list := range candidates {
+		values, err := list.UnpackValues(data)
+		if err == nil {
+			args := make([]string, len(values))
+			for i, v := range values {
+				args[i] = formatAbiValue(v)
+			}
+			return args, true
+		}
+		log.Debugf("can not decode %s call data; %s", name, err.Error())
+	}
+
+	args := make([]string, 0, (len(data)+31)/32)
+	for i := 0; i < len(data); i += 32 {
+		end := i + 32
+		if end > len(data) {
+			end = len(data)
+		}
+		args = append(args, hexutil.Encode(data[i:end]))
+	}
+	return args, false
+}
+
+// formatAbiValue formats a decoded ABI value to its string representation.
+func formatAbiValue(v interface{}) string {
+	switch val := v.(type) {
+	case *big.Int:
+		return val.String()
+	case common.Address:
+		return val.String()
+	case []byte:
+		return hexutil.Encode(val)
+	case string:
+		return val
+	}
+
+	// fixed size bytes
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,157 @@
+package svc
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"motif-api/internal/config"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testMethodAbi is the ABI of the watched test contract; it knows the transfer method only.
+const testMethodAbi = `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"type":"bool"}]}]`
+
+// testMethodContract is the address of the watched test contract.
+var testMethodContract = common.HexToAddress("0xc0")
+
+// testMethodWatcher creates a method watcher of the given methods on the test contract
+// using the given ABI loader; the number of the ABI loads is counted.
+func testMethodWatcher(src string, loads *int, methods ...string) *methodWatcher {
+	testSvcLogger()
+
+	list := make([]config.MethodWatch, len(methods))
+	for i, m := range methods {
+		list[i] = config.MethodWatch{Contract: testMethodContract, Method: m}
+	}
+	mw := newMethodWatcher(list)
+	mw.loadAbi = func(addr *common.Address) (string, error) {
+		*loads++
+		if *addr != testMethodContract {
+			return "", fmt.Errorf("unknown contract")
+		}
+		return src, nil
+	}
+	return mw
+}
+
+// testMethodTrx creates a transaction calling the given method with an address and an amount.
+func testMethodTrx(t *testing.T, to *common.Address, selector string) *types.Transaction {
+	addressType, _ := abi.NewType("address", "", nil)
+	uintType, _ := abi.NewType("uint256", "", nil)
+	data, err := abi.Arguments{{Type: addressType}, {Type: uintType}}.Pack(common.HexToAddress("0x01"), big.NewInt(5))
+	if err != nil {
+		t.Fatalf("can not pack call data; %s", err.Error())
+	}
+	return &types.Transaction{
+		Hash:      common.HexToHash("0xaa"),
+		To:        to,
+		InputData: append(hexutil.MustDecode(selector), data...),
+	}
+}
+
+// TestMethodWatcherMatch tests the calls are decoded by the contract ABI, or by the watched
+// signature, and the raw arguments are recorded only if the method is not known.
+func TestMethodWatcherMatch(t *testing.T) {
+	blk := &types.Block{Number: 10}
+	to := testMethodContract
+	decoded := []string{common.HexToAddress("0x01").String(), "5"}
+
+	tests := []struct {
+		name    string
+		abi     string
+		watch   string
+		call    string
+		method  string
+		args    []string
+		isKnown bool
+	}{
+		{"selector by ABI", testMethodAbi, "0xa9059cbb", "0xa9059cbb", "transfer", decoded, true},
+		{"selector without ABI", "", "0xa9059cbb", "0xa9059cbb", "", nil, false},
+		{"signature without ABI", "", "transfer(address,uint256)", "0xa9059cbb", "transfer", decoded, true},
+		{"signature not in ABI", testMethodAbi, "approve(address,uint256)", "0x095ea7b3", "approve", decoded, true},
+		{"selector not in ABI", testMethodAbi, "0x095ea7b3", "0x095ea7b3", "", nil, false},
+		{"invalid ABI", "{", "0xa9059cbb", "0xa9059cbb", "", nil, false},
+	}
+	for _, tc := range tests {
+		var loads int
+		mw := testMethodWatcher(tc.abi, &loads, tc.watch)
+		call := mw.match(blk, testMethodTrx(t, &to, tc.call))
+		if call == nil {
+			t.Errorf("%s: expected call matched", tc.name)
+			continue
+		}
+		if call.Name != tc.method || call.Decoded != tc.isKnown || call.Contract != to || call.BlockNumber != 10 {
+			t.Errorf("%s: unexpected call %+v", tc.name, call)
+		}
+		if tc.isKnown && fmt.Sprint(call.Args) != fmt.Sprint(tc.args) {
+			t.Errorf("%s: expected args %v, got %v", tc.name, tc.args, call.Args)
+		}
+		if !tc.isKnown && len(call.Args) != 2 {
+			t.Errorf("%s: expected 2 raw words, got %v", tc.name, call.Args)
+		}
+	}
+}
+
+// TestMethodWatcherSkip tests the calls not watched are not matched.
+func TestMethodWatcherSkip(t *testing.T) {
+	var loads int
+	mw := testMethodWatcher(testMethodAbi, &loads, "transfer(address,uint256)")
+	other := common.HexToAddress("0xc1")
+	to := testMethodContract
+
+	for name, trx := range map[string]*types.Transaction{
+		"other contract": testMethodTrx(t, &other, "0xa9059cbb"),
+		"other method":   testMethodTrx(t, &to, "0x095ea7b3"),
+		"contract call":  testMethodTrx(t, nil, "0xa9059cbb"),
+		"short input":    {To: &to, InputData: []byte{0xa9}},
+	} {
+		if call := mw.match(&types.Block{}, trx); call != nil {
+			t.Errorf("%s: unexpected call %+v", name, call)
+		}
+	}
+	if loads != 0 {
+		t.Errorf("expected no ABI loaded, got %d", loads)
+	}
+}
+
+// TestMethodWatcherAbiCache tests the contract ABI is loaded once, and a missing ABI
+// is not loaded again before the retry delay.
+func TestMethodWatcherAbiCache(t *testing.T) {
+	to := testMethodContract
+	for _, src := range []string{testMethodAbi, ""} {
+		var loads int
+		mw := testMethodWatcher(src, &loads, "transfer(address,uint256)")
+		for i := 0; i < 3; i++ {
+			mw.match(&types.Block{}, testMethodTrx(t, &to, "0xa9059cbb"))
+		}
+		if loads != 1 {
+			t.Errorf("expected single ABI load, got %d", loads)
+		}
+	}
+
+	// the missing ABI is retried after the delay
+	var loads int
+	mw := testMethodWatcher("", &loads, "transfer(address,uint256)")
+	mw.match(&types.Block{}, testMethodTrx(t, &to, "0xa9059cbb"))
+	mw.abis[to].loaded = mw.abis[to].loaded.Add(-methodAbiRetry - 1)
+	mw.match(&types.Block{}, testMethodTrx(t, &to, "0xa9059cbb"))
+	if loads != 2 {
+		t.Errorf("expected ABI load retried, got %d loads", loads)
+	}
+}
+
+// TestSplitAbiTypes tests the method signature arguments are split respecting tuples.
+func TestSplitAbiTypes(t *testing.T) {
+	got := splitAbiTypes("address,(uint256,(bool,bytes)),uint8[]")
+	want := []string{"address", "(uint256,(bool,bytes))", "uint8[]"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if splitAbiTypes("") != nil {
+		t.Errorf("expected no types")
+	}
+}
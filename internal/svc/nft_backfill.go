@@ -0,0 +1,72 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// nftBackfillBatchSize represents the number of NFT transfers applied in one batch.
+	nftBackfillBatchSize = 500
+
+	// nftBackfillRetryDelay represents the delay before a failed batch is retried.
+	nftBackfillRetryDelay = 30 * time.Second
+)
+
+// nftBackfill implements a service applying the NFT transfers indexed before the NFT
+// ownership was tracked to the NFT holdings. The service terminates once all the transfers
+// are applied; the transfers indexed live are applied as they are stored.
+type nftBackfill struct {
+	service
+}
+
+// name returns the name of the service used by orchestrator.
+func (nb *nftBackfill) name() string {
+	return "NFT holdings backfill"
+}
+
+// run starts the NFT holdings backfill
+func (nb *nftBackfill) run() {
+	// make sure we are orchestrated
+	if nb.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", nb.name()))
+	}
+
+	// signal orchestrator we started and go
+	nb.mgr.started(nb)
+	go nb.execute()
+}
+
+// execute applies the batches of the NFT transfers until there is none left.
+func (nb *nftBackfill) execute() {
+	defer nb.mgr.finished(nb)
+
+	var cursor *string
+	var batches int
+	for {
+		select {
+		case <-nb.sigStop:
+			return
+		default:
+		}
+
+		next, err := repo.BackfillNftHoldings(cursor, nftBackfillBatchSize)
+		if err != nil {
+			log.Errorf("NFT holdings backfill failed; %s", err.Error())
+			select {
+			case <-nb.sigStop:
+				return
+			case <-time.After(nftBackfillRetryDelay):
+				continue
+			}
+		}
+
+		batches++
+		if next == nil {
+			log.Noticef("NFT holdings backfill done in %d batches", batches)
+			return
+		}
+		cursor = next
+	}
+}
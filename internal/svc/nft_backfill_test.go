@@ -0,0 +1,100 @@
+package svc
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"motif-api/internal/repository"
+)
+
+// testBackfillRepo implements the repository calls of the NFT holdings backfill.
+type testBackfillRepo struct {
+	repository.Repository
+	batches int
+	fail    int
+	cursors []string
+}
+
+// BackfillNftHoldings provides the given number of batches; the first calls fail, if requested.
+func (tr *testBackfillRepo) BackfillNftHoldings(cursor *string, count int64) (*string, error) {
+	if tr.fail > 0 {
+		tr.fail--
+		return nil, fmt.Errorf("backfill failed")
+	}
+
+	cur := "nil"
+	if cursor != nil {
+		cur = *cursor
+	}
+	tr.cursors = append(tr.cursors, cur)
+
+	if len(tr.cursors) >= tr.batches {
+		return nil, nil
+	}
+	next := fmt.Sprintf("0x%02x", len(tr.cursors)*int(count))
+	return &next, nil
+}
+
+// testBackfill starts the NFT holdings backfill against the given repository.
+func testBackfill(t *testing.T, tr *testBackfillRepo) *nftBackfill {
+	testSvcLogger()
+	prev := repo
+	repo = tr
+	t.Cleanup(func() { repo = prev })
+
+	nb := &nftBackfill{service: service{mgr: &ServiceManager{wg: new(sync.WaitGroup)}}}
+	nb.init()
+	return nb
+}
+
+// testBackfillWait waits for the backfill to terminate.
+func testBackfillWait(t *testing.T, nb *nftBackfill) {
+	done := make(chan struct{})
+	go func() {
+		nb.mgr.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("backfill not terminated")
+	}
+}
+
+// TestNftBackfill tests the backfill follows the cursors until no transfers are left.
+func TestNftBackfill(t *testing.T) {
+	tr := &testBackfillRepo{batches: 3}
+	nb := testBackfill(t, tr)
+	nb.run()
+	testBackfillWait(t, nb)
+
+	want := []string{"nil", fmt.Sprintf("0x%02x", nftBackfillBatchSize), fmt.Sprintf("0x%02x", 2*nftBackfillBatchSize)}
+	if fmt.Sprint(tr.cursors) != fmt.Sprint(want) {
+		t.Errorf("expected cursors %v, got %v", want, tr.cursors)
+	}
+}
+
+// TestNftBackfillStop tests the backfill terminates on the stop signal, even if waiting for a retry.
+func TestNftBackfillStop(t *testing.T) {
+	tr := &testBackfillRepo{batches: 3}
+	nb := testBackfill(t, tr)
+	nb.close()
+	nb.run()
+	testBackfillWait(t, nb)
+	if len(tr.cursors) != 0 {
+		t.Errorf("expected no batch after stop, got %v", tr.cursors)
+	}
+
+	tr = &testBackfillRepo{batches: 3, fail: 1}
+	nb = testBackfill(t, tr)
+	nb.run()
+	time.Sleep(50 * time.Millisecond)
+	nb.close()
+	testBackfillWait(t, nb)
+	if tr.fail != 0 || len(tr.cursors) != 0 {
+		t.Errorf("expected failed batch not retried before stop, got %d / %v", tr.fail, tr.cursors)
+	}
+}
@@ -38,6 +38,9 @@ func (or *orchestrator) init() {
 	or.mgr.bld.inBlock = or.mgr.bls.outBlock
 	or.mgr.bls.inDispatched = or.mgr.bld.outDispatched
 	or.inScanStateSwitch = or.mgr.bls.outStateSwitch
+	if or.mgr.sup != nil {
+		or.mgr.sup.inBlock = or.mgr.bld.outSupply
+	}
 
 	// read initial block scanner state
 	// no need to worry about race condition, init() is called sequentially and this is the last one
@@ -0,0 +1,186 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// reorgWindowSize represents the number of recent block hashes
+// kept to detect chain reorganizations.
+const reorgWindowSize = 256
+
+// reorgDetector tracks hashes of recently dispatched blocks
+// and detects chain reorganizations replacing them.
+type reorgDetector struct {
+	hashes map[uint64]common.Hash
+	top    uint64
+
+	// block loads the new canonical blocks by hash
+	block func(*common.Hash) (*types.Block, error)
+}
+
+// newReorgDetector creates a new chain reorg detector.
+func newReorgDetector() *reorgDetector {
+	return &reorgDetector{
+		hashes: make(map[uint64]common.Hash, reorgWindowSize),
+		block: func(hash *common.Hash) (*types.Block, error) {
+			return repo.BlockByHash(hash)
+		},
+	}
+}
+
+// check verifies the given block extends the known chain. If the block replaces
+// known blocks, it provides the reorg record and the new canonical blocks
+// below the given block which were not dispatched yet, ordered from the oldest.
+func (rd *reorgDetector) check(blk *types.Block) (*types.Reorg, []*types.Block) {
+	num := uint64(blk.Number)
+	var re *types.Reorg
+	var blocks []*types.Block
+
+	// the block itself replaces a known block on the same height?
+	if old, ok := rd.hashes[num]; ok && old != blk.Hash {
+		re = &types.Reorg{FromBlock: blk.Number, ToBlock: blk.Number, OldHead: old, NewHead: blk.Hash, Depth: 1}
+	}
+
+	// walk the new chain down until we reach a known ancestor;
+	// h is the height of the parent block
+	parent := blk.ParentHash
+	for h := int64(num) - 1; h >= 0; h-- {
+		old, ok := rd.hashes[uint64(h)]
+		if !ok || old == parent {
+			break
+		}
+
+		// load the new canonical block on this height
+		pb, err := rd.block(&parent)
+		if err != nil {
+			log.Errorf("can not load reorg block %s; %s", parent.String(), err.Error())
+			break
+		}
+		blocks = append([]*types.Block{pb}, blocks...)
+
+		// extend the reorg record
+		if re == nil {
+			re = &types.Reorg{ToBlock: hexutil.Uint64(h), OldHead: old, NewHead: parent}
+		}
+		re.FromBlock = hexutil.Uint64(h)
+		re.Depth++
+
+		parent = pb.ParentHash
+	}
+
+	// remember the new chain
+	for _, b := range blocks {
+		rd.add(b)
+	}
+	rd.add(blk)
+
+	if re != nil {
+		now := time.Now().UTC()
+		re.Id = hexutil.Uint64(now.UnixNano())
+		re.Detected = now
+	}
+	return re, blocks
+}
+
+// add remembers the hash of the given block and drops hashes out of the window.
+func (rd *reorgDetector) add(blk *types.Block) {
+	num := uint64(blk.Number)
+	rd.hashes[num] = blk.Hash
+
+	if num <= rd.top {
+		return
+	}
+	rd.top = num
+
+	// drop old hashes
+	for n := range rd.hashes {
+		if n+reorgWindowSize < rd.top {
+			delete(rd.hashes, n)
+		}
+	}
+}
+
+// revertBlocks removes the indexed transactions and events of the given range of blocks
+// replaced by a chain reorg, so the new canonical blocks can be indexed.
+func revertBlocks(fromBlock uint64, toBlock uint64) {
+	if err := repo.RevertTransactions(fromBlock, toBlock); err != nil {
+		log.Errorf("can not revert transactions; %s", err.Error())
+	}
+	if err := repo.RevertTokenTransactions(fromBlock, toBlock); err != nil {
+		log.Errorf("can not revert token transactions; %s", err.Error())
+	}
+	if err := repo.RevertStakeChanges(fromBlock, toBlock); err != nil {
+		log.Errorf("can not revert stake changes; %s", err.Error())
+	}
+	if err := repo.RevertMethodCalls(fromBlock, toBlock); err != nil {
+		log.Errorf("can not revert method calls; %s", err.Error())
+	}
+	if err := repo.RevertLogs(fromBlock, toBlock); err != nil {
+		log.Errorf("can not revert indexed logs; %s", err.Error())
+	}
+}
+
+// storedReorg checks the indexed blocks below the given last known block against the current
+// chain of the node and provides the reorg replacing them, if any. The check walks down from
+// the last known block until it finds a block with the same hash, at most maxDepth blocks.
+func storedReorg(lnb uint64, maxDepth uint64) (*types.Reorg, error) {
+	if maxDepth == 0 || lnb == 0 {
+		return nil, nil
+	}
+
+	from := uint64(0)
+	if lnb >= maxDepth {
+		from = lnb - maxDepth + 1
+	}
+	known, err := repo.BlockHashes(from, lnb)
+	if err != nil {
+		return nil, err
+	}
+	return divergence(known, from, lnb, func(num uint64) (common.Hash, error) {
+		n := hexutil.Uint64(num)
+		blk, err := repo.BlockByNumber(&n)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return blk.Hash, nil
+	})
+}
+
+// divergence walks the known block hashes from the top block down and compares them with
+// the current chain hashes until the first match. The range of the mismatched blocks is
+// provided as the reorg; nil if the top known block matches.
+func divergence(known map[uint64]common.Hash, from uint64, to uint64, current func(uint64) (common.Hash, error)) (*types.Reorg, error) {
+	var re *types.Reorg
+	for num := to; num >= from; num-- {
+		old, ok := known[num]
+		if ok {
+			now, err := current(num)
+			if err != nil {
+				return nil, err
+			}
+			if now == old {
+				break
+			}
+
+			if re == nil {
+				re = &types.Reorg{ToBlock: hexutil.Uint64(num), OldHead: old, NewHead: now}
+			}
+			re.FromBlock = hexutil.Uint64(num)
+		}
+		if num == 0 {
+			break
+		}
+	}
+
+	if re != nil {
+		now := time.Now().UTC()
+		re.Id = hexutil.Uint64(now.UnixNano())
+		re.Depth = re.ToBlock - re.FromBlock + 1
+		re.Detected = now
+	}
+	return re, nil
+}
@@ -0,0 +1,303 @@
+package svc
+
+import (
+	"fmt"
+	"testing"
+
+	"motif-api/internal/config"
+	"motif-api/internal/logger"
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TestDivergence tests the indexed blocks replaced by a chain reorg are detected
+// down to the first block matching the current chain.
+func TestDivergence(t *testing.T) {
+	known := map[uint64]common.Hash{
+		10: common.HexToHash("0x0a"),
+		12: common.HexToHash("0x0c"),
+		13: common.HexToHash("0x0d"),
+		15: common.HexToHash("0x0f"),
+	}
+
+	// blocks above #12 were replaced
+	var calls int
+	current := func(num uint64) (common.Hash, error) {
+		calls++
+		if num > 12 {
+			return common.BigToHash(common.Big1), nil
+		}
+		return known[num], nil
+	}
+
+	re, err := divergence(known, 5, 16, current)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	if re == nil || re.FromBlock != 13 || re.ToBlock != 15 || re.Depth != 3 || re.OldHead != known[15] {
+		t.Fatalf("unexpected reorg %+v", re)
+	}
+	if calls != 3 {
+		t.Errorf("expected the walk to stop at the first match, got %d calls", calls)
+	}
+
+	// no reorg
+	re, err = divergence(known, 5, 16, func(num uint64) (common.Hash, error) { return known[num], nil })
+	if err != nil || re != nil {
+		t.Errorf("expected no reorg, got %+v; %v", re, err)
+	}
+
+	// all the blocks down to the genesis replaced
+	re, err = divergence(known, 0, 16, func(uint64) (common.Hash, error) { return common.Hash{}, nil })
+	if err != nil || re == nil || re.FromBlock != 10 {
+		t.Errorf("expected reorg from #10, got %+v; %v", re, err)
+	}
+}
+
+// testSvcLogger sets the services logger, if not set yet.
+func testSvcLogger() {
+	if log == nil {
+		SetLogger(logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}))
+	}
+}
+
+// testChain builds a chain of blocks from the genesis to the given top;
+// the fork byte makes the hashes of the chain distinct from other forks.
+func testChain(top uint64, fork byte) []*types.Block {
+	chain := make([]*types.Block, top+1)
+	for n := uint64(0); n <= top; n++ {
+		chain[n] = &types.Block{Number: hexutil.Uint64(n), Hash: common.BytesToHash([]byte{fork, byte(n >> 8), byte(n)})}
+		if n > 0 {
+			chain[n].ParentHash = chain[n-1].Hash
+		}
+	}
+	return chain
+}
+
+// testReorgDetector creates a detector loading blocks from the given chain by hash.
+func testReorgDetector(chain []*types.Block, loads *int) *reorgDetector {
+	testSvcLogger()
+	rd := newReorgDetector()
+	rd.block = func(hash *common.Hash) (*types.Block, error) {
+		*loads++
+		for _, b := range chain {
+			if b.Hash == *hash {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("block %s not found", hash.String())
+	}
+	return rd
+}
+
+// TestReorgDetectorCheck tests the live reorg detection on dispatched blocks.
+func TestReorgDetectorCheck(t *testing.T) {
+	old, fork := testChain(10, 0xa), testChain(10, 0xb)
+
+	// the new fork shares the blocks up to #6 with the old chain
+	for n := 0; n <= 6; n++ {
+		fork[n] = old[n]
+	}
+	fork[7].ParentHash = old[6].Hash
+
+	var loads int
+	rd := testReorgDetector(fork, &loads)
+	for _, b := range old[:10] {
+		if re, blocks := rd.check(b); re != nil || len(blocks) != 0 {
+			t.Fatalf("unexpected reorg on the linear chain at #%d; %+v", b.Number, re)
+		}
+	}
+
+	// the new block #10 replaces #7 - #9 of the old chain
+	re, blocks := rd.check(fork[10])
+	if re == nil || re.FromBlock != 7 || re.ToBlock != 9 || re.Depth != 3 {
+		t.Fatalf("unexpected reorg %+v", re)
+	}
+	if re.OldHead != old[9].Hash || re.NewHead != fork[9].Hash {
+		t.Errorf("unexpected reorg heads %s / %s", re.OldHead.String(), re.NewHead.String())
+	}
+	if len(blocks) != 3 || blocks[0] != fork[7] || blocks[2] != fork[9] {
+		t.Fatalf("expected new blocks #7 - #9, got %v", blocks)
+	}
+	if loads != 3 {
+		t.Errorf("expected 3 block loads, got %d", loads)
+	}
+
+	// the new chain is remembered
+	for n := 7; n <= 10; n++ {
+		if rd.hashes[uint64(n)] != fork[n].Hash {
+			t.Errorf("expected new hash on #%d", n)
+		}
+	}
+	if re, _ := rd.check(&types.Block{Number: 11, Hash: common.HexToHash("0x11"), ParentHash: fork[10].Hash}); re != nil {
+		t.Errorf("unexpected reorg on the new chain; %+v", re)
+	}
+}
+
+// TestReorgDetectorSameHeight tests a block replacing a known block on the same height.
+func TestReorgDetectorSameHeight(t *testing.T) {
+	old, fork := testChain(5, 0xa), testChain(5, 0xb)
+	fork[5].ParentHash = old[4].Hash
+
+	var loads int
+	rd := testReorgDetector(fork, &loads)
+	for _, b := range old {
+		rd.check(b)
+	}
+
+	re, blocks := rd.check(fork[5])
+	if re == nil || re.FromBlock != 5 || re.ToBlock != 5 || re.Depth != 1 || re.OldHead != old[5].Hash || re.NewHead != fork[5].Hash {
+		t.Fatalf("unexpected reorg %+v", re)
+	}
+	if len(blocks) != 0 || loads != 0 {
+		t.Errorf("expected no blocks to load, got %d blocks, %d loads", len(blocks), loads)
+	}
+}
+
+// TestReorgDetectorGenesis tests the walk stops at the genesis block if the whole chain is replaced.
+func TestReorgDetectorGenesis(t *testing.T) {
+	old, fork := testChain(3, 0xa), testChain(4, 0xb)
+
+	var loads int
+	rd := testReorgDetector(fork, &loads)
+	for _, b := range old {
+		rd.check(b)
+	}
+
+	re, blocks := rd.check(fork[4])
+	if re == nil || re.FromBlock != 0 || re.ToBlock != 3 || re.Depth != 4 {
+		t.Fatalf("unexpected reorg %+v", re)
+	}
+	if len(blocks) != 4 || blocks[0] != fork[0] || loads != 4 {
+		t.Errorf("expected blocks #0 - #3 loaded once each, got %d blocks, %d loads", len(blocks), loads)
+	}
+}
+
+// TestReorgDetectorLoadFailure tests the walk stops at a block which can not be loaded.
+func TestReorgDetectorLoadFailure(t *testing.T) {
+	old, fork := testChain(6, 0xa), testChain(6, 0xb)
+	fork[3].ParentHash = old[2].Hash
+
+	// the fork block #4 is not available
+	var loads int
+	rd := testReorgDetector(append(append([]*types.Block{}, fork[:4]...), fork[5:]...), &loads)
+	for _, b := range old[:6] {
+		rd.check(b)
+	}
+
+	re, blocks := rd.check(fork[6])
+	if re == nil || re.FromBlock != 5 || re.ToBlock != 5 || re.Depth != 1 {
+		t.Fatalf("unexpected reorg %+v", re)
+	}
+	if len(blocks) != 1 || blocks[0] != fork[5] {
+		t.Errorf("expected only block #5, got %v", blocks)
+	}
+}
+
+// TestReorgDetectorWindow tests the hashes out of the window are dropped.
+func TestReorgDetectorWindow(t *testing.T) {
+	chain := testChain(reorgWindowSize+10, 0xa)
+
+	var loads int
+	rd := testReorgDetector(chain, &loads)
+	for _, b := range chain {
+		rd.check(b)
+	}
+
+	if len(rd.hashes) != reorgWindowSize+1 {
+		t.Errorf("expected %d hashes in the window, got %d", reorgWindowSize+1, len(rd.hashes))
+	}
+	if _, ok := rd.hashes[9]; ok {
+		t.Errorf("expected old hashes to be dropped")
+	}
+}
+
+// testReorgRepo implements the repository calls of the live reorg handling.
+type testReorgRepo struct {
+	repository.Repository
+	reorgs   []*types.Reorg
+	reverted []string
+}
+
+// AddReorg records the reorg.
+func (tr *testReorgRepo) AddReorg(re *types.Reorg) error {
+	tr.reorgs = append(tr.reorgs, re)
+	return nil
+}
+
+// RevertTransactions records the reverted range.
+func (tr *testReorgRepo) RevertTransactions(from uint64, to uint64) error {
+	tr.reverted = append(tr.reverted, fmt.Sprintf("trx %d-%d", from, to))
+	return nil
+}
+
+// RevertTokenTransactions records the reverted range.
+func (tr *testReorgRepo) RevertTokenTransactions(from uint64, to uint64) error {
+	tr.reverted = append(tr.reverted, fmt.Sprintf("token %d-%d", from, to))
+	return nil
+}
+
+// RevertStakeChanges records the reverted range.
+func (tr *testReorgRepo) RevertStakeChanges(from uint64, to uint64) error {
+	tr.reverted = append(tr.reverted, fmt.Sprintf("stake %d-%d", from, to))
+	return nil
+}
+
+// RevertMethodCalls records the reverted range.
+func (tr *testReorgRepo) RevertMethodCalls(from uint64, to uint64) error {
+	tr.reverted = append(tr.reverted, fmt.Sprintf("calls %d-%d", from, to))
+	return nil
+}
+
+// RevertLogs records the reverted range.
+func (tr *testReorgRepo) RevertLogs(from uint64, to uint64) error {
+	tr.reverted = append(tr.reverted, fmt.Sprintf("logs %d-%d", from, to))
+	return nil
+}
+
+// TestHandleReorg tests the live reorg is recorded, the replaced blocks are reverted
+// and the new canonical blocks are dispatched from the oldest.
+func TestHandleReorg(t *testing.T) {
+	tr := &testReorgRepo{}
+	prev := repo
+	repo = tr
+	t.Cleanup(func() { repo = prev })
+
+	old, fork := testChain(8, 0xa), testChain(8, 0xb)
+	for n := 0; n <= 5; n++ {
+		fork[n] = old[n]
+	}
+	fork[6].ParentHash = old[5].Hash
+
+	var loads int
+	bld := blockDispatcher{outDispatched: make(chan uint64, 16), reorg: testReorgDetector(fork, &loads)}
+	bld.sigStop = make(chan bool, 1)
+	for _, b := range old[:8] {
+		if !bld.handleReorg(b) {
+			t.Fatalf("unexpected termination at #%d", b.Number)
+		}
+	}
+	if len(tr.reorgs) != 0 || len(bld.outDispatched) != 0 {
+		t.Fatalf("unexpected reorg on the linear chain")
+	}
+
+	// #8 of the fork replaces #6 and #7
+	if !bld.handleReorg(fork[8]) {
+		t.Fatalf("unexpected termination")
+	}
+	if len(tr.reorgs) != 1 || tr.reorgs[0].FromBlock != 6 || tr.reorgs[0].ToBlock != 7 {
+		t.Fatalf("unexpected reorg records %+v", tr.reorgs)
+	}
+
+	want := []string{"trx 6-7", "token 6-7", "stake 6-7", "calls 6-7", "logs 6-7"}
+	if fmt.Sprint(tr.reverted) != fmt.Sprint(want) {
+		t.Errorf("expected reverted %v, got %v", want, tr.reverted)
+	}
+
+	// the new blocks below #8 are dispatched in order; #8 itself is dispatched by the caller
+	if len(bld.outDispatched) != 2 || <-bld.outDispatched != 6 || <-bld.outDispatched != 7 {
+		t.Errorf("expected new blocks #6 and #7 dispatched")
+	}
+}
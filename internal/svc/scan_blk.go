@@ -6,6 +6,7 @@ import (
 	"motif-api/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/atomic"
 	"time"
 )
 
@@ -36,6 +37,9 @@ type blkScanner struct {
 	outBlock       chan *types.Block
 	outStateSwitch chan bool
 	inDispatched   chan uint64
+	sigHalt        chan bool
+	halted         chan struct{}
+	stopped        *atomic.Bool
 	observeTick    *time.Ticker
 	scanTick       *time.Ticker
 	onIdle         bool
@@ -54,6 +58,9 @@ func (bls *blkScanner) name() string {
 func (bls *blkScanner) init() {
 	bls.onIdle = false
 	bls.sigStop = make(chan bool, 1)
+	bls.sigHalt = make(chan bool, 1)
+	bls.halted = make(chan struct{})
+	bls.stopped = atomic.NewBool(false)
 	bls.outStateSwitch = make(chan bool, 1)
 	bls.outBlock = make(chan *types.Block, blsBlockBufferCapacity)
 }
@@ -71,6 +78,8 @@ func (bls *blkScanner) run() {
 	start, err := bls.boundaries()
 	if err != nil {
 		log.Errorf("scanner can not proceed; %s", err.Error())
+		bls.stopped.Store(true)
+		close(bls.halted)
 		return
 	}
 
@@ -85,10 +94,6 @@ func (bls *blkScanner) run() {
 
 // close terminates the block dispatcher.
 func (bls *blkScanner) close() {
-	if bls.scanTick != nil {
-		bls.scanTick.Stop()
-		bls.observeTick.Stop()
-	}
 	if bls.sigStop != nil {
 		bls.sigStop <- true
 	}
@@ -103,22 +108,106 @@ func (bls *blkScanner) boundaries() (uint64, error) {
 		return 0, err
 	}
 
+	// detect a chain reorg replacing the indexed blocks while we were down
+	start := lnb
+	re, err := storedReorg(lnb, bls.cfg.BlockScanMaxReorg)
+	if err != nil {
+		log.Errorf("can not check indexed blocks for a chain reorg; %s", err.Error())
+	}
+	if re != nil {
+		log.Warningf("chain reorg of %d blocks detected at <#%d, #%d>", uint64(re.Depth), uint64(re.FromBlock), uint64(re.ToBlock))
+		if uint64(re.Depth) >= bls.cfg.BlockScanMaxReorg {
+			log.Warningf("chain reorg may reach below the max reorg depth of %d blocks", bls.cfg.BlockScanMaxReorg)
+		}
+		if err := repo.AddReorg(re); err != nil {
+			log.Errorf("can not record chain reorg; %s", err.Error())
+		}
+
+		// drop the orphaned data and re-scan from the divergence point
+		revertBlocks(uint64(re.FromBlock), lnb)
+		start = uint64(re.FromBlock)
+	}
+
 	// apply re-scan
-	if lnb > bls.cfg.BlockScanReScan {
+	if lnb > bls.cfg.BlockScanReScan && lnb-bls.cfg.BlockScanReScan < start {
 		log.Debugf("last known block is #%d, re-scanning %d blocks", lnb, bls.cfg.BlockScanReScan)
-		lnb = lnb - bls.cfg.BlockScanReScan
+		start = lnb - bls.cfg.BlockScanReScan
 	}
-	return lnb, nil
+	return start, nil
+}
+
+// restart halts the scan loop, moves the scanner to the given block and starts
+// scanning again. If the block is not given, the scan continues from the last known block
+// the same way it does on the service start. The output channels stay open
+// so the connected services are not affected by the restart.
+func (bls *blkScanner) restart(from *uint64) error {
+	// signal the scan loop to halt, unless a halt is already pending, and wait for it
+	select {
+	case bls.sigHalt <- true:
+	default:
+	}
+	<-bls.halted
+
+	// the scanner may have been terminated in the meantime
+	if bls.stopped.Load() {
+		return fmt.Errorf("%s is not running", bls.name())
+	}
+
+	start, err := bls.restartBlock(from)
+	if err != nil {
+		log.Errorf("can not reset the scanner; %s", err.Error())
+		start = bls.next
+	}
+
+	// reset the scanner state and make sure the orchestrator
+	// caches new heads while we scan the range again
+	log.Noticef("block scan restarts at #%d", start)
+	bls.from = start
+	bls.next = start
+	bls.done = 0
+	bls.onIdle = false
+	select {
+	case bls.outStateSwitch <- false:
+	case <-bls.sigStop:
+		bls.sigStop <- true
+	}
+
+	bls.halted = make(chan struct{})
+	go bls.execute()
+	return nil
+}
+
+// restartBlock provides the block the scanner restarts at. If the block is given,
+// the last known block checkpoint is reset to it so the progress persists.
+func (bls *blkScanner) restartBlock(from *uint64) (uint64, error) {
+	if from == nil {
+		return bls.boundaries()
+	}
+
+	if err := repo.UpdateLastKnownBlock((*hexutil.Uint64)(from)); err != nil {
+		return 0, err
+	}
+	bls.mgr.trd.blkObserver.Store(*from)
+	return *from, nil
 }
 
 // execute scans blockchain blocks in the given range and push found blocks
-// to the output channel for processing.
+// to the output channel for processing. The scan loop can be halted for a restart,
+// in which case the output channels are left open.
 func (bls *blkScanner) execute() {
+	var halt bool
 	defer func() {
-		close(bls.sigStop)
-		close(bls.outBlock)
-		close(bls.outStateSwitch)
-		bls.mgr.finished(bls)
+		bls.scanTick.Stop()
+		bls.observeTick.Stop()
+
+		if !halt {
+			bls.stopped.Store(true)
+			close(bls.sigStop)
+			close(bls.outBlock)
+			close(bls.outStateSwitch)
+			bls.mgr.finished(bls)
+		}
+		close(bls.halted)
 	}()
 
 	// set initial state and start the tickers for observer and scanner
@@ -131,6 +220,9 @@ func (bls *blkScanner) execute() {
 		select {
 		case <-bls.sigStop:
 			return
+		case <-bls.sigHalt:
+			halt = true
+			return
 		case bin, ok := <-bls.inDispatched:
 			// ignore block re-scans; do not skip blocks in dispatched # counter
 			if ok && (bls.done == 0 || int64(bin)-int64(bls.done) == 1) {
@@ -188,6 +280,9 @@ func (bls *blkScanner) updateState(target bool) {
 	case <-bls.sigStop:
 		bls.sigStop <- true
 		return
+	case <-bls.sigHalt:
+		bls.sigHalt <- true
+		return
 	}
 
 	// going full speed
@@ -229,5 +324,7 @@ func (bls *blkScanner) shift() {
 		bls.next++
 	case <-bls.sigStop:
 		bls.sigStop <- true
+	case <-bls.sigHalt:
+		bls.sigHalt <- true
 	}
 }
@@ -0,0 +1,237 @@
+package svc
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"motif-api/internal/repository"
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/atomic"
+)
+
+// testScanRepo implements the repository calls of the block scanner.
+type testScanRepo struct {
+	repository.Repository
+	head uint64
+
+	mu      sync.Mutex
+	lnb     uint64
+	updated []uint64
+	hold    chan struct{}
+}
+
+// BlockHeight provides the head of the test chain.
+func (tr *testScanRepo) BlockHeight() (*hexutil.Big, error) {
+	return (*hexutil.Big)(hexutil.MustDecodeBig(hexutil.EncodeUint64(tr.head))), nil
+}
+
+// LastKnownBlock provides the last known block checkpoint.
+func (tr *testScanRepo) LastKnownBlock() (uint64, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.lnb, nil
+}
+
+// UpdateLastKnownBlock records the checkpoint; it waits for the release if held.
+func (tr *testScanRepo) UpdateLastKnownBlock(num *hexutil.Uint64) error {
+	tr.mu.Lock()
+	hold := tr.hold
+	tr.mu.Unlock()
+	if hold != nil {
+		<-hold
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.lnb = uint64(*num)
+	tr.updated = append(tr.updated, uint64(*num))
+	return nil
+}
+
+// BlockByNumber provides an empty block of the given number.
+func (tr *testScanRepo) BlockByNumber(num *hexutil.Uint64) (*types.Block, error) {
+	return &types.Block{Number: *num}, nil
+}
+
+// testScan represents a running block scanner and the blocks it emitted.
+type testScan struct {
+	mgr *ServiceManager
+	bls *blkScanner
+
+	mu     sync.Mutex
+	blocks []uint64
+}
+
+// testScanner starts the block scanner over the given repository;
+// the emitted blocks and the state switches are consumed in the background.
+func testScanner(t *testing.T, tr *testScanRepo) *testScan {
+	testSvcLogger()
+	prev := repo
+	repo = tr
+	t.Cleanup(func() { repo = prev })
+
+	ts := testScan{mgr: &ServiceManager{wg: new(sync.WaitGroup), trd: &trxDispatcher{blkObserver: atomic.NewUint64(1)}}}
+	ts.bls = &blkScanner{service: service{mgr: ts.mgr}, inDispatched: make(chan uint64)}
+	ts.mgr.bls = ts.bls
+	ts.bls.init()
+
+	// the channels are replaced by the scanner init only, capture them before the run
+	blocks, switches := ts.bls.outBlock, ts.bls.outStateSwitch
+	go func() {
+		for blk := range blocks {
+			ts.mu.Lock()
+			ts.blocks = append(ts.blocks, uint64(blk.Number))
+			ts.mu.Unlock()
+		}
+	}()
+	go func() {
+		for range switches {
+		}
+	}()
+
+	ts.bls.run()
+	return &ts
+}
+
+// emitted checks if the scanner emitted the given block after the given number of blocks.
+func (ts *testScan) emitted(after int, num uint64) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for i := after; i < len(ts.blocks); i++ {
+		if ts.blocks[i] == num {
+			return true
+		}
+	}
+	return false
+}
+
+// count provides the number of emitted blocks.
+func (ts *testScan) count() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return len(ts.blocks)
+}
+
+// waitFor polls the condition until it's met, or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// stop closes the scanner and waits for it to terminate.
+func (ts *testScan) stop(t *testing.T) {
+	ts.bls.close()
+	done := make(chan struct{})
+	go func() {
+		ts.mgr.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("scanner not terminated")
+	}
+}
+
+// TestScannerRestart tests the scanner restarted during a scan continues from the given block.
+func TestScannerRestart(t *testing.T) {
+	tr := &testScanRepo{head: 1 << 20, lnb: 100}
+	ts := testScanner(t, tr)
+	waitFor(t, "scan progress", func() bool { return ts.count() > 10 })
+
+	from := uint64(5)
+	after := ts.count()
+	if err := ts.mgr.RestartScanner(&from); err != nil {
+		t.Fatalf("restart failed; %s", err.Error())
+	}
+	waitFor(t, "block #5 re-scanned", func() bool { return ts.emitted(after, 5) })
+
+	tr.mu.Lock()
+	if len(tr.updated) != 1 || tr.updated[0] != 5 {
+		t.Errorf("expected checkpoint moved to #5, got %v", tr.updated)
+	}
+	tr.mu.Unlock()
+	if ts.mgr.trd.blkObserver.Load() != 5 {
+		t.Errorf("expected observer moved to #5, got %d", ts.mgr.trd.blkObserver.Load())
+	}
+
+	// restart without the block resumes from the checkpoint
+	after = ts.count()
+	if err := ts.mgr.RestartScanner(nil); err != nil {
+		t.Fatalf("restart failed; %s", err.Error())
+	}
+	waitFor(t, "block #5 re-scanned again", func() bool { return ts.emitted(after, 5) })
+	ts.stop(t)
+}
+
+// TestScannerRestartClose tests the restart racing with the scanner close terminates the scanner.
+func TestScannerRestartClose(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		ts := testScanner(t, &testScanRepo{head: 1 << 20, lnb: 100})
+
+		res := make(chan error, 1)
+		go func() {
+			res <- ts.mgr.RestartScanner(nil)
+		}()
+		ts.stop(t)
+
+		select {
+		case <-res:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("restart not finished")
+		}
+		if !ts.bls.stopped.Load() {
+			t.Fatalf("expected scanner stopped")
+		}
+	}
+}
+
+// TestScannerRestartConcurrent tests only one restart can be in progress.
+func TestScannerRestartConcurrent(t *testing.T) {
+	tr := &testScanRepo{head: 1 << 20, lnb: 100, hold: make(chan struct{})}
+	ts := testScanner(t, tr)
+
+	// the first restart waits for the checkpoint update
+	from := uint64(5)
+	first := make(chan error, 1)
+	go func() {
+		first <- ts.mgr.RestartScanner(&from)
+	}()
+	waitFor(t, "first restart in progress", ts.mgr.restarting.Load)
+
+	if err := ts.mgr.RestartScanner(nil); err != ErrScannerRestarting {
+		t.Errorf("expected %v, got %v", ErrScannerRestarting, err)
+	}
+
+	tr.mu.Lock()
+	close(tr.hold)
+	tr.mu.Unlock()
+	if err := <-first; err != nil {
+		t.Errorf("first restart failed; %s", err.Error())
+	}
+	if ts.mgr.restarting.Load() {
+		t.Errorf("expected restart guard released")
+	}
+	ts.stop(t)
+}
+
+// TestScannerRestartTerminated tests the terminated scanner refuses to restart.
+func TestScannerRestartTerminated(t *testing.T) {
+	ts := testScanner(t, &testScanRepo{head: 1 << 20, lnb: 100})
+	ts.stop(t)
+
+	for i := 0; i < 2; i++ {
+		err := ts.mgr.RestartScanner(nil)
+		if err == nil || err == ErrScannerRestarting {
+			t.Errorf("expected scanner not running, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"motif-api/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// priceSnapshotter represents a job taking snapshots of oracle prices
+// of the registered DeFi tokens. It's refreshed by the views refresher
+// as the prices view on the configured snapshot interval.
+type priceSnapshotter struct {
+	// tracked represents the set of tokens included in the last snapshot
+	tracked map[common.Address]bool
+}
+
+// newPriceSnapshotter creates a new price snapshots collector.
+func newPriceSnapshotter() *priceSnapshotter {
+	return &priceSnapshotter{tracked: make(map[common.Address]bool)}
+}
+
+// snapshot stores the current price of all the active DeFi tokens.
+// The tokens registry is loaded on each snapshot so new tokens are picked up
+// and deactivated tokens are dropped without restarting the service.
+func (ps *priceSnapshotter) snapshot() {
+	tokens, err := repo.DefiTokens()
+	if err != nil {
+		log.Errorf("can not load DeFi tokens for price snapshot; %s", err.Error())
+		return
+	}
+
+	// the block height binds the snapshot to the chain
+	var blk uint64
+	if h, err := repo.BlockHeight(); err == nil {
+		blk = h.ToInt().Uint64()
+	} else {
+		log.Errorf("can not get block height for price snapshot; %s", err.Error())
+	}
+
+	now := time.Now().UTC()
+	seen := make(map[common.Address]bool, len(tokens))
+	for i := range tokens {
+		if !tokens[i].IsActive {
+			continue
+		}
+		seen[tokens[i].Address] = true
+		ps.store(&tokens[i], blk, now)
+	}
+
+	ps.track(seen)
+
+	// the price snapshots are taken on time, the block just marks the progress
+	if blk > 0 {
+		if err := repo.UpdateIndexerCheckpoint(types.IndexerPrices, blk); err != nil {
+			log.Errorf("could not update prices indexer checkpoint; %s", err.Error())
+		}
+	}
+}
+
+// store takes the price snapshot of the given token.
+func (ps *priceSnapshotter) store(tok *types.DefiToken, blk uint64, now time.Time) {
+	price, err := repo.DefiTokenPrice(&tok.Address)
+	if err != nil {
+		log.Errorf("can not get price of %s; %s", tok.Symbol, err.Error())
+		return
+	}
+
+	err = repo.StorePriceSnapshot(&types.PriceSnapshot{
+		Token:         tok.Address,
+		Price:         price,
+		PriceDecimals: tok.PriceDecimals,
+		BlockNumber:   hexutil.Uint64(blk),
+		Time:          now,
+	})
+	if err != nil {
+		log.Errorf("can not store price snapshot of %s; %s", tok.Symbol, err.Error())
+	}
+}
+
+// track logs changes of the set of tokens included in the snapshots.
+func (ps *priceSnapshotter) track(seen map[common.Address]bool) {
+	for adr := range seen {
+		if !ps.tracked[adr] {
+			log.Noticef("price snapshots of token %s started", adr.String())
+		}
+	}
+	for adr := range ps.tracked {
+		if !seen[adr] {
+			log.Noticef("price snapshots of token %s stopped", adr.String())
+		}
+	}
+	ps.tracked = seen
+}
@@ -0,0 +1,92 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// supplyBlockBufferCapacity represents the capacity of the snapshot blocks channel.
+const supplyBlockBufferCapacity = 100
+
+// supplySnapshotter implements a service taking snapshots of the total supply
+// of the most active ERC20 tokens on the blocks dispatched by the block dispatcher.
+type supplySnapshotter struct {
+	service
+	inBlock chan *types.Block
+}
+
+// name returns the name of the service used by orchestrator.
+func (sup *supplySnapshotter) name() string {
+	return "supply snapshotter"
+}
+
+// init prepares the supply snapshotter to perform its function.
+func (sup *supplySnapshotter) init() {
+	sup.sigStop = make(chan bool, 1)
+}
+
+// run starts the supply snapshotter
+func (sup *supplySnapshotter) run() {
+	// make sure we are orchestrated
+	if sup.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", sup.name()))
+	}
+
+	// signal orchestrator we started and go
+	sup.mgr.started(sup)
+	go sup.execute()
+}
+
+// execute collects the snapshot blocks from the input channel and takes the snapshots.
+func (sup *supplySnapshotter) execute() {
+	defer func() {
+		close(sup.sigStop)
+		sup.mgr.finished(sup)
+	}()
+
+	for {
+		select {
+		case <-sup.sigStop:
+			return
+		case blk, ok := <-sup.inBlock:
+			if !ok {
+				log.Noticef("block channel closed, terminating %s", sup.name())
+				return
+			}
+			sup.snapshot(blk)
+		}
+	}
+}
+
+// snapshot stores the total supply of the most active ERC20 tokens at the given block.
+// The tokens list is loaded on each snapshot so new tokens are picked up.
+func (sup *supplySnapshotter) snapshot(blk *types.Block) {
+	tokens, err := repo.Erc20TokensList(cfg.SupplySnapshot.MaxTokens)
+	if err != nil {
+		log.Errorf("can not load ERC20 tokens for supply snapshot; %s", err.Error())
+		return
+	}
+
+	ts := time.Unix(int64(blk.TimeStamp), 0).UTC()
+	for i := range tokens {
+		supply, err := repo.Erc20TotalSupplyAt(&tokens[i], uint64(blk.Number))
+		if err != nil {
+			log.Debugf("supply of %s at #%d not available; %s", tokens[i].String(), uint64(blk.Number), err.Error())
+			continue
+		}
+
+		err = repo.StoreSupplySnapshot(&types.SupplySnapshot{
+			Token:       tokens[i],
+			BlockNumber: blk.Number,
+			TotalSupply: hexutil.Big(*supply),
+			Time:        ts,
+		})
+		if err != nil {
+			log.Errorf("can not store supply snapshot of %s; %s", tokens[i].String(), err.Error())
+		}
+	}
+	log.Debugf("supply snapshot of %d tokens at #%d done", len(tokens), uint64(blk.Number))
+}
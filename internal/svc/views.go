@@ -0,0 +1,132 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"motif-api/internal/types"
+	"fmt"
+	"go.uber.org/atomic"
+	"sync"
+	"time"
+)
+
+// vwrTickDuration represents the frequency of the views schedule check.
+const vwrTickDuration = 5 * time.Second
+
+// view represents a periodically refreshed, materialized view.
+type view struct {
+	name     string
+	interval time.Duration
+	refresh  func()
+
+	// mu serializes refreshes of the view; busy skips scheduled refreshes
+	// while the previous one is still running
+	mu   sync.Mutex
+	busy atomic.Bool
+	last atomic.Int64
+}
+
+// viewRefresher implements a service refreshing the materialized views
+// on their configured schedule.
+type viewRefresher struct {
+	service
+	views []*view
+	tick  *time.Ticker
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (vwr *viewRefresher) name() string {
+	return "views refresher"
+}
+
+// register adds a view to the refresher. Views are registered before the service starts.
+func (vwr *viewRefresher) register(name string, interval time.Duration, refresh func()) {
+	vwr.views = append(vwr.views, &view{name: name, interval: interval, refresh: refresh})
+}
+
+// run starts the views refresher.
+func (vwr *viewRefresher) run() {
+	// make sure we are orchestrated
+	if vwr.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", vwr.name()))
+	}
+
+	// start go routine for processing
+	vwr.mgr.started(vwr)
+	go vwr.execute()
+}
+
+// execute refreshes the views when they are due. The views are refreshed
+// in parallel so a slow view does not delay the others.
+func (vwr *viewRefresher) execute() {
+	defer func() {
+		vwr.tick.Stop()
+		close(vwr.sigStop)
+		vwr.mgr.finished(vwr)
+	}()
+
+	// views not refreshed yet are due right away
+	vwr.tick = time.NewTicker(vwrTickDuration)
+	vwr.schedule(time.Now())
+
+	for {
+		select {
+		case <-vwr.sigStop:
+			return
+		case now := <-vwr.tick.C:
+			vwr.schedule(now)
+		}
+	}
+}
+
+// schedule starts refresh of all the views due at the given time.
+func (vwr *viewRefresher) schedule(now time.Time) {
+	for _, v := range vwr.views {
+		if now.Sub(time.Unix(0, v.last.Load())) < v.interval || !v.busy.CAS(false, true) {
+			continue
+		}
+		go func(v *view) {
+			defer v.busy.Store(false)
+			v.update()
+		}(v)
+	}
+}
+
+// refreshView refreshes the view of the given name right away
+// and provides its updated state.
+func (vwr *viewRefresher) refreshView(name string) (*types.MaterializedView, error) {
+	for _, v := range vwr.views {
+		if v.name == name {
+			v.update()
+			return v.status(), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown view %s", name)
+}
+
+// list provides the state of all the views.
+func (vwr *viewRefresher) list() []types.MaterializedView {
+	list := make([]types.MaterializedView, len(vwr.views))
+	for i, v := range vwr.views {
+		list[i] = *v.status()
+	}
+	return list
+}
+
+// update refreshes the view and records the time of the refresh.
+func (v *view) update() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.refresh()
+	v.last.Store(time.Now().UnixNano())
+}
+
+// status provides the current state of the view.
+func (v *view) status() *types.MaterializedView {
+	mv := types.MaterializedView{Name: v.name, Interval: v.interval}
+	if last := v.last.Load(); last > 0 {
+		ts := time.Unix(0, last)
+		mv.LastRefresh = &ts
+	}
+	return &mv
+}
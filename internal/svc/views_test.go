@@ -0,0 +1,43 @@
+package svc
+
+import (
+	"testing"
+	"time"
+)
+
+// TestForcedViewRefresh tests a forced refresh updates the last refresh time of the view.
+func TestForcedViewRefresh(t *testing.T) {
+	var refreshed int
+	vwr := viewRefresher{}
+	vwr.register("test", time.Hour, func() { refreshed++ })
+
+	if mv := vwr.list()[0]; mv.LastRefresh != nil || !mv.IsStale(time.Now()) {
+		t.Fatalf("expected a stale view not refreshed yet, got %v", mv.LastRefresh)
+	}
+
+	start := time.Now()
+	mv, err := vwr.refreshView("test")
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if refreshed != 1 {
+		t.Errorf("expected a single refresh, got %d", refreshed)
+	}
+	if mv.LastRefresh == nil || mv.LastRefresh.Before(start) {
+		t.Fatalf("last refresh not updated, got %v", mv.LastRefresh)
+	}
+	if mv.IsStale(time.Now()) {
+		t.Errorf("refreshed view is stale")
+	}
+
+	// a refreshed view is not due before the interval elapses
+	vwr.schedule(time.Now())
+	time.Sleep(10 * time.Millisecond)
+	if refreshed != 1 {
+		t.Errorf("view refreshed before due, got %d refreshes", refreshed)
+	}
+
+	if _, err := vwr.refreshView("unknown"); err == nil {
+		t.Errorf("expected unknown view error")
+	}
+}
@@ -0,0 +1,32 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AccountOverviewsMaxAddresses represents the max number of addresses of a single batch of account overviews.
+const AccountOverviewsMaxAddresses = 100
+
+// AccountBalancesMaxAddresses represents the max number of addresses of a single batch of account balances.
+const AccountBalancesMaxAddresses = 100
+
+// AccountOverview represents the live overview of an account loaded from the node.
+// The values failed to load are nil and the failure is described by the error.
+type AccountOverview struct {
+	// Address is the address of the account.
+	Address common.Address
+
+	// Balance is the current native balance of the account.
+	Balance *hexutil.Big
+
+	// Nonce is the number of transactions sent by the account.
+	Nonce *hexutil.Uint64
+
+	// CodeSize is the size of the code deployed at the address; zero for wallets.
+	CodeSize *hexutil.Uint64
+
+	// Error describes the values failed to load, if any.
+	Error *string
+}
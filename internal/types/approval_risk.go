@@ -0,0 +1,85 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// approval risk levels
+const (
+	ApprovalRiskLow    = "LOW"
+	ApprovalRiskMedium = "MEDIUM"
+	ApprovalRiskHigh   = "HIGH"
+)
+
+// ApprovalRiskMaxApprovals represents the max number of approvals evaluated for a single account.
+const ApprovalRiskMaxApprovals = 200
+
+// Erc20Approval represents the latest ERC20 approval granted by an owner to a spender.
+type Erc20Approval struct {
+	// Token is the address of the approved ERC20 token.
+	Token common.Address
+
+	// Spender is the address allowed to transfer the tokens.
+	Spender common.Address
+
+	// TimeStamp is the time of the latest approval.
+	TimeStamp hexutil.Uint64
+}
+
+// Erc20ApprovalState represents the current on-chain state of an ERC20 approval.
+type Erc20ApprovalState struct {
+	// Allowance is the current amount the spender can transfer.
+	Allowance hexutil.Big
+
+	// Balance is the current token balance of the owner.
+	Balance hexutil.Big
+
+	// Supply is the current total supply of the token.
+	Supply hexutil.Big
+
+	// Error describes the calls failed to load the state, if any.
+	Error error
+}
+
+// ApprovalRiskItem represents a single active ERC20 approval
+// contributing to the account approval risk score.
+type ApprovalRiskItem struct {
+	// Token is the address of the approved ERC20 token.
+	Token common.Address
+
+	// Spender is the address allowed to transfer the tokens.
+	Spender common.Address
+
+	// Allowance is the current amount the spender can transfer.
+	Allowance hexutil.Big
+
+	// IsUnlimited signals the allowance is effectively unlimited.
+	IsUnlimited bool
+
+	// IsVerified signals the spender is a contract with verified source code.
+	IsVerified bool
+
+	// IsFlagged signals the spender is flagged by the token risk heuristics.
+	IsFlagged bool
+
+	// Exposure is the share of the owner balance covered by the allowance, 0 to 1.
+	Exposure float64
+
+	// Score is the contribution of the approval to the account risk score.
+	Score float64
+}
+
+// ApprovalRisk represents a heuristic risk score of ERC20 approvals granted by an account.
+// The score is not a verdict; it only suggests the approvals should be reviewed.
+type ApprovalRisk struct {
+	// Score is the total score of all the active approvals.
+	Score float64
+
+	// Level is the label of the score, see ApprovalRiskLow, ApprovalRiskMedium and ApprovalRiskHigh.
+	Level string
+
+	// Approvals is the breakdown of active approvals ordered by their score, highest first.
+	Approvals []ApprovalRiskItem
+}
@@ -0,0 +1,23 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+// CacheStats represents the usage statistics of the in-memory cache.
+type CacheStats struct {
+	// Entries is the number of entries in the cache.
+	Entries int
+
+	// Capacity is the number of bytes allocated by the cache.
+	Capacity int
+
+	// Hits and Misses count the cache lookups since the Since time.
+	Hits   uint64
+	Misses uint64
+
+	// Evictions counts the entries removed on expiration, or to make space since the Since time.
+	Evictions uint64
+
+	// Since is the time the counters were started, or reset.
+	Since time.Time
+}
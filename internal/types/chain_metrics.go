@@ -0,0 +1,26 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+// ChainMetrics represents throughput metrics of the blockchain
+// derived from a moving window of recently indexed blocks.
+type ChainMetrics struct {
+	// Blocks is the number of blocks in the window.
+	Blocks int32
+
+	// FirstBlock is the number of the oldest block in the window.
+	FirstBlock hexutil.Uint64
+
+	// LastBlock is the number of the most recent block in the window.
+	LastBlock hexutil.Uint64
+
+	// AvgBlockInterval is the average time between blocks in seconds.
+	AvgBlockInterval float64
+
+	// Tps is the average number of transactions per second.
+	Tps float64
+
+	// AvgGasUtilization is the average ratio of used gas to the block gas limit.
+	AvgGasUtilization float64
+}
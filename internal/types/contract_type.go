@@ -0,0 +1,55 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// classifications of accounts by their code
+const (
+	// ContractTypeEOA identifies an externally owned account without code.
+	ContractTypeEOA = "eoa"
+
+	// ContractTypeErc20 identifies an ERC20 token contract.
+	ContractTypeErc20 = "erc20"
+
+	// ContractTypeErc721 identifies an ERC721 non-fungible token contract.
+	ContractTypeErc721 = "erc721"
+
+	// ContractTypeErc1155 identifies an ERC1155 multi-token contract.
+	ContractTypeErc1155 = "erc1155"
+
+	// ContractTypeProxy identifies an EIP-1967 proxy contract.
+	ContractTypeProxy = "proxy"
+
+	// ContractTypeUnknown identifies a contract not matching any known interface.
+	ContractTypeUnknown = "unknown-contract"
+)
+
+// ContractType represents classification of an account by its code.
+type ContractType struct {
+	// Address is the address of the classified account.
+	Address common.Address `json:"adr"`
+
+	// Type is the classification of the account.
+	Type string `json:"type"`
+
+	// Implementation is the address of the implementation contract of a proxy.
+	Implementation *common.Address `json:"impl,omitempty"`
+
+	// ImplementationType is the classification of the implementation contract of a proxy.
+	ImplementationType string `json:"implType,omitempty"`
+}
+
+// UnmarshalContractType parses the JSON-encoded contract type data.
+func UnmarshalContractType(data []byte) (*ContractType, error) {
+	var ct ContractType
+	err := json.Unmarshal(data, &ct)
+	return &ct, err
+}
+
+// Marshal returns the JSON encoding of contract type.
+func (ct *ContractType) Marshal() ([]byte, error) {
+	return json.Marshal(ct)
+}
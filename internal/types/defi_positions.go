@@ -0,0 +1,64 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefiPositions represents positions of an account across the DeFi protocols.
+// Protocols the account has no position in are nil, or empty.
+type DefiPositions struct {
+	// FMint is the fMint position of the account, if any.
+	FMint *FMintPosition
+
+	// Liquidity is the list of DEX liquidity positions of the account.
+	Liquidity []LiquidityPosition
+
+	// IsPartial signals some tokens of the positions could not be priced;
+	// USD values do not cover them.
+	IsPartial bool
+}
+
+// FMintPosition represents a combined fMint position of an account.
+type FMintPosition struct {
+	// Collateral is the list of tokens deposited as collateral.
+	Collateral []*FMintTokenPosition
+
+	// Debt is the list of tokens borrowed/minted.
+	Debt []*FMintTokenPosition
+
+	// CollateralUsd is the USD value of the collateral.
+	CollateralUsd float64
+
+	// DebtUsd is the USD value of the debt.
+	DebtUsd float64
+
+	// HealthRatio is the ratio between the collateral and the debt values; nil without a debt.
+	HealthRatio *float64
+
+	// MinCollateralRatio is the minimal allowed ratio between the collateral and the debt values.
+	MinCollateralRatio float64
+}
+
+// LiquidityPosition represents a liquidity position of an account on a DEX pair.
+type LiquidityPosition struct {
+	// Pair is the address of the pair.
+	Pair common.Address
+
+	// Tokens are the addresses of the tokens of the pair.
+	Tokens []common.Address
+
+	// Balance is the amount of the pair liquidity tokens held.
+	Balance hexutil.Big
+
+	// Share is the percentage of the pair liquidity held.
+	Share float64
+
+	// Amounts are the amounts of the underlying tokens represented by the share,
+	// in the order of the tokens.
+	Amounts []hexutil.Big
+
+	// ValueUsd is the USD value of the underlying amounts; nil if any of the tokens is not priced.
+	ValueUsd *float64
+}
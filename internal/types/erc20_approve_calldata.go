@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Erc20ApproveCallData represents an unsigned ERC20 approve call
+// prepared for a client to sign and send.
+type Erc20ApproveCallData struct {
+	// To is the address of the ERC20 token contract to be called.
+	To common.Address
+
+	// Data is the ABI encoded input of the call.
+	Data hexutil.Bytes
+
+	// GasLimit is the suggested gas limit of the transaction.
+	GasLimit hexutil.Uint64
+
+	// IsRevoke signals the call revokes the allowance of the spender.
+	IsRevoke bool
+}
@@ -0,0 +1,81 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+)
+
+const (
+	FiErc20HolderPk      = "_id"
+	FiErc20HolderToken   = "tok"
+	FiErc20HolderOwner   = "own"
+	FiErc20HolderBalance = "bal"
+)
+
+// Erc20HoldersMaxCount is the max number of ERC20 token holders loaded at once.
+const Erc20HoldersMaxCount = 200
+
+// Erc20Holder represents the balance of an ERC20 token held by an account
+// as accumulated from the indexed token transfers.
+type Erc20Holder struct {
+	// Owner is the address holding the tokens.
+	Owner common.Address
+
+	// Token is the address of the ERC20 contract.
+	Token common.Address
+
+	// Balance is the amount of tokens held.
+	Balance hexutil.Big
+
+	// Updated is the time stamp of the last transfer changing the balance.
+	Updated hexutil.Uint64
+}
+
+// BsonErc20Holder represents the ERC20 holder data structure for BSON formatting.
+// The balance is stored as a fixed width hex number so the holders can be sorted by it.
+type BsonErc20Holder struct {
+	ID      string `bson:"_id"`
+	Token   string `bson:"tok"`
+	Owner   string `bson:"own"`
+	Balance string `bson:"bal"`
+	Updated uint64 `bson:"ts"`
+}
+
+// Erc20HolderPk generates unique identifier of the ERC20 holder.
+func Erc20HolderPk(token *common.Address, owner *common.Address) string {
+	return token.String() + owner.String()
+}
+
+// MarshalBSON creates a BSON representation of the ERC20 holder record.
+func (eh *Erc20Holder) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonErc20Holder{
+		ID:      Erc20HolderPk(&eh.Token, &eh.Owner),
+		Token:   eh.Token.String(),
+		Owner:   eh.Owner.String(),
+		Balance: fmt.Sprintf("%064x", eh.Balance.ToInt()),
+		Updated: uint64(eh.Updated),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (eh *Erc20Holder) UnmarshalBSON(data []byte) (err error) {
+	var row BsonErc20Holder
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	bal, ok := new(big.Int).SetString(row.Balance, 16)
+	if !ok {
+		return fmt.Errorf("invalid ERC20 holder balance %s", row.Balance)
+	}
+
+	eh.Token = common.HexToAddress(row.Token)
+	eh.Owner = common.HexToAddress(row.Owner)
+	eh.Balance = hexutil.Big(*bal)
+	eh.Updated = hexutil.Uint64(row.Updated)
+	return nil
+}
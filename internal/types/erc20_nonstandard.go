@@ -0,0 +1,24 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+const (
+	// Erc20VariantBytes32String marks a token returning its name, or symbol
+	// as bytes32 instead of the ABI encoded string.
+	Erc20VariantBytes32String = "bytes32_string"
+
+	// Erc20VariantShortDecimals marks a token returning its decimals
+	// in less than the full 32 bytes ABI word.
+	Erc20VariantShortDecimals = "short_decimals"
+)
+
+// Erc20NonStandardToken represents an ERC20 token which required a non-standard
+// decoding of some of its return values.
+type Erc20NonStandardToken struct {
+	// Token is the address of the token contract.
+	Token common.Address
+
+	// Variants is the list of non-standard return values found, see Erc20Variant*.
+	Variants []string
+}
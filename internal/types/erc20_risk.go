@@ -0,0 +1,47 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+// Erc20RiskFlags represents a set of heuristic flags signaling
+// the ERC20 token may be a scam, or a spam token. The flags are not
+// definitive, they only suggest the token should be treated with care.
+type Erc20RiskFlags struct {
+	// MassAirdrop signals the token has been distributed
+	// by a single sender to a large number of recipients.
+	MassAirdrop bool
+
+	// AirdropSender is the most distributing sender of the token.
+	AirdropSender *common.Address
+
+	// AirdropRecipients is the number of distinct recipients
+	// of the most distributing sender.
+	AirdropRecipients int64
+
+	// MimicsKnownToken signals the name, or the symbol of the token
+	// matches a known token deployed on a different address.
+	MimicsKnownToken bool
+
+	// MimickedToken is the address of the known token being mimicked.
+	MimickedToken *common.Address
+
+	// MissingMetadata signals the token does not provide
+	// the standard name, or symbol metadata.
+	MissingMetadata bool
+}
+
+// Erc20SenderStats represents aggregated transfer statistics
+// of the most distributing sender of an ERC20 token.
+type Erc20SenderStats struct {
+	// Sender is the address of the sender.
+	Sender common.Address
+
+	// Recipients is the number of distinct recipients of the sender.
+	Recipients int64
+
+	// Transfers is the number of transfers made by the sender.
+	Transfers int64
+
+	// Total is the total number of transfers of the token.
+	Total int64
+}
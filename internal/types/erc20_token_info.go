@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Erc20TokenInfo represents the metadata of an ERC20 token loaded from the node at once.
+// The name, the symbol and the decimals are optional in ERC20; the values
+// the token does not provide, or failed to load are nil.
+type Erc20TokenInfo struct {
+	// Address is the address of the token contract.
+	Address common.Address
+
+	// Name is the name of the token.
+	Name *string
+
+	// Symbol is the symbol of the token.
+	Symbol *string
+
+	// Decimals is the number of decimals of the token amounts.
+	Decimals *int32
+
+	// TotalSupply is the current total supply of the token.
+	TotalSupply *hexutil.Big
+}
@@ -119,6 +119,11 @@ func (etx *TokenTransaction) MarshalBSON() ([]byte, error) {
 		val = etx.Amount.ToInt()
 	}
 
+	// absurd amounts would overflow and poison the aggregated value
+	if !val.IsInt64() {
+		val = new(big.Int)
+	}
+
 	// make the record and encode it
 	return bson.Marshal(BsonErc20Transaction{
 		ID:        etx.Pk(),
@@ -164,5 +169,12 @@ func (etx *TokenTransaction) UnmarshalBSON(data []byte) (err error) {
 	etx.Recipient = common.HexToAddress(row.To)
 	etx.Amount = (hexutil.Big)(*hexutil.MustDecodeBig(row.Amo))
 	etx.TokenId = (hexutil.Big)(*hexutil.MustDecodeBig(row.TokenId))
+
+	// the block and the log position are encoded in the primary key
+	if pk, err := hexutil.Decode(row.ID); err == nil && len(pk) == 14 {
+		etx.BlockNumber = binary.BigEndian.Uint64(pk[0:8])
+		etx.LogIndex = uint(binary.BigEndian.Uint32(pk[8:12]))
+		etx.Seq = binary.BigEndian.Uint16(pk[12:14])
+	}
 	return nil
 }
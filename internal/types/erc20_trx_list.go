@@ -11,6 +11,10 @@ type TokenTransactionList struct {
 	// Total indicates total number of ERC transactions in the whole collection.
 	Total uint64
 
+	// TotalSkipped indicates the total has not been calculated on the client demand;
+	// the Total only signals if the list is empty, or not.
+	TotalSkipped bool
+
 	// First is the index of the first item on the list
 	First uint64
 
@@ -0,0 +1,24 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+// FeeHistoryMaxBlocks represents the max number of blocks of a single fee history query.
+const FeeHistoryMaxBlocks = 1024
+
+// FeeHistory represents the history of the base fee and the priority fee rewards
+// of a range of the most recent blocks.
+type FeeHistory struct {
+	// OldestBlock is the number of the oldest block of the range.
+	OldestBlock hexutil.Uint64 `json:"oldestBlock"`
+
+	// BaseFeePerGas is the base fee per gas of each block of the range,
+	// including the next block after the newest one.
+	BaseFeePerGas []hexutil.Big `json:"baseFeePerGas"`
+
+	// GasUsedRatio is the ratio of the gas used to the gas limit of each block of the range.
+	GasUsedRatio []float64 `json:"gasUsedRatio"`
+
+	// Reward is the priority fee per gas at the requested percentiles of each block of the range.
+	Reward [][]hexutil.Big `json:"reward"`
+}
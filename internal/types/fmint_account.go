@@ -26,3 +26,55 @@ type FMintAccount struct {
 	// in ref. denomination (fUSD).
 	DebtValue hexutil.Big
 }
+
+// FMintTokenPosition represents a position of a single token on an fMint account,
+// either deposited as a collateral, or borrowed/minted as a debt.
+type FMintTokenPosition struct {
+	// Token is the address of the token.
+	Token common.Address
+
+	// Type represents the side of the position, collateral or debt.
+	Type DefiTokenType
+
+	// Amount represents the amount of tokens on the position.
+	Amount hexutil.Big
+
+	// Value represents the current value of the position in USD
+	// corrected for the token decimals; it uses the price oracle decimals.
+	Value hexutil.Big
+
+	// ValueDecimals is the number of decimals of the value.
+	ValueDecimals int32
+}
+
+// FMintLiquidationRisk represents the distance of the collateral ratio of an fMint position
+// from the minimal collateral ratio the position can be liquidated below.
+type FMintLiquidationRisk struct {
+	// CollateralRatio is the current ratio between the collateral and the debt values.
+	CollateralRatio float64
+
+	// MinCollateralRatio is the minimal allowed ratio between the collateral and the debt values.
+	MinCollateralRatio float64
+
+	// Margin is the distance of the collateral ratio above the minimal ratio in percent
+	// of the minimal ratio; it's negative if the position is already below the ratio.
+	Margin float64
+
+	// AtRisk signals the margin is within the configured risk buffer.
+	AtRisk bool
+}
+
+// FMintLiquidationPrice represents the price of a collateral token at which
+// an fMint position would drop below the minimal collateral ratio,
+// assuming the prices of the other tokens of the position do not change.
+type FMintLiquidationPrice struct {
+	// Token is the address of the collateral token.
+	Token common.Address
+
+	// Price is the USD price of the token at which the position can be liquidated;
+	// nil if the position can not be liquidated by the token price drop.
+	Price *float64
+
+	// CurrentPrice is the current USD price of the token.
+	CurrentPrice float64
+}
@@ -0,0 +1,23 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FMintCallData represents an unsigned call of the fMint minter contract
+// prepared for a client to sign and send.
+type FMintCallData struct {
+	// To is the address of the fMint minter contract to be called.
+	To common.Address
+
+	// Data is the ABI encoded input of the call.
+	Data hexutil.Bytes
+
+	// GasLimit is the suggested gas limit of the transaction.
+	GasLimit hexutil.Uint64
+
+	// Warning describes a problem the call would most likely run into, if any.
+	Warning *string
+}
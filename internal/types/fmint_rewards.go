@@ -0,0 +1,29 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FMintRewards represents the rewards of an fMint account distributed
+// by the fMint reward distribution contract.
+type FMintRewards struct {
+	// Owner is the address of the fMint account.
+	Owner common.Address
+
+	// RewardToken is the address of the token the rewards are paid in.
+	RewardToken common.Address
+
+	// Pending is the amount of rewards accumulated on the account, including the stash.
+	Pending hexutil.Big
+
+	// RewardRate is the amount of rewards distributed per second across all the accounts.
+	RewardRate hexutil.Big
+
+	// PrincipalBalance is the principal balance of the account the rewards accrue on.
+	PrincipalBalance hexutil.Big
+
+	// TotalPrincipalBalance is the principal balance of all the accounts.
+	TotalPrincipalBalance hexutil.Big
+}
@@ -0,0 +1,31 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// FxBaseCurrency is the currency the API values are denominated in.
+const FxBaseCurrency = "USD"
+
+// FxRates represents exchange rates of the base currency to other currencies.
+type FxRates struct {
+	// Rates are the rates by the upper case currency code.
+	Rates map[string]float64 `json:"rates"`
+
+	// Updated is the time the rates have been loaded.
+	Updated time.Time `json:"updated"`
+}
+
+// Marshal returns the JSON encoding of the exchange rates.
+func (fx *FxRates) Marshal() ([]byte, error) {
+	return json.Marshal(fx)
+}
+
+// UnmarshalFxRates parses the JSON encoded exchange rates.
+func UnmarshalFxRates(data []byte) (*FxRates, error) {
+	var fx FxRates
+	err := json.Unmarshal(data, &fx)
+	return &fx, err
+}
@@ -0,0 +1,31 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+// SyncProgress represents the synchronization state of the connected blockchain node.
+type SyncProgress struct {
+	// IsSyncing signals the node is still catching up with the network.
+	IsSyncing bool `json:"syncing"`
+
+	// CurrentBlock is the number of the latest block known to the node.
+	CurrentBlock hexutil.Uint64 `json:"currentBlock"`
+
+	// HighestBlock is the number of the highest block of the network known to the node.
+	HighestBlock hexutil.Uint64 `json:"highestBlock"`
+}
+
+// Health represents the state of the dependencies of the API server.
+type Health struct {
+	// Healthy signals the node is reachable and synced, and the database responds.
+	Healthy bool `json:"healthy"`
+
+	// Node is the synchronization state of the node; nil if the node is not reachable.
+	Node *SyncProgress `json:"node"`
+
+	// NodeError describes the failure of the node, if any.
+	NodeError *string `json:"nodeError,omitempty"`
+
+	// DatabaseError describes the failure of the database, if any.
+	DatabaseError *string `json:"databaseError,omitempty"`
+}
@@ -0,0 +1,33 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+const (
+	// IndexerTransactions is the name of the transactions indexer.
+	IndexerTransactions = "transactions"
+
+	// IndexerTransfers is the name of the token transfers indexer.
+	IndexerTransfers = "transfers"
+
+	// IndexerStaking is the name of the staking events indexer.
+	IndexerStaking = "staking"
+
+	// IndexerPrices is the name of the price snapshots indexer.
+	IndexerPrices = "prices"
+)
+
+// IndexProgress represents the indexing progress of a single scanner collection.
+type IndexProgress struct {
+	// Name is the name of the indexer.
+	Name string
+
+	// Enabled indicates the indexer runs on this API node.
+	Enabled bool
+
+	// LastBlock is the last block processed by the indexer, if any.
+	LastBlock *hexutil.Uint64
+
+	// Lag is the number of blocks the indexer is behind the chain head, if known.
+	Lag *hexutil.Uint64
+}
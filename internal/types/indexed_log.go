@@ -0,0 +1,115 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/binary"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	FiIndexedLogPk      = "_id"
+	FiIndexedLogAddress = "adr"
+	FiIndexedLogTopics  = "top"
+	FiIndexedLogBlock   = "blk"
+)
+
+// IndexedLog represents a log record stored in the logs index.
+type IndexedLog struct {
+	retypes.Log
+}
+
+// BsonIndexedLog represents the indexed log data structure for BSON formatting.
+type BsonIndexedLog struct {
+	ID        string   `bson:"_id"`
+	Address   string   `bson:"adr"`
+	Topics    []string `bson:"top"`
+	Data      string   `bson:"data"`
+	Block     uint64   `bson:"blk"`
+	BlockHash string   `bson:"bh"`
+	Trx       string   `bson:"trx"`
+	TrxIndex  uint64   `bson:"tix"`
+	LogIndex  uint64   `bson:"lix"`
+}
+
+// IndexedLogPk generates unique identifier of a log record emitted
+// on the given position in the chain. The identifier sorts logs in the chain order.
+func IndexedLogPk(block uint64, logIndex uint64) string {
+	bytes := make([]byte, 12)
+	binary.BigEndian.PutUint64(bytes[0:8], block)
+	binary.BigEndian.PutUint32(bytes[8:12], uint32(logIndex))
+	return hexutil.Encode(bytes)
+}
+
+// Pk provides the unique identifier of the indexed log record.
+func (il *IndexedLog) Pk() string {
+	return IndexedLogPk(il.BlockNumber, uint64(il.Index))
+}
+
+// MarshalBSON creates a BSON representation of the indexed log record.
+func (il *IndexedLog) MarshalBSON() ([]byte, error) {
+	topics := make([]string, len(il.Topics))
+	for i, t := range il.Topics {
+		topics[i] = t.String()
+	}
+	return bson.Marshal(BsonIndexedLog{
+		ID:        il.Pk(),
+		Address:   il.Address.String(),
+		Topics:    topics,
+		Data:      hexutil.Encode(il.Data),
+		Block:     il.BlockNumber,
+		BlockHash: il.BlockHash.String(),
+		Trx:       il.TxHash.String(),
+		TrxIndex:  uint64(il.TxIndex),
+		LogIndex:  uint64(il.Index),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (il *IndexedLog) UnmarshalBSON(data []byte) (err error) {
+	var row BsonIndexedLog
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	il.Data, err = hexutil.Decode(row.Data)
+	if err != nil {
+		return err
+	}
+
+	il.Topics = make([]common.Hash, len(row.Topics))
+	for i, t := range row.Topics {
+		il.Topics[i] = common.HexToHash(t)
+	}
+	il.Address = common.HexToAddress(row.Address)
+	il.BlockNumber = row.Block
+	il.BlockHash = common.HexToHash(row.BlockHash)
+	il.TxHash = common.HexToHash(row.Trx)
+	il.TxIndex = uint(row.TrxIndex)
+	il.Index = uint(row.LogIndex)
+	return nil
+}
+
+// IndexedLogList represents a list of indexed log records.
+type IndexedLogList struct {
+	// Collection keeps the actual list of log records.
+	Collection []*IndexedLog
+
+	// Total indicates total number of log records matching the list filter.
+	Total uint64
+
+	// IsStart indicates there are no newer log records available above the list.
+	IsStart bool
+
+	// IsEnd indicates there are no older log records available below the list.
+	IsEnd bool
+}
+
+// Reverse reverses the order of log records in the list.
+func (ll *IndexedLogList) Reverse() {
+	for i, j := 0, len(ll.Collection)-1; i < j; i, j = i+1, j-1 {
+		ll.Collection[i], ll.Collection[j] = ll.Collection[j], ll.Collection[i]
+	}
+}
@@ -0,0 +1,149 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/binary"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	FiMethodCallPk       = "_id"
+	FiMethodCallContract = "adr"
+	FiMethodCallSelector = "sel"
+	FiMethodCallName     = "name"
+)
+
+// MethodCall represents a recorded call of a watched contract method.
+type MethodCall struct {
+	// Transaction is the hash of the transaction making the call.
+	Transaction common.Hash
+
+	// Contract is the address of the called contract.
+	Contract common.Address
+
+	// From is the address of the caller.
+	From common.Address
+
+	// Selector is the 4 bytes method selector.
+	Selector hexutil.Bytes
+
+	// Name is the method name, if the method signature is known.
+	Name string
+
+	// Args is the list of call arguments; decoded values if the method signature
+	// is known, raw 32 bytes words of the call data otherwise.
+	Args []string
+
+	// Decoded signals the arguments were decoded using the method signature.
+	Decoded bool
+
+	// Value is the amount of native tokens sent along with the call.
+	Value hexutil.Big
+
+	// BlockNumber is the number of the block containing the call.
+	BlockNumber hexutil.Uint64
+
+	// TrxIndex is the index of the transaction in the block.
+	TrxIndex hexutil.Uint64
+
+	// TimeStamp is the time stamp of the block containing the call.
+	TimeStamp hexutil.Uint64
+}
+
+// BsonMethodCall represents the method call data structure for BSON formatting.
+type BsonMethodCall struct {
+	ID        string   `bson:"_id"`
+	Trx       string   `bson:"trx"`
+	Contract  string   `bson:"adr"`
+	From      string   `bson:"from"`
+	Selector  string   `bson:"sel"`
+	Name      string   `bson:"name"`
+	Args      []string `bson:"args"`
+	Decoded   bool     `bson:"dec"`
+	Value     string   `bson:"val"`
+	Block     uint64   `bson:"blk"`
+	TrxIndex  uint64   `bson:"tix"`
+	TimeStamp uint64   `bson:"ts"`
+}
+
+// MethodCallPk generates unique identifier of a method call made by a transaction
+// on the given position in the chain. The identifier sorts calls in the chain order.
+func MethodCallPk(block uint64, trxIndex uint64) string {
+	bytes := make([]byte, 12)
+	binary.BigEndian.PutUint64(bytes[0:8], block)
+	binary.BigEndian.PutUint32(bytes[8:12], uint32(trxIndex))
+	return hexutil.Encode(bytes)
+}
+
+// Pk provides the unique identifier of the method call.
+func (mc *MethodCall) Pk() string {
+	return MethodCallPk(uint64(mc.BlockNumber), uint64(mc.TrxIndex))
+}
+
+// MarshalBSON creates a BSON representation of the method call record.
+func (mc *MethodCall) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonMethodCall{
+		ID:        mc.Pk(),
+		Trx:       mc.Transaction.String(),
+		Contract:  mc.Contract.String(),
+		From:      mc.From.String(),
+		Selector:  mc.Selector.String(),
+		Name:      mc.Name,
+		Args:      mc.Args,
+		Decoded:   mc.Decoded,
+		Value:     mc.Value.String(),
+		Block:     uint64(mc.BlockNumber),
+		TrxIndex:  uint64(mc.TrxIndex),
+		TimeStamp: uint64(mc.TimeStamp),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (mc *MethodCall) UnmarshalBSON(data []byte) (err error) {
+	var row BsonMethodCall
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	val, err := hexutil.DecodeBig(row.Value)
+	if err != nil {
+		return err
+	}
+
+	mc.Transaction = common.HexToHash(row.Trx)
+	mc.Contract = common.HexToAddress(row.Contract)
+	mc.From = common.HexToAddress(row.From)
+	mc.Selector = common.FromHex(row.Selector)
+	mc.Name = row.Name
+	mc.Args = row.Args
+	mc.Decoded = row.Decoded
+	mc.Value = hexutil.Big(*val)
+	mc.BlockNumber = hexutil.Uint64(row.Block)
+	mc.TrxIndex = hexutil.Uint64(row.TrxIndex)
+	mc.TimeStamp = hexutil.Uint64(row.TimeStamp)
+	return nil
+}
+
+// MethodCallList represents a list of recorded method calls.
+type MethodCallList struct {
+	// Collection keeps the actual list of calls.
+	Collection []*MethodCall
+
+	// Total indicates total number of calls matching the list filter.
+	Total uint64
+
+	// IsStart indicates there are no newer calls available above the list.
+	IsStart bool
+
+	// IsEnd indicates there are no older calls available below the list.
+	IsEnd bool
+}
+
+// Reverse reverses the order of calls in the list.
+func (ml *MethodCallList) Reverse() {
+	for i, j := 0, len(ml.Collection)-1; i < j; i, j = i+1, j-1 {
+		ml.Collection[i], ml.Collection[j] = ml.Collection[j], ml.Collection[i]
+	}
+}
@@ -0,0 +1,31 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// NetWorthMaxPoints represents the max number of points of a single net worth history request.
+// Each point needs a historical balance call per priced token, and a price lookup.
+const NetWorthMaxPoints = 100
+
+// NetWorthPoint represents the USD value of an account portfolio at a block.
+type NetWorthPoint struct {
+	// BlockNumber is the number of the block the value is calculated at.
+	BlockNumber hexutil.Uint64
+
+	// Time is the time stamp of the block.
+	Time time.Time
+
+	// Value is the total USD value of the native and priced token balances.
+	Value float64
+
+	// BalancesMissing signals some historical balances were not available, e.g. the node
+	// does not keep the state of the block; the value is partial.
+	BalancesMissing bool
+
+	// PricesMissing signals some held tokens had no price snapshot close to the block time;
+	// the value is partial.
+	PricesMissing bool
+}
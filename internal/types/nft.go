@@ -0,0 +1,141 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"math/big"
+)
+
+const (
+	FiNftHoldingPk        = "_id"
+	FiNftHoldingOwner     = "own"
+	FiNftHoldingToken     = "tok"
+	FiNftHoldingTokenType = "tty"
+	FiNftHoldingTokenId   = "tid"
+	FiNftHoldingQuantity  = "qty"
+	FiNftHoldingUpdated   = "ts"
+)
+
+// NftHolding represents an ERC721/ERC1155 token held by an account.
+type NftHolding struct {
+	// Owner is the address holding the token.
+	Owner common.Address
+
+	// Token is the address of the ERC721/ERC1155 contract.
+	Token common.Address
+
+	// TokenType is the type of the contract (ERC721/ERC1155).
+	TokenType string
+
+	// TokenId is the identifier of the token inside the contract.
+	TokenId hexutil.Big
+
+	// Quantity is the number of the tokens held; always 1 for ERC721.
+	Quantity hexutil.Big
+
+	// Updated is the time stamp of the last transfer changing the holding.
+	Updated hexutil.Uint64
+}
+
+// BsonNftHolding represents the NFT holding data structure for BSON formatting.
+// The quantity is a decimal number so the holdings can be updated by increments.
+type BsonNftHolding struct {
+	ID        string               `bson:"_id"`
+	Owner     string               `bson:"own"`
+	Token     string               `bson:"tok"`
+	TokenType string               `bson:"tty"`
+	TokenId   string               `bson:"tid"`
+	Quantity  primitive.Decimal128 `bson:"qty"`
+	Updated   uint64               `bson:"ts"`
+}
+
+// NftHoldingPk generates unique identifier of the NFT holding.
+// The identifier sorts holdings of an owner by the contract and the token id.
+func NftHoldingPk(owner *common.Address, token *common.Address, tokenId *big.Int) string {
+	return owner.String() + token.String() + common.BigToHash(tokenId).String()
+}
+
+// NftQuantityDecimal converts the given token quantity into the decimal stored in the database.
+// Quantities not representable by the decimal exactly are refused.
+func NftQuantityDecimal(qty *big.Int) (primitive.Decimal128, error) {
+	dec, ok := primitive.ParseDecimal128FromBigInt(qty, 0)
+	if !ok {
+		return primitive.Decimal128{}, fmt.Errorf("quantity %s out of range", qty.String())
+	}
+	return dec, nil
+}
+
+// MarshalBSON creates a BSON representation of the NFT holding record.
+func (nh *NftHolding) MarshalBSON() ([]byte, error) {
+	qty, err := NftQuantityDecimal(nh.Quantity.ToInt())
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.Marshal(BsonNftHolding{
+		ID:        NftHoldingPk(&nh.Owner, &nh.Token, nh.TokenId.ToInt()),
+		Owner:     nh.Owner.String(),
+		Token:     nh.Token.String(),
+		TokenType: nh.TokenType,
+		TokenId:   nh.TokenId.String(),
+		Quantity:  qty,
+		Updated:   uint64(nh.Updated),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (nh *NftHolding) UnmarshalBSON(data []byte) (err error) {
+	var row BsonNftHolding
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	tid, err := hexutil.DecodeBig(row.TokenId)
+	if err != nil {
+		return err
+	}
+	qty, exp, err := row.Quantity.BigInt()
+	if err != nil {
+		return err
+	}
+	switch {
+	case exp > 0:
+		qty.Mul(qty, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	case exp < 0:
+		qty.Quo(qty, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil))
+	}
+
+	nh.Owner = common.HexToAddress(row.Owner)
+	nh.Token = common.HexToAddress(row.Token)
+	nh.TokenType = row.TokenType
+	nh.TokenId = hexutil.Big(*tid)
+	nh.Quantity = hexutil.Big(*qty)
+	nh.Updated = hexutil.Uint64(row.Updated)
+	return nil
+}
+
+// NftHoldingList represents a list of NFT holdings of an account.
+type NftHoldingList struct {
+	// Collection keeps the actual list of holdings.
+	Collection []*NftHolding
+
+	// Total indicates total number of holdings matching the list filter.
+	Total uint64
+
+	// IsStart indicates there are no holdings available above the list.
+	IsStart bool
+
+	// IsEnd indicates there are no holdings available below the list.
+	IsEnd bool
+}
+
+// Reverse reverses the order of holdings in the list.
+func (nl *NftHoldingList) Reverse() {
+	for i, j := 0, len(nl.Collection)-1; i < j; i, j = i+1, j-1 {
+		nl.Collection[i], nl.Collection[j] = nl.Collection[j], nl.Collection[i]
+	}
+}
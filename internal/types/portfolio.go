@@ -0,0 +1,57 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// PortfolioHolding represents a single token held by an account in the portfolio distribution.
+type PortfolioHolding struct {
+	// Token is the address of the token; nil for the native balance.
+	Token *common.Address `json:"token,omitempty"`
+
+	// Symbol is the symbol of the token.
+	Symbol string `json:"symbol"`
+
+	// Balance is the raw balance of the token.
+	Balance hexutil.Big `json:"balance"`
+
+	// Decimals is the number of decimals of the balance.
+	Decimals int32 `json:"decimals"`
+
+	// Value is the USD value of the balance.
+	Value float64 `json:"value"`
+
+	// Share is the percentage of the total portfolio value.
+	Share float64 `json:"share"`
+}
+
+// PortfolioDistribution represents the breakdown of the account portfolio value by tokens.
+type PortfolioDistribution struct {
+	// Total is the USD value of the priced holdings.
+	Total float64 `json:"total"`
+
+	// Holdings are the priced holdings ordered by their value, the highest first.
+	Holdings []PortfolioHolding `json:"holdings"`
+
+	// Unpriced are the holdings without a price; they are excluded from the total.
+	Unpriced []PortfolioHolding `json:"unpriced"`
+
+	// Computed is the time the distribution has been calculated.
+	Computed time.Time `json:"computed"`
+}
+
+// Marshal returns the JSON encoding of the portfolio distribution.
+func (pd *PortfolioDistribution) Marshal() ([]byte, error) {
+	return json.Marshal(pd)
+}
+
+// UnmarshalPortfolioDistribution parses the JSON encoded portfolio distribution.
+func UnmarshalPortfolioDistribution(data []byte) (*PortfolioDistribution, error) {
+	var pd PortfolioDistribution
+	err := json.Unmarshal(data, &pd)
+	return &pd, err
+}
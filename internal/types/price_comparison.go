@@ -0,0 +1,16 @@
+// Package types implements different core types of the API.
+package types
+
+// PriceComparison represents the oracle price of a token cross-referenced
+// with the spot price derived from the DEX pair against the configured base token.
+// Prices unavailable from either source are nil.
+type PriceComparison struct {
+	// OraclePrice is the price of the token provided by the on-chain price oracle.
+	OraclePrice *float64
+
+	// DexPrice is the spot price of the token derived from the DEX pair reserves.
+	DexPrice *float64
+
+	// Divergence is the difference of the DEX price from the oracle price in percent.
+	Divergence *float64
+}
@@ -0,0 +1,72 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiPriceSnapshotToken = "tok"
+	FiPriceSnapshotTime  = "ts"
+)
+
+// PriceSnapshot represents the oracle price of a token observed at a point in time.
+type PriceSnapshot struct {
+	// Token is the address of the token.
+	Token common.Address
+
+	// Price is the oracle price of the token.
+	Price hexutil.Big
+
+	// PriceDecimals is the number of decimals of the price.
+	PriceDecimals int32
+
+	// BlockNumber is the number of the chain head block at the time of the snapshot.
+	BlockNumber hexutil.Uint64
+
+	// Time is the time of the snapshot.
+	Time time.Time
+}
+
+// BsonPriceSnapshot represents the price snapshot data structure for BSON formatting.
+type BsonPriceSnapshot struct {
+	Token    string    `bson:"tok"`
+	Price    string    `bson:"price"`
+	Decimals int32     `bson:"dec"`
+	Block    uint64    `bson:"blk"`
+	Time     time.Time `bson:"ts"`
+}
+
+// MarshalBSON creates a BSON representation of the price snapshot.
+func (ps *PriceSnapshot) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonPriceSnapshot{
+		Token:    ps.Token.String(),
+		Price:    ps.Price.String(),
+		Decimals: ps.PriceDecimals,
+		Block:    uint64(ps.BlockNumber),
+		Time:     ps.Time,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ps *PriceSnapshot) UnmarshalBSON(data []byte) (err error) {
+	var row BsonPriceSnapshot
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	price, err := hexutil.DecodeBig(row.Price)
+	if err != nil {
+		return err
+	}
+
+	ps.Token = common.HexToAddress(row.Token)
+	ps.Price = hexutil.Big(*price)
+	ps.PriceDecimals = row.Decimals
+	ps.BlockNumber = hexutil.Uint64(row.Block)
+	ps.Time = row.Time
+	return nil
+}
@@ -0,0 +1,40 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// ProtocolFeesMaxWindow represents the longest window of protocol fees aggregation.
+const ProtocolFeesMaxWindow = 90 * 24 * time.Hour
+
+// TokenFee represents protocol fees collected in a single token.
+type TokenFee struct {
+	// Token is the address of the token the fees were collected in.
+	Token common.Address
+
+	// Amount is the amount of tokens collected.
+	Amount hexutil.Big
+
+	// UsdValue is the USD value of the collected amount by the current
+	// oracle price of the token; nil if the price is not available.
+	UsdValue *float64
+}
+
+// ProtocolFees represents fees collected by a protocol over a time window.
+type ProtocolFees struct {
+	// Since is the start of the aggregation window.
+	Since time.Time
+
+	// Fees is the list of fees collected by token.
+	Fees []TokenFee
+
+	// TotalUsd is the total USD value of the fees with known prices.
+	TotalUsd float64
+
+	// IsPartial signals some collected tokens could not be priced,
+	// the total USD value does not cover them.
+	IsPartial bool
+}
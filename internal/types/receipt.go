@@ -0,0 +1,74 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// TransactionReceipt represents the receipt of a processed transaction.
+type TransactionReceipt struct {
+	// TransactionHash is the hash of the transaction.
+	TransactionHash common.Hash `json:"transactionHash"`
+
+	// TransactionIndex is the index of the transaction in the block.
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+
+	// BlockHash is the hash of the block containing the transaction.
+	BlockHash common.Hash `json:"blockHash"`
+
+	// BlockNumber is the number of the block containing the transaction.
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+
+	// From is the address of the sender.
+	From common.Address `json:"from"`
+
+	// To is the address of the recipient; nil for a contract creation.
+	To *common.Address `json:"to"`
+
+	// Status is 1 for a successful transaction, 0 for a failed one.
+	Status hexutil.Uint64 `json:"status"`
+
+	// GasUsed is the amount of gas used by the transaction.
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+
+	// CumulativeGasUsed is the total amount of gas used in the block up to and including the transaction.
+	CumulativeGasUsed hexutil.Uint64 `json:"cumulativeGasUsed"`
+
+	// EffectiveGasPrice is the gas price actually paid per unit of gas.
+	EffectiveGasPrice *hexutil.Big `json:"effectiveGasPrice"`
+
+	// ContractAddress is the address of the created contract; nil if no contract was created.
+	ContractAddress *common.Address `json:"contractAddress"`
+
+	// Logs is the list of log records emitted by the transaction.
+	Logs []retypes.Log `json:"logs"`
+}
+
+// DecodedEvent represents a log record decoded by the ABI of the emitting contract.
+type DecodedEvent struct {
+	// Name is the name of the event.
+	Name string
+
+	// Signature is the canonical signature of the event, i.e. "Transfer(address,address,uint256)".
+	Signature string
+
+	// Fields is the list of the decoded event arguments in the ABI order.
+	Fields []DecodedEventField
+}
+
+// DecodedEventField represents a decoded argument of an event.
+type DecodedEventField struct {
+	// Name is the name of the argument.
+	Name string
+
+	// Type is the ABI type of the argument.
+	Type string
+
+	// Value is the string representation of the argument value.
+	Value string
+
+	// Indexed signals the argument is stored in the log topics.
+	Indexed bool
+}
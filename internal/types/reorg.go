@@ -0,0 +1,96 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+// Reorg represents a chain reorganization detected by the block scanner.
+type Reorg struct {
+	// Id identifies the reorg; it's the detection time in nanoseconds.
+	Id hexutil.Uint64
+
+	// Depth is the number of indexed blocks replaced by the reorg.
+	Depth hexutil.Uint64
+
+	// FromBlock is the number of the lowest replaced block.
+	FromBlock hexutil.Uint64
+
+	// ToBlock is the number of the highest replaced block.
+	ToBlock hexutil.Uint64
+
+	// OldHead is the hash of the replaced block on the highest replaced height.
+	OldHead common.Hash
+
+	// NewHead is the hash of the canonical block on the highest replaced height.
+	NewHead common.Hash
+
+	// Detected is the time the reorg was detected.
+	Detected time.Time
+}
+
+// BsonReorg represents the reorg data structure for BSON formatting.
+type BsonReorg struct {
+	ID       int64     `bson:"_id"`
+	Depth    int64     `bson:"depth"`
+	From     int64     `bson:"from"`
+	To       int64     `bson:"to"`
+	OldHead  string    `bson:"old"`
+	NewHead  string    `bson:"new"`
+	Detected time.Time `bson:"ts"`
+}
+
+// MarshalBSON creates a BSON representation of the reorg record.
+func (r *Reorg) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonReorg{
+		ID:       int64(r.Id),
+		Depth:    int64(r.Depth),
+		From:     int64(r.FromBlock),
+		To:       int64(r.ToBlock),
+		OldHead:  r.OldHead.String(),
+		NewHead:  r.NewHead.String(),
+		Detected: r.Detected,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (r *Reorg) UnmarshalBSON(data []byte) (err error) {
+	var row BsonReorg
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	r.Id = hexutil.Uint64(row.ID)
+	r.Depth = hexutil.Uint64(row.Depth)
+	r.FromBlock = hexutil.Uint64(row.From)
+	r.ToBlock = hexutil.Uint64(row.To)
+	r.OldHead = common.HexToHash(row.OldHead)
+	r.NewHead = common.HexToHash(row.NewHead)
+	r.Detected = row.Detected
+	return nil
+}
+
+// ReorgList represents a list of chain reorganizations.
+type ReorgList struct {
+	// Collection keeps the actual list of reorgs.
+	Collection []*Reorg
+
+	// Total indicates total number of reorgs in the whole collection.
+	Total uint64
+
+	// IsStart indicates there are no newer reorgs available above the list.
+	IsStart bool
+
+	// IsEnd indicates there are no older reorgs available below the list.
+	IsEnd bool
+}
+
+// Reverse reverses the order of reorgs in the list.
+func (rl *ReorgList) Reverse() {
+	for i, j := 0, len(rl.Collection)-1; i < j; i, j = i+1, j-1 {
+		rl.Collection[i], rl.Collection[j] = rl.Collection[j], rl.Collection[i]
+	}
+}
@@ -0,0 +1,38 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// RewardSourcesMaxWindow represents the longest window of reward sources aggregation.
+const RewardSourcesMaxWindow = 365 * 24 * time.Hour
+
+// RewardSource represents rewards claimed by a delegator from a single validator.
+type RewardSource struct {
+	// ValidatorId is the ID of the validator the rewards were earned on.
+	ValidatorId hexutil.Big
+
+	// Amount is the total amount of rewards claimed.
+	Amount hexutil.Big
+
+	// Claims is the number of reward claims.
+	Claims int32
+
+	// Share is the percentage of all the rewards claimed in the window.
+	Share float64
+}
+
+// RewardSources represents rewards claimed by a delegator over a time window
+// grouped by the validator they were earned on.
+type RewardSources struct {
+	// Since is the start of the aggregation window.
+	Since time.Time
+
+	// Total is the total amount of rewards claimed in the window.
+	Total hexutil.Big
+
+	// Sources is the list of validators the rewards were earned on.
+	Sources []RewardSource
+}
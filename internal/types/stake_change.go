@@ -0,0 +1,159 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/binary"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	FiStakeChangePk        = "_id"
+	FiStakeChangeDelegator = "dlg"
+	FiStakeChangeValidator = "val"
+)
+
+// types of stake changes
+const (
+	StakeChangeDelegated   = "DELEGATED"
+	StakeChangeUndelegated = "UNDELEGATED"
+	StakeChangeWithdrawn   = "WITHDRAWN"
+	StakeChangeRestaked    = "RESTAKED"
+	StakeChangeLocked      = "LOCKED"
+	StakeChangeUnlocked    = "UNLOCKED"
+)
+
+// StakeChange represents a single change of a delegator stake in the SFC contract.
+type StakeChange struct {
+	// Type is the type of the stake change.
+	Type string
+
+	// Delegator is the address of the delegator.
+	Delegator common.Address
+
+	// ValidatorId is the id of the validator receiving the delegation.
+	ValidatorId hexutil.Big
+
+	// Amount is the amount of tokens affected by the change.
+	Amount hexutil.Big
+
+	// Penalty is the amount of tokens slashed by an early unlock.
+	Penalty hexutil.Big
+
+	// Duration is the lock duration in seconds of a lock change.
+	Duration hexutil.Uint64
+
+	// Transaction is the hash of the transaction emitting the change.
+	Transaction common.Hash
+
+	// BlockNumber is the number of the block containing the change.
+	BlockNumber hexutil.Uint64
+
+	// LogIndex is the index of the change event in the block.
+	LogIndex hexutil.Uint64
+
+	// TimeStamp is the time stamp of the block containing the change.
+	TimeStamp hexutil.Uint64
+}
+
+// BsonStakeChange represents the stake change data structure for BSON formatting.
+type BsonStakeChange struct {
+	ID        string `bson:"_id"`
+	Type      string `bson:"typ"`
+	Delegator string `bson:"dlg"`
+	Validator string `bson:"val"`
+	Amount    string `bson:"amo"`
+	Penalty   string `bson:"pen"`
+	Duration  uint64 `bson:"dur"`
+	Trx       string `bson:"trx"`
+	Block     uint64 `bson:"blk"`
+	LogIndex  uint64 `bson:"lix"`
+	TimeStamp uint64 `bson:"ts"`
+}
+
+// StakeChangePk generates unique identifier of a stake change emitted
+// on the given position in the chain. The identifier sorts changes in the chain order.
+func StakeChangePk(block uint64, logIndex uint64) string {
+	bytes := make([]byte, 12)
+	binary.BigEndian.PutUint64(bytes[0:8], block)
+	binary.BigEndian.PutUint32(bytes[8:12], uint32(logIndex))
+	return hexutil.Encode(bytes)
+}
+
+// Pk provides the unique identifier of the stake change.
+func (sc *StakeChange) Pk() string {
+	return StakeChangePk(uint64(sc.BlockNumber), uint64(sc.LogIndex))
+}
+
+// MarshalBSON creates a BSON representation of the stake change record.
+func (sc *StakeChange) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonStakeChange{
+		ID:        sc.Pk(),
+		Type:      sc.Type,
+		Delegator: sc.Delegator.String(),
+		Validator: sc.ValidatorId.String(),
+		Amount:    sc.Amount.String(),
+		Penalty:   sc.Penalty.String(),
+		Duration:  uint64(sc.Duration),
+		Trx:       sc.Transaction.String(),
+		Block:     uint64(sc.BlockNumber),
+		LogIndex:  uint64(sc.LogIndex),
+		TimeStamp: uint64(sc.TimeStamp),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (sc *StakeChange) UnmarshalBSON(data []byte) (err error) {
+	var row BsonStakeChange
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	val, err := hexutil.DecodeBig(row.Validator)
+	if err != nil {
+		return err
+	}
+	amo, err := hexutil.DecodeBig(row.Amount)
+	if err != nil {
+		return err
+	}
+	pen, err := hexutil.DecodeBig(row.Penalty)
+	if err != nil {
+		return err
+	}
+
+	sc.Type = row.Type
+	sc.Delegator = common.HexToAddress(row.Delegator)
+	sc.ValidatorId = hexutil.Big(*val)
+	sc.Amount = hexutil.Big(*amo)
+	sc.Penalty = hexutil.Big(*pen)
+	sc.Duration = hexutil.Uint64(row.Duration)
+	sc.Transaction = common.HexToHash(row.Trx)
+	sc.BlockNumber = hexutil.Uint64(row.Block)
+	sc.LogIndex = hexutil.Uint64(row.LogIndex)
+	sc.TimeStamp = hexutil.Uint64(row.TimeStamp)
+	return nil
+}
+
+// StakeChangeList represents a list of stake changes.
+type StakeChangeList struct {
+	// Collection keeps the actual list of changes.
+	Collection []*StakeChange
+
+	// Total indicates total number of changes matching the list filter.
+	Total uint64
+
+	// IsStart indicates there are no newer changes available above the list.
+	IsStart bool
+
+	// IsEnd indicates there are no older changes available below the list.
+	IsEnd bool
+}
+
+// Reverse reverses the order of changes in the list.
+func (sl *StakeChangeList) Reverse() {
+	for i, j := 0, len(sl.Collection)-1; i < j; i, j = i+1, j-1 {
+		sl.Collection[i], sl.Collection[j] = sl.Collection[j], sl.Collection[i]
+	}
+}
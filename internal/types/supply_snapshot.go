@@ -0,0 +1,78 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiSupplySnapshotPk    = "_id"
+	FiSupplySnapshotToken = "tok"
+	FiSupplySnapshotBlock = "blk"
+)
+
+// SupplyHistoryMaxCount is the max number of supply snapshots loaded at once.
+const SupplyHistoryMaxCount = 1000
+
+// SupplySnapshot represents the total supply of an ERC20 token at a block.
+type SupplySnapshot struct {
+	// Token is the address of the ERC20 token.
+	Token common.Address
+
+	// BlockNumber is the number of the block the supply was observed at.
+	BlockNumber hexutil.Uint64
+
+	// TotalSupply is the total supply of the token at the block.
+	TotalSupply hexutil.Big
+
+	// Time is the time stamp of the block.
+	Time time.Time
+}
+
+// BsonSupplySnapshot represents the supply snapshot data structure for BSON formatting.
+type BsonSupplySnapshot struct {
+	ID     string    `bson:"_id"`
+	Token  string    `bson:"tok"`
+	Block  uint64    `bson:"blk"`
+	Supply string    `bson:"supply"`
+	Time   time.Time `bson:"ts"`
+}
+
+// SupplySnapshotPk generates unique identifier of the supply snapshot of the token at the block.
+func SupplySnapshotPk(token *common.Address, blk uint64) string {
+	return fmt.Sprintf("%s%016x", token.String(), blk)
+}
+
+// MarshalBSON creates a BSON representation of the supply snapshot.
+func (ss *SupplySnapshot) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonSupplySnapshot{
+		ID:     SupplySnapshotPk(&ss.Token, uint64(ss.BlockNumber)),
+		Token:  ss.Token.String(),
+		Block:  uint64(ss.BlockNumber),
+		Supply: ss.TotalSupply.String(),
+		Time:   ss.Time,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ss *SupplySnapshot) UnmarshalBSON(data []byte) (err error) {
+	var row BsonSupplySnapshot
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	supply, err := hexutil.DecodeBig(row.Supply)
+	if err != nil {
+		return err
+	}
+
+	ss.Token = common.HexToAddress(row.Token)
+	ss.BlockNumber = hexutil.Uint64(row.Block)
+	ss.TotalSupply = hexutil.Big(*supply)
+	ss.Time = row.Time
+	return nil
+}
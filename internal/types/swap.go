@@ -51,6 +51,10 @@ type Swap struct {
 
 	// Reserve1 is a total reserve in time of this event for Token B
 	Reserve1 *big.Int `json:"reserve1" bson:"reserve1"`
+
+	// Suspicious signals an amount of the swap exceeds the sanity bound;
+	// the swap is kept on record, but left out of volume and price aggregations.
+	Suspicious bool `json:"suspicious" bson:"sus"`
 }
 
 // Marshal returns the JSON encoding of swap.
@@ -0,0 +1,29 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+const (
+	FiTokenActivityToken  = "tok"
+	FiTokenActivityBucket = "bucket"
+	FiTokenActivityCount  = "cnt"
+
+	// TokenActivityBucket represents the time span of a single token activity counter.
+	// Token transactions are counted by the time of their block into buckets
+	// of this size, so trailing windows are rounded to whole buckets.
+	TokenActivityBucket = time.Hour
+
+	// TokenActivityMaxWindow represents the longest trailing window of token activity
+	// available; older buckets are removed from the database.
+	TokenActivityMaxWindow = 30 * 24 * time.Hour
+)
+
+// TokenActivity represents the number of transactions of a token over a time window.
+type TokenActivity struct {
+	Token     common.Address `bson:"-"`
+	TokenType string         `bson:"tty"`
+	Count     int64          `bson:"cnt"`
+}
@@ -0,0 +1,22 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TokenPrice represents the current oracle price of a token.
+type TokenPrice struct {
+	// Token is the address of the token.
+	Token common.Address
+
+	// Price is the raw oracle price of the token.
+	Price hexutil.Big
+
+	// PriceDecimals is the number of decimals of the price.
+	PriceDecimals int32
+
+	// Value is the price corrected for the price decimals.
+	Value float64
+}
@@ -0,0 +1,20 @@
+// Package types implements different core types of the API.
+package types
+
+// TrxEta represents a best-effort estimate of the time to confirmation
+// of a pending transaction. The estimate is a heuristic based on the gas price
+// of the transaction and the recent block interval of the chain.
+type TrxEta struct {
+	// Blocks is the estimated number of blocks until the transaction is confirmed.
+	Blocks int32
+
+	// Seconds is the estimated number of seconds until the transaction is confirmed.
+	Seconds float64
+
+	// GasPriceRatio is the ratio of the transaction gas price to the suggested gas price.
+	GasPriceRatio float64
+
+	// IsUnderpriced signals the gas price is too low to estimate the confirmation;
+	// the transaction may never be confirmed.
+	IsUnderpriced bool
+}
@@ -0,0 +1,31 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// transaction status types
+const (
+	TrxStatusPending = "PENDING"
+	TrxStatusSuccess = "SUCCESS"
+	TrxStatusFailed  = "FAILED"
+	TrxStatusUnknown = "UNKNOWN"
+)
+
+// TrxStatus represents the processing status of a transaction.
+type TrxStatus struct {
+	// Hash is the hash of the transaction.
+	Hash common.Hash
+
+	// Status is the status of the transaction, see TrxStatusPending, TrxStatusSuccess,
+	// TrxStatusFailed and TrxStatusUnknown.
+	Status string
+
+	// BlockNumber is the number of the block containing the transaction; nil if not processed.
+	BlockNumber *hexutil.Uint64
+
+	// Confirmations is the number of blocks on top of the block containing the transaction.
+	Confirmations hexutil.Uint64
+}
@@ -0,0 +1,37 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UniswapQuote represents a slippage adjusted quote of a swap along a path of tokens.
+type UniswapQuote struct {
+	// Path is the list of tokens the swap goes through.
+	Path []common.Address
+
+	// AmountIn is the amount of the first token of the path sent in.
+	AmountIn hexutil.Big
+
+	// AmountOut is the expected amount of the last token of the path received.
+	AmountOut hexutil.Big
+
+	// AmountOutMin is the minimal amount of the last token received
+	// with the slippage tolerance applied.
+	AmountOutMin hexutil.Big
+
+	// Amounts is the list of expected amounts on each step of the path.
+	Amounts []hexutil.Big
+
+	// SlippageBps is the applied slippage tolerance in basis points.
+	SlippageBps int32
+
+	// PriceImpact is the relative loss of the output caused by moving
+	// the pools prices, e.g. 0.01 represents 1% impact.
+	PriceImpact float64
+
+	// ExecutionPrice is the number of output tokens received per one input token,
+	// adjusted by decimals of both tokens.
+	ExecutionPrice float64
+}
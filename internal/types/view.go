@@ -0,0 +1,43 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+const (
+	// ViewTrxFlow is the name of the transaction flow statistics view.
+	ViewTrxFlow = "trx_flow"
+
+	// ViewTrxCount is the name of the total transactions count estimate view.
+	ViewTrxCount = "trx_count"
+
+	// ViewPrices is the name of the DeFi token price snapshots view.
+	ViewPrices = "prices"
+)
+
+// MaterializedView represents the refresh state of a periodically refreshed view.
+type MaterializedView struct {
+	// Name is the name of the view.
+	Name string
+
+	// Interval is the time between two scheduled refreshes of the view.
+	Interval time.Duration
+
+	// LastRefresh is the time of the last finished refresh; nil if not refreshed yet.
+	LastRefresh *time.Time
+}
+
+// Staleness provides the time elapsed since the last refresh of the view.
+func (mv *MaterializedView) Staleness(now time.Time) *time.Duration {
+	if mv.LastRefresh == nil {
+		return nil
+	}
+	st := now.Sub(*mv.LastRefresh)
+	return &st
+}
+
+// IsStale checks if the view missed its scheduled refresh, e.g. it was not refreshed
+// within two intervals. A view not refreshed yet is stale.
+func (mv *MaterializedView) IsStale(now time.Time) bool {
+	st := mv.Staleness(now)
+	return st == nil || *st > 2*mv.Interval
+}